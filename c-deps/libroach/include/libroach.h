@@ -308,11 +308,25 @@ DBStatus MVCCFindSplitKey(DBIterator* iter, DBKey start, DBKey end, DBKey min_sp
 // TODO(peter): We could investigate using
 // https://github.com/petermattis/cppgo to generate C++ code that can
 // read the Go roachpb.Transaction structure.
+// DBIgnoredSeqNumRange is an inclusive range of sequence numbers of a
+// transaction whose writes were rolled back by rolling back to a savepoint.
+typedef struct {
+  int32_t start_seqnum;
+  int32_t end_seqnum;
+} DBIgnoredSeqNumRange;
+
+typedef struct {
+  DBIgnoredSeqNumRange* ranges;
+  // len is the number of DBIgnoredSeqNumRanges in ranges.
+  int len;
+} DBIgnoredSeqNums;
+
 typedef struct {
   DBSlice id;
   uint32_t epoch;
   int32_t sequence;
   DBTimestamp max_timestamp;
+  DBIgnoredSeqNums ignored_seqnums;
 } DBTxn;
 
 typedef struct {
@@ -61,6 +61,7 @@ template <bool reverse> class mvccScanner {
         txn_epoch_(txn.epoch),
         txn_sequence_(txn.sequence),
         txn_max_timestamp_(txn.max_timestamp),
+        txn_ignored_seqnums_(txn.ignored_seqnums),
         inconsistent_(inconsistent),
         tombstones_(tombstones),
         ignore_sequence_(ignore_sequence),
@@ -166,24 +167,42 @@ template <bool reverse> class mvccScanner {
     return results_;
   }
 
+  // seqNumIsIgnored returns true if the given sequence number of our
+  // transaction was rolled back by rolling back to a savepoint.
+  bool seqNumIsIgnored(int32_t sequence) const {
+    // The ignored ranges are sorted and do not overlap. Find the first range
+    // whose end is at or above the sequence number and check whether the
+    // sequence number falls within it.
+    const DBIgnoredSeqNumRange* begin = txn_ignored_seqnums_.ranges;
+    const DBIgnoredSeqNumRange* end = begin + txn_ignored_seqnums_.len;
+    auto it = std::lower_bound(begin, end, sequence,
+                               [](const DBIgnoredSeqNumRange& r, int32_t seq) -> bool {
+                                 return r.end_seqnum < seq;
+                               });
+    return it != end && it->start_seqnum <= sequence;
+  }
+
   bool getFromIntentHistory() {
     cockroach::storage::engine::enginepb::MVCCMetadata_SequencedIntent readIntent;
-    readIntent.set_sequence(txn_sequence_);
+    readIntent.set_sequence(ignore_sequence_ ? INT32_MAX : txn_sequence_);
     // Look for the intent with the sequence number less than or equal to the
     // read sequence. To do so, search using upper_bound, which returns an
     // iterator pointing to the first element in the range [first, last) that is
     // greater than value, or last if no such element is found. Then, return the
-    // previous value.
+    // previous value that was not rolled back.
     auto up = std::upper_bound(
         meta_.intent_history().begin(), meta_.intent_history().end(), readIntent,
         [](const cockroach::storage::engine::enginepb::MVCCMetadata_SequencedIntent& a,
            const cockroach::storage::engine::enginepb::MVCCMetadata_SequencedIntent& b) -> bool {
           return a.sequence() < b.sequence();
         });
+    while (up != meta_.intent_history().begin() && seqNumIsIgnored((up - 1)->sequence())) {
+      --up;
+    }
     if (up == meta_.intent_history().begin()) {
       // It is possible that no intent exists such that the sequence is less
-      // than the read sequence. In this case, we cannot read a value from the
-      // intent history.
+      // than the read sequence and the sequence was not rolled back. In this
+      // case, we cannot read a value from the intent history.
       return false;
     }
     const auto intent = *(up - 1);
@@ -293,7 +312,8 @@ template <bool reverse> class mvccScanner {
     }
 
     if (txn_epoch_ == meta_.txn().epoch()) {
-      if ((ignore_sequence_) || (txn_sequence_ >= meta_.txn().sequence())) {
+      if (((ignore_sequence_) || (txn_sequence_ >= meta_.txn().sequence())) &&
+          !seqNumIsIgnored(meta_.txn().sequence())) {
         // 8. We're reading our own txn's intent at an equal or higher sequence.
         // Note that we read at the intent timestamp, not at our read timestamp
         // as the intent timestamp may have been pushed forward by another
@@ -301,10 +321,11 @@ template <bool reverse> class mvccScanner {
         return seekVersion(meta_timestamp, false);
       } else {
         // 9. We're reading our own txn's intent at a lower sequence than is
-        // currently present in the intent. This means the intent we're seeing
-        // was written at a higher sequence than the read and that there may or
-        // may not be earlier versions of the intent (with lower sequence
-        // numbers) that we should read. If there exists a value in the intent
+        // currently present in the intent, or the intent was written at a
+        // sequence number that has been rolled back. This means the intent we're
+        // seeing should not be read and that there may or may not be earlier
+        // versions of the intent (with lower sequence numbers) that we should
+        // read. If there exists a value in the intent
         // history that has a sequence number equal to or less than the read
         // sequence, read that value.
         const bool found = getFromIntentHistory();
@@ -646,6 +667,7 @@ template <bool reverse> class mvccScanner {
   const uint32_t txn_epoch_;
   const int32_t txn_sequence_;
   const DBTimestamp txn_max_timestamp_;
+  const DBIgnoredSeqNums txn_ignored_seqnums_;
   const bool inconsistent_;
   const bool tombstones_;
   const bool ignore_sequence_;
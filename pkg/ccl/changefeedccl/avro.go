@@ -125,7 +125,7 @@ type avroMetadata map[string]interface{}
 // avroEnvelopeOpts controls which fields in avroEnvelopeRecord are set.
 type avroEnvelopeOpts struct {
 	updatedField, resolvedField bool
-	afterField                  bool
+	beforeField, afterField     bool
 }
 
 // avroEnvelopeRecord is an `avroRecord` that wraps a changed SQL row and some
@@ -133,8 +133,8 @@ type avroEnvelopeOpts struct {
 type avroEnvelopeRecord struct {
 	avroRecord
 
-	opts  avroEnvelopeOpts
-	after *avroDataRecord
+	opts          avroEnvelopeOpts
+	before, after *avroDataRecord
 }
 
 // columnDescToAvroSchema converts a column descriptor into its corresponding
@@ -222,6 +222,16 @@ func columnDescToAvroSchema(colDesc *sqlbase.ColumnDescriptor) (*avroSchemaField
 			micros := x.(time.Duration) / time.Microsecond
 			return tree.MakeDTime(timeofday.TimeOfDay(micros)), nil
 		}
+	case types.TimeTZFamily:
+		// Avro has no logical type for a time of day with a time zone, so the
+		// value is sent in its string form.
+		avroType = avroSchemaString
+		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
+			return d.(*tree.DTimeTZ).TimeTZ.String(), nil
+		}
+		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
+			return tree.ParseDTimeTZ(nil /* ctx */, x.(string))
+		}
 	case types.TimestampFamily:
 		avroType = avroLogicalType{
 			SchemaType:  avroSchemaLong,
@@ -375,12 +385,23 @@ func indexToAvroSchema(
 	return schema, nil
 }
 
+const avroSchemaNoSuffix = ``
+
 // tableToAvroSchema converts a column descriptor into its corresponding avro
 // record schema. The fields are kept in the same order as `tableDesc.Columns`.
-func tableToAvroSchema(tableDesc *sqlbase.TableDescriptor) (*avroDataRecord, error) {
+// If a name suffix is provided (as opposed to avroSchemaNoSuffix), it will be
+// appended to the end of the avro record's name, which is needed when the same
+// table is embedded more than once in a single schema.
+func tableToAvroSchema(
+	tableDesc *sqlbase.TableDescriptor, nameSuffix string,
+) (*avroDataRecord, error) {
+	name := SQLNameToAvroName(tableDesc.Name)
+	if nameSuffix != avroSchemaNoSuffix {
+		name = name + `_` + nameSuffix
+	}
 	schema := &avroDataRecord{
 		avroRecord: avroRecord{
-			Name:       SQLNameToAvroName(tableDesc.Name),
+			Name:       name,
 			SchemaType: `record`,
 		},
 		fieldIdxByName:   make(map[string]int),
@@ -489,7 +510,7 @@ func (r *avroDataRecord) rowFromNative(native interface{}) (sqlbase.EncDatumRow,
 // envelopeToAvroSchema creates an avro record schema for an envelope containing
 // before and after versions of a row change and metadata about that row change.
 func envelopeToAvroSchema(
-	topic string, opts avroEnvelopeOpts, after *avroDataRecord,
+	topic string, opts avroEnvelopeOpts, before, after *avroDataRecord,
 ) (*avroEnvelopeRecord, error) {
 	schema := &avroEnvelopeRecord{
 		avroRecord: avroRecord{
@@ -515,6 +536,15 @@ func envelopeToAvroSchema(
 		}
 		schema.Fields = append(schema.Fields, resolvedField)
 	}
+	if opts.beforeField {
+		schema.before = before
+		beforeField := &avroSchemaField{
+			Name:       `before`,
+			SchemaType: []avroSchemaType{avroSchemaNull, before},
+			Default:    nil,
+		}
+		schema.Fields = append(schema.Fields, beforeField)
+	}
 	if opts.afterField {
 		schema.after = after
 		afterField := &avroSchemaField{
@@ -537,13 +567,11 @@ func envelopeToAvroSchema(
 }
 
 // BinaryFromRow encodes the given metadata and row data into avro's defined
-// binary format.
+// binary format. A nil `beforeRow` or `afterRow` is encoded as a null.
 func (r *avroEnvelopeRecord) BinaryFromRow(
-	buf []byte, meta avroMetadata, row sqlbase.EncDatumRow,
+	buf []byte, meta avroMetadata, beforeRow, afterRow sqlbase.EncDatumRow,
 ) ([]byte, error) {
-	native := map[string]interface{}{
-		`after`: nil,
-	}
+	native := map[string]interface{}{}
 	if r.opts.updatedField {
 		native[`updated`] = nil
 		if u, ok := meta[`updated`]; ok {
@@ -567,11 +595,22 @@ func (r *avroEnvelopeRecord) BinaryFromRow(
 		}
 	}
 	// WIP verify that meta is now empty
+	if r.opts.beforeField {
+		if beforeRow == nil {
+			native[`before`] = nil
+		} else {
+			beforeNative, err := r.before.nativeFromRow(beforeRow)
+			if err != nil {
+				return nil, err
+			}
+			native[`before`] = goavro.Union(avroUnionKey(&r.before.avroRecord), beforeNative)
+		}
+	}
 	if r.opts.afterField {
-		if row == nil {
+		if afterRow == nil {
 			native[`after`] = nil
 		} else {
-			afterNative, err := r.after.nativeFromRow(row)
+			afterNative, err := r.after.nativeFromRow(afterRow)
 			if err != nil {
 				return nil, err
 			}
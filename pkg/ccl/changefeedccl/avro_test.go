@@ -116,7 +116,7 @@ func parseAvroSchema(j string) (*avroDataRecord, error) {
 		}
 		tableDesc.Columns = append(tableDesc.Columns, *colDesc)
 	}
-	return tableToAvroSchema(tableDesc)
+	return tableToAvroSchema(tableDesc, avroSchemaNoSuffix)
 }
 
 func avroFieldMetadataToColDesc(metadata string) (*sqlbase.ColumnDescriptor, error) {
@@ -234,7 +234,7 @@ func TestAvroSchema(t *testing.T) {
 			tableDesc, err := parseTableDesc(
 				fmt.Sprintf(`CREATE TABLE "%s" %s`, test.name, test.schema))
 			require.NoError(t, err)
-			origSchema, err := tableToAvroSchema(tableDesc)
+			origSchema, err := tableToAvroSchema(tableDesc, avroSchemaNoSuffix)
 			require.NoError(t, err)
 			jsonSchema := origSchema.codec.Schema()
 			roundtrippedSchema, err := parseAvroSchema(jsonSchema)
@@ -268,7 +268,7 @@ func TestAvroSchema(t *testing.T) {
 	t.Run("escaping", func(t *testing.T) {
 		tableDesc, err := parseTableDesc(`CREATE TABLE "☃" (🍦 INT PRIMARY KEY)`)
 		require.NoError(t, err)
-		tableSchema, err := tableToAvroSchema(tableDesc)
+		tableSchema, err := tableToAvroSchema(tableDesc, avroSchemaNoSuffix)
 		require.NoError(t, err)
 		require.Equal(t,
 			`{"type":"record","name":"_u2603_","fields":[`+
@@ -297,6 +297,7 @@ func TestAvroSchema(t *testing.T) {
 			`JSONB`:        `["null","string"]`,
 			`STRING`:       `["null","string"]`,
 			`TIME`:         `["null",{"type":"long","logicalType":"time-micros"}]`,
+			`TIMETZ`:       `["null","string"]`,
 			`TIMESTAMP`:    `["null",{"type":"long","logicalType":"timestamp-micros"}]`,
 			`TIMESTAMPTZ`:  `["null",{"type":"long","logicalType":"timestamp-micros"}]`,
 			`UUID`:         `["null","string"]`,
@@ -372,6 +373,11 @@ func TestAvroSchema(t *testing.T) {
 				sql:  `'03:04:05'`,
 				avro: `{"long.time-micros":11045000000}`},
 
+			{sqlType: `TIMETZ`, sql: `NULL`, avro: `null`},
+			{sqlType: `TIMETZ`,
+				sql:  `'03:04:05-08'`,
+				avro: `{"string":"03:04:05-08"}`},
+
 			{sqlType: `TIMESTAMP`, sql: `NULL`, avro: `null`},
 			{sqlType: `TIMESTAMP`,
 				sql:  `'2019-01-02 03:04:05'`,
@@ -425,7 +431,7 @@ func TestAvroSchema(t *testing.T) {
 			rows, err := parseValues(tableDesc, `VALUES (1, `+test.sql+`)`)
 			require.NoError(t, err)
 
-			schema, err := tableToAvroSchema(tableDesc)
+			schema, err := tableToAvroSchema(tableDesc, avroSchemaNoSuffix)
 			require.NoError(t, err)
 			textual, err := schema.textualFromRow(rows[0])
 			require.NoError(t, err)
@@ -527,12 +533,12 @@ func TestAvroMigration(t *testing.T) {
 			writerDesc, err := parseTableDesc(
 				fmt.Sprintf(`CREATE TABLE "%s" %s`, test.name, test.writerSchema))
 			require.NoError(t, err)
-			writerSchema, err := tableToAvroSchema(writerDesc)
+			writerSchema, err := tableToAvroSchema(writerDesc, avroSchemaNoSuffix)
 			require.NoError(t, err)
 			readerDesc, err := parseTableDesc(
 				fmt.Sprintf(`CREATE TABLE "%s" %s`, test.name, test.readerSchema))
 			require.NoError(t, err)
-			readerSchema, err := tableToAvroSchema(readerDesc)
+			readerSchema, err := tableToAvroSchema(readerDesc, avroSchemaNoSuffix)
 			require.NoError(t, err)
 
 			writerRows, err := parseValues(writerDesc, `VALUES `+test.writerValues)
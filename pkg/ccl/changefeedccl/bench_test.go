@@ -128,6 +128,9 @@ func (s *benchSink) EmitResolvedTimestamp(_ context.Context, e Encoder, ts hlc.T
 	}
 	return s.emit(int64(len(p)))
 }
+func (s *benchSink) EmitSchemaChange(context.Context, *sqlbase.TableDescriptor) error {
+	return nil
+}
 func (s *benchSink) Flush(_ context.Context) error { return nil }
 func (s *benchSink) Close() error                  { return nil }
 func (s *benchSink) emit(bytes int64) error {
@@ -224,7 +227,7 @@ func createBenchmarkChangefeed(
 			sf := makeSpanFrontier(spans...)
 			for {
 				// This is basically the ChangeAggregator processor.
-				resolvedSpans, err := tickFn(ctx)
+				resolvedSpans, _, err := tickFn(ctx)
 				if err != nil {
 					return err
 				}
@@ -26,8 +26,14 @@ import (
 )
 
 type bufferEntry struct {
-	kv       roachpb.KeyValue
+	kv roachpb.KeyValue
+	// prevVal is the value of kv.Key before this change. It is only set if
+	// the changefeed was created with the diff option and the key existed.
+	prevVal  roachpb.Value
 	resolved *jobspb.ResolvedSpan
+	// schemaChange, if non-nil, is a new version of a watched table's
+	// descriptor. It is ordered before every kv that must be read with it.
+	schemaChange *sqlbase.TableDescriptor
 	// Timestamp of the schema that should be used to read this KV.
 	// If unset (zero-valued), the value's timestamp will be used instead.
 	schemaTimestamp hlc.Timestamp
@@ -45,12 +51,12 @@ func makeBuffer() *buffer {
 	return &buffer{entriesCh: make(chan bufferEntry)}
 }
 
-// AddKV inserts a changed kv into the buffer. Individual keys must be added in
-// increasing mvcc order.
+// AddKV inserts a changed kv and its previous value, if known, into the
+// buffer. Individual keys must be added in increasing mvcc order.
 func (b *buffer) AddKV(
-	ctx context.Context, kv roachpb.KeyValue, schemaTimestamp hlc.Timestamp,
+	ctx context.Context, kv roachpb.KeyValue, prevVal roachpb.Value, schemaTimestamp hlc.Timestamp,
 ) error {
-	return b.addEntry(ctx, bufferEntry{kv: kv, schemaTimestamp: schemaTimestamp})
+	return b.addEntry(ctx, bufferEntry{kv: kv, prevVal: prevVal, schemaTimestamp: schemaTimestamp})
 }
 
 // AddResolved inserts a resolved timestamp notification in the buffer.
@@ -58,6 +64,12 @@ func (b *buffer) AddResolved(ctx context.Context, span roachpb.Span, ts hlc.Time
 	return b.addEntry(ctx, bufferEntry{resolved: &jobspb.ResolvedSpan{Span: span, Timestamp: ts}})
 }
 
+// AddSchemaChange inserts a new version of a watched table's descriptor into
+// the buffer.
+func (b *buffer) AddSchemaChange(ctx context.Context, desc *sqlbase.TableDescriptor) error {
+	return b.addEntry(ctx, bufferEntry{schemaChange: desc})
+}
+
 func (b *buffer) addEntry(ctx context.Context, e bufferEntry) error {
 	select {
 	case <-ctx.Done():
@@ -96,6 +108,7 @@ var memBufferColTypes = []types.T{
 	*types.Int,   // ts.Logical
 	*types.Int,   // schemaTimestamp.WallTime
 	*types.Int,   // schemaTimestamp.Logical
+	*types.Bytes, // prevVal
 }
 
 // memBuffer is an in-memory buffer for changed KV and resolved timestamp
@@ -133,12 +146,16 @@ func (b *memBuffer) Close(ctx context.Context) {
 	b.mu.Unlock()
 }
 
-// AddKV inserts a changed kv into the buffer. Individual keys must be added in
-// increasing mvcc order.
+// AddKV inserts a changed kv and its previous value, if known, into the
+// buffer. Individual keys must be added in increasing mvcc order.
 func (b *memBuffer) AddKV(
-	ctx context.Context, kv roachpb.KeyValue, schemaTimestamp hlc.Timestamp,
+	ctx context.Context, kv roachpb.KeyValue, prevVal roachpb.Value, schemaTimestamp hlc.Timestamp,
 ) error {
 	b.allocMu.Lock()
+	prevValDatum := tree.DNull
+	if prevVal.IsPresent() {
+		prevValDatum = b.allocMu.a.NewDBytes(tree.DBytes(prevVal.RawBytes))
+	}
 	row := tree.Datums{
 		b.allocMu.a.NewDBytes(tree.DBytes(kv.Key)),
 		b.allocMu.a.NewDBytes(tree.DBytes(kv.Value.RawBytes)),
@@ -148,6 +165,7 @@ func (b *memBuffer) AddKV(
 		b.allocMu.a.NewDInt(tree.DInt(kv.Value.Timestamp.Logical)),
 		b.allocMu.a.NewDInt(tree.DInt(schemaTimestamp.WallTime)),
 		b.allocMu.a.NewDInt(tree.DInt(schemaTimestamp.Logical)),
+		prevValDatum,
 	}
 	b.allocMu.Unlock()
 	return b.addRow(ctx, row)
@@ -165,6 +183,7 @@ func (b *memBuffer) AddResolved(ctx context.Context, span roachpb.Span, ts hlc.T
 		b.allocMu.a.NewDInt(tree.DInt(ts.Logical)),
 		tree.DNull,
 		tree.DNull,
+		tree.DNull,
 	}
 	b.allocMu.Unlock()
 	return b.addRow(ctx, row)
@@ -194,6 +213,9 @@ func (b *memBuffer) Get(ctx context.Context) (bufferEntry, error) {
 			WallTime: int64(*row[6].(*tree.DInt)),
 			Logical:  int32(*row[7].(*tree.DInt)),
 		}
+		if row[8] != tree.DNull {
+			e.prevVal = roachpb.Value{RawBytes: []byte(*row[8].(*tree.DBytes))}
+		}
 		return e, nil
 	}
 	e.resolved = &jobspb.ResolvedSpan{
@@ -0,0 +1,182 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package cdctest
+
+import (
+	"encoding/binary"
+	gojson "encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/linkedin/goavro"
+	"github.com/pkg/errors"
+)
+
+// confluentAvroWireFormatMagic is the first byte of every message written in
+// the confluent wire format.
+//
+// https://docs.confluent.io/current/schema-registry/docs/serializer-formatter.html#wire-format
+const confluentAvroWireFormatMagic = byte(0)
+
+// SchemaRegistry is an in-process stand-in for a Confluent schema registry,
+// which lets changefeeds using `format=experimental_avro` be tested without
+// any outside service. It supports registering schemas (`POST
+// /subjects/<subject>/versions`) and looking them up by id (`GET
+// /schemas/ids/<id>`). As in the real registry, registering an identical
+// schema twice returns the same id.
+type SchemaRegistry struct {
+	server *httptest.Server
+	mu     struct {
+		syncutil.Mutex
+		idAlloc  int32
+		schemas  map[int32]string
+		idSchema map[string]int32
+		subjects map[string][]int32
+	}
+}
+
+// MakeTestSchemaRegistry starts a SchemaRegistry listening on a local port.
+// It must be closed with Close.
+func MakeTestSchemaRegistry() *SchemaRegistry {
+	r := &SchemaRegistry{}
+	r.mu.schemas = make(map[int32]string)
+	r.mu.idSchema = make(map[string]int32)
+	r.mu.subjects = make(map[string][]int32)
+	r.server = httptest.NewServer(http.HandlerFunc(r.handle))
+	return r
+}
+
+// URL returns the url of the registry, for use as the value of the
+// `confluent_schema_registry` changefeed option.
+func (r *SchemaRegistry) URL() string {
+	return r.server.URL
+}
+
+// Close shuts down the registry.
+func (r *SchemaRegistry) Close() {
+	r.server.Close()
+}
+
+// Subjects returns the ids of every schema registered under the given subject,
+// in the order they were registered.
+func (r *SchemaRegistry) Subjects(subject string) []int32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int32(nil), r.mu.subjects[subject]...)
+}
+
+type confluentSchema struct {
+	Schema string `json:"schema"`
+}
+
+type confluentSchemaID struct {
+	ID int32 `json:"id"`
+}
+
+func (r *SchemaRegistry) handle(hw http.ResponseWriter, hr *http.Request) {
+	if err := func() error {
+		defer hr.Body.Close()
+		path := strings.Split(strings.Trim(hr.URL.Path, `/`), `/`)
+		switch {
+		case hr.Method == http.MethodPost && len(path) == 3 &&
+			path[0] == `subjects` && path[2] == `versions`:
+			return r.register(hw, hr, path[1])
+		case hr.Method == http.MethodGet && len(path) == 3 &&
+			path[0] == `schemas` && path[1] == `ids`:
+			return r.lookup(hw, path[2])
+		default:
+			http.NotFound(hw, hr)
+			return nil
+		}
+	}(); err != nil {
+		http.Error(hw, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (r *SchemaRegistry) register(hw http.ResponseWriter, hr *http.Request, subject string) error {
+	var req confluentSchema
+	if err := gojson.NewDecoder(hr.Body).Decode(&req); err != nil {
+		return err
+	}
+	// Catch schemas that a real registry would reject.
+	if _, err := goavro.NewCodec(req.Schema); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	id, ok := r.mu.idSchema[req.Schema]
+	if !ok {
+		id = r.mu.idAlloc
+		r.mu.idAlloc++
+		r.mu.schemas[id] = req.Schema
+		r.mu.idSchema[req.Schema] = id
+	}
+	if ids := r.mu.subjects[subject]; len(ids) == 0 || ids[len(ids)-1] != id {
+		r.mu.subjects[subject] = append(ids, id)
+	}
+	r.mu.Unlock()
+
+	return writeJSON(hw, confluentSchemaID{ID: id})
+}
+
+func (r *SchemaRegistry) lookup(hw http.ResponseWriter, idStr string) error {
+	id, err := strconv.ParseInt(idStr, 10, 32)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	schema, ok := r.mu.schemas[int32(id)]
+	r.mu.Unlock()
+	if !ok {
+		http.Error(hw, `schema not found`, http.StatusNotFound)
+		return nil
+	}
+	return writeJSON(hw, confluentSchema{Schema: schema})
+}
+
+func writeJSON(hw http.ResponseWriter, v interface{}) error {
+	res, err := gojson.Marshal(v)
+	if err != nil {
+		return err
+	}
+	hw.Header().Set(`Content-type`, `application/json`)
+	_, _ = hw.Write(res)
+	return nil
+}
+
+// EncodedAvroToNative decodes bytes in the confluent wire format, using the
+// registered schema they reference, into the go native representation used by
+// goavro.
+func (r *SchemaRegistry) EncodedAvroToNative(b []byte) (interface{}, error) {
+	if len(b) == 0 || b[0] != confluentAvroWireFormatMagic {
+		return ``, errors.Errorf(`bad magic byte`)
+	}
+	b = b[1:]
+	if len(b) < 4 {
+		return ``, errors.Errorf(`missing registry id`)
+	}
+	id := int32(binary.BigEndian.Uint32(b[:4]))
+	b = b[4:]
+
+	r.mu.Lock()
+	jsonSchema, ok := r.mu.schemas[id]
+	r.mu.Unlock()
+	if !ok {
+		return ``, errors.Errorf(`unknown schema id: %d`, id)
+	}
+	codec, err := goavro.NewCodec(jsonSchema)
+	if err != nil {
+		return ``, err
+	}
+	native, _, err := codec.NativeFromBinary(b)
+	return native, err
+}
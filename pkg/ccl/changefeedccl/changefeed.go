@@ -61,6 +61,10 @@ type emitEntry struct {
 	// timestamp will be emitted.
 	resolved *jobspb.ResolvedSpan
 
+	// schemaChange, if non-nil, is a new version of a watched table's
+	// descriptor. It is ordered before any row that was read with it.
+	schemaChange *sqlbase.TableDescriptor
+
 	// bufferGetTimestamp is the time this entry came out of the buffer.
 	bufferGetTimestamp time.Time
 }
@@ -74,11 +78,18 @@ func kvsToRows(
 	inputFn func(context.Context) (bufferEntry, error),
 ) func(context.Context) ([]emitEntry, error) {
 	rfCache := newRowFetcherCache(leaseMgr)
+	_, withDiff := details.Opts[optDiff]
+	var sel *changefeedSelect
+	var selErr error
+	if details.Select != `` {
+		// Any error is returned with the first row, since this can't return one.
+		sel, selErr = parseChangefeedSelect(details.Select)
+	}
 
 	var kvs row.SpanKVFetcher
 	appendEmitEntryForKV := func(
-		ctx context.Context, output []emitEntry, kv roachpb.KeyValue, schemaTimestamp hlc.Timestamp,
-		bufferGetTimestamp time.Time,
+		ctx context.Context, output []emitEntry, kv roachpb.KeyValue, prevVal roachpb.Value,
+		schemaTimestamp hlc.Timestamp, bufferGetTimestamp time.Time,
 	) ([]emitEntry, error) {
 		// Reuse kvs to save allocations.
 		kvs.KVs = kvs.KVs[:0]
@@ -105,6 +116,7 @@ func kvsToRows(
 			return nil, err
 		}
 
+		rowsStart := len(output)
 		for {
 			var r emitEntry
 			r.bufferGetTimestamp = bufferGetTimestamp
@@ -117,14 +129,70 @@ func kvsToRows(
 			}
 			r.row.datums = append(sqlbase.EncDatumRow(nil), r.row.datums...)
 			r.row.deleted = rf.RowIsDeleted()
+			if !r.row.deleted {
+				if err := rfCache.ComputeVirtualColumns(desc, r.row.datums); err != nil {
+					return nil, err
+				}
+			}
 			r.row.updated = schemaTimestamp
 			output = append(output, r)
 		}
-		return output, nil
+
+		if withDiff && len(output) > rowsStart {
+			// Decode the previous value of the row with the same descriptor. A
+			// missing previous value means the row didn't exist before this change
+			// (or that it's unknown, e.g. for the initial scan).
+			var prevDatums sqlbase.EncDatumRow
+			prevDeleted := true
+			if prevVal.IsPresent() {
+				kvs.KVs = append(kvs.KVs[:0], roachpb.KeyValue{Key: kv.Key, Value: prevVal})
+				if err := rf.StartScanFrom(ctx, &kvs); err != nil {
+					return nil, err
+				}
+				prevDatums, _, _, err = rf.NextRow(ctx)
+				if err != nil {
+					return nil, err
+				}
+				if prevDatums != nil {
+					prevDatums = append(sqlbase.EncDatumRow(nil), prevDatums...)
+					prevDeleted = rf.RowIsDeleted()
+					if !prevDeleted {
+						if err := rfCache.ComputeVirtualColumns(desc, prevDatums); err != nil {
+							return nil, err
+						}
+					}
+				}
+			}
+			for i := rowsStart; i < len(output); i++ {
+				output[i].row.prevDatums = prevDatums
+				output[i].row.prevDeleted = prevDeleted
+			}
+		}
+
+		if sel == nil {
+			return output, nil
+		}
+		// Filter and project the decoded rows, dropping the ones that don't
+		// match.
+		rowsEnd := rowsStart
+		for i := rowsStart; i < len(output); i++ {
+			keep, err := sel.apply(&output[i].row, withDiff)
+			if err != nil {
+				return nil, err
+			}
+			if keep {
+				output[rowsEnd] = output[i]
+				rowsEnd++
+			}
+		}
+		return output[:rowsEnd], nil
 	}
 
 	var output []emitEntry
 	return func(ctx context.Context) ([]emitEntry, error) {
+		if selErr != nil {
+			return nil, selErr
+		}
 		// Reuse output to save allocations.
 		output = output[:0]
 		for {
@@ -141,11 +209,26 @@ func kvsToRows(
 					schemaTimestamp = input.schemaTimestamp
 				}
 				output, err = appendEmitEntryForKV(
-					ctx, output, input.kv, schemaTimestamp, input.bufferGetTimestamp)
+					ctx, output, input.kv, input.prevVal, schemaTimestamp, input.bufferGetTimestamp)
 				if err != nil {
 					return nil, err
 				}
 			}
+			if input.schemaChange != nil {
+				schemaChange := input.schemaChange
+				if sel != nil {
+					// Describe the columns that are emitted.
+					c, err := sel.forTable(schemaChange)
+					if err != nil {
+						return nil, err
+					}
+					schemaChange = c.desc
+				}
+				output = append(output, emitEntry{
+					schemaChange:       schemaChange,
+					bufferGetTimestamp: input.bufferGetTimestamp,
+				})
+			}
 			if input.resolved != nil {
 				output = append(output, emitEntry{
 					resolved:           input.resolved,
@@ -162,7 +245,10 @@ func kvsToRows(
 // emitEntries connects to a sink, receives rows from a closure, and repeatedly
 // emits them to the sink. It returns a closure that may be repeatedly called to
 // advance the changefeed and which returns span-level resolved timestamp
-// updates. The returned closure is not threadsafe.
+// updates, along with the new table descriptor versions seen before them.
+// Schema changes aren't emitted to the sink here: they're published once for
+// the whole changefeed by the changeFrontier. The returned closure is not
+// threadsafe.
 func emitEntries(
 	settings *cluster.Settings,
 	details jobspb.ChangefeedDetails,
@@ -172,7 +258,7 @@ func emitEntries(
 	inputFn func(context.Context) ([]emitEntry, error),
 	knobs TestingKnobs,
 	metrics *Metrics,
-) func(context.Context) ([]jobspb.ResolvedSpan, error) {
+) func(context.Context) ([]jobspb.ResolvedSpan, []*sqlbase.TableDescriptor, error) {
 	var scratch bufalloc.ByteAllocator
 	emitRowFn := func(ctx context.Context, row encodeRow) error {
 		var keyCopy, valueCopy []byte
@@ -207,14 +293,16 @@ func emitEntries(
 	// (There is a different SpanFrontier elsewhere for the entire changefeed.)
 	watchedSF := makeSpanFrontier(watchedSpans...)
 
+	_, initialScanOnly := details.Opts[optInitialScanOnly]
 	var lastFlush time.Time
 	// TODO(dan): We could keep these in `watchedSF` to eliminate dups.
 	var resolvedSpans []jobspb.ResolvedSpan
+	var schemaChanges []*sqlbase.TableDescriptor
 
-	return func(ctx context.Context) ([]jobspb.ResolvedSpan, error) {
+	return func(ctx context.Context) ([]jobspb.ResolvedSpan, []*sqlbase.TableDescriptor, error) {
 		inputs, err := inputFn(ctx)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		for _, input := range inputs {
 			if input.bufferGetTimestamp == (time.Time{}) {
@@ -228,9 +316,12 @@ func emitEntries(
 			processingNanos := timeutil.Since(input.bufferGetTimestamp).Nanoseconds()
 			metrics.ProcessingNanos.Inc(processingNanos)
 
+			if input.schemaChange != nil {
+				schemaChanges = append(schemaChanges, input.schemaChange)
+			}
 			if input.row.datums != nil {
 				if err := emitRowFn(ctx, input.row); err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 			}
 			if input.resolved != nil {
@@ -259,13 +350,17 @@ func emitEntries(
 		if r, ok := details.Opts[optResolvedTimestamps]; ok && r != `` {
 			var err error
 			if timeBetweenFlushes, err = time.ParseDuration(r); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		} else {
 			timeBetweenFlushes = changefeedPollInterval.Get(&settings.SV) / 5
 		}
-		if len(resolvedSpans) == 0 || timeutil.Since(lastFlush) < timeBetweenFlushes {
-			return nil, nil
+		// With `initial_scan_only`, nothing is emitted after the scan, so
+		// there's no reason to wait for more input before flushing.
+		scanDone := initialScanOnly && !watchedSF.Frontier().Less(details.StatementTime)
+		if len(resolvedSpans) == 0 ||
+			(timeutil.Since(lastFlush) < timeBetweenFlushes && !scanDone) {
+			return nil, nil, nil
 		}
 
 		// Make sure to flush the sink before forwarding resolved spans,
@@ -273,17 +368,19 @@ func emitEntries(
 		// at-least-once guarantee. This is also true for checkpointing the
 		// resolved spans in the job progress.
 		if err := sink.Flush(ctx); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		lastFlush = timeutil.Now()
 		if knobs.AfterSinkFlush != nil {
 			if err := knobs.AfterSinkFlush(); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 		ret := append([]jobspb.ResolvedSpan(nil), resolvedSpans...)
 		resolvedSpans = resolvedSpans[:0]
-		return ret, nil
+		retSchemaChanges := schemaChanges
+		schemaChanges = nil
+		return ret, retSchemaChanges, nil
 	}
 }
 
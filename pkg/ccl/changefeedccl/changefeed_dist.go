@@ -33,6 +33,11 @@ const (
 	changeFrontierProcName   = `changefntr`
 )
 
+// changefeedResultTypes are the types of the rows returned by the changefeed
+// processors. A changeAggregator returns a resolved span, a changed row of a
+// sinkless changefeed (without a resolved span) or a new version of a watched
+// table's descriptor, marshaled in the value column (without a resolved span or
+// a topic).
 var changefeedResultTypes = []types.T{
 	*types.Bytes,  // resolved span
 	*types.String, // topic
@@ -58,13 +58,14 @@ type changeAggregator struct {
 	// tickFn is the workhorse behind Next(). It pulls kv changes from the
 	// buffer that poller fills, handles table leasing, converts them to rows,
 	// and writes them to the sink.
-	tickFn func(context.Context) ([]jobspb.ResolvedSpan, error)
+	tickFn func(context.Context) ([]jobspb.ResolvedSpan, []*sqlbase.TableDescriptor, error)
 	// changedRowBuf, if non-nil, contains changed rows to be emitted. Anything
 	// queued in `resolvedSpanBuf` is dependent on these having been emitted, so
 	// this one must be empty before moving on to that one.
 	changedRowBuf *encDatumRowBuffer
-	// resolvedSpanBuf contains resolved span updates to send to changeFrontier.
-	// If sink is a bufferSink, it must be emptied before these are sent.
+	// resolvedSpanBuf contains resolved span updates and schema changes to send
+	// to changeFrontier. If sink is a bufferSink, it must be emptied before
+	// these are sent.
 	resolvedSpanBuf encDatumRowBuffer
 }
 
@@ -268,11 +269,26 @@ func (ca *changeAggregator) Next() (sqlbase.EncDatumRow, *distsqlpb.ProducerMeta
 }
 
 func (ca *changeAggregator) tick() error {
-	resolvedSpans, err := ca.tickFn(ca.Ctx)
+	resolvedSpans, schemaChanges, err := ca.tickFn(ca.Ctx)
 	if err != nil {
 		return err
 	}
 
+	// Every changeAggregator sees the schema changes of the watched tables, so
+	// they're forwarded to changeFrontier, which publishes each of them once.
+	// They're queued before the resolved spans, which may have passed them.
+	for _, desc := range schemaChanges {
+		descBytes, err := protoutil.Marshal(desc)
+		if err != nil {
+			return err
+		}
+		ca.resolvedSpanBuf.Push(sqlbase.EncDatumRow{
+			sqlbase.EncDatum{Datum: tree.DNull},                             // resolved span
+			sqlbase.EncDatum{Datum: tree.DNull},                             // topic
+			sqlbase.EncDatum{Datum: tree.DNull},                             // key
+			sqlbase.EncDatum{Datum: tree.NewDBytes(tree.DBytes(descBytes))}, // value
+		})
+	}
 	for _, resolvedSpan := range resolvedSpans {
 		resolvedBytes, err := protoutil.Marshal(&resolvedSpan)
 		if err != nil {
@@ -348,11 +364,21 @@ type changeFrontier struct {
 	// resolved timestamp to be returned. It depends on everything in
 	// `passthroughBuf` being sent, so that one needs to be emptied first.
 	resolvedBuf *encDatumRowBuffer
+	// schemaVersions is the most recent version of each watched table that was
+	// emitted to the sink. Every changeAggregator forwards the schema changes
+	// it sees, so this is used to emit each version only once.
+	schemaVersions map[sqlbase.ID]sqlbase.DescriptorVersion
+	// schemaChangesUnflushed is set when a schema change was emitted to the
+	// sink since it was last flushed.
+	schemaChangesUnflushed bool
 	// metrics are monitoring counters shared between all changefeeds.
 	metrics *Metrics
 	// metricsID is used as the unique id of this changefeed in the
 	// metrics.MaxBehindNanos map.
 	metricsID int
+	// initialScanOnly is set for changefeeds that stop once every span has
+	// been resolved at the statement time (see `initial_scan_only`).
+	initialScanOnly bool
 }
 
 var _ distsqlrun.Processor = &changeFrontier{}
@@ -373,6 +399,8 @@ func newChangeFrontierProcessor(
 		memAcc:  memMonitor.MakeBoundAccount(),
 		input:   input,
 		sf:      makeSpanFrontier(spec.TrackedSpans...),
+
+		schemaVersions: make(map[sqlbase.ID]sqlbase.DescriptorVersion),
 	}
 	if err := cf.Init(
 		cf, &distsqlpb.PostProcessSpec{},
@@ -404,6 +432,8 @@ func newChangeFrontierProcessor(
 		cf.freqEmitResolved = emitNoResolved
 	}
 
+	_, cf.initialScanOnly = cf.spec.Feed.Opts[optInitialScanOnly]
+
 	var err error
 	if cf.encoder, err = getEncoder(spec.Feed.Opts); err != nil {
 		return nil, err
@@ -514,6 +544,15 @@ func (cf *changeFrontier) Next() (sqlbase.EncDatumRow, *distsqlpb.ProducerMetada
 			return cf.resolvedBuf.Pop(), nil
 		}
 
+		if cf.initialScanOnly && !cf.sf.Frontier().Less(cf.spec.Feed.StatementTime) {
+			// The initial scan is complete and everything it emitted has been
+			// flushed and checkpointed, so the changefeed is done once any
+			// resolved timestamp emitted here is flushed too.
+			log.VEventf(cf.Ctx, 1, `changefeed initial scan completed at %s`, cf.sf.Frontier())
+			cf.MoveToDraining(cf.sink.Flush(cf.Ctx))
+			break
+		}
+
 		row, meta := cf.input.Next()
 		if meta != nil {
 			if meta.Err != nil {
@@ -526,6 +565,16 @@ func (cf *changeFrontier) Next() (sqlbase.EncDatumRow, *distsqlpb.ProducerMetada
 			break
 		}
 
+		if row[0].IsNull() && row[1].IsNull() {
+			// A row with neither a resolved span nor a topic is a schema change
+			// forwarded by a changeAggregator.
+			if err := cf.noteSchemaChange(row[3]); err != nil {
+				cf.MoveToDraining(err)
+				break
+			}
+			continue
+		}
+
 		if row[0].IsNull() {
 			// In changefeeds with a sink, this will never happen. But in the
 			// core changefeed, which returns changed rows directly via pgwire,
@@ -573,6 +622,15 @@ func (cf *changeFrontier) noteResolvedSpan(d sqlbase.EncDatum) error {
 
 	frontierChanged := cf.sf.Forward(resolved.Span, resolved.Timestamp)
 	if frontierChanged {
+		// The schema changes must be delivered before the high-water is
+		// checkpointed past them, otherwise they'd be lost if the changefeed
+		// was restarted.
+		if cf.schemaChangesUnflushed {
+			if err := cf.sink.Flush(cf.Ctx); err != nil {
+				return err
+			}
+			cf.schemaChangesUnflushed = false
+		}
 		newResolved := cf.sf.Frontier()
 		cf.metrics.mu.Lock()
 		if cf.metricsID != -1 {
@@ -624,6 +682,34 @@ func (cf *changeFrontier) noteResolvedSpan(d sqlbase.EncDatum) error {
 	return nil
 }
 
+// noteSchemaChange emits a table descriptor forwarded by a changeAggregator to
+// the sink, unless the same or a newer version of the table was already
+// emitted.
+func (cf *changeFrontier) noteSchemaChange(d sqlbase.EncDatum) error {
+	if err := d.EnsureDecoded(&changefeedResultTypes[3], &cf.a); err != nil {
+		return err
+	}
+	raw, ok := d.Datum.(*tree.DBytes)
+	if !ok {
+		return pgerror.AssertionFailedf(`unexpected datum type %T: %s`, d.Datum, d.Datum)
+	}
+	var desc sqlbase.TableDescriptor
+	if err := protoutil.Unmarshal([]byte(*raw), &desc); err != nil {
+		return pgerror.NewAssertionErrorWithWrappedErrf(err,
+			`unmarshalling table descriptor: %x`, raw)
+	}
+
+	if lastVersion, ok := cf.schemaVersions[desc.ID]; ok && desc.Version <= lastVersion {
+		return nil
+	}
+	if err := cf.sink.EmitSchemaChange(cf.Ctx, &desc); err != nil {
+		return err
+	}
+	cf.schemaVersions[desc.ID] = desc.Version
+	cf.schemaChangesUnflushed = true
+	return nil
+}
+
 // ConsumerDone is part of the RowSource interface.
 func (cf *changeFrontier) ConsumerDone() {
 	cf.MoveToDraining(nil /* err */)
@@ -50,9 +50,13 @@ type formatType string
 const (
 	optConfluentSchemaRegistry = `confluent_schema_registry`
 	optCursor                  = `cursor`
+	optDiff                    = `diff`
 	optEnvelope                = `envelope`
 	optFormat                  = `format`
+	optInitialScan             = `initial_scan`
+	optInitialScanOnly         = `initial_scan_only`
 	optKeyInValue              = `key_in_value`
+	optNoInitialScan           = `no_initial_scan`
 	optResolvedTimestamps      = `resolved`
 	optUpdatedTimestamps       = `updated`
 
@@ -65,13 +69,19 @@ const (
 	optFormatAvro formatType = `experimental_avro`
 
 	sinkParamCACert           = `ca_cert`
+	sinkParamClientCert       = `client_cert`
+	sinkParamClientKey        = `client_key`
 	sinkParamFileSize         = `file_size`
+	sinkParamFlushInterval    = `flush_interval`
+	sinkParamMaxBatchSize     = `max_batch_size`
+	sinkParamMaxRetries       = `max_retries`
 	sinkParamSchemaTopic      = `schema_topic`
 	sinkParamTLSEnabled       = `tls_enabled`
 	sinkParamTopicPrefix      = `topic_prefix`
 	sinkSchemeBuffer          = ``
 	sinkSchemeExperimentalSQL = `experimental-sql`
 	sinkSchemeKafka           = `kafka`
+	sinkSchemeWebhookHTTPS    = `webhook-https`
 	sinkParamSASLEnabled      = `sasl_enabled`
 	sinkParamSASLHandshake    = `sasl_handshake`
 	sinkParamSASLUser         = `sasl_user`
@@ -81,9 +91,13 @@ const (
 var changefeedOptionExpectValues = map[string]sql.KVStringOptValidate{
 	optConfluentSchemaRegistry: sql.KVStringOptRequireValue,
 	optCursor:                  sql.KVStringOptRequireValue,
+	optDiff:                    sql.KVStringOptRequireNoValue,
 	optEnvelope:                sql.KVStringOptRequireValue,
 	optFormat:                  sql.KVStringOptRequireValue,
+	optInitialScan:             sql.KVStringOptRequireNoValue,
+	optInitialScanOnly:         sql.KVStringOptRequireNoValue,
 	optKeyInValue:              sql.KVStringOptRequireNoValue,
+	optNoInitialScan:           sql.KVStringOptRequireNoValue,
 	optResolvedTimestamps:      sql.KVStringOptAny,
 	optUpdatedTimestamps:       sql.KVStringOptRequireNoValue,
 }
@@ -178,6 +192,12 @@ func changefeedPlanHook(
 			}
 			statementTime = initialHighWater
 		}
+		// An empty initial high-water makes the changefeed start with a scan of
+		// every watched table at the statement time.
+		initialHighWater = hlc.Timestamp{}
+		if !initialScanRequired(opts) {
+			initialHighWater = statementTime
+		}
 
 		// For now, disallow targeting a database or wildcard table selection.
 		// Getting it right as tables enter and leave the set over time is
@@ -220,6 +240,22 @@ func changefeedPlanHook(
 			SinkURI:       sinkURI,
 			StatementTime: statementTime,
 		}
+		if changefeedStmt.Projection != nil {
+			details.Select = tree.Serialize(changefeedStmt.Select())
+			// Compile the projection and filter to catch any errors in them now,
+			// instead of when the first row is emitted.
+			sel, err := parseChangefeedSelect(details.Select)
+			if err != nil {
+				return err
+			}
+			for _, desc := range targetDescs {
+				if tableDesc := desc.GetTable(); tableDesc != nil {
+					if _, err := sel.forTable(tableDesc); err != nil {
+						return err
+					}
+				}
+			}
+		}
 		progress := jobspb.Progress{
 			Progress: &jobspb.Progress_HighWater{HighWater: &initialHighWater},
 			Details: &jobspb.Progress_Changefeed{
@@ -263,6 +299,9 @@ func changefeedPlanHook(
 		if _, err := getEncoder(details.Opts); err != nil {
 			return err
 		}
+		if _, ok := details.Opts[optDiff]; ok && !PushEnabled.Get(&p.ExecCfg().Settings.SV) {
+			return errors.Errorf(`%s requires the changefeed.push.enabled setting`, optDiff)
+		}
 		if isCloudStorageSink(parsedSink) {
 			details.Opts[optKeyInValue] = ``
 		}
@@ -348,8 +387,10 @@ func changefeedJobDescription(
 		return "", err
 	}
 	c := &tree.CreateChangefeed{
-		Targets: changefeed.Targets,
-		SinkURI: tree.NewDString(cleanedSinkURI),
+		Targets:    changefeed.Targets,
+		SinkURI:    tree.NewDString(cleanedSinkURI),
+		Projection: changefeed.Projection,
+		Filter:     changefeed.Filter,
 	}
 	for k, v := range opts {
 		opt := tree.KVOption{Key: tree.Name(k)}
@@ -381,6 +422,17 @@ func validateDetails(details jobspb.ChangefeedDetails) (jobspb.ChangefeedDetails
 		}
 	}
 
+	var initialScanOpts []string
+	for _, opt := range []string{optInitialScan, optInitialScanOnly, optNoInitialScan} {
+		if _, ok := details.Opts[opt]; ok {
+			initialScanOpts = append(initialScanOpts, opt)
+		}
+	}
+	if len(initialScanOpts) > 1 {
+		return jobspb.ChangefeedDetails{}, errors.Errorf(
+			`cannot specify both %s and %s`, initialScanOpts[0], initialScanOpts[1])
+	}
+
 	switch envelopeType(details.Opts[optEnvelope]) {
 	case optEnvelopeRow, optEnvelopeDeprecatedRow:
 		details.Opts[optEnvelope] = string(optEnvelopeRow)
@@ -406,6 +458,23 @@ func validateDetails(details jobspb.ChangefeedDetails) (jobspb.ChangefeedDetails
 	return details, nil
 }
 
+// initialScanRequired returns whether a changefeed with the given options
+// starts with a scan of its tables at the statement time. By default, it does
+// unless it was given a cursor.
+func initialScanRequired(opts map[string]string) bool {
+	if _, ok := opts[optInitialScan]; ok {
+		return true
+	}
+	if _, ok := opts[optInitialScanOnly]; ok {
+		return true
+	}
+	if _, ok := opts[optNoInitialScan]; ok {
+		return false
+	}
+	_, ok := opts[optCursor]
+	return !ok
+}
+
 func validateChangefeedTable(
 	targets jobspb.ChangefeedTargets, tableDesc *sqlbase.TableDescriptor,
 ) error {
@@ -470,7 +539,7 @@ func (b *changefeedResumer) Resume(
 	// progress high-water when creating a job (currently only the progress
 	// details can be set). I didn't want to pick off the refactor to get this
 	// fix in, but it'd be nice to remove this hack.
-	if _, ok := details.Opts[optCursor]; ok {
+	if !initialScanRequired(details.Opts) {
 		if h := progress.GetHighWater(); h == nil || *h == (hlc.Timestamp{}) {
 			progress.Progress = &jobspb.Progress_HighWater{HighWater: &details.StatementTime}
 		}
@@ -11,6 +11,7 @@ package changefeedccl
 import (
 	"context"
 	gosql "database/sql"
+	gojson "encoding/json"
 	"fmt"
 	"net/url"
 	"sort"
@@ -31,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/distsqlrun"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
@@ -129,6 +131,100 @@ func TestChangefeedEnvelope(t *testing.T) {
 	t.Run(`poller`, pollerTest(sinklessTest, testFn))
 }
 
+func TestChangefeedDiff(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (0, 'initial')`)
+		sqlDB.Exec(t, `UPSERT INTO foo VALUES (0, 'updated')`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH diff`)
+		defer closeFeed(t, foo)
+
+		// The initial scan doesn't know the previous values of rows.
+		assertPayloads(t, foo, []string{
+			`foo: [0]->{"after": {"a": 0, "b": "updated"}, "before": null}`,
+		})
+
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'a')`)
+		sqlDB.Exec(t, `UPSERT INTO foo VALUES (1, 'b')`)
+		sqlDB.Exec(t, `DELETE FROM foo WHERE a = 1`)
+		sqlDB.Exec(t, `UPSERT INTO foo VALUES (0, 'c')`)
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "b": "a"}, "before": null}`,
+			`foo: [1]->{"after": {"a": 1, "b": "b"}, "before": {"a": 1, "b": "a"}}`,
+			`foo: [1]->{"after": null, "before": {"a": 1, "b": "b"}}`,
+			`foo: [0]->{"after": {"a": 0, "b": "c"}, "before": {"a": 0, "b": "updated"}}`,
+		})
+	}
+
+	t.Run(`sinkless`, sinklessTest(testFn))
+	t.Run(`enterprise`, enterpriseTest(testFn))
+}
+
+func TestChangefeedSelect(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c INT)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (0, 'zero', 0), (1, 'one', 10)`)
+
+		t.Run(`projection and filter`, func(t *testing.T) {
+			foo := feed(t, f, `CREATE CHANGEFEED AS SELECT b, c * 2 AS d FROM foo WHERE c > 5`)
+			defer closeFeed(t, foo)
+
+			assertPayloads(t, foo, []string{
+				`foo: [1]->{"after": {"b": "one", "d": 20}}`,
+			})
+			sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 'two', 2), (3, 'three', 30)`)
+			sqlDB.Exec(t, `UPDATE foo SET c = 40 WHERE a = 2`)
+			sqlDB.Exec(t, `DELETE FROM foo WHERE a = 3`)
+			// Without the previous values of rows, deletions can't be filtered.
+			assertPayloads(t, foo, []string{
+				`foo: [3]->{"after": {"b": "three", "d": 60}}`,
+				`foo: [2]->{"after": {"b": "two", "d": 80}}`,
+				`foo: [3]->{"after": null}`,
+			})
+		})
+
+		t.Run(`star`, func(t *testing.T) {
+			foo := feed(t, f, `CREATE CHANGEFEED AS SELECT *, upper(b) FROM foo WHERE a = 0`)
+			defer closeFeed(t, foo)
+
+			assertPayloads(t, foo, []string{
+				`foo: [0]->{"after": {"a": 0, "b": "zero", "c": 0, "upper": "ZERO"}}`,
+			})
+		})
+
+		t.Run(`diff`, func(t *testing.T) {
+			foo := feed(t, f, `CREATE CHANGEFEED WITH diff AS SELECT c FROM foo WHERE c < 100`)
+			defer closeFeed(t, foo)
+
+			assertPayloads(t, foo, []string{
+				`foo: [0]->{"after": {"c": 0}, "before": null}`,
+				`foo: [1]->{"after": {"c": 10}, "before": null}`,
+				`foo: [2]->{"after": {"c": 40}, "before": null}`,
+			})
+			// A row that stops matching the filter is emitted as a deletion, and
+			// the changes to rows that didn't match are not emitted.
+			sqlDB.Exec(t, `UPDATE foo SET c = 100 WHERE a = 0`)
+			sqlDB.Exec(t, `UPDATE foo SET c = 200 WHERE a = 0`)
+			sqlDB.Exec(t, `DELETE FROM foo WHERE a = 0`)
+			sqlDB.Exec(t, `UPDATE foo SET c = 11 WHERE a = 1`)
+			assertPayloads(t, foo, []string{
+				`foo: [0]->{"after": null, "before": {"c": 0}}`,
+				`foo: [1]->{"after": {"c": 11}, "before": {"c": 10}}`,
+			})
+		})
+	}
+
+	t.Run(`sinkless`, sinklessTest(testFn))
+	t.Run(`enterprise`, enterpriseTest(testFn))
+}
+
 func TestChangefeedMultiTable(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -212,6 +308,72 @@ func TestChangefeedCursor(t *testing.T) {
 	t.Run(`poller`, pollerTest(sinklessTest, testFn))
 }
 
+func TestChangefeedInitialScan(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (0, 'initial')`)
+		var cursor string
+		sqlDB.QueryRow(t, `SELECT cluster_logical_timestamp()`).Scan(&cursor)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'after cursor')`)
+
+		t.Run(`no_initial_scan`, func(t *testing.T) {
+			foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH no_initial_scan`)
+			defer closeFeed(t, foo)
+
+			sqlDB.Exec(t, `UPSERT INTO foo VALUES (0, 'updated')`)
+			assertPayloads(t, foo, []string{
+				`foo: [0]->{"after": {"a": 0, "b": "updated"}}`,
+			})
+		})
+
+		t.Run(`initial_scan with cursor`, func(t *testing.T) {
+			foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH initial_scan, cursor=$1`, cursor)
+			defer closeFeed(t, foo)
+
+			// The scan is at the cursor, and the changes since are emitted after.
+			assertPayloads(t, foo, []string{
+				`foo: [0]->{"after": {"a": 0, "b": "initial"}}`,
+				`foo: [1]->{"after": {"a": 1, "b": "after cursor"}}`,
+				`foo: [0]->{"after": {"a": 0, "b": "updated"}}`,
+			})
+		})
+
+		t.Run(`initial_scan_only`, func(t *testing.T) {
+			foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH initial_scan_only`)
+			defer closeFeed(t, foo)
+
+			assertPayloads(t, foo, []string{
+				`foo: [0]->{"after": {"a": 0, "b": "updated"}}`,
+				`foo: [1]->{"after": {"a": 1, "b": "after cursor"}}`,
+			})
+			// The changefeed finishes after the scan.
+			if e, ok := foo.(*cdctest.TableFeed); ok {
+				testutils.SucceedsSoon(t, func() error {
+					var status string
+					sqlDB.QueryRow(t,
+						`SELECT status FROM [SHOW JOBS] WHERE job_id = $1`, e.JobID,
+					).Scan(&status)
+					if status != string(jobs.StatusSucceeded) {
+						return errors.Errorf(`expected %s got %s`, jobs.StatusSucceeded, status)
+					}
+					return nil
+				})
+			} else {
+				m, err := foo.Next()
+				require.NoError(t, err)
+				require.Nil(t, m)
+			}
+		})
+	}
+
+	t.Run(`sinkless`, sinklessTest(testFn))
+	t.Run(`enterprise`, enterpriseTest(testFn))
+	t.Run(`poller`, pollerTest(sinklessTest, testFn))
+}
+
 func TestChangefeedTimestamps(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -789,7 +951,6 @@ func TestChangefeedComputedColumn(t *testing.T) {
 
 	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
 		sqlDB := sqlutils.MakeSQLRunner(db)
-		// TODO(dan): Also test a non-STORED computed column once we support them.
 		sqlDB.Exec(t, `CREATE TABLE cc (
 		a INT, b INT AS (a + 1) STORED, c INT AS (a + 2) STORED, PRIMARY KEY (b, a)
 	)`)
@@ -813,6 +974,46 @@ func TestChangefeedComputedColumn(t *testing.T) {
 	t.Run(`poller`, pollerTest(sinklessTest, testFn))
 }
 
+func TestChangefeedVirtualComputedColumn(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE cc (a INT PRIMARY KEY, b INT, c INT AS (b + 1) VIRTUAL)`)
+		sqlDB.Exec(t, `INSERT INTO cc (a, b) VALUES (1, 1)`)
+
+		t.Run(`diff`, func(t *testing.T) {
+			cc := feed(t, f, `CREATE CHANGEFEED FOR cc WITH diff`)
+			defer closeFeed(t, cc)
+
+			// The virtual computed columns are not stored, so they are computed
+			// for the emitted rows and their previous values.
+			assertPayloads(t, cc, []string{
+				`cc: [1]->{"after": {"a": 1, "b": 1, "c": 2}, "before": null}`,
+			})
+			sqlDB.Exec(t, `UPDATE cc SET b = 10 WHERE a = 1`)
+			sqlDB.Exec(t, `DELETE FROM cc WHERE a = 1`)
+			assertPayloads(t, cc, []string{
+				`cc: [1]->{"after": {"a": 1, "b": 10, "c": 11}, "before": {"a": 1, "b": 1, "c": 2}}`,
+				`cc: [1]->{"after": null, "before": {"a": 1, "b": 10, "c": 11}}`,
+			})
+		})
+
+		t.Run(`select`, func(t *testing.T) {
+			sqlDB.Exec(t, `INSERT INTO cc (a, b) VALUES (2, 2), (3, 3)`)
+			cc := feed(t, f, `CREATE CHANGEFEED AS SELECT c FROM cc WHERE c > 3`)
+			defer closeFeed(t, cc)
+
+			assertPayloads(t, cc, []string{
+				`cc: [3]->{"after": {"c": 4}}`,
+			})
+		})
+	}
+
+	t.Run(`sinkless`, sinklessTest(testFn))
+	t.Run(`enterprise`, enterpriseTest(testFn))
+}
+
 func TestChangefeedUpdatePrimaryKey(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -1312,6 +1513,44 @@ func TestChangefeedErrors(t *testing.T) {
 		`EXPERIMENTAL CHANGEFEED FOR foo WITH cursor=$1`, timeutil.Now().Add(time.Hour),
 	)
 
+	sqlDB.ExpectErr(
+		t, `impure functions are not allowed in changefeed projection`,
+		`EXPERIMENTAL CHANGEFEED AS SELECT a, now() FROM foo`,
+	)
+	sqlDB.ExpectErr(
+		t, `impure functions are not allowed in changefeed filter`,
+		`EXPERIMENTAL CHANGEFEED AS SELECT a FROM foo WHERE random() < 0.5`,
+	)
+	sqlDB.ExpectErr(
+		t, `subqueries are not allowed in changefeed filter`,
+		`EXPERIMENTAL CHANGEFEED AS SELECT a FROM foo WHERE a IN (SELECT 1)`,
+	)
+	sqlDB.ExpectErr(
+		t, `aggregate functions are not allowed in changefeed projection`,
+		`EXPERIMENTAL CHANGEFEED AS SELECT max(a) FROM foo`,
+	)
+	sqlDB.ExpectErr(
+		t, `column "nope" does not exist`,
+		`EXPERIMENTAL CHANGEFEED AS SELECT nope FROM foo`,
+	)
+	sqlDB.ExpectErr(
+		t, `argument of WHERE must be type bool, not type int`,
+		`EXPERIMENTAL CHANGEFEED AS SELECT a FROM foo WHERE a`,
+	)
+	sqlDB.ExpectErr(
+		t, `duplicate column name in changefeed projection: "a"`,
+		`EXPERIMENTAL CHANGEFEED AS SELECT *, b AS a FROM foo`,
+	)
+
+	sqlDB.ExpectErr(
+		t, `cannot specify both initial_scan and no_initial_scan`,
+		`EXPERIMENTAL CHANGEFEED FOR foo WITH initial_scan, no_initial_scan`,
+	)
+	sqlDB.ExpectErr(
+		t, `cannot specify both initial_scan_only and no_initial_scan`,
+		`EXPERIMENTAL CHANGEFEED FOR foo WITH initial_scan_only, no_initial_scan`,
+	)
+
 	sqlDB.ExpectErr(
 		t, `omit the SINK clause`,
 		`CREATE CHANGEFEED FOR foo INTO ''`,
@@ -1390,12 +1629,6 @@ func TestChangefeedErrors(t *testing.T) {
 		`CREATE CHANGEFEED FOR foo INTO $1`, `kafka://nope/?kafka_topic_prefix=foo`,
 	)
 
-	// schema_topic will be implemented but isn't yet.
-	sqlDB.ExpectErr(
-		t, `schema_topic is not yet supported`,
-		`CREATE CHANGEFEED FOR foo INTO $1`, `kafka://nope/?schema_topic=foo`,
-	)
-
 	// Sanity check kafka tls parameters.
 	sqlDB.ExpectErr(
 		t, `param tls_enabled must be a bool`,
@@ -1468,6 +1701,24 @@ func TestChangefeedErrors(t *testing.T) {
 		t, `key_in_value is only usable with envelope=wrapped`,
 		`CREATE CHANGEFEED FOR foo INTO $1 WITH key_in_value, envelope='row'`, `kafka://nope`,
 	)
+
+	// WITH diff requires envelope=wrapped
+	sqlDB.ExpectErr(
+		t, `diff is only usable with envelope=wrapped`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH diff, envelope='key_only'`, `kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `diff is only usable with envelope=wrapped`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH diff, envelope='row'`, `kafka://nope`,
+	)
+
+	// WITH diff requires rangefeeds
+	sqlDB.Exec(t, `SET CLUSTER SETTING changefeed.push.enabled = false`)
+	sqlDB.ExpectErr(
+		t, `diff requires the changefeed.push.enabled setting`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH diff`, `kafka://nope`,
+	)
+	sqlDB.Exec(t, `SET CLUSTER SETTING changefeed.push.enabled TO DEFAULT`)
 }
 
 func TestChangefeedPermissions(t *testing.T) {
@@ -1738,6 +1989,92 @@ func TestChangefeedNodeShutdown(t *testing.T) {
 	})
 }
 
+// TestChangefeedSchemaTopicMultipleAggregators ensures that a changefeed that
+// is watched by a changeAggregator on every node publishes each version of a
+// table's descriptor to the schema topic only once.
+func TestChangefeedSchemaTopicMultipleAggregators(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	tc := serverutils.StartTestCluster(t, 3, base.TestClusterArgs{
+		ReplicationMode: base.ReplicationManual,
+		ServerArgs:      base.TestServerArgs{UseDatabase: "d"},
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+	sqlDB.Exec(t, `SET CLUSTER SETTING changefeed.push.enabled = false`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING changefeed.experimental_poll_interval = '10ms'`)
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+	sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `INSERT INTO foo VALUES (0), (1), (2)`)
+
+	// Move the lease of each range of foo to a different node, so that the
+	// changefeed is planned with a changeAggregator on each of them.
+	sqlDB.Exec(t, `ALTER TABLE foo SPLIT AT VALUES (1), (2)`)
+	sqlDB.Exec(t, fmt.Sprintf(
+		`ALTER TABLE foo EXPERIMENTAL_RELOCATE VALUES (ARRAY[%d], 0), (ARRAY[%d], 1), (ARRAY[%d], 2)`,
+		tc.Server(0).GetFirstStoreID(), tc.Server(1).GetFirstStoreID(), tc.Server(2).GetFirstStoreID(),
+	))
+	// This also populates the range cache of the gateway (see #31235).
+	sqlDB.CheckQueryResults(t,
+		`SELECT count(DISTINCT lease_holder) FROM [SHOW EXPERIMENTAL_RANGES FROM TABLE foo]`,
+		[][]string{{`3`}},
+	)
+
+	sink, cleanup := sqlutils.PGUrl(
+		t, tc.Server(0).ServingAddr(), t.Name(), url.User(security.RootUser))
+	defer cleanup()
+	sink.Scheme = sinkSchemeExperimentalSQL
+	sink.Path = `d`
+	q := sink.Query()
+	q.Set(sinkParamSchemaTopic, `schemas`)
+	sink.RawQuery = q.Encode()
+
+	var jobID int64
+	sqlDB.QueryRow(t, `CREATE CHANGEFEED FOR foo INTO $1`, sink.String()).Scan(&jobID)
+	sqlDB.Exec(t, `ALTER TABLE foo ADD COLUMN b INT`)
+	sqlDB.Exec(t, `ALTER TABLE foo ADD COLUMN c STRING`)
+
+	// Every schema change is published before the high-water passes it.
+	var ts string
+	sqlDB.QueryRow(t, `SELECT cluster_logical_timestamp()`).Scan(&ts)
+	testutils.SucceedsSoon(t, func() error {
+		var passed bool
+		sqlDB.QueryRow(t,
+			`SELECT COALESCE(high_water_timestamp >= $2::DECIMAL, false) FROM crdb_internal.jobs WHERE job_id = $1`,
+			jobID, ts,
+		).Scan(&passed)
+		if !passed {
+			return errors.Errorf(`high-water of job %d hasn't reached %s`, jobID, ts)
+		}
+		return nil
+	})
+
+	var lastVersion sqlbase.DescriptorVersion
+	sqlDB.QueryRow(t,
+		`SELECT version FROM crdb_internal.tables WHERE name = 'foo' AND database_name = 'd'`,
+	).Scan(&lastVersion)
+
+	seen := make(map[sqlbase.DescriptorVersion]int)
+	rows := sqlDB.Query(t, `SELECT key, value FROM sqlsink WHERE topic = 'schemas'`)
+	for rows.Next() {
+		var key, value []byte
+		require.NoError(t, rows.Scan(&key, &value))
+		require.Equal(t, `foo`, string(key))
+		var msg schemaChangeMessage
+		require.NoError(t, gojson.Unmarshal(value, &msg))
+		seen[sqlbase.DescriptorVersion(msg.Version)]++
+	}
+	require.NoError(t, rows.Err())
+
+	require.Contains(t, seen, lastVersion)
+	require.True(t, len(seen) > 1, `expected the initial version and the schema changes: %v`, seen)
+	for version, count := range seen {
+		require.Equal(t, 1, count, `version %d was published %d times`, version, count)
+	}
+}
+
 func TestChangefeedTelemetry(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -47,6 +47,30 @@ type encodeRow struct {
 	// tableDesc is a TableDescriptor for the table containing `datums`.
 	// It's valid for interpreting the row at `updated`.
 	tableDesc *sqlbase.TableDescriptor
+
+	// prevDatums is the old value of a changed table row, interpreted with
+	// `tableDesc`. It is only populated when the `diff` option is set.
+	prevDatums sqlbase.EncDatumRow
+	// prevDeleted is true if the row did not exist before this change (or if
+	// its previous value is not known, as is the case for rows emitted by an
+	// initial scan or a backfill). In this case, `prevDatums` is not set.
+	prevDeleted bool
+
+	// keyDatums and keyDesc, if set, are used instead of `datums` and
+	// `tableDesc` to encode the primary key of the row. They're set for
+	// changefeeds with a projection, where `datums` and `tableDesc` only
+	// describe the projected columns.
+	keyDatums sqlbase.EncDatumRow
+	keyDesc   *sqlbase.TableDescriptor
+}
+
+// keyRow returns the datums and descriptor to encode the row's primary key
+// from.
+func (r encodeRow) keyRow() (sqlbase.EncDatumRow, *sqlbase.TableDescriptor) {
+	if r.keyDesc != nil {
+		return r.keyDatums, r.keyDesc
+	}
+	return r.datums, r.tableDesc
 }
 
 // Encoder turns a row into a serialized changefeed key, value, or resolved
@@ -84,7 +108,7 @@ func getEncoder(opts map[string]string) (Encoder, error) {
 // to its value. Updated timestamps in rows and resolved timestamp payloads are
 // stored in a sub-object under the `__crdb__` key in the top-level JSON object.
 type jsonEncoder struct {
-	updatedField, wrapped, keyOnly, keyInValue bool
+	updatedField, beforeField, wrapped, keyOnly, keyInValue bool
 
 	alloc sqlbase.DatumAlloc
 	buf   bytes.Buffer
@@ -103,6 +127,11 @@ func makeJSONEncoder(opts map[string]string) (*jsonEncoder, error) {
 		return nil, errors.Errorf(`%s is only usable with %s=%s`,
 			optKeyInValue, optEnvelope, optEnvelopeWrapped)
 	}
+	_, e.beforeField = opts[optDiff]
+	if e.beforeField && !e.wrapped {
+		return nil, errors.Errorf(`%s is only usable with %s=%s`,
+			optDiff, optEnvelope, optEnvelopeWrapped)
+	}
 	return e, nil
 }
 
@@ -122,14 +151,15 @@ func (e *jsonEncoder) EncodeKey(row encodeRow) ([]byte, error) {
 }
 
 func (e *jsonEncoder) encodeKeyRaw(row encodeRow) ([]interface{}, error) {
-	colIdxByID := row.tableDesc.ColumnIdxMap()
-	jsonEntries := make([]interface{}, len(row.tableDesc.PrimaryIndex.ColumnIDs))
-	for i, colID := range row.tableDesc.PrimaryIndex.ColumnIDs {
+	datums, tableDesc := row.keyRow()
+	colIdxByID := tableDesc.ColumnIdxMap()
+	jsonEntries := make([]interface{}, len(tableDesc.PrimaryIndex.ColumnIDs))
+	for i, colID := range tableDesc.PrimaryIndex.ColumnIDs {
 		idx, ok := colIdxByID[colID]
 		if !ok {
 			return nil, errors.Errorf(`unknown column id: %d`, colID)
 		}
-		datum, col := row.datums[idx], &row.tableDesc.Columns[idx]
+		datum, col := datums[idx], &tableDesc.Columns[idx]
 		if err := datum.EnsureDecoded(&col.Type, &e.alloc); err != nil {
 			return nil, err
 		}
@@ -150,19 +180,9 @@ func (e *jsonEncoder) EncodeValue(row encodeRow) ([]byte, error) {
 
 	var after map[string]interface{}
 	if !row.deleted {
-		columns := row.tableDesc.Columns
-		after = make(map[string]interface{}, len(columns))
-		for i := range columns {
-			col := &columns[i]
-			datum := row.datums[i]
-			if err := datum.EnsureDecoded(&col.Type, &e.alloc); err != nil {
-				return nil, err
-			}
-			var err error
-			after[col.Name], err = tree.AsJSON(datum.Datum)
-			if err != nil {
-				return nil, err
-			}
+		var err error
+		if after, err = e.encodeDatums(row.tableDesc, row.datums); err != nil {
+			return nil, err
 		}
 	}
 
@@ -173,6 +193,20 @@ func (e *jsonEncoder) EncodeValue(row encodeRow) ([]byte, error) {
 		} else {
 			jsonEntries = map[string]interface{}{`after`: nil}
 		}
+		if e.beforeField {
+			var before map[string]interface{}
+			if !row.prevDeleted {
+				var err error
+				if before, err = e.encodeDatums(row.tableDesc, row.prevDatums); err != nil {
+					return nil, err
+				}
+			}
+			if before != nil {
+				jsonEntries[`before`] = before
+			} else {
+				jsonEntries[`before`] = nil
+			}
+		}
 		if e.keyInValue {
 			keyEntries, err := e.encodeKeyRaw(row)
 			if err != nil {
@@ -204,6 +238,27 @@ func (e *jsonEncoder) EncodeValue(row encodeRow) ([]byte, error) {
 	return e.buf.Bytes(), nil
 }
 
+// encodeDatums returns a map of every column name to its JSON value.
+func (e *jsonEncoder) encodeDatums(
+	tableDesc *sqlbase.TableDescriptor, datums sqlbase.EncDatumRow,
+) (map[string]interface{}, error) {
+	columns := tableDesc.Columns
+	jsonEntries := make(map[string]interface{}, len(columns))
+	for i := range columns {
+		col := &columns[i]
+		datum := datums[i]
+		if err := datum.EnsureDecoded(&col.Type, &e.alloc); err != nil {
+			return nil, err
+		}
+		var err error
+		jsonEntries[col.Name], err = tree.AsJSON(datum.Datum)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return jsonEntries, nil
+}
+
 // EncodeResolvedTimestamp implements the Encoder interface.
 func (e *jsonEncoder) EncodeResolvedTimestamp(_ string, resolved hlc.Timestamp) ([]byte, error) {
 	meta := map[string]interface{}{
@@ -224,8 +279,8 @@ func (e *jsonEncoder) EncodeResolvedTimestamp(_ string, resolved hlc.Timestamp)
 // JSON format. Keys are the primary key columns in a record. Values are all
 // columns in a record.
 type confluentAvroEncoder struct {
-	registryURL           string
-	updatedField, keyOnly bool
+	registryURL                        string
+	updatedField, beforeField, keyOnly bool
 
 	keyCache      map[tableIDAndVersion]confluentRegisteredKeySchema
 	valueCache    map[tableIDAndVersion]confluentRegisteredEnvelopeSchema
@@ -262,6 +317,11 @@ func newConfluentAvroEncoder(opts map[string]string) (*confluentAvroEncoder, err
 			optEnvelope, opts[optEnvelope], optFormat, optFormatAvro)
 	}
 	_, e.updatedField = opts[optUpdatedTimestamps]
+	_, e.beforeField = opts[optDiff]
+	if e.beforeField && e.keyOnly {
+		return nil, errors.Errorf(`%s is only usable with %s=%s`,
+			optDiff, optEnvelope, optEnvelopeWrapped)
+	}
 
 	if _, ok := opts[optKeyInValue]; ok {
 		return nil, errors.Errorf(`%s is not supported with %s=%s`,
@@ -281,18 +341,19 @@ func newConfluentAvroEncoder(opts map[string]string) (*confluentAvroEncoder, err
 
 // EncodeKey implements the Encoder interface.
 func (e *confluentAvroEncoder) EncodeKey(row encodeRow) ([]byte, error) {
-	cacheKey := makeTableIDAndVersion(row.tableDesc.ID, row.tableDesc.Version)
+	datums, tableDesc := row.keyRow()
+	cacheKey := makeTableIDAndVersion(tableDesc.ID, tableDesc.Version)
 	registered, ok := e.keyCache[cacheKey]
 	if !ok {
 		var err error
-		registered.schema, err = indexToAvroSchema(row.tableDesc, &row.tableDesc.PrimaryIndex)
+		registered.schema, err = indexToAvroSchema(tableDesc, &tableDesc.PrimaryIndex)
 		if err != nil {
 			return nil, err
 		}
 
 		// NB: This uses the kafka name escaper because it has to match the name
 		// of the kafka topic.
-		subject := SQLNameToKafkaName(row.tableDesc.Name) + confluentSubjectSuffixKey
+		subject := SQLNameToKafkaName(tableDesc.Name) + confluentSubjectSuffixKey
 		registered.registryID, err = e.register(&registered.schema.avroRecord, subject)
 		if err != nil {
 			return nil, err
@@ -307,7 +368,7 @@ func (e *confluentAvroEncoder) EncodeKey(row encodeRow) ([]byte, error) {
 		0, 0, 0, 0, // Placeholder for the ID.
 	}
 	binary.BigEndian.PutUint32(header[1:5], uint32(registered.registryID))
-	return registered.schema.BinaryFromRow(header, row.datums)
+	return registered.schema.BinaryFromRow(header, datums)
 }
 
 // EncodeValue implements the Encoder interface.
@@ -319,13 +380,23 @@ func (e *confluentAvroEncoder) EncodeValue(row encodeRow) ([]byte, error) {
 	cacheKey := makeTableIDAndVersion(row.tableDesc.ID, row.tableDesc.Version)
 	registered, ok := e.valueCache[cacheKey]
 	if !ok {
-		afterDataSchema, err := tableToAvroSchema(row.tableDesc)
+		afterDataSchema, err := tableToAvroSchema(row.tableDesc, avroSchemaNoSuffix)
 		if err != nil {
 			return nil, err
 		}
+		var beforeDataSchema *avroDataRecord
+		if e.beforeField {
+			beforeDataSchema, err = tableToAvroSchema(row.tableDesc, `before`)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-		opts := avroEnvelopeOpts{afterField: true, updatedField: e.updatedField}
-		registered.schema, err = envelopeToAvroSchema(row.tableDesc.Name, opts, afterDataSchema)
+		opts := avroEnvelopeOpts{
+			beforeField: e.beforeField, afterField: true, updatedField: e.updatedField,
+		}
+		registered.schema, err = envelopeToAvroSchema(
+			row.tableDesc.Name, opts, beforeDataSchema, afterDataSchema)
 		if err != nil {
 			return nil, err
 		}
@@ -346,9 +417,12 @@ func (e *confluentAvroEncoder) EncodeValue(row encodeRow) ([]byte, error) {
 			`updated`: row.updated,
 		}
 	}
-	var datums sqlbase.EncDatumRow
+	var beforeDatums, afterDatums sqlbase.EncDatumRow
+	if registered.schema.opts.beforeField && !row.prevDeleted {
+		beforeDatums = row.prevDatums
+	}
 	if !row.deleted {
-		datums = row.datums
+		afterDatums = row.datums
 	}
 	// https://docs.confluent.io/current/schema-registry/docs/serializer-formatter.html#wire-format
 	header := []byte{
@@ -356,7 +430,7 @@ func (e *confluentAvroEncoder) EncodeValue(row encodeRow) ([]byte, error) {
 		0, 0, 0, 0, // Placeholder for the ID.
 	}
 	binary.BigEndian.PutUint32(header[1:5], uint32(registered.registryID))
-	return registered.schema.BinaryFromRow(header, meta, datums)
+	return registered.schema.BinaryFromRow(header, meta, beforeDatums, afterDatums)
 }
 
 // EncodeResolvedTimestamp implements the Encoder interface.
@@ -367,7 +441,7 @@ func (e *confluentAvroEncoder) EncodeResolvedTimestamp(
 	if !ok {
 		opts := avroEnvelopeOpts{resolvedField: true}
 		var err error
-		registered.schema, err = envelopeToAvroSchema(topic, opts, nil /* after */)
+		registered.schema, err = envelopeToAvroSchema(topic, opts, nil /* before */, nil /* after */)
 		if err != nil {
 			return nil, err
 		}
@@ -394,7 +468,7 @@ func (e *confluentAvroEncoder) EncodeResolvedTimestamp(
 		0, 0, 0, 0, // Placeholder for the ID.
 	}
 	binary.BigEndian.PutUint32(header[1:5], uint32(registered.registryID))
-	return registered.schema.BinaryFromRow(header, meta, nil /* row */)
+	return registered.schema.BinaryFromRow(header, meta, nil /* beforeRow */, nil /* afterRow */)
 }
 
 func (e *confluentAvroEncoder) register(schema *avroRecord, subject string) (int32, error) {
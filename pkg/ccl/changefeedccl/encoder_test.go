@@ -11,11 +11,7 @@ package changefeedccl
 import (
 	"context"
 	gosql "database/sql"
-	"encoding/binary"
-	gojson "encoding/json"
 	"fmt"
-	"net/http"
-	"net/http/httptest"
 	"testing"
 
 	"github.com/cockroachdb/cockroach-go/crdb"
@@ -26,11 +22,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
-	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/workload"
 	"github.com/cockroachdb/cockroach/pkg/workload/ledger"
-	"github.com/linkedin/goavro"
-	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -55,6 +48,10 @@ func TestEncoders(t *testing.T) {
 				map[string]string{optFormat: f, optEnvelope: e, optUpdatedTimestamps: ``},
 			)
 		}
+		opts = append(opts,
+			map[string]string{optFormat: f, optEnvelope: string(optEnvelopeKeyOnly), optDiff: ``},
+			map[string]string{optFormat: f, optEnvelope: string(optEnvelopeWrapped), optDiff: ``},
+		)
 	}
 
 	expecteds := map[string]struct {
@@ -94,6 +91,14 @@ func TestEncoders(t *testing.T) {
 			delete:   `[1]->{"after": null, "updated": "1.0000000002"}`,
 			resolved: `{"resolved":"1.0000000002"}`,
 		},
+		`format=json,envelope=key_only,diff`: {
+			err: `diff is only usable with envelope=wrapped`,
+		},
+		`format=json,envelope=wrapped,diff`: {
+			insert:   `[1]->{"after": {"a": 1, "b": "bar"}, "before": null}`,
+			delete:   `[1]->{"after": null, "before": {"a": 1, "b": "bar"}}`,
+			resolved: `{"resolved":"1.0000000002"}`,
+		},
 		`format=experimental_avro,envelope=key_only`: {
 			insert:   `{"a":{"long":1}}->`,
 			delete:   `{"a":{"long":1}}->`,
@@ -123,6 +128,16 @@ func TestEncoders(t *testing.T) {
 			delete:   `{"a":{"long":1}}->{"after":null,"updated":{"string":"1.0000000002"}}`,
 			resolved: `{"resolved":{"string":"1.0000000002"}}`,
 		},
+		`format=experimental_avro,envelope=key_only,diff`: {
+			err: `diff is only usable with envelope=wrapped`,
+		},
+		`format=experimental_avro,envelope=wrapped,diff`: {
+			insert: `{"a":{"long":1}}->` +
+				`{"before":null,"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}}}`,
+			delete: `{"a":{"long":1}}->` +
+				`{"before":{"foo_before":{"a":{"long":1},"b":{"string":"bar"}}},"after":null}`,
+			resolved: `{"resolved":{"string":"1.0000000002"}}`,
+		},
 	}
 
 	for _, o := range opts {
@@ -130,6 +145,9 @@ func TestEncoders(t *testing.T) {
 		if _, ok := o[optUpdatedTimestamps]; ok {
 			name += `,updated`
 		}
+		if _, ok := o[optDiff]; ok {
+			name += `,diff`
+		}
 		t.Run(name, func(t *testing.T) {
 			expected := expecteds[name]
 
@@ -140,9 +158,9 @@ func TestEncoders(t *testing.T) {
 				rowStringFn = func(k, v []byte) string { return fmt.Sprintf(`%s->%s`, k, v) }
 				resolvedStringFn = func(r []byte) string { return string(r) }
 			case string(optFormatAvro):
-				reg := makeTestSchemaRegistry()
+				reg := cdctest.MakeTestSchemaRegistry()
 				defer reg.Close()
-				o[optConfluentSchemaRegistry] = reg.server.URL
+				o[optConfluentSchemaRegistry] = reg.URL()
 				rowStringFn = func(k, v []byte) string {
 					key, value := avroToJSON(t, reg, k), avroToJSON(t, reg, v)
 					return fmt.Sprintf(`%s->%s`, key, value)
@@ -162,9 +180,10 @@ func TestEncoders(t *testing.T) {
 			require.NoError(t, err)
 
 			rowInsert := encodeRow{
-				datums:    row,
-				updated:   ts,
-				tableDesc: tableDesc,
+				datums:      row,
+				updated:     ts,
+				tableDesc:   tableDesc,
+				prevDeleted: true,
 			}
 			keyInsert, err := e.EncodeKey(rowInsert)
 			require.NoError(t, err)
@@ -174,10 +193,11 @@ func TestEncoders(t *testing.T) {
 			require.Equal(t, expected.insert, rowStringFn(keyInsert, valueInsert))
 
 			rowDelete := encodeRow{
-				datums:    row,
-				deleted:   true,
-				updated:   ts,
-				tableDesc: tableDesc,
+				datums:     row,
+				deleted:    true,
+				updated:    ts,
+				tableDesc:  tableDesc,
+				prevDatums: row,
 			}
 			keyDelete, err := e.EncodeKey(rowDelete)
 			require.NoError(t, err)
@@ -193,87 +213,12 @@ func TestEncoders(t *testing.T) {
 	}
 }
 
-type testSchemaRegistry struct {
-	server *httptest.Server
-	mu     struct {
-		syncutil.Mutex
-		idAlloc int32
-		schemas map[int32]string
-	}
-}
-
-func makeTestSchemaRegistry() *testSchemaRegistry {
-	r := &testSchemaRegistry{}
-	r.mu.schemas = make(map[int32]string)
-	r.server = httptest.NewServer(http.HandlerFunc(r.Register))
-	return r
-}
-
-func (r *testSchemaRegistry) Close() {
-	r.server.Close()
-}
-
-func (r *testSchemaRegistry) Register(hw http.ResponseWriter, hr *http.Request) {
-	type confluentSchemaVersionRequest struct {
-		Schema string `json:"schema"`
-	}
-	type confluentSchemaVersionResponse struct {
-		ID int32 `json:"id"`
-	}
-	if err := func() error {
-		defer hr.Body.Close()
-		var req confluentSchemaVersionRequest
-		if err := gojson.NewDecoder(hr.Body).Decode(&req); err != nil {
-			return err
-		}
-
-		r.mu.Lock()
-		id := r.mu.idAlloc
-		r.mu.idAlloc++
-		r.mu.schemas[id] = req.Schema
-		r.mu.Unlock()
-
-		res, err := gojson.Marshal(confluentSchemaVersionResponse{ID: id})
-		if err != nil {
-			return err
-		}
-
-		hw.Header().Set(`Content-type`, `application/json`)
-		_, _ = hw.Write(res)
-		return nil
-	}(); err != nil {
-		http.Error(hw, err.Error(), http.StatusInternalServerError)
-	}
-}
-
-func (r *testSchemaRegistry) encodedAvroToNative(b []byte) (interface{}, error) {
-	if len(b) == 0 || b[0] != confluentAvroWireFormatMagic {
-		return ``, errors.Errorf(`bad magic byte`)
-	}
-	b = b[1:]
-	if len(b) < 4 {
-		return ``, errors.Errorf(`missing registry id`)
-	}
-	id := int32(binary.BigEndian.Uint32(b[:4]))
-	b = b[4:]
-
-	r.mu.Lock()
-	jsonSchema := r.mu.schemas[id]
-	r.mu.Unlock()
-	codec, err := goavro.NewCodec(jsonSchema)
-	if err != nil {
-		return ``, err
-	}
-	native, _, err := codec.NativeFromBinary(b)
-	return native, err
-}
-
 func TestAvroEncoder(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
 	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
 		ctx := context.Background()
-		reg := makeTestSchemaRegistry()
+		reg := cdctest.MakeTestSchemaRegistry()
 		defer reg.Close()
 
 		sqlDB := sqlutils.MakeSQLRunner(db)
@@ -285,7 +230,7 @@ func TestAvroEncoder(t *testing.T) {
 
 		foo := feed(t, f, `CREATE CHANGEFEED FOR foo `+
 			`WITH format=$1, confluent_schema_registry=$2, resolved`,
-			optFormatAvro, reg.server.URL)
+			optFormatAvro, reg.URL())
 		defer closeFeed(t, foo)
 		assertPayloadsAvro(t, reg, foo, []string{
 			`foo: {"a":{"long":1}}->{"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}}}`,
@@ -298,7 +243,7 @@ func TestAvroEncoder(t *testing.T) {
 
 		fooUpdated := feed(t, f, `CREATE CHANGEFEED FOR foo `+
 			`WITH format=$1, confluent_schema_registry=$2, updated`,
-			optFormatAvro, reg.server.URL)
+			optFormatAvro, reg.URL())
 		defer closeFeed(t, fooUpdated)
 		// Skip over the first two rows since we don't know the statement timestamp.
 		_, err := fooUpdated.Next()
@@ -323,11 +268,53 @@ func TestAvroEncoder(t *testing.T) {
 	t.Run(`poller`, pollerTest(sinklessTest, testFn))
 }
 
+func TestAvroSchemaChange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		reg := cdctest.MakeTestSchemaRegistry()
+		defer reg.Close()
+
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1)`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo `+
+			`WITH format=$1, confluent_schema_registry=$2`,
+			optFormatAvro, reg.URL())
+		defer closeFeed(t, foo)
+		assertPayloadsAvro(t, reg, foo, []string{
+			`foo: {"a":{"long":1}}->{"after":{"foo":{"a":{"long":1}}}}`,
+		})
+		require.Len(t, reg.Subjects(`foo-key`), 1)
+		require.Len(t, reg.Subjects(`foo-value`), 1)
+
+		// A new table version with the same columns registers the same schemas.
+		sqlDB.Exec(t, `CREATE INDEX ON foo (a)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2)`)
+		assertPayloadsAvro(t, reg, foo, []string{
+			`foo: {"a":{"long":2}}->{"after":{"foo":{"a":{"long":2}}}}`,
+		})
+		require.Len(t, reg.Subjects(`foo-value`), 1)
+
+		sqlDB.Exec(t, `ALTER TABLE foo ADD COLUMN b STRING`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (3, 'c')`)
+		assertPayloadsAvro(t, reg, foo, []string{
+			`foo: {"a":{"long":3}}->{"after":{"foo":{"a":{"long":3},"b":{"string":"c"}}}}`,
+		})
+		require.Len(t, reg.Subjects(`foo-key`), 1)
+		require.Len(t, reg.Subjects(`foo-value`), 2)
+	}
+
+	t.Run(`sinkless`, sinklessTest(testFn))
+	t.Run(`enterprise`, enterpriseTest(testFn))
+}
+
 func TestAvroMigrateToUnsupportedColumn(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
 	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
-		reg := makeTestSchemaRegistry()
+		reg := cdctest.MakeTestSchemaRegistry()
 		defer reg.Close()
 
 		sqlDB := sqlutils.MakeSQLRunner(db)
@@ -336,7 +323,7 @@ func TestAvroMigrateToUnsupportedColumn(t *testing.T) {
 
 		foo := feed(t, f, `CREATE CHANGEFEED FOR foo `+
 			`WITH format=$1, confluent_schema_registry=$2`,
-			optFormatAvro, reg.server.URL)
+			optFormatAvro, reg.URL())
 		defer closeFeed(t, foo)
 		assertPayloadsAvro(t, reg, foo, []string{
 			`foo: {"a":{"long":1}}->{"after":{"foo":{"a":{"long":1}}}}`,
@@ -358,7 +345,7 @@ func TestAvroLedger(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
 	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
-		reg := makeTestSchemaRegistry()
+		reg := cdctest.MakeTestSchemaRegistry()
 		defer reg.Close()
 
 		ctx := context.Background()
@@ -368,7 +355,7 @@ func TestAvroLedger(t *testing.T) {
 
 		ledger := feed(t, f, `CREATE CHANGEFEED FOR customer, transaction, entry, session
 	                       WITH format=$1, confluent_schema_registry=$2
-	               `, optFormatAvro, reg.server.URL)
+	               `, optFormatAvro, reg.URL())
 		defer closeFeed(t, ledger)
 
 		assertPayloadsAvro(t, reg, ledger, []string{
@@ -79,11 +79,11 @@ func assertPayloads(t testing.TB, f cdctest.TestFeed, expected []string) {
 	}
 }
 
-func avroToJSON(t testing.TB, reg *testSchemaRegistry, avroBytes []byte) []byte {
+func avroToJSON(t testing.TB, reg *cdctest.SchemaRegistry, avroBytes []byte) []byte {
 	if len(avroBytes) == 0 {
 		return nil
 	}
-	native, err := reg.encodedAvroToNative(avroBytes)
+	native, err := reg.EncodedAvroToNative(avroBytes)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -98,7 +98,7 @@ func avroToJSON(t testing.TB, reg *testSchemaRegistry, avroBytes []byte) []byte
 }
 
 func assertPayloadsAvro(
-	t testing.TB, reg *testSchemaRegistry, f cdctest.TestFeed, expected []string,
+	t testing.TB, reg *cdctest.SchemaRegistry, f cdctest.TestFeed, expected []string,
 ) {
 	t.Helper()
 
@@ -178,7 +178,7 @@ func expectResolvedTimestamp(t testing.TB, f cdctest.TestFeed) hlc.Timestamp {
 }
 
 func expectResolvedTimestampAvro(
-	t testing.TB, reg *testSchemaRegistry, f cdctest.TestFeed,
+	t testing.TB, reg *cdctest.SchemaRegistry, f cdctest.TestFeed,
 ) hlc.Timestamp {
 	t.Helper()
 	m, err := f.Next()
@@ -194,7 +194,7 @@ func expectResolvedTimestampAvro(
 	if m.Resolved == nil {
 		t.Fatal(`expected a resolved timestamp notification`)
 	}
-	resolvedNative, err := reg.encodedAvroToNative(m.Resolved)
+	resolvedNative, err := reg.EncodedAvroToNative(m.Resolved)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -61,6 +61,17 @@ func (s *metricsSink) EmitResolvedTimestamp(
 	return err
 }
 
+func (s *metricsSink) EmitSchemaChange(
+	ctx context.Context, table *sqlbase.TableDescriptor,
+) error {
+	start := timeutil.Now()
+	err := s.wrapped.EmitSchemaChange(ctx, table)
+	if err == nil {
+		s.metrics.EmitNanos.Inc(timeutil.Since(start).Nanoseconds())
+	}
+	return err
+}
+
 func (s *metricsSink) Flush(ctx context.Context) error {
 	start := timeutil.Now()
 	err := s.wrapped.Flush(ctx)
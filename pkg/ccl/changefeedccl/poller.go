@@ -124,6 +124,14 @@ func makePoller(
 // number are inflight or being inserted into the buffer. Finally, after each
 // poll completes, a resolved timestamp notification is added to the buffer.
 func (p *poller) Run(ctx context.Context) error {
+	if _, ok := p.details.Opts[optInitialScanOnly]; ok {
+		return p.runInitialScanOnly(ctx)
+	}
+	// ExportRequests only return the versions of keys that changed within each
+	// poll, which doesn't include the previous value of the first change.
+	if _, withDiff := p.details.Opts[optDiff]; withDiff {
+		return errors.Errorf(`%s requires the changefeed.push.enabled setting`, optDiff)
+	}
 	for {
 		// Wait for polling interval
 		p.mu.Lock()
@@ -195,6 +203,9 @@ func (p *poller) Run(ctx context.Context) error {
 // the experimental Rangefeed system to capture changes rather than the
 // poll-and-export method.  Note
 func (p *poller) RunUsingRangefeeds(ctx context.Context) error {
+	if _, ok := p.details.Opts[optInitialScanOnly]; ok {
+		return p.runInitialScanOnly(ctx)
+	}
 	// Start polling tablehistory, which must be done concurrently with
 	// the individual rangefeed routines.
 	g := ctxgroup.WithContext(ctx)
@@ -203,6 +214,46 @@ func (p *poller) RunUsingRangefeeds(ctx context.Context) error {
 	return g.Wait()
 }
 
+// runInitialScanOnly is used instead of Run and RunUsingRangefeeds by
+// changefeeds with the `initial_scan_only` option. It performs the initial scan
+// (unless a previous run of the changefeed already finished it) and then blocks
+// until it's cancelled, which happens once the changefeed has seen every
+// watched span resolved at the scan timestamp. No change after the scan
+// timestamp is ever emitted.
+func (p *poller) runInitialScanOnly(ctx context.Context) error {
+	p.mu.Lock()
+	scanTime := p.mu.highWater
+	isFullScan := len(p.mu.scanBoundaries) > 0 && p.mu.scanBoundaries[0].Equal(scanTime)
+	p.mu.scanBoundaries = nil
+	p.mu.Unlock()
+
+	if err := p.updateTableHistory(ctx, scanTime); err != nil {
+		return err
+	}
+	if isFullScan {
+		log.VEventf(ctx, 1, `changefeed initial scan @ %s`, scanTime)
+		spans, err := getSpansToProcess(ctx, p.db, p.spans)
+		if err != nil {
+			return err
+		}
+		if err := p.exportSpansParallel(ctx, spans, scanTime, scanTime, isFullScan); err != nil {
+			return err
+		}
+	} else {
+		// The scan was completed by a previous run of the changefeed, but the
+		// changefeed didn't get to shut down. Resolve the spans again so that it
+		// does.
+		for _, span := range p.spans {
+			if err := p.buf.AddResolved(ctx, span, scanTime); err != nil {
+				return err
+			}
+		}
+	}
+
+	<-ctx.Done()
+	return ctx.Err()
+}
+
 var errBoundaryReached = errors.New("scan boundary reached")
 
 func (p *poller) rangefeedImpl(ctx context.Context) error {
@@ -280,11 +331,12 @@ func (p *poller) rangefeedImpl(ctx context.Context) error {
 		frontier := makeSpanFrontier(spans...)
 
 		rangeFeedStartTS := lastHighwater
+		_, withDiff := p.details.Opts[optDiff]
 		for _, span := range p.spans {
 			span := span
 			frontier.Forward(span, rangeFeedStartTS)
 			g.GoCtx(func(ctx context.Context) error {
-				return ds.RangeFeed(ctx, span, rangeFeedStartTS, eventC)
+				return ds.RangeFeed(ctx, span, rangeFeedStartTS, withDiff, eventC)
 			})
 		}
 		g.GoCtx(func(ctx context.Context) error {
@@ -294,7 +346,7 @@ func (p *poller) rangefeedImpl(ctx context.Context) error {
 					switch t := e.GetValue().(type) {
 					case *roachpb.RangeFeedValue:
 						kv := roachpb.KeyValue{Key: t.Key, Value: t.Value}
-						if err := memBuf.AddKV(ctx, kv, hlc.Timestamp{}); err != nil {
+						if err := memBuf.AddKV(ctx, kv, t.PrevValue, hlc.Timestamp{}); err != nil {
 							return err
 						}
 					case *roachpb.RangeFeedCheckpoint:
@@ -336,7 +388,7 @@ func (p *poller) rangefeedImpl(ctx context.Context) error {
 					if pastBoundary {
 						continue
 					}
-					if err := p.buf.AddKV(ctx, e.kv, e.schemaTimestamp); err != nil {
+					if err := p.buf.AddKV(ctx, e.kv, e.prevVal, e.schemaTimestamp); err != nil {
 						return err
 					}
 				} else if e.resolved != nil {
@@ -562,7 +614,7 @@ func (p *poller) slurpSST(ctx context.Context, sst []byte, schemaTimestamp hlc.T
 	slurpKVs := func() error {
 		sort.Sort(byValueTimestamp(kvs))
 		for _, kv := range kvs {
-			if err := p.buf.AddKV(ctx, kv, schemaTimestamp); err != nil {
+			if err := p.buf.AddKV(ctx, kv, roachpb.Value{}, schemaTimestamp); err != nil {
 				return err
 			}
 		}
@@ -648,11 +700,27 @@ func (p *poller) validateTable(ctx context.Context, desc *sqlbase.TableDescripto
 	if err := validateChangefeedTable(p.details.Targets, desc); err != nil {
 		return err
 	}
+	schemaChanged, err := p.noteTableVersion(ctx, desc)
+	if err != nil || !schemaChanged {
+		return err
+	}
+	// This is called by tableHist before it advances its high-water past the
+	// new version, which holds back every kv that must be read with it, so the
+	// schema change is buffered before any of them.
+	return p.buf.AddSchemaChange(ctx, desc)
+}
+
+// noteTableVersion records the given descriptor as the most recent version of
+// its table and returns whether it's a version that hasn't been seen before.
+func (p *poller) noteTableVersion(
+	ctx context.Context, desc *sqlbase.TableDescriptor,
+) (bool, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	if lastVersion, ok := p.mu.previousTableVersion[desc.ID]; ok {
+	lastVersion, ok := p.mu.previousTableVersion[desc.ID]
+	if ok {
 		if desc.ModificationTime.Less(lastVersion.ModificationTime) {
-			return nil
+			return false, nil
 		}
 		if lastVersion.HasColumnBackfillMutation() && !desc.HasColumnBackfillMutation() {
 			boundaryTime := desc.GetModificationTime()
@@ -660,7 +728,7 @@ func (p *poller) validateTable(ctx context.Context, desc *sqlbase.TableDescripto
 			// interesting here.
 			if p.details.StatementTime.Less(boundaryTime) {
 				if boundaryTime.Less(p.mu.highWater) {
-					return pgerror.AssertionFailedf(
+					return false, pgerror.AssertionFailedf(
 						"error: detected table ID %d backfill completed at %s "+
 							"earlier than highwater timestamp %s",
 						log.Safe(desc.ID),
@@ -680,13 +748,13 @@ func (p *poller) validateTable(ctx context.Context, desc *sqlbase.TableDescripto
 				// return the previous version of the table, which is still technically
 				// allowed by the schema change system.
 				if err := p.leaseMgr.AcquireFreshestFromStore(ctx, desc.ID); err != nil {
-					return err
+					return false, err
 				}
 			}
 		}
 	}
 	p.mu.previousTableVersion[desc.ID] = desc
-	return nil
+	return !ok || lastVersion.Version != desc.Version, nil
 }
 
 func fetchSpansForTargets(
@@ -0,0 +1,294 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/pkg/errors"
+)
+
+// changefeedSelect filters and projects the changed rows of a changefeed
+// created with `CREATE CHANGEFEED ... AS SELECT <projection> FROM <table>
+// [WHERE <filter>]`. The expressions are compiled once for every version of the
+// table's descriptor and are evaluated on every decoded row, before it is
+// encoded. The rows are still keyed by the table's primary key.
+//
+// Only the rows matching the filter are emitted. Without the `diff` option, the
+// previous value of a row is not known, so deletions are always emitted and a
+// row that stops matching the filter is not emitted at all. With it, an update
+// that makes a row stop matching the filter is emitted as a deletion, and the
+// deletion of a row that didn't match is not emitted.
+type changefeedSelect struct {
+	stmt      *tree.SelectClause
+	tableName tree.TableName
+	// compiled maps the versions of the table's descriptor to the expressions
+	// compiled for them. Only the most recently used versions are kept.
+	compiled *cache.UnorderedCache
+}
+
+// compiledSelectCacheSize is the number of versions of the table's descriptor
+// for which a changefeedSelect keeps the compiled expressions. The rows of a
+// changefeed are mostly emitted in timestamp order, so only the latest few
+// versions are needed at any time.
+const compiledSelectCacheSize = 8
+
+// parseChangefeedSelect parses the `Select` field of a ChangefeedDetails.
+func parseChangefeedSelect(sel string) (*changefeedSelect, error) {
+	stmt, err := parser.ParseOne(sel)
+	if err != nil {
+		return nil, err
+	}
+	s := &changefeedSelect{
+		compiled: cache.NewUnorderedCache(cache.Config{
+			Policy: cache.CacheLRU,
+			ShouldEvict: func(size int, _, _ interface{}) bool {
+				return size > compiledSelectCacheSize
+			},
+		}),
+	}
+	if selStmt, ok := stmt.AST.(*tree.Select); ok {
+		s.stmt, _ = selStmt.Select.(*tree.SelectClause)
+	}
+	if s.stmt == nil || s.stmt.From == nil || len(s.stmt.From.Tables) != 1 ||
+		s.stmt.Distinct || s.stmt.GroupBy != nil || s.stmt.Having != nil || s.stmt.Window != nil {
+		return nil, errors.Errorf(`unsupported changefeed select: %s`, sel)
+	}
+	from := s.stmt.From.Tables[0]
+	if aliased, ok := from.(*tree.AliasedTableExpr); ok && aliased.As.Alias == "" {
+		from = aliased.Expr
+	}
+	tn, ok := from.(*tree.TableName)
+	if !ok {
+		return nil, errors.Errorf(`unsupported changefeed select: %s`, sel)
+	}
+	s.tableName = *tn
+	return s, nil
+}
+
+// forTable returns the expressions compiled for the given version of the
+// table's descriptor.
+func (s *changefeedSelect) forTable(desc *sqlbase.TableDescriptor) (*compiledSelect, error) {
+	cacheKey := makeTableIDAndVersion(desc.ID, desc.Version)
+	if c, ok := s.compiled.Get(cacheKey); ok {
+		return c.(*compiledSelect), nil
+	}
+	c, err := s.compile(desc)
+	if err != nil {
+		return nil, err
+	}
+	s.compiled.Add(cacheKey, c)
+	return c, nil
+}
+
+func (s *changefeedSelect) compile(desc *sqlbase.TableDescriptor) (*compiledSelect, error) {
+	c := &compiledSelect{
+		cols: desc.Columns,
+		eval: sqlbase.NewRowExprEvaluator(s.tableName, desc.Columns),
+	}
+
+	if s.stmt.Where != nil {
+		filter, err := c.eval.TypeCheck(s.stmt.Where.Expr, `changefeed filter`, types.Bool)
+		if err != nil {
+			return nil, err
+		}
+		if typ := filter.ResolvedType(); typ.Family() != types.BoolFamily {
+			return nil, errors.Errorf(`argument of WHERE must be type %s, not type %s`,
+				types.Bool, typ)
+		}
+		c.filter = filter
+	}
+
+	var cols []sqlbase.ColumnDescriptor
+	seen := make(map[string]struct{})
+	addCol := func(name string, expr tree.TypedExpr) error {
+		if _, ok := seen[name]; ok {
+			return errors.Errorf(`duplicate column name in changefeed projection: %q`, name)
+		}
+		seen[name] = struct{}{}
+		c.exprs = append(c.exprs, expr)
+		cols = append(cols, sqlbase.ColumnDescriptor{
+			Name:     name,
+			ID:       sqlbase.ColumnID(len(cols) + 1),
+			Type:     *expr.ResolvedType(),
+			Nullable: true,
+		})
+		return nil
+	}
+	for _, target := range s.stmt.Exprs {
+		if err := target.NormalizeTopLevelVarName(); err != nil {
+			return nil, err
+		}
+		switch t := target.Expr.(type) {
+		case tree.UnqualifiedStar, *tree.AllColumnsSelector:
+			if sel, ok := t.(*tree.AllColumnsSelector); ok {
+				if tn := sel.TableName.ToTableName(); tn.TableName != s.tableName.TableName {
+					return nil, errors.Errorf(`no data source matches pattern: %s`, sel)
+				}
+			}
+			if target.As != "" {
+				return nil, errors.Errorf(`"%s" cannot be aliased`, target.Expr)
+			}
+			for i := range c.cols {
+				if c.cols[i].Hidden {
+					continue
+				}
+				if err := addCol(c.cols[i].Name, c.eval.IndexedVar(i)); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		expr, err := c.eval.TypeCheck(target.Expr, `changefeed projection`, types.Any)
+		if err != nil {
+			return nil, err
+		}
+		name, err := tree.GetRenderColName(sqlbase.DefaultSearchPath, target)
+		if err != nil {
+			return nil, err
+		}
+		if err := addCol(name, expr); err != nil {
+			return nil, err
+		}
+	}
+
+	// The projected rows are emitted in place of the table's, so they keep its
+	// identity. The primary index is the table's too, since the rows are still
+	// keyed by it.
+	c.desc = &sqlbase.TableDescriptor{
+		Name:             desc.Name,
+		ID:               desc.ID,
+		ParentID:         desc.ParentID,
+		Version:          desc.Version,
+		ModificationTime: desc.ModificationTime,
+		Columns:          cols,
+		PrimaryIndex:     desc.PrimaryIndex,
+	}
+	return c, nil
+}
+
+// apply filters and projects a changed row in place. It returns false if the
+// row should not be emitted. withDiff is whether the previous value of the row
+// is known (see the `diff` option).
+func (s *changefeedSelect) apply(row *encodeRow, withDiff bool) (bool, error) {
+	c, err := s.forTable(row.tableDesc)
+	if err != nil {
+		return false, err
+	}
+
+	prevMatches := false
+	var prevDatums sqlbase.EncDatumRow
+	if withDiff && !row.prevDeleted {
+		if prevMatches, err = c.matches(row.prevDatums); err != nil {
+			return false, err
+		}
+		if prevMatches {
+			if prevDatums, err = c.project(row.prevDatums); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	matches := false
+	if !row.deleted {
+		if matches, err = c.matches(row.datums); err != nil {
+			return false, err
+		}
+	}
+
+	var datums sqlbase.EncDatumRow
+	switch {
+	case matches:
+		if datums, err = c.project(row.datums); err != nil {
+			return false, err
+		}
+	case withDiff:
+		// The row left the set of matching rows, or wasn't part of it.
+		if !prevMatches {
+			return false, nil
+		}
+		row.deleted = true
+	case !row.deleted:
+		return false, nil
+	}
+	if row.deleted {
+		// Only the primary key of a deleted row is known, and it's not part of
+		// the projection.
+		datums = make(sqlbase.EncDatumRow, len(c.exprs))
+		for i := range datums {
+			datums[i] = sqlbase.DatumToEncDatum(&c.desc.Columns[i].Type, tree.DNull)
+		}
+	}
+
+	row.keyDatums, row.keyDesc = row.datums, row.tableDesc
+	row.datums, row.tableDesc = datums, c.desc
+	row.prevDatums, row.prevDeleted = prevDatums, !prevMatches
+	return true, nil
+}
+
+// compiledSelect is a changefeedSelect compiled for one version of the table's
+// descriptor.
+type compiledSelect struct {
+	// desc describes the projected columns.
+	desc *sqlbase.TableDescriptor
+	// filter is nil if the changefeed has no WHERE clause.
+	filter tree.TypedExpr
+	// exprs parallels the columns of desc.
+	exprs []tree.TypedExpr
+
+	cols  []sqlbase.ColumnDescriptor
+	eval  *sqlbase.RowExprEvaluator
+	alloc sqlbase.DatumAlloc
+}
+
+// loadRow decodes a row of the table into the inputs of the expressions.
+func (c *compiledSelect) loadRow(row sqlbase.EncDatumRow) error {
+	curSourceRow := c.eval.Row()
+	for i := range c.cols {
+		if err := row[i].EnsureDecoded(&c.cols[i].Type, &c.alloc); err != nil {
+			return err
+		}
+		curSourceRow[i] = row[i].Datum
+	}
+	return nil
+}
+
+// matches returns whether the row satisfies the filter.
+func (c *compiledSelect) matches(row sqlbase.EncDatumRow) (bool, error) {
+	if c.filter == nil {
+		return true, nil
+	}
+	if err := c.loadRow(row); err != nil {
+		return false, err
+	}
+	d, err := c.eval.Eval(c.filter)
+	if err != nil {
+		return false, err
+	}
+	return d == tree.DBoolTrue, nil
+}
+
+// project returns the projected columns of the row.
+func (c *compiledSelect) project(row sqlbase.EncDatumRow) (sqlbase.EncDatumRow, error) {
+	if err := c.loadRow(row); err != nil {
+		return nil, err
+	}
+	projected := make(sqlbase.EncDatumRow, len(c.exprs))
+	for i, expr := range c.exprs {
+		d, err := c.eval.Eval(expr)
+		if err != nil {
+			return nil, err
+		}
+		projected[i] = sqlbase.DatumToEncDatum(&c.desc.Columns[i].Type, d)
+	}
+	return projected, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -28,14 +29,19 @@ import (
 type rowFetcherCache struct {
 	leaseMgr *sql.LeaseManager
 	fetchers map[*sqlbase.ImmutableTableDescriptor]*row.Fetcher
+	// virtualCols contains the helpers that compute the virtual computed
+	// columns of the tables that have some.
+	virtualCols map[*sqlbase.ImmutableTableDescriptor]*sqlbase.VirtualColumnHelper
 
-	a sqlbase.DatumAlloc
+	a      sqlbase.DatumAlloc
+	datums tree.Datums
 }
 
 func newRowFetcherCache(leaseMgr *sql.LeaseManager) *rowFetcherCache {
 	return &rowFetcherCache{
-		leaseMgr: leaseMgr,
-		fetchers: make(map[*sqlbase.ImmutableTableDescriptor]*row.Fetcher),
+		leaseMgr:    leaseMgr,
+		fetchers:    make(map[*sqlbase.ImmutableTableDescriptor]*row.Fetcher),
+		virtualCols: make(map[*sqlbase.ImmutableTableDescriptor]*sqlbase.VirtualColumnHelper),
 	}
 }
 
@@ -96,9 +102,28 @@ func (c *rowFetcherCache) RowFetcherForTableDesc(
 		valNeededForCol.Add(colIdx)
 	}
 
+	// The virtual computed columns are not stored in the primary index, so
+	// they are computed by ComputeVirtualColumns instead.
+	virtualCols, err := sqlbase.NewVirtualColumnHelper(tableDesc.TableDesc(), tableDesc.Columns)
+	if err != nil {
+		return nil, err
+	}
+	if virtualCols != nil {
+		for colIdx := range tableDesc.Columns {
+			if tableDesc.Columns[colIdx].Virtual {
+				valNeededForCol.Remove(colIdx)
+			}
+		}
+	}
+
 	var rf row.Fetcher
 	if err := rf.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &c.a,
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		&c.a,
 		row.FetcherTableArgs{
 			Spans:            tableDesc.AllIndexSpans(),
 			Desc:             tableDesc,
@@ -115,5 +140,35 @@ func (c *rowFetcherCache) RowFetcherForTableDesc(
 	// us evict anything for timestamps entirely before the notification. Then
 	// probably an LRU just in case?
 	c.fetchers[tableDesc] = &rf
+	if virtualCols != nil {
+		c.virtualCols[tableDesc] = virtualCols
+	}
 	return &rf, nil
 }
+
+// ComputeVirtualColumns sets the values of the virtual computed columns in a
+// row of the given table returned by the Fetcher from RowFetcherForTableDesc.
+func (c *rowFetcherCache) ComputeVirtualColumns(
+	tableDesc *sqlbase.ImmutableTableDescriptor, row sqlbase.EncDatumRow,
+) error {
+	virtualCols, ok := c.virtualCols[tableDesc]
+	if !ok {
+		return nil
+	}
+	c.datums = c.datums[:0]
+	for i := range row {
+		if err := row[i].EnsureDecoded(&tableDesc.Columns[i].Type, &c.a); err != nil {
+			return err
+		}
+		c.datums = append(c.datums, row[i].Datum)
+	}
+	if err := virtualCols.ComputeRow(c.datums); err != nil {
+		return err
+	}
+	for i := range tableDesc.Columns {
+		if tableDesc.Columns[i].Virtual {
+			row[i] = sqlbase.DatumToEncDatum(&tableDesc.Columns[i].Type, c.datums[i])
+		}
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"crypto/x509"
 	gosql "database/sql"
 	"encoding/base64"
+	gojson "encoding/json"
 	"fmt"
 	"hash"
 	"hash/fnv"
@@ -55,6 +56,11 @@ type Sink interface {
 	// asynchronous delivery on every topic that has been seen by EmitRow. An
 	// error may be returned if a previously enqueued message has failed.
 	EmitResolvedTimestamp(ctx context.Context, encoder Encoder, resolved hlc.Timestamp) error
+	// EmitSchemaChange enqueues a message announcing a new version of a watched
+	// table's descriptor for asynchronous delivery. It's only called by the
+	// changeFrontier, once for each version, before the resolved timestamp
+	// passes it. Sinks that have nowhere to publish schema changes ignore it.
+	EmitSchemaChange(ctx context.Context, table *sqlbase.TableDescriptor) error
 	// Flush blocks until every message enqueued by EmitRow and
 	// EmitResolvedTimestamp has been acknowledged by the sink. If an error is
 	// returned, no guarantees are given about which messages have been
@@ -87,9 +93,7 @@ func getSink(
 		var cfg kafkaSinkConfig
 		cfg.kafkaTopicPrefix = q.Get(sinkParamTopicPrefix)
 		q.Del(sinkParamTopicPrefix)
-		if schemaTopic := q.Get(sinkParamSchemaTopic); schemaTopic != `` {
-			return nil, errors.Errorf(`%s is not yet supported`, sinkParamSchemaTopic)
-		}
+		cfg.schemaTopic = q.Get(sinkParamSchemaTopic)
 		q.Del(sinkParamSchemaTopic)
 		if tlsBool := q.Get(sinkParamTLSEnabled); tlsBool != `` {
 			var err error
@@ -173,6 +177,53 @@ func getSink(
 		makeSink = func() (Sink, error) {
 			return makeCloudStorageSink(u.String(), nodeID, fileSize, settings, opts)
 		}
+	case u.Scheme == sinkSchemeWebhookHTTPS:
+		cfg := webhookSinkConfig{
+			maxBatchSize: defaultWebhookMaxBatchSize,
+			maxRetries:   defaultWebhookMaxRetries,
+		}
+		for _, p := range []struct {
+			param string
+			dest  *[]byte
+		}{
+			{sinkParamCACert, &cfg.caCert},
+			{sinkParamClientCert, &cfg.clientCert},
+			{sinkParamClientKey, &cfg.clientKey},
+		} {
+			if v := q.Get(p.param); v != `` {
+				if *p.dest, err = base64.StdEncoding.DecodeString(v); err != nil {
+					return nil, errors.Errorf(`param %s must be base 64 encoded: %s`, p.param, err)
+				}
+			}
+			q.Del(p.param)
+		}
+		if v := q.Get(sinkParamMaxBatchSize); v != `` {
+			if cfg.maxBatchSize, err = strconv.Atoi(v); err != nil || cfg.maxBatchSize <= 0 {
+				return nil, errors.Errorf(`param %s must be a positive integer: %s`, sinkParamMaxBatchSize, v)
+			}
+		}
+		q.Del(sinkParamMaxBatchSize)
+		if v := q.Get(sinkParamMaxRetries); v != `` {
+			if cfg.maxRetries, err = strconv.Atoi(v); err != nil || cfg.maxRetries < 0 {
+				return nil, errors.Errorf(`param %s must be a non-negative integer: %s`, sinkParamMaxRetries, v)
+			}
+		}
+		q.Del(sinkParamMaxRetries)
+		if v := q.Get(sinkParamFlushInterval); v != `` {
+			if cfg.flushInterval, err = time.ParseDuration(v); err != nil || cfg.flushInterval < 0 {
+				return nil, errors.Errorf(`param %s must be a non-negative duration: %s`, sinkParamFlushInterval, v)
+			}
+		}
+		q.Del(sinkParamFlushInterval)
+
+		// Swap the changefeed prefix for the one the http client expects. Every
+		// query parameter is consumed by the sink, so none are forwarded to the
+		// webhook.
+		u.Scheme = `https`
+		u.RawQuery = ``
+		makeSink = func() (Sink, error) {
+			return makeWebhookSink(cfg, u, targets, opts)
+		}
 	case u.Scheme == sinkSchemeExperimentalSQL:
 		// Swap the changefeed prefix for the sql connection one that sqlSink
 		// expects.
@@ -180,8 +231,12 @@ func getSink(
 		// TODO(dan): Make tableName configurable or based on the job ID or
 		// something.
 		tableName := `sqlsink`
+		schemaTopic := q.Get(sinkParamSchemaTopic)
+		q.Del(sinkParamSchemaTopic)
+		// The schema topic is not a parameter of the sql connection.
+		u.RawQuery = q.Encode()
 		makeSink = func() (Sink, error) {
-			return makeSQLSink(u.String(), tableName, targets)
+			return makeSQLSink(u.String(), tableName, schemaTopic, targets)
 		}
 		// Remove parameters we know about for the unknown parameter check.
 		q.Del(`sslcert`)
@@ -229,6 +284,15 @@ func (s errorWrapperSink) EmitResolvedTimestamp(
 	return nil
 }
 
+func (s errorWrapperSink) EmitSchemaChange(
+	ctx context.Context, table *sqlbase.TableDescriptor,
+) error {
+	if err := s.wrapped.EmitSchemaChange(ctx, table); err != nil {
+		return MarkRetryableError(err)
+	}
+	return nil
+}
+
 func (s errorWrapperSink) Flush(ctx context.Context) error {
 	if err := s.wrapped.Flush(ctx); err != nil {
 		return MarkRetryableError(err)
@@ -269,6 +333,7 @@ func init() {
 
 type kafkaSinkConfig struct {
 	kafkaTopicPrefix string
+	schemaTopic      string
 	tlsEnabled       bool
 	caCert           []byte
 	saslEnabled      bool
@@ -479,6 +544,58 @@ func (s *kafkaSink) EmitResolvedTimestamp(
 	return nil
 }
 
+// EmitSchemaChange implements the Sink interface. If a schema topic was
+// configured, the new table descriptor is published to it, keyed by table name
+// so that the versions of each table are kept in order.
+func (s *kafkaSink) EmitSchemaChange(ctx context.Context, table *sqlbase.TableDescriptor) error {
+	if s.cfg.schemaTopic == `` {
+		return nil
+	}
+	payload, err := encodeSchemaChange(table)
+	if err != nil {
+		return err
+	}
+	msg := &sarama.ProducerMessage{
+		Topic: s.cfg.schemaTopic,
+		Key:   sarama.StringEncoder(table.Name),
+		Value: sarama.ByteEncoder(payload),
+	}
+	return s.emitMessage(ctx, msg)
+}
+
+// schemaChangeColumn describes one column in a schema change message.
+type schemaChangeColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// schemaChangeMessage is the JSON payload of a schema change message. Columns
+// are in the same order as the values in the changefeed's row messages.
+type schemaChangeMessage struct {
+	Table      string               `json:"table"`
+	TableID    sqlbase.ID           `json:"table_id"`
+	Version    uint32               `json:"version"`
+	Updated    string               `json:"updated"`
+	Columns    []schemaChangeColumn `json:"columns"`
+	PrimaryKey []string             `json:"primary_key"`
+}
+
+func encodeSchemaChange(table *sqlbase.TableDescriptor) ([]byte, error) {
+	msg := schemaChangeMessage{
+		Table:      table.Name,
+		TableID:    table.ID,
+		Version:    uint32(table.Version),
+		Updated:    table.ModificationTime.AsOfSystemTime(),
+		Columns:    make([]schemaChangeColumn, len(table.Columns)),
+		PrimaryKey: table.PrimaryIndex.ColumnNames,
+	}
+	for i := range table.Columns {
+		col := &table.Columns[i]
+		msg.Columns[i] = schemaChangeColumn{Name: col.Name, Type: col.Type.SQLString()}
+	}
+	return gojson.Marshal(msg)
+}
+
 // Flush implements the Sink interface.
 func (s *kafkaSink) Flush(ctx context.Context) error {
 	flushCh := make(chan struct{}, 1)
@@ -601,22 +718,25 @@ const (
 // but writes to a SQL table (presumably in CockroachDB). Currently only for
 // testing.
 //
-// Each emitted row or resolved timestamp is stored as a row in the table. Each
-// table gets 3 partitions. Similar to kafkaSink, the order between two emits is
-// only preserved if they are emitted to by the same node and to the same
-// partition.
+// Each emitted row, resolved timestamp or schema change is stored as a row in
+// the table. Each table gets 3 partitions. Similar to kafkaSink, the order
+// between two emits is only preserved if they are emitted to by the same node
+// and to the same partition.
 type sqlSink struct {
 	db *gosql.DB
 
-	tableName string
-	topics    map[string]struct{}
-	hasher    hash.Hash32
+	tableName   string
+	schemaTopic string
+	topics      map[string]struct{}
+	hasher      hash.Hash32
 
 	rowBuf  []interface{}
 	scratch bufalloc.ByteAllocator
 }
 
-func makeSQLSink(uri, tableName string, targets jobspb.ChangefeedTargets) (*sqlSink, error) {
+func makeSQLSink(
+	uri, tableName, schemaTopic string, targets jobspb.ChangefeedTargets,
+) (*sqlSink, error) {
 	if u, err := url.Parse(uri); err != nil {
 		return nil, err
 	} else if u.Path == `` {
@@ -632,10 +752,11 @@ func makeSQLSink(uri, tableName string, targets jobspb.ChangefeedTargets) (*sqlS
 	}
 
 	s := &sqlSink{
-		db:        db,
-		tableName: tableName,
-		topics:    make(map[string]struct{}),
-		hasher:    fnv.New32a(),
+		db:          db,
+		tableName:   tableName,
+		schemaTopic: schemaTopic,
+		topics:      make(map[string]struct{}),
+		hasher:      fnv.New32a(),
 	}
 	for _, t := range targets {
 		s.topics[t.StatementTimeName] = struct{}{}
@@ -652,18 +773,27 @@ func (s *sqlSink) EmitRow(
 		return errors.Errorf(`cannot emit to undeclared topic: %s`, topic)
 	}
 
+	partition, err := s.partition(key)
+	if err != nil {
+		return err
+	}
+	var noResolved []byte
+	return s.emit(ctx, topic, partition, key, value, noResolved)
+}
+
+// partition returns the partition that a message with the given key is
+// emitted to.
+func (s *sqlSink) partition(key []byte) (int32, error) {
 	// Hashing logic copied from sarama.HashPartitioner.
 	s.hasher.Reset()
 	if _, err := s.hasher.Write(key); err != nil {
-		return err
+		return 0, err
 	}
 	partition := int32(s.hasher.Sum32()) % sqlSinkNumPartitions
 	if partition < 0 {
 		partition = -partition
 	}
-
-	var noResolved []byte
-	return s.emit(ctx, topic, partition, key, value, noResolved)
+	return partition, nil
 }
 
 // EmitResolvedTimestamp implements the Sink interface.
@@ -700,6 +830,26 @@ func (s *sqlSink) emit(
 	return nil
 }
 
+// EmitSchemaChange implements the Sink interface. If a schema topic was
+// configured, the new table descriptor is stored in it, keyed by table name
+// like kafkaSink does.
+func (s *sqlSink) EmitSchemaChange(ctx context.Context, table *sqlbase.TableDescriptor) error {
+	if s.schemaTopic == `` {
+		return nil
+	}
+	payload, err := encodeSchemaChange(table)
+	if err != nil {
+		return err
+	}
+	key := []byte(table.Name)
+	partition, err := s.partition(key)
+	if err != nil {
+		return err
+	}
+	var noResolved []byte
+	return s.emit(ctx, s.schemaTopic, partition, key, payload, noResolved)
+}
+
 // Flush implements the Sink interface.
 func (s *sqlSink) Flush(ctx context.Context) error {
 	if len(s.rowBuf) == 0 {
@@ -796,6 +946,11 @@ func (s *bufferSink) EmitResolvedTimestamp(
 	return nil
 }
 
+// EmitSchemaChange implements the Sink interface.
+func (s *bufferSink) EmitSchemaChange(context.Context, *sqlbase.TableDescriptor) error {
+	return nil
+}
+
 // Flush implements the Sink interface.
 func (s *bufferSink) Flush(_ context.Context) error {
 	return nil
@@ -231,6 +231,11 @@ func (s *cloudStorageSink) EmitResolvedTimestamp(
 	return s.es.WriteFile(ctx, filepath.Join(part, filename), bytes.NewReader(payload))
 }
 
+// EmitSchemaChange implements the Sink interface.
+func (s *cloudStorageSink) EmitSchemaChange(context.Context, *sqlbase.TableDescriptor) error {
+	return nil
+}
+
 // Flush implements the Sink interface.
 func (s *cloudStorageSink) Flush(ctx context.Context) error {
 	if s.files == nil {
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
@@ -137,6 +138,59 @@ func TestKafkaSink(t *testing.T) {
 	}
 }
 
+func TestKafkaSinkSchemaTopic(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	p := asyncProducerMock{
+		inputCh:     make(chan *sarama.ProducerMessage, 1),
+		successesCh: make(chan *sarama.ProducerMessage, 1),
+		errorsCh:    make(chan *sarama.ProducerError, 1),
+	}
+	sink := &kafkaSink{
+		cfg:      kafkaSinkConfig{schemaTopic: `schemas`},
+		producer: p,
+		topics:   map[string]struct{}{`t`: {}},
+	}
+	sink.start()
+	defer func() { require.NoError(t, sink.Close()) }()
+
+	table := &sqlbase.TableDescriptor{
+		Name:    `t`,
+		ID:      52,
+		Version: 3,
+		Columns: []sqlbase.ColumnDescriptor{
+			{Name: `a`, Type: *types.Int},
+			{Name: `b`, Type: *types.String},
+		},
+		PrimaryIndex:     sqlbase.IndexDescriptor{ColumnNames: []string{`a`}},
+		ModificationTime: hlc.Timestamp{WallTime: 1, Logical: 2},
+	}
+	require.NoError(t, sink.EmitSchemaChange(ctx, table))
+	m := <-p.inputCh
+	require.Equal(t, `schemas`, m.Topic)
+	key, err := m.Key.Encode()
+	require.NoError(t, err)
+	require.Equal(t, `t`, string(key))
+	value, err := m.Value.Encode()
+	require.NoError(t, err)
+	require.Equal(t, `{"table":"t","table_id":52,"version":3,"updated":"1.0000000002",`+
+		`"columns":[{"name":"a","type":"INT8"},{"name":"b","type":"STRING"}],`+
+		`"primary_key":["a"]}`, string(value))
+	go func() { p.successesCh <- m }()
+	require.NoError(t, sink.Flush(ctx))
+
+	// Without a schema topic, schema changes aren't published.
+	sink.cfg.schemaTopic = ``
+	require.NoError(t, sink.EmitSchemaChange(ctx, table))
+	select {
+	case m := <-p.inputCh:
+		t.Fatalf(`unexpected message: %v`, m)
+	default:
+	}
+	require.NoError(t, sink.Flush(ctx))
+}
+
 func TestKafkaSinkEscaping(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -194,7 +248,7 @@ func TestSQLSink(t *testing.T) {
 		0: jobspb.ChangefeedTarget{StatementTimeName: `foo`},
 		1: jobspb.ChangefeedTarget{StatementTimeName: `bar`},
 	}
-	sink, err := makeSQLSink(sinkURL.String(), `sink`, targets)
+	sink, err := makeSQLSink(sinkURL.String(), `sink`, `` /* schemaTopic */, targets)
 	require.NoError(t, err)
 	defer func() { require.NoError(t, sink.Close()) }()
 
@@ -0,0 +1,247 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	gojson "encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultWebhookMaxBatchSize = 1000
+	defaultWebhookMaxRetries   = 3
+)
+
+type webhookSinkConfig struct {
+	caCert, clientCert, clientKey []byte
+	// maxBatchSize is the number of buffered messages that triggers a request
+	// to the webhook.
+	maxBatchSize int
+	// flushInterval, if non-zero, triggers a request to the webhook when a
+	// message is emitted and the previous request was at least this long ago.
+	// The sink has no background goroutine, so this is only checked on emit.
+	flushInterval time.Duration
+	// maxRetries is the number of times a failed request is retried (with
+	// exponential backoff) before the error is returned.
+	maxRetries int
+}
+
+// webhookMessage is a single row or resolved timestamp in a webhook request.
+// Key and Value are the (JSON) output of the changefeed's encoder. Resolved
+// timestamp messages have no Key.
+type webhookMessage struct {
+	Topic string            `json:"topic"`
+	Key   gojson.RawMessage `json:"key,omitempty"`
+	Value gojson.RawMessage `json:"value,omitempty"`
+}
+
+// webhookPayload is the body of each request sent to the webhook.
+type webhookPayload struct {
+	Payload []webhookMessage `json:"payload"`
+	Length  int              `json:"length"`
+}
+
+// webhookSink emits batches of messages to an HTTPS endpoint as JSON POST
+// requests. A batch is sent once it reaches the configured size, once the
+// flush interval has elapsed, whenever a resolved timestamp is emitted, and on
+// every Flush. Each request blocks until the webhook responds with a 2xx
+// status, so Flush returning nil means every message has been acknowledged.
+// Failed requests are retried, but once the retries are exhausted the error is
+// returned and the changefeed restarts from its last checkpoint, which means
+// the webhook may receive the same message more than once.
+//
+// It is not concurrency-safe; all calls to Emit and Flush should be from the
+// same goroutine.
+type webhookSink struct {
+	cfg       webhookSinkConfig
+	url       string
+	client    *http.Client
+	transport *http.Transport
+	topics    map[string]struct{}
+
+	batch     []webhookMessage
+	lastFlush time.Time
+}
+
+func makeWebhookSink(
+	cfg webhookSinkConfig, u *url.URL, targets jobspb.ChangefeedTargets, opts map[string]string,
+) (Sink, error) {
+	switch formatType(opts[optFormat]) {
+	case optFormatJSON:
+	default:
+		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
+			optFormat, opts[optFormat])
+	}
+
+	tlsConfig := &tls.Config{}
+	if cfg.caCert != nil {
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(cfg.caCert) {
+			return nil, errors.Errorf(`param %s does not contain a PEM certificate`, sinkParamCACert)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+	if cfg.clientCert != nil || cfg.clientKey != nil {
+		if cfg.clientCert == nil {
+			return nil, errors.Errorf(`%s requires %s`, sinkParamClientKey, sinkParamClientCert)
+		}
+		if cfg.clientKey == nil {
+			return nil, errors.Errorf(`%s requires %s`, sinkParamClientCert, sinkParamClientKey)
+		}
+		cert, err := tls.X509KeyPair(cfg.clientCert, cfg.clientKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, `invalid %s or %s`, sinkParamClientCert, sinkParamClientKey)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	sink := &webhookSink{
+		cfg:       cfg,
+		url:       u.String(),
+		transport: &http.Transport{TLSClientConfig: tlsConfig},
+		topics:    make(map[string]struct{}),
+		lastFlush: timeutil.Now(),
+	}
+	sink.client = &http.Client{Transport: sink.transport}
+	for _, t := range targets {
+		sink.topics[t.StatementTimeName] = struct{}{}
+	}
+	return sink, nil
+}
+
+// EmitRow implements the Sink interface.
+func (s *webhookSink) EmitRow(
+	ctx context.Context, table *sqlbase.TableDescriptor, key, value []byte, _ hlc.Timestamp,
+) error {
+	topic := table.Name
+	if _, ok := s.topics[topic]; !ok {
+		return errors.Errorf(`cannot emit to undeclared topic: %s`, topic)
+	}
+
+	// The encoder's buffers are only valid until its next call, so copy them.
+	s.batch = append(s.batch, webhookMessage{
+		Topic: topic,
+		Key:   append(gojson.RawMessage(nil), key...),
+		Value: append(gojson.RawMessage(nil), value...),
+	})
+	if len(s.batch) >= s.cfg.maxBatchSize ||
+		(s.cfg.flushInterval > 0 && timeutil.Since(s.lastFlush) >= s.cfg.flushInterval) {
+		return s.sendBatch(ctx)
+	}
+	return nil
+}
+
+// EmitResolvedTimestamp implements the Sink interface.
+func (s *webhookSink) EmitResolvedTimestamp(
+	ctx context.Context, encoder Encoder, resolved hlc.Timestamp,
+) error {
+	for topic := range s.topics {
+		payload, err := encoder.EncodeResolvedTimestamp(topic, resolved)
+		if err != nil {
+			return err
+		}
+		s.batch = append(s.batch, webhookMessage{
+			Topic: topic,
+			Value: append(gojson.RawMessage(nil), payload...),
+		})
+	}
+	// Messages are delivered in order, so sending the resolved timestamps along
+	// with whatever rows are buffered maintains the guarantee that every row
+	// with a lower timestamp has already been delivered.
+	return s.sendBatch(ctx)
+}
+
+// EmitSchemaChange implements the Sink interface.
+func (s *webhookSink) EmitSchemaChange(context.Context, *sqlbase.TableDescriptor) error {
+	return nil
+}
+
+// Flush implements the Sink interface.
+func (s *webhookSink) Flush(ctx context.Context) error {
+	return s.sendBatch(ctx)
+}
+
+// Close implements the Sink interface.
+func (s *webhookSink) Close() error {
+	s.transport.CloseIdleConnections()
+	return nil
+}
+
+// sendBatch sends every buffered message to the webhook in one request,
+// retrying on failure. The buffered messages are only discarded once the
+// webhook has acknowledged them.
+func (s *webhookSink) sendBatch(ctx context.Context) error {
+	if len(s.batch) == 0 {
+		return nil
+	}
+	body, err := gojson.Marshal(webhookPayload{Payload: s.batch, Length: len(s.batch)})
+	if err != nil {
+		return err
+	}
+
+	// NB: MaxRetries is not set in the options because zero means unlimited
+	// there, but it means no retries for this sink.
+	attempt := 0
+	for r := retry.StartWithCtx(ctx, retry.Options{}); r.Next(); attempt++ {
+		if err = s.post(ctx, body); err == nil {
+			s.batch = s.batch[:0]
+			s.lastFlush = timeutil.Now()
+			return nil
+		}
+		if log.V(1) {
+			log.Infof(ctx, "webhook request with %d messages failed: %v", len(s.batch), err)
+		}
+		if attempt >= s.cfg.maxRetries {
+			break
+		}
+	}
+	if err == nil {
+		// The retry loop exited before the first attempt.
+		err = ctx.Err()
+	}
+	return errors.Wrapf(err, `sending %d messages to webhook`, len(s.batch))
+}
+
+func (s *webhookSink) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set(`Content-Type`, `application/json`)
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	// Drain the body so the connection can be reused.
+	if _, err := io.Copy(ioutil.Discard, res.Body); err != nil {
+		return err
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.Errorf(`webhook responded with %s`, res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/base64"
+	gojson "encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/stretchr/testify/require"
+)
+
+// testWebhookServer is an httptest TLS server that records the messages in
+// every request it acknowledges. The next `failures` requests are rejected.
+type testWebhookServer struct {
+	server *httptest.Server
+	mu     struct {
+		syncutil.Mutex
+		failures int
+		requests [][]string
+	}
+}
+
+func makeTestWebhookServer() *testWebhookServer {
+	s := &testWebhookServer{}
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *testWebhookServer) handle(hw http.ResponseWriter, hr *http.Request) {
+	defer hr.Body.Close()
+	var payload webhookPayload
+	if err := gojson.NewDecoder(hr.Body).Decode(&payload); err != nil {
+		http.Error(hw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if payload.Length != len(payload.Payload) {
+		http.Error(hw, `bad length`, http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mu.failures > 0 {
+		s.mu.failures--
+		http.Error(hw, `injected failure`, http.StatusServiceUnavailable)
+		return
+	}
+	var msgs []string
+	for _, m := range payload.Payload {
+		msgs = append(msgs, fmt.Sprintf(`%s: %s->%s`, m.Topic, m.Key, m.Value))
+	}
+	s.mu.requests = append(s.mu.requests, msgs)
+}
+
+// sinkURI returns a webhook sink URI that trusts the server's certificate.
+func (s *testWebhookServer) sinkURI(params ...string) string {
+	caCert := pem.EncodeToMemory(&pem.Block{Type: `CERTIFICATE`, Bytes: s.server.Certificate().Raw})
+	u, err := url.Parse(s.server.URL)
+	if err != nil {
+		panic(err)
+	}
+	u.Scheme = sinkSchemeWebhookHTTPS
+	u.RawQuery = sinkParamCACert + `=` + url.QueryEscape(base64.StdEncoding.EncodeToString(caCert))
+	if len(params) > 0 {
+		u.RawQuery += `&` + strings.Join(params, `&`)
+	}
+	return u.String()
+}
+
+func (s *testWebhookServer) injectFailures(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.failures = n
+}
+
+// popRequests returns the messages in every acknowledged request since the
+// last call.
+func (s *testWebhookServer) popRequests() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := s.mu.requests
+	s.mu.requests = nil
+	return requests
+}
+
+func TestWebhookSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	server := makeTestWebhookServer()
+	defer server.server.Close()
+
+	settings := cluster.MakeTestingClusterSettings()
+	opts := map[string]string{
+		optFormat:   string(optFormatJSON),
+		optEnvelope: string(optEnvelopeWrapped),
+	}
+	targets := jobspb.ChangefeedTargets{
+		0: jobspb.ChangefeedTarget{StatementTimeName: `t1`},
+	}
+	t1 := &sqlbase.TableDescriptor{Name: `t1`}
+	ts := hlc.Timestamp{WallTime: 1}
+	e, err := makeJSONEncoder(opts)
+	require.NoError(t, err)
+
+	makeSink := func(t *testing.T, params ...string) Sink {
+		s, err := getSink(server.sinkURI(params...), 1 /* nodeID */, opts, targets, settings)
+		require.NoError(t, err)
+		return s
+	}
+
+	t.Run(`flush`, func(t *testing.T) {
+		s := makeSink(t)
+		defer func() { require.NoError(t, s.Close()) }()
+
+		require.NoError(t, s.EmitRow(ctx, t1, []byte(`[1]`), []byte(`{"after": 1}`), ts))
+		require.NoError(t, s.EmitRow(ctx, t1, []byte(`[2]`), nil, ts))
+		require.Empty(t, server.popRequests())
+		require.NoError(t, s.Flush(ctx))
+		require.Equal(t, [][]string{{`t1: [1]->{"after":1}`, `t1: [2]->`}}, server.popRequests())
+
+		// Nothing is buffered, so this doesn't send a request.
+		require.NoError(t, s.Flush(ctx))
+		require.Empty(t, server.popRequests())
+
+		table := &sqlbase.TableDescriptor{Name: `t2`}
+		require.EqualError(t, s.EmitRow(ctx, table, []byte(`[1]`), nil, ts),
+			`cannot emit to undeclared topic: t2`)
+	})
+
+	t.Run(`max_batch_size`, func(t *testing.T) {
+		s := makeSink(t, sinkParamMaxBatchSize+`=2`)
+		defer func() { require.NoError(t, s.Close()) }()
+
+		require.NoError(t, s.EmitRow(ctx, t1, []byte(`[1]`), nil, ts))
+		require.Empty(t, server.popRequests())
+		require.NoError(t, s.EmitRow(ctx, t1, []byte(`[2]`), nil, ts))
+		require.NoError(t, s.EmitRow(ctx, t1, []byte(`[3]`), nil, ts))
+		require.Equal(t, [][]string{{`t1: [1]->`, `t1: [2]->`}}, server.popRequests())
+		require.NoError(t, s.Flush(ctx))
+		require.Equal(t, [][]string{{`t1: [3]->`}}, server.popRequests())
+	})
+
+	t.Run(`flush_interval`, func(t *testing.T) {
+		s := makeSink(t, sinkParamFlushInterval+`=1ns`)
+		defer func() { require.NoError(t, s.Close()) }()
+
+		require.NoError(t, s.EmitRow(ctx, t1, []byte(`[1]`), nil, ts))
+		require.Equal(t, [][]string{{`t1: [1]->`}}, server.popRequests())
+	})
+
+	t.Run(`resolved`, func(t *testing.T) {
+		s := makeSink(t)
+		defer func() { require.NoError(t, s.Close()) }()
+
+		require.NoError(t, s.EmitRow(ctx, t1, []byte(`[1]`), nil, ts))
+		require.NoError(t, s.EmitResolvedTimestamp(ctx, e, ts))
+		require.Equal(t, [][]string{{
+			`t1: [1]->`,
+			`t1: ->{"resolved":"1.0000000000"}`,
+		}}, server.popRequests())
+	})
+
+	t.Run(`retries`, func(t *testing.T) {
+		s := makeSink(t, sinkParamMaxRetries+`=2`)
+		defer func() { require.NoError(t, s.Close()) }()
+
+		server.injectFailures(2)
+		require.NoError(t, s.EmitRow(ctx, t1, []byte(`[1]`), nil, ts))
+		require.NoError(t, s.Flush(ctx))
+		require.Equal(t, [][]string{{`t1: [1]->`}}, server.popRequests())
+
+		// Once the retries are exhausted, the error is returned but the messages
+		// are kept so a later Flush can deliver them.
+		server.injectFailures(3)
+		require.NoError(t, s.EmitRow(ctx, t1, []byte(`[2]`), nil, ts))
+		require.EqualError(t, s.Flush(ctx),
+			`sending 1 messages to webhook: webhook responded with 503 Service Unavailable`)
+		require.Empty(t, server.popRequests())
+		require.NoError(t, s.Flush(ctx))
+		require.Equal(t, [][]string{{`t1: [2]->`}}, server.popRequests())
+	})
+
+	t.Run(`untrusted`, func(t *testing.T) {
+		u, err := url.Parse(server.server.URL)
+		require.NoError(t, err)
+		u.Scheme = sinkSchemeWebhookHTTPS
+		s, err := getSink(u.String()+`?`+sinkParamMaxRetries+`=0`, 1, opts, targets, settings)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, s.Close()) }()
+
+		require.NoError(t, s.EmitRow(ctx, t1, []byte(`[1]`), nil, ts))
+		require.Error(t, s.Flush(ctx))
+		require.Empty(t, server.popRequests())
+	})
+
+	t.Run(`errors`, func(t *testing.T) {
+		for _, tc := range []struct {
+			params []string
+			opts   map[string]string
+			err    string
+		}{
+			{
+				params: []string{`nope=1`},
+				err:    `unknown sink query parameter: nope`,
+			},
+			{
+				params: []string{sinkParamMaxBatchSize + `=0`},
+				err:    `param max_batch_size must be a positive integer: 0`,
+			},
+			{
+				params: []string{sinkParamFlushInterval + `=soon`},
+				err:    `param flush_interval must be a non-negative duration: soon`,
+			},
+			{
+				params: []string{sinkParamClientCert + `=` + base64.StdEncoding.EncodeToString([]byte(`x`))},
+				err:    `client_cert requires client_key`,
+			},
+			{
+				opts: map[string]string{optFormat: string(optFormatAvro)},
+				err:  `this sink is incompatible with format=experimental_avro`,
+			},
+		} {
+			o := opts
+			if tc.opts != nil {
+				o = tc.opts
+			}
+			_, err := getSink(server.sinkURI(tc.params...), 1, o, targets, settings)
+			require.EqualError(t, err, tc.err)
+		}
+	})
+}
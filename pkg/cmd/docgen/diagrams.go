@@ -539,6 +539,18 @@ var specs = []stmtSpec{
 		match:  []*regexp.Regexp{regexp.MustCompile("'CREATE' 'INVERTED'")},
 		inline: []string{"opt_storing", "storing", "opt_unique", "opt_name", "index_params", "index_elem", "opt_asc_desc"},
 	},
+	{
+		name:   "create_function_stmt",
+		inline: []string{"opt_or_replace", "opt_func_param_list", "func_param_list", "func_param", "opt_setof", "func_option_list", "func_option"},
+		replace: map[string]string{
+			"db_object_name":              "function_name",
+			"'IDENT'":                     "param_name",
+			"non_reserved_word_or_sconst": "language_name",
+			"'SCONST'":                    "definition",
+		},
+		unlink:  []string{"function_name", "param_name", "language_name", "definition"},
+		nosplit: true,
+	},
 	{
 		name:    "create_sequence_stmt",
 		inline:  []string{"opt_sequence_option_list", "sequence_option_list", "sequence_option_elem"},
@@ -559,6 +571,17 @@ var specs = []stmtSpec{
 		name:   "create_table_stmt",
 		inline: []string{"opt_table_elem_list", "table_elem_list", "table_elem"},
 	},
+	{
+		name:   "create_trigger_stmt",
+		inline: []string{"trigger_action_time", "trigger_event_list", "trigger_event", "opt_trigger_for_each", "opt_each", "opt_trigger_when"},
+		replace: map[string]string{
+			"'TRIGGER' name": "'TRIGGER' trigger_name",
+			"a_expr":         "condition",
+			"'SCONST'":       "definition",
+		},
+		unlink:  []string{"trigger_name", "condition", "definition"},
+		nosplit: true,
+	},
 	{
 		name:   "create_view_stmt",
 		inline: []string{"opt_column_list"},
@@ -656,6 +679,15 @@ var specs = []stmtSpec{
 		name:    "drop_role_stmt",
 		replace: map[string]string{"string_or_placeholder_list": "name"},
 	},
+	{
+		name:   "drop_function_stmt",
+		inline: []string{"func_obj_list", "func_obj", "opt_func_param_list", "func_param_list", "func_param", "opt_drop_behavior"},
+		replace: map[string]string{
+			"db_object_name": "function_name",
+			"'IDENT'":        "param_name",
+		},
+		unlink: []string{"function_name", "param_name"},
+	},
 	{
 		name:   "drop_sequence_stmt",
 		inline: []string{"table_name_list", "opt_drop_behavior"},
@@ -671,6 +703,12 @@ var specs = []stmtSpec{
 		inline: []string{"opt_drop_behavior", "table_name_list"},
 		match:  []*regexp.Regexp{regexp.MustCompile("'DROP' 'TABLE'")},
 	},
+	{
+		name:    "drop_trigger_stmt",
+		inline:  []string{"opt_drop_behavior"},
+		replace: map[string]string{" name 'ON'": " trigger_name 'ON'"},
+		unlink:  []string{"trigger_name"},
+	},
 	{
 		name:   "drop_view",
 		stmt:   "drop_view_stmt",
@@ -744,21 +782,21 @@ var specs = []stmtSpec{
 		stmt:   "grant_stmt",
 		inline: []string{"privileges", "privilege_list", "privilege", "table_pattern_list", "name_list"},
 		replace: map[string]string{
-			"( name | 'CREATE' | 'GRANT' | 'SELECT' )": "( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' )",
+			"( name | 'CREATE' | 'GRANT' | 'SELECT' )": "( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' | 'EXECUTE' )",
 			"table_pattern":                     "table_name",
 			"'TO' ( ( name ) ( ( ',' name ) )*": "'TO' ( ( user_name ) ( ( ',' user_name ) )*",
-			"| 'GRANT' ( ( ( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' ) ) ( ( ',' ( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' ) ) )* ) 'TO' ( ( user_name ) ( ( ',' user_name ) )* )": "",
+			"| 'GRANT' ( ( ( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' | 'EXECUTE' ) ) ( ( ',' ( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' | 'EXECUTE' ) ) )* ) 'TO' ( ( user_name ) ( ( ',' user_name ) )* )": "",
 			"'WITH' 'ADMIN' 'OPTION'": "",
-			"targets":                 "( ( 'TABLE' | ) table_pattern ( ( ',' table_pattern ) )* | 'DATABASE' database_name ( ( ',' database_name ) )* )",
+			"grant_targets":           "( ( 'TABLE' | ) table_pattern ( ( ',' table_pattern ) )* | 'DATABASE' database_name ( ( ',' database_name ) )* | 'FUNCTION' function_name ( ( ',' function_name ) )* )",
 		},
-		unlink:  []string{"table_name", "database_name", "user_name"},
+		unlink:  []string{"table_name", "database_name", "function_name", "user_name"},
 		nosplit: true,
 	},
 	{
 		name: "grant_roles",
 		stmt: "grant_stmt",
 		replace: map[string]string{
-			"'GRANT' privileges 'ON' targets 'TO' name_list":                "",
+			"'GRANT' privileges 'ON' grant_targets 'TO' name_list":          "",
 			"'GRANT' privilege_list 'TO' name_list 'WITH' 'ADMIN' 'OPTION'": "'GRANT' ( role_name ) ( ( ',' role_name ) )* 'TO' ( user_name ) ( ( ',' user_name ) )* 'WITH' 'ADMIN' 'OPTION'",
 			"| 'GRANT' privilege_list 'TO' name_list":                       "'GRANT' ( role_name ) ( ( ',' role_name ) )* 'TO' ( user_name ) ( ( ',' user_name ) )*",
 		},
@@ -859,6 +897,10 @@ var specs = []stmtSpec{
 		replace: map[string]string{"	stmt": "	'CREATE' 'TABLE' table_name '(' ( column_def ( ',' column_def )* ) ( 'CONSTRAINT' name | ) 'PRIMARY KEY' '(' ( column_name ( ',' column_name )* ) ')' ( table_constraints | ) ')'"},
 		unlink: []string{"table_name", "column_name", "table_constraints"},
 	},
+	{
+		name: "refresh_materialized_view",
+		stmt: "refresh_stmt",
+	},
 	{
 		name:   "release_savepoint",
 		stmt:   "release_stmt",
@@ -925,21 +967,21 @@ var specs = []stmtSpec{
 		stmt:   "revoke_stmt",
 		inline: []string{"privileges", "privilege_list", "privilege", "name_list"},
 		replace: map[string]string{
-			"( name | 'CREATE' | 'GRANT' | 'SELECT' )": "( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' )",
-			"targets":                             "( ( 'TABLE' | ) table_pattern ( ( ',' table_pattern ) )* | 'DATABASE' database_name ( ( ',' database_name ) )* )",
+			"( name | 'CREATE' | 'GRANT' | 'SELECT' )": "( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' | 'EXECUTE' )",
+			"grant_targets":                       "( ( 'TABLE' | ) table_pattern ( ( ',' table_pattern ) )* | 'DATABASE' database_name ( ( ',' database_name ) )* | 'FUNCTION' function_name ( ( ',' function_name ) )* )",
 			"'FROM' ( ( name ) ( ( ',' name ) )*": "'FROM' ( ( user_name ) ( ( ',' user_name ) )*",
-			"| 'REVOKE' ( ( ( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' ) ) ( ( ',' ( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' ) ) )* ) 'FROM' ( ( user_name ) ( ( ',' user_name ) )* )":  "",
-			"| 'REVOKE'  ( ( ( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' ) ) ( ( ',' ( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' ) ) )* ) 'FROM' ( ( user_name ) ( ( ',' user_name ) )* )": "",
+			"| 'REVOKE' ( ( ( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' | 'EXECUTE' ) ) ( ( ',' ( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' | 'EXECUTE' ) ) )* ) 'FROM' ( ( user_name ) ( ( ',' user_name ) )* )":  "",
+			"| 'REVOKE'  ( ( ( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' | 'EXECUTE' ) ) ( ( ',' ( 'CREATE' | 'GRANT' | 'SELECT' | 'DROP' | 'INSERT' | 'DELETE' | 'UPDATE' | 'EXECUTE' ) ) )* ) 'FROM' ( ( user_name ) ( ( ',' user_name ) )* )": "",
 			"'ADMIN' 'OPTION' 'FOR'": "",
 		},
-		unlink:  []string{"table_name", "database_name", "user_name"},
+		unlink:  []string{"table_name", "database_name", "function_name", "user_name"},
 		nosplit: true,
 	},
 	{
 		name: "revoke_roles",
 		stmt: "revoke_stmt",
 		replace: map[string]string{
-			"'REVOKE' privileges 'ON' targets 'FROM' name_list":               "",
+			"'REVOKE' privileges 'ON' grant_targets 'FROM' name_list":         "",
 			"'REVOKE' 'ADMIN' 'OPTION' 'FOR' privilege_list 'FROM' name_list": "'REVOKE' 'ADMIN' 'OPTION' 'FOR' ( role_name ) ( ( ',' role_name ) )* 'FROM' ( user_name ) ( ( ',' user_name ) )*",
 			"| 'REVOKE' privilege_list 'FROM' name_list":                      "'REVOKE' ( role_name ) ( ( ',' role_name ) )* 'FROM' ( user_name ) ( ( ',' user_name ) )*",
 		},
@@ -1068,6 +1110,12 @@ var specs = []stmtSpec{
 		},
 		match: []*regexp.Regexp{regexp.MustCompile("'SET' 'TRANSACTION'")},
 	},
+	{
+		name:   "set_constraints",
+		stmt:   "nonpreparable_set_stmt",
+		inline: []string{"set_constraints_stmt"},
+		match:  []*regexp.Regexp{regexp.MustCompile("'SET' 'CONSTRAINTS'")},
+	},
 	{
 		name: "show_var",
 		stmt: "show_stmt",
@@ -1246,6 +1294,8 @@ var specs = []stmtSpec{
 			"set_clause",
 			"single_set_clause",
 			"multiple_set_clause",
+			"update_from_clause",
+			"from_list",
 			"in_expr",
 			"expr_list",
 			"expr_tuple1_ambiguous",
@@ -207,8 +207,27 @@ type TxnSender interface {
 	// However, this is used by DistSQL for sending the transaction over the wire
 	// when it creates flows.
 	SerializeTxn() *roachpb.Transaction
+
+	// CreateSavepoint establishes a savepoint at the current point of the
+	// transaction. The returned token can later be passed to
+	// RollbackToSavepoint to discard the writes performed after it.
+	CreateSavepoint(context.Context) (SavepointToken, error)
+
+	// RollbackToSavepoint rolls the transaction back to the given savepoint.
+	// The writes performed after the savepoint was created are discarded; reads
+	// no longer observe them and they are removed when the transaction's
+	// intents are resolved. The savepoint remains valid and can be rolled back
+	// to again.
+	//
+	// Rolling back is not possible across an epoch increment, since the
+	// restart has already discarded the writes preceding the savepoint.
+	RollbackToSavepoint(context.Context, SavepointToken) error
 }
 
+// SavepointToken represents a savepoint created by TxnSender.CreateSavepoint.
+// It is opaque to the client.
+type SavepointToken interface{}
+
 // TxnStatusOpt represents options for TxnSender.GetMeta().
 type TxnStatusOpt int
 
@@ -368,6 +387,16 @@ func (m *MockTransactionalSender) UpdateStateOnRemoteRetryableErr(
 // DisablePipelining is part of the client.TxnSender interface.
 func (m *MockTransactionalSender) DisablePipelining() error { return nil }
 
+// CreateSavepoint is part of the client.TxnSender interface.
+func (m *MockTransactionalSender) CreateSavepoint(context.Context) (SavepointToken, error) {
+	panic("unimplemented")
+}
+
+// RollbackToSavepoint is part of the client.TxnSender interface.
+func (m *MockTransactionalSender) RollbackToSavepoint(context.Context, SavepointToken) error {
+	panic("unimplemented")
+}
+
 // MockTxnSenderFactory is a TxnSenderFactory producing MockTxnSenders.
 type MockTxnSenderFactory struct {
 	senderFunc func(context.Context, *roachpb.Transaction, roachpb.BatchRequest) (
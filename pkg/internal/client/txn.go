@@ -978,6 +978,23 @@ func (txn *Txn) ManualRestart(ctx context.Context, ts hlc.Timestamp) {
 	txn.mu.sender.ManualRestart(ctx, txn.mu.userPriority, ts)
 }
 
+// CreateSavepoint establishes a savepoint that the transaction can later be
+// rolled back to with RollbackToSavepoint.
+func (txn *Txn) CreateSavepoint(ctx context.Context) (SavepointToken, error) {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.CreateSavepoint(ctx)
+}
+
+// RollbackToSavepoint discards the writes performed by the transaction since
+// the given savepoint was created. The transaction remains usable afterwards,
+// even if one of its requests had failed with a non-retriable error.
+func (txn *Txn) RollbackToSavepoint(ctx context.Context, s SavepointToken) error {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.RollbackToSavepoint(ctx, s)
+}
+
 // IsSerializablePushAndRefreshNotPossible returns true if the transaction is
 // serializable, its timestamp has been pushed and there's no chance that
 // refreshing the read spans will succeed later (thus allowing the transaction
@@ -28,6 +28,7 @@ var _ Details = RestoreDetails{}
 var _ Details = SchemaChangeDetails{}
 var _ Details = ChangefeedDetails{}
 var _ Details = CreateStatsDetails{}
+var _ Details = MaterializedViewRefreshDetails{}
 
 // ProgressDetails is a marker interface for job progress details proto structs.
 type ProgressDetails interface{}
@@ -37,6 +38,7 @@ var _ ProgressDetails = RestoreProgress{}
 var _ ProgressDetails = SchemaChangeProgress{}
 var _ ProgressDetails = ChangefeedProgress{}
 var _ ProgressDetails = CreateStatsProgress{}
+var _ ProgressDetails = MaterializedViewRefreshProgress{}
 
 // Type returns the payload's job type.
 func (p *Payload) Type() Type {
@@ -62,6 +64,8 @@ func DetailsType(d isPayload_Details) Type {
 			return TypeAutoCreateStats
 		}
 		return TypeCreateStats
+	case *Payload_MaterializedViewRefresh:
+		return TypeMaterializedViewRefresh
 	default:
 		panic(fmt.Sprintf("Payload.Type called on a payload with an unknown details type: %T", d))
 	}
@@ -88,6 +92,8 @@ func WrapProgressDetails(details ProgressDetails) interface {
 		return &Progress_Changefeed{Changefeed: &d}
 	case CreateStatsProgress:
 		return &Progress_CreateStats{CreateStats: &d}
+	case MaterializedViewRefreshProgress:
+		return &Progress_MaterializedViewRefresh{MaterializedViewRefresh: &d}
 	default:
 		panic(fmt.Sprintf("WrapProgressDetails: unknown details type %T", d))
 	}
@@ -109,6 +115,8 @@ func (p *Payload) UnwrapDetails() Details {
 		return *d.Changefeed
 	case *Payload_CreateStats:
 		return *d.CreateStats
+	case *Payload_MaterializedViewRefresh:
+		return *d.MaterializedViewRefresh
 	default:
 		return nil
 	}
@@ -130,6 +138,8 @@ func (p *Progress) UnwrapDetails() ProgressDetails {
 		return *d.Changefeed
 	case *Progress_CreateStats:
 		return *d.CreateStats
+	case *Progress_MaterializedViewRefresh:
+		return *d.MaterializedViewRefresh
 	default:
 		return nil
 	}
@@ -164,6 +174,8 @@ func WrapPayloadDetails(details Details) interface {
 		return &Payload_Changefeed{Changefeed: &d}
 	case CreateStatsDetails:
 		return &Payload_CreateStats{CreateStats: &d}
+	case MaterializedViewRefreshDetails:
+		return &Payload_MaterializedViewRefresh{MaterializedViewRefresh: &d}
 	default:
 		panic(fmt.Sprintf("jobs.WrapPayloadDetails: unknown details type %T", d))
 	}
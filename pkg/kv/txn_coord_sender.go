@@ -125,6 +125,11 @@ type TxnCoordSender struct {
 		// EndTransactionRequest.
 		systemConfigTrigger bool
 
+		// savepointsCreated is set once the client creates a savepoint. From then
+		// on, errors that can be undone by rolling back to a savepoint do not
+		// cause the transaction to be cleaned up.
+		savepointsCreated bool
+
 		// txn is the Transaction proto attached to all the requests and updated on
 		// all the responses.
 		txn roachpb.Transaction
@@ -1096,8 +1101,14 @@ func (tc *TxnCoordSender) updateStateLocked(
 		tc.mu.storedErr = roachpb.NewError(&roachpb.TxnAlreadyEncounteredErrorError{
 			PrevError: pErr.String(),
 		})
-		// Cleanup.
 		tc.mu.txn.Update(errTxn)
+		// A ConditionFailedError is unambiguous: the write did not happen. If the
+		// client uses savepoints, it can roll back to one of them and continue,
+		// so we keep the transaction around instead of cleaning it up.
+		if _, ok := pErr.GetDetail().(*roachpb.ConditionFailedError); ok && tc.mu.savepointsCreated {
+			return pErr
+		}
+		// Cleanup.
 		tc.cleanupTxnLocked(ctx)
 	}
 	return pErr
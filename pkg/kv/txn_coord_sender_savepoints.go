@@ -0,0 +1,116 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package kv
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/pkg/errors"
+)
+
+// savepoint is the TxnCoordSender's implementation of client.SavepointToken.
+// It captures the sequence number watermark of the transaction at the time the
+// savepoint was created: the writes performed after the savepoint are exactly
+// those with a higher sequence number.
+type savepoint struct {
+	// txnID and epoch are used to detect savepoints that are no longer
+	// applicable because the transaction was restarted since.
+	txnID uuid.UUID
+	epoch enginepb.TxnEpoch
+
+	// seqNum is the sequence number of the last write performed before the
+	// savepoint was created.
+	seqNum enginepb.TxnSeq
+}
+
+var _ client.SavepointToken = (*savepoint)(nil)
+
+// CreateSavepoint is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) CreateSavepoint(ctx context.Context) (client.SavepointToken, error) {
+	if tc.typ != client.RootTxn {
+		return nil, errors.Errorf("cannot get savepoint in non-root txn")
+	}
+
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if err := tc.assertNotFinalized(); err != nil {
+		return nil, err
+	}
+
+	// Remember that the transaction uses savepoints: unambiguous errors no
+	// longer tear the transaction down, since it may be rolled back to a
+	// savepoint and continue.
+	tc.mu.savepointsCreated = true
+	return &savepoint{
+		txnID:  tc.mu.txn.ID,
+		epoch:  tc.mu.txn.Epoch,
+		seqNum: tc.interceptorAlloc.txnSeqNumAllocator.seqGen,
+	}, nil
+}
+
+// RollbackToSavepoint is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) RollbackToSavepoint(ctx context.Context, s client.SavepointToken) error {
+	if tc.typ != client.RootTxn {
+		return errors.Errorf("cannot rollback to savepoint in non-root txn")
+	}
+
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if err := tc.assertNotFinalized(); err != nil {
+		return err
+	}
+	if tc.mu.closed {
+		// The transaction was cleaned up, for example after an error that cannot
+		// be undone by rolling back to a savepoint.
+		if tc.mu.storedErr != nil {
+			return errors.Errorf("cannot rollback to savepoint after error: %s", tc.mu.storedErr)
+		}
+		return errors.Errorf("cannot rollback to savepoint in a transaction that was cleaned up")
+	}
+
+	sp := s.(*savepoint)
+	if sp.txnID != tc.mu.txn.ID {
+		return errors.Errorf("cannot rollback to savepoint created by a different transaction")
+	}
+	if sp.epoch != tc.mu.txn.Epoch {
+		return errors.Errorf("cannot rollback to savepoint after a transaction restart")
+	}
+
+	// Mark all the writes performed since the savepoint as ignored. The
+	// sequence number allocator keeps counting from where it was, so new
+	// writes are not affected.
+	if seqGen := tc.interceptorAlloc.txnSeqNumAllocator.seqGen; seqGen > sp.seqNum {
+		tc.mu.txn.AddIgnoredSeqNumRange(enginepb.IgnoredSeqNumRange{
+			Start: sp.seqNum + 1, End: seqGen,
+		})
+	}
+
+	// The error that might have brought us here was undone.
+	tc.mu.txnState = txnPending
+	tc.mu.storedErr = nil
+	return nil
+}
+
+// assertNotFinalized returns an error if the transaction has already committed
+// or rolled back.
+func (tc *TxnCoordSender) assertNotFinalized() error {
+	if tc.mu.txnState == txnFinalized {
+		return errors.Errorf("cannot use savepoints in a finalized transaction")
+	}
+	return nil
+}
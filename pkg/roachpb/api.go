@@ -1024,8 +1024,19 @@ func (drr *DeleteRangeRequest) flags() int {
 // Note that ClearRange commands cannot be part of a transaction as
 // they clear all MVCC versions.
 func (*ClearRangeRequest) flags() int { return isWrite | isRange | isAlone }
-func (*ScanRequest) flags() int       { return isRead | isRange | isTxn | updatesReadTSCache | needsRefresh }
-func (*ReverseScanRequest) flags() int {
+
+// A locking scan writes intents on the keys it returns, so it is a
+// transactional write in addition to being a read.
+func (sr *ScanRequest) flags() int {
+	if sr.KeyLocking {
+		return isRead | isWrite | isTxn | isTxnWrite | isRange | consultsTSCache | updatesReadTSCache | needsRefresh | canBackpressure
+	}
+	return isRead | isRange | isTxn | updatesReadTSCache | needsRefresh
+}
+func (rsr *ReverseScanRequest) flags() int {
+	if rsr.KeyLocking {
+		return isRead | isWrite | isTxn | isTxnWrite | isRange | isReverse | consultsTSCache | updatesReadTSCache | needsRefresh | canBackpressure
+	}
 	return isRead | isRange | isReverse | isTxn | updatesReadTSCache | needsRefresh
 }
 func (*BeginTransactionRequest) flags() int { return isWrite | isTxn }
@@ -37,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/interval"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/pkg/errors"
@@ -439,6 +440,15 @@ func (v *Value) SetTime(t time.Time) {
 	v.setTag(ValueType_TIME)
 }
 
+// SetTimeTZ encodes the specified time value into the bytes field of the
+// receiver, sets the tag and clears the checksum.
+func (v *Value) SetTimeTZ(t timetz.TimeTZ) {
+	const encodingSizeOverestimate = 15
+	v.ensureRawBytes(headerSize + encodingSizeOverestimate)
+	v.RawBytes = encoding.EncodeTimeTZAscending(v.RawBytes[:headerSize], t)
+	v.setTag(ValueType_TIMETZ)
+}
+
 // SetDuration encodes the specified duration value into the bytes field of the
 // receiver, sets the tag and clears the checksum.
 func (v *Value) SetDuration(t duration.Duration) error {
@@ -563,6 +573,16 @@ func (v Value) GetTime() (time.Time, error) {
 	return t, err
 }
 
+// GetTimeTZ decodes a time value from the bytes field of the receiver. If the
+// tag is not TIMETZ an error will be returned.
+func (v Value) GetTimeTZ() (timetz.TimeTZ, error) {
+	if tag := v.GetTag(); tag != ValueType_TIMETZ {
+		return timetz.TimeTZ{}, fmt.Errorf("value type is not %s: %s", ValueType_TIMETZ, tag)
+	}
+	_, t, err := encoding.DecodeTimeTZAscending(v.dataBytes())
+	return t, err
+}
+
 // GetDuration decodes a duration value from the bytes field of the receiver. If
 // the tag is not DURATION an error will be returned.
 func (v Value) GetDuration() (duration.Duration, error) {
@@ -719,6 +739,10 @@ func (v Value) PrettyPrint() string {
 		var t time.Time
 		t, err = v.GetTime()
 		buf.WriteString(t.UTC().Format(time.RFC3339Nano))
+	case ValueType_TIMETZ:
+		var t timetz.TimeTZ
+		t, err = v.GetTimeTZ()
+		buf.WriteString(t.String())
 	case ValueType_DECIMAL:
 		var d apd.Decimal
 		d, err = v.GetDecimal()
@@ -936,6 +960,9 @@ func (t *Transaction) Restart(
 	t.UpgradePriority(upgradePriority)
 	t.WriteTooOld = false
 	t.Sequence = 0
+	// Writes rolled back by savepoints are discarded along with all other
+	// writes from the previous epoch.
+	t.IgnoredSeqNums = nil
 	// Reset Writing. Since we're using a new epoch, we don't care about the abort
 	// cache.
 	t.DeprecatedWriting = false
@@ -998,6 +1025,11 @@ func (t *Transaction) Update(o *Transaction) {
 
 	if t.Epoch < o.Epoch {
 		t.Epoch = o.Epoch
+		t.IgnoredSeqNums = o.IgnoredSeqNums
+	} else if t.Epoch == o.Epoch {
+		for _, r := range o.IgnoredSeqNums {
+			t.AddIgnoredSeqNumRange(r)
+		}
 	}
 
 	t.Timestamp.Forward(o.Timestamp)
@@ -1033,6 +1065,44 @@ func (t *Transaction) Update(o *Transaction) {
 	}
 }
 
+// AddIgnoredSeqNumRange adds the given range of sequence numbers to the
+// transaction's list of ignored sequence numbers, merging it with any ranges
+// it overlaps or abuts. The list is copied rather than modified in place, as
+// it may be shared with other copies of the transaction.
+func (t *Transaction) AddIgnoredSeqNumRange(newRange enginepb.IgnoredSeqNumRange) {
+	if newRange.Start > newRange.End {
+		return
+	}
+	res := make([]enginepb.IgnoredSeqNumRange, 0, len(t.IgnoredSeqNums)+1)
+	added := false
+	for _, r := range t.IgnoredSeqNums {
+		switch {
+		case r.End+1 < newRange.Start:
+			// r is entirely before the new range.
+			res = append(res, r)
+		case newRange.End+1 < r.Start:
+			// r is entirely after the new range.
+			if !added {
+				res = append(res, newRange)
+				added = true
+			}
+			res = append(res, r)
+		default:
+			// r overlaps or abuts the new range; absorb it.
+			if r.Start < newRange.Start {
+				newRange.Start = r.Start
+			}
+			if r.End > newRange.End {
+				newRange.End = r.End
+			}
+		}
+	}
+	if !added {
+		res = append(res, newRange)
+	}
+	t.IgnoredSeqNums = res
+}
+
 // UpgradePriority sets transaction priority to the maximum of current
 // priority and the specified minPriority. The exception is if the
 // current priority is set to the minimum, in which case the minimum
@@ -1140,6 +1210,7 @@ func (t *Transaction) AsRecord() TransactionRecord {
 	tr.OrigTimestamp = t.OrigTimestamp
 	tr.IntentSpans = t.IntentSpans
 	tr.InFlightWrites = t.InFlightWrites
+	tr.IgnoredSeqNums = t.IgnoredSeqNums
 	return tr
 }
 
@@ -1154,6 +1225,7 @@ func (tr *TransactionRecord) AsTransaction() Transaction {
 	t.OrigTimestamp = tr.OrigTimestamp
 	t.IntentSpans = tr.IntentSpans
 	t.InFlightWrites = tr.InFlightWrites
+	t.IgnoredSeqNums = tr.IgnoredSeqNums
 	return t
 }
 
@@ -1482,9 +1554,10 @@ func AsIntents(spans []Span, txn *Transaction) []Intent {
 	ret := make([]Intent, len(spans))
 	for i := range spans {
 		ret[i] = Intent{
-			Span:   spans[i],
-			Txn:    txn.TxnMeta,
-			Status: txn.Status,
+			Span:           spans[i],
+			Txn:            txn.TxnMeta,
+			Status:         txn.Status,
+			IgnoredSeqNums: txn.IgnoredSeqNums,
 		}
 	}
 	return ret
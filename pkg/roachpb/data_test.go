@@ -32,6 +32,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/kr/pretty"
 )
@@ -493,6 +495,7 @@ var nonZeroTxn = Transaction{
 	InFlightWrites:           []SequencedWrite{{Key: []byte("c"), Sequence: 1}},
 	EpochZeroTimestamp:       makeTS(1, 1),
 	OrigTimestampWasObserved: true,
+	IgnoredSeqNums:           []enginepb.IgnoredSeqNumRange{{Start: 888, End: 999}},
 }
 
 func TestTransactionUpdate(t *testing.T) {
@@ -575,6 +578,31 @@ func TestTransactionUpdateStaging(t *testing.T) {
 	}
 }
 
+func TestTransactionAddIgnoredSeqNumRange(t *testing.T) {
+	type r = enginepb.IgnoredSeqNumRange
+	testCases := []struct {
+		list     []r
+		newRange r
+		exp      []r
+	}{
+		{nil, r{Start: 1, End: 2}, []r{{Start: 1, End: 2}}},
+		{[]r{{Start: 1, End: 2}}, r{Start: 5, End: 6}, []r{{Start: 1, End: 2}, {Start: 5, End: 6}}},
+		{[]r{{Start: 5, End: 6}}, r{Start: 1, End: 2}, []r{{Start: 1, End: 2}, {Start: 5, End: 6}}},
+		{[]r{{Start: 1, End: 2}}, r{Start: 3, End: 4}, []r{{Start: 1, End: 4}}},
+		{[]r{{Start: 1, End: 2}, {Start: 5, End: 6}}, r{Start: 2, End: 5}, []r{{Start: 1, End: 6}}},
+		{[]r{{Start: 1, End: 2}, {Start: 8, End: 9}}, r{Start: 4, End: 5}, []r{{Start: 1, End: 2}, {Start: 4, End: 5}, {Start: 8, End: 9}}},
+		{[]r{{Start: 3, End: 9}}, r{Start: 4, End: 5}, []r{{Start: 3, End: 9}}},
+		{[]r{{Start: 1, End: 2}}, r{Start: 5, End: 4}, []r{{Start: 1, End: 2}}},
+	}
+	for _, tc := range testCases {
+		txn := Transaction{IgnoredSeqNums: tc.list}
+		txn.AddIgnoredSeqNumRange(tc.newRange)
+		if !reflect.DeepEqual(tc.exp, txn.IgnoredSeqNums) {
+			t.Errorf("adding %v to %v: expected %v, got %v", tc.newRange, tc.list, tc.exp, txn.IgnoredSeqNums)
+		}
+	}
+}
+
 func TestTransactionClone(t *testing.T) {
 	txnPtr := nonZeroTxn.Clone()
 	txn := *txnPtr
@@ -586,6 +614,7 @@ func TestTransactionClone(t *testing.T) {
 	// listed below. If this test fails, please update the list below and/or
 	// Transaction.Clone().
 	expFields := []string{
+		"IgnoredSeqNums",
 		"InFlightWrites",
 		"InFlightWrites.Key",
 		"IntentSpans",
@@ -638,6 +667,9 @@ func TestTransactionRecordRoundtrips(t *testing.T) {
 	if !reflect.DeepEqual(txnRecord.IntentSpans, txn.IntentSpans) {
 		t.Fatalf("txnRecord.IntentSpans = %v, txn.IntentSpans = %v", txnRecord.IntentSpans, txn.IntentSpans)
 	}
+	if !reflect.DeepEqual(txnRecord.IgnoredSeqNums, txn.IgnoredSeqNums) {
+		t.Fatalf("txnRecord.IgnoredSeqNums = %v, txn.IgnoredSeqNums = %v", txnRecord.IgnoredSeqNums, txn.IgnoredSeqNums)
+	}
 
 	// Verify that converting through a Transaction message and back
 	// to a TransactionRecord is a lossless round trip.
@@ -1439,6 +1471,9 @@ func TestValuePrettyPrint(t *testing.T) {
 	var timeValue Value
 	timeValue.SetTime(time.Date(2016, 6, 29, 16, 2, 50, 5, time.UTC))
 
+	var timeTZValue Value
+	timeTZValue.SetTimeTZ(timetz.MakeTimeTZ(timeofday.New(16, 2, 50, 5), 8*60*60))
+
 	var decimalValue Value
 	_ = decimalValue.SetDecimal(apd.New(628, -2))
 
@@ -1473,6 +1508,7 @@ func TestValuePrettyPrint(t *testing.T) {
 		{intValue, "/INT/7"},
 		{floatValue, "/FLOAT/6.28"},
 		{timeValue, "/TIME/2016-06-29T16:02:50.000000005Z"},
+		{timeTZValue, "/TIMETZ/16:02:50.000005-08"},
 		{decimalValue, "/DECIMAL/6.28"},
 		{durationValue, "/DURATION/1 mon 2 days 00:00:00+3ns"},
 		{MakeValueFromBytes([]byte{0x1, 0x2, 0xF, 0xFF}), "/BYTES/0x01020fff"},
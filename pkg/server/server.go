@@ -1583,6 +1583,9 @@ func (s *Server) Start(ctx context.Context) error {
 		s.distSQLServer.ServerConfig.SessionBoundInternalExecutorFactory,
 	).Start(s.stopper)
 
+	// Start the cleaner of temporary objects left behind by dead sessions.
+	sql.NewTemporaryObjectCleaner(s.cfg.AmbientCtx, s.execCfg, s.nodeLiveness).Start(s.stopper)
+
 	s.distSQLServer.Start()
 	s.pgServer.Start(ctx, s.stopper)
 
@@ -484,7 +484,7 @@ func TestReportUsage(t *testing.T) {
 			t.Fatal(err)
 		}
 		// pass args to force a prepare/exec path as that may differ.
-		if _, err := db.Exec(`SELECT 1::INTERVAL(1), $1`, 1); !testutils.IsError(
+		if _, err := db.Exec(`SELECT '1'::TIME(1), $1`, 1); !testutils.IsError(
 			err, "unimplemented",
 		) {
 			t.Fatal(err)
@@ -709,7 +709,7 @@ func TestReportUsage(t *testing.T) {
 		"unimplemented.#33285.json_object_agg":          10,
 		"unimplemented.pg_catalog.pg_stat_wal_receiver": 10,
 		"unimplemented.syntax.#28751":                   10,
-		"unimplemented.syntax.#32564":                   10,
+		"unimplemented.syntax.#32565":                   10,
 		"unimplemented.#9148":                           10,
 		"othererror.builtins.go":                        10,
 		"othererror." +
@@ -0,0 +1,242 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+)
+
+// alterColumnTypeGeneral changes the type of a column whose values must be
+// converted. The column is rewritten by the schema changer: a hidden column
+// computed from the USING expression (or from a cast of the column) is added
+// along with copies of the secondary indexes containing the column. Once they
+// are backfilled, they replace the column and its indexes, which are dropped.
+func alterColumnTypeGeneral(
+	tableDesc *sqlbase.MutableTableDescriptor,
+	col *sqlbase.ColumnDescriptor,
+	toType *types.T,
+	using tree.Expr,
+	params runParams,
+) error {
+	if err := checkColumnCanBeRewritten(tableDesc, col); err != nil {
+		return err
+	}
+
+	// The default expression is kept, so it must produce values of the new
+	// type.
+	if col.DefaultExpr != nil {
+		expr, err := parser.ParseExpr(*col.DefaultExpr)
+		if err != nil {
+			return err
+		}
+		if _, err := sqlbase.SanitizeVarFreeExpr(
+			expr, toType, "DEFAULT", &params.p.semaCtx, true, /* allowImpure */
+		); err != nil {
+			return pgerror.Newf(pgerror.CodeDatatypeMismatchError,
+				"default for column %q cannot be cast automatically to type %s",
+				col.Name, toType.SQLString())
+		}
+	}
+
+	if using == nil {
+		// Values of an equivalent type are only checked against the width of
+		// the new type by the backfill, instead of being cast, which could
+		// truncate them.
+		using = &tree.ColumnItem{ColumnName: tree.Name(col.Name)}
+		if !col.Type.Equivalent(toType) {
+			using = &tree.CastExpr{Expr: using, Type: toType, SyntaxMode: tree.CastShort}
+		}
+	}
+
+	// Add the column holding the converted values.
+	d := &tree.ColumnTableDef{
+		Name: tree.Name(makeUniqueName(col.Name+"_new", func(name string) bool {
+			_, _, err := tableDesc.FindColumnByName(tree.Name(name))
+			return err == nil
+		})),
+		Type: toType,
+	}
+	if !col.Nullable {
+		d.Nullable.Nullability = tree.NotNull
+	}
+	d.Computed.Computed = true
+	d.Computed.Expr = using
+	if err := validateComputedColumn(tableDesc, d, &params.p.semaCtx); err != nil {
+		return err
+	}
+	newCol, _, _, err := sqlbase.MakeColumnDefDescs(d, &params.p.semaCtx)
+	if err != nil {
+		return err
+	}
+	newCol.Hidden = true
+	tableDesc.AddColumnMutation(newCol, sqlbase.DescriptorMutation_ADD)
+	for i := range tableDesc.Families {
+		family := &tableDesc.Families[i]
+		for _, id := range family.ColumnIDs {
+			if id == col.ID {
+				if err := tableDesc.AddColumnToFamilyMaybeCreate(
+					newCol.Name, family.Name, false /* create */, false, /* ifNotExists */
+				); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := tableDesc.AllocateIDs(); err != nil {
+		return err
+	}
+
+	// Add a copy of every secondary index containing the column, which uses the
+	// new column instead.
+	swap := &sqlbase.ColumnTypeSwap{OldColumnID: col.ID, NewColumnID: newCol.ID}
+	var newIndexes []*sqlbase.IndexDescriptor
+	for i := range tableDesc.Indexes {
+		idx := &tableDesc.Indexes[i]
+		if !idx.ContainsColumnID(col.ID) {
+			continue
+		}
+		newIdx := protoutil.Clone(idx).(*sqlbase.IndexDescriptor)
+		newIdx.ID = 0
+		newIdx.Name = makeUniqueName(idx.Name+"_new", func(name string) bool {
+			_, _, err := tableDesc.FindIndexByName(name)
+			return err == nil
+		})
+		for j := range newIdx.ColumnNames {
+			if newIdx.ColumnNames[j] == col.Name {
+				newIdx.ColumnNames[j] = newCol.Name
+			}
+		}
+		for j := range newIdx.StoreColumnNames {
+			if newIdx.StoreColumnNames[j] == col.Name {
+				newIdx.StoreColumnNames[j] = newCol.Name
+			}
+		}
+		newIdx.ColumnIDs = nil
+		newIdx.ExtraColumnIDs = nil
+		newIdx.StoreColumnIDs = nil
+		newIdx.CompositeColumnIDs = nil
+		if err := tableDesc.AddIndexMutation(newIdx, sqlbase.DescriptorMutation_ADD); err != nil {
+			return err
+		}
+		swap.OldIndexIDs = append(swap.OldIndexIDs, idx.ID)
+		newIndexes = append(newIndexes, newIdx)
+	}
+	if err := tableDesc.AllocateIDs(); err != nil {
+		return err
+	}
+	for _, idx := range newIndexes {
+		swap.NewIndexIDs = append(swap.NewIndexIDs, idx.ID)
+	}
+
+	tableDesc.AddColumnTypeSwapMutation(swap)
+	return nil
+}
+
+// checkColumnCanBeRewritten returns an error if the type of the column cannot
+// be changed by rewriting it, because the column, or an object depending on
+// it, cannot be replaced.
+func checkColumnCanBeRewritten(
+	tableDesc *sqlbase.MutableTableDescriptor, col *sqlbase.ColumnDescriptor,
+) error {
+	if _, err := tableDesc.FindActiveColumnByID(col.ID); err != nil {
+		return pgerror.Newf(pgerror.CodeObjectNotInPrerequisiteStateError,
+			"column %q in the middle of being added, try again later", col.Name)
+	}
+	if tableDesc.PrimaryIndex.ContainsColumnID(col.ID) {
+		return pgerror.UnimplementedWithIssueDetail(9851, "primary key",
+			"ALTER COLUMN TYPE requiring a rewrite of a primary key column is not supported")
+	}
+	if col.IsComputed() {
+		return pgerror.UnimplementedWithIssueDetail(9851, "computed column",
+			"ALTER COLUMN TYPE requiring a rewrite of a computed column is not supported")
+	}
+	if len(col.UsesSequenceIds) > 0 {
+		return pgerror.UnimplementedWithIssueDetail(9851, "sequence",
+			"ALTER COLUMN TYPE requiring a rewrite of a column using a sequence is not supported")
+	}
+	for _, ref := range tableDesc.DependedOnBy {
+		for _, id := range ref.ColumnIDs {
+			if id == col.ID {
+				return pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+					"cannot alter type of a column used by a view")
+			}
+		}
+	}
+	for i := range tableDesc.Indexes {
+		idx := &tableDesc.Indexes[i]
+		if !idx.ContainsColumnID(col.ID) {
+			continue
+		}
+		if idx.ForeignKey.IsSet() || len(idx.ReferencedBy) > 0 {
+			return pgerror.UnimplementedWithIssueDetail(9851, "foreign key",
+				"ALTER COLUMN TYPE requiring a rewrite of a column used by a foreign key is not supported")
+		}
+		if idx.IsInterleaved() {
+			return pgerror.UnimplementedWithIssueDetail(9851, "interleaved index",
+				"ALTER COLUMN TYPE requiring a rewrite of a column in an interleaved index is not supported")
+		}
+		if idx.Partitioning.NumColumns > 0 {
+			return pgerror.UnimplementedWithIssueDetail(9851, "partitioned index",
+				"ALTER COLUMN TYPE requiring a rewrite of a column in a partitioned index is not supported")
+		}
+	}
+
+	// Expressions referencing the column would be typed against the new column.
+	var exprs []string
+	for _, check := range tableDesc.Checks {
+		exprs = append(exprs, check.Expr)
+	}
+	for i := range tableDesc.Columns {
+		if c := &tableDesc.Columns[i]; c.IsComputed() {
+			exprs = append(exprs, *c.ComputeExpr)
+		}
+	}
+	for i := range tableDesc.Indexes {
+		if idx := &tableDesc.Indexes[i]; idx.IsPartial() {
+			exprs = append(exprs, idx.Predicate)
+		}
+	}
+	for _, s := range exprs {
+		expr, err := parser.ParseExpr(s)
+		if err != nil {
+			return err
+		}
+		if err := iterColDescriptorsInExpr(tableDesc, expr, func(c *sqlbase.ColumnDescriptor) error {
+			if c.ID == col.ID {
+				return pgerror.UnimplementedWithIssueDetailf(9851, "referenced by expression",
+					"ALTER COLUMN TYPE requiring a rewrite of a column referenced by %q is not supported", s)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// makeUniqueName returns the first of base, base1, base2, ... that is not
+// taken.
+func makeUniqueName(base string, taken func(string) bool) string {
+	name := base
+	for i := 1; taken(name); i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	return name
+}
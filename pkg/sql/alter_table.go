@@ -16,7 +16,6 @@ import (
 	"bytes"
 	"context"
 	gojson "encoding/json"
-	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -95,6 +94,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 	descriptorChanged := false
 	origNumMutations := len(n.tableDesc.Mutations)
 	var droppedViews []string
+	// identitySeqs holds the sequences created for new identity columns, which
+	// are owned by these columns once their IDs are allocated.
+	var identitySeqs []identitySequence
 	tn := params.p.ResolvedName(n.n.Table)
 
 	for i, cmd := range n.n.Cmds {
@@ -123,8 +125,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			// If the new column has a DEFAULT expression that uses a sequence, add references between
 			// its descriptor and this column descriptor.
+			var changedSeqDescs []*MutableTableDescriptor
 			if d.HasDefaultExpr() {
-				changedSeqDescs, err := maybeAddSequenceDependencies(params.ctx, params.p, n.tableDesc, col, expr)
+				changedSeqDescs, err = maybeAddSequenceDependencies(params.ctx, params.p, n.tableDesc, col, expr)
 				if err != nil {
 					return err
 				}
@@ -157,7 +160,18 @@ func (n *alterTableNode) startExec(params runParams) error {
 				}
 			}
 
+			if err := params.p.addTypeBackReferences(
+				params.ctx, n.tableDesc.ID, []*types.T{&col.Type},
+			); err != nil {
+				return err
+			}
+
 			n.tableDesc.AddColumnMutation(col, sqlbase.DescriptorMutation_ADD)
+			if d.IsIdentity() {
+				for _, seqDesc := range changedSeqDescs {
+					identitySeqs = append(identitySeqs, identitySequence{col: col, seqDesc: seqDesc})
+				}
+			}
 			if idx != nil {
 				if err := n.tableDesc.AddIndexMutation(idx, sqlbase.DescriptorMutation_ADD); err != nil {
 					return err
@@ -193,10 +207,13 @@ func (n *alterTableNode) startExec(params runParams) error {
 					return pgerror.Newf(pgerror.CodeSyntaxError,
 						"multiple primary keys for table %q are not allowed", n.tableDesc.Name)
 				}
+				// Deferrable unique indexes can temporarily contain duplicate keys, so
+				// they are encoded like non-unique indexes.
 				idx := sqlbase.IndexDescriptor{
-					Name:             string(d.Name),
-					Unique:           true,
-					StoreColumnNames: d.Storing.ToStrings(),
+					Name:                string(d.Name),
+					Unique:              d.Deferrability == tree.NotDeferrable,
+					UniqueDeferrability: sqlbase.ConstraintDeferrabilityValue[d.Deferrability],
+					StoreColumnNames:    d.Storing.ToStrings(),
 				}
 				if err := idx.FillColumns(d.Columns); err != nil {
 					return err
@@ -309,6 +326,11 @@ func (n *alterTableNode) startExec(params runParams) error {
 				}
 			}
 
+			// If the dropped column owns sequences, drop them along with it.
+			if err := params.p.dropSequencesOwnedByCol(params.ctx, col); err != nil {
+				return err
+			}
+
 			// You can't drop a column depended on by a view unless CASCADE was
 			// specified.
 			for _, ref := range n.tableDesc.DependedOnBy {
@@ -395,6 +417,13 @@ func (n *alterTableNode) startExec(params runParams) error {
 						containsThisColumn = true
 					}
 				}
+				// A partial index also depends on the columns referenced by its
+				// predicate.
+				for _, id := range idx.PredicateColumnIDs {
+					if id == col.ID {
+						containsThisColumn = true
+					}
+				}
 
 				// Perform the DROP.
 				if containsThisColumn {
@@ -413,6 +442,10 @@ func (n *alterTableNode) startExec(params runParams) error {
 				}
 			}
 
+			if err := checkNoNotNullMutation(n.tableDesc, col); err != nil {
+				return err
+			}
+
 			// Drop check constraints which reference the column.
 			validChecks := n.tableDesc.Checks[:0]
 			for _, check := range n.tableDesc.AllActiveAndInactiveChecks() {
@@ -651,6 +684,14 @@ func (n *alterTableNode) startExec(params runParams) error {
 			return err
 		}
 	}
+
+	for _, seq := range identitySeqs {
+		setSequenceOwner(seq.seqDesc, n.tableDesc.ID, seq.col)
+		if err := params.p.writeSchemaChange(params.ctx, seq.seqDesc, sqlbase.InvalidMutationID); err != nil {
+			return err
+		}
+	}
+
 	// Were some changes made?
 	//
 	// This is only really needed for the unittests that add dummy mutations
@@ -716,6 +757,13 @@ func (p *planner) setAuditMode(
 	return desc.SetAuditMode(auditMode)
 }
 
+// identitySequence is a sequence created for an identity column added by
+// ALTER TABLE.
+type identitySequence struct {
+	col     *sqlbase.ColumnDescriptor
+	seqDesc *MutableTableDescriptor
+}
+
 func (n *alterTableNode) Next(runParams) (bool, error) { return false, nil }
 func (n *alterTableNode) Values() tree.Datums          { return tree.Datums{} }
 func (n *alterTableNode) Close(context.Context)        {}
@@ -731,7 +779,10 @@ func applyColumnMutation(
 ) error {
 	switch t := mut.(type) {
 	case *tree.AlterTableAlterColumnType:
-		typ := t.ToType
+		typ, err := tree.ResolveType(t.ToType, &params.p.semaCtx)
+		if err != nil {
+			return err
+		}
 
 		// Special handling for STRING COLLATE xy to verify that we recognize the language.
 		if t.Collation != "" {
@@ -742,8 +793,7 @@ func applyColumnMutation(
 			}
 		}
 
-		err := sqlbase.ValidateColumnDefType(typ)
-		if err != nil {
+		if err := sqlbase.ValidateColumnDefType(typ); err != nil {
 			return err
 		}
 
@@ -754,28 +804,35 @@ func applyColumnMutation(
 			return nil
 		}
 
+		// A USING expression can convert between types that have no cast.
 		kind, err := schemachange.ClassifyConversion(&col.Type, typ)
-		if err != nil {
+		if err != nil && t.Using == nil {
 			return err
 		}
 
-		switch kind {
-		case schemachange.ColumnConversionDangerous, schemachange.ColumnConversionImpossible:
+		switch {
+		case t.Using != nil:
+			// The values are converted by the USING expression.
+			return alterColumnTypeGeneral(tableDesc, col, typ, t.Using, params)
+		case kind == schemachange.ColumnConversionDangerous,
+			kind == schemachange.ColumnConversionImpossible:
 			// We're not going to make it impossible for the user to perform
 			// this conversion, but we do want them to explicit about
 			// what they're going for.
 			return pgerror.Newf(pgerror.CodeCannotCoerceError,
 				"the requested type conversion (%s -> %s) requires an explicit USING expression",
 				col.Type.SQLString(), typ.SQLString())
-		case schemachange.ColumnConversionTrivial:
+		case kind == schemachange.ColumnConversionTrivial:
 			col.Type = *typ
 		default:
-			return pgerror.UnimplementedWithIssueDetail(9851,
-				fmt.Sprintf("%s->%s", col.Type.SQLString(), typ.SQLString()),
-				"type conversion not yet implemented")
+			// The values are converted by a cast to the new type.
+			return alterColumnTypeGeneral(tableDesc, col, typ, nil /* using */, params)
 		}
 
 	case *tree.AlterTableSetDefault:
+		if col.IsIdentity() {
+			return identityColumnAlterError(tableDesc, col)
+		}
 		if len(col.UsesSequenceIds) > 0 {
 			if err := removeSequenceDependencies(tableDesc, col, params); err != nil {
 				return err
@@ -806,7 +863,38 @@ func applyColumnMutation(
 			}
 		}
 
+	case *tree.AlterTableSetNotNull:
+		if !col.Nullable {
+			return nil
+		}
+		if err := checkNoNotNullMutation(tableDesc, col); err != nil {
+			return err
+		}
+		info, err := tableDesc.GetConstraintInfo(params.ctx, nil)
+		if err != nil {
+			return err
+		}
+		inuseNames := make(map[string]struct{}, len(info))
+		for k := range info {
+			inuseNames[k] = struct{}{}
+		}
+		for _, c := range tableDesc.AllActiveAndInactiveChecks() {
+			inuseNames[c.Name] = struct{}{}
+		}
+		// The column is marked as not nullable by the schema changer, once all
+		// the existing rows have been validated. In the meantime, the check
+		// constraint enforces NOT NULL for new writes.
+		check := sqlbase.MakeNotNullCheckConstraint(
+			col.Name, col.ID, inuseNames, sqlbase.ConstraintValidity_Validating)
+		tableDesc.AddNotNullMutation(check, sqlbase.DescriptorMutation_ADD)
+
 	case *tree.AlterTableDropNotNull:
+		if col.IsIdentity() {
+			return identityColumnAlterError(tableDesc, col)
+		}
+		if err := checkNoNotNullMutation(tableDesc, col); err != nil {
+			return err
+		}
 		col.Nullable = true
 
 	case *tree.AlterTableDropStored:
@@ -819,6 +907,31 @@ func applyColumnMutation(
 	return nil
 }
 
+// checkNoNotNullMutation returns an error if the column is in the middle of
+// being made NOT NULL.
+// identityColumnAlterError returns the error for an ALTER COLUMN command that
+// would change the default expression or nullability of an identity column.
+func identityColumnAlterError(
+	tableDesc *sqlbase.MutableTableDescriptor, col *sqlbase.ColumnDescriptor,
+) error {
+	return pgerror.Newf(pgerror.CodeSyntaxError,
+		"column %q of relation %q is an identity column", col.Name, tableDesc.Name)
+}
+
+func checkNoNotNullMutation(
+	tableDesc *sqlbase.MutableTableDescriptor, col *sqlbase.ColumnDescriptor,
+) error {
+	for i := range tableDesc.Mutations {
+		if c := tableDesc.Mutations[i].GetConstraint(); c != nil &&
+			c.ConstraintType == sqlbase.ConstraintToUpdate_NOT_NULL && c.NotNullColumn == col.ID {
+			return pgerror.Newf(pgerror.CodeObjectNotInPrerequisiteStateError,
+				"NOT NULL constraint on column %q in the middle of being added, try again later",
+				col.Name)
+		}
+	}
+	return nil
+}
+
 func labeledRowValues(cols []sqlbase.ColumnDescriptor, values tree.Datums) string {
 	var s bytes.Buffer
 	for i := range cols {
@@ -0,0 +1,351 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/enum"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+type alterTypeNode struct {
+	n       *tree.AlterTypeAddValue
+	typDesc *sqlbase.TypeDescriptor
+}
+
+// AlterTypeAddValue transforms a tree.AlterTypeAddValue into a plan node.
+func (p *planner) AlterTypeAddValue(
+	ctx context.Context, n *tree.AlterTypeAddValue,
+) (planNode, error) {
+	dbDesc, err := p.ResolveUncachedDatabase(ctx, &n.TypeName)
+	if err != nil {
+		return nil, err
+	}
+	typDesc, err := lookupTypeDesc(ctx, p.txn, dbDesc.ID, n.TypeName.Table())
+	if err != nil {
+		return nil, err
+	}
+	if typDesc == nil {
+		return nil, pgerror.Newf(pgerror.CodeUndefinedObjectError,
+			"type %q does not exist", n.TypeName.Table())
+	}
+
+	if typDesc.Kind != sqlbase.TypeDescriptor_ENUM {
+		return nil, pgerror.Newf(pgerror.CodeWrongObjectTypeError,
+			"%q is not an enum", n.TypeName.Table())
+	}
+
+	if err := p.CheckPrivilege(ctx, typDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	return &alterTypeNode{n: n, typDesc: typDesc}, nil
+}
+
+func (n *alterTypeNode) startExec(params runParams) error {
+	desc := n.typDesc
+	members := desc.EnumMembers
+
+	for i := range members {
+		if members[i].LogicalRepresentation == n.n.NewVal {
+			if n.n.IfNotExists {
+				return nil
+			}
+			return pgerror.Newf(pgerror.CodeDuplicateObjectError,
+				"enum label %q already exists", n.n.NewVal)
+		}
+	}
+
+	// Find the position of the new value, and the physical representations
+	// of its neighbors.
+	pos := len(members)
+	if n.n.Placement != nil {
+		pos = -1
+		for i := range members {
+			if members[i].LogicalRepresentation == n.n.Placement.ExistingVal {
+				pos = i
+				break
+			}
+		}
+		if pos == -1 {
+			return pgerror.Newf(pgerror.CodeInvalidParameterValueError,
+				"%q is not an existing enum label", n.n.Placement.ExistingVal)
+		}
+		if !n.n.Placement.Before {
+			pos++
+		}
+	}
+	var prev, next []byte
+	if pos > 0 {
+		prev = members[pos-1].PhysicalRepresentation
+	}
+	if pos < len(members) {
+		next = members[pos].PhysicalRepresentation
+	}
+
+	// The new value can only be written once every node is able to decode it,
+	// which the schema changers of the tables that use the type ensure.
+	capability := sqlbase.TypeDescriptor_EnumMember_ALL
+	if len(desc.ReferencingDescriptorIDs) > 0 {
+		capability = sqlbase.TypeDescriptor_EnumMember_READ_ONLY
+	}
+	newMember := sqlbase.TypeDescriptor_EnumMember{
+		LogicalRepresentation:  n.n.NewVal,
+		PhysicalRepresentation: enum.GenByteStringBetween(prev, next),
+		Capability:             capability,
+	}
+	desc.EnumMembers = append(desc.EnumMembers, sqlbase.TypeDescriptor_EnumMember{})
+	copy(desc.EnumMembers[pos+1:], desc.EnumMembers[pos:])
+	desc.EnumMembers[pos] = newMember
+	desc.Version++
+
+	if err := desc.Validate(); err != nil {
+		return err
+	}
+	kvTrace := params.p.ExtendedEvalContext().Tracing.KVTracingEnabled()
+	if err := writeTypeDesc(params.ctx, params.p.txn, desc, kvTrace); err != nil {
+		return err
+	}
+
+	// Update the column types of the tables that use the type, so that they
+	// are able to decode the new value.
+	typ := desc.MakeTypesT()
+	for _, id := range desc.ReferencingDescriptorIDs {
+		tableDesc, err := params.p.Tables().getMutableTableVersionByID(params.ctx, id, params.p.txn)
+		if err != nil {
+			if err == sqlbase.ErrDescriptorNotFound {
+				continue
+			}
+			return err
+		}
+		if tableDesc.Dropped() || !setEnumColumnTypes(tableDesc.TableDesc(), typ) {
+			continue
+		}
+		if err := params.p.writeSchemaChange(params.ctx, tableDesc, sqlbase.InvalidMutationID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*alterTypeNode) Next(runParams) (bool, error) { return false, nil }
+func (*alterTypeNode) Values() tree.Datums          { return tree.Datums{} }
+func (*alterTypeNode) Close(context.Context)        {}
+
+// writeTypeDesc writes a type descriptor to the database within the given
+// transaction.
+func writeTypeDesc(
+	ctx context.Context, txn *client.Txn, desc *sqlbase.TypeDescriptor, kvTrace bool,
+) error {
+	descKey := sqlbase.MakeDescMetadataKey(desc.ID)
+	descVal := sqlbase.WrapDescriptor(desc)
+	if kvTrace {
+		log.VEventf(ctx, 2, "Put %s -> %s", descKey, descVal)
+	}
+	return txn.Put(ctx, descKey, descVal)
+}
+
+// columnTypes returns the types of all the columns of the table, including
+// the columns that are being added or dropped.
+func columnTypes(desc *sqlbase.TableDescriptor) []*types.T {
+	res := make([]*types.T, 0, len(desc.Columns)+len(desc.Mutations))
+	for i := range desc.Columns {
+		res = append(res, &desc.Columns[i].Type)
+	}
+	for _, m := range desc.Mutations {
+		if col := m.GetColumn(); col != nil {
+			res = append(res, &col.Type)
+		}
+	}
+	return res
+}
+
+// setEnumColumnTypes replaces the types of the columns of the table that are
+// of the given enum type with typ. It returns whether any column was changed.
+func setEnumColumnTypes(desc *sqlbase.TableDescriptor, typ *types.T) bool {
+	changed := false
+	for _, t := range columnTypes(desc) {
+		if t.Family() == types.EnumFamily && t.TypeID() == typ.TypeID() {
+			*t = *typ
+			changed = true
+		}
+	}
+	return changed
+}
+
+// addTypeBackReferences records in the descriptors of the user-defined types
+// of the given new columns of the table with the given ID that the table uses
+// them. The values of enum types are all made writable in the columns, since
+// every node that knows about the columns is able to decode them.
+func (p *planner) addTypeBackReferences(
+	ctx context.Context, tableID sqlbase.ID, colTypes []*types.T,
+) error {
+	kvTrace := p.ExtendedEvalContext().Tracing.KVTracingEnabled()
+	var typeIDs []sqlbase.ID
+	for _, t := range colTypes {
+		if t.TypeID() == 0 {
+			continue
+		}
+		if id := sqlbase.ID(t.TypeID()); !containsID(typeIDs, id) {
+			typeIDs = append(typeIDs, id)
+			typDesc, err := sqlbase.GetTypeDescFromID(ctx, p.txn, id)
+			if err != nil {
+				return err
+			}
+			typDesc.AddReferencingDescriptorID(tableID)
+			typDesc.Version++
+			if err := writeTypeDesc(ctx, p.txn, typDesc, kvTrace); err != nil {
+				return err
+			}
+		}
+		if data := t.EnumData(); data != nil {
+			*t = *types.MakeEnum(data.TypeID, data.Name, data.LogicalRepresentations,
+				data.PhysicalRepresentations, make([]bool, len(data.IsMemberReadOnly)))
+		}
+	}
+	return nil
+}
+
+// removeTypeBackReferences removes the references from the descriptors of the
+// user-defined types used by the given dropped table. Enum values that were
+// only kept read-only because of the table become writable.
+func (p *planner) removeTypeBackReferences(
+	ctx context.Context, tableDesc *sqlbase.TableDescriptor,
+) error {
+	kvTrace := p.ExtendedEvalContext().Tracing.KVTracingEnabled()
+	var typeIDs []sqlbase.ID
+	for _, t := range columnTypes(tableDesc) {
+		if t.TypeID() == 0 {
+			continue
+		}
+		id := sqlbase.ID(t.TypeID())
+		if containsID(typeIDs, id) {
+			continue
+		}
+		typeIDs = append(typeIDs, id)
+		typDesc, err := sqlbase.GetTypeDescFromID(ctx, p.txn, id)
+		if err != nil {
+			if err == sqlbase.ErrDescriptorNotFound {
+				continue
+			}
+			return err
+		}
+		typDesc.RemoveReferencingDescriptorID(tableDesc.ID)
+		typDesc.Version++
+		if err := writeTypeDesc(ctx, p.txn, typDesc, kvTrace); err != nil {
+			return err
+		}
+		if err := maybePromoteEnumType(ctx, p.txn, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enumTypeHasReadOnlyMembers returns whether t is an enum type with values
+// that cannot be written yet.
+func enumTypeHasReadOnlyMembers(t *types.T) bool {
+	if t.Family() != types.EnumFamily || t.EnumData() == nil {
+		return false
+	}
+	for _, readOnly := range t.EnumData().IsMemberReadOnly {
+		if readOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// tableHasReadOnlyEnumMembers returns whether a column of the table has an
+// enum type with values that cannot be written yet.
+func tableHasReadOnlyEnumMembers(desc *sqlbase.TableDescriptor) bool {
+	for _, t := range columnTypes(desc) {
+		if enumTypeHasReadOnlyMembers(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// promoteEnumColumnTypes makes all the values of the enum types of the columns
+// of the table writable. It returns the IDs of the types that had values that
+// were not writable.
+func promoteEnumColumnTypes(desc *sqlbase.TableDescriptor) []sqlbase.ID {
+	var typeIDs []sqlbase.ID
+	for _, t := range columnTypes(desc) {
+		if !enumTypeHasReadOnlyMembers(t) {
+			continue
+		}
+		data := t.EnumData()
+		*t = *types.MakeEnum(data.TypeID, data.Name, data.LogicalRepresentations,
+			data.PhysicalRepresentations, make([]bool, len(data.IsMemberReadOnly)))
+		if id := sqlbase.ID(data.TypeID); !containsID(typeIDs, id) {
+			typeIDs = append(typeIDs, id)
+		}
+	}
+	return typeIDs
+}
+
+func containsID(ids []sqlbase.ID, id sqlbase.ID) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
+
+// maybePromoteEnumType makes all the values of the type with the given ID
+// writable if none of the tables that use the type still needs some of them
+// to be read-only.
+func maybePromoteEnumType(ctx context.Context, txn *client.Txn, id sqlbase.ID) error {
+	typDesc, err := sqlbase.GetTypeDescFromID(ctx, txn, id)
+	if err != nil {
+		if err == sqlbase.ErrDescriptorNotFound {
+			return nil
+		}
+		return err
+	}
+	if !typDesc.HasReadOnlyMembers() {
+		return nil
+	}
+	for _, refID := range typDesc.ReferencingDescriptorIDs {
+		tableDesc, err := sqlbase.GetTableDescFromID(ctx, txn, refID)
+		if err != nil {
+			if err == sqlbase.ErrDescriptorNotFound {
+				continue
+			}
+			return err
+		}
+		if tableDesc.Dropped() {
+			continue
+		}
+		for _, t := range columnTypes(tableDesc) {
+			if t.TypeID() == uint32(id) && enumTypeHasReadOnlyMembers(t) {
+				return nil
+			}
+		}
+	}
+	for i := range typDesc.EnumMembers {
+		typDesc.EnumMembers[i].Capability = sqlbase.TypeDescriptor_EnumMember_ALL
+	}
+	typDesc.Version++
+	return writeTypeDesc(ctx, txn, typDesc, false /* kvTrace */)
+}
@@ -289,9 +289,16 @@ func (a *applyJoinNode) Next(params runParams) (bool, error) {
 func (a *applyJoinNode) runRightSidePlan(params runParams, plan *planTop) error {
 	a.run.curRightRow = 0
 	a.run.rightRows.Clear(params.ctx)
-	rowResultWriter := NewRowResultWriter(a.run.rightRows)
+	return runPlanInsidePlan(params, plan, NewRowResultWriter(a.run.rightRows))
+}
+
+// runPlanInsidePlan is used to run a plan and gather the results in a row
+// container, as part of the execution of an "outer" plan.
+func runPlanInsidePlan(
+	params runParams, plan *planTop, resultWriter rowResultWriter,
+) error {
 	recv := MakeDistSQLReceiver(
-		params.ctx, rowResultWriter, tree.Rows,
+		params.ctx, resultWriter, tree.Rows,
 		params.extendedEvalCtx.ExecCfg.RangeDescriptorCache,
 		params.extendedEvalCtx.ExecCfg.LeaseHolderCache,
 		params.p.Txn(),
@@ -310,7 +317,7 @@ func (a *applyJoinNode) runRightSidePlan(params runParams, plan *planTop) error
 		recv,
 		true,
 	) {
-		if err := rowResultWriter.Err(); err != nil {
+		if err := resultWriter.Err(); err != nil {
 			return err
 		}
 		return recv.commErr
@@ -332,8 +339,7 @@ func (a *applyJoinNode) runRightSidePlan(params runParams, plan *planTop) error
 	if recv.commErr != nil {
 		return recv.commErr
 	}
-	return rowResultWriter.err
-
+	return resultWriter.Err()
 }
 
 func (a *applyJoinNode) Values() tree.Datums {
@@ -158,6 +158,8 @@ func (sc *SchemaChanger) runBackfill(
 			case *sqlbase.DescriptorMutation_Constraint:
 				constraintsToAdd = append(constraintsToAdd, *t.Constraint)
 				constraintsToValidate = append(constraintsToValidate, *t.Constraint)
+			case *sqlbase.DescriptorMutation_ColumnTypeSwap:
+				// no-op
 			default:
 				return pgerror.AssertionFailedf(
 					"unsupported mutation: %+v", m)
@@ -178,6 +180,8 @@ func (sc *SchemaChanger) runBackfill(
 						"trying to drop constraint through schema changer outside of a rollback: %+v", t)
 				}
 				// no-op
+			case *sqlbase.DescriptorMutation_ColumnTypeSwap:
+				// Only possible during a rollback, nothing to undo.
 			default:
 				return pgerror.AssertionFailedf(
 					"unsupported mutation: %+v", m)
@@ -243,7 +247,7 @@ func (sc *SchemaChanger) AddConstraints(
 		func(desc *sqlbase.MutableTableDescriptor) error {
 			for i, added := range constraints {
 				switch added.ConstraintType {
-				case sqlbase.ConstraintToUpdate_CHECK:
+				case sqlbase.ConstraintToUpdate_CHECK, sqlbase.ConstraintToUpdate_NOT_NULL:
 					found := false
 					for _, c := range desc.Checks {
 						if c.Name == added.Name {
@@ -346,7 +350,7 @@ func (sc *SchemaChanger) validateConstraints(
 				// goroutines.
 				newEvalCtx := createSchemaChangeEvalCtx(ctx, readAsOf, evalCtx.Tracing, sc.ieFactory)
 				switch c.ConstraintType {
-				case sqlbase.ConstraintToUpdate_CHECK:
+				case sqlbase.ConstraintToUpdate_CHECK, sqlbase.ConstraintToUpdate_NOT_NULL:
 					if err := validateCheckInTxn(ctx, sc.leaseMgr, &newEvalCtx.EvalContext, desc, txn, c.Name); err != nil {
 						return err
 					}
@@ -1009,6 +1013,14 @@ func (sc *SchemaChanger) validateForwardIndexes(
 				ie.impl.tcModifier = nil
 			}()
 
+			if idx.IsPartial() {
+				// The predicate is evaluated under the same session data as
+				// when the index entries are written, so that both counts agree.
+				pie := sc.ieFactory(ctx, sqlbase.NewRowExprSessionData()).(*SessionBoundInternalExecutor)
+				pie.impl.tcModifier = tc
+				return validatePartialIndex(ctx, pie, txn, tableDesc, readAsOf, idx, start)
+			}
+
 			row, err := newEvalCtx.InternalExecutor.QueryRow(ctx, "verify-idx-count", txn,
 				fmt.Sprintf(`SELECT count(1) FROM [%d AS t]@[%d] AS OF SYSTEM TIME %s`,
 					tableDesc.ID, idx.ID, readAsOf.AsOfSystemTime()))
@@ -1060,6 +1072,48 @@ func (sc *SchemaChanger) validateForwardIndexes(
 	return grp.Wait()
 }
 
+// validatePartialIndex checks that the partial index contains exactly the rows
+// of the table that satisfy its predicate. The rows of the table are counted
+// using the primary index. ie must be bound to the session data returned by
+// sqlbase.NewRowExprSessionData.
+func validatePartialIndex(
+	ctx context.Context,
+	ie tree.SessionBoundInternalExecutor,
+	txn *client.Txn,
+	tableDesc *TableDescriptor,
+	readAsOf hlc.Timestamp,
+	idx *sqlbase.IndexDescriptor,
+	start time.Time,
+) error {
+	row, err := ie.QueryRow(ctx, "verify-idx-count", txn,
+		fmt.Sprintf(`SELECT count(1) FROM [%d AS t]@[%d] AS OF SYSTEM TIME %s WHERE %s`,
+			tableDesc.ID, idx.ID, readAsOf.AsOfSystemTime(), idx.Predicate))
+	if err != nil {
+		return err
+	}
+	idxLen := int64(tree.MustBeDInt(row[0]))
+
+	log.Infof(ctx, "validation: index %s/%s row count = %d, took %s",
+		tableDesc.Name, idx.Name, idxLen, timeutil.Since(start))
+
+	row, err = ie.QueryRow(ctx, "verify-partial-idx-count", txn,
+		fmt.Sprintf(`SELECT count(1) FROM [%d AS t]@[%d] AS OF SYSTEM TIME %s WHERE %s`,
+			tableDesc.ID, tableDesc.PrimaryIndex.ID, readAsOf.AsOfSystemTime(), idx.Predicate))
+	if err != nil {
+		return err
+	}
+	expectedLen := int64(tree.MustBeDInt(row[0]))
+
+	if idxLen != expectedLen {
+		// The backfill reports the rows that violate a unique partial index, so
+		// if the counts do not match, it's always a bug.
+		return pgerror.AssertionFailedf(
+			"validation of partial index %s failed: expected %d rows, found %d",
+			idx.Name, log.Safe(expectedLen), log.Safe(idxLen))
+	}
+	return nil
+}
+
 func (sc *SchemaChanger) backfillIndexes(
 	ctx context.Context,
 	evalCtx *extendedEvalContext,
@@ -1139,8 +1193,13 @@ func runSchemaChangesInTxn(
 	doneColumnBackfill := false
 	// Checks are validated after all other mutations have been applied.
 	var constraintsToValidate []sqlbase.ConstraintToUpdate
+	// NOT NULL constraint mutations are only completed after validation.
+	var notNullMutations []sqlbase.DescriptorMutation
 
-	for _, m := range tableDesc.Mutations {
+	// Completing a column type swap queues more mutations, which are processed
+	// by this loop as well.
+	for i := 0; i < len(tableDesc.Mutations); i++ {
+		m := tableDesc.Mutations[i]
 		immutDesc := sqlbase.NewImmutableTableDescriptor(*tableDesc.TableDesc())
 		switch m.Direction {
 		case sqlbase.DescriptorMutation_ADD:
@@ -1163,6 +1222,13 @@ func runSchemaChangesInTxn(
 				switch t.Constraint.ConstraintType {
 				case sqlbase.ConstraintToUpdate_CHECK:
 					tableDesc.Checks = append(tableDesc.Checks, &t.Constraint.Check)
+				case sqlbase.ConstraintToUpdate_NOT_NULL:
+					// The column can only be marked as not nullable once it has been
+					// validated, so the mutation is completed after the validation.
+					tableDesc.Checks = append(tableDesc.Checks, &t.Constraint.Check)
+					constraintsToValidate = append(constraintsToValidate, *t.Constraint)
+					notNullMutations = append(notNullMutations, m)
+					continue
 				case sqlbase.ConstraintToUpdate_FOREIGN_KEY:
 					idx, err := tableDesc.FindIndexByID(t.Constraint.ForeignKeyIndex)
 					if err != nil {
@@ -1175,6 +1241,11 @@ func runSchemaChangesInTxn(
 				}
 				constraintsToValidate = append(constraintsToValidate, *t.Constraint)
 
+			case *sqlbase.DescriptorMutation_ColumnTypeSwap:
+				// The replaced column is dropped by a mutation queued by the
+				// swap, which needs its own column backfill.
+				doneColumnBackfill = false
+
 			default:
 				return pgerror.AssertionFailedf(
 					"unsupported mutation: %+v", m)
@@ -1216,7 +1287,7 @@ func runSchemaChangesInTxn(
 	// mutations applied, it can be used for validating check constraints
 	for _, c := range constraintsToValidate {
 		switch c.ConstraintType {
-		case sqlbase.ConstraintToUpdate_CHECK:
+		case sqlbase.ConstraintToUpdate_CHECK, sqlbase.ConstraintToUpdate_NOT_NULL:
 			if err := validateCheckInTxn(ctx, tc.leaseMgr, evalCtx, tableDesc, txn, c.Name); err != nil {
 				return err
 			}
@@ -1243,6 +1314,11 @@ func runSchemaChangesInTxn(
 				"unsupported constraint type: %d", log.Safe(c.ConstraintType))
 		}
 	}
+	for _, m := range notNullMutations {
+		if err := tableDesc.MakeMutationComplete(m); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1280,7 +1356,20 @@ func validateCheckInTxn(
 	if err != nil {
 		return err
 	}
-	return validateCheckExpr(ctx, check.Expr, tableDesc.TableDesc(), ie, txn)
+	err = validateCheckExpr(ctx, check.Expr, tableDesc.TableDesc(), ie, txn)
+	if err != nil && check.IsNonNullConstraint {
+		if pgErr, ok := pgerror.GetPGCause(err); ok && pgErr.Code == pgerror.CodeCheckViolationError {
+			// Report the violation without exposing the temporary check
+			// constraint enforcing NOT NULL.
+			col, err := tableDesc.FindColumnByID(check.ColumnIDs[0])
+			if err != nil {
+				return err
+			}
+			return pgerror.Newf(pgerror.CodeNotNullViolationError,
+				"column %q contains null values", col.Name)
+		}
+	}
+	return err
 }
 
 // validateFkInTxn validates foreign key constraints within the provided
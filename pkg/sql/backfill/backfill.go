@@ -123,7 +123,13 @@ func (cb *ColumnBackfiller) Init(
 		ValNeededForCol: valNeededForCol,
 	}
 	return cb.fetcher.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &cb.alloc, tableArgs,
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		&cb.alloc,
+		tableArgs,
 	)
 }
 
@@ -234,6 +240,13 @@ func (cb *ColumnBackfiller) RunColumnBackfillChunk(
 			if j < len(cb.added) && !cb.added[j].Nullable && val == tree.DNull {
 				return roachpb.Key{}, sqlbase.NewNonNullViolationError(cb.added[j].Name)
 			}
+			if j < len(cb.added) {
+				// As with INSERT, the values must fit the width of the column.
+				val, err = sqlbase.LimitValueWidth(&cb.added[j].Type, val, &cb.added[j].Name)
+				if err != nil {
+					return roachpb.Key{}, err
+				}
+			}
 
 			// Added computed column values should be usable for the next
 			// added columns being backfilled. They have already been type
@@ -294,6 +307,13 @@ type IndexBackfiller struct {
 
 	types   []types.T
 	rowVals tree.Datums
+
+	// partialIndexes is nil if none of the added indexes is partial.
+	partialIndexes *sqlbase.PartialIndexHelper
+
+	// virtualCols is nil if none of the columns of the table is a virtual
+	// computed column.
+	virtualCols *sqlbase.VirtualColumnHelper
 }
 
 // ContainsInvertedIndex returns true if backfilling an inverted index.
@@ -336,6 +356,32 @@ func (ib *IndexBackfiller) Init(desc *sqlbase.ImmutableTableDescriptor) error {
 				if idx.ContainsColumnID(id) {
 					valNeededForCol.Add(i)
 				}
+				for _, predID := range idx.PredicateColumnIDs {
+					if predID == id {
+						valNeededForCol.Add(i)
+					}
+				}
+			}
+		}
+	}
+
+	var err error
+	ib.partialIndexes, err = sqlbase.NewPartialIndexHelper(desc.TableDesc(), cols, ib.added)
+	if err != nil {
+		return err
+	}
+
+	// The virtual computed columns are not stored in the primary index, so the
+	// columns they reference are fetched instead.
+	ib.virtualCols, err = sqlbase.NewVirtualColumnHelper(desc.TableDesc(), cols)
+	if err != nil {
+		return err
+	}
+	if ib.virtualCols != nil {
+		for i := range cols {
+			if cols[i].Virtual && valNeededForCol.Contains(i) {
+				valNeededForCol.Remove(i)
+				valNeededForCol.UnionWith(ib.virtualCols.Dependencies(i))
 			}
 		}
 	}
@@ -358,7 +404,13 @@ func (ib *IndexBackfiller) Init(desc *sqlbase.ImmutableTableDescriptor) error {
 		ValNeededForCol: valNeededForCol,
 	}
 	return ib.fetcher.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, &ib.alloc, tableArgs,
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		&ib.alloc,
+		tableArgs,
 	)
 }
 
@@ -407,6 +459,11 @@ func (ib *IndexBackfiller) BuildIndexEntriesChunk(
 		if err := sqlbase.EncDatumRowToDatums(ib.types, ib.rowVals, encRow, &ib.alloc); err != nil {
 			return nil, nil, err
 		}
+		if ib.virtualCols != nil {
+			if err := ib.virtualCols.ComputeRow(ib.rowVals); err != nil {
+				return nil, nil, err
+			}
+		}
 
 		// We're resetting the length of this slice for variable length indexes such as inverted
 		// indexes which can append entries to the end of the slice. If we don't do this, then everything
@@ -418,6 +475,26 @@ func (ib *IndexBackfiller) BuildIndexEntriesChunk(
 			ib.rowVals, buffer); err != nil {
 			return nil, nil, err
 		}
+		if ib.partialIndexes != nil {
+			// Partial indexes can't be inverted, so the entry of the i-th added
+			// index is at position i of the buffer. Drop the entries of the
+			// partial indexes that don't contain the row.
+			ib.partialIndexes.LoadRow(ib.colIdxMap, ib.rowVals)
+			filtered := buffer[:0]
+			for i := range buffer {
+				if i < len(ib.added) {
+					ok, err := ib.partialIndexes.IndexContainsRow(i)
+					if err != nil {
+						return nil, nil, err
+					}
+					if !ok {
+						continue
+					}
+				}
+				filtered = append(filtered, buffer[i])
+			}
+			buffer = filtered
+		}
 		entries = append(entries, buffer...)
 	}
 	return entries, ib.fetcher.Key(), nil
@@ -15,9 +15,16 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/distsqlrun"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/storage/diskmap"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 )
 
 // bufferNode consumes its input one row at a time, stores it in the buffer,
@@ -26,18 +33,15 @@ import (
 type bufferNode struct {
 	plan planNode
 
-	// TODO(yuzefovich): the buffer should probably be backed by disk. If so, the
-	// comments about TempStorage suggest that it should be used by DistSQL
-	// processors, but this node is local.
-	bufferedRows       *rowcontainer.RowContainer
-	passThruNextRowIdx int
+	// label is a string used to describe the node in an EXPLAIN output.
+	label string
+
+	bufferedRows *rowContainerHelper
 }
 
 func (n *bufferNode) startExec(params runParams) error {
-	n.bufferedRows = rowcontainer.NewRowContainer(
-		params.EvalContext().Mon.MakeBoundAccount(),
-		sqlbase.ColTypeInfoFromResCols(getPlanColumns(n.plan, false /* mut */)),
-		0, /* rowCapacity */
+	n.bufferedRows = newRowContainerHelper(
+		params, getPlanColumns(n.plan, false /* mut */), "buffer",
 	)
 	return nil
 }
@@ -53,20 +57,21 @@ func (n *bufferNode) Next(params runParams) (bool, error) {
 	if !ok {
 		return false, nil
 	}
-	if _, err = n.bufferedRows.AddRow(params.ctx, n.plan.Values()); err != nil {
+	if err := n.bufferedRows.addRow(params.ctx, n.plan.Values()); err != nil {
 		return false, err
 	}
-	n.passThruNextRowIdx++
 	return true, nil
 }
 
 func (n *bufferNode) Values() tree.Datums {
-	return n.bufferedRows.At(n.passThruNextRowIdx - 1)
+	return n.plan.Values()
 }
 
 func (n *bufferNode) Close(ctx context.Context) {
 	n.plan.Close(ctx)
-	n.bufferedRows.Close(ctx)
+	if n.bufferedRows != nil {
+		n.bufferedRows.close(ctx)
+	}
 }
 
 // scanBufferNode behaves like an iterator into the bufferNode it is
@@ -75,21 +80,343 @@ func (n *bufferNode) Close(ctx context.Context) {
 type scanBufferNode struct {
 	buffer *bufferNode
 
-	nextRowIdx int
+	// label is a string used to describe the node in an EXPLAIN output.
+	label string
+
+	iterator *rowContainerIterator
+	current  tree.Datums
 }
 
 func (n *scanBufferNode) startExec(runParams) error {
 	return nil
 }
 
-func (n *scanBufferNode) Next(runParams) (bool, error) {
-	n.nextRowIdx++
-	return n.nextRowIdx <= n.buffer.bufferedRows.Len(), nil
+func (n *scanBufferNode) Next(params runParams) (bool, error) {
+	// The iterator is created lazily, since the buffer might only be fully
+	// populated after this node is started.
+	if n.iterator == nil {
+		n.iterator = n.buffer.bufferedRows.newIterator(params.ctx)
+	}
+	var err error
+	n.current, err = n.iterator.next()
+	if err != nil {
+		return false, err
+	}
+	return n.current != nil, nil
 }
 
 func (n *scanBufferNode) Values() tree.Datums {
-	return n.buffer.bufferedRows.At(n.nextRowIdx - 1)
+	return n.current
 }
 
 func (n *scanBufferNode) Close(context.Context) {
+	if n.iterator != nil {
+		n.iterator.close()
+		n.iterator = nil
+	}
+}
+
+// rowContainerHelper stores rows for local planNodes that need to buffer them.
+// The rows are kept in memory until the memory limit for buffering operators
+// (sql.distsql.temp_storage.workmem) is reached, at which point they spill to
+// temporary storage.
+type rowContainerHelper struct {
+	rows        rowcontainer.DiskBackedRowContainer
+	memMonitor  mon.BytesMonitor
+	diskMonitor *mon.BytesMonitor
+
+	types   []types.T
+	scratch sqlbase.EncDatumRow
+}
+
+// newRowContainerHelper creates a rowContainerHelper for rows with the given
+// columns. The opName is used to name the memory and disk monitors.
+func newRowContainerHelper(
+	params runParams, cols sqlbase.ResultColumns, opName string,
+) *rowContainerHelper {
+	evalCtx := params.EvalContext()
+	distSQLCfg := &params.extendedEvalCtx.ExecCfg.DistSQLSrv.ServerConfig
+
+	c := &rowContainerHelper{
+		types:   make([]types.T, len(cols)),
+		scratch: make(sqlbase.EncDatumRow, len(cols)),
+	}
+	for i := range cols {
+		c.types[i] = *cols[i].Typ
+	}
+
+	// Limit the memory use by creating a child monitor with a hard limit. The
+	// container will overflow to disk if this limit is not enough.
+	limit := distsqlrun.SettingWorkMemBytes.Get(&evalCtx.Settings.SV)
+	c.memMonitor = mon.MakeMonitorInheritWithLimit(opName+"-limited", limit, evalCtx.Mon)
+	c.memMonitor.Start(params.ctx, evalCtx.Mon, mon.BoundAccount{})
+	c.diskMonitor = distsqlrun.NewMonitor(params.ctx, distSQLCfg.DiskMonitor, opName+"-disk")
+
+	c.rows.Init(
+		nil, /* ordering */
+		c.types,
+		evalCtx,
+		distSQLCfg.TempStorage,
+		&c.memMonitor,
+		c.diskMonitor,
+		0, /* rowCapacity */
+	)
+	return c
+}
+
+// addRow adds a copy of the given row to the container.
+func (c *rowContainerHelper) addRow(ctx context.Context, row tree.Datums) error {
+	for i := range row {
+		c.scratch[i] = sqlbase.DatumToEncDatum(&c.types[i], row[i])
+	}
+	return c.rows.AddRow(ctx, c.scratch)
+}
+
+// len returns the number of rows in the container.
+func (c *rowContainerHelper) len() int {
+	return c.rows.Len()
+}
+
+// newIterator returns an iterator over the rows in the container, in the order
+// in which they were added.
+func (c *rowContainerHelper) newIterator(ctx context.Context) *rowContainerIterator {
+	it := &rowContainerIterator{
+		iter:   c.rows.NewIterator(ctx),
+		types:  c.types,
+		datums: make(tree.Datums, len(c.types)),
+	}
+	it.iter.Rewind()
+	return it
+}
+
+// close releases the resources held by the container, including any temporary
+// storage.
+func (c *rowContainerHelper) close(ctx context.Context) {
+	c.rows.Close(ctx)
+	c.diskMonitor.Stop(ctx)
+	c.memMonitor.Stop(ctx)
+}
+
+// rowContainerIterator iterates over the rows of a rowContainerHelper.
+type rowContainerIterator struct {
+	iter   rowcontainer.RowIterator
+	types  []types.T
+	datums tree.Datums
+	alloc  sqlbase.DatumAlloc
+}
+
+// next returns the next row, or nil if there are no more rows. The returned
+// row is only valid until the next call to next.
+func (i *rowContainerIterator) next() (tree.Datums, error) {
+	if valid, err := i.iter.Valid(); err != nil || !valid {
+		return nil, err
+	}
+	row, err := i.iter.Row()
+	if err != nil {
+		return nil, err
+	}
+	for j := range row {
+		if err := row[j].EnsureDecoded(&i.types[j], &i.alloc); err != nil {
+			return nil, err
+		}
+		i.datums[j] = row[j].Datum
+	}
+	i.iter.Next()
+	return i.datums, nil
+}
+
+func (i *rowContainerIterator) close() {
+	i.iter.Close()
+}
+
+// rowSetHelper is a set of rows for local planNodes that need to deduplicate
+// them. Like rowContainerHelper, it keeps the rows in memory until the memory
+// limit for buffering operators is reached, at which point it spills them to
+// temporary storage.
+//
+// The set counts the number of times every row was inserted, so it can also be
+// used as a multiset through insert and remove.
+type rowSetHelper struct {
+	memMonitor  mon.BytesMonitor
+	memAcc      mon.BoundAccount
+	diskMonitor *mon.BytesMonitor
+	diskAcc     mon.BoundAccount
+	engine      diskmap.Factory
+
+	// Only one of mem and disk is used at a time: the rows are moved from mem
+	// to disk when the memory limit is reached. Both map the encoding of every
+	// row to the number of times it is in the set; the count is encoded as a
+	// uvarint on disk.
+	mem  map[string]int
+	disk diskmap.SortedDiskMap
+
+	types  []types.T
+	keyBuf []byte
+	valBuf []byte
+}
+
+// newRowSetHelper creates a rowSetHelper for rows with the given columns. The
+// opName is used to name the memory and disk monitors.
+func newRowSetHelper(params runParams, cols sqlbase.ResultColumns, opName string) *rowSetHelper {
+	evalCtx := params.EvalContext()
+	distSQLCfg := &params.extendedEvalCtx.ExecCfg.DistSQLSrv.ServerConfig
+
+	s := &rowSetHelper{
+		engine: distSQLCfg.TempStorage,
+		mem:    make(map[string]int),
+		types:  make([]types.T, len(cols)),
+	}
+	for i := range cols {
+		s.types[i] = *cols[i].Typ
+	}
+
+	limit := distsqlrun.SettingWorkMemBytes.Get(&evalCtx.Settings.SV)
+	s.memMonitor = mon.MakeMonitorInheritWithLimit(opName+"-limited", limit, evalCtx.Mon)
+	s.memMonitor.Start(params.ctx, evalCtx.Mon, mon.BoundAccount{})
+	s.memAcc = s.memMonitor.MakeBoundAccount()
+	s.diskMonitor = distsqlrun.NewMonitor(params.ctx, distSQLCfg.DiskMonitor, opName+"-disk")
+	s.diskAcc = s.diskMonitor.MakeBoundAccount()
+	return s
+}
+
+// encode sets keyBuf to an encoding of the row that is the same for all the
+// rows that are equal. The key encoding is used for the columns that support
+// it, and the value encoding for the others, like JSON columns.
+func (s *rowSetHelper) encode(row tree.Datums) error {
+	s.keyBuf = s.keyBuf[:0]
+	for i, d := range row {
+		var err error
+		if sqlbase.MustBeValueEncoded(s.types[i].Family()) {
+			s.keyBuf, err = sqlbase.EncodeTableValue(
+				s.keyBuf, sqlbase.ColumnID(encoding.NoColumnID), d, nil, /* scratch */
+			)
+		} else {
+			s.keyBuf, err = sqlbase.EncodeTableKey(s.keyBuf, d, encoding.Ascending)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add adds the row to the set. It returns false if the row was already in the
+// set.
+func (s *rowSetHelper) add(ctx context.Context, row tree.Datums) (bool, error) {
+	if err := s.encode(row); err != nil {
+		return false, err
+	}
+	n, found, err := s.lookup()
+	if err != nil || n > 0 {
+		return false, err
+	}
+	return true, s.set(ctx, 1, found)
+}
+
+// insert adds one more copy of the row to the set.
+func (s *rowSetHelper) insert(ctx context.Context, row tree.Datums) error {
+	if err := s.encode(row); err != nil {
+		return err
+	}
+	n, found, err := s.lookup()
+	if err != nil {
+		return err
+	}
+	return s.set(ctx, n+1, found)
+}
+
+// remove removes one copy of the row from the set. It returns false if the row
+// wasn't in the set.
+func (s *rowSetHelper) remove(ctx context.Context, row tree.Datums) (bool, error) {
+	if err := s.encode(row); err != nil {
+		return false, err
+	}
+	n, found, err := s.lookup()
+	if err != nil || n == 0 {
+		return false, err
+	}
+	return true, s.set(ctx, n-1, found)
+}
+
+// lookup returns the number of times the row encoded in keyBuf is in the set,
+// and whether the set has an entry for it. The entry of a row that was removed
+// as many times as it was inserted is kept, with a count of zero.
+func (s *rowSetHelper) lookup() (n int, found bool, _ error) {
+	if s.disk == nil {
+		n, found = s.mem[string(s.keyBuf)]
+		return n, found, nil
+	}
+	v, err := s.disk.Get(s.keyBuf)
+	if err != nil || v == nil {
+		return 0, false, err
+	}
+	_, count, err := encoding.DecodeUvarintAscending(v)
+	return int(count), true, err
+}
+
+// set sets the number of times the row encoded in keyBuf is in the set. found
+// is whether the set already has an entry for the row, as returned by lookup.
+func (s *rowSetHelper) set(ctx context.Context, n int, found bool) error {
+	if s.disk == nil {
+		if found {
+			s.mem[string(s.keyBuf)] = n
+			return nil
+		}
+		err := s.memAcc.Grow(ctx, int64(len(s.keyBuf)))
+		if err == nil {
+			s.mem[string(s.keyBuf)] = n
+			return nil
+		}
+		if pgErr, ok := pgerror.GetPGCause(err); !(ok && pgErr.Code == pgerror.CodeOutOfMemoryError) {
+			return err
+		}
+		if err := s.spillToDisk(ctx); err != nil {
+			return err
+		}
+		log.VEventf(ctx, 2, "spilled to disk: %v", err)
+	}
+	s.valBuf = encoding.EncodeUvarintAscending(s.valBuf[:0], uint64(n))
+	if !found {
+		if err := s.diskAcc.Grow(ctx, int64(len(s.keyBuf)+len(s.valBuf))); err != nil {
+			return err
+		}
+	}
+	return s.disk.Put(s.keyBuf, s.valBuf)
+}
+
+// spillToDisk moves the rows of the set to temporary storage.
+func (s *rowSetHelper) spillToDisk(ctx context.Context) error {
+	s.disk = s.engine.NewSortedDiskMap()
+	w := s.disk.NewBatchWriter()
+	for k, n := range s.mem {
+		s.valBuf = encoding.EncodeUvarintAscending(s.valBuf[:0], uint64(n))
+		if err := s.diskAcc.Grow(ctx, int64(len(k)+len(s.valBuf))); err != nil {
+			_ = w.Close(ctx)
+			return err
+		}
+		if err := w.Put([]byte(k), s.valBuf); err != nil {
+			_ = w.Close(ctx)
+			return err
+		}
+	}
+	if err := w.Close(ctx); err != nil {
+		return err
+	}
+	s.mem = nil
+	s.memAcc.Clear(ctx)
+	return nil
+}
+
+// close releases the resources held by the set, including any temporary
+// storage.
+func (s *rowSetHelper) close(ctx context.Context) {
+	if s.disk != nil {
+		s.disk.Close(ctx)
+		s.disk = nil
+	}
+	s.mem = nil
+	s.diskAcc.Close(ctx)
+	s.memAcc.Close(ctx)
+	s.diskMonitor.Stop(ctx)
+	s.memMonitor.Stop(ctx)
 }
@@ -648,6 +648,10 @@ func (s *Server) newConnExecutorWithTxn(
 	if tcModifier != nil {
 		tcModifier.copyModifiedSchema(&ex.extraTxnState.tables)
 	}
+
+	// The executor doesn't commit the transaction, so it can't defer the
+	// validation of constraints until then.
+	ex.extraTxnState.deferredConstraints.forceImmediate = true
 	return ex, nil
 }
 
@@ -742,6 +746,9 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 		if err := ex.machine.ApplyWithPayload(ctx, ev, payload); err != nil {
 			log.Warningf(ctx, "error while cleaning up connExecutor: %s", err)
 		}
+		// If the connection is closed in the Aborted state, the KV txn might
+		// not have been cleaned up yet in case it had savepoints.
+		ex.state.cleanupPendingErr()
 	} else if closeType == externalTxnClose {
 		ex.state.finishExternalTxn()
 	}
@@ -750,6 +757,9 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 		log.Warningf(ctx, "error while cleaning up connExecutor: %s", err)
 	}
 
+	// Drop the temporary objects of the session, if any.
+	ex.cleanupTemporarySchema(ctx)
+
 	if closeType != panicClose {
 		// Close all statements and prepared portals.
 		ex.extraTxnState.prepStmtsNamespace.resetTo(ctx, prepStmtNamespace{})
@@ -840,6 +850,11 @@ type connExecutor struct {
 		// is done if the statement was executed in an implicit txn).
 		schemaChangers schemaChangerCollection
 
+		// deferredConstraints queues the deferrable constraints that the
+		// statements of the transaction need validated, either at the end of the
+		// statement or before the transaction commits.
+		deferredConstraints deferredConstraintCollection
+
 		// autoRetryCounter keeps track of the which iteration of a transaction
 		// auto-retry we're currently in. It's 0 whenever the transaction state is not
 		// stateOpen.
@@ -929,6 +944,10 @@ type connExecutor struct {
 
 	sessionID ClusterWideID
 
+	// temporarySchema tracks the temporary tables and views created by the
+	// session. They are dropped when the session ends.
+	temporarySchema temporarySchema
+
 	// activated determines whether activate() was called already.
 	// When this is set, close() must be called to release resources.
 	activated bool
@@ -1020,6 +1039,8 @@ func (ex *connExecutor) resetExtraTxnState(
 ) error {
 	ex.extraTxnState.schemaChangers.reset()
 
+	ex.extraTxnState.deferredConstraints.reset()
+
 	ex.extraTxnState.tables.releaseTables(ctx)
 
 	ex.extraTxnState.tables.databaseCache = dbCacheHolder.getDatabaseCache()
@@ -1130,6 +1151,7 @@ func (ex *connExecutor) run(
 	ex.onCancelSession = onCancel
 
 	ex.sessionID = ex.generateID()
+	ex.temporarySchema.sessionID = ex.sessionID
 	ex.server.cfg.SessionRegistry.register(ex.sessionID, ex)
 	defer ex.server.cfg.SessionRegistry.deregister(ex.sessionID)
 
@@ -1660,6 +1682,12 @@ func (ex *connExecutor) getRewindTxnCapability() (rewindCapability, bool) {
 		cl.Close()
 		return rewindCapability{}, false
 	}
+	// Savepoints capture the state of the current KV transaction; they can't
+	// survive a restart, so we can't rewind after one was established.
+	if len(ex.state.savepoints) > 0 {
+		cl.Close()
+		return rewindCapability{}, false
+	}
 	return rewindCapability{
 		cl:        cl,
 		buf:       ex.stmtBuf,
@@ -1801,17 +1829,19 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 			InternalExecutor: ie,
 			DB:               ex.server.cfg.DB,
 		},
-		SessionMutator:  ex.dataMutator,
-		VirtualSchemas:  ex.server.cfg.VirtualSchemas,
-		Tracing:         &ex.sessionTracing,
-		StatusServer:    ex.server.cfg.StatusServer,
-		MemMetrics:      &ex.memMetrics,
-		Tables:          &ex.extraTxnState.tables,
-		ExecCfg:         ex.server.cfg,
-		DistSQLPlanner:  ex.server.cfg.DistSQLPlanner,
-		TxnModesSetter:  ex,
-		SchemaChangers:  &ex.extraTxnState.schemaChangers,
-		schemaAccessors: scInterface,
+		SessionMutator:      ex.dataMutator,
+		VirtualSchemas:      ex.server.cfg.VirtualSchemas,
+		Tracing:             &ex.sessionTracing,
+		StatusServer:        ex.server.cfg.StatusServer,
+		MemMetrics:          &ex.memMetrics,
+		Tables:              &ex.extraTxnState.tables,
+		ExecCfg:             ex.server.cfg,
+		DistSQLPlanner:      ex.server.cfg.DistSQLPlanner,
+		TxnModesSetter:      ex,
+		SchemaChangers:      &ex.extraTxnState.schemaChangers,
+		DeferredConstraints: &ex.extraTxnState.deferredConstraints,
+		TemporarySchema:     &ex.temporarySchema,
+		schemaAccessors:     scInterface,
 	}
 }
 
@@ -1888,6 +1918,8 @@ func (ex *connExecutor) resetPlanner(
 	p.semaCtx = tree.MakeSemaContext()
 	p.semaCtx.Location = &ex.sessionData.DataConversion.Location
 	p.semaCtx.SearchPath = ex.sessionData.SearchPath
+	p.semaCtx.TypeResolver = p
+	p.semaCtx.FunctionResolver = p
 	p.semaCtx.AsOfTimestamp = nil
 	p.semaCtx.Annotations = tree.MakeAnnotations(numAnnotations)
 
@@ -2258,7 +2290,7 @@ func (sc *StatementCounters) incrementCount(ex *connExecutor, stmt tree.Statemen
 	case *tree.RollbackTransaction:
 		sc.TxnRollbackCount.Inc()
 	case *tree.Savepoint:
-		if err := ex.validateSavepointName(t.Name); err == nil {
+		if ex.isRestartSavepoint(t.Name) {
 			sc.RestartSavepointCount.Inc()
 		} else {
 			sc.SavepointCount.Inc()
@@ -231,8 +231,11 @@ func (ex *connExecutor) execStmtInOpenState(
 		return ev, payload, nil
 
 	case *tree.ReleaseSavepoint:
-		if err := ex.validateSavepointName(s.Savepoint); err != nil {
-			return makeErrEvent(err)
+		if !ex.isRestartSavepoint(s.Savepoint) {
+			if idx, ok := ex.state.savepoints.find(s.Savepoint); ok {
+				return ex.execReleaseSavepointInOpenState(idx)
+			}
+			return makeErrEvent(errSavepointDoesNotExist(s.Savepoint))
 		}
 		if !ex.machine.CurState().(stateOpen).RetryIntent.Get() {
 			return makeErrEvent(errSavepointNotUsed)
@@ -249,14 +252,14 @@ func (ex *connExecutor) execStmtInOpenState(
 		return ev, payload, nil
 
 	case *tree.Savepoint:
+		if !ex.isRestartSavepoint(s.Name) {
+			return ex.execSavepointInOpenState(ctx, s)
+		}
 		// Ensure that the user isn't trying to run BEGIN; SAVEPOINT; SAVEPOINT;
 		if ex.state.activeSavepointName != "" {
 			err := pgerror.UnimplementedWithIssueDetail(10735, "nested", "SAVEPOINT may not be nested")
 			return makeErrEvent(err)
 		}
-		if err := ex.validateSavepointName(s.Name); err != nil {
-			return makeErrEvent(err)
-		}
 		// We want to disallow SAVEPOINTs to be issued after a KV transaction has
 		// started running. The client txn's statement count indicates how many
 		// statements have been executed as part of this transaction. It is
@@ -265,7 +268,7 @@ func (ex *connExecutor) execStmtInOpenState(
 		// See also:
 		// https://github.com/cockroachdb/cockroach/issues/15012
 		meta := ex.state.mu.txn.GetTxnCoordMeta(ctx)
-		if meta.CommandCount > 0 {
+		if meta.CommandCount > 0 || len(ex.state.savepoints) > 0 {
 			err := pgerror.Newf(pgerror.CodeSyntaxError,
 				"SAVEPOINT %s needs to be the first statement in a "+
 					"transaction", RestartSavepointName)
@@ -277,8 +280,11 @@ func (ex *connExecutor) execStmtInOpenState(
 		return eventRetryIntentSet{}, nil /* payload */, nil
 
 	case *tree.RollbackToSavepoint:
-		if err := ex.validateSavepointName(s.Savepoint); err != nil {
-			return makeErrEvent(err)
+		if !ex.isRestartSavepoint(s.Savepoint) {
+			if idx, ok := ex.state.savepoints.find(s.Savepoint); ok {
+				return ex.execRollbackToSavepointInOpenState(ctx, s, idx)
+			}
+			return makeErrEvent(errSavepointDoesNotExist(s.Savepoint))
 		}
 		if !os.RetryIntent.Get() {
 			return makeErrEvent(errSavepointNotUsed)
@@ -360,6 +366,12 @@ func (ex *connExecutor) execStmtInOpenState(
 	// For regular statements (the ones that get to this point), we don't return
 	// any event unless an an error happens.
 
+	if tree.CanModifySchema(stmt.AST) {
+		// Schema changes can't be rolled back to a savepoint; remember that one
+		// was performed.
+		ex.state.ddlCount++
+	}
+
 	p := &ex.planner
 	stmtTS := ex.server.cfg.Clock.PhysicalTime()
 	ex.resetPlanner(ctx, p, ex.state.mu.txn, stmtTS, stmt.NumAnnotations)
@@ -421,6 +433,12 @@ func (ex *connExecutor) execStmtInOpenState(
 		return makeErrEvent(err)
 	}
 
+	// Validate the deferrable constraints that the statement may have violated,
+	// unless they are deferred until the commit.
+	if err := ex.validateDeferredConstraints(ctx, false /* atCommit */); err != nil {
+		return makeErrEvent(err)
+	}
+
 	txn := ex.state.mu.txn
 	if !os.ImplicitTxn.Get() && txn.IsSerializablePushAndRefreshNotPossible() {
 		rc, canAutoRetry := ex.getRewindTxnCapability()
@@ -572,6 +590,10 @@ func (ex *connExecutor) commitSQLTransaction(
 		isRelease = true
 	}
 
+	if err := ex.validateDeferredConstraints(ctx, true /* atCommit */); err != nil {
+		return ex.makeErrEvent(err, stmt)
+	}
+
 	if err := ex.checkTableTwoVersionInvariant(ctx); err != nil {
 		return ex.makeErrEvent(err, stmt)
 	}
@@ -586,6 +608,16 @@ func (ex *connExecutor) commitSQLTransaction(
 	return eventTxnReleased{}, nil
 }
 
+// validateDeferredConstraints checks the deferrable constraints that the
+// statements of the transaction queued for validation. Unless atCommit is set,
+// the constraints that are currently deferred remain queued.
+func (ex *connExecutor) validateDeferredConstraints(ctx context.Context, atCommit bool) error {
+	ie := ex.planner.extendedEvalCtx.InternalExecutor.(*SessionBoundInternalExecutor)
+	return ex.extraTxnState.deferredConstraints.validate(
+		ctx, ie, &ex.extraTxnState.tables, ex.state.mu.txn, atCommit,
+	)
+}
+
 // rollbackSQLTransaction executes a ROLLBACK statement: the KV transaction is
 // rolled-back and an event is produced.
 func (ex *connExecutor) rollbackSQLTransaction(ctx context.Context) (fsm.Event, fsm.EventPayload) {
@@ -976,7 +1008,8 @@ func (ex *connExecutor) execStmtInNoTxnState(
 				historicalTs,
 				ex.transitionCtx)
 	case *tree.CommitTransaction, *tree.ReleaseSavepoint,
-		*tree.RollbackTransaction, *tree.SetTransaction, *tree.Savepoint:
+		*tree.RollbackTransaction, *tree.SetTransaction, *tree.Savepoint,
+		*tree.RollbackToSavepoint:
 		return ex.makeErrEvent(errNoTransactionInProgress, stmt.AST)
 	default:
 		mode := tree.ReadWrite
@@ -1001,6 +1034,8 @@ func (ex *connExecutor) execStmtInNoTxnState(
 // - COMMIT / ROLLBACK: aborts the current transaction.
 // - ROLLBACK TO SAVEPOINT / SAVEPOINT: reopens the current transaction,
 //   allowing it to be retried.
+// - ROLLBACK TO SAVEPOINT of a regular savepoint: undoes the error, allowing
+//   the transaction to continue.
 func (ex *connExecutor) execStmtInAbortedState(
 	ctx context.Context, stmt Statement, res RestrictedCommandResult,
 ) (fsm.Event, fsm.EventPayload) {
@@ -1039,7 +1074,18 @@ func (ex *connExecutor) execStmtInAbortedState(
 		if !isRollback {
 			ex.state.activeSavepointName = ""
 		}
-		if err := ex.validateSavepointName(spName); err != nil {
+		if !ex.isRestartSavepoint(spName) {
+			var err error
+			if isRollback {
+				// ROLLBACK TO SAVEPOINT of a regular savepoint undoes the error and
+				// lets the transaction continue.
+				if idx, ok := ex.state.savepoints.find(spName); ok {
+					return ex.execRollbackToSavepointInAbortedState(ctx, idx)
+				}
+				err = errSavepointDoesNotExist(spName)
+			} else {
+				err = sqlbase.NewTransactionAbortedError("" /* customMsg */)
+			}
 			ev := eventNonRetriableErr{IsCommit: fsm.False}
 			payload := eventNonRetriableErrPayload{
 				err: err,
@@ -1325,25 +1371,3 @@ func payloadHasError(payload fsm.EventPayload) bool {
 	_, hasErr := payload.(payloadWithError)
 	return hasErr
 }
-
-// validateSavepointName validates that it is that the provided ident
-// matches the active savepoint name, begins with RestartSavepointName,
-// or that force_savepoint_restart==true. We accept everything with the
-// desired prefix because at least the C++ libpqxx appends sequence
-// numbers to the savepoint name specified by the user.
-func (ex *connExecutor) validateSavepointName(savepoint tree.Name) error {
-	if ex.state.activeSavepointName != "" {
-		if savepoint == ex.state.activeSavepointName {
-			return nil
-		}
-		return pgerror.Newf(pgerror.CodeInvalidSavepointSpecificationError,
-			`SAVEPOINT %q is in use`, tree.ErrString(&ex.state.activeSavepointName))
-	}
-	if !ex.sessionData.ForceSavepointRestart && !strings.HasPrefix(string(savepoint), RestartSavepointName) {
-		return pgerror.UnimplementedWithIssueHint(10735,
-			"SAVEPOINT not supported except for "+RestartSavepointName,
-			"Retryable transactions with arbitrary SAVEPOINT names can be enabled "+
-				"with SET force_savepoint_restart=true")
-	}
-	return nil
-}
@@ -339,6 +339,13 @@ func (ex *connExecutor) execBind(
 			if arg == nil {
 				// nil indicates a NULL argument value.
 				qargs[k] = tree.DNull
+			} else if typ, _ := ps.ValueType(k); typ != nil && typ.EnumData() != nil {
+				// Values of enum types are sent as their labels in both formats.
+				d, err := tree.MakeDEnumFromLogicalRepresentation(typ, string(arg))
+				if err != nil {
+					return retErr(err)
+				}
+				qargs[k] = d
 			} else {
 				d, err := pgwirebase.DecodeOidDatum(ptCtx, t, qArgFormatCodes[i], arg)
 				if err != nil {
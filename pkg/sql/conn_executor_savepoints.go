@@ -0,0 +1,179 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/fsm"
+)
+
+// savepoint is a regular savepoint established with SAVEPOINT <name>. Unlike
+// the restart savepoint (cockroach_restart), rolling back to a regular
+// savepoint doesn't restart the transaction: it only discards the writes
+// performed since the savepoint was established.
+type savepoint struct {
+	name tree.Name
+
+	// kvToken is the KV savepoint capturing the state of the KV transaction
+	// when the savepoint was established.
+	kvToken client.SavepointToken
+
+	// ddlCount is the value of txnState.ddlCount when the savepoint was
+	// established.
+	ddlCount int
+}
+
+// savepointStack is the stack of savepoints of a transaction, innermost last.
+// Savepoint names don't need to be unique; a savepoint shadows the savepoints
+// with the same name established before it.
+type savepointStack []savepoint
+
+// find returns the index of the innermost savepoint with the given name.
+func (s savepointStack) find(name tree.Name) (int, bool) {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i].name == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func errSavepointDoesNotExist(name tree.Name) error {
+	return pgerror.Newf(pgerror.CodeInvalidSavepointSpecificationError,
+		"savepoint %s does not exist", tree.ErrString(&name))
+}
+
+// isRestartSavepoint returns true if the savepoint name refers to the restart
+// savepoint: the active savepoint name if one was established, or else any
+// name beginning with RestartSavepointName, or any name at all if
+// force_savepoint_restart is set. We accept everything with the desired
+// prefix because at least the C++ libpqxx appends sequence numbers to the
+// savepoint name specified by the user.
+func (ex *connExecutor) isRestartSavepoint(savepoint tree.Name) bool {
+	if ex.state.activeSavepointName != "" {
+		return savepoint == ex.state.activeSavepointName
+	}
+	return ex.sessionData.ForceSavepointRestart ||
+		strings.HasPrefix(string(savepoint), RestartSavepointName)
+}
+
+// execSavepointInOpenState establishes a regular savepoint.
+func (ex *connExecutor) execSavepointInOpenState(
+	ctx context.Context, s *tree.Savepoint,
+) (fsm.Event, fsm.EventPayload, error) {
+	token, err := ex.state.mu.txn.CreateSavepoint(ctx)
+	if err != nil {
+		ev, payload := ex.makeErrEvent(err, s)
+		return ev, payload, nil
+	}
+	ex.state.savepoints = append(ex.state.savepoints, savepoint{
+		name:     s.Name,
+		kvToken:  token,
+		ddlCount: ex.state.ddlCount,
+	})
+	return nil, nil, nil
+}
+
+// execReleaseSavepointInOpenState releases the innermost savepoint with the
+// given name, along with all the savepoints established after it. The
+// writes performed since the savepoint remain part of the transaction.
+func (ex *connExecutor) execReleaseSavepointInOpenState(
+	idx int,
+) (fsm.Event, fsm.EventPayload, error) {
+	ex.state.savepoints = ex.state.savepoints[:idx]
+	return nil, nil, nil
+}
+
+// rollbackToSavepoint rolls the KV transaction back to the savepoint at the
+// given index of the stack. The savepoints established after it are
+// destroyed; the savepoint itself remains established.
+func (ex *connExecutor) rollbackToSavepoint(ctx context.Context, idx int) error {
+	sp := &ex.state.savepoints[idx]
+	if ex.state.ddlCount > sp.ddlCount {
+		return pgerror.UnimplementedWithIssueDetailf(10735, "rollback-ddl",
+			"ROLLBACK TO SAVEPOINT %s not supported after schema changes",
+			tree.ErrString(&sp.name))
+	}
+	if err := ex.state.mu.txn.RollbackToSavepoint(ctx, sp.kvToken); err != nil {
+		return pgerror.Wrapf(err, pgerror.CodeInvalidTransactionStateError,
+			"cannot rollback to savepoint %s", tree.ErrString(&sp.name))
+	}
+	ex.state.savepoints = ex.state.savepoints[:idx+1]
+	ex.state.pendingCleanupErr = nil
+	return nil
+}
+
+// execRollbackToSavepointInOpenState rolls the transaction back to a regular
+// savepoint. The transaction remains open.
+func (ex *connExecutor) execRollbackToSavepointInOpenState(
+	ctx context.Context, s *tree.RollbackToSavepoint, idx int,
+) (fsm.Event, fsm.EventPayload, error) {
+	if err := ex.rollbackToSavepoint(ctx, idx); err != nil {
+		ev, payload := ex.makeErrEvent(err, s)
+		return ev, payload, nil
+	}
+	return nil, nil, nil
+}
+
+// execRollbackToSavepointInAbortedState rolls the transaction back to a
+// regular savepoint after an error, allowing it to continue.
+func (ex *connExecutor) execRollbackToSavepointInAbortedState(
+	ctx context.Context, idx int,
+) (fsm.Event, fsm.EventPayload) {
+	if ex.state.pendingCleanupErr == nil {
+		// The error that moved us to the Aborted state already caused the KV
+		// transaction to be cleaned up (e.g. a retriable error), so there's
+		// nothing to roll back.
+		ev := eventNonRetriableErr{IsCommit: fsm.False}
+		payload := eventNonRetriableErrPayload{
+			err: pgerror.Newf(pgerror.CodeInFailedSQLTransactionError,
+				"cannot rollback to savepoint %s: the transaction was aborted",
+				tree.ErrString(&ex.state.savepoints[idx].name)),
+		}
+		return ev, payload
+	}
+	if err := ex.rollbackToSavepoint(ctx, idx); err != nil {
+		ev := eventNonRetriableErr{IsCommit: fsm.False}
+		payload := eventNonRetriableErrPayload{err: err}
+		return ev, payload
+	}
+	return eventSavepointRollback{}, nil
+}
+
+// cleanupOnError cleans up the KV txn after the SQL txn encountered a
+// non-retriable error, unless the transaction might still be rolled back to
+// a savepoint. In that case the cleanup is postponed until the SQL txn is
+// finished.
+func (ts *txnState) cleanupOnError(err error) {
+	if len(ts.savepoints) > 0 {
+		ts.pendingCleanupErr = err
+		return
+	}
+	ts.mu.txn.CleanupOnError(ts.Ctx, err)
+}
+
+// cleanupPendingErr performs the cleanup postponed by cleanupOnError, if any.
+func (ts *txnState) cleanupPendingErr() {
+	if ts.pendingCleanupErr == nil {
+		return
+	}
+	if ts.mu.txn != nil {
+		ts.mu.txn.CleanupOnError(ts.Ctx, ts.pendingCleanupErr)
+	}
+	ts.pendingCleanupErr = nil
+}
@@ -209,18 +209,24 @@ var _ payloadWithError = eventRetriableErrPayload{}
 // cockroach_restart. It moves the state to CommitWait.
 type eventTxnReleased struct{}
 
+// eventSavepointRollback is generated in the Aborted state after a successful
+// ROLLBACK TO SAVEPOINT to a regular savepoint. It moves the state back to
+// Open.
+type eventSavepointRollback struct{}
+
 // payloadWithError is a common interface for the payloads that wrap an error.
 type payloadWithError interface {
 	errorCause() error
 }
 
-func (eventRetryIntentSet) Event()  {}
-func (eventTxnStart) Event()        {}
-func (eventTxnFinish) Event()       {}
-func (eventTxnRestart) Event()      {}
-func (eventNonRetriableErr) Event() {}
-func (eventRetriableErr) Event()    {}
-func (eventTxnReleased) Event()     {}
+func (eventRetryIntentSet) Event()    {}
+func (eventTxnStart) Event()          {}
+func (eventTxnFinish) Event()         {}
+func (eventTxnRestart) Event()        {}
+func (eventNonRetriableErr) Event()   {}
+func (eventRetriableErr) Event()      {}
+func (eventTxnReleased) Event()       {}
+func (eventSavepointRollback) Event() {}
 
 // TxnStateTransitions describe the transitions used by a connExecutor's
 // fsm.Machine. Args.Extended is a txnState, which is muted by the Actions.
@@ -326,7 +332,7 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 			Next: stateAborted{RetryIntent: fsm.Var("retryIntent")},
 			Action: func(args fsm.Args) error {
 				ts := args.Extended.(*txnState)
-				ts.mu.txn.CleanupOnError(ts.Ctx, args.Payload.(payloadWithError).errorCause())
+				ts.cleanupOnError(args.Payload.(payloadWithError).errorCause())
 				ts.setAdvanceInfo(skipBatch, noRewind, txnAborted)
 				ts.txnAbortCount.Inc(1)
 				return nil
@@ -390,6 +396,8 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 				// timestamp in that case. In the special case of the cockroach_restart
 				// savepoint, it's not clear to me what a user's expectation might be.
 				state.mu.txn.ManualRestart(args.Ctx, hlc.Timestamp{})
+				// The restart invalidates all the regular savepoints.
+				state.savepoints = nil
 				args.Extended.(*txnState).setAdvanceInfo(advanceOne, noRewind, txnRestart)
 				return nil
 			},
@@ -421,6 +429,17 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 				return nil
 			},
 		},
+		// ROLLBACK TO SAVEPOINT of a regular savepoint. The KV transaction has
+		// already been rolled back to the savepoint by the time this event is
+		// generated.
+		eventSavepointRollback{}: {
+			Description: "ROLLBACK TO SAVEPOINT (not cockroach_restart)",
+			Next:        stateOpen{ImplicitTxn: fsm.False, RetryIntent: fsm.Var("retryIntent")},
+			Action: func(args fsm.Args) error {
+				args.Extended.(*txnState).setAdvanceInfo(advanceOne, noRewind, noEvent)
+				return nil
+			},
+		},
 	},
 	stateAborted{RetryIntent: fsm.True}: {
 		// ROLLBACK TO SAVEPOINT. We accept this in the Aborted state for the
@@ -470,7 +489,10 @@ var TxnStateTransitions = fsm.Compile(fsm.Pattern{
 			Description: "ROLLBACK TO SAVEPOINT cockroach_restart",
 			Next:        stateOpen{ImplicitTxn: fsm.False, RetryIntent: fsm.True},
 			Action: func(args fsm.Args) error {
-				args.Extended.(*txnState).setAdvanceInfo(advanceOne, noRewind, txnRestart)
+				ts := args.Extended.(*txnState)
+				// The restart invalidates all the regular savepoints.
+				ts.savepoints = nil
+				ts.setAdvanceInfo(advanceOne, noRewind, txnRestart)
 				return nil
 			},
 		},
@@ -341,6 +341,9 @@ CREATE TABLE crdb_internal.schema_changes (
 				case *sqlbase.DescriptorMutation_Constraint:
 					mutType = "CONSTRAINT VALIDATION"
 					targetName = tree.NewDString(d.Constraint.Name)
+				case *sqlbase.DescriptorMutation_ColumnTypeSwap:
+					mutType = "COLUMN TYPE SWAP"
+					targetID = tree.NewDInt(tree.DInt(int64(d.ColumnTypeSwap.OldColumnID)))
 				}
 				if err := addRow(
 					tableID,
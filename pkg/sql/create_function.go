@@ -0,0 +1,256 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+type createFunctionNode struct {
+	n      *tree.CreateFunction
+	dbDesc *sqlbase.DatabaseDescriptor
+	// overload is the overload defined by the statement.
+	overload sqlbase.FunctionDescriptor_Overload
+}
+
+// CreateFunction creates a user-defined function, or adds an overload to an
+// existing one.
+func (p *planner) CreateFunction(ctx context.Context, n *tree.CreateFunction) (planNode, error) {
+	dbDesc, err := p.ResolveUncachedDatabase(ctx, &n.FuncName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	// Built-in functions take precedence over user-defined functions, which
+	// could not be called.
+	if _, ok := tree.FunDefs[n.FuncName.Table()]; ok {
+		return nil, pgerror.Newf(pgerror.CodeDuplicateFunctionError,
+			"function %q already exists as a built-in function", n.FuncName.Table())
+	}
+
+	overload, err := p.makeFunctionOverloadDesc(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createFunctionNode{
+		n:        n,
+		dbDesc:   dbDesc,
+		overload: overload,
+	}, nil
+}
+
+// makeFunctionOverloadDesc returns the overload defined by a CREATE FUNCTION
+// statement, after checking its options and its body.
+func (p *planner) makeFunctionOverloadDesc(
+	n *tree.CreateFunction,
+) (sqlbase.FunctionDescriptor_Overload, error) {
+	overload := sqlbase.FunctionDescriptor_Overload{
+		ReturnsSet: n.ReturnsSet,
+		Volatility: sqlbase.FunctionDescriptor_VOLATILE,
+	}
+	var seenBody, seenLanguage, seenVolatility, seenStrictness bool
+	for _, opt := range n.Options {
+		var seen *bool
+		switch opt.Name {
+		case tree.FuncOptAs:
+			seen = &seenBody
+			overload.Body = opt.StrVal
+		case tree.FuncOptLanguage:
+			seen = &seenLanguage
+			overload.Language = strings.ToLower(opt.StrVal)
+		case tree.FuncOptImmutable:
+			seen = &seenVolatility
+			overload.Volatility = sqlbase.FunctionDescriptor_IMMUTABLE
+		case tree.FuncOptStable:
+			seen = &seenVolatility
+			overload.Volatility = sqlbase.FunctionDescriptor_STABLE
+		case tree.FuncOptVolatile:
+			seen = &seenVolatility
+			overload.Volatility = sqlbase.FunctionDescriptor_VOLATILE
+		case tree.FuncOptCalledOnNullInput:
+			seen = &seenStrictness
+			overload.Strict = false
+		case tree.FuncOptReturnsNullOnNullInput, tree.FuncOptStrict:
+			seen = &seenStrictness
+			overload.Strict = true
+		default:
+			return overload, pgerror.AssertionFailedf("unknown function option %q", opt.Name)
+		}
+		if *seen {
+			return overload, pgerror.New(pgerror.CodeSyntaxError, "conflicting or redundant options")
+		}
+		*seen = true
+	}
+	if !seenBody {
+		return overload, pgerror.New(pgerror.CodeInvalidFunctionDefinitionError,
+			"no function body specified")
+	}
+	if !seenLanguage {
+		return overload, pgerror.New(pgerror.CodeInvalidFunctionDefinitionError,
+			"no language specified")
+	}
+	if overload.Language != sqlbase.FunctionLanguageSQL {
+		return overload, pgerror.Unimplementedf("udf language",
+			"functions in language %q are not supported", overload.Language)
+	}
+
+	overload.Params = make([]sqlbase.FunctionDescriptor_Param, len(n.Params))
+	for i := range n.Params {
+		param := &n.Params[i]
+		if param.Name != "" {
+			for j := 0; j < i; j++ {
+				if n.Params[j].Name == param.Name {
+					return overload, pgerror.Newf(pgerror.CodeInvalidFunctionDefinitionError,
+						"parameter name %q used more than once", param.Name)
+				}
+			}
+		}
+		typ, err := tree.ResolveType(param.Type, &p.semaCtx)
+		if err != nil {
+			return overload, err
+		}
+		overload.Params[i] = sqlbase.FunctionDescriptor_Param{Name: string(param.Name), Type: *typ}
+	}
+	retType, err := tree.ResolveType(n.ReturnType, &p.semaCtx)
+	if err != nil {
+		return overload, err
+	}
+	overload.ReturnType = *retType
+
+	// The body is parsed again whenever the function is used, but errors are
+	// better reported now.
+	if _, err := parseFunctionBody(overload.Body, overload.Params); err != nil {
+		return overload, err
+	}
+	return overload, nil
+}
+
+func (n *createFunctionNode) startExec(params runParams) error {
+	name := n.n.FuncName.Table()
+	tKey := sqlbase.NewTableKey(n.dbDesc.ID, name)
+	id, err := getDescriptorID(params.ctx, params.p.txn, tKey)
+	if err != nil {
+		return err
+	}
+	if id != sqlbase.InvalidID {
+		fnDesc, err := sqlbase.GetFunctionDescFromID(params.ctx, params.p.txn, id)
+		if err == sqlbase.ErrDescriptorNotFound {
+			return pgerror.Newf(pgerror.CodeDuplicateObjectError,
+				"%q already exists and is not a function", name)
+		} else if err != nil {
+			return err
+		}
+		return n.addOverload(params, fnDesc)
+	}
+
+	id, err = GenerateUniqueDescID(params.ctx, params.p.ExecCfg().DB)
+	if err != nil {
+		return err
+	}
+
+	// Inherit the privileges that apply to functions from the database
+	// descriptor. As in Postgres, everyone can execute a new function.
+	privs := sqlbase.NewDefaultPrivilegeDescriptor()
+	for _, u := range n.dbDesc.GetPrivileges().Users {
+		var kinds privilege.List
+		for _, kind := range privilege.ListFromBitField(u.Privileges) {
+			if kind.AppliesTo(true /* isFunction */) {
+				kinds = append(kinds, kind)
+			}
+		}
+		if len(kinds) > 0 {
+			privs.Grant(u.User, kinds)
+		}
+	}
+	privs.Grant(sqlbase.PublicRole, privilege.List{privilege.EXECUTE})
+
+	fnDesc := &sqlbase.FunctionDescriptor{
+		Name:       name,
+		ID:         id,
+		ParentID:   n.dbDesc.ID,
+		Version:    1,
+		Privileges: privs,
+		Overloads:  []sqlbase.FunctionDescriptor_Overload{n.overload},
+	}
+	if err := fnDesc.Validate(); err != nil {
+		return err
+	}
+
+	return params.p.createDescriptorWithID(
+		params.ctx, tKey.Key(), id, fnDesc, params.EvalContext().Settings)
+}
+
+// addOverload adds the overload defined by the statement to an existing
+// function, or replaces the overload with the same parameter types if the
+// statement is CREATE OR REPLACE.
+func (n *createFunctionNode) addOverload(
+	params runParams, fnDesc *sqlbase.FunctionDescriptor,
+) error {
+	if err := params.p.CheckPrivilege(params.ctx, fnDesc, privilege.DROP); err != nil {
+		return err
+	}
+	if fnDesc.Overloads[0].ReturnsSet != n.overload.ReturnsSet {
+		return pgerror.Newf(pgerror.CodeInvalidFunctionDefinitionError,
+			"the overloads of function %q must all return sets, or none of them", fnDesc.Name)
+	}
+	if i := fnDesc.FindOverload(n.overload.ParamTypes()); i >= 0 {
+		old := &fnDesc.Overloads[i]
+		if !n.n.Replace {
+			return pgerror.Newf(pgerror.CodeDuplicateFunctionError,
+				"function %s already exists", old.Signature(fnDesc.Name))
+		}
+		if !old.ReturnType.Identical(&n.overload.ReturnType) {
+			return pgerror.Newf(pgerror.CodeInvalidFunctionDefinitionError,
+				"cannot change return type of existing function %s", old.Signature(fnDesc.Name))
+		}
+		*old = n.overload
+	} else {
+		fnDesc.Overloads = append(fnDesc.Overloads, n.overload)
+	}
+	fnDesc.Version++
+	if err := fnDesc.Validate(); err != nil {
+		return err
+	}
+	kvTrace := params.p.ExtendedEvalContext().Tracing.KVTracingEnabled()
+	return writeFunctionDesc(params.ctx, params.p.txn, fnDesc, kvTrace)
+}
+
+func (*createFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (*createFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createFunctionNode) Close(context.Context)        {}
+
+// writeFunctionDesc writes a function descriptor to the database within the
+// given transaction.
+func writeFunctionDesc(
+	ctx context.Context, txn *client.Txn, desc *sqlbase.FunctionDescriptor, kvTrace bool,
+) error {
+	descKey := sqlbase.MakeDescMetadataKey(desc.ID)
+	descVal := sqlbase.WrapDescriptor(desc)
+	if kvTrace {
+		log.VEventf(ctx, 2, "Put %s -> %s", descKey, descVal)
+	}
+	return txn.Put(ctx, descKey, descVal)
+}
@@ -69,6 +69,10 @@ func MakeIndexDescriptor(n *tree.CreateIndex) (*sqlbase.IndexDescriptor, error)
 		if n.Unique {
 			return nil, pgerror.New(pgerror.CodeInvalidSQLStatementNameError, "inverted indexes can't be unique")
 		}
+
+		if n.Predicate != nil {
+			return nil, pgerror.New(pgerror.CodeInvalidSQLStatementNameError, "inverted indexes can't be partial")
+		}
 		indexDesc.Type = sqlbase.IndexDescriptor_INVERTED
 	}
 
@@ -78,6 +82,34 @@ func MakeIndexDescriptor(n *tree.CreateIndex) (*sqlbase.IndexDescriptor, error)
 	return &indexDesc, nil
 }
 
+// makeIndexPredicate validates the predicate of a partial index on the given
+// table and returns its serialized form, with the column references
+// dequalified.
+func makeIndexPredicate(
+	ctx context.Context,
+	desc *sqlbase.MutableTableDescriptor,
+	predicate tree.Expr,
+	semaCtx *tree.SemaContext,
+	tableName tree.TableName,
+) (string, error) {
+	replacedExpr, _, err := replaceVars(desc, predicate)
+	if err != nil {
+		return "", err
+	}
+	if _, err := sqlbase.SanitizeIndexPredicate(replacedExpr, semaCtx); err != nil {
+		return "", err
+	}
+
+	sourceInfo := sqlbase.NewSourceInfoForSingleTable(
+		tableName, sqlbase.ResultColumnsFromColDescs(desc.TableDesc().AllNonDropColumns()),
+	)
+	expr, err := dequalifyColumnRefs(ctx, sqlbase.MultiSourceInfo{sourceInfo}, predicate)
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(expr), nil
+}
+
 func (n *createIndexNode) startExec(params runParams) error {
 	_, dropped, err := n.tableDesc.FindIndexByName(string(n.n.Name))
 	if err == nil {
@@ -95,6 +127,16 @@ func (n *createIndexNode) startExec(params runParams) error {
 		return err
 	}
 
+	if n.n.Predicate != nil {
+		predicate, err := makeIndexPredicate(
+			params.ctx, n.tableDesc, n.n.Predicate, &params.p.semaCtx, n.n.Table,
+		)
+		if err != nil {
+			return err
+		}
+		indexDesc.Predicate = predicate
+	}
+
 	if n.n.PartitionBy != nil {
 		partitioning, err := CreatePartitioning(params.ctx, params.p.ExecCfg().Settings,
 			params.EvalContext(), n.tableDesc, indexDesc, n.n.PartitionBy)
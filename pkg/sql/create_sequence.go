@@ -87,6 +87,10 @@ func doCreateSequence(
 	// makeSequenceTableDesc already validates the table. No call to
 	// desc.ValidateTable() needed here.
 
+	if err := params.p.assignSequenceOwner(params.ctx, &desc, opts); err != nil {
+		return err
+	}
+
 	key := sqlbase.NewTableKey(dbDesc.ID, name.Table()).Key()
 	if err = params.p.createDescriptorWithID(params.ctx, key, id, &desc, params.EvalContext().Settings); err != nil {
 		return err
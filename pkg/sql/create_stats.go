@@ -208,6 +208,10 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 				return nil, pgerror.UnimplementedWithIssuef(35844,
 					"CREATE STATISTICS is not supported for JSON columns")
 			}
+			if columns[i].Virtual {
+				return nil, pgerror.Unimplemented("virtual column stats",
+					"CREATE STATISTICS is not supported for virtual computed columns")
+			}
 			columnIDs[i] = columns[i].ID
 		}
 		createStatsColLists = []jobspb.CreateStatsDetails_ColList{{IDs: columnIDs}}
@@ -284,6 +288,14 @@ func createStatsDefaultColumns(
 	columns = append(columns, jobspb.CreateStatsDetails_ColList{IDs: []sqlbase.ColumnID{pkCol}})
 	requestedCols.Add(int(pkCol))
 
+	// Virtual computed columns are not stored in the primary index, which is
+	// the one that is sampled.
+	for i := range desc.Columns {
+		if desc.Columns[i].Virtual {
+			requestedCols.Add(int(desc.Columns[i].ID))
+		}
+	}
+
 	// Add columns for each secondary index.
 	for i := range desc.Indexes {
 		if desc.Indexes[i].Type == sqlbase.IndexDescriptor_INVERTED {
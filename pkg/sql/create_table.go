@@ -48,10 +48,11 @@ type createTableNode struct {
 // Privileges: CREATE on database.
 //   Notes: postgres/mysql require CREATE on database.
 func (p *planner) CreateTable(ctx context.Context, n *tree.CreateTable) (planNode, error) {
-	dbDesc, err := p.ResolveUncachedDatabase(ctx, &n.Table)
+	dbDesc, temporary, err := p.resolveTargetObjectMaybeTemporary(ctx, &n.Table, n.Temporary)
 	if err != nil {
 		return nil, err
 	}
+	n.Temporary = temporary
 
 	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
 		return nil, err
@@ -105,7 +106,16 @@ type createTableRun struct {
 func (n *createTableNode) startExec(params runParams) error {
 	tKey := sqlbase.NewTableKey(n.dbDesc.ID, n.n.Table.Table())
 	key := tKey.Key()
-	if exists, err := descExists(params.ctx, params.p.txn, key); err == nil && exists {
+	var exists bool
+	var err error
+	if n.n.Temporary {
+		// Temporary tables do not have a namespace entry.
+		key = nil
+		exists, err = params.p.temporaryObjectExists(params.ctx, n.dbDesc, n.n.Table.Table())
+	} else {
+		exists, err = descExists(params.ctx, params.p.txn, key)
+	}
+	if err == nil && exists {
 		if n.n.IfNotExists {
 			return nil
 		}
@@ -149,9 +159,15 @@ func (n *createTableNode) startExec(params runParams) error {
 		return err
 	}
 
+	if n.n.Temporary {
+		params.p.registerTemporaryObject(n.dbDesc.ID, &desc)
+	}
+
 	if desc.Adding() {
 		// if this table and all its references are created in the same
-		// transaction it can be made PUBLIC.
+		// transaction it can be made PUBLIC. Temporary tables can only be
+		// referenced by other temporary tables of the same session, so they
+		// can always be made PUBLIC.
 		refs, err := desc.FindAllReferences()
 		if err != nil {
 			return err
@@ -163,11 +179,17 @@ func (n *createTableNode) startExec(params runParams) error {
 				break
 			}
 		}
-		if !foundExternalReference {
+		if !foundExternalReference || desc.IsTemporary() {
 			desc.State = sqlbase.TableDescriptor_PUBLIC
 		}
 	}
 
+	if err := params.p.addTypeBackReferences(
+		params.ctx, id, columnTypes(desc.TableDesc()),
+	); err != nil {
+		return err
+	}
+
 	// Descriptor written to store here.
 	if err := params.p.createDescriptorWithID(
 		params.ctx, key, id, &desc, params.EvalContext().Settings); err != nil {
@@ -342,10 +364,14 @@ const (
 
 // Referenced cols must be unique, thus referenced indexes must match exactly.
 // Referencing cols have no uniqueness requirement and thus may match a strict
-// prefix of an index.
+// prefix of an index. Partial indexes never match, since they don't contain
+// all the rows of the table.
 func matchesIndex(
 	cols []sqlbase.ColumnDescriptor, idx sqlbase.IndexDescriptor, exact indexMatch,
 ) bool {
+	if idx.IsPartial() {
+		return false
+	}
 	if len(cols) > len(idx.ColumnIDs) || (exact && len(cols) != len(idx.ColumnIDs)) {
 		return false
 	}
@@ -444,6 +470,11 @@ func ResolveFK(
 	if err != nil {
 		return err
 	}
+	// The temporary state of new tables is only known once their descriptor
+	// is complete; it is checked by MakeTableDesc.
+	if ts != NewTable && target.IsTemporary() != tbl.IsTemporary() {
+		return errTemporaryFKMismatch(tbl.IsTemporary())
+	}
 	if target.ID == tbl.ID {
 		// When adding a self-ref FK to an _existing_ table, we want to make sure
 		// we edit the same copy.
@@ -567,6 +598,7 @@ func ResolveFK(
 		OnDelete:        sqlbase.ForeignKeyReferenceActionValue[d.Actions.Delete],
 		OnUpdate:        sqlbase.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:           sqlbase.CompositeKeyMatchMethodValue[d.Match],
+		Deferrability:   sqlbase.ConstraintDeferrabilityValue[d.Deferrability],
 	}
 
 	if ts != NewTable {
@@ -986,7 +1018,7 @@ func dequalifyColumnRefs(
 // any of the columns and no partitioning expression.
 //
 // semaCtx can be nil if the table to be created has no default expression on
-// any of the columns and no check constraints.
+// any of the columns, no check constraints and no partial indexes.
 //
 // The caller must also ensure that the SchemaResolver is configured
 // to bypass caching and enable visibility of just-added descriptors.
@@ -1104,6 +1136,13 @@ func MakeTableDesc(
 				}
 				idx.Partitioning = partitioning
 			}
+			if d.Predicate != nil {
+				predicate, err := makeIndexPredicate(ctx, &desc, d.Predicate, semaCtx, n.Table)
+				if err != nil {
+					return desc, err
+				}
+				idx.Predicate = predicate
+			}
 			if err := desc.AddIndex(idx, false); err != nil {
 				return desc, err
 			}
@@ -1111,10 +1150,13 @@ func MakeTableDesc(
 				return desc, pgerror.UnimplementedWithIssue(9148, "use CREATE INDEX to make interleaved indexes")
 			}
 		case *tree.UniqueConstraintTableDef:
+			// Deferrable unique indexes can temporarily contain duplicate keys, so
+			// they are encoded like non-unique indexes.
 			idx := sqlbase.IndexDescriptor{
-				Name:             string(d.Name),
-				Unique:           true,
-				StoreColumnNames: d.Storing.ToStrings(),
+				Name:                string(d.Name),
+				Unique:              d.Deferrability == tree.NotDeferrable,
+				UniqueDeferrability: sqlbase.ConstraintDeferrabilityValue[d.Deferrability],
+				StoreColumnNames:    d.Storing.ToStrings(),
 			}
 			if err := idx.FillColumns(d.Columns); err != nil {
 				return desc, err
@@ -1126,6 +1168,13 @@ func MakeTableDesc(
 				}
 				idx.Partitioning = partitioning
 			}
+			if d.Predicate != nil {
+				predicate, err := makeIndexPredicate(ctx, &desc, d.Predicate, semaCtx, n.Table)
+				if err != nil {
+					return desc, err
+				}
+				idx.Predicate = predicate
+			}
 			if err := desc.AddIndex(idx, d.PrimaryKey); err != nil {
 				return desc, err
 			}
@@ -1228,6 +1277,13 @@ func MakeTableDesc(
 			return desc, errors.Errorf("unsupported table def: %T", def)
 		}
 	}
+	// Temporary tables are dropped when their session ends, so they cannot be
+	// linked to permanent tables through foreign keys.
+	for _, ref := range affected {
+		if ref.IsTemporary() != n.Temporary {
+			return desc, errTemporaryFKMismatch(n.Temporary)
+		}
+	}
 	// Now that we have all the other columns set up, we can validate
 	// any computed columns.
 	for _, def := range n.Defs {
@@ -1258,8 +1314,16 @@ func makeTableDesc(
 	privileges *sqlbase.PrivilegeDescriptor,
 	affected map[sqlbase.ID]*sqlbase.MutableTableDescriptor,
 ) (ret sqlbase.MutableTableDescriptor, err error) {
-	// Process any SERIAL columns to remove the SERIAL type,
-	// as required by MakeTableDesc.
+	// Replace any LIKE clauses with the definitions they copy, as required by
+	// MakeTableDesc.
+	n, err = params.p.expandLikeTableDefs(params.ctx, n)
+	if err != nil {
+		return ret, err
+	}
+
+	// Process any SERIAL and identity columns to remove the SERIAL type and
+	// create the sequences of the identity columns, as required by
+	// MakeTableDesc.
 	createStmt := n
 	ensureCopy := func() {
 		if createStmt == n {
@@ -1278,6 +1342,14 @@ func makeTableDesc(
 			return ret, err
 		}
 		if seqName != nil {
+			if n.Temporary && d.IsIdentity() {
+				return ret, pgerror.UnimplementedWithIssueDetail(5807, "identity",
+					"identity columns are not supported in temporary tables")
+			}
+			if n.Temporary {
+				return ret, pgerror.UnimplementedWithIssueDetail(5807, "serial",
+					"SERIAL columns backed by sequences are not supported in temporary tables")
+			}
 			if err := doCreateSequence(params, n.String(), seqDbDesc, seqName, seqOpts); err != nil {
 				return ret, err
 			}
@@ -1308,7 +1380,174 @@ func makeTableDesc(
 			params.EvalContext(),
 		)
 	})
-	return ret, err
+	if err != nil {
+		return ret, err
+	}
+
+	// The identity columns own the sequences used by their default
+	// expressions, which were created above.
+	for i := range ret.Columns {
+		col := &ret.Columns[i]
+		if !col.IsIdentity() {
+			continue
+		}
+		for _, seqID := range col.UsesSequenceIds {
+			seqDesc, ok := affected[seqID]
+			if !ok {
+				return ret, pgerror.AssertionFailedf(
+					"sequence %d of identity column %q not found", seqID, col.Name)
+			}
+			setSequenceOwner(seqDesc, ret.ID, col)
+		}
+	}
+	return ret, nil
+}
+
+// expandLikeTableDefs returns a copy of the CreateTable statement in which the
+// LIKE clauses are replaced by the definitions of the columns of the source
+// tables and, depending on the options of each clause, by the definitions of
+// their default values, computed columns, check constraints and indexes. The
+// statement is returned unchanged if it has no LIKE clause.
+//
+// As in Postgres, NOT NULL constraints are always copied, and foreign keys,
+// column families, partitioning and interleaving are never copied. The hidden
+// columns of the source table are not copied either: if its primary key is on
+// such a column, the new table gets its own, unless it defines another one.
+func (p *planner) expandLikeTableDefs(
+	ctx context.Context, n *tree.CreateTable,
+) (*tree.CreateTable, error) {
+	hasLike := false
+	for _, def := range n.Defs {
+		if _, ok := def.(*tree.LikeTableDef); ok {
+			hasLike = true
+		}
+	}
+	if !hasLike {
+		return n, nil
+	}
+
+	defs := make(tree.TableDefs, 0, len(n.Defs))
+	for _, def := range n.Defs {
+		like, ok := def.(*tree.LikeTableDef)
+		if !ok {
+			defs = append(defs, def)
+			continue
+		}
+		desc, err := ResolveExistingObject(ctx, p, &like.Name, true /*required*/, ResolveRequireTableOrViewDesc)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.CheckPrivilege(ctx, desc, privilege.SELECT); err != nil {
+			return nil, err
+		}
+		likeDefs, err := makeLikeTableDefs(desc.TableDesc(), like.Opts())
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, likeDefs...)
+	}
+	newStmt := *n
+	newStmt.Defs = defs
+	return &newStmt, nil
+}
+
+// makeLikeTableDefs returns the definitions copied from the given table by a
+// LIKE clause with the given options.
+func makeLikeTableDefs(
+	desc *sqlbase.TableDescriptor, opts tree.LikeTableOpt,
+) (tree.TableDefs, error) {
+	var defs tree.TableDefs
+	copiedCols := make(map[string]struct{})
+	cols := desc.VisibleColumns()
+	for i := range cols {
+		col := &cols[i]
+		def := &tree.ColumnTableDef{Name: tree.Name(col.Name), Type: &col.Type}
+		def.Nullable.Nullability = tree.Null
+		if !col.Nullable {
+			def.Nullable.Nullability = tree.NotNull
+		}
+		if col.DefaultExpr != nil && opts.Has(tree.LikeTableOptDefaults) {
+			expr, err := parser.ParseExpr(*col.DefaultExpr)
+			if err != nil {
+				return nil, err
+			}
+			def.DefaultExpr.Expr = expr
+		}
+		if col.IsComputed() && opts.Has(tree.LikeTableOptGenerated) {
+			expr, err := parser.ParseExpr(*col.ComputeExpr)
+			if err != nil {
+				return nil, err
+			}
+			def.Computed.Computed = true
+			def.Computed.Expr = expr
+			def.Computed.Virtual = col.Virtual
+		}
+		defs = append(defs, def)
+		copiedCols[col.Name] = struct{}{}
+	}
+
+	if opts.Has(tree.LikeTableOptConstraints) {
+		for _, check := range desc.Checks {
+			if check.Validity == sqlbase.ConstraintValidity_Validating || check.IsNonNullConstraint {
+				// The constraint is still being added.
+				continue
+			}
+			expr, err := parser.ParseExpr(check.Expr)
+			if err != nil {
+				return nil, err
+			}
+			defs = append(defs, &tree.CheckConstraintTableDef{Name: tree.Name(check.Name), Expr: expr})
+		}
+	}
+
+	if opts.Has(tree.LikeTableOptIndexes) && desc.IsPhysicalTable() {
+		allIdx := append([]sqlbase.IndexDescriptor{desc.PrimaryIndex}, desc.Indexes...)
+	indexLoop:
+		for i := range allIdx {
+			idx := &allIdx[i]
+			indexDef := tree.IndexTableDef{
+				Name:    tree.Name(idx.Name),
+				Columns: make(tree.IndexElemList, len(idx.ColumnNames)),
+			}
+			for j, name := range idx.ColumnNames {
+				if _, ok := copiedCols[name]; !ok {
+					// The index is on a hidden column.
+					continue indexLoop
+				}
+				indexDef.Columns[j].Column = tree.Name(name)
+				if idx.ColumnDirections[j] == sqlbase.IndexDescriptor_DESC {
+					indexDef.Columns[j].Direction = tree.Descending
+				}
+			}
+			for _, name := range idx.StoreColumnNames {
+				indexDef.Storing = append(indexDef.Storing, tree.Name(name))
+			}
+			indexDef.Inverted = idx.Type == sqlbase.IndexDescriptor_INVERTED
+			if idx.IsPartial() {
+				expr, err := parser.ParseExpr(idx.Predicate)
+				if err != nil {
+					return nil, err
+				}
+				indexDef.Predicate = expr
+			}
+
+			switch {
+			case idx.ID == desc.PrimaryIndex.ID:
+				defs = append(defs, &tree.UniqueConstraintTableDef{
+					IndexTableDef: indexDef,
+					PrimaryKey:    true,
+				})
+			case idx.Unique || idx.IsDeferrableUnique():
+				defs = append(defs, &tree.UniqueConstraintTableDef{
+					IndexTableDef: indexDef,
+					Deferrability: sqlbase.TreeConstraintDeferrabilityValue[idx.UniqueDeferrability],
+				})
+			default:
+				defs = append(defs, &indexDef)
+			}
+		}
+	}
+	return defs, nil
 }
 
 // dummyColumnItem is used in MakeCheckConstraint to construct an expression
@@ -1479,8 +1718,12 @@ func validateComputedColumn(
 		return err
 	}
 
+	typ, err := tree.ResolveType(d.Type, semaCtx)
+	if err != nil {
+		return err
+	}
 	if _, err := sqlbase.SanitizeVarFreeExpr(
-		replacedExpr, d.Type, "computed column", semaCtx, false, /* allowImpure */
+		replacedExpr, typ, "computed column", semaCtx, false, /* allowImpure */
 	); err != nil {
 		return err
 	}
@@ -0,0 +1,149 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+type createTriggerNode struct {
+	n         *tree.CreateTrigger
+	tableDesc *sqlbase.MutableTableDescriptor
+	trigger   sqlbase.TableDescriptor_Trigger
+}
+
+// CreateTrigger creates a trigger on a table.
+// Privileges: CREATE on table.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	tableDesc, err := p.ResolveMutableTableDescriptor(ctx, &n.Table, true /* required */, ResolveRequireTableDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == string(n.Name) {
+			return nil, pgerror.Newf(pgerror.CodeDuplicateObjectError,
+				"trigger %q for relation %q already exists", n.Name, tableDesc.Name)
+		}
+	}
+
+	trigger := sqlbase.TableDescriptor_Trigger{
+		Name:       string(n.Name),
+		ActionTime: sqlbase.TableDescriptor_Trigger_AFTER,
+		ForEachRow: n.ForEachRow,
+		Body:       n.Body,
+	}
+	if n.ActionTime == tree.TriggerBefore {
+		trigger.ActionTime = sqlbase.TableDescriptor_Trigger_BEFORE
+	}
+	for _, event := range n.Events {
+		e := sqlbase.TableDescriptor_Trigger_Event(event)
+		found := false
+		for _, prev := range trigger.Events {
+			found = found || prev == e
+		}
+		if !found {
+			trigger.Events = append(trigger.Events, e)
+		}
+	}
+	if n.When != nil {
+		if err := p.checkTriggerWhen(tableDesc.TableDesc(), n); err != nil {
+			return nil, err
+		}
+		trigger.When = tree.Serialize(n.When)
+	}
+
+	// The body is prepared again whenever the table is used, but errors are
+	// better reported now.
+	t := &sqlTrigger{desc: &trigger, resultType: types.EmptyTuple}
+	if err := t.prepare(tableDesc.TableDesc()); err != nil {
+		return nil, err
+	}
+
+	return &createTriggerNode{n: n, tableDesc: tableDesc, trigger: trigger}, nil
+}
+
+// checkTriggerWhen checks the WHEN condition of a trigger. The condition of a
+// row-level trigger can reference the new values of the affected row, unless
+// the trigger fires on DELETE, and its old values, unless the trigger fires on
+// INSERT.
+func (p *planner) checkTriggerWhen(desc *sqlbase.TableDescriptor, n *tree.CreateTrigger) error {
+	var firesOnInsert, firesOnDelete bool
+	for _, event := range n.Events {
+		firesOnInsert = firesOnInsert || event == tree.TriggerInsert
+		firesOnDelete = firesOnDelete || event == tree.TriggerDelete
+	}
+
+	// Replace the references to the affected row with NULL values of the
+	// column types, so that the condition can be type checked.
+	expr, err := tree.SimpleVisit(n.When, func(expr tree.Expr) (bool, tree.Expr, error) {
+		name, ok := expr.(*tree.UnresolvedName)
+		if !ok || !isTriggerRowReference(name) {
+			return true, expr, nil
+		}
+		isNew := name.Parts[1] == "new"
+		switch {
+		case !n.ForEachRow:
+			return false, nil, pgerror.Newf(pgerror.CodeInvalidObjectDefinitionError,
+				"statement trigger's WHEN condition cannot reference column values")
+		case firesOnInsert && !isNew:
+			return false, nil, pgerror.Newf(pgerror.CodeInvalidObjectDefinitionError,
+				"INSERT trigger's WHEN condition cannot reference OLD values")
+		case firesOnDelete && isNew:
+			return false, nil, pgerror.Newf(pgerror.CodeInvalidObjectDefinitionError,
+				"DELETE trigger's WHEN condition cannot reference NEW values")
+		}
+		ord, err := findTriggerColumn(desc, name)
+		if err != nil {
+			return false, nil, err
+		}
+		return false, &tree.CastExpr{Expr: tree.DNull, Type: &desc.Columns[ord].Type}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	defer p.semaCtx.Properties.Restore(p.semaCtx.Properties)
+	p.semaCtx.Properties.Require("WHEN", tree.RejectSpecial|tree.RejectSubqueries)
+	_, err = tree.TypeCheckAndRequire(expr, &p.semaCtx, types.Bool, "WHEN")
+	return err
+}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	triggers := append(n.tableDesc.Triggers, n.trigger)
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].Name < triggers[j].Name
+	})
+	n.tableDesc.Triggers = triggers
+
+	if err := n.tableDesc.Validate(params.ctx, params.p.txn, params.EvalContext().Settings); err != nil {
+		return err
+	}
+
+	return params.p.writeSchemaChange(params.ctx, n.tableDesc, sqlbase.InvalidMutationID)
+}
+
+func (*createTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (*createTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createTriggerNode) Close(context.Context)        {}
@@ -0,0 +1,157 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/enum"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+type createTypeNode struct {
+	n      *tree.CreateType
+	dbDesc *sqlbase.DatabaseDescriptor
+	// fields are the attributes of a composite type, with their types
+	// resolved.
+	fields []sqlbase.TypeDescriptor_CompositeField
+}
+
+func (p *planner) CreateType(ctx context.Context, n *tree.CreateType) (planNode, error) {
+	dbDesc, err := p.ResolveUncachedDatabase(ctx, &n.TypeName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	var fields []sqlbase.TypeDescriptor_CompositeField
+	switch n.Variety {
+	case tree.Enum:
+		seen := make(map[string]struct{}, len(n.EnumLabels))
+		for _, label := range n.EnumLabels {
+			if _, ok := seen[label]; ok {
+				return nil, pgerror.Newf(pgerror.CodeInvalidObjectDefinitionError,
+					"enum definition contains duplicate value %q", label)
+			}
+			seen[label] = struct{}{}
+		}
+
+	case tree.Composite:
+		fields = make([]sqlbase.TypeDescriptor_CompositeField, len(n.CompositeFields))
+		seen := make(map[string]struct{}, len(n.CompositeFields))
+		for i := range n.CompositeFields {
+			field := &n.CompositeFields[i]
+			name := string(field.Name)
+			if _, ok := seen[name]; ok {
+				return nil, pgerror.Newf(pgerror.CodeDuplicateColumnError,
+					"column %q specified more than once", name)
+			}
+			seen[name] = struct{}{}
+			typ, err := p.resolveCompositeFieldType(field.Type)
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = sqlbase.TypeDescriptor_CompositeField{Name: name, Type: *typ}
+		}
+	}
+
+	return &createTypeNode{
+		n:      n,
+		dbDesc: dbDesc,
+		fields: fields,
+	}, nil
+}
+
+// resolveCompositeFieldType resolves and validates the type of a field of a
+// composite type. The fields can have any type that a column can have, except
+// for user-defined types: the column types that embed the composite type would
+// not be updated when the user-defined type is altered.
+func (p *planner) resolveCompositeFieldType(t *types.T) (*types.T, error) {
+	typ, err := tree.ResolveType(t, &p.semaCtx)
+	if err != nil {
+		return nil, err
+	}
+	if err := sqlbase.ValidateColumnDefType(typ); err != nil {
+		return nil, err
+	}
+	elemTyp := typ
+	for elemTyp.Family() == types.ArrayFamily {
+		elemTyp = elemTyp.ArrayContents()
+	}
+	if elemTyp.TypeID() != 0 {
+		return nil, pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+			"fields of composite types cannot have user-defined type %s", elemTyp.SQLString())
+	}
+	return typ, nil
+}
+
+func (n *createTypeNode) startExec(params runParams) error {
+	tKey := sqlbase.NewTableKey(n.dbDesc.ID, n.n.TypeName.Table())
+	if exists, err := descExists(params.ctx, params.p.txn, tKey.Key()); err == nil && exists {
+		return pgerror.Newf(pgerror.CodeDuplicateObjectError,
+			"type %q already exists", tKey.Name())
+	} else if err != nil {
+		return err
+	}
+
+	id, err := GenerateUniqueDescID(params.ctx, params.p.ExecCfg().DB)
+	if err != nil {
+		return err
+	}
+
+	// Inherit permissions from the database descriptor.
+	privs := n.dbDesc.GetPrivileges()
+
+	typDesc := &sqlbase.TypeDescriptor{
+		Name:       n.n.TypeName.Table(),
+		ID:         id,
+		ParentID:   n.dbDesc.ID,
+		Version:    1,
+		Privileges: privs,
+	}
+	switch n.n.Variety {
+	case tree.Enum:
+		physicalReps := enum.GenerateNEvenlySpacedBytes(len(n.n.EnumLabels))
+		members := make([]sqlbase.TypeDescriptor_EnumMember, len(n.n.EnumLabels))
+		for i, label := range n.n.EnumLabels {
+			members[i] = sqlbase.TypeDescriptor_EnumMember{
+				LogicalRepresentation:  label,
+				PhysicalRepresentation: physicalReps[i],
+				Capability:             sqlbase.TypeDescriptor_EnumMember_ALL,
+			}
+		}
+		typDesc.Kind = sqlbase.TypeDescriptor_ENUM
+		typDesc.EnumMembers = members
+
+	case tree.Composite:
+		typDesc.Kind = sqlbase.TypeDescriptor_COMPOSITE
+		typDesc.CompositeFields = n.fields
+	}
+	if err := typDesc.Validate(); err != nil {
+		return err
+	}
+
+	return params.p.createDescriptorWithID(
+		params.ctx, tKey.Key(), id, typDesc, params.EvalContext().Settings)
+}
+
+func (*createTypeNode) Next(runParams) (bool, error) { return false, nil }
+func (*createTypeNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createTypeNode) Close(context.Context)        {}
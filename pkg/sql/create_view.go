@@ -16,8 +16,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -33,6 +35,9 @@ type createViewNode struct {
 	// depends on. This is collected during the construction of
 	// the view query's logical plan.
 	planDeps planDependencies
+	// fnDeps tracks which user-defined functions the view being created
+	// depends on.
+	fnDeps planFunctionDependencies
 }
 
 // CreateView creates a view.
@@ -41,27 +46,49 @@ type createViewNode struct {
 //						selected columns.
 //          mysql requires CREATE VIEW plus SELECT on all the selected columns.
 func (p *planner) CreateView(ctx context.Context, n *tree.CreateView) (planNode, error) {
-	dbDesc, err := p.ResolveUncachedDatabase(ctx, &n.Name)
+	dbDesc, temporary, err := p.resolveTargetObjectMaybeTemporary(ctx, &n.Name, n.Temporary)
 	if err != nil {
 		return nil, err
 	}
+	n.Temporary = temporary
 
 	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
 		return nil, err
 	}
 
 	var planDeps planDependencies
+	var fnDeps planFunctionDependencies
 	var sourceColumns sqlbase.ResultColumns
 	// To avoid races with ongoing schema changes to tables that the view
 	// depends on, make sure we use the most recent versions of table
 	// descriptors rather than the copies in the lease cache.
 	p.runWithOptions(resolveFlags{skipCache: true}, func() {
-		planDeps, sourceColumns, err = p.analyzeViewQuery(ctx, n.AsSource)
+		planDeps, fnDeps, sourceColumns, err = p.analyzeViewQuery(ctx, n.AsSource)
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// As in PostgreSQL, a view that depends on temporary objects is itself
+	// temporary.
+	if !n.Temporary {
+		for _, dep := range planDeps {
+			if dep.desc.IsTemporary() {
+				if n.Materialized {
+					return nil, pgerror.New(pgerror.CodeFeatureNotSupportedError,
+						"materialized views must not use temporary tables or views")
+				}
+				if n.Name.ExplicitSchema {
+					return nil, pgerror.New(pgerror.CodeInvalidTableDefinitionError,
+						"cannot create temporary relation in non-temporary schema")
+				}
+				n.Temporary = true
+				n.Name.SchemaName = sessiondata.PgTempSchemaName
+				break
+			}
+		}
+	}
+
 	// Ensure that all the table names pretty-print as fully qualified,
 	// so we store that in the view descriptor.
 	//
@@ -103,6 +130,7 @@ func (p *planner) CreateView(ctx context.Context, n *tree.CreateView) (planNode,
 		dbDesc:        dbDesc,
 		sourceColumns: sourceColumns,
 		planDeps:      planDeps,
+		fnDeps:        fnDeps,
 	}, nil
 }
 
@@ -110,7 +138,16 @@ func (n *createViewNode) startExec(params runParams) error {
 	viewName := n.n.Name.Table()
 	tKey := sqlbase.NewTableKey(n.dbDesc.ID, viewName)
 	key := tKey.Key()
-	if exists, err := descExists(params.ctx, params.p.txn, key); err == nil && exists {
+	var exists bool
+	var err error
+	if n.n.Temporary {
+		// Temporary views do not have a namespace entry.
+		key = nil
+		exists, err = params.p.temporaryObjectExists(params.ctx, n.dbDesc, viewName)
+	} else {
+		exists, err = descExists(params.ctx, params.p.txn, key)
+	}
+	if err == nil && exists {
 		// TODO(a-robinson): Support CREATE OR REPLACE commands.
 		return sqlbase.NewRelationAlreadyExistsError(tKey.Name())
 	} else if err != nil {
@@ -142,6 +179,14 @@ func (n *createViewNode) startExec(params runParams) error {
 	for backrefID := range n.planDeps {
 		desc.DependsOn = append(desc.DependsOn, backrefID)
 	}
+	// Collect all the user-defined functions this view depends on.
+	for fnID := range n.fnDeps {
+		desc.DependsOnFunctions = append(desc.DependsOnFunctions, fnID)
+	}
+
+	if n.n.Temporary {
+		params.p.registerTemporaryObject(n.dbDesc.ID, &desc)
+	}
 
 	if err = params.p.createDescriptorWithID(
 		params.ctx, key, id, &desc, params.EvalContext().Settings); err != nil {
@@ -169,10 +214,31 @@ func (n *createViewNode) startExec(params runParams) error {
 		}
 	}
 
+	// Persist the back-references in all referenced function descriptors.
+	kvTrace := params.p.ExtendedEvalContext().Tracing.KVTracingEnabled()
+	for _, fnDesc := range n.fnDeps {
+		fnDesc.DependedOnBy = append(fnDesc.DependedOnBy, sqlbase.TableDescriptor_Reference{ID: desc.ID})
+		fnDesc.Version++
+		if err := writeFunctionDesc(params.ctx, params.p.txn, fnDesc, kvTrace); err != nil {
+			return err
+		}
+	}
+
 	if err := desc.Validate(params.ctx, params.p.txn, params.EvalContext().Settings); err != nil {
 		return err
 	}
 
+	if desc.MaterializedView() {
+		// The contents of a materialized view are computed in the same
+		// transaction that creates it.
+		if err := populateMaterializedView(
+			params.ctx, params.ExecCfg(), params.p.txn, params.p.User(),
+			sqlbase.NewImmutableTableDescriptor(*desc.TableDesc()),
+		); err != nil {
+			return err
+		}
+	}
+
 	// Log Create View event. This is an auditable log event and is
 	// recorded in the same transaction as the table descriptor update.
 	return MakeEventLogger(params.extendedEvalCtx.ExecCfg).InsertEventRecord(
@@ -212,6 +278,9 @@ func (n *createViewNode) makeViewTableDesc(
 	desc := InitTableDescriptor(id, parentID, viewName,
 		params.p.txn.CommitTimestamp(), privileges)
 	desc.ViewQuery = tree.AsStringWithFlags(n.n.AsSource, tree.FmtParsable)
+	// A materialized view is stored like a table, so AllocateIDs adds a hidden
+	// rowid primary key to it.
+	desc.IsMaterializedView = n.n.Materialized
 	for i, colRes := range resultColumns {
 		columnTableDef := tree.ColumnTableDef{Name: tree.Name(colRes.Name), Type: colRes.Typ}
 		if len(columnNames) > i {
@@ -246,6 +315,7 @@ func MakeViewTableDesc(
 	viewName := n.Name.Table()
 	desc := InitTableDescriptor(id, parentID, viewName, creationTime, privileges)
 	desc.ViewQuery = tree.AsStringWithFlags(n.AsSource, tree.FmtParsable)
+	desc.IsMaterializedView = n.Materialized
 
 	for i, colRes := range resultColumns {
 		columnTableDef := tree.ColumnTableDef{Name: tree.Name(colRes.Name), Type: colRes.Typ}
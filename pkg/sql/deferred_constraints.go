@@ -0,0 +1,534 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// deferredConstraintsMode is the mode set by SET CONSTRAINTS ALL for the
+// deferrable constraints of the current transaction.
+type deferredConstraintsMode int
+
+const (
+	// deferredConstraintsInitial uses the INITIALLY DEFERRED or INITIALLY
+	// IMMEDIATE mode each constraint was declared with.
+	deferredConstraintsInitial deferredConstraintsMode = iota
+	// deferredConstraintsDeferred checks all the deferrable constraints at
+	// commit time.
+	deferredConstraintsDeferred
+	// deferredConstraintsImmediate checks all the deferrable constraints at
+	// the end of each statement.
+	deferredConstraintsImmediate
+)
+
+// deferredConstraintKey identifies a deferrable constraint: either the
+// foreign key or the UNIQUE constraint carried by an index.
+type deferredConstraintKey struct {
+	tableID sqlbase.ID
+	indexID sqlbase.IndexID
+	unique  bool
+}
+
+// deferredConstraint is a deferrable constraint that may have been violated
+// by a statement of the current transaction and that still needs to be
+// validated.
+type deferredConstraint struct {
+	deferredConstraintKey
+	initiallyDeferred bool
+
+	// keys are the distinct key values to validate the constraint for: the
+	// values of the columns of the index carrying the constraint, for each
+	// row that was written or, for foreign keys, whose referenced row was
+	// removed. seen contains the encodings of keys.
+	keys []tree.Datums
+	seen map[string]struct{}
+}
+
+// deferredConstraintCollection queues the deferrable constraints that the
+// statements of a transaction need validated. Deferrable foreign keys and
+// UNIQUE constraints are not checked row by row: the writers of each mutation
+// report the key values of the rows they modify here (see row.DeferredChecks),
+// and the constraints are validated for these values only at the end of the
+// statement or, if they are deferred, right before the transaction commits.
+type deferredConstraintCollection struct {
+	// mode is set by SET CONSTRAINTS ALL and lasts until the end of the
+	// transaction.
+	mode deferredConstraintsMode
+
+	// forceImmediate is set when the collection belongs to an executor that
+	// doesn't control the commit of its transaction (e.g. an internal executor
+	// using the transaction of its caller). All the constraints are then
+	// validated at the end of each statement.
+	forceImmediate bool
+
+	// pending lists the constraints to validate, in the order they were
+	// registered. byKey indexes them.
+	pending []*deferredConstraint
+	byKey   map[deferredConstraintKey]*deferredConstraint
+
+	// scratch is used to encode the key values.
+	scratch []byte
+}
+
+var _ row.DeferredChecks = &deferredConstraintCollection{}
+
+// reset forgets the pending constraints and the mode of the transaction.
+func (dc *deferredConstraintCollection) reset() {
+	dc.mode = deferredConstraintsInitial
+	dc.pending = nil
+	dc.byKey = nil
+}
+
+// setMode implements SET CONSTRAINTS ALL. Constraints that become immediate
+// are validated at the end of the SET CONSTRAINTS statement itself.
+func (dc *deferredConstraintCollection) setMode(deferred bool) {
+	if deferred {
+		dc.mode = deferredConstraintsDeferred
+	} else {
+		dc.mode = deferredConstraintsImmediate
+	}
+}
+
+// isDeferred returns whether the validation of c must wait for the commit.
+func (dc *deferredConstraintCollection) isDeferred(c *deferredConstraint) bool {
+	if dc.forceImmediate {
+		return false
+	}
+	switch dc.mode {
+	case deferredConstraintsDeferred:
+		return true
+	case deferredConstraintsImmediate:
+		return false
+	default:
+		return c.initiallyDeferred
+	}
+}
+
+// AddForeignKeyCheck is part of the row.DeferredChecks interface.
+func (dc *deferredConstraintCollection) AddForeignKeyCheck(
+	tableID sqlbase.ID, idx *sqlbase.IndexDescriptor, values tree.Datums,
+) {
+	dc.add(
+		deferredConstraintKey{tableID: tableID, indexID: idx.ID},
+		idx.ForeignKey.Deferrability == sqlbase.ConstraintDeferrability_DeferrableInitiallyDeferred,
+		values,
+	)
+}
+
+// AddUniqueCheck is part of the row.DeferredChecks interface.
+func (dc *deferredConstraintCollection) AddUniqueCheck(
+	tableID sqlbase.ID, idx *sqlbase.IndexDescriptor, values tree.Datums,
+) {
+	dc.add(
+		deferredConstraintKey{tableID: tableID, indexID: idx.ID, unique: true},
+		idx.UniqueDeferrability == sqlbase.ConstraintDeferrability_DeferrableInitiallyDeferred,
+		values,
+	)
+}
+
+// add queues the validation of a constraint for the given key values, unless
+// they are already queued.
+func (dc *deferredConstraintCollection) add(
+	key deferredConstraintKey, initiallyDeferred bool, values tree.Datums,
+) {
+	c, ok := dc.byKey[key]
+	if !ok {
+		c = &deferredConstraint{
+			deferredConstraintKey: key,
+			initiallyDeferred:     initiallyDeferred,
+			seen:                  make(map[string]struct{}),
+		}
+		if dc.byKey == nil {
+			dc.byKey = make(map[deferredConstraintKey]*deferredConstraint)
+		}
+		dc.byKey[key] = c
+		dc.pending = append(dc.pending, c)
+	}
+	// The values come from the columns of an index, so they can always be key
+	// encoded. Should that fail anyway, the values are validated again rather
+	// than skipped.
+	var err error
+	dc.scratch, err = sqlbase.EncodeDatumsKeyAscending(dc.scratch[:0], values)
+	if err == nil {
+		if _, ok := c.seen[string(dc.scratch)]; ok {
+			return
+		}
+		c.seen[string(dc.scratch)] = struct{}{}
+	}
+	c.keys = append(c.keys, values)
+}
+
+// registerDeferrableConstraints prepares the validation of the deferrable
+// constraints that a mutation of the given table may violate. fkTables is the
+// metadata of the tables involved in the mutation's foreign key checks and
+// cascades.
+//
+// It returns the row.DeferredChecks that the writers of the mutation must
+// report the rows they modify to, or nil if the mutation can't violate any
+// deferrable constraint. Validating deferred constraints requires that the
+// statement doesn't commit its transaction, so auto-commit is disabled in the
+// former case.
+func (p *planner) registerDeferrableConstraints(
+	desc *sqlbase.ImmutableTableDescriptor, fkTables row.FkTableMetadata, checkType row.FKCheckType,
+) (row.DeferredChecks, error) {
+	lookup := func(id sqlbase.ID) *sqlbase.ImmutableTableDescriptor {
+		if id == desc.ID {
+			return desc
+		}
+		return fkTables[id].Desc
+	}
+	// hasDeferrable returns whether writing to the table, if writes is set, or
+	// removing rows from it may violate a deferrable constraint.
+	hasDeferrable := func(table *sqlbase.ImmutableTableDescriptor, writes bool) bool {
+		for _, idx := range table.AllNonDropIndexes() {
+			if writes && (idx.ForeignKey.IsDeferrable() || idx.IsDeferrableUnique()) {
+				return true
+			}
+			if checkType == row.CheckInserts {
+				continue
+			}
+			for _, ref := range idx.ReferencedBy {
+				other := lookup(ref.Table)
+				if other == nil {
+					continue
+				}
+				otherIdx, err := other.FindIndexByID(ref.Index)
+				if err != nil {
+					continue
+				}
+				if otherIdx.ForeignKey.IsDeferrable() {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	found := hasDeferrable(desc, checkType != row.CheckDeletes)
+	if !found && checkType != row.CheckInserts {
+		// The cascading actions of the mutation may modify the rows of other
+		// tables.
+		for id, entry := range fkTables {
+			if id != desc.ID && entry.Desc != nil && hasDeferrable(entry.Desc, true /* writes */) {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	dc := p.extendedEvalCtx.DeferredConstraints
+	if dc == nil {
+		return nil, pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+			"cannot modify table %q with deferrable constraints outside of a session",
+			desc.Name)
+	}
+	p.autoCommit = false
+	return dc, nil
+}
+
+// validate checks the pending constraints. If atCommit is false, only the
+// constraints that are not deferred are checked; the others remain pending.
+// tables is the table collection of the transaction, used to resolve the
+// tables it created or modified.
+func (dc *deferredConstraintCollection) validate(
+	ctx context.Context,
+	ie *SessionBoundInternalExecutor,
+	tables *TableCollection,
+	txn *client.Txn,
+	atCommit bool,
+) error {
+	if len(dc.pending) == 0 {
+		return nil
+	}
+	if tables != nil && len(tables.uncommittedTables) > 0 {
+		ie.impl.tcModifier = tables
+		defer func() {
+			ie.impl.tcModifier = nil
+		}()
+	}
+
+	remaining := dc.pending[:0]
+	for i, c := range dc.pending {
+		if !atCommit && dc.isDeferred(c) {
+			remaining = append(remaining, c)
+			continue
+		}
+		if err := c.validate(ctx, ie, txn); err != nil {
+			// Keep the constraints that were not validated yet.
+			dc.pending = append(remaining, dc.pending[i:]...)
+			return err
+		}
+		delete(dc.byKey, c.deferredConstraintKey)
+	}
+	dc.pending = remaining
+	return nil
+}
+
+// deferredConstraintsBatchSize is the maximum number of key values that are
+// validated by a single query.
+const deferredConstraintsBatchSize = 100
+
+// validate checks that the constraint holds for the queued key values.
+// Constraints that were dropped in the meantime are ignored.
+func (c *deferredConstraint) validate(
+	ctx context.Context, ie *SessionBoundInternalExecutor, txn *client.Txn,
+) error {
+	tableDesc, err := sqlbase.GetTableDescFromID(ctx, txn, c.tableID)
+	if err != nil {
+		if err == sqlbase.ErrDescriptorNotFound {
+			return nil
+		}
+		return err
+	}
+	if tableDesc.Dropped() {
+		return nil
+	}
+	idx, err := tableDesc.FindIndexByID(c.indexID)
+	if err != nil {
+		// The index, and so the constraint, was dropped.
+		return nil
+	}
+	if c.unique && !idx.IsDeferrableUnique() || !c.unique && !idx.ForeignKey.IsDeferrable() {
+		return nil
+	}
+
+	for len(c.keys) > 0 {
+		n := len(c.keys)
+		if n > deferredConstraintsBatchSize {
+			n = deferredConstraintsBatchSize
+		}
+		if c.unique {
+			err = validateUniqueKeys(ctx, tableDesc, idx, c.keys[:n], ie, txn)
+		} else {
+			err = validateForeignKeyKeys(ctx, tableDesc, idx, c.keys[:n], ie, txn)
+		}
+		if err != nil {
+			return err
+		}
+		c.keys = c.keys[n:]
+	}
+	return nil
+}
+
+// keysFilter returns a filter on the given columns that selects the rows
+// matching one of the keys, and the arguments to use with it. A NULL value
+// only matches a NULL.
+func keysFilter(cols []string, keys []tree.Datums) (string, []interface{}) {
+	var buf bytes.Buffer
+	var args []interface{}
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteString(" OR ")
+		}
+		buf.WriteByte('(')
+		for j, d := range key {
+			if j > 0 {
+				buf.WriteString(" AND ")
+			}
+			if d == tree.DNull {
+				fmt.Fprintf(&buf, "%s IS NULL", cols[j])
+				continue
+			}
+			args = append(args, d)
+			fmt.Fprintf(&buf, "%s = $%d", cols[j], len(args))
+		}
+		buf.WriteByte(')')
+	}
+	return buf.String(), args
+}
+
+// validateUniqueKeys checks that no two rows of the table have the same
+// values as one of the given keys in the columns of the index.
+func validateUniqueKeys(
+	ctx context.Context,
+	tableDesc *sqlbase.TableDescriptor,
+	idx *sqlbase.IndexDescriptor,
+	keys []tree.Datums,
+	ie *SessionBoundInternalExecutor,
+	txn *client.Txn,
+) error {
+	cols := make([]string, len(idx.ColumnNames))
+	for i, n := range idx.ColumnNames {
+		cols[i] = tree.NameString(n)
+	}
+	where, args := keysFilter(cols, keys)
+	if idx.IsPartial() {
+		where = fmt.Sprintf("(%s) AND (%s)", where, idx.Predicate)
+	}
+	query := fmt.Sprintf(
+		`SELECT %[1]s FROM [%[2]d AS tbl] WHERE %[3]s GROUP BY %[1]s HAVING count(*) > 1 LIMIT 1`,
+		strings.Join(cols, ", "), tableDesc.ID, where,
+	)
+
+	log.VEventf(ctx, 2, "validating UNIQUE constraint %q on %q for %d keys with query %q",
+		idx.Name, tableDesc.Name, len(keys), query)
+
+	values, err := ie.QueryRow(ctx, "validate unique constraint", txn, query, args...)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valStrs := make([]string, len(values))
+		for i, val := range values {
+			valStrs[i] = val.String()
+		}
+		return pgerror.Newf(pgerror.CodeUniqueViolationError,
+			"duplicate key value (%s)=(%s) violates unique constraint %q",
+			strings.Join(idx.ColumnNames, ","), strings.Join(valStrs, ","), idx.Name)
+	}
+	return nil
+}
+
+// validateForeignKeyKeys checks that the rows of the referencing table which
+// have one of the given keys in their referencing columns satisfy the foreign
+// key carried by srcIdx. It is the counterpart of validateForeignKey for a
+// subset of the rows.
+func validateForeignKeyKeys(
+	ctx context.Context,
+	srcTable *sqlbase.TableDescriptor,
+	srcIdx *sqlbase.IndexDescriptor,
+	keys []tree.Datums,
+	ie *SessionBoundInternalExecutor,
+	txn *client.Txn,
+) error {
+	targetTable, err := sqlbase.GetTableDescFromID(ctx, txn, srcIdx.ForeignKey.Table)
+	if err != nil {
+		return err
+	}
+	targetIdx, err := targetTable.FindIndexByID(srcIdx.ForeignKey.Index)
+	if err != nil {
+		return err
+	}
+
+	prefix := len(srcIdx.ColumnNames)
+	if srcIdx.ForeignKey.SharedPrefixLen != 0 {
+		prefix = int(srcIdx.ForeignKey.SharedPrefixLen)
+	}
+
+	colNames := append([]string(nil), srcIdx.ColumnNames...)
+	// ExtraColumns will include primary index key values not already part of the index.
+	for _, id := range srcIdx.ExtraColumnIDs {
+		column, err := srcTable.FindActiveColumnByID(id)
+		if err != nil {
+			return err
+		}
+		colNames = append(colNames, column.Name)
+	}
+	srcCols := make([]string, len(colNames))
+	qualifiedSrcCols := make([]string, len(colNames))
+	for i, n := range colNames {
+		srcCols[i] = tree.NameString(n)
+		qualifiedSrcCols[i] = fmt.Sprintf("s.%s", srcCols[i])
+	}
+
+	// Keys with NULL values only reach here for MATCH FULL foreign keys, which
+	// don't allow mixing NULL and non-NULL values.
+	var complete, mixed []tree.Datums
+	for _, key := range keys {
+		hasNulls := false
+		for _, d := range key {
+			if d == tree.DNull {
+				hasNulls = true
+				break
+			}
+		}
+		if hasNulls {
+			mixed = append(mixed, key)
+		} else {
+			complete = append(complete, key)
+		}
+	}
+
+	if len(mixed) > 0 {
+		where, args := keysFilter(srcCols, mixed)
+		query := fmt.Sprintf(
+			`SELECT %[1]s FROM [%[2]d AS src]@{FORCE_INDEX=[%[3]d],IGNORE_FOREIGN_KEYS} WHERE %[4]s LIMIT 1`,
+			strings.Join(srcCols, ", "), srcTable.ID, srcIdx.ID, where,
+		)
+		log.VEventf(ctx, 2, "validating MATCH FULL FK %q for %d keys with query %q",
+			srcIdx.ForeignKey.Name, len(mixed), query)
+		values, err := ie.QueryRow(ctx, "validate foreign key constraint", txn, query, args...)
+		if err != nil {
+			return err
+		}
+		if values.Len() > 0 {
+			return pgerror.Newf(pgerror.CodeForeignKeyViolationError,
+				"foreign key violation: MATCH FULL does not allow mixing of null and nonnull values %s for %s",
+				formatValues(colNames, values), srcIdx.ForeignKey.Name,
+			)
+		}
+	}
+	if len(complete) == 0 {
+		return nil
+	}
+
+	srcWhere, args := keysFilter(srcCols, complete)
+	notNull := make([]string, prefix)
+	targetCols := make([]string, prefix)
+	on := make([]string, prefix)
+	for i := 0; i < prefix; i++ {
+		notNull[i] = fmt.Sprintf("%s IS NOT NULL", srcCols[i])
+		targetCols[i] = fmt.Sprintf("t.%s", tree.NameString(targetIdx.ColumnNames[i]))
+		on[i] = fmt.Sprintf("%s = %s", qualifiedSrcCols[i], targetCols[i])
+	}
+	query := fmt.Sprintf(
+		`SELECT %[1]s FROM
+		  (SELECT %[2]s FROM [%[3]d AS src]@{FORCE_INDEX=[%[4]d],IGNORE_FOREIGN_KEYS} WHERE (%[5]s) AND %[6]s) AS s
+			LEFT OUTER JOIN
+			(SELECT * FROM [%[7]d AS target]@[%[8]d]) AS t
+			ON %[9]s
+		 WHERE %[10]s IS NULL LIMIT 1`,
+		strings.Join(qualifiedSrcCols, ", "), // 1
+		strings.Join(srcCols, ", "),          // 2
+		srcTable.ID,                          // 3
+		srcIdx.ID,                            // 4
+		srcWhere,                             // 5
+		strings.Join(notNull, " AND "),       // 6
+		targetTable.ID,                       // 7
+		targetIdx.ID,                         // 8
+		strings.Join(on, " AND "),            // 9
+		// Sufficient to check the first column to see whether there was no matching row
+		targetCols[0], // 10
+	)
+
+	log.VEventf(ctx, 2, "validating FK %q (%q [%v] -> %q [%v]) for %d keys with query %q",
+		srcIdx.ForeignKey.Name,
+		srcTable.Name, srcIdx.ColumnNames, targetTable.Name, targetIdx.ColumnNames,
+		len(complete), query,
+	)
+
+	values, err := ie.QueryRow(ctx, "validate fk constraint", txn, query, args...)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		return pgerror.Newf(pgerror.CodeForeignKeyViolationError,
+			"foreign key violation: %q row %s has no match in %q",
+			srcTable.Name, formatValues(colNames, values), targetTable.Name)
+	}
+	return nil
+}
@@ -41,7 +41,7 @@ SELECT
  JOIN %[1]s.pg_catalog.pg_namespace   AS ns ON (ns.oid = pc.relnamespace)
 LEFT JOIN %[1]s.pg_catalog.pg_description AS pd ON (pc.oid = pd.objoid AND pd.objsubid = 0)
 WHERE ns.nspname = %[2]s
-  AND pc.relkind IN ('r', 'v')`
+  AND pc.relkind IN ('r', 'v', 'm')`
 
 		query = fmt.Sprintf(
 			getTablesQuery,
@@ -91,6 +91,11 @@ func (p *planner) Delete(
 	if err != nil {
 		return nil, err
 	}
+	// Triggers are only fired by the mutations planned by the optimizer.
+	if len(desc.Triggers) > 0 {
+		return nil, pgerror.UnimplementedWithIssue(28296,
+			"DELETE on a table with triggers requires the cost-based optimizer")
+	}
 	if err := p.CheckPrivilege(ctx, desc, privilege.DELETE); err != nil {
 		return nil, err
 	}
@@ -108,6 +113,10 @@ func (p *planner) Delete(
 	if err != nil {
 		return nil, err
 	}
+	deferredChecks, err := p.registerDeferrableConstraints(desc, fkTables, row.CheckDeletes)
+	if err != nil {
+		return nil, err
+	}
 
 	// rowsNeeded will help determine whether we can use the fast path
 	// in startExec.
@@ -134,6 +143,7 @@ func (p *planner) Delete(
 	if err != nil {
 		return nil, err
 	}
+	rd.SetDeferredChecks(deferredChecks)
 
 	tracing.AnnotateTrace()
 
@@ -176,7 +186,9 @@ func (p *planner) Delete(
 		run: deleteRun{
 			td:                  tableDeleter{rd: rd, alloc: &p.alloc},
 			rowsNeeded:          rowsNeeded,
-			fastPathInterleaved: canDeleteFastInterleaved(desc, fkTables),
+			// The fast path doesn't report the deleted rows to the deferrable
+			// constraints.
+			fastPathInterleaved: canDeleteFastInterleaved(desc, fkTables) && deferredChecks == nil,
 		},
 	}
 
@@ -208,6 +220,10 @@ type deleteRun struct {
 	// rows contains the accumulated result rows if rowsNeeded is set.
 	rows *rowcontainer.RowContainer
 
+	// triggers contains the run-time state of the triggers fired by the
+	// delete.
+	triggers triggerRun
+
 	// traceKV caches the current KV tracing flag.
 	traceKV bool
 }
@@ -231,7 +247,11 @@ func (d *deleteNode) startExec(params runParams) error {
 			params.EvalContext().Mon.MakeBoundAccount(),
 			sqlbase.ColTypeInfoFromResCols(d.columns), 0)
 	}
-	return d.run.td.init(params.p.txn, params.EvalContext())
+	if err := d.run.td.init(params.p.txn, params.EvalContext()); err != nil {
+		return err
+	}
+
+	return d.run.triggers.fireBefore(params)
 }
 
 // Next is required because batchedPlanNode inherits from planNode, but
@@ -309,6 +329,11 @@ func (d *deleteNode) BatchedNext(params runParams) (bool, error) {
 		d.run.done = true
 	}
 
+	// Run the AFTER triggers now that the rows of the batch are written.
+	if err := d.run.triggers.fireAfter(params, lastBatch); err != nil {
+		return false, err
+	}
+
 	// Possibly initiate a run of CREATE STATISTICS.
 	params.ExecCfg().StatsRefresher.NotifyMutation(
 		d.run.td.tableDesc().ID,
@@ -321,10 +346,14 @@ func (d *deleteNode) BatchedNext(params runParams) (bool, error) {
 // processSourceRow processes one row from the source for deletion and, if
 // result rows are needed, saves it in the result row container
 func (d *deleteNode) processSourceRow(params runParams, sourceVals tree.Datums) error {
+	// Split off the arguments of the AFTER row-level triggers, if any.
+	sourceVals, triggerVals := d.run.triggers.splitRow(sourceVals)
+
 	// Queue the deletion in the KV batch.
 	if err := d.run.td.row(params.ctx, sourceVals, d.run.traceKV); err != nil {
 		return err
 	}
+	d.run.triggers.queueRow(triggerVals)
 
 	// If result rows need to be accumulated, do it.
 	if d.run.rows != nil {
@@ -439,5 +468,10 @@ func canDeleteFastInterleaved(table *ImmutableTableDescriptor, fkTables row.FkTa
 
 // enableAutoCommit is part of the autoCommitNode interface.
 func (d *deleteNode) enableAutoCommit() {
+	// The AFTER triggers run once the rows are written, so the transaction
+	// cannot be committed with the last batch.
+	if d.run.triggers.hasAfter() {
+		return
+	}
 	d.run.td.enableAutoCommit()
 }
@@ -145,7 +145,12 @@ func (d *deleteRangeNode) startExec(params runParams) error {
 		return err
 	}
 	if err := d.fetcher.Init(
-		false, false, false, &params.p.alloc,
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		&params.p.alloc,
 		row.FetcherTableArgs{
 			Desc:  d.desc,
 			Index: &d.desc.PrimaryIndex,
@@ -112,11 +112,17 @@ func (p *planner) createDescriptorWithID(
 	b := &client.Batch{}
 	descID := descriptor.GetID()
 	descDesc := sqlbase.WrapDescriptor(descriptor)
+	// Temporary objects do not have a namespace entry, in which case idKey is
+	// nil and only the descriptor is written.
+	if idKey != nil {
+		if p.ExtendedEvalContext().Tracing.KVTracingEnabled() {
+			log.VEventf(ctx, 2, "CPut %s -> %d", idKey, descID)
+		}
+		b.CPut(idKey, descID, nil)
+	}
 	if p.ExtendedEvalContext().Tracing.KVTracingEnabled() {
-		log.VEventf(ctx, 2, "CPut %s -> %d", idKey, descID)
 		log.VEventf(ctx, 2, "CPut %s -> %s", descKey, descDesc)
 	}
-	b.CPut(idKey, descID, nil)
 	b.CPut(descKey, descDesc, nil)
 
 	mutDesc, isTable := descriptor.(*sqlbase.MutableTableDescriptor)
@@ -194,6 +200,28 @@ func getDescriptorByID(
 			return err
 		}
 		*t = *database
+	case *sqlbase.TypeDescriptor:
+		typ := desc.GetType()
+		if typ == nil {
+			return pgerror.Newf(pgerror.CodeWrongObjectTypeError,
+				"%q is not a type", desc.String())
+		}
+
+		if err := typ.Validate(); err != nil {
+			return err
+		}
+		*t = *typ
+	case *sqlbase.FunctionDescriptor:
+		fn := desc.GetFunction()
+		if fn == nil {
+			return pgerror.Newf(pgerror.CodeWrongObjectTypeError,
+				"%q is not a function", desc.String())
+		}
+
+		if err := fn.Validate(); err != nil {
+			return err
+		}
+		*t = *fn
 	}
 	return nil
 }
@@ -218,6 +246,10 @@ func GetAllDescriptors(ctx context.Context, txn *client.Txn) ([]sqlbase.Descript
 			descs[i] = desc.GetTable()
 		case *sqlbase.Descriptor_Database:
 			descs[i] = desc.GetDatabase()
+		case *sqlbase.Descriptor_Type:
+			descs[i] = desc.GetType()
+		case *sqlbase.Descriptor_Function:
+			descs[i] = desc.GetFunction()
 		default:
 			return nil, pgerror.AssertionFailedf("Descriptor.Union has unexpected type %T", t)
 		}
@@ -218,10 +218,22 @@ func (v *distSQLExprCheckVisitor) VisitPre(expr tree.Expr) (recurse bool, newExp
 	case *tree.DOid:
 		v.err = newQueryNotSupportedError("OID expressions are not supported by distsql")
 		return false, expr
+	case *tree.DEnum:
+		// Enum types are resolved by name, which remote nodes cannot do when
+		// they parse the expression.
+		v.err = newQueryNotSupportedError("enum values are not supported by distsql")
+		return false, expr
 	case *tree.CastExpr:
-		if t.Type.Family() == types.OidFamily {
+		switch t.Type.Family() {
+		case types.OidFamily, types.EnumFamily:
 			v.err = newQueryNotSupportedErrorf("cast to %s is not supported by distsql", t.Type)
 			return false, expr
+		case types.TupleFamily:
+			// Like enum types, composite types are resolved by name.
+			if t.Type.CompositeData() != nil {
+				v.err = newQueryNotSupportedErrorf("cast to %s is not supported by distsql", t.Type)
+				return false, expr
+			}
 		}
 	}
 	return true, expr
@@ -381,6 +393,13 @@ func (dsp *DistSQLPlanner) checkSupportForNode(node planNode) (distRecommendatio
 		return rec, nil
 
 	case *scanNode:
+		if n.lockingStrength != sqlbase.ScanLockingStrength_FOR_NONE {
+			// Scans that are performing row-level locking cannot currently be
+			// distributed because their locks would not be propagated back to
+			// the root transaction coordinator.
+			return cannotDistribute, newQueryNotSupportedError(
+				"scans with row-level locking are not supported by distsql")
+		}
 		rec := canDistribute
 		if n.softLimit != 0 {
 			// We don't yet recommend distributing plans where soft limits propagate
@@ -482,6 +501,11 @@ func (dsp *DistSQLPlanner) checkSupportForNode(node planNode) (distRecommendatio
 		return cannotDistribute, setNotSupportedError
 
 	case *projectSetNode:
+		for _, e := range n.exprs {
+			if err := dsp.checkExpr(e); err != nil {
+				return cannotDistribute, err
+			}
+		}
 		return dsp.checkSupportForNode(n.source)
 
 	case *unaryNode:
@@ -867,10 +891,12 @@ func initTableReaderSpec(
 ) (*distsqlpb.TableReaderSpec, distsqlpb.PostProcessSpec, error) {
 	s := distsqlplan.NewTableReaderSpec()
 	*s = distsqlpb.TableReaderSpec{
-		Table:      *n.desc.TableDesc(),
-		Reverse:    n.reverse,
-		IsCheck:    n.isCheck,
-		Visibility: n.colCfg.visibility.toDistSQLScanVisibility(),
+		Table:             *n.desc.TableDesc(),
+		Reverse:           n.reverse,
+		IsCheck:           n.isCheck,
+		Visibility:        n.colCfg.visibility.toDistSQLScanVisibility(),
+		LockingStrength:   n.lockingStrength,
+		LockingWaitPolicy: n.lockingWaitPolicy,
 
 		// Retain the capacity of the spans slice.
 		Spans: s.Spans[:0],
@@ -78,6 +78,10 @@ func newColBatchScan(
 	if flowCtx.nodeID == 0 {
 		return nil, errors.Errorf("attempting to create a colBatchScan with uninitialized NodeID")
 	}
+	if spec.LockingStrength != sqlbase.ScanLockingStrength_FOR_NONE {
+		// The vectorized fetcher does not acquire row-level locks.
+		return nil, errors.Errorf("row-level locking is not supported by colBatchScan")
+	}
 
 	limitHint := limitHint(spec.LimitHint, post)
 
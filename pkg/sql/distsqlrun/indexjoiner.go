@@ -103,6 +103,8 @@ func newIndexJoiner(
 		0, /* primary index */
 		ij.desc.ColumnIdxMapWithMutations(needMutations),
 		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
 		ij.out.neededColumns(),
 		false, /* isCheck */
 		&ij.alloc,
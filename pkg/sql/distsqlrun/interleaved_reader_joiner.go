@@ -419,8 +419,15 @@ func (irj *interleavedReaderJoiner) initRowFetcher(
 		}
 	}
 
-	return irj.fetcher.Init(reverseScan, true /* returnRangeInfo */, true /* isCheck */, alloc,
-		args...)
+	return irj.fetcher.Init(
+		reverseScan,
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		true, /* returnRangeInfo */
+		true, /* isCheck */
+		alloc,
+		args...,
+	)
 }
 
 func (irj *interleavedReaderJoiner) generateTrailingMeta(
@@ -195,6 +195,7 @@ func newJoinReader(
 
 	_, _, err = initRowFetcher(
 		&jr.fetcher, &jr.desc, int(spec.IndexIdx), jr.colIdxMap, false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE, sqlbase.ScanLockingWaitPolicy_BLOCK,
 		jr.neededRightCols(), false /* isCheck */, &jr.alloc,
 		distsqlpb.ScanVisibility_PUBLIC,
 	)
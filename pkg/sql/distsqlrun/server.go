@@ -98,7 +98,9 @@ var settingUseTempStorageJoins = settings.RegisterBoolSetting(
 	true,
 )
 
-var settingWorkMemBytes = settings.RegisterByteSizeSetting(
+// SettingWorkMemBytes is the limit on the memory that a processor (or a local
+// planNode that buffers rows) can use before falling back to temp storage.
+var SettingWorkMemBytes = settings.RegisterByteSizeSetting(
 	"sql.distsql.temp_storage.workmem",
 	"maximum amount of memory in bytes a processor can use before falling back to temp storage",
 	64*1024*1024, /* 64MB */
@@ -30,6 +30,8 @@ type dropDatabaseNode struct {
 	n      *tree.DropDatabase
 	dbDesc *sqlbase.DatabaseDescriptor
 	td     []toDelete
+	typs   []*sqlbase.TypeDescriptor
+	fns    []*sqlbase.FunctionDescriptor
 }
 
 // DropDatabase drops a database.
@@ -68,7 +70,17 @@ func (p *planner) DropDatabase(ctx context.Context, n *tree.DropDatabase) (planN
 		return nil, err
 	}
 
-	if len(tbNames) > 0 {
+	typs, err := getTypeDescsInDatabase(ctx, p.txn, dbDesc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	fns, err := getFunctionDescsInDatabase(ctx, p.txn, dbDesc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tbNames) > 0 || len(typs) > 0 || len(fns) > 0 {
 		switch n.DropBehavior {
 		case tree.DropRestrict:
 			return nil, pgerror.Newf(pgerror.CodeDependentObjectsStillExistError,
@@ -108,7 +120,7 @@ func (p *planner) DropDatabase(ctx context.Context, n *tree.DropDatabase) (planN
 		return nil, err
 	}
 
-	return &dropDatabaseNode{n: n, dbDesc: dbDesc, td: td}, nil
+	return &dropDatabaseNode{n: n, dbDesc: dbDesc, td: td, typs: typs, fns: fns}, nil
 }
 
 func (n *dropDatabaseNode) startExec(params runParams) error {
@@ -142,6 +154,13 @@ func (n *dropDatabaseNode) startExec(params runParams) error {
 
 	for _, toDel := range n.td {
 		tbDesc := toDel.desc
+		// The object may have been dropped along with another one above, e.g.
+		// a sequence owned by a column of a table.
+		if cur := p.Tables().getUncommittedTableByID(tbDesc.ID); cur.MutableTableDescriptor != nil &&
+			cur.MutableTableDescriptor.Dropped() {
+			tbNameStrings = append(tbNameStrings, toDel.tn.FQString())
+			continue
+		}
 		if tbDesc.IsView() {
 			cascadedViews, err := p.dropViewImpl(ctx, tbDesc, tree.DropCascade)
 			if err != nil {
@@ -160,6 +179,27 @@ func (n *dropDatabaseNode) startExec(params runParams) error {
 		tbNameStrings = append(tbNameStrings, toDel.tn.FQString())
 	}
 
+	// Drop the types of the database once the tables that use them are
+	// dropped.
+	for _, typDesc := range n.typs {
+		if err := p.typeDependencyError(ctx, typDesc); err != nil {
+			return err
+		}
+		if err := dropTypeDesc(
+			ctx, p.txn, typDesc, p.ExtendedEvalContext().Tracing.KVTracingEnabled(),
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, fnDesc := range n.fns {
+		if err := dropFunctionDesc(
+			ctx, p.txn, fnDesc, p.ExtendedEvalContext().Tracing.KVTracingEnabled(),
+		); err != nil {
+			return err
+		}
+	}
+
 	_ /* zoneKey */, nameKey, descKey := getKeysForDatabaseDescriptor(n.dbDesc)
 
 	b := &client.Batch{}
@@ -0,0 +1,174 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+type dropFunctionNode struct {
+	n *tree.DropFunction
+	// fns are the functions whose overloads are dropped. The overloads are
+	// already removed from the descriptors.
+	fns []*sqlbase.FunctionDescriptor
+}
+
+// DropFunction drops user-defined functions, or some of their overloads.
+func (p *planner) DropFunction(ctx context.Context, n *tree.DropFunction) (planNode, error) {
+	node := &dropFunctionNode{n: n}
+	for i := range n.Functions {
+		fn := &n.Functions[i]
+		dbDesc, err := p.ResolveUncachedDatabase(ctx, &fn.FuncName)
+		if err != nil {
+			return nil, err
+		}
+		fnDesc, err := lookupFunctionDesc(ctx, p.txn, dbDesc.ID, fn.FuncName.Table())
+		if err != nil {
+			return nil, err
+		}
+		if fnDesc == nil {
+			if n.IfExists {
+				continue
+			}
+			return nil, pgerror.Newf(pgerror.CodeUndefinedFunctionError,
+				"function %q does not exist", fn.FuncName.Table())
+		}
+
+		if err := p.CheckPrivilege(ctx, fnDesc, privilege.DROP); err != nil {
+			return nil, err
+		}
+
+		var paramTypes []types.T
+		if fn.Params == nil {
+			if len(fnDesc.Overloads) > 1 {
+				return nil, pgerror.Newf(pgerror.CodeAmbiguousFunctionError,
+					"function name %q is not unique", fnDesc.Name).SetHintf(
+					"Specify the argument list to select the function unambiguously.")
+			}
+			paramTypes = fnDesc.Overloads[0].ParamTypes()
+		} else {
+			paramTypes = make([]types.T, len(fn.Params))
+			for j := range fn.Params {
+				typ, err := tree.ResolveType(fn.Params[j].Type, &p.semaCtx)
+				if err != nil {
+					return nil, err
+				}
+				paramTypes[j] = *typ
+			}
+			if fnDesc.FindOverload(paramTypes) < 0 {
+				if n.IfExists {
+					continue
+				}
+				return nil, pgerror.Newf(pgerror.CodeUndefinedFunctionError,
+					"function %s does not exist", tree.ErrString(fn))
+			}
+		}
+
+		// The same function can be named several times with different parameter
+		// types: the overloads must be dropped from a single descriptor.
+		found := false
+		for j := range node.fns {
+			if node.fns[j].ID == fnDesc.ID {
+				fnDesc, found = node.fns[j], true
+			}
+		}
+		if !found {
+			node.fns = append(node.fns, fnDesc)
+		}
+		if idx := fnDesc.FindOverload(paramTypes); idx >= 0 {
+			fnDesc.Overloads = append(fnDesc.Overloads[:idx], fnDesc.Overloads[idx+1:]...)
+		}
+	}
+
+	if len(node.fns) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+
+	// Views depend on a function as a whole, so dropping any of its overloads
+	// drops the views that use it.
+	for _, fnDesc := range node.fns {
+		for _, ref := range fnDesc.DependedOnBy {
+			if err := p.canRemoveDependentViewGeneric(
+				ctx, "function", fnDesc.Name, fnDesc.ParentID, ref, n.DropBehavior,
+			); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return node, nil
+}
+
+func (n *dropFunctionNode) startExec(params runParams) error {
+	kvTrace := params.p.ExtendedEvalContext().Tracing.KVTracingEnabled()
+	for _, fnDesc := range n.fns {
+		// Drop all views that depend on this function, assuming that we
+		// wouldn't have made it to this point if `cascade` wasn't enabled.
+		for _, ref := range fnDesc.DependedOnBy {
+			viewDesc, err := params.p.getViewDescForCascade(
+				params.ctx, "function", fnDesc.Name, fnDesc.ParentID, ref.ID, tree.DropCascade,
+			)
+			if err != nil {
+				return err
+			}
+			// This view is already getting dropped. Don't do it twice.
+			if viewDesc.Dropped() {
+				continue
+			}
+			if _, err := params.p.dropViewImpl(params.ctx, viewDesc, tree.DropCascade); err != nil {
+				return err
+			}
+		}
+		fnDesc.DependedOnBy = nil
+
+		// The function is dropped with its last overload.
+		if len(fnDesc.Overloads) == 0 {
+			if err := dropFunctionDesc(params.ctx, params.p.txn, fnDesc, kvTrace); err != nil {
+				return err
+			}
+			continue
+		}
+		fnDesc.Version++
+		if err := writeFunctionDesc(params.ctx, params.p.txn, fnDesc, kvTrace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*dropFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropFunctionNode) Close(context.Context)        {}
+
+// dropFunctionDesc deletes the descriptor and the name of a function.
+func dropFunctionDesc(
+	ctx context.Context, txn *client.Txn, fnDesc *sqlbase.FunctionDescriptor, kvTrace bool,
+) error {
+	b := txn.NewBatch()
+	descKey := sqlbase.MakeDescMetadataKey(fnDesc.ID)
+	nameKey := sqlbase.NewTableKey(fnDesc.ParentID, fnDesc.Name).Key()
+	if kvTrace {
+		log.VEventf(ctx, 2, "Del %s", descKey)
+		log.VEventf(ctx, 2, "Del %s", nameKey)
+	}
+	b.Del(descKey, nameKey)
+	return txn.Run(ctx, b)
+}
@@ -110,7 +110,8 @@ func (n *dropTableNode) startExec(params runParams) error {
 			[]*sqlbase.MutableTableDescriptor{droppedDesc},
 			[]jobspb.DroppedTableDetails{droppedDetails},
 			tree.AsStringWithFQNames(n.n, params.Ann()),
-			true, /* drainNames */
+			// Temporary tables do not have a name to drain.
+			!droppedDesc.IsTemporary(), /* drainNames */
 			sqlbase.InvalidID /* droppedDatabaseID */); err != nil {
 			return err
 		}
@@ -292,6 +293,13 @@ func (p *planner) dropTableImpl(
 		}
 	}
 
+	// Drop the sequences owned by the columns of the table.
+	for i := range tableDesc.Columns {
+		if err := p.dropSequencesOwnedByCol(ctx, &tableDesc.Columns[i]); err != nil {
+			return droppedViews, err
+		}
+	}
+
 	// Drop all views that depend on this table, assuming that we wouldn't have
 	// made it to this point if `cascade` wasn't enabled.
 	for _, ref := range tableDesc.DependedOnBy {
@@ -318,14 +326,19 @@ func (p *planner) dropTableImpl(
 		return droppedViews, err
 	}
 
-	err = p.initiateDropTable(ctx, tableDesc, true /* drain name */)
+	if err := p.initiateDropTable(ctx, tableDesc, !tableDesc.IsTemporary() /* drainName */); err != nil {
+		return droppedViews, err
+	}
+
+	err = p.removeTypeBackReferences(ctx, tableDesc.TableDesc())
 	return droppedViews, err
 }
 
 // drainName when set implies that the name needs to go through the draining
 // names process. This parameter is always passed in as true except from
 // TRUNCATE which directly deletes the old name to id map and doesn't need
-// drain the old map.
+// drain the old map, and for temporary objects which don't have a name to id
+// map.
 func (p *planner) initiateDropTable(
 	ctx context.Context, tableDesc *sqlbase.MutableTableDescriptor, drainName bool,
 ) error {
@@ -342,7 +355,7 @@ func (p *planner) initiateDropTable(
 	//
 	// TODO(bram): If interleaved and ON DELETE CASCADE, we will be
 	// able to use this faster mechanism.
-	if tableDesc.IsTable() && !tableDesc.IsInterleaved() &&
+	if (tableDesc.IsTable() || tableDesc.MaterializedView()) && !tableDesc.IsInterleaved() &&
 		p.ExecCfg().Settings.Version.IsActive(cluster.VersionClearRange) {
 		// Get the zone config applying to this table in order to
 		// ensure there is a GC TTL.
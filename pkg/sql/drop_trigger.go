@@ -0,0 +1,75 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+type dropTriggerNode struct {
+	n         *tree.DropTrigger
+	tableDesc *sqlbase.MutableTableDescriptor
+	// idx is the index of the dropped trigger in tableDesc.Triggers.
+	idx int
+}
+
+// DropTrigger drops a trigger of a table.
+// Privileges: CREATE on table.
+func (p *planner) DropTrigger(ctx context.Context, n *tree.DropTrigger) (planNode, error) {
+	if n.DropBehavior == tree.DropCascade {
+		return nil, pgerror.Unimplemented("drop trigger cascade",
+			"DROP TRIGGER ... CASCADE is not yet supported")
+	}
+
+	tableDesc, err := p.ResolveMutableTableDescriptor(ctx, &n.Table, !n.IfExists, ResolveRequireTableDesc)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		return newZeroNode(nil /* columns */), nil
+	}
+
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == string(n.Name) {
+			return &dropTriggerNode{n: n, tableDesc: tableDesc, idx: i}, nil
+		}
+	}
+	if n.IfExists {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return nil, pgerror.Newf(pgerror.CodeUndefinedObjectError,
+		"trigger %q for table %q does not exist", n.Name, tableDesc.Name)
+}
+
+func (n *dropTriggerNode) startExec(params runParams) error {
+	n.tableDesc.Triggers = append(n.tableDesc.Triggers[:n.idx], n.tableDesc.Triggers[n.idx+1:]...)
+
+	if err := n.tableDesc.Validate(params.ctx, params.p.txn, params.EvalContext().Settings); err != nil {
+		return err
+	}
+
+	return params.p.writeSchemaChange(params.ctx, n.tableDesc, sqlbase.InvalidMutationID)
+}
+
+func (*dropTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropTriggerNode) Close(context.Context)        {}
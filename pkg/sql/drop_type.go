@@ -0,0 +1,130 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+type dropTypeNode struct {
+	n    *tree.DropType
+	typs []*sqlbase.TypeDescriptor
+}
+
+// DropType drops user-defined types.
+func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, error) {
+	if n.DropBehavior == tree.DropCascade {
+		return nil, pgerror.Unimplemented("drop type cascade",
+			"DROP TYPE ... CASCADE is not yet supported")
+	}
+
+	typs := make([]*sqlbase.TypeDescriptor, 0, len(n.Names))
+	for i := range n.Names {
+		tn := &n.Names[i]
+		dbDesc, err := p.ResolveUncachedDatabase(ctx, tn)
+		if err != nil {
+			return nil, err
+		}
+		typDesc, err := lookupTypeDesc(ctx, p.txn, dbDesc.ID, tn.Table())
+		if err != nil {
+			return nil, err
+		}
+		if typDesc == nil {
+			if n.IfExists {
+				continue
+			}
+			return nil, pgerror.Newf(pgerror.CodeUndefinedObjectError,
+				"type %q does not exist", tn.Table())
+		}
+
+		if err := p.CheckPrivilege(ctx, typDesc, privilege.DROP); err != nil {
+			return nil, err
+		}
+
+		if err := p.typeDependencyError(ctx, typDesc); err != nil {
+			return nil, err
+		}
+
+		typs = append(typs, typDesc)
+	}
+
+	if len(typs) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+
+	return &dropTypeNode{n: n, typs: typs}, nil
+}
+
+func (n *dropTypeNode) startExec(params runParams) error {
+	kvTrace := params.p.ExtendedEvalContext().Tracing.KVTracingEnabled()
+	for _, typDesc := range n.typs {
+		if err := dropTypeDesc(params.ctx, params.p.txn, typDesc, kvTrace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*dropTypeNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropTypeNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropTypeNode) Close(context.Context)        {}
+
+// typeDependencyError returns an error if the given type cannot be dropped
+// because a table has a column of the type, or nil if there is no such
+// dependency. The references recorded in the type descriptor can outlive the
+// columns that use the type, so the tables are checked too.
+func (p *planner) typeDependencyError(
+	ctx context.Context, typDesc *sqlbase.TypeDescriptor,
+) error {
+	for _, id := range typDesc.ReferencingDescriptorIDs {
+		tableDesc, err := p.Tables().getMutableTableVersionByID(ctx, id, p.txn)
+		if err != nil {
+			if err == sqlbase.ErrDescriptorNotFound {
+				continue
+			}
+			return err
+		}
+		if tableDesc.Dropped() {
+			continue
+		}
+		for _, t := range columnTypes(tableDesc.TableDesc()) {
+			if sqlbase.ColumnTypeReferencesType(t, typDesc.ID) {
+				return pgerror.Newf(pgerror.CodeDependentObjectsStillExistError,
+					"cannot drop type %s because other objects depend on it", typDesc.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// dropTypeDesc deletes the descriptor and the name of a type.
+func dropTypeDesc(
+	ctx context.Context, txn *client.Txn, typDesc *sqlbase.TypeDescriptor, kvTrace bool,
+) error {
+	b := txn.NewBatch()
+	descKey := sqlbase.MakeDescMetadataKey(typDesc.ID)
+	nameKey := sqlbase.NewTableKey(typDesc.ParentID, typDesc.Name).Key()
+	if kvTrace {
+		log.VEventf(ctx, 2, "Del %s", descKey)
+		log.VEventf(ctx, 2, "Del %s", nameKey)
+	}
+	b.Del(descKey, nameKey)
+	return txn.Run(ctx, b)
+}
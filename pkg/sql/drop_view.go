@@ -45,6 +45,9 @@ func (p *planner) DropView(ctx context.Context, n *tree.DropView) (planNode, err
 			// IfExists specified and the view did not exist.
 			continue
 		}
+		if err := checkViewMatchesMaterialized(tn, droppedDesc, n.IsMaterialized); err != nil {
+			return nil, err
+		}
 
 		td = append(td, toDelete{tn, droppedDesc})
 	}
@@ -110,6 +113,24 @@ func (*dropViewNode) Next(runParams) (bool, error) { return false, nil }
 func (*dropViewNode) Values() tree.Datums          { return tree.Datums{} }
 func (*dropViewNode) Close(context.Context)        {}
 
+// checkViewMatchesMaterialized returns an error if the view was not created
+// with the kind of view the statement expects, as in Postgres.
+func checkViewMatchesMaterialized(
+	tn *tree.TableName, desc *sqlbase.MutableTableDescriptor, requireMaterialized bool,
+) error {
+	if desc.MaterializedView() == requireMaterialized {
+		return nil
+	}
+	if requireMaterialized {
+		return pgerror.Newf(pgerror.CodeWrongObjectTypeError,
+			"%q is not a materialized view", tree.ErrString(tn)).SetHintf(
+			"use DROP VIEW to remove a view")
+	}
+	return pgerror.Newf(pgerror.CodeWrongObjectTypeError,
+		"%q is not a view", tree.ErrString(tn)).SetHintf(
+		"use DROP MATERIALIZED VIEW to remove a materialized view")
+}
+
 func descInSlice(descID sqlbase.ID, td []toDelete) bool {
 	for _, toDel := range td {
 		if descID == toDel.desc.ID {
@@ -192,6 +213,25 @@ func (p *planner) dropViewImpl(
 	}
 	viewDesc.DependsOn = nil
 
+	// Remove back-references from the user-defined functions this view uses.
+	kvTrace := p.ExtendedEvalContext().Tracing.KVTracingEnabled()
+	for _, fnID := range viewDesc.DependsOnFunctions {
+		fnDesc, err := sqlbase.GetFunctionDescFromID(ctx, p.txn, fnID)
+		if err == sqlbase.ErrDescriptorNotFound {
+			// The function is also being dropped.
+			continue
+		}
+		if err != nil {
+			return cascadeDroppedViews, err
+		}
+		fnDesc.DependedOnBy = removeMatchingReferences(fnDesc.DependedOnBy, viewDesc.ID)
+		fnDesc.Version++
+		if err := writeFunctionDesc(ctx, p.txn, fnDesc, kvTrace); err != nil {
+			return cascadeDroppedViews, err
+		}
+	}
+	viewDesc.DependsOnFunctions = nil
+
 	if behavior == tree.DropCascade {
 		for _, ref := range viewDesc.DependedOnBy {
 			dependentDesc, err := p.getViewDescForCascade(
@@ -209,7 +249,7 @@ func (p *planner) dropViewImpl(
 		}
 	}
 
-	if err := p.initiateDropTable(ctx, viewDesc, true /* drainName */); err != nil {
+	if err := p.initiateDropTable(ctx, viewDesc, !viewDesc.IsTemporary() /* drainName */); err != nil {
 		return cascadeDroppedViews, err
 	}
 
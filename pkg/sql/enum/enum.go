@@ -0,0 +1,94 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+// Package enum generates the physical representations of the values of enum
+// types. The physical representation of a value is a byte string, and the
+// values of an enum sort in their declaration order when their physical
+// representations are compared bytewise. This allows new values to be added
+// anywhere in an enum without rewriting the values that are already stored.
+//
+// Every physical representation is non-empty and does not end in a zero byte.
+// Interpreted as a base-256 fraction, such a byte string is strictly between 0
+// and 1, so there is always room for a new value on either side of it.
+package enum
+
+import "bytes"
+
+// GenByteStringBetween returns a byte string that sorts strictly between prev
+// and next. An empty prev stands for the smallest possible byte string and an
+// empty next for the largest one, so GenByteStringBetween(nil, nil) returns
+// the byte string in the middle of the space.
+//
+// The inputs must be valid physical representations, and prev must sort
+// before next if both are non-empty. The result is as short as possible for
+// the given inputs.
+func GenByteStringBetween(prev, next []byte) []byte {
+	if len(prev) != 0 && len(next) != 0 && bytes.Compare(prev, next) >= 0 {
+		panic("enum physical representations must be generated in order")
+	}
+	var result []byte
+	// bounded is true as long as result is a prefix of next, in which case next
+	// bounds the digit chosen at the current position.
+	bounded := len(next) != 0
+	for i := 0; ; i++ {
+		lo := 0
+		if i < len(prev) {
+			lo = int(prev[i])
+		}
+		hi := 256
+		if bounded {
+			hi = int(next[i])
+		}
+		switch {
+		case hi-lo >= 2:
+			// There is room for a digit between prev and next.
+			return append(result, byte((lo+hi)/2))
+		case hi-lo == 1:
+			// The result now sorts before next regardless of what follows, but
+			// it still needs to sort after the rest of prev.
+			result = append(result, byte(lo))
+			bounded = false
+		default:
+			result = append(result, byte(lo))
+		}
+	}
+}
+
+// GenerateNEvenlySpacedBytes returns n physical representations that are
+// spread evenly over the space of byte strings, in increasing order. All of
+// them have the same length, which is the smallest length that fits n
+// distinct values.
+func GenerateNEvenlySpacedBytes(n int) [][]byte {
+	if n == 0 {
+		return nil
+	}
+	// Use base-255 digits that are shifted by one, so that no digit is zero and
+	// thus no representation ends in a zero byte.
+	const base = 255
+	length, total := 1, uint64(base)
+	for total < uint64(n)+1 {
+		length++
+		total *= base
+	}
+	step := total / (uint64(n) + 1)
+	result := make([][]byte, n)
+	for i := range result {
+		v := uint64(i+1) * step
+		rep := make([]byte, length)
+		for j := length - 1; j >= 0; j-- {
+			rep[j] = byte(v%base) + 1
+			v /= base
+		}
+		result[i] = rep
+	}
+	return result
+}
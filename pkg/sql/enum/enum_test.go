@@ -0,0 +1,104 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package enum
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+)
+
+func checkRep(t *testing.T, rep []byte) {
+	t.Helper()
+	if len(rep) == 0 {
+		t.Fatal("empty physical representation")
+	}
+	if rep[len(rep)-1] == 0 {
+		t.Fatalf("physical representation %v ends in a zero byte", rep)
+	}
+}
+
+func TestGenByteStringBetween(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		prev, next []byte
+		expected   []byte
+	}{
+		{nil, nil, []byte{128}},
+		{[]byte{128}, nil, []byte{192}},
+		{nil, []byte{128}, []byte{64}},
+		{[]byte{1}, []byte{3}, []byte{2}},
+		{[]byte{1}, []byte{2}, []byte{1, 128}},
+		{[]byte{1, 255}, []byte{2}, []byte{1, 255, 128}},
+		{nil, []byte{1}, []byte{0, 128}},
+		{[]byte{255}, nil, []byte{255, 128}},
+		{[]byte{5, 7}, []byte{5, 7, 1}, []byte{5, 7, 0, 128}},
+	}
+	for _, tc := range testCases {
+		result := GenByteStringBetween(tc.prev, tc.next)
+		if !bytes.Equal(result, tc.expected) {
+			t.Errorf("between %v and %v: expected %v, got %v", tc.prev, tc.next, tc.expected, result)
+		}
+	}
+}
+
+func TestGenByteStringBetweenRandom(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	rng, _ := randutil.NewPseudoRand()
+	// Repeatedly insert a new value at a random position, and check that the
+	// values remain ordered.
+	reps := [][]byte{GenByteStringBetween(nil, nil)}
+	for i := 0; i < 1000; i++ {
+		pos := rng.Intn(len(reps) + 1)
+		var prev, next []byte
+		if pos > 0 {
+			prev = reps[pos-1]
+		}
+		if pos < len(reps) {
+			next = reps[pos]
+		}
+		rep := GenByteStringBetween(prev, next)
+		checkRep(t, rep)
+		if prev != nil && bytes.Compare(prev, rep) >= 0 {
+			t.Fatalf("%v does not sort after %v", rep, prev)
+		}
+		if next != nil && bytes.Compare(rep, next) >= 0 {
+			t.Fatalf("%v does not sort before %v", rep, next)
+		}
+		reps = append(reps, nil)
+		copy(reps[pos+1:], reps[pos:])
+		reps[pos] = rep
+	}
+}
+
+func TestGenerateNEvenlySpacedBytes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, n := range []int{0, 1, 2, 3, 100, 254, 255, 256, 1000, 100000, 1 + rand.Intn(10000)} {
+		reps := GenerateNEvenlySpacedBytes(n)
+		if len(reps) != n {
+			t.Fatalf("expected %d values, got %d", n, len(reps))
+		}
+		for i, rep := range reps {
+			checkRep(t, rep)
+			if i > 0 && bytes.Compare(reps[i-1], rep) >= 0 {
+				t.Fatalf("%v does not sort after %v", rep, reps[i-1])
+			}
+		}
+	}
+}
@@ -73,7 +73,7 @@ func TestUnimplementedCounts(t *testing.T) {
 	s, db, _ := serverutils.StartServer(t, params)
 	defer s.Stopper().Stop(context.TODO())
 
-	if _, err := db.Exec("CREATE TABLE t(x INT8)"); err != nil {
+	if _, err := db.Exec("CREATE TABLE t(x INT8 PRIMARY KEY)"); err != nil {
 		t.Fatal(err)
 	}
 
@@ -81,7 +81,7 @@ func TestUnimplementedCounts(t *testing.T) {
 		t.Fatal("expected error, got no error")
 	}
 
-	if telemetry.GetRawFeatureCounts()["unimplemented.#9851.INT8->STRING"] == 0 {
+	if telemetry.GetRawFeatureCounts()["unimplemented.#9851.primary key"] == 0 {
 		t.Fatal("expected unimplemented telemetry, got nothing")
 	}
 }
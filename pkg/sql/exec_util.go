@@ -549,6 +549,11 @@ type ExecutorTestingKnobs struct {
 	// optimization). This is only called when the Executor is the one doing the
 	// committing.
 	BeforeAutoCommit func(ctx context.Context, stmt string) error
+
+	// DisableTempObjectsCleanupOnSessionExit, if set, leaves the temporary
+	// objects of a session behind when it ends, so that tests can check that
+	// the TemporaryObjectCleaner drops them.
+	DisableTempObjectsCleanupOnSessionExit bool
 }
 
 // PGWireTestingKnobs contains knobs for the pgwire module.
@@ -778,6 +783,7 @@ func checkResultType(typ *types.T) error {
 	case types.DateFamily:
 	case types.TimestampFamily:
 	case types.TimeFamily:
+	case types.TimeTZFamily:
 	case types.TimestampTZFamily:
 	case types.IntervalFamily:
 	case types.JsonFamily:
@@ -786,15 +792,6 @@ func checkResultType(typ *types.T) error {
 	case types.OidFamily:
 	case types.TupleFamily:
 	case types.ArrayFamily:
-		if typ.ArrayContents().Family() == types.ArrayFamily {
-			// Technically we could probably return arrays of arrays to a
-			// client (the encoding exists) but we don't want to give
-			// mixed signals -- that nested arrays appear to be supported
-			// in this case, and not in other cases (eg. CREATE). So we
-			// reject them in every case instead.
-			return pgerror.UnimplementedWithIssueDetail(32552,
-				"result", "arrays cannot have arrays as element type")
-		}
 	case types.AnyFamily:
 		// Placeholder case.
 		return errors.Errorf("could not determine data type of %s", typ)
@@ -1091,7 +1088,8 @@ func (scc *schemaChangerCollection) execSchemaChanges(
 	// Execute any schema changes that were scheduled, in the order of the
 	// statements that scheduled them.
 	var firstError error
-	for _, sc := range scc.schemaChangers {
+	for i := 0; i < len(scc.schemaChangers); i++ {
+		sc := scc.schemaChangers[i]
 		sc.db = cfg.DB
 		sc.testingKnobs = cfg.SchemaChangerTestingKnobs
 		sc.distSQLPlanner = cfg.DistSQLPlanner
@@ -1126,6 +1124,14 @@ func (scc *schemaChangerCollection) execSchemaChanges(
 					// retryable error.
 					continue
 				}
+			} else if sc.cleanupMutationID != sqlbase.InvalidMutationID {
+				// Run the mutations queued by the schema change right away
+				// instead of leaving them to the asynchronous schema changer.
+				cleanup := sc
+				cleanup.mutationID = sc.cleanupMutationID
+				cleanup.cleanupMutationID = sqlbase.InvalidMutationID
+				cleanup.job = nil
+				scc.schemaChangers = append(scc.schemaChangers, cleanup)
 			}
 			break
 		}
@@ -15,6 +15,7 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -23,7 +24,7 @@ import (
 
 // Grant adds privileges to users.
 // Current status:
-// - Target: single database, table, or view.
+// - Target: single database, table, view, or function.
 // TODO(marc): open questions:
 // - should we have root always allowed and not present in the permissions list?
 // - should we make users case-insensitive?
@@ -31,14 +32,15 @@ import (
 //   Notes: postgres requires the object owner.
 //          mysql requires the "grant option" and the same privileges, and sometimes superuser.
 func (p *planner) Grant(ctx context.Context, n *tree.Grant) (planNode, error) {
-	return p.changePrivileges(ctx, n.Targets, n.Grantees, func(privDesc *sqlbase.PrivilegeDescriptor, grantee string) {
-		privDesc.Grant(grantee, n.Privileges)
-	})
+	return p.changePrivileges(ctx, n.Targets, n.Grantees, n.Privileges,
+		func(privDesc *sqlbase.PrivilegeDescriptor, grantee string, _ bool) {
+			privDesc.Grant(grantee, n.Privileges)
+		})
 }
 
 // Revoke removes privileges from users.
 // Current status:
-// - Target: single database, table, or view.
+// - Target: single database, table, view, or function.
 // TODO(marc): open questions:
 // - should we have root always allowed and not present in the permissions list?
 // - should we make users case-insensitive?
@@ -46,16 +48,22 @@ func (p *planner) Grant(ctx context.Context, n *tree.Grant) (planNode, error) {
 //   Notes: postgres requires the object owner.
 //          mysql requires the "grant option" and the same privileges, and sometimes superuser.
 func (p *planner) Revoke(ctx context.Context, n *tree.Revoke) (planNode, error) {
-	return p.changePrivileges(ctx, n.Targets, n.Grantees, func(privDesc *sqlbase.PrivilegeDescriptor, grantee string) {
-		privDesc.Revoke(grantee, n.Privileges)
-	})
+	return p.changePrivileges(ctx, n.Targets, n.Grantees, n.Privileges,
+		func(privDesc *sqlbase.PrivilegeDescriptor, grantee string, isFunction bool) {
+			if isFunction {
+				privDesc.RevokeFunctionPrivileges(grantee, n.Privileges)
+			} else {
+				privDesc.Revoke(grantee, n.Privileges)
+			}
+		})
 }
 
 func (p *planner) changePrivileges(
 	ctx context.Context,
 	targets tree.TargetList,
 	grantees tree.NameList,
-	changePrivilege func(*sqlbase.PrivilegeDescriptor, string),
+	privs privilege.List,
+	changePrivilege func(privDesc *sqlbase.PrivilegeDescriptor, grantee string, isFunction bool),
 ) (planNode, error) {
 	// Check whether grantees exists
 	users, err := p.GetAllUsersAndRoles(ctx)
@@ -90,9 +98,18 @@ func (p *planner) changePrivileges(
 		if err := p.CheckPrivilege(ctx, descriptor, privilege.GRANT); err != nil {
 			return nil, err
 		}
+		// EXECUTE only applies to functions, and functions only support some
+		// of the other privileges.
+		_, isFunction := descriptor.(*sqlbase.FunctionDescriptor)
+		for _, priv := range privs {
+			if !priv.AppliesTo(isFunction) {
+				return nil, pgerror.Newf(pgerror.CodeInvalidGrantOperationError,
+					"invalid privilege type %s for %s", priv, descriptor.TypeName())
+			}
+		}
 		privileges := descriptor.GetPrivileges()
 		for _, grantee := range grantees {
-			changePrivilege(privileges, string(grantee))
+			changePrivilege(privileges, string(grantee), isFunction)
 		}
 
 		// Validate privilege descriptors directly as the db/table level Validate
@@ -116,6 +133,14 @@ func (p *planner) changePrivileges(
 					return nil, err
 				}
 			}
+
+		case *sqlbase.FunctionDescriptor:
+			if err := d.Validate(); err != nil {
+				return nil, err
+			}
+			d.Version++
+			descKey := sqlbase.MakeDescMetadataKey(descriptor.GetID())
+			b.Put(descKey, sqlbase.WrapDescriptor(descriptor))
 		}
 	}
 
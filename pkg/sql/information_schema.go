@@ -319,6 +319,13 @@ https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
 					collationSchema = pgCatalogNameDString
 					collationName = tree.NewDString(locale)
 				}
+				identityGeneration := tree.DNull
+				switch column.GeneratedAsIdentityType {
+				case sqlbase.GeneratedAsIdentityType_GeneratedAlways:
+					identityGeneration = tree.NewDString("ALWAYS")
+				case sqlbase.GeneratedAsIdentityType_GeneratedByDefault:
+					identityGeneration = tree.NewDString("BY DEFAULT")
+				}
 				return addRow(
 					dbNameStr,                                            // table_catalog
 					scNameStr,                                            // table_schema
@@ -354,8 +361,8 @@ https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
 					tree.DNull,                                           // maximum_cardinality
 					tree.DNull,                                           // dtd_identifier
 					tree.DNull,                                           // is_self_referencing
-					tree.DNull,                                           // is_identity
-					tree.DNull,                                           // identity_generation
+					yesOrNoDatum(column.IsIdentity()),                    // is_identity
+					identityGeneration,                                   // identity_generation
 					tree.DNull,                                           // identity_start
 					tree.DNull,                                           // identity_increment
 					tree.DNull,                                           // identity_maximum
@@ -1180,6 +1187,9 @@ CREATE TABLE information_schema.user_privileges (
 			for _, u := range []string{security.RootUser, sqlbase.AdminRole} {
 				grantee := tree.NewDString(u)
 				for _, p := range privilege.List(privilege.ByValue[:]).SortedNames() {
+					if kind := privilege.ByName[p]; !kind.AppliesTo(false /* isFunction */) {
+						continue
+					}
 					if err := addRow(
 						grantee,            // grantee
 						dbNameStr,          // table_catalog
@@ -1246,9 +1256,10 @@ func populateTablePrivileges(
 }
 
 var (
-	tableTypeSystemView = tree.NewDString("SYSTEM VIEW")
-	tableTypeBaseTable  = tree.NewDString("BASE TABLE")
-	tableTypeView       = tree.NewDString("VIEW")
+	tableTypeSystemView       = tree.NewDString("SYSTEM VIEW")
+	tableTypeBaseTable        = tree.NewDString("BASE TABLE")
+	tableTypeView             = tree.NewDString("VIEW")
+	tableTypeMaterializedView = tree.NewDString("MATERIALIZED VIEW")
 )
 
 var informationSchemaTablesTable = virtualSchemaTable{
@@ -1267,6 +1278,9 @@ https://www.postgresql.org/docs/9.5/infoschema-tables.html`,
 				if table.IsVirtualTable() {
 					tableType = tableTypeSystemView
 					insertable = noString
+				} else if table.MaterializedView() {
+					tableType = tableTypeMaterializedView
+					insertable = noString
 				} else if table.IsView() {
 					tableType = tableTypeView
 					insertable = noString
@@ -1308,7 +1322,7 @@ CREATE TABLE information_schema.views (
 	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual schemas have no views */
 			func(db *sqlbase.DatabaseDescriptor, scName string, table *sqlbase.TableDescriptor) error {
-				if !table.IsView() {
+				if !table.IsView() || table.MaterializedView() {
 					return nil
 				}
 				// Note that the view query printed will not include any column aliases
@@ -1385,6 +1399,80 @@ func forEachDatabaseDesc(
 	return nil
 }
 
+// forEachTypeDesc retrieves all user-defined type descriptors and iterates
+// through them in ID order. For each type, the function will call fn with its
+// respective database and type descriptor.
+//
+// The dbContext argument specifies in which database context we are
+// requesting the descriptors. In context nil all descriptors are
+// visible, in non-empty contexts only the descriptors of that
+// database are visible.
+func forEachTypeDesc(
+	ctx context.Context,
+	p *planner,
+	dbContext *DatabaseDescriptor,
+	fn func(*sqlbase.DatabaseDescriptor, *sqlbase.TypeDescriptor) error,
+) error {
+	descs, err := p.Tables().getAllDescriptors(ctx, p.txn)
+	if err != nil {
+		return err
+	}
+	lCtx := newInternalLookupCtx(descs, dbContext)
+
+	for _, id := range lCtx.tyIDs {
+		typ := lCtx.tyDescs[id]
+		dbDesc, err := lCtx.getDatabaseByID(typ.ParentID)
+		if err != nil {
+			// The parent database has been dropped.
+			continue
+		}
+		if !userCanSeeDatabase(ctx, p, dbDesc) {
+			continue
+		}
+		if err := fn(dbDesc, typ); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachFunctionDesc retrieves all user-defined function descriptors and
+// iterates through them. For each function, the function will call fn with its
+// respective database and function descriptor.
+//
+// The dbContext argument specifies in which database context we are
+// requesting the descriptors. In context nil all descriptors are
+// visible, in non-empty contexts only the descriptors of that
+// database are visible.
+func forEachFunctionDesc(
+	ctx context.Context,
+	p *planner,
+	dbContext *DatabaseDescriptor,
+	fn func(*sqlbase.DatabaseDescriptor, *sqlbase.FunctionDescriptor) error,
+) error {
+	descs, err := p.Tables().getAllDescriptors(ctx, p.txn)
+	if err != nil {
+		return err
+	}
+	lCtx := newInternalLookupCtx(descs, dbContext)
+
+	for _, id := range lCtx.fnIDs {
+		fnDesc := lCtx.fnDescs[id]
+		dbDesc, err := lCtx.getDatabaseByID(fnDesc.ParentID)
+		if err != nil {
+			// The parent database has been dropped.
+			continue
+		}
+		if !userCanSeeDatabase(ctx, p, dbDesc) {
+			continue
+		}
+		if err := fn(dbDesc, fnDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // forEachTableDesc retrieves all table descriptors from the current
 // database and all system databases and iterates through them. For
 // each table, the function will call fn with its respective database
@@ -1524,7 +1612,16 @@ func forEachTableDescWithTableLookupInternal(
 		if table.Dropped() || !userCanSeeTable(ctx, p, table, allowAdding) || !parentExists {
 			continue
 		}
-		if err := fn(dbDesc, tree.PublicSchema, table, lCtx); err != nil {
+		scName := tree.PublicSchema
+		if table.IsTemporary() {
+			// Temporary objects are only visible to their own session.
+			ts := p.extendedEvalCtx.TemporarySchema
+			if ts == nil || !ts.ownsDescriptor(table) {
+				continue
+			}
+			scName = sessiondata.PgTempSchemaName
+		}
+		if err := fn(dbDesc, scName, table, lCtx); err != nil {
 			return err
 		}
 	}
@@ -93,6 +93,16 @@ func (p *planner) Insert(
 	if err != nil {
 		return nil, err
 	}
+	// Triggers are only fired by the mutations planned by the optimizer.
+	if len(desc.Triggers) > 0 {
+		return nil, pgerror.UnimplementedWithIssue(28296,
+			"INSERT on a table with triggers requires the cost-based optimizer")
+	}
+	// The fields of composite columns can only be assigned by the optimizer.
+	if n.ColumnFields != nil {
+		return nil, pgerror.UnimplementedWithIssue(27792,
+			"INSERT into a field of a column requires the cost-based optimizer")
+	}
 	if err := p.CheckPrivilege(ctx, desc, privilege.INSERT); err != nil {
 		return nil, err
 	}
@@ -134,6 +144,10 @@ func (p *planner) Insert(
 	if err != nil {
 		return nil, err
 	}
+	deferredChecks, err := p.registerDeferrableConstraints(desc, fkTables, fkCheckType)
+	if err != nil {
+		return nil, err
+	}
 
 	// Determine which columns we're inserting into.
 	var insertCols []sqlbase.ColumnDescriptor
@@ -154,6 +168,13 @@ func (p *planner) Insert(
 		}
 	}
 
+	// The values specified for identity columns can only be overridden or
+	// ignored by the optimizer.
+	if n.Overriding != tree.OverridingNone {
+		return nil, pgerror.Unimplementedf("identity",
+			"INSERT ... %s requires the cost-based optimizer", n.Overriding)
+	}
+
 	// maxInsertIdx is the highest column index we are allowed to insert into -
 	// in the presence of computed columns, when we don't explicitly specify the
 	// columns we're inserting into, we should allow inserts if and only if they
@@ -227,6 +248,9 @@ func (p *planner) Insert(
 					// if x is a computed column. See #22434.
 					return nil, sqlbase.CannotWriteToComputedColError(insertCols[maxInsertIdx].Name)
 				}
+				if err := checkIdentityColsForInsert(insertCols[:numExprs], values); err != nil {
+					return nil, err
+				}
 				arityChecked = true
 			}
 			src, err = fillDefaults(defaultExprs, insertCols, values)
@@ -257,6 +281,9 @@ func (p *planner) Insert(
 		if numExprs > maxInsertIdx {
 			return nil, sqlbase.CannotWriteToComputedColError(insertCols[maxInsertIdx].Name)
 		}
+		if err := checkIdentityColsForInsert(insertCols[:numExprs], nil /* values */); err != nil {
+			return nil, err
+		}
 	}
 
 	// The required types may not have been matched exactly by the planning.
@@ -274,6 +301,7 @@ func (p *planner) Insert(
 	if err != nil {
 		return nil, err
 	}
+	ri.SetDeferredChecks(deferredChecks)
 
 	// rowsNeeded will help determine whether we need to allocate a
 	// rowsContainer.
@@ -375,6 +403,10 @@ type insertRun struct {
 	// index is not public.
 	rowIdxToRetIdx []int
 
+	// triggers contains the run-time state of the triggers fired by the
+	// insert.
+	triggers triggerRun
+
 	// traceKV caches the current KV tracing flag.
 	traceKV bool
 }
@@ -430,7 +462,11 @@ func (n *insertNode) startExec(params runParams) error {
 		}
 	}
 
-	return n.run.ti.init(params.p.txn, params.EvalContext())
+	if err := n.run.ti.init(params.p.txn, params.EvalContext()); err != nil {
+		return err
+	}
+
+	return n.run.triggers.fireBefore(params)
 }
 
 // Next is required because batchedPlanNode inherits from planNode, but
@@ -509,6 +545,11 @@ func (n *insertNode) BatchedNext(params runParams) (bool, error) {
 		n.run.done = true
 	}
 
+	// Run the AFTER triggers now that the rows of the batch are written.
+	if err := n.run.triggers.fireAfter(params, lastBatch); err != nil {
+		return false, err
+	}
+
 	// Possibly initiate a run of CREATE STATISTICS.
 	params.ExecCfg().StatsRefresher.NotifyMutation(n.run.ti.tableDesc().ID, n.run.rowCount)
 
@@ -518,6 +559,9 @@ func (n *insertNode) BatchedNext(params runParams) (bool, error) {
 // processSourceRow processes one row from the source for insertion and, if
 // result rows are needed, saves it in the result row container.
 func (n *insertNode) processSourceRow(params runParams, sourceVals tree.Datums) error {
+	// Split off the arguments of the AFTER row-level triggers, if any.
+	sourceVals, triggerVals := n.run.triggers.splitRow(sourceVals)
+
 	// Process the incoming row tuple and generate the full inserted
 	// row. This fills in the defaults, computes computed columns, and
 	// check the data width complies with the schema constraints.
@@ -560,6 +604,7 @@ func (n *insertNode) processSourceRow(params runParams, sourceVals tree.Datums)
 	if err = n.run.ti.row(params.ctx, rowVals, n.run.traceKV); err != nil {
 		return err
 	}
+	n.run.triggers.queueRow(triggerVals)
 
 	// If result rows need to be accumulated, do it.
 	if n.run.rows != nil {
@@ -597,6 +642,11 @@ func (n *insertNode) Close(ctx context.Context) {
 
 // enableAutoCommit is part of the autoCommitNode interface.
 func (n *insertNode) enableAutoCommit() {
+	// The AFTER triggers run once the rows are written, so the transaction
+	// cannot be committed with the last batch.
+	if n.run.triggers.hasAfter() {
+		return
+	}
 	n.run.ti.enableAutoCommit()
 }
 
@@ -912,3 +962,28 @@ func checkNumExprs(isUpsert bool, numExprs, numCols int, specifiedTargets bool)
 	}
 	return nil
 }
+
+// checkIdentityColsForInsert returns an error if values other than DEFAULT are
+// specified for an identity column defined as GENERATED ALWAYS. The columns
+// must line up with the expressions of the source of the insert, and values is
+// nil if the source is not a VALUES clause.
+func checkIdentityColsForInsert(cols []sqlbase.ColumnDescriptor, values *tree.ValuesClause) error {
+	for i := range cols {
+		if !cols[i].IsGeneratedAlwaysAsIdentity() {
+			continue
+		}
+		if values == nil {
+			return sqlbase.NewGeneratedAlwaysInsertError(cols[i].Name)
+		}
+		for _, tuple := range values.Rows {
+			// The arity of the tuples is checked later.
+			if i >= len(tuple) {
+				continue
+			}
+			if _, ok := tuple[i].(tree.DefaultVal); !ok {
+				return sqlbase.NewGeneratedAlwaysInsertError(cols[i].Name)
+			}
+		}
+	}
+	return nil
+}
@@ -1241,6 +1241,11 @@ func (c *tableNameCache) get(
 }
 
 func (c *tableNameCache) insert(table *tableVersionState) {
+	// Temporary tables are not in system.namespace, and are never resolved
+	// through this cache.
+	if table.IsTemporary() {
+		return
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
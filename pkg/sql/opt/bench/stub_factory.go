@@ -41,6 +41,7 @@ func (f *stubFactory) ConstructScan(
 	reverse bool,
 	maxResults uint64,
 	reqOrdering exec.OutputOrdering,
+	locking *tree.LockingItem,
 ) (exec.Node, error) {
 	return struct{}{}, nil
 }
@@ -222,6 +223,7 @@ func (f *stubFactory) ConstructInsert(
 	table cat.Table,
 	insertCols exec.ColumnOrdinalSet,
 	checks exec.CheckOrdinalSet,
+	triggers exec.TriggerOrdinalSet,
 	rowsNeeded bool,
 ) (exec.Node, error) {
 	return struct{}{}, nil
@@ -233,6 +235,7 @@ func (f *stubFactory) ConstructUpdate(
 	fetchCols exec.ColumnOrdinalSet,
 	updateCols exec.ColumnOrdinalSet,
 	checks exec.CheckOrdinalSet,
+	triggers exec.TriggerOrdinalSet,
 	rowsNeeded bool,
 ) (exec.Node, error) {
 	return struct{}{}, nil
@@ -252,7 +255,11 @@ func (f *stubFactory) ConstructUpsert(
 }
 
 func (f *stubFactory) ConstructDelete(
-	input exec.Node, table cat.Table, fetchCols exec.ColumnOrdinalSet, rowsNeeded bool,
+	input exec.Node,
+	table cat.Table,
+	fetchCols exec.ColumnOrdinalSet,
+	triggers exec.TriggerOrdinalSet,
+	rowsNeeded bool,
 ) (exec.Node, error) {
 	return struct{}{}, nil
 }
@@ -278,3 +285,13 @@ func (f *stubFactory) ConstructSaveTable(
 ) (exec.Node, error) {
 	return struct{}{}, nil
 }
+
+func (f *stubFactory) ConstructRecursiveCTE(
+	initial exec.Node, fn exec.RecursiveCTEIterationFn, label string, deduplicate bool,
+) (exec.Node, error) {
+	return struct{}{}, nil
+}
+
+func (f *stubFactory) ConstructScanBuffer(ref exec.BufferNode, label string) (exec.Node, error) {
+	return struct{}{}, nil
+}
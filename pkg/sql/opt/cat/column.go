@@ -82,6 +82,21 @@ type Column interface {
 	// computed columns, but they can depend on all other columns, including
 	// columns with default values.
 	ComputedExprStr() string
+
+	// IsVirtualComputed returns true if the column is a computed column whose
+	// value is not stored in the primary index. The value of such a column is
+	// computed from the other columns when it is read, unless it is read from a
+	// secondary index that stores it.
+	IsVirtualComputed() bool
+
+	// IsIdentity returns true if the column is an identity column. The default
+	// value of such a column is the next value of a sequence owned by it.
+	IsIdentity() bool
+
+	// IsGeneratedAlwaysAsIdentity returns true if the column is an identity
+	// column defined as GENERATED ALWAYS. A value cannot be specified for such a
+	// column by INSERT without OVERRIDING SYSTEM VALUE, nor by UPDATE.
+	IsGeneratedAlwaysAsIdentity() bool
 }
 
 // IsMutationColumn is a convenience function that returns true if the column at
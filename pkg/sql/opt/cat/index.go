@@ -46,6 +46,11 @@ type Index interface {
 	// IsInverted returns true if this is a JSON inverted index.
 	IsInverted() bool
 
+	// Predicate returns the predicate expression and true if the index is a
+	// partial index, that is, if it only contains the rows of the table that
+	// satisfy the predicate. Otherwise it returns the empty string and false.
+	Predicate() (string, bool)
+
 	// ColumnCount returns the number of columns in the index. This includes
 	// columns that were part of the index definition (including the STORING
 	// clause), as well as implicitly added primary key columns.
@@ -43,6 +43,11 @@ type Table interface {
 	// information_schema tables.
 	IsVirtualTable() bool
 
+	// IsMaterializedView returns true if this table stores the contents of a
+	// materialized view. Materialized views can be read like tables, but their
+	// contents can only be changed by refreshing them.
+	IsMaterializedView() bool
+
 	// IsInterleaved returns true if any of this table's indexes are interleaved
 	// with index(es) from other table(s).
 	IsInterleaved() bool
@@ -137,6 +142,13 @@ type Table interface {
 
 	// InboundForeignKey returns the ith inbound foreign key reference.
 	InboundForeignKey(i int) ForeignKeyConstraint
+
+	// TriggerCount returns the number of triggers defined on the table.
+	TriggerCount() int
+
+	// Trigger returns the ith trigger, where i < TriggerCount. Triggers are
+	// ordered by name, which is the order in which they fire.
+	Trigger(i int) Trigger
 }
 
 // CheckConstraint contains the SQL text and the validity status for a check
@@ -0,0 +1,77 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package cat
+
+import "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+
+// Trigger is an interface to a trigger defined on a table, exposing only the
+// information needed by the query optimizer. A trigger runs a SQL body when
+// rows of the table are inserted, updated, or deleted. Row-level triggers are
+// planned as part of the mutation: the optimizer projects the values of the
+// affected rows that are passed to their body.
+type Trigger interface {
+	// Name is the name of the trigger, which is unique among the triggers of
+	// the table.
+	Name() tree.Name
+
+	// IsBefore returns true if the trigger fires before the rows are written,
+	// or false if it fires after.
+	IsBefore() bool
+
+	// FiresOn returns true if the trigger fires on the given event.
+	FiresOn(event tree.TriggerEvent) bool
+
+	// ForEachRow returns true if the trigger fires once for each affected row,
+	// or false if it fires once for each triggering statement.
+	ForEachRow() bool
+
+	// When returns the text of the condition of the WHEN clause, or the empty
+	// string if the trigger fires unconditionally. The condition of a row-level
+	// trigger can reference the columns of the affected row as NEW.<column>
+	// and OLD.<column>.
+	When() string
+
+	// ArgCount returns the number of values of the affected row that are
+	// referenced by the body of a row-level trigger.
+	ArgCount() int
+
+	// Arg returns the ith value of the affected row that is referenced by the
+	// body, where i < ArgCount.
+	Arg(i int) TriggerArg
+
+	// ResultColumnCount returns the number of columns whose new values are
+	// replaced by the row returned by a BEFORE row-level trigger.
+	ResultColumnCount() int
+
+	// ResultColumnOrdinal returns the ordinal (see Table.Column) of the ith
+	// column replaced by the row returned by a BEFORE row-level trigger, where
+	// i < ResultColumnCount.
+	ResultColumnOrdinal(i int) int
+
+	// Function returns the function that runs the body of a BEFORE row-level
+	// trigger. It takes the values listed by Arg, and returns a tuple with the
+	// values of the columns listed by ResultColumnOrdinal, or NULL if the row
+	// must be skipped.
+	Function() (*tree.FunctionProperties, *tree.Overload)
+}
+
+// TriggerArg is a value of the affected row that is referenced by the body of
+// a row-level trigger.
+type TriggerArg struct {
+	// Ordinal is the ordinal position of the column in the table.
+	Ordinal int
+
+	// New is true if the new value of the column is referenced (NEW.<column>),
+	// or false if the old value is referenced (OLD.<column>).
+	New bool
+}
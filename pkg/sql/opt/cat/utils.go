@@ -200,6 +200,10 @@ func formatCatalogIndex(tab Table, ord int, tp treeprinter.Node) {
 
 		child.Child(buf.String())
 	}
+
+	if pred, isPartial := idx.Predicate(); isPartial {
+		child.Childf("WHERE %s", pred)
+	}
 }
 
 // formatColPrefix returns a string representation of a list of columns. The
@@ -47,6 +47,11 @@ type Builder struct {
 	// each relational subexpression when evalCtx.SessionData.SaveTablesPrefix is
 	// non-empty.
 	nameGen *memo.ExprNameGenerator
+
+	// workTables maps the WithID of a recursive CTE to the buffer that holds its
+	// working table. It is only populated when building the plan for an
+	// iteration of the recursive CTE.
+	workTables map[opt.WithID]exec.BufferNode
 }
 
 // New constructs an instance of the execution node builder using the
@@ -68,19 +73,7 @@ func (b *Builder) DisableTelemetry() {
 // Build constructs the execution node tree and returns its root node if no
 // error occurred.
 func (b *Builder) Build() (_ exec.Plan, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			// This code allows us to propagate internal errors without having to add
-			// error checks everywhere throughout the code. This is only possible
-			// because the code does not update shared state and does not manipulate
-			// locks.
-			if pgErr, ok := r.(*pgerror.Error); ok {
-				err = pgErr
-			} else {
-				panic(r)
-			}
-		}
-	}()
+	defer catchBuildError(&err)
 
 	root, err := b.build(b.e)
 	if err != nil {
@@ -89,6 +82,22 @@ func (b *Builder) Build() (_ exec.Plan, err error) {
 	return b.factory.ConstructPlan(root, b.subqueries)
 }
 
+// catchBuildError recovers from a panic that was raised while building the
+// plan, and converts it to an error. It must be deferred directly.
+func catchBuildError(err *error) {
+	if r := recover(); r != nil {
+		// This code allows us to propagate internal errors without having to add
+		// error checks everywhere throughout the code. This is only possible
+		// because the code does not update shared state and does not manipulate
+		// locks.
+		if pgErr, ok := r.(*pgerror.Error); ok {
+			*err = pgErr
+		} else {
+			panic(r)
+		}
+	}
+}
+
 func (b *Builder) build(e opt.Expr) (exec.Node, error) {
 	rel, ok := e.(memo.RelExpr)
 	if !ok {
@@ -17,10 +17,12 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/ordering"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
@@ -241,6 +243,12 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.SequenceSelectExpr:
 		ep, err = b.buildSequenceSelect(t)
 
+	case *memo.RecursiveCTEExpr:
+		ep, err = b.buildRecursiveCTE(t)
+
+	case *memo.WorkTableScanExpr:
+		ep, err = b.buildWorkTableScan(t)
+
 	default:
 		if opt.IsSetOp(e) {
 			ep, err = b.buildSetOp(e)
@@ -388,6 +396,8 @@ func (b *Builder) buildScan(scan *memo.ScanExpr) (execPlan, error) {
 		var err error
 		if idx.IsInverted() {
 			err = fmt.Errorf("index \"%s\" is inverted and cannot be used for this query", idx.Name())
+		} else if _, isPartial := idx.Predicate(); isPartial {
+			err = fmt.Errorf("index \"%s\" is partial and cannot be used for this query", idx.Name())
 		} else {
 			// This should never happen.
 			err = fmt.Errorf("index \"%s\" cannot be used for this query", idx.Name())
@@ -395,7 +405,18 @@ func (b *Builder) buildScan(scan *memo.ScanExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 
-	needed, output := b.getColumns(scan.Cols, scan.Table)
+	// Virtual computed columns are not stored in the primary index, so they are
+	// computed from the columns they reference.
+	cols, virtualCols := scan.Cols, opt.ColSet{}
+	if scan.Index == cat.PrimaryIndex {
+		var err error
+		cols, virtualCols, err = b.virtualColumnDeps(scan.Table, scan.Cols)
+		if err != nil {
+			return execPlan{}, err
+		}
+	}
+
+	needed, output := b.getColumns(cols, scan.Table)
 	res := execPlan{outputCols: output}
 
 	root, err := b.factory.ConstructScan(
@@ -408,11 +429,84 @@ func (b *Builder) buildScan(scan *memo.ScanExpr) (execPlan, error) {
 		ordering.ScanIsReverse(scan, &scan.RequiredPhysical().Ordering),
 		b.indexConstraintMaxResults(scan),
 		res.reqOrdering(scan),
+		scan.Locking,
 	)
 	if err != nil {
 		return execPlan{}, err
 	}
 	res.root = root
+	if !virtualCols.Empty() {
+		return b.computeVirtualColumns(res, scan.Table, scan.Cols, scan.ProvidedPhysical().Ordering)
+	}
+	return res, nil
+}
+
+// virtualColumnDeps returns the columns that must be read from the primary
+// index of the given table in order to produce the given columns: the virtual
+// computed columns are replaced with the columns their expressions reference.
+// It also returns the set of virtual computed columns, which must then be
+// computed with computeVirtualColumns.
+func (b *Builder) virtualColumnDeps(
+	tabID opt.TableID, cols opt.ColSet,
+) (fetchCols, virtualCols opt.ColSet, _ error) {
+	tabMeta := b.mem.Metadata().TableMeta(tabID)
+	fetchCols = cols
+	for col, ok := cols.Next(0); ok; col, ok = cols.Next(col + 1) {
+		ord := tabID.ColumnOrdinal(opt.ColumnID(col))
+		if !tabMeta.Table.Column(ord).IsVirtualComputed() {
+			continue
+		}
+		expr, ok := tabMeta.VirtualColumnExpr(ord)
+		if !ok {
+			return opt.ColSet{}, opt.ColSet{}, pgerror.AssertionFailedf(
+				"expression of virtual computed column %q not found", tabMeta.Table.Column(ord).ColName())
+		}
+		if virtualCols.Empty() {
+			fetchCols = cols.Copy()
+		}
+		virtualCols.Add(col)
+		fetchCols.Remove(col)
+		var shared props.Shared
+		memo.BuildSharedProps(b.mem, expr, &shared)
+		fetchCols.UnionWith(shared.OuterCols)
+	}
+	return fetchCols, virtualCols, nil
+}
+
+// computeVirtualColumns adds a projection on top of the given plan, which reads
+// from the given table, that produces the given columns. The virtual computed
+// columns among them are computed from their expressions, which must only
+// reference columns produced by the plan.
+func (b *Builder) computeVirtualColumns(
+	input execPlan, tabID opt.TableID, cols opt.ColSet, providedOrd opt.Ordering,
+) (execPlan, error) {
+	md := b.mem.Metadata()
+	tabMeta := md.TableMeta(tabID)
+
+	var res execPlan
+	exprs := make(tree.TypedExprs, 0, cols.Len())
+	colNames := make([]string, 0, cols.Len())
+	ctx := input.makeBuildScalarCtx()
+	for i, ok := cols.Next(0); ok; i, ok = cols.Next(i + 1) {
+		colID := opt.ColumnID(i)
+		res.outputCols.Set(i, len(exprs))
+		if expr, ok := tabMeta.VirtualColumnExpr(tabID.ColumnOrdinal(colID)); ok {
+			texpr, err := b.buildScalar(&ctx, expr)
+			if err != nil {
+				return execPlan{}, err
+			}
+			exprs = append(exprs, texpr)
+		} else {
+			exprs = append(exprs, b.indexedVar(&ctx, md, colID))
+		}
+		colNames = append(colNames, md.ColumnMeta(colID).Alias)
+	}
+	var err error
+	reqOrdering := exec.OutputOrdering(res.sqlOrdering(providedOrd))
+	res.root, err = b.factory.ConstructRender(input.root, exprs, colNames, reqOrdering)
+	if err != nil {
+		return execPlan{}, err
+	}
 	return res, nil
 }
 
@@ -1052,6 +1146,78 @@ func (b *Builder) buildSetOp(set memo.RelExpr) (execPlan, error) {
 	return ep, nil
 }
 
+func (b *Builder) buildRecursiveCTE(rec *memo.RecursiveCTEExpr) (execPlan, error) {
+	initial, err := b.buildRelational(rec.Initial)
+	if err != nil {
+		return execPlan{}, err
+	}
+	// Make sure the initial plan produces the columns in the same order as the
+	// recursive plans.
+	initial, err = b.ensureColumns(
+		initial, rec.InitialCols, nil /* colNames */, rec.Initial.ProvidedPhysical().Ordering,
+	)
+	if err != nil {
+		return execPlan{}, err
+	}
+
+	// The recursive expression is planned anew for each iteration, using a
+	// separate builder in which the working table is bound to the buffer that
+	// holds the rows produced by the previous iteration.
+	fn := func(bufferRef exec.BufferNode) (_ exec.Plan, err error) {
+		defer catchBuildError(&err)
+
+		innerBld := New(b.factory, b.mem, rec.Recursive, b.evalCtx)
+		innerBld.disableTelemetry = true
+		innerBld.workTables = make(map[opt.WithID]exec.BufferNode, len(b.workTables)+1)
+		for id, ref := range b.workTables {
+			innerBld.workTables[id] = ref
+		}
+		innerBld.workTables[rec.WithID] = bufferRef
+
+		plan, err := innerBld.buildRelational(rec.Recursive)
+		if err != nil {
+			return nil, err
+		}
+		// Make sure the columns are produced in the same order as the working
+		// table.
+		plan, err = innerBld.ensureColumns(
+			plan, rec.RecursiveCols, nil /* colNames */, rec.Recursive.ProvidedPhysical().Ordering,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return innerBld.factory.ConstructPlan(plan.root, innerBld.subqueries)
+	}
+
+	node, err := b.factory.ConstructRecursiveCTE(initial.root, fn, rec.Name, rec.Deduplicate)
+	if err != nil {
+		return execPlan{}, err
+	}
+	ep := execPlan{root: node}
+	for i, col := range rec.OutCols {
+		ep.outputCols.Set(int(col), i)
+	}
+	return ep, nil
+}
+
+func (b *Builder) buildWorkTableScan(scan *memo.WorkTableScanExpr) (execPlan, error) {
+	bufferRef, ok := b.workTables[scan.ID]
+	if !ok {
+		return execPlan{}, pgerror.AssertionFailedf(
+			"no working table for recursive CTE %s", log.Safe(scan.Name),
+		)
+	}
+	node, err := b.factory.ConstructScanBuffer(bufferRef, scan.Name)
+	if err != nil {
+		return execPlan{}, err
+	}
+	ep := execPlan{root: node}
+	for i, col := range scan.OutCols {
+		ep.outputCols.Set(int(col), i)
+	}
+	return ep, nil
+}
+
 // buildLimitOffset builds a plan for a LimitOp or OffsetOp
 func (b *Builder) buildLimitOffset(e memo.RelExpr) (execPlan, error) {
 	input, err := b.buildRelational(e.Child(0).(memo.RelExpr))
@@ -1125,10 +1291,27 @@ func (b *Builder) buildIndexJoin(join *memo.IndexJoinExpr) (execPlan, error) {
 
 	md := b.mem.Metadata()
 
-	cols := join.Cols
+	// Virtual computed columns are not stored in the primary index, so they are
+	// computed from the columns they reference.
+	cols, virtualCols, err := b.virtualColumnDeps(join.Table, join.Cols)
+	if err != nil {
+		return execPlan{}, err
+	}
 	needed, output := b.getColumns(cols, join.Table)
 	res := execPlan{outputCols: output}
 
+	// The index join can only maintain an ordering on virtual computed columns
+	// if they are computed before the rows are merged, so they are sorted again
+	// instead.
+	if ordering == nil && !virtualCols.Empty() {
+		for _, c := range join.ProvidedPhysical().Ordering {
+			if virtualCols.Contains(int(c.ID())) {
+				ordering = join.ProvidedPhysical().Ordering
+				break
+			}
+		}
+	}
+
 	// Get sort *result column* ordinals. Don't confuse these with *table column*
 	// ordinals, which are used by the needed set. The sort columns should already
 	// be in the needed set, so no need to add anything further to that.
@@ -1143,6 +1326,16 @@ func (b *Builder) buildIndexJoin(join *memo.IndexJoinExpr) (execPlan, error) {
 	if err != nil {
 		return execPlan{}, err
 	}
+	if !virtualCols.Empty() {
+		var providedOrd opt.Ordering
+		if ordering == nil {
+			providedOrd = join.ProvidedPhysical().Ordering
+		}
+		res, err = b.computeVirtualColumns(res, join.Table, join.Cols, providedOrd)
+		if err != nil {
+			return execPlan{}, err
+		}
+	}
 	if ordering != nil {
 		res, err = b.buildSortedInput(res, ordering)
 		if err != nil {
@@ -1488,9 +1681,10 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (execPlan, error) {
 
 	// Construct list of columns that only contains columns that need to be
 	// inserted (e.g. delete-only mutation columns don't need to be inserted).
-	colList := make(opt.ColList, 0, len(ins.InsertCols)+len(ins.CheckCols))
+	colList := make(opt.ColList, 0, len(ins.InsertCols)+len(ins.CheckCols)+len(ins.TriggerCols))
 	colList = appendColsWhenPresent(colList, ins.InsertCols)
 	colList = appendColsWhenPresent(colList, ins.CheckCols)
+	colList = appendColsWhenPresent(colList, ins.TriggerCols)
 	input, err = b.ensureColumns(input, colList, nil, ins.Input.ProvidedPhysical().Ordering)
 	if err != nil {
 		return execPlan{}, err
@@ -1500,11 +1694,13 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (execPlan, error) {
 	tab := b.mem.Metadata().Table(ins.Table)
 	insertOrds := ordinalSetFromColList(ins.InsertCols)
 	checkOrds := ordinalSetFromColList(ins.CheckCols)
+	triggerOrds := ordinalSetFromColList(ins.TriggerCols)
 	node, err := b.factory.ConstructInsert(
 		input.root,
 		tab,
 		insertOrds,
 		checkOrds,
+		triggerOrds,
 		ins.NeedResults(),
 	)
 	if err != nil {
@@ -1538,10 +1734,15 @@ func (b *Builder) buildUpdate(upd *memo.UpdateExpr) (execPlan, error) {
 	//
 	// TODO(andyk): Using ensureColumns here can result in an extra Render.
 	// Upgrade execution engine to not require this.
-	colList := make(opt.ColList, 0, len(upd.FetchCols)+len(upd.UpdateCols)+len(upd.CheckCols))
+	colList := make(
+		opt.ColList,
+		0,
+		len(upd.FetchCols)+len(upd.UpdateCols)+len(upd.CheckCols)+len(upd.TriggerCols),
+	)
 	colList = appendColsWhenPresent(colList, upd.FetchCols)
 	colList = appendColsWhenPresent(colList, upd.UpdateCols)
 	colList = appendColsWhenPresent(colList, upd.CheckCols)
+	colList = appendColsWhenPresent(colList, upd.TriggerCols)
 	input, err = b.ensureColumns(input, colList, nil, upd.Input.ProvidedPhysical().Ordering)
 	if err != nil {
 		return execPlan{}, err
@@ -1553,12 +1754,14 @@ func (b *Builder) buildUpdate(upd *memo.UpdateExpr) (execPlan, error) {
 	fetchColOrds := ordinalSetFromColList(upd.FetchCols)
 	updateColOrds := ordinalSetFromColList(upd.UpdateCols)
 	checkOrds := ordinalSetFromColList(upd.CheckCols)
+	triggerOrds := ordinalSetFromColList(upd.TriggerCols)
 	node, err := b.factory.ConstructUpdate(
 		input.root,
 		tab,
 		fetchColOrds,
 		updateColOrds,
 		checkOrds,
+		triggerOrds,
 		upd.NeedResults(),
 	)
 	if err != nil {
@@ -1665,8 +1868,9 @@ func (b *Builder) buildDelete(del *memo.DeleteExpr) (execPlan, error) {
 	//
 	// TODO(andyk): Using ensureColumns here can result in an extra Render.
 	// Upgrade execution engine to not require this.
-	colList := make(opt.ColList, 0, len(del.FetchCols))
+	colList := make(opt.ColList, 0, len(del.FetchCols)+len(del.TriggerCols))
 	colList = appendColsWhenPresent(colList, del.FetchCols)
+	colList = appendColsWhenPresent(colList, del.TriggerCols)
 	input, err = b.ensureColumns(input, colList, nil, del.Input.ProvidedPhysical().Ordering)
 	if err != nil {
 		return execPlan{}, err
@@ -1676,7 +1880,14 @@ func (b *Builder) buildDelete(del *memo.DeleteExpr) (execPlan, error) {
 	md := b.mem.Metadata()
 	tab := md.Table(del.Table)
 	fetchColOrds := ordinalSetFromColList(del.FetchCols)
-	node, err := b.factory.ConstructDelete(input.root, tab, fetchColOrds, del.NeedResults())
+	triggerOrds := ordinalSetFromColList(del.TriggerCols)
+	node, err := b.factory.ConstructDelete(
+		input.root,
+		tab,
+		fetchColOrds,
+		triggerOrds,
+		del.NeedResults(),
+	)
 	if err != nil {
 		return execPlan{}, err
 	}
@@ -1714,6 +1925,10 @@ func (b *Builder) canUseDeleteRange(del *memo.DeleteExpr) bool {
 		// is possible, because the integrity of those references must be checked.
 		return false
 	}
+	if tab.TriggerCount() > 0 {
+		// Triggers must be fired by the deleted rows, or by the statement.
+		return false
+	}
 
 	// Check for simple Scan input operator without a limit; anything else is not
 	// supported by a range delete.
@@ -34,6 +34,17 @@ type Node interface{}
 // trees (see ConstructPlan).
 type Plan interface{}
 
+// BufferNode represents a node that holds a set of buffered rows which can be
+// read (possibly multiple times) by nodes created with ConstructScanBuffer.
+type BufferNode interface {
+	Node
+}
+
+// RecursiveCTEIterationFn creates a plan for an iteration of WITH RECURSIVE,
+// given the result of the last iteration (as a BufferNode that can be used
+// with ConstructScanBuffer).
+type RecursiveCTEIterationFn func(bufferRef BufferNode) (Plan, error)
+
 // Factory defines the interface for building an execution plan, which consists
 // of a tree of execution nodes (currently a sql.planNode tree).
 //
@@ -56,6 +67,8 @@ type Factory interface {
 	//     the scan.
 	//   - If maxResults > 0, the scan is guaranteed to return at most maxResults
 	//     rows.
+	//   - If locking is provided, the scan should use the specified row-level
+	//     locking mode.
 	ConstructScan(
 		table cat.Table,
 		index cat.Index,
@@ -65,6 +78,7 @@ type Factory interface {
 		reverse bool,
 		maxResults uint64,
 		reqOrdering OutputOrdering,
+		locking *tree.LockingItem,
 	) (Node, error)
 
 	// ConstructVirtualScan returns a node that represents the scan of a virtual
@@ -289,11 +303,17 @@ type Factory interface {
 	// since those do not need to participate in an insert operation. The
 	// rowsNeeded parameter is true if a RETURNING clause needs the inserted
 	// row(s) as output.
+	//
+	// The triggers set contains the ordinal positions of the AFTER row-level
+	// triggers that can be fired by the inserted rows. The last input columns
+	// contain the tuples of values passed to each of them, or NULL if the
+	// trigger doesn't fire for the row.
 	ConstructInsert(
 		input Node,
 		table cat.Table,
 		insertCols ColumnOrdinalSet,
 		checks CheckOrdinalSet,
+		triggers TriggerOrdinalSet,
 		rowsNeeded bool,
 	) (Node, error)
 
@@ -308,13 +328,15 @@ type Factory interface {
 	// fetch and update columns in the target table. The input must contain those
 	// columns in the same order as they appear in the table schema, with the
 	// fetch columns first and the update columns second. The rowsNeeded parameter
-	// is true if a RETURNING clause needs the updated row(s) as output.
+	// is true if a RETURNING clause needs the updated row(s) as output. See
+	// ConstructInsert for the triggers parameter.
 	ConstructUpdate(
 		input Node,
 		table cat.Table,
 		fetchCols ColumnOrdinalSet,
 		updateCols ColumnOrdinalSet,
 		checks CheckOrdinalSet,
+		triggers TriggerOrdinalSet,
 		rowsNeeded bool,
 	) (Node, error)
 
@@ -359,9 +381,14 @@ type Factory interface {
 	// The fetchCols set contains the ordinal positions of the fetch columns in
 	// the target table. The input must contain those columns in the same order
 	// as they appear in the table schema. The rowsNeeded parameter is true if a
-	// RETURNING clause needs the deleted row(s) as output.
+	// RETURNING clause needs the deleted row(s) as output. See ConstructInsert
+	// for the triggers parameter.
 	ConstructDelete(
-		input Node, table cat.Table, fetchCols ColumnOrdinalSet, rowsNeeded bool,
+		input Node,
+		table cat.Table,
+		fetchCols ColumnOrdinalSet,
+		triggers TriggerOrdinalSet,
+		rowsNeeded bool,
 	) (Node, error)
 
 	// ConstructDeleteRange creates a node that efficiently deletes contiguous
@@ -386,6 +413,25 @@ type Factory interface {
 	// ConstructSaveTable wraps the input into a node that passes through all the
 	// rows, but also creates a table and inserts all the rows into it.
 	ConstructSaveTable(input Node, table *cat.DataSourceName, colNames []string) (Node, error)
+
+	// ConstructRecursiveCTE constructs a node that executes a recursive CTE:
+	//   * the initial plan is run first; the results are emitted and also saved
+	//     in a buffer.
+	//   * so long as the last buffer is not empty:
+	//     - the RecursiveCTEIterationFn is used to create a plan for the
+	//       recursive side; a reference to the last buffer is passed to this
+	//       function. The returned plan uses this reference with a
+	//       ConstructScanBuffer call.
+	//     - the plan is executed; the results are emitted and also saved in a
+	//       new buffer for the next iteration.
+	// If deduplicate is set, rows that were already emitted are discarded.
+	ConstructRecursiveCTE(
+		initial Node, fn RecursiveCTEIterationFn, label string, deduplicate bool,
+	) (Node, error)
+
+	// ConstructScanBuffer constructs a node which refers to a BufferNode passed
+	// to a RecursiveCTEIterationFn. The label is used for EXPLAIN output.
+	ConstructScanBuffer(ref BufferNode, label string) (Node, error)
 }
 
 // OutputOrdering indicates the required output ordering on a Node that is being
@@ -442,6 +488,10 @@ type ColumnOrdinalSet = util.FastIntSet
 // taken from the opt.Table.Check collection.
 type CheckOrdinalSet = util.FastIntSet
 
+// TriggerOrdinalSet contains the ordinal positions of a set of triggers taken
+// from the opt.Table.Trigger collection.
+type TriggerOrdinalSet = util.FastIntSet
+
 // AggInfo represents an aggregation (see ConstructGroupBy).
 type AggInfo struct {
 	FuncName   string
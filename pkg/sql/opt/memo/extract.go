@@ -292,3 +292,76 @@ func extractConstEquality(condition opt.ScalarExpr) (bool, int, tree.Datum) {
 
 	return false, 0, nil
 }
+
+// ExtractConjuncts returns the conjuncts of the given condition, which are the
+// operands of its top-level AND operators.
+func ExtractConjuncts(condition opt.ScalarExpr) []opt.ScalarExpr {
+	if and, ok := condition.(*AndExpr); ok {
+		return append(ExtractConjuncts(and.Left), ExtractConjuncts(and.Right)...)
+	}
+	return []opt.ScalarExpr{condition}
+}
+
+// FiltersImplyPredicate returns true if the given filters imply the given
+// predicate, that is, if every row that satisfies the filters also satisfies
+// the predicate. Every conjunct of the predicate must either be identical to
+// one of the filters, or be exactly equivalent to a constraint that contains
+// the constraint deduced from one of the filters. For example, the filter
+// a > 5 implies the predicate a > 0, but not the predicate a > 10.
+func FiltersImplyPredicate(
+	evalCtx *tree.EvalContext, mem *Memo, filters FiltersExpr, pred opt.ScalarExpr,
+) bool {
+	for _, conjunct := range ExtractConjuncts(pred) {
+		if conjunct.Op() == opt.TrueOp {
+			continue
+		}
+		if !filtersImplyCondition(evalCtx, mem, filters, conjunct) {
+			return false
+		}
+	}
+	return true
+}
+
+// filtersImplyCondition returns true if the given filters imply the given
+// condition. See FiltersImplyPredicate.
+func filtersImplyCondition(
+	evalCtx *tree.EvalContext, mem *Memo, filters FiltersExpr, condition opt.ScalarExpr,
+) bool {
+	for i := range filters {
+		if filters[i].Condition == condition {
+			return true
+		}
+	}
+
+	item := FiltersItem{Condition: condition}
+	condProps := item.ScalarProps(mem)
+	if !condProps.TightConstraints || condProps.Constraints == nil ||
+		condProps.Constraints.Length() != 1 {
+		return false
+	}
+	condConstraint := condProps.Constraints.Constraint(0)
+
+	for i := range filters {
+		filterConstraints := filters[i].ScalarProps(mem).Constraints
+		if filterConstraints == nil {
+			continue
+		}
+		for j, n := 0, filterConstraints.Length(); j < n; j++ {
+			filterConstraint := filterConstraints.Constraint(j)
+			if !filterConstraint.Columns.Equals(&condConstraint.Columns) {
+				continue
+			}
+			contained := true
+			for k, m := 0, filterConstraint.Spans.Count(); k < m; k++ {
+				if !condConstraint.ContainsSpan(evalCtx, filterConstraint.Spans.Get(k)) {
+					contained = false
+					break
+				}
+			}
+			if contained {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -355,6 +355,9 @@ func (h *hasher) HashDatum(val tree.Datum) {
 		h.HashUint64(uint64(t.PGEpochDays()))
 	case *tree.DTime:
 		h.HashUint64(uint64(*t))
+	case *tree.DTimeTZ:
+		h.HashUint64(uint64(t.TimeOfDay))
+		h.HashUint64(uint64(t.OffsetSecs))
 	case *tree.DJSON:
 		h.HashString(t.String())
 	case *tree.DTuple:
@@ -452,6 +455,10 @@ func (h *hasher) HashValuesID(val opt.ValuesID) {
 	h.HashUint64(uint64(val))
 }
 
+func (h *hasher) HashWithID(val opt.WithID) {
+	h.HashUint64(uint64(val))
+}
+
 func (h *hasher) HashScanLimit(val ScanLimit) {
 	h.HashUint64(uint64(val))
 }
@@ -643,6 +650,10 @@ func (h *hasher) IsDatumEqual(l, r tree.Datum) bool {
 		if rt, ok := r.(*tree.DTime); ok {
 			return uint64(*lt) == uint64(*rt)
 		}
+	case *tree.DTimeTZ:
+		if rt, ok := r.(*tree.DTimeTZ); ok {
+			return lt.TimeTZ == rt.TimeTZ
+		}
 	case *tree.DJSON:
 		if rt, ok := r.(*tree.DJSON); ok {
 			return h.IsStringEqual(lt.String(), rt.String())
@@ -739,6 +750,10 @@ func (h *hasher) IsValuesIDEqual(l, r opt.ValuesID) bool {
 	return l == r
 }
 
+func (h *hasher) IsWithIDEqual(l, r opt.WithID) bool {
+	return l == r
+}
+
 func (h *hasher) IsScanLimitEqual(l, r ScanLimit) bool {
 	return l == r
 }
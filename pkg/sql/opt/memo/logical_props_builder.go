@@ -635,6 +635,91 @@ func (b *logicalPropsBuilder) buildSetProps(setNode RelExpr, rel *props.Relation
 	}
 }
 
+func (b *logicalPropsBuilder) buildRecursiveCTEProps(
+	rec *RecursiveCTEExpr, rel *props.Relational,
+) {
+	BuildSharedProps(b.mem, rec, &rel.Shared)
+
+	initialProps := rec.Initial.Relational()
+	recursiveProps := rec.Recursive.Relational()
+
+	// Output Columns
+	// --------------
+	// Output columns are stored in the definition.
+	rel.OutputCols = rec.OutCols.ToSet()
+
+	// Not Null Columns
+	// ----------------
+	// Columns have to be not-null in both the initial and recursive expressions
+	// to be not-null in the result.
+	for i := range rec.OutCols {
+		if initialProps.NotNullCols.Contains(int(rec.InitialCols[i])) &&
+			recursiveProps.NotNullCols.Contains(int(rec.RecursiveCols[i])) {
+			rel.NotNullCols.Add(int(rec.OutCols[i]))
+		}
+	}
+
+	// Outer Columns
+	// -------------
+	// Outer columns were already derived by buildSharedProps.
+
+	// Functional Dependencies
+	// -----------------------
+	// If duplicates are eliminated, then all the output columns form a key.
+	if rec.Deduplicate {
+		rel.FuncDeps.AddStrictKey(rel.OutputCols, rel.OutputCols)
+	}
+
+	// Cardinality
+	// -----------
+	// At least as many rows as the initial expression; no upper bound, since the
+	// number of iterations is not known in advance.
+	rel.Cardinality = props.AnyCardinality.AtLeast(
+		props.Cardinality{Min: initialProps.Cardinality.Min},
+	)
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildRecursiveCTE(rec, rel)
+	}
+}
+
+func (b *logicalPropsBuilder) buildWorkTableScanProps(
+	scan *WorkTableScanExpr, rel *props.Relational,
+) {
+	BuildSharedProps(b.mem, scan, &rel.Shared)
+
+	// Output Columns
+	// --------------
+	// Output columns are stored in the definition.
+	rel.OutputCols = scan.OutCols.ToSet()
+
+	// Not Null Columns
+	// ----------------
+	// All columns are assumed to be nullable.
+
+	// Outer Columns
+	// -------------
+	// The working table doesn't have outer columns.
+
+	// Functional Dependencies
+	// -----------------------
+	// The working table has an empty FD set.
+
+	// Cardinality
+	// -----------
+	// The recursive expression is never evaluated with an empty working table,
+	// so there is always at least one row.
+	rel.Cardinality = props.AnyCardinality.AtLeast(props.Cardinality{Min: 1})
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildWorkTableScan(rel)
+	}
+}
+
 func (b *logicalPropsBuilder) buildValuesProps(values *ValuesExpr, rel *props.Relational) {
 	BuildSharedProps(b.mem, values, &rel.Shared)
 
@@ -1302,6 +1387,11 @@ func makeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 			// Skip inverted indexes for now.
 			continue
 		}
+		if _, isPartial := index.Predicate(); isPartial {
+			// Partial indexes only guarantee uniqueness over the rows they
+			// contain, so they don't provide keys for the table.
+			continue
+		}
 
 		// If index has a separate lax key, add a lax key FD. Otherwise, add a
 		// strict key. See the comment for cat.Index.LaxKeyColumnCount.
@@ -345,6 +345,12 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, e RelExpr) *props.Column
 	case opt.SequenceSelectOp:
 		return sb.colStatSequenceSelect(colSet, e.(*SequenceSelectExpr))
 
+	case opt.RecursiveCTEOp:
+		return sb.colStatRecursiveCTE(colSet, e.(*RecursiveCTEExpr))
+
+	case opt.WorkTableScanOp:
+		return sb.colStatWorkTableScan(colSet, e.(*WorkTableScanExpr))
+
 	case opt.ExplainOp:
 		return sb.colStatExplain(colSet, e.(*ExplainExpr))
 
@@ -2053,6 +2059,50 @@ func (sb *statisticsBuilder) colStatSequenceSelect(
 	return colStat
 }
 
+// +---------------+
+// | Recursive CTE |
+// +---------------+
+
+func (sb *statisticsBuilder) buildRecursiveCTE(rec *RecursiveCTEExpr, relProps *props.Relational) {
+	s := &relProps.Stats
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+
+	// The number of iterations is unknown, so assume that the recursive CTE
+	// produces a multiple of the rows produced by the initial expression.
+	initialStats := &rec.Initial.Relational().Stats
+	s.RowCount = max(initialStats.RowCount, 1) * unknownRecursiveCTEFactor
+	sb.finalizeFromCardinality(relProps)
+}
+
+func (sb *statisticsBuilder) colStatRecursiveCTE(
+	colSet opt.ColSet, rec *RecursiveCTEExpr,
+) *props.ColumnStatistic {
+	relProps := rec.Relational()
+	s := &relProps.Stats
+	return sb.colStatLeaf(colSet, s, &relProps.FuncDeps, relProps.NotNullCols)
+}
+
+// +-----------------+
+// | Work Table Scan |
+// +-----------------+
+
+func (sb *statisticsBuilder) buildWorkTableScan(relProps *props.Relational) {
+	s := &relProps.Stats
+	s.RowCount = unknownGeneratorRowCount
+	sb.finalizeFromCardinality(relProps)
+}
+
+func (sb *statisticsBuilder) colStatWorkTableScan(
+	colSet opt.ColSet, scan *WorkTableScanExpr,
+) *props.ColumnStatistic {
+	relProps := scan.Relational()
+	s := &relProps.Stats
+	return sb.colStatLeaf(colSet, s, &relProps.FuncDeps, relProps.NotNullCols)
+}
+
 // +---------+
 // | Explain |
 // +---------+
@@ -2267,6 +2317,11 @@ const (
 	// Since the generator row count is so small, we need a larger distinct count
 	// ratio for generator functions.
 	unknownGeneratorDistinctCountRatio = 0.7
+
+	// This is the factor by which the row count of the initial query of a
+	// recursive CTE is multiplied to estimate the row count of the whole CTE,
+	// since the number of iterations is not known ahead of time.
+	unknownRecursiveCTEFactor = 10
 )
 
 // countJSONPaths returns the number of JSON paths in the specified
@@ -82,6 +82,10 @@ type Metadata struct {
 	// values is the highest id for a Values clause that has been assigned.
 	values ValuesID
 
+	// withs is the highest id for a recursive WITH binding that has been
+	// assigned.
+	withs WithID
+
 	// deps stores information about all catalog objects depended on by the query,
 	// as well as the privileges required to access those objects. The objects are
 	// deduplicated: any name/object pair shows up at most once.
@@ -161,6 +165,7 @@ func (md *Metadata) CopyFrom(from *Metadata) {
 
 	md.sequences = append(md.sequences, from.sequences...)
 	md.deps = append(md.deps, from.deps...)
+	md.withs = from.withs
 }
 
 // AddDataSourceDependency tracks one of the catalog data sources on which the
@@ -478,6 +483,20 @@ func (md *Metadata) NextValuesID() ValuesID {
 	return md.values
 }
 
+// WithID uniquely identifies the working table of a recursive common table
+// expression within the scope of a query. It is used to match a scan of the
+// working table with the RecursiveCTE operator that populates it.
+//
+// See the comment for Metadata for more details on identifiers.
+type WithID uint64
+
+// NextWithID returns a fresh WithID which is guaranteed to never have been
+// allocated prior in this memo.
+func (md *Metadata) NextWithID() WithID {
+	md.withs++
+	return md.withs
+}
+
 // AddView adds a new reference to a view used by the query.
 func (md *Metadata) AddView(v cat.View) {
 	md.views = append(md.views, v)
@@ -920,6 +920,90 @@ func (c *CustomFuncs) ConstructEmptyValues(cols opt.ColSet) memo.RelExpr {
 	})
 }
 
+// FindVirtualColumnExprs returns the set of virtual computed columns of the
+// table scanned by the given Scan operator whose expressions occur in the given
+// filters. Only columns whose type is identical to the type of their expression
+// are returned, so that replacing the expression with a reference to the column
+// does not change the result of the filters.
+func (c *CustomFuncs) FindVirtualColumnExprs(
+	filters memo.FiltersExpr, scanPrivate *memo.ScanPrivate,
+) opt.ColSet {
+	var cols opt.ColSet
+	tabMeta := c.mem.Metadata().TableMeta(scanPrivate.Table)
+	if !tabMeta.HasVirtualColumnExprs() {
+		return cols
+	}
+	for i, n := 0, tabMeta.Table.ColumnCount(); i < n; i++ {
+		expr, ok := tabMeta.VirtualColumnExpr(i)
+		if !ok || !expr.DataType().Identical(tabMeta.Table.Column(i).DatumType()) {
+			continue
+		}
+		for j := range filters {
+			if containsExpr(filters[j].Condition, expr) {
+				cols.Add(int(scanPrivate.Table.ColumnID(i)))
+				break
+			}
+		}
+	}
+	return cols
+}
+
+// containsExpr returns true if the given expression is, or has a descendant
+// that is, the given target expression. Since scalar expressions are interned,
+// the expressions are compared by pointer.
+func containsExpr(e, target opt.Expr) bool {
+	if e == target {
+		return true
+	}
+	for i, n := 0, e.ChildCount(); i < n; i++ {
+		if containsExpr(e.Child(i), target) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddColsToScan returns a copy of the given ScanPrivate that also scans the
+// given columns.
+func (c *CustomFuncs) AddColsToScan(
+	scanPrivate *memo.ScanPrivate, cols opt.ColSet,
+) *memo.ScanPrivate {
+	newScanPrivate := *scanPrivate
+	newScanPrivate.Cols = scanPrivate.Cols.Union(cols)
+	return &newScanPrivate
+}
+
+// ReplaceVirtualColumnExprs replaces the occurrences of the expressions of the
+// given virtual computed columns in the filters with references to the
+// columns. It returns a new Filters list containing the replaced expressions.
+func (c *CustomFuncs) ReplaceVirtualColumnExprs(
+	filters memo.FiltersExpr, scanPrivate *memo.ScanPrivate, cols opt.ColSet,
+) memo.FiltersExpr {
+	tabMeta := c.mem.Metadata().TableMeta(scanPrivate.Table)
+	exprs := make(map[opt.ScalarExpr]opt.ColumnID, cols.Len())
+	cols.ForEach(func(i int) {
+		col := opt.ColumnID(i)
+		expr, _ := tabMeta.VirtualColumnExpr(scanPrivate.Table.ColumnOrdinal(col))
+		exprs[expr] = col
+	})
+
+	var replace ReplaceFunc
+	replace = func(e opt.Expr) opt.Expr {
+		if scalar, ok := e.(opt.ScalarExpr); ok {
+			if col, ok := exprs[scalar]; ok {
+				return c.f.ConstructVariable(col)
+			}
+		}
+		return c.f.Replace(e, replace)
+	}
+
+	newFilters := make(memo.FiltersExpr, len(filters))
+	for i := range filters {
+		newFilters[i].Condition = replace(filters[i].Condition).(opt.ScalarExpr)
+	}
+	return newFilters
+}
+
 // ----------------------------------------------------------------------
 //
 // GroupBy Rules
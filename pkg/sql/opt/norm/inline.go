@@ -288,3 +288,56 @@ func (c *CustomFuncs) inlineProjections(e opt.Expr, projections memo.Projections
 
 	return replace(e)
 }
+
+// InlineFunctionBody replaces the references to the parameters of a
+// user-defined function in the body of the function with the arguments of a
+// call. paramCols are the columns that stand for the parameters in the body.
+// InlineFunctionBody returns nil if the body contains a subquery, or if an
+// argument that is not referenced exactly once cannot be inlined (see
+// CanInline), since inlining would change the number of times the argument is
+// evaluated.
+func (c *CustomFuncs) InlineFunctionBody(
+	body opt.ScalarExpr, paramCols opt.ColList, args memo.ScalarListExpr,
+) opt.ScalarExpr {
+	refs := make([]int, len(paramCols))
+	var countRefs func(e opt.Expr) bool
+	countRefs = func(e opt.Expr) bool {
+		switch t := e.(type) {
+		case *memo.VariableExpr:
+			if i, ok := paramCols.Find(t.Col); ok {
+				refs[i]++
+			}
+			return true
+
+		case memo.RelExpr:
+			return false
+		}
+
+		for i, n := 0, e.ChildCount(); i < n; i++ {
+			if !countRefs(e.Child(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	if !countRefs(body) {
+		return nil
+	}
+	for i := range args {
+		if refs[i] != 1 && !c.CanInline(args[i]) {
+			return nil
+		}
+	}
+
+	var replace ReplaceFunc
+	replace = func(e opt.Expr) opt.Expr {
+		if t, ok := e.(*memo.VariableExpr); ok {
+			if i, ok := paramCols.Find(t.Col); ok {
+				return args[i]
+			}
+			return t
+		}
+		return c.f.Replace(e, replace)
+	}
+	return replace(body).(opt.ScalarExpr)
+}
@@ -60,6 +60,7 @@ func (c *CustomFuncs) NeededMutationCols(private *memo.MutationPrivate) opt.ColS
 	addCols(private.FetchCols)
 	addCols(private.UpdateCols)
 	addCols(private.CheckCols)
+	addCols(private.TriggerCols)
 	addCols(private.ReturnCols)
 	if private.CanaryCol != 0 {
 		cols.Add(int(private.CanaryCol))
@@ -131,7 +132,11 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 
 		// Make sure to consider indexes that are being added or dropped.
 		for i, n := 0, tabMeta.Table.DeletableIndexCount(); i < n; i++ {
+			// The columns of the predicate of a partial index are needed to know
+			// whether the old and the new rows are part of the index, so they are
+			// treated as index columns.
 			indexCols := tabMeta.IndexColumns(i)
+			indexCols.UnionWith(c.partialIndexPredicateCols(tabMeta, i))
 			if !indexCols.Intersects(updateCols) {
 				// This index is not being updated.
 				continue
@@ -163,12 +168,34 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 		// or dropped.
 		for i, n := 0, tabMeta.Table.DeletableIndexCount(); i < n; i++ {
 			cols.UnionWith(tabMeta.IndexKeyColumns(i))
+			cols.UnionWith(c.partialIndexPredicateCols(tabMeta, i))
 		}
 	}
 
 	return cols
 }
 
+// partialIndexPredicateCols returns the columns referenced by the predicate of
+// the index with the given ordinal, or the empty set if the index is not
+// partial. If the predicate was not added to the table metadata, which is the
+// case for mutation indexes, all the columns of the table are returned.
+func (c *CustomFuncs) partialIndexPredicateCols(tabMeta *opt.TableMeta, indexOrd int) opt.ColSet {
+	if _, isPartial := tabMeta.Table.Index(indexOrd).Predicate(); !isPartial {
+		return opt.ColSet{}
+	}
+	pred, ok := tabMeta.PartialIndexPredicate(indexOrd)
+	if !ok {
+		var cols opt.ColSet
+		for i, n := 0, tabMeta.Table.DeletableColumnCount(); i < n; i++ {
+			cols.Add(int(tabMeta.MetaID.ColumnID(i)))
+		}
+		return cols
+	}
+	var shared props.Shared
+	memo.BuildSharedProps(c.mem, pred, &shared)
+	return shared.OuterCols
+}
+
 // CanPruneCols returns true if the target expression has extra columns that are
 // not needed at this level of the tree, and can be eliminated by one of the
 // PruneCols rules. CanPruneCols uses the PruneCols property to determine the
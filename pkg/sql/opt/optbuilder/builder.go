@@ -98,6 +98,18 @@ type Builder struct {
 	// subquery contains a pointer to the subquery which is currently being built
 	// (if any).
 	subquery *subquery
+
+	// typeResolver is the resolver of user-defined types that semaCtx had when
+	// the build started.
+	typeResolver tree.TypeReferenceResolver
+
+	// functionResolver is the resolver of user-defined functions that semaCtx
+	// had when the build started.
+	functionResolver tree.FunctionReferenceResolver
+
+	// inlineDepth is the number of user-defined functions that are being
+	// inlined; see tryInlineFunction.
+	inlineDepth int
 }
 
 // New creates a new Builder structure initialized with the given
@@ -144,6 +156,20 @@ func (b *Builder) Build() (err error) {
 		}
 	}()
 
+	// Intercept the resolution of user-defined types; see ResolveType.
+	if b.semaCtx.TypeResolver != nil {
+		b.typeResolver = b.semaCtx.TypeResolver
+		b.semaCtx.TypeResolver = b
+		defer func() { b.semaCtx.TypeResolver = b.typeResolver }()
+	}
+
+	// Intercept the resolution of user-defined functions; see ResolveFunction.
+	if b.semaCtx.FunctionResolver != nil {
+		b.functionResolver = b.semaCtx.FunctionResolver
+		b.semaCtx.FunctionResolver = b
+		defer func() { b.semaCtx.FunctionResolver = b.functionResolver }()
+	}
+
 	// Special case for CannedOptPlan.
 	if canned, ok := b.stmt.(*tree.CannedOptPlan); ok {
 		b.factory.DisableOptimizations()
@@ -159,6 +185,25 @@ func (b *Builder) Build() (err error) {
 	return nil
 }
 
+// ResolveType implements the tree.TypeReferenceResolver interface. The memo
+// does not track the versions of the user-defined types that it references, so
+// it cannot be reused once such a type has been resolved.
+func (b *Builder) ResolveType(name string) (*types.T, error) {
+	b.DisableMemoReuse = true
+	return b.typeResolver.ResolveType(name)
+}
+
+// ResolveFunction implements the tree.FunctionReferenceResolver interface. As
+// with user-defined types, the memo cannot be reused once a user-defined
+// function has been resolved.
+func (b *Builder) ResolveFunction(name *tree.UnresolvedName) (*tree.FunctionDefinition, error) {
+	def, err := b.functionResolver.ResolveFunction(name)
+	if def != nil {
+		b.DisableMemoReuse = true
+	}
+	return def, err
+}
+
 // builderError is used to wrap errors returned by various external APIs that
 // occur during the build process. It exists for us to be able to panic on these
 // errors and then catch them inside Builder.Build even if they are not
@@ -209,10 +254,10 @@ func (b *Builder) buildStmt(
 		return b.buildInsert(stmt, inScope)
 
 	case *tree.ParenSelect:
-		return b.buildSelect(stmt.Select, desiredTypes, inScope)
+		return b.buildSelect(stmt.Select, noRowLocking, desiredTypes, inScope)
 
 	case *tree.Select:
-		return b.buildSelect(stmt, desiredTypes, inScope)
+		return b.buildSelect(stmt, noRowLocking, desiredTypes, inScope)
 
 	case *tree.ShowTraceForSession:
 		return b.buildShowTrace(stmt, inScope)
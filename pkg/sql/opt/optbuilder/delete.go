@@ -41,7 +41,7 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 	}
 
 	if del.With != nil {
-		inScope = b.buildCTE(del.With.CTEList, inScope)
+		inScope = b.buildCTE(del.With, inScope)
 		defer b.checkCTEUsage(inScope)
 	}
 
@@ -51,7 +51,7 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 	tn, alias := getAliasedTableName(del.Table)
 
 	// Find which table we're working on, check the permissions.
-	tab, resName := b.resolveTable(tn, privilege.DELETE)
+	tab, resName := b.resolveTableForMutation(tn, privilege.DELETE)
 	if alias == nil {
 		alias = &resName
 	}
@@ -69,7 +69,10 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 	//   ORDER BY <order-by> LIMIT <limit>
 	//
 	// All columns from the delete table will be projected.
-	mb.buildInputForUpdateOrDelete(inScope, del.Where, del.Limit, del.OrderBy)
+	mb.buildInputForUpdateOrDelete(inScope, nil /* from */, del.Where, del.Limit, del.OrderBy)
+
+	// Run the BEFORE row-level triggers, which can skip rows.
+	mb.addBeforeTriggers()
 
 	// Build the final delete statement, including any returned expressions.
 	if resultsNeeded(del.Returning) {
@@ -84,6 +87,8 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 // buildDelete constructs a Delete operator, possibly wrapped by a Project
 // operator that corresponds to the given RETURNING clause.
 func (mb *mutationBuilder) buildDelete(returning tree.ReturningExprs) {
+	mb.addAfterTriggerCols()
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructDelete(mb.outScope.expr, private)
 
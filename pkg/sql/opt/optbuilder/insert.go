@@ -19,9 +19,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
 )
@@ -138,7 +140,7 @@ func init() {
 // and thereby scrambles the input ordering.
 func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope) {
 	if ins.With != nil {
-		inScope = b.buildCTE(ins.With.CTEList, inScope)
+		inScope = b.buildCTE(ins.With, inScope)
 		defer b.checkCTEUsage(inScope)
 	}
 
@@ -148,7 +150,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	tn, alias := getAliasedTableName(ins.Table)
 
 	// Find which table we're working on, check the permissions.
-	tab, resName := b.resolveTable(tn, privilege.INSERT)
+	tab, resName := b.resolveTableForMutation(tn, privilege.INSERT)
 	if alias == nil {
 		alias = &resName
 	}
@@ -171,6 +173,13 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	} else {
 		mb.init(b, opt.InsertOp, tab, *alias)
 	}
+	if ins.OnConflict != nil && !ins.OnConflict.DoNothing {
+		mb.checkNoTriggers()
+	}
+
+	// Assignments of fields of composite columns are rewritten into
+	// assignments of whole columns.
+	ins = mb.rewriteFieldInsert(ins)
 
 	// Compute target columns in two cases:
 	//
@@ -217,6 +226,10 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		rows := mb.replaceDefaultExprs(ins.Rows)
 
 		mb.buildInputForInsert(inScope, rows)
+
+		// Check the values specified for identity columns, and ignore them if
+		// OVERRIDING USER VALUE is specified.
+		mb.checkIdentityColsForInsert(ins)
 	} else {
 		mb.buildInputForInsert(inScope, nil /* rows */)
 	}
@@ -231,6 +244,12 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	// the inserted columns.
 	mb.roundDecimalValues(mb.insertOrds, false /* roundComputedCols */)
 
+	// Run the BEFORE row-level triggers, which can replace the inserted values
+	// or skip rows. UPSERT statements cannot fire triggers.
+	if mb.op == opt.InsertOp {
+		mb.addBeforeTriggers()
+	}
+
 	// Add any computed columns. This includes columns undergoing write mutations,
 	// if they have a computed value.
 	mb.addComputedColsForInsert()
@@ -268,7 +287,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		if mb.needExistingRows() {
 			// Left-join each input row to the target table, using conflict columns
 			// derived from the primary index as the join condition.
-			mb.buildInputForUpsert(inScope, mb.getPrimaryKeyColumnNames(), nil /* whereClause */)
+			mb.buildInputForUpsert(inScope, mb.tab.Index(cat.PrimaryIndex), nil /* whereClause */)
 
 			// Add additional columns for computed expressions that may depend on any
 			// updated columns.
@@ -280,15 +299,20 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 
 	// Case 4: INSERT..ON CONFLICT..DO UPDATE statement.
 	default:
-		// Left-join each input row to the target table, using the conflict columns
-		// as the join condition.
-		mb.buildInputForUpsert(inScope, ins.OnConflict.Columns, ins.OnConflict.Where)
+		// Left-join each input row to the target table, using the columns of the
+		// arbiter index as the join condition. Check that the ON CONFLICT columns
+		// reference at most one target row. Using LEFT OUTER JOIN to detect
+		// conflicts relies upon this being true (otherwise result cardinality
+		// could increase). This is also a Postgres requirement.
+		arbiterIndex := mb.findArbiterIndex(ins.OnConflict)
+		mb.buildInputForUpsert(inScope, arbiterIndex, ins.OnConflict.Where)
 
 		// Derive the columns that will be updated from the SET expressions.
-		mb.addTargetColsForUpdate(ins.OnConflict.Exprs)
+		exprs := mb.rewriteFieldAssignments(ins.OnConflict.Exprs)
+		mb.addTargetColsForUpdate(exprs)
 
 		// Build each of the SET expressions.
-		mb.addUpdateCols(ins.OnConflict.Exprs)
+		mb.addUpdateCols(exprs)
 
 		// Build the final upsert statement, including any returned expressions.
 		mb.buildUpsert(returning)
@@ -346,6 +370,109 @@ func (mb *mutationBuilder) needExistingRows() bool {
 	return false
 }
 
+// rewriteFieldInsert rewrites an INSERT statement that assigns fields of
+// columns of composite types into an INSERT of whole columns. The fields that
+// are not assigned are NULL:
+//
+//   INSERT INTO t (k, p.x, p.z) VALUES (1, 2, 3)
+//
+// is rewritten into:
+//
+//   INSERT INTO t (k, p) VALUES (1, (2, NULL, 3))
+//
+// Other input expressions are wrapped into a projection:
+//
+//   INSERT INTO t (k, p) SELECT column1, (column2, NULL, column3)
+//   FROM (<input>) AS fields (column1, column2, column3)
+func (mb *mutationBuilder) rewriteFieldInsert(ins *tree.Insert) *tree.Insert {
+	if ins.ColumnFields == nil {
+		return ins
+	}
+
+	// project returns the values of the target columns given the values of
+	// the fields and columns named in the statement.
+	project := func(row tree.Exprs) tree.Exprs {
+		mb.checkNumCols(len(ins.Columns), len(row))
+		fa := fieldAssignments{mb: mb}
+		res := make(tree.Exprs, 0, len(row))
+		for i, colName := range ins.Columns {
+			if ins.ColumnFields[i] == "" {
+				res = append(res, row[i])
+				continue
+			}
+			if tuple, first := fa.add(colName, ins.ColumnFields[i], row[i]); first {
+				res = append(res, tuple)
+			}
+		}
+		fa.finish(func(_, _ tree.Name) tree.Expr { return tree.DNull })
+		return res
+	}
+
+	// A column can be assigned several fields, but not both a field and a
+	// whole value. Columns that are assigned several whole values are
+	// rejected by addTargetColsByName.
+	var cols, fieldCols tree.NameList
+	for i, colName := range ins.Columns {
+		if ins.ColumnFields[i] == "" {
+			if containsName(fieldCols, colName) {
+				panic(pgerror.Newf(pgerror.CodeSyntaxError,
+					"multiple assignments to the same column %q", colName))
+			}
+			cols = append(cols, colName)
+			continue
+		}
+		if !containsName(fieldCols, colName) {
+			if containsName(cols, colName) {
+				panic(pgerror.Newf(pgerror.CodeSyntaxError,
+					"multiple assignments to the same column %q", colName))
+			}
+			fieldCols = append(fieldCols, colName)
+			cols = append(cols, colName)
+		}
+	}
+
+	res := *ins
+	res.Columns, res.ColumnFields = cols, nil
+	if values := mb.extractValuesInput(ins.Rows); values != nil {
+		rows := make([]tree.Exprs, len(values.Rows))
+		for i := range values.Rows {
+			rows[i] = project(values.Rows[i])
+		}
+		res.Rows = &tree.Select{Select: &tree.ValuesClause{Rows: rows}}
+		return &res
+	}
+
+	inCols := make(tree.NameList, len(ins.Columns))
+	row := make(tree.Exprs, len(ins.Columns))
+	for i := range inCols {
+		inCols[i] = tree.Name(fmt.Sprintf("column%d", i+1))
+		row[i] = tree.NewUnresolvedName(string(inCols[i]))
+	}
+	exprs := project(row)
+	selExprs := make(tree.SelectExprs, len(exprs))
+	for i := range exprs {
+		selExprs[i] = tree.SelectExpr{Expr: exprs[i]}
+	}
+	res.Rows = &tree.Select{Select: &tree.SelectClause{
+		Exprs: selExprs,
+		From: tree.From{Tables: tree.TableExprs{&tree.AliasedTableExpr{
+			Expr: &tree.Subquery{Select: &tree.ParenSelect{Select: ins.Rows}},
+			As:   tree.AliasClause{Alias: "fields", Cols: inCols},
+		}}},
+	}}
+	return &res
+}
+
+// containsName returns whether the list contains the given name.
+func containsName(names tree.NameList, name tree.Name) bool {
+	for i := range names {
+		if names[i] == name {
+			return true
+		}
+	}
+	return false
+}
+
 // addTargetNamedColsForInsert adds a list of user-specified column names to the
 // list of table columns that are the target of the Insert operation.
 func (mb *mutationBuilder) addTargetNamedColsForInsert(names tree.NameList) {
@@ -531,7 +658,7 @@ func (mb *mutationBuilder) buildInputForInsert(inScope *scope, inputRows *tree.S
 		}
 	}
 
-	mb.outScope = mb.b.buildSelect(inputRows, desiredTypes, inScope)
+	mb.outScope = mb.b.buildSelect(inputRows, noRowLocking, desiredTypes, inScope)
 
 	if len(mb.targetColList) != 0 {
 		// Target columns already exist, so ensure that the number of input
@@ -565,6 +692,46 @@ func (mb *mutationBuilder) buildInputForInsert(inScope *scope, inputRows *tree.S
 	}
 }
 
+// checkIdentityColsForInsert raises an error if values are specified for an
+// identity column defined as GENERATED ALWAYS, unless the OVERRIDING SYSTEM
+// VALUE clause is specified. Only DEFAULT can be specified for such a column in
+// a VALUES clause:
+//
+//   INSERT INTO t (id, v) VALUES (DEFAULT, 1)
+//
+// With the OVERRIDING USER VALUE clause, the values specified for identity
+// columns are ignored, and the columns are removed from the target column list
+// so that they get their default values instead.
+func (mb *mutationBuilder) checkIdentityColsForInsert(ins *tree.Insert) {
+	values := mb.extractValuesInput(ins.Rows)
+
+	targetColList := mb.targetColList[:0]
+	for i, colID := range mb.targetColList {
+		ord := mb.tabID.ColumnOrdinal(colID)
+		tabCol := mb.tab.Column(ord)
+		switch {
+		case !tabCol.IsIdentity():
+
+		case ins.Overriding == tree.OverridingUserValue:
+			mb.insertOrds[ord] = -1
+			mb.targetColSet.Remove(int(colID))
+			continue
+
+		case tabCol.IsGeneratedAlwaysAsIdentity() && ins.Overriding != tree.OverridingSystemValue:
+			if values == nil {
+				panic(builderError{sqlbase.NewGeneratedAlwaysInsertError(string(tabCol.ColName()))})
+			}
+			for _, tuple := range values.Rows {
+				if _, ok := tuple[i].(tree.DefaultVal); !ok {
+					panic(builderError{sqlbase.NewGeneratedAlwaysInsertError(string(tabCol.ColName()))})
+				}
+			}
+		}
+		targetColList = append(targetColList, colID)
+	}
+	mb.targetColList = targetColList
+}
+
 // addDefaultColsForInsert wraps an Insert input expression with a Project
 // operator containing any default (or nullable) columns that are not yet part
 // of the target column list. This includes mutation columns, since they must
@@ -598,6 +765,9 @@ func (mb *mutationBuilder) buildInsert(returning tree.ReturningExprs) {
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols()
 
+	// Add the arguments of any AFTER row-level triggers to the input.
+	mb.addAfterTriggerCols()
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(mb.outScope.expr, private)
 
@@ -612,12 +782,12 @@ func (mb *mutationBuilder) buildInsert(returning tree.ReturningExprs) {
 func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tree.OnConflict) {
 	// DO NOTHING clause does not require ON CONFLICT columns.
 	var conflictIndex cat.Index
-	if len(onConflict.Columns) != 0 {
+	if len(onConflict.Columns) != 0 || onConflict.Constraint != "" {
 		// Check that the ON CONFLICT columns reference at most one target row by
 		// ensuring they match columns of a UNIQUE index. Using LEFT OUTER JOIN
 		// to detect conflicts relies upon this being true (otherwise result
 		// cardinality could increase). This is also a Postgres requirement.
-		conflictIndex = mb.ensureUniqueConflictCols(onConflict.Columns)
+		conflictIndex = mb.findArbiterIndex(onConflict)
 	}
 
 	insertColSet := mb.outScope.expr.Relational().OutputCols
@@ -630,6 +800,13 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tr
 			continue
 		}
 
+		// Partial indexes don't contain all the rows of the table, so they are
+		// only used to detect conflicts when they are the arbiter index.
+		_, isPartial := index.Predicate()
+		if isPartial && conflictIndex != index {
+			continue
+		}
+
 		// If conflict columns were explicitly specified, then only check for a
 		// conflict on a single index. Otherwise, check on all indexes.
 		if conflictIndex != nil && conflictIndex != index {
@@ -644,6 +821,7 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tr
 			&mb.alias,
 			nil, /* ordinals */
 			nil, /* indexFlags */
+			noRowLocking,
 			excludeMutations,
 			inScope,
 		)
@@ -671,6 +849,12 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tr
 			on = append(on, memo.FiltersItem{Condition: condition})
 		}
 
+		// A partial index only conflicts with the rows that satisfy its
+		// predicate, both on the insert side and on the scan side.
+		if isPartial {
+			mb.addPartialIndexPredicateToJoin(index, scanScope, &on)
+		}
+
 		// Construct the left join + filter.
 		// TODO(andyk): Convert this to use anti-join once we have support for
 		// lookup anti-joins.
@@ -699,20 +883,14 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tr
 }
 
 // buildInputForUpsert assumes that the output scope already contains the insert
-// columns. It left-joins each insert row to the target table, using the given
-// conflict columns as the join condition. It also selects one of the table
-// columns to be a "canary column" that can be tested to determine whether a
-// given insert row conflicts with an existing row in the table. If it is null,
-// then there is no conflict.
+// columns. It left-joins each insert row to the target table, using the key
+// columns of the given conflict index as the join condition. It also selects
+// one of the table columns to be a "canary column" that can be tested to
+// determine whether a given insert row conflicts with an existing row in the
+// table. If it is null, then there is no conflict.
 func (mb *mutationBuilder) buildInputForUpsert(
-	inScope *scope, conflictCols tree.NameList, whereClause *tree.Where,
+	inScope *scope, conflictIndex cat.Index, whereClause *tree.Where,
 ) {
-	// Check that the ON CONFLICT columns reference at most one target row.
-	// Using LEFT OUTER JOIN to detect conflicts relies upon this being true
-	// (otherwise result cardinality could increase). This is also a Postgres
-	// requirement.
-	mb.ensureUniqueConflictCols(conflictCols)
-
 	// Re-alias all INSERT columns so that they are accessible as if they were
 	// part of a special data source named "crdb_internal.excluded".
 	for i := range mb.outScope.cols {
@@ -727,6 +905,7 @@ func (mb *mutationBuilder) buildInputForUpsert(
 		&mb.alias,
 		nil, /* ordinals */
 		nil, /* indexFlags */
+		noRowLocking,
 		includeMutations,
 		inScope,
 	)
@@ -754,18 +933,19 @@ func (mb *mutationBuilder) buildInputForUpsert(
 	//   ON ins.x = scan.a AND ins.y = scan.b
 	//
 	var on memo.FiltersExpr
-	for _, name := range conflictCols {
-		for i := range fetchScope.cols {
-			fetchCol := &fetchScope.cols[i]
-			if fetchCol.name == name {
-				condition := mb.b.factory.ConstructEq(
-					mb.b.factory.ConstructVariable(mb.insertColID(i)),
-					mb.b.factory.ConstructVariable(fetchCol.id),
-				)
-				on = append(on, memo.FiltersItem{Condition: condition})
-				break
-			}
-		}
+	for i, n := 0, conflictIndex.LaxKeyColumnCount(); i < n; i++ {
+		ord := conflictIndex.Column(i).Ordinal
+		condition := mb.b.factory.ConstructEq(
+			mb.b.factory.ConstructVariable(mb.insertColID(ord)),
+			mb.b.factory.ConstructVariable(fetchScope.cols[ord].id),
+		)
+		on = append(on, memo.FiltersItem{Condition: condition})
+	}
+
+	// A partial index only conflicts with the rows that satisfy its predicate,
+	// both on the insert side and on the fetch side.
+	if _, isPartial := conflictIndex.Predicate(); isPartial {
+		mb.addPartialIndexPredicateToJoin(conflictIndex, fetchScope, &on)
 	}
 
 	// Construct the left join.
@@ -938,11 +1118,45 @@ func (mb *mutationBuilder) projectUpsertColumns() {
 	mb.outScope = projectionsScope
 }
 
+// findArbiterIndex returns the UNIQUE index that is used to detect conflicts
+// for the given ON CONFLICT clause, which is called the arbiter index. It is
+// either named by an ON CONSTRAINT clause, or inferred from the conflict
+// columns and the arbiter predicate. It reports an error if there is no such
+// index.
+func (mb *mutationBuilder) findArbiterIndex(onConflict *tree.OnConflict) cat.Index {
+	if onConflict.Constraint == "" {
+		return mb.ensureUniqueConflictCols(onConflict.Columns, onConflict.ArbiterPredicate)
+	}
+
+	// Unique constraints are implemented by UNIQUE indexes, which share their
+	// name.
+	for idx, idxCount := 0, mb.tab.IndexCount(); idx < idxCount; idx++ {
+		index := mb.tab.Index(idx)
+		if index.IsUnique() && index.Name() == onConflict.Constraint {
+			return index
+		}
+	}
+	panic(pgerror.Newf(pgerror.CodeUndefinedObjectError,
+		"constraint %q for table %q does not exist", onConflict.Constraint, mb.tab.Name().TableName))
+}
+
 // ensureUniqueConflictCols tries to prove that the given list of column names
 // correspond to the columns of at least one UNIQUE index on the target table.
 // If true, then ensureUniqueConflictCols returns the matching index. Otherwise,
 // it reports an error.
-func (mb *mutationBuilder) ensureUniqueConflictCols(cols tree.NameList) cat.Index {
+//
+// A partial UNIQUE index only matches if the given arbiter predicate implies
+// its predicate, since it only guarantees uniqueness over the rows that satisfy
+// it. Indexes that are not partial are preferred.
+func (mb *mutationBuilder) ensureUniqueConflictCols(
+	cols tree.NameList, arbiterPredicate tree.Expr,
+) cat.Index {
+	var arbiterFilters memo.FiltersExpr
+	if arbiterPredicate != nil {
+		arbiterFilters = mb.buildArbiterPredicate(arbiterPredicate)
+	}
+
+	var partialIndex cat.Index
 	for idx, idxCount := 0, mb.tab.IndexCount(); idx < idxCount; idx++ {
 		index := mb.tab.Index(idx)
 
@@ -961,22 +1175,99 @@ func (mb *mutationBuilder) ensureUniqueConflictCols(cols tree.NameList) cat.Inde
 				break
 			}
 		}
+		if !found {
+			continue
+		}
 
-		if found {
+		if _, isPartial := index.Predicate(); !isPartial {
 			return index
 		}
+		if partialIndex == nil && arbiterFilters != nil {
+			pred := mb.buildPartialIndexPredicate(index, mb.tabID.ColumnID)
+			if memo.FiltersImplyPredicate(mb.b.evalCtx, mb.b.factory.Memo(), arbiterFilters, pred) {
+				partialIndex = index
+			}
+		}
+	}
+	if partialIndex != nil {
+		return partialIndex
 	}
 	panic(pgerror.Newf(pgerror.CodeInvalidColumnReferenceError,
 		"there is no unique or exclusion constraint matching the ON CONFLICT specification"))
 }
 
-// getPrimaryKeyColumnNames returns the names of all primary key columns in the
-// target table.
-func (mb *mutationBuilder) getPrimaryKeyColumnNames() tree.NameList {
-	pkIndex := mb.tab.Index(cat.PrimaryIndex)
-	names := make(tree.NameList, pkIndex.KeyColumnCount())
-	for i, n := 0, pkIndex.KeyColumnCount(); i < n; i++ {
-		names[i] = pkIndex.Column(i).ColName()
+// buildArbiterPredicate builds the WHERE predicate of an ON CONFLICT clause,
+// which can only reference the columns of the target table. The predicate is
+// only used to select the arbiter index, so it is built over the columns of
+// the table metadata rather than over the columns of the mutation input. It
+// returns the conjuncts of the predicate.
+func (mb *mutationBuilder) buildArbiterPredicate(arbiterPredicate tree.Expr) memo.FiltersExpr {
+	// We need to save and restore the previous value of the field in
+	// semaCtx in case we are recursively called within a subquery
+	// context.
+	defer mb.b.semaCtx.Properties.Restore(mb.b.semaCtx.Properties)
+	mb.b.semaCtx.Properties.Require("ON CONFLICT", tree.RejectSpecial|tree.RejectSubqueries)
+
+	predScope := mb.tableColsScope(mb.alias, mb.tabID.ColumnID)
+	predScope.context = "ON CONFLICT"
+	texpr := predScope.resolveAndRequireType(arbiterPredicate, types.Bool)
+	pred := mb.b.buildScalar(texpr, predScope, nil, nil, nil)
+
+	conjuncts := memo.ExtractConjuncts(pred)
+	filters := make(memo.FiltersExpr, len(conjuncts))
+	for i := range conjuncts {
+		filters[i] = memo.FiltersItem{Condition: conjuncts[i]}
+	}
+	return filters
+}
+
+// buildPartialIndexPredicate builds the predicate of the given partial index
+// as a scalar expression that references the given columns, one for each
+// public column of the target table.
+func (mb *mutationBuilder) buildPartialIndexPredicate(
+	index cat.Index, colID func(ord int) opt.ColumnID,
+) opt.ScalarExpr {
+	predStr, _ := index.Predicate()
+	expr, err := parser.ParseExpr(predStr)
+	if err != nil {
+		panic(builderError{err})
+	}
+	predScope := mb.tableColsScope(*mb.tab.Name(), colID)
+	texpr := predScope.resolveAndRequireType(expr, types.Bool)
+	return mb.b.buildScalar(texpr, predScope, nil, nil, nil)
+}
+
+// addPartialIndexPredicateToJoin adds the predicate of the given partial
+// conflict index to the join condition that detects conflicts, once over the
+// insert columns and once over the columns of the given scan scope: a row that
+// doesn't satisfy the predicate is not in the index, so it can't conflict.
+func (mb *mutationBuilder) addPartialIndexPredicateToJoin(
+	index cat.Index, scanScope *scope, on *memo.FiltersExpr,
+) {
+	*on = append(*on,
+		memo.FiltersItem{Condition: mb.buildPartialIndexPredicate(index, mb.insertColID)},
+		memo.FiltersItem{Condition: mb.buildPartialIndexPredicate(
+			index, func(ord int) opt.ColumnID { return scanScope.cols[ord].id },
+		)},
+	)
+}
+
+// tableColsScope returns a new scope that contains one column for each public
+// column of the target table, with the given table name and column IDs.
+func (mb *mutationBuilder) tableColsScope(
+	tabName tree.TableName, colID func(ord int) opt.ColumnID,
+) *scope {
+	s := mb.b.allocScope()
+	s.cols = make([]scopeColumn, 0, mb.tab.ColumnCount())
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		tabCol := mb.tab.Column(i)
+		s.cols = append(s.cols, scopeColumn{
+			name:   tabCol.ColName(),
+			table:  tabName,
+			typ:    tabCol.DatumType(),
+			id:     colID(i),
+			hidden: tabCol.IsHidden(),
+		})
 	}
-	return names
+	return s
 }
@@ -0,0 +1,174 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// lockingSpec maintains the collection of FOR [KEY] UPDATE/SHARE items that
+// apply to a given scope. Locking clauses are added to the spec as they come
+// into scope in the AST, and the spec is consulted to determine the locking
+// mode to use when building each data source.
+type lockingSpec []*tree.LockingItem
+
+// noRowLocking indicates that no row-level locking has been specified.
+var noRowLocking lockingSpec
+
+// isSet returns whether the spec contains any row-level locking modes.
+func (lm lockingSpec) isSet() bool {
+	return len(lm) != 0
+}
+
+// get returns the first row-level locking mode in the spec. If the spec was
+// the outcome of a filter operation, this is the only locking mode in the
+// spec.
+func (lm lockingSpec) get() *tree.LockingItem {
+	if lm.isSet() {
+		return lm[0]
+	}
+	return nil
+}
+
+// strength returns the strongest locking strength in the spec.
+func (lm lockingSpec) strength() tree.LockingStrength {
+	var s tree.LockingStrength
+	for _, li := range lm {
+		s = s.Max(li.Strength)
+	}
+	return s
+}
+
+// apply merges the locking clause into the current locking spec. The clause
+// is appended to a copy of the spec, so specs that are shared with enclosing
+// scopes are never modified.
+func (lm *lockingSpec) apply(locking tree.LockingClause) {
+	if len(locking) == 0 {
+		return
+	}
+	merged := make(lockingSpec, 0, len(*lm)+len(locking))
+	merged = append(merged, *lm...)
+	*lm = append(merged, locking...)
+}
+
+// filter returns the row-level locking mode for the table with the given alias
+// as a new, consolidated lockingSpec. If no locking item applies to the table,
+// the resulting spec is not set. Otherwise, it contains exactly one locking
+// item with no targets, so it applies to every data source that it is passed
+// to (e.g. the tables underneath a view).
+//
+// As in Postgres, if the same table is affected by more than one locking item,
+// it is processed as if it was only specified by the strongest one. Similarly,
+// the table is processed as NOWAIT if that is specified by any of the items,
+// and otherwise as SKIP LOCKED if that is specified by any of the items.
+func (lm lockingSpec) filter(alias tree.Name) lockingSpec {
+	var ret *tree.LockingItem
+	for _, li := range lm {
+		if !lockingItemAppliesTo(li, alias) {
+			continue
+		}
+		if ret == nil {
+			ret = &tree.LockingItem{}
+		}
+		ret.Strength = ret.Strength.Max(li.Strength)
+		ret.WaitPolicy = ret.WaitPolicy.Max(li.WaitPolicy)
+	}
+	if ret == nil {
+		return noRowLocking
+	}
+	return lockingSpec{ret}
+}
+
+// withoutTargets returns a new lockingSpec that only contains the locking
+// items which apply to all tables.
+func (lm lockingSpec) withoutTargets() lockingSpec {
+	return lm.filter("")
+}
+
+// raiseLockingContextError raises an error indicating that a row-level locking
+// clause is not permitted in the specified context.
+func (lm lockingSpec) raiseLockingContextError(context string) {
+	panic(pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+		"%s is not allowed with %s", lm.strength(), context))
+}
+
+// validateLockingInFrom checks for operations that are not supported with
+// FOR [KEY] UPDATE/SHARE, and that every relation named in a locking clause
+// refers to a data source in the FROM clause.
+func (b *Builder) validateLockingInFrom(
+	sel *tree.SelectClause, needsAgg bool, locking lockingSpec, fromScope *scope,
+) {
+	if !locking.isSet() {
+		return
+	}
+
+	switch {
+	case sel.Distinct:
+		locking.raiseLockingContextError("DISTINCT clause")
+
+	case len(sel.GroupBy) != 0:
+		locking.raiseLockingContextError("GROUP BY clause")
+
+	case sel.Having != nil:
+		locking.raiseLockingContextError("HAVING clause")
+
+	case needsAgg:
+		locking.raiseLockingContextError("aggregate functions")
+
+	case len(fromScope.windows) != 0:
+		locking.raiseLockingContextError("window functions")
+
+	case len(fromScope.srfs) != 0:
+		locking.raiseLockingContextError("set-returning functions in the target list")
+	}
+
+	for _, li := range locking {
+		for i := range li.Targets {
+			target := &li.Targets[i]
+			// Insist on unqualified relation names, like Postgres does.
+			if target.ExplicitCatalog || target.ExplicitSchema {
+				panic(pgerror.Newf(pgerror.CodeSyntaxError,
+					"%s must specify unqualified relation names", li.Strength))
+			}
+
+			found := false
+			for j := range fromScope.cols {
+				if fromScope.cols[j].table.TableName == target.TableName {
+					found = true
+					break
+				}
+			}
+			if !found {
+				panic(pgerror.Newf(pgerror.CodeUndefinedTableError,
+					"relation %q in %s clause not found in FROM clause",
+					tree.ErrString(&target.TableName), li.Strength))
+			}
+		}
+	}
+}
+
+// lockingItemAppliesTo returns whether the locking item applies to the data
+// source with the given alias. Items without targets apply to every data
+// source.
+func lockingItemAppliesTo(li *tree.LockingItem, alias tree.Name) bool {
+	if len(li.Targets) == 0 {
+		return true
+	}
+	for i := range li.Targets {
+		if li.Targets[i].TableName == alias {
+			return true
+		}
+	}
+	return false
+}
@@ -52,9 +52,9 @@ type mutationBuilder struct {
 	// expression is completed, it will be contained in outScope.expr. Columns,
 	// when present, are arranged in this order:
 	//
-	//   +--------+-------+--------+--------+-------+
-	//   | Insert | Fetch | Update | Upsert | Check |
-	//   +--------+-------+--------+--------+-------+
+	//   +--------+-------+--------+--------+-------+---------+
+	//   | Insert | Fetch | Update | Upsert | Check | Trigger |
+	//   +--------+-------+--------+--------+-------+---------+
 	//
 	// Each column is identified by its ordinal position in outScope, and those
 	// ordinals are stored in the corresponding ScopeOrds fields (see below).
@@ -107,6 +107,13 @@ type mutationBuilder struct {
 	// (see opt.Table.CheckCount).
 	checkOrds []scopeOrdinal
 
+	// triggerOrds lists the outScope columns storing the tuples of values passed
+	// to the AFTER row-level triggers fired by each row. Its length is always
+	// equal to the number of triggers defined on the target table (see
+	// opt.Table.TriggerCount). Triggers that are not fired by the mutation are
+	// set to -1.
+	triggerOrds []scopeOrdinal
+
 	// canaryColID is the ID of the column that is used to decide whether to
 	// insert or update each row. If the canary column's value is null, then it's
 	// an insert; otherwise it's an update.
@@ -132,7 +139,7 @@ func (mb *mutationBuilder) init(b *Builder, op opt.Operator, tab cat.Table, alia
 
 	// Allocate segmented array of scope column ordinals.
 	n := tab.DeletableColumnCount()
-	scopeOrds := make([]scopeOrdinal, n*4+tab.CheckCount())
+	scopeOrds := make([]scopeOrdinal, n*4+tab.CheckCount()+tab.TriggerCount())
 	for i := range scopeOrds {
 		scopeOrds[i] = -1
 	}
@@ -140,7 +147,8 @@ func (mb *mutationBuilder) init(b *Builder, op opt.Operator, tab cat.Table, alia
 	mb.fetchOrds = scopeOrds[n : n*2]
 	mb.updateOrds = scopeOrds[n*2 : n*3]
 	mb.upsertOrds = scopeOrds[n*3 : n*4]
-	mb.checkOrds = scopeOrds[n*4:]
+	mb.checkOrds = scopeOrds[n*4 : n*4+tab.CheckCount()]
+	mb.triggerOrds = scopeOrds[n*4+tab.CheckCount():]
 
 	// Add the table and its columns (including mutation columns) to metadata.
 	mb.tabID = mb.md.AddTableWithAlias(tab, &mb.alias)
@@ -166,15 +174,21 @@ func (mb *mutationBuilder) insertColID(tabOrd int) opt.ColumnID {
 // the Update or Delete operator, similar to this:
 //
 //   SELECT <cols>
-//   FROM <table>
+//   FROM <table> [, <from>]
 //   WHERE <where>
 //   ORDER BY <order-by>
 //   LIMIT <limit>
 //
 // All columns from the table to update are added to fetchColList.
+//
+// The from tables are only used by UPDATE ... FROM. They are joined with the
+// table to update, and their columns can be referenced by the WHERE clause,
+// the ORDER BY clause and the SET expressions. A row of the table to update
+// can match several rows of the from tables, but it is only updated once: like
+// Postgres, the first matching row wins (see buildDistinctOnPrimaryKey).
 // TODO(andyk): Do needed column analysis to project fewer columns if possible.
 func (mb *mutationBuilder) buildInputForUpdateOrDelete(
-	inScope *scope, where *tree.Where, limit *tree.Limit, orderBy tree.OrderBy,
+	inScope *scope, from tree.TableExprs, where *tree.Where, limit *tree.Limit, orderBy tree.OrderBy,
 ) {
 	// Fetch columns from different instance of the table metadata, so that it's
 	// possible to remap columns, as in this example:
@@ -188,13 +202,39 @@ func (mb *mutationBuilder) buildInputForUpdateOrDelete(
 		&mb.alias,
 		nil, /* ordinals */
 		nil, /* indexFlags */
+		noRowLocking,
 		includeMutations,
 		inScope,
 	)
 
+	if len(from) > 0 {
+		fromScope := mb.b.buildFromTables(from, noRowLocking, inScope)
+
+		// The table to update can't be referenced by the same name in the from
+		// tables.
+		mb.b.validateJoinTableNames(mb.outScope, fromScope)
+
+		// The columns of the table to update remain first in the scope, so that
+		// the fetch columns have the same ordinals as without from tables.
+		joinScope := mb.outScope.replace()
+		joinScope.appendColumnsFromScope(mb.outScope)
+		joinScope.appendColumnsFromScope(fromScope)
+		joinScope.expr = mb.b.factory.ConstructInnerJoin(
+			mb.outScope.expr.(memo.RelExpr),
+			fromScope.expr.(memo.RelExpr),
+			memo.TrueFilter,
+			memo.EmptyJoinPrivate,
+		)
+		mb.outScope = joinScope
+	}
+
 	// WHERE
 	mb.b.buildWhere(where, mb.outScope)
 
+	if len(from) > 0 {
+		mb.buildDistinctOnPrimaryKey()
+	}
+
 	// SELECT + ORDER BY (which may add projected expressions)
 	projectionsScope := mb.outScope.replace()
 	projectionsScope.appendColumnsFromScope(mb.outScope)
@@ -209,12 +249,49 @@ func (mb *mutationBuilder) buildInputForUpdateOrDelete(
 
 	mb.outScope = projectionsScope
 
-	// Set list of columns that will be fetched by the input expression.
-	for i := range mb.outScope.cols {
+	// Set list of columns that will be fetched by the input expression. These
+	// are the first columns in scope, followed by the columns of the from
+	// tables, if any.
+	for i := range mb.fetchOrds {
 		mb.fetchOrds[i] = scopeOrdinal(i)
 	}
 }
 
+// buildDistinctOnPrimaryKey wraps the join of the table to update with the
+// from tables of an UPDATE ... FROM statement in a DistinctOn operator that
+// groups on the primary key of the table to update. This ensures that each
+// row of the table is updated at most once, using the values of the first
+// matching row of the from tables, like Postgres does:
+//
+//   UPDATE abc SET b=x FROM xyz WHERE a=y
+//   =>
+//   SELECT DISTINCT ON (a) a, b, c, x, y, z FROM abc, xyz WHERE a=y
+//
+// Which of the matching rows is used is unspecified.
+func (mb *mutationBuilder) buildDistinctOnPrimaryKey() {
+	var pkCols opt.ColSet
+	primary := mb.tab.Index(cat.PrimaryIndex)
+	for i, n := 0, primary.KeyColumnCount(); i < n; i++ {
+		pkCols.Add(int(mb.outScope.cols[primary.Column(i).Ordinal].id))
+	}
+
+	// Build FirstAgg for all the other columns, including the hidden ones.
+	aggs := make(memo.AggregationsExpr, 0, len(mb.outScope.cols))
+	excluded := pkCols.Copy()
+	for i := range mb.outScope.cols {
+		if id := mb.outScope.cols[i].id; !excluded.Contains(int(id)) {
+			excluded.Add(int(id))
+			aggs = append(aggs, memo.AggregationsItem{
+				Agg:        mb.b.factory.ConstructFirstAgg(mb.b.factory.ConstructVariable(id)),
+				ColPrivate: memo.ColPrivate{Col: id},
+			})
+		}
+	}
+
+	private := memo.GroupingPrivate{GroupingCols: pkCols}
+	mb.outScope.expr = mb.b.factory.ConstructDistinctOn(mb.outScope.expr.(memo.RelExpr), aggs, &private)
+}
+
 // addTargetColsByName adds one target column for each of the names in the given
 // list.
 func (mb *mutationBuilder) addTargetColsByName(names tree.NameList) {
@@ -256,6 +333,144 @@ func (mb *mutationBuilder) addTargetCol(ord int) {
 	mb.targetColList = append(mb.targetColList, colID)
 }
 
+// findTargetField returns the ordinal position of the named column of
+// composite type in the target table, and the position of the named field in
+// that type. It raises an error if the column or the field doesn't exist.
+func (mb *mutationBuilder) findTargetField(colName, fieldName tree.Name) (ord, field int) {
+	ord = cat.FindTableColumnByName(mb.tab, colName)
+	if ord == -1 {
+		panic(builderError{sqlbase.NewUndefinedColumnError(string(colName))})
+	}
+	typ := mb.tab.Column(ord).DatumType()
+	if typ.CompositeData() == nil {
+		panic(pgerror.Newf(pgerror.CodeDatatypeMismatchError,
+			"cannot assign to field %q of column %q because its type %s is not a composite type",
+			fieldName, colName, typ.SQLString()))
+	}
+	for i, label := range typ.TupleLabels() {
+		if label == string(fieldName) {
+			return ord, i
+		}
+	}
+	panic(pgerror.Newf(pgerror.CodeUndefinedColumnError,
+		"cannot assign to field %q of column %q because there is no such column in data type %s",
+		fieldName, colName, typ.SQLString()))
+}
+
+// fieldAssignments builds the values of the columns of composite types whose
+// fields are assigned by an INSERT or by the SET expressions of an UPDATE. For
+// each such column, a tuple expression is built with the values assigned to
+// its fields. The other fields are filled in by finish.
+type fieldAssignments struct {
+	mb     *mutationBuilder
+	tuples map[tree.Name]*tree.Tuple
+	// names lists the columns in tuples, in the order in which they were
+	// first assigned.
+	names tree.NameList
+}
+
+// add records the assignment of expr to the given field of the given column.
+// It returns the tuple expression of the column, and whether this is the
+// first field of the column to be assigned.
+func (fa *fieldAssignments) add(colName, fieldName tree.Name, expr tree.Expr) (*tree.Tuple, bool) {
+	ord, field := fa.mb.findTargetField(colName, fieldName)
+	if _, ok := expr.(tree.DefaultVal); ok {
+		panic(pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+			"cannot set field %q of column %q to DEFAULT", fieldName, colName))
+	}
+	if fa.tuples == nil {
+		fa.tuples = make(map[tree.Name]*tree.Tuple)
+	}
+	tuple, ok := fa.tuples[colName]
+	if !ok {
+		typ := fa.mb.tab.Column(ord).DatumType()
+		tuple = &tree.Tuple{Exprs: make(tree.Exprs, len(typ.TupleContents()))}
+		fa.tuples[colName] = tuple
+		fa.names = append(fa.names, colName)
+	}
+	if tuple.Exprs[field] != nil {
+		panic(pgerror.Newf(pgerror.CodeSyntaxError,
+			"multiple assignments to the same column %q", colName))
+	}
+	tuple.Exprs[field] = expr
+	return tuple, !ok
+}
+
+// finish sets the fields that were not assigned to the expressions returned by
+// fill, given the name of the column and the name of the field.
+func (fa *fieldAssignments) finish(fill func(colName, fieldName tree.Name) tree.Expr) {
+	for _, colName := range fa.names {
+		tuple := fa.tuples[colName]
+		typ := fa.mb.tab.Column(cat.FindTableColumnByName(fa.mb.tab, colName)).DatumType()
+		for i := range tuple.Exprs {
+			if tuple.Exprs[i] == nil {
+				tuple.Exprs[i] = fill(colName, tree.Name(typ.TupleLabels()[i]))
+			}
+		}
+	}
+}
+
+// rewriteFieldAssignments rewrites the SET expressions that assign fields of
+// columns of composite types into assignments of whole columns. The fields that
+// are not assigned keep their current values:
+//
+//   UPDATE t SET p.x = 1, p.z = 2
+//
+// is rewritten into:
+//
+//   UPDATE t SET p = (1, (t.p).y, 2)
+//
+// If the current value of the column is NULL, the fields that are not assigned
+// are NULL.
+func (mb *mutationBuilder) rewriteFieldAssignments(exprs tree.UpdateExprs) tree.UpdateExprs {
+	var fields bool
+	for _, set := range exprs {
+		fields = fields || set.Fields != nil
+	}
+	if !fields {
+		return exprs
+	}
+
+	fa := fieldAssignments{mb: mb}
+	res := make(tree.UpdateExprs, 0, len(exprs))
+	for _, set := range exprs {
+		if set.Fields == nil {
+			res = append(res, set)
+			continue
+		}
+		values := tree.Exprs{set.Expr}
+		if set.Tuple {
+			t, ok := set.Expr.(*tree.Tuple)
+			if !ok {
+				panic(unimplementedWithIssueDetailf(27792, fmt.Sprintf("%T", set.Expr),
+					"source for a multiple-column UPDATE item that assigns fields must be a ROW() expression; not supported: %T", set.Expr))
+			}
+			if len(t.Exprs) != len(set.Names) {
+				panic(pgerror.Newf(pgerror.CodeSyntaxError,
+					"number of columns (%d) does not match number of values (%d)",
+					len(set.Names), len(t.Exprs)))
+			}
+			values = t.Exprs
+		}
+		for i, colName := range set.Names {
+			if set.Fields[i] == "" {
+				res = append(res, &tree.UpdateExpr{Names: tree.NameList{colName}, Expr: values[i]})
+				continue
+			}
+			if tuple, first := fa.add(colName, set.Fields[i], values[i]); first {
+				res = append(res, &tree.UpdateExpr{Names: tree.NameList{colName}, Expr: tuple})
+			}
+		}
+	}
+	fa.finish(func(colName, fieldName tree.Name) tree.Expr {
+		return &tree.ColumnAccessExpr{
+			Expr:    tree.NewUnresolvedName(string(mb.alias.TableName), string(colName)),
+			ColName: string(fieldName),
+		}
+	})
+	return res
+}
+
 // extractValuesInput tests whether the given input is a VALUES clause with no
 // WITH, ORDER BY, or LIMIT modifier. If so, it's returned, otherwise nil is
 // returned.
@@ -482,8 +697,8 @@ func (mb *mutationBuilder) roundDecimalValues(scopeOrds []scopeOrdinal, roundCom
 // If an input decimal value has more than the required number of fractional
 // digits, it must be rounded before being inserted into these types.
 //
-// NOTE: CRDB does not allow nested array storage types, so only one level of
-// array nesting needs to be checked.
+// NOTE: only one level of array nesting is checked, so the values of
+// multi-dimensional DECIMAL arrays are not rounded.
 func findRoundingFunction(typ *types.T, precision int) (*tree.FunctionProperties, *tree.Overload) {
 	if precision == 0 {
 		// Unlimited precision decimal target type never needs rounding.
@@ -579,12 +794,13 @@ func (mb *mutationBuilder) makeMutationPrivate(needResults bool) *memo.MutationP
 	}
 
 	private := &memo.MutationPrivate{
-		Table:      mb.tabID,
-		InsertCols: makeColList(mb.insertOrds),
-		FetchCols:  makeColList(mb.fetchOrds),
-		UpdateCols: makeColList(mb.updateOrds),
-		CanaryCol:  mb.canaryColID,
-		CheckCols:  makeColList(mb.checkOrds),
+		Table:       mb.tabID,
+		InsertCols:  makeColList(mb.insertOrds),
+		FetchCols:   makeColList(mb.fetchOrds),
+		UpdateCols:  makeColList(mb.updateOrds),
+		CanaryCol:   mb.canaryColID,
+		CheckCols:   makeColList(mb.checkOrds),
+		TriggerCols: makeColList(mb.triggerOrds),
 	}
 
 	if needResults {
@@ -736,6 +952,246 @@ func (mb *mutationBuilder) parseDefaultOrComputedExpr(colID opt.ColumnID) tree.E
 	return expr
 }
 
+// triggerEvent returns the trigger event corresponding to the mutation
+// operator.
+func (mb *mutationBuilder) triggerEvent() tree.TriggerEvent {
+	switch mb.op {
+	case opt.InsertOp:
+		return tree.TriggerInsert
+	case opt.UpdateOp:
+		return tree.TriggerUpdate
+	case opt.DeleteOp:
+		return tree.TriggerDelete
+	default:
+		panic(pgerror.AssertionFailedf("unexpected mutation operator %s", mb.op))
+	}
+}
+
+// checkNoTriggers raises an error if the target table has triggers. It is used
+// by the mutations that cannot fire triggers yet.
+func (mb *mutationBuilder) checkNoTriggers() {
+	if mb.tab.TriggerCount() > 0 {
+		panic(pgerror.UnimplementedWithIssueDetailf(28296, "upsert",
+			"UPSERT and INSERT ... ON CONFLICT DO UPDATE are not supported on tables with triggers"))
+	}
+}
+
+// addBeforeTriggers wraps the input expression with the BEFORE row-level
+// triggers fired by the mutation, in the order in which they fire. Each trigger
+// is a function of the values of the affected row that returns the values of
+// the columns it replaces, or NULL if the row must be skipped:
+//
+//   SELECT ..., (t1).a AS a
+//   FROM (SELECT ..., t1(a, b) AS t1 FROM <input>)
+//   WHERE t1 IS NOT NULL
+//
+// The new values returned by a trigger are seen by the next triggers, and are
+// used to compute the computed columns and the check constraints.
+func (mb *mutationBuilder) addBeforeTriggers() {
+	event := mb.triggerEvent()
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trig := mb.tab.Trigger(i)
+		if trig.IsBefore() && trig.ForEachRow() && trig.FiresOn(event) {
+			mb.addBeforeTrigger(trig)
+		}
+	}
+}
+
+// addBeforeTrigger wraps the input expression with a BEFORE row-level trigger.
+// See addBeforeTriggers.
+func (mb *mutationBuilder) addBeforeTrigger(trig cat.Trigger) {
+	props, overload := trig.Function()
+	typ := overload.ReturnType(nil /* args */)
+	args := make(memo.ScalarListExpr, trig.ArgCount())
+	for i := range args {
+		args[i] = mb.buildTriggerArg(trig.Arg(i))
+	}
+	private := &memo.FunctionPrivate{
+		Name:       string(trig.Name()),
+		Typ:        typ,
+		Properties: props,
+		Overload:   overload,
+	}
+	fn := mb.b.factory.ConstructFunction(args, private)
+
+	// If the WHEN condition is not satisfied, the trigger doesn't run and the
+	// new values are left unchanged.
+	if when := mb.buildTriggerWhen(trig); when != nil {
+		elems := make(memo.ScalarListExpr, trig.ResultColumnCount())
+		for i := range elems {
+			elems[i] = mb.buildTriggerArg(cat.TriggerArg{Ordinal: trig.ResultColumnOrdinal(i), New: true})
+		}
+		fn = mb.b.factory.ConstructCase(
+			memo.TrueSingleton,
+			memo.ScalarListExpr{mb.b.factory.ConstructWhen(when, fn)},
+			mb.b.factory.ConstructTuple(elems, typ),
+		)
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	alias := fmt.Sprintf("trigger_%s", trig.Name())
+	resultCol := mb.b.synthesizeColumn(projectionsScope, alias, typ, nil /* expr */, fn)
+	resultCol.clearName()
+	resultColID := resultCol.id
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Skip the rows for which the trigger returns NULL.
+	mb.outScope.expr = mb.b.factory.ConstructSelect(
+		mb.outScope.expr,
+		memo.FiltersExpr{memo.FiltersItem{
+			Condition: mb.b.factory.ConstructIsNot(
+				mb.b.factory.ConstructVariable(resultColID),
+				memo.NullSingleton,
+			),
+		}},
+	)
+
+	// A DELETE has no new values to replace.
+	if trig.ResultColumnCount() == 0 || mb.op == opt.DeleteOp {
+		return
+	}
+
+	scopeOrds := mb.insertOrds
+	if mb.op == opt.UpdateOp {
+		scopeOrds = mb.updateOrds
+	}
+	projectionsScope = mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	for i, n := 0, trig.ResultColumnCount(); i < n; i++ {
+		ord := trig.ResultColumnOrdinal(i)
+		tabCol := mb.tab.Column(ord)
+		access := mb.b.factory.ConstructColumnAccess(
+			mb.b.factory.ConstructVariable(resultColID), memo.TupleOrdinal(i),
+		)
+		scopeCol := mb.b.synthesizeColumn(
+			projectionsScope, string(tabCol.ColName()), tabCol.DatumType(), nil /* expr */, access,
+		)
+		scopeCol.table = *mb.tab.Name()
+
+		// The replaced column can no longer be referenced by name. A column that
+		// is not yet updated becomes a target column.
+		if prevOrd := scopeOrds[ord]; prevOrd != -1 {
+			projectionsScope.cols[prevOrd].clearName()
+		} else {
+			colID := mb.tabID.ColumnID(ord)
+			mb.targetColList = append(mb.targetColList, colID)
+			mb.targetColSet.Add(int(colID))
+		}
+		scopeOrds[ord] = scopeOrdinal(len(projectionsScope.cols) - 1)
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
+
+// addAfterTriggerCols synthesizes a column for each AFTER row-level trigger
+// fired by the mutation. The value of the column is the tuple of the values of
+// the affected row that are passed to the trigger, or NULL if the WHEN
+// condition of the trigger is not satisfied. The mutation operator runs the
+// triggers once the rows are written.
+func (mb *mutationBuilder) addAfterTriggerCols() {
+	event := mb.triggerEvent()
+	var projectionsScope *scope
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trig := mb.tab.Trigger(i)
+		if trig.IsBefore() || !trig.ForEachRow() || !trig.FiresOn(event) {
+			continue
+		}
+
+		contents := make([]types.T, trig.ArgCount())
+		elems := make(memo.ScalarListExpr, trig.ArgCount())
+		for j := range elems {
+			arg := trig.Arg(j)
+			contents[j] = *mb.tab.Column(arg.Ordinal).DatumType()
+			elems[j] = mb.buildTriggerArg(arg)
+		}
+		typ := types.MakeTuple(contents)
+		var args opt.ScalarExpr = mb.b.factory.ConstructTuple(elems, typ)
+		if when := mb.buildTriggerWhen(trig); when != nil {
+			args = mb.b.factory.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{mb.b.factory.ConstructWhen(when, args)},
+				mb.b.factory.ConstructNull(typ),
+			)
+		}
+
+		if projectionsScope == nil {
+			projectionsScope = mb.outScope.replace()
+			projectionsScope.appendColumnsFromScope(mb.outScope)
+		}
+		alias := fmt.Sprintf("trigger_%s", trig.Name())
+		scopeCol := mb.b.synthesizeColumn(projectionsScope, alias, typ, nil /* expr */, args)
+		scopeCol.clearName()
+		mb.triggerOrds[i] = scopeOrdinal(len(projectionsScope.cols) - 1)
+	}
+
+	if projectionsScope != nil {
+		mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+		mb.outScope = projectionsScope
+	}
+}
+
+// buildTriggerArg builds a scalar expression for the given value of the
+// affected row. The new values of a deleted row and the old values of an
+// inserted row are NULL, as are the new values that are not computed yet.
+func (mb *mutationBuilder) buildTriggerArg(arg cat.TriggerArg) opt.ScalarExpr {
+	if colID := mb.triggerArgColID(arg); colID != 0 {
+		return mb.b.factory.ConstructVariable(colID)
+	}
+	return mb.b.factory.ConstructNull(mb.tab.Column(arg.Ordinal).DatumType())
+}
+
+// triggerArgColID returns the ID of the input column that provides the given
+// value of the affected row, or 0 if the value is NULL.
+func (mb *mutationBuilder) triggerArgColID(arg cat.TriggerArg) opt.ColumnID {
+	switch {
+	case arg.New && mb.op == opt.DeleteOp, !arg.New && mb.op == opt.InsertOp:
+		return 0
+	case arg.New:
+		return mb.scopeOrdToColID(mb.mapToReturnScopeOrd(arg.Ordinal))
+	default:
+		return mb.scopeOrdToColID(mb.fetchOrds[arg.Ordinal])
+	}
+}
+
+// buildTriggerWhen builds the WHEN condition of a row-level trigger, or returns
+// nil if the trigger has no condition. The condition references the values of
+// the affected row as NEW.<column> and OLD.<column>.
+func (mb *mutationBuilder) buildTriggerWhen(trig cat.Trigger) opt.ScalarExpr {
+	if trig.When() == "" {
+		return nil
+	}
+	expr, err := parser.ParseExpr(trig.When())
+	if err != nil {
+		panic(builderError{err})
+	}
+
+	defer mb.b.semaCtx.Properties.Restore(mb.b.semaCtx.Properties)
+	mb.b.semaCtx.Properties.Require("WHEN", tree.RejectSpecial|tree.RejectSubqueries)
+
+	whenScope := mb.tableColsScope(tree.MakeUnqualifiedTableName("new"), func(ord int) opt.ColumnID {
+		return mb.triggerArgColID(cat.TriggerArg{Ordinal: ord, New: true})
+	})
+	oldScope := mb.tableColsScope(tree.MakeUnqualifiedTableName("old"), func(ord int) opt.ColumnID {
+		return mb.triggerArgColID(cat.TriggerArg{Ordinal: ord, New: false})
+	})
+	whenScope.cols = append(whenScope.cols, oldScope.cols...)
+
+	// Values that are always NULL are not columns of the input.
+	cols := whenScope.cols[:0]
+	for i := range whenScope.cols {
+		if whenScope.cols[i].id != 0 {
+			cols = append(cols, whenScope.cols[i])
+		}
+	}
+	whenScope.cols = cols
+	whenScope.context = "WHEN"
+
+	texpr := whenScope.resolveAndRequireType(expr, types.Bool)
+	return mb.b.buildScalar(texpr, whenScope, nil, nil, nil)
+}
+
 // findNotNullIndexCol finds the first not-null column in the given index and
 // returns its ordinal position in the owner table. There must always be such a
 // column, even if it turns out to be an implicit primary key column.
@@ -98,6 +98,11 @@ type cteSource struct {
 	// to only having a single reference to a given CTE, so if this is set then
 	// this CTE has already been referenced and may not be referenced again.
 	used bool
+
+	// onRef, if set, is called to build each reference to the CTE in place of
+	// expr. It is used while building the terms of a recursive CTE, where a
+	// reference either scans the working table or is an error.
+	onRef func(inScope *scope) (outScope *scope)
 }
 
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
@@ -830,7 +835,7 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 		return false, colI.(*scopeColumn)
 
 	case *tree.FuncExpr:
-		def, err := t.Func.Resolve(s.builder.semaCtx.SearchPath)
+		def, err := t.Func.ResolveInContext(s.builder.semaCtx)
 		if err != nil {
 			panic(builderError{err})
 		}
@@ -889,6 +894,11 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 			return false, expr
 		}
 
+		if s.builder.semaCtx.Properties.IsSet(tree.RejectSubqueries) {
+			panic(pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+				"subqueries are not allowed in %s", s.context))
+		}
+
 		if t.Exists {
 			expr = s.replaceSubquery(
 				t, true /* wrapInTuple */, -1 /* desiredNumColumns */, noExtraColsAllowed,
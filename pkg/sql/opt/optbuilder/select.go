@@ -43,7 +43,7 @@ const (
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
 func (b *Builder) buildDataSource(
-	texpr tree.TableExpr, indexFlags *tree.IndexFlags, inScope *scope,
+	texpr tree.TableExpr, indexFlags *tree.IndexFlags, locking lockingSpec, inScope *scope,
 ) (outScope *scope) {
 	// NB: The case statements are sorted lexicographically.
 	switch source := texpr.(type) {
@@ -52,8 +52,13 @@ func (b *Builder) buildDataSource(
 			telemetry.Inc(sqltelemetry.IndexHintUseCounter)
 			indexFlags = source.IndexFlags
 		}
+		if source.As.Alias != "" {
+			// Row-level locking clauses refer to aliased data sources by their
+			// alias.
+			locking = locking.filter(source.As.Alias)
+		}
 
-		outScope = b.buildDataSource(source.Expr, indexFlags, inScope)
+		outScope = b.buildDataSource(source.Expr, indexFlags, locking, inScope)
 
 		if source.Ordinality {
 			outScope = b.buildWithOrdinality("ordinality", outScope)
@@ -65,13 +70,16 @@ func (b *Builder) buildDataSource(
 		return outScope
 
 	case *tree.JoinTableExpr:
-		return b.buildJoin(source, inScope)
+		return b.buildJoin(source, locking, inScope)
 
 	case *tree.TableName:
 		tn := source
 
 		// CTEs take precedence over other data sources.
 		if cte := inScope.resolveCTE(tn); cte != nil {
+			if cte.onRef != nil {
+				return cte.onRef(inScope)
+			}
 			if cte.used {
 				panic(unimplementedWithIssueDetailf(21084, "", "unsupported multiple use of CTE clause %q", tn))
 			}
@@ -87,11 +95,14 @@ func (b *Builder) buildDataSource(
 		}
 
 		ds, resName := b.resolveDataSource(tn, privilege.SELECT)
+		locking = locking.filter(tn.TableName)
 		switch t := ds.(type) {
 		case cat.Table:
-			return b.buildScan(t, &resName, nil /* ordinals */, indexFlags, excludeMutations, inScope)
+			return b.buildScan(
+				t, &resName, nil /* ordinals */, indexFlags, locking, excludeMutations, inScope,
+			)
 		case cat.View:
-			return b.buildView(t, inScope)
+			return b.buildView(t, locking, inScope)
 		case cat.Sequence:
 			return b.buildSequenceSelect(t, inScope)
 		default:
@@ -99,13 +110,18 @@ func (b *Builder) buildDataSource(
 		}
 
 	case *tree.ParenTableExpr:
-		return b.buildDataSource(source.Expr, indexFlags, inScope)
+		return b.buildDataSource(source.Expr, indexFlags, locking, inScope)
 
 	case *tree.RowsFromExpr:
 		return b.buildZip(source.Items, inScope)
 
 	case *tree.Subquery:
-		outScope = b.buildStmt(source.Select, nil /* desiredTypes */, inScope)
+		// Row-level locking clauses of the enclosing statement also apply to the
+		// tables in the subquery, unless they are restricted to specific tables
+		// (which are only visible in the enclosing statement).
+		outScope = b.buildSelectStmt(
+			source.Select, locking.withoutTargets(), nil /* desiredTypes */, inScope,
+		)
 
 		// Treat the subquery result as an anonymous data source (i.e. column names
 		// are not qualified). Remove hidden columns, as they are not accessible
@@ -127,7 +143,10 @@ func (b *Builder) buildDataSource(
 		ds := b.resolveDataSourceRef(source, privilege.SELECT)
 		switch t := ds.(type) {
 		case cat.Table:
-			outScope = b.buildScanFromTableRef(t, source, indexFlags, inScope)
+			if source.As.Alias != "" {
+				locking = locking.filter(source.As.Alias)
+			}
+			outScope = b.buildScanFromTableRef(t, source, indexFlags, locking, inScope)
 		default:
 			panic(unimplementedWithIssueDetailf(35708, fmt.Sprintf("%T", t), "view and sequence numeric refs are not supported"))
 		}
@@ -140,7 +159,11 @@ func (b *Builder) buildDataSource(
 }
 
 // buildView parses the view query text and builds it as a Select expression.
-func (b *Builder) buildView(view cat.View, inScope *scope) (outScope *scope) {
+// Any row-level locking that applies to the view applies to all of the tables
+// that are scanned by its query.
+func (b *Builder) buildView(
+	view cat.View, locking lockingSpec, inScope *scope,
+) (outScope *scope) {
 	// Cache the AST so that multiple references won't need to reparse.
 	if b.views == nil {
 		b.views = make(map[cat.View]*tree.Select)
@@ -177,7 +200,7 @@ func (b *Builder) buildView(view cat.View, inScope *scope) (outScope *scope) {
 		defer func() { b.skipSelectPrivilegeChecks = false }()
 	}
 
-	outScope = b.buildSelect(sel, nil /* desiredTypes */, &scope{builder: b})
+	outScope = b.buildSelect(sel, locking, nil /* desiredTypes */, &scope{builder: b})
 
 	// Update data source name to be the name of the view. And if view columns
 	// are specified, then update names of output columns.
@@ -254,7 +277,11 @@ func (b *Builder) renameSource(as tree.AliasClause, scope *scope) {
 // Note, the query SELECT * FROM [53() as t] is unsupported. Column lists must
 // be non-empty
 func (b *Builder) buildScanFromTableRef(
-	tab cat.Table, ref *tree.TableRef, indexFlags *tree.IndexFlags, inScope *scope,
+	tab cat.Table,
+	ref *tree.TableRef,
+	indexFlags *tree.IndexFlags,
+	locking lockingSpec,
+	inScope *scope,
 ) (outScope *scope) {
 	if ref.Columns != nil && len(ref.Columns) == 0 {
 		panic(pgerror.Newf(pgerror.CodeSyntaxError,
@@ -286,7 +313,7 @@ func (b *Builder) buildScanFromTableRef(
 		}
 	}
 
-	return b.buildScan(tab, tab.Name(), ordinals, indexFlags, excludeMutations, inScope)
+	return b.buildScan(tab, tab.Name(), ordinals, indexFlags, locking, excludeMutations, inScope)
 }
 
 // buildScan builds a memo group for a ScanOp or VirtualScanOp expression on the
@@ -298,6 +325,9 @@ func (b *Builder) buildScanFromTableRef(
 // list are projected by the scan. Otherwise, all columns from the table are
 // projected.
 //
+// If the locking spec is set, then the rows returned by the scan are locked
+// with the spec's locking mode, which must have been filtered for the table.
+//
 // See Builder.buildStmt for a description of the remaining input and return
 // values.
 func (b *Builder) buildScan(
@@ -305,6 +335,7 @@ func (b *Builder) buildScan(
 	alias *tree.TableName,
 	ordinals []int,
 	indexFlags *tree.IndexFlags,
+	locking lockingSpec,
 	scanMutationCols bool,
 	inScope *scope,
 ) (outScope *scope) {
@@ -359,6 +390,9 @@ func (b *Builder) buildScan(
 		outScope.expr = b.factory.ConstructVirtualScan(&private)
 	} else {
 		private := memo.ScanPrivate{Table: tabID, Cols: tabColIDs}
+		if locking.isSet() {
+			private.Locking = locking.get()
+		}
 
 		if indexFlags != nil {
 			private.Flags.NoIndexJoin = indexFlags.NoIndexJoin
@@ -387,6 +421,20 @@ func (b *Builder) buildScan(
 		}
 		outScope.expr = b.factory.ConstructScan(&private)
 		b.addCheckConstraintsToScan(outScope, tabID)
+		if ordinals == nil {
+			// The predicates and the expressions of the virtual computed columns can
+			// reference any column of the table, so they can only be built when all
+			// the columns are in scope.
+			b.addPartialIndexPredicatesToScan(outScope, tabID)
+			b.addVirtualColumnExprsToScan(outScope, tabID)
+		} else {
+			for _, ord := range ordinals {
+				if tab.Column(ord).IsVirtualComputed() {
+					panic(pgerror.Unimplemented("virtual column table ref",
+						"virtual computed columns cannot be referenced by column ID"))
+				}
+			}
+		}
 	}
 	return outScope
 }
@@ -420,6 +468,53 @@ func (b *Builder) addCheckConstraintsToScan(scope *scope, tabID opt.TableID) {
 	}
 }
 
+// addPartialIndexPredicatesToScan finds all the public partial indexes of the
+// table and adds their predicates to the table metadata, built as scalar
+// expressions. The optimizer only uses a partial index when the filters of the
+// query imply its predicate.
+func (b *Builder) addPartialIndexPredicatesToScan(scope *scope, tabID opt.TableID) {
+	tabMeta := b.factory.Metadata().TableMeta(tabID)
+	tab := tabMeta.Table
+
+	for i, n := 0, tab.IndexCount(); i < n; i++ {
+		pred, isPartial := tab.Index(i).Predicate()
+		if !isPartial {
+			continue
+		}
+		expr, err := parser.ParseExpr(pred)
+		if err != nil {
+			panic(builderError{err})
+		}
+
+		texpr := scope.resolveAndRequireType(expr, types.Bool)
+		tabMeta.AddPartialIndexPredicate(i, b.buildScalar(texpr, scope, nil, nil, nil))
+	}
+}
+
+// addVirtualColumnExprsToScan builds the expressions of the virtual computed
+// columns of the table and adds them to the table metadata. They are used to
+// compute the values of the columns when they are not read from a secondary
+// index, and to replace occurrences of the expressions in filters with
+// references to the columns.
+func (b *Builder) addVirtualColumnExprsToScan(scope *scope, tabID opt.TableID) {
+	tabMeta := b.factory.Metadata().TableMeta(tabID)
+	tab := tabMeta.Table
+
+	for i, n := 0, tab.DeletableColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if !col.IsVirtualComputed() {
+			continue
+		}
+		expr, err := parser.ParseExpr(col.ComputedExprStr())
+		if err != nil {
+			panic(builderError{err})
+		}
+
+		texpr := scope.resolveAndRequireType(expr, col.DatumType())
+		tabMeta.AddVirtualColumnExpr(i, b.buildScalar(texpr, scope, nil, nil, nil))
+	}
+}
+
 func (b *Builder) buildSequenceSelect(seq cat.Sequence, inScope *scope) (outScope *scope) {
 	tn := seq.SequenceName()
 	md := b.factory.Metadata()
@@ -471,12 +566,18 @@ func (b *Builder) buildWithOrdinality(colName string, inScope *scope) (outScope
 	return inScope
 }
 
-func (b *Builder) buildCTE(ctes []*tree.CTE, inScope *scope) (outScope *scope) {
+func (b *Builder) buildCTE(with *tree.With, inScope *scope) (outScope *scope) {
 	outScope = inScope.push()
 
+	ctes := with.CTEList
 	outScope.ctes = make(map[string]*cteSource)
 	for i := range ctes {
-		cteScope := b.buildStmt(ctes[i].Stmt, nil /* desiredTypes */, outScope)
+		var cteScope *scope
+		if with.Recursive {
+			cteScope = b.buildRecursiveCTE(ctes[i], outScope)
+		} else {
+			cteScope = b.buildStmt(ctes[i].Stmt, nil /* desiredTypes */, outScope)
+		}
 		cols := cteScope.cols
 		name := ctes[i].Name.Alias
 
@@ -540,20 +641,26 @@ func (b *Builder) checkCTEUsage(inScope *scope) {
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
 func (b *Builder) buildSelectStmt(
-	stmt tree.SelectStatement, desiredTypes []*types.T, inScope *scope,
+	stmt tree.SelectStatement, locking lockingSpec, desiredTypes []*types.T, inScope *scope,
 ) (outScope *scope) {
 	// NB: The case statements are sorted lexicographically.
 	switch stmt := stmt.(type) {
 	case *tree.ParenSelect:
-		return b.buildSelect(stmt.Select, desiredTypes, inScope)
+		return b.buildSelect(stmt.Select, locking, desiredTypes, inScope)
 
 	case *tree.SelectClause:
-		return b.buildSelectClause(stmt, nil /* orderBy */, desiredTypes, inScope)
+		return b.buildSelectClause(stmt, nil /* orderBy */, locking, desiredTypes, inScope)
 
 	case *tree.UnionClause:
+		if locking.isSet() {
+			locking.raiseLockingContextError("UNION/INTERSECT/EXCEPT")
+		}
 		return b.buildUnion(stmt, desiredTypes, inScope)
 
 	case *tree.ValuesClause:
+		if locking.isSet() {
+			locking.raiseLockingContextError("VALUES")
+		}
 		return b.buildValuesClause(stmt, desiredTypes, inScope)
 
 	default:
@@ -562,17 +669,20 @@ func (b *Builder) buildSelectStmt(
 }
 
 // buildSelect builds a set of memo groups that represent the given select
-// expression.
+// expression. The locking spec contains the row-level locking clauses of the
+// enclosing statements (if any); the locking clauses of the select expression
+// itself are added to it.
 //
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
 func (b *Builder) buildSelect(
-	stmt *tree.Select, desiredTypes []*types.T, inScope *scope,
+	stmt *tree.Select, locking lockingSpec, desiredTypes []*types.T, inScope *scope,
 ) (outScope *scope) {
 	wrapped := stmt.Select
 	orderBy := stmt.OrderBy
 	limit := stmt.Limit
 	with := stmt.With
+	locking.apply(stmt.Locking)
 
 	for s, ok := wrapped.(*tree.ParenSelect); ok; s, ok = wrapped.(*tree.ParenSelect) {
 		stmt = s.Select
@@ -602,22 +712,29 @@ func (b *Builder) buildSelect(
 			}
 			limit = stmt.Limit
 		}
+		locking.apply(stmt.Locking)
 	}
 
 	if with != nil {
-		inScope = b.buildCTE(with.CTEList, inScope)
+		inScope = b.buildCTE(with, inScope)
 		defer b.checkCTEUsage(inScope)
 	}
 
 	// NB: The case statements are sorted lexicographically.
 	switch t := stmt.Select.(type) {
 	case *tree.SelectClause:
-		outScope = b.buildSelectClause(t, orderBy, desiredTypes, inScope)
+		outScope = b.buildSelectClause(t, orderBy, locking, desiredTypes, inScope)
 
 	case *tree.UnionClause:
+		if locking.isSet() {
+			locking.raiseLockingContextError("UNION/INTERSECT/EXCEPT")
+		}
 		outScope = b.buildUnion(t, desiredTypes, inScope)
 
 	case *tree.ValuesClause:
+		if locking.isSet() {
+			locking.raiseLockingContextError("VALUES")
+		}
 		outScope = b.buildValuesClause(t, desiredTypes, inScope)
 
 	default:
@@ -656,12 +773,16 @@ func (b *Builder) buildSelect(
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
 func (b *Builder) buildSelectClause(
-	sel *tree.SelectClause, orderBy tree.OrderBy, desiredTypes []*types.T, inScope *scope,
+	sel *tree.SelectClause,
+	orderBy tree.OrderBy,
+	locking lockingSpec,
+	desiredTypes []*types.T,
+	inScope *scope,
 ) (outScope *scope) {
 	if len(sel.Window) > 0 {
 		panic(unimplementedWithIssueDetailf(34251, "", "unsupported window function"))
 	}
-	fromScope := b.buildFrom(sel.From, inScope)
+	fromScope := b.buildFrom(sel.From, locking, inScope)
 	b.buildWhere(sel.Where, fromScope)
 
 	projectionsScope := fromScope.replace()
@@ -681,6 +802,7 @@ func (b *Builder) buildSelectClause(
 	var groupingCols []scopeColumn
 	var having opt.ScalarExpr
 	needsAgg := b.needsAggregation(sel, fromScope)
+	b.validateLockingInFrom(sel, needsAgg, locking, fromScope)
 	if needsAgg {
 		// Grouping columns must be built before building the projection list so
 		// we can check that any column references that appear in the SELECT list
@@ -723,7 +845,9 @@ func (b *Builder) buildSelectClause(
 //
 // See Builder.buildStmt for a description of the remaining input and return
 // values.
-func (b *Builder) buildFrom(from *tree.From, inScope *scope) (outScope *scope) {
+func (b *Builder) buildFrom(
+	from *tree.From, locking lockingSpec, inScope *scope,
+) (outScope *scope) {
 	// The root AS OF clause is recognized and handled by the executor. The only
 	// thing that must be done at this point is to ensure that if any timestamps
 	// are specified, the root SELECT was an AS OF SYSTEM TIME and that the time
@@ -733,7 +857,7 @@ func (b *Builder) buildFrom(from *tree.From, inScope *scope) (outScope *scope) {
 	}
 
 	if len(from.Tables) > 0 {
-		outScope = b.buildFromTables(from.Tables, inScope)
+		outScope = b.buildFromTables(from.Tables, locking, inScope)
 	} else {
 		outScope = inScope.push()
 		outScope.expr = b.factory.ConstructValues(memo.ScalarListWithEmptyTuple, &memo.ValuesPrivate{
@@ -778,15 +902,17 @@ func (b *Builder) buildWhere(where *tree.Where, inScope *scope) {
 //
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
-func (b *Builder) buildFromTables(tables tree.TableExprs, inScope *scope) (outScope *scope) {
+func (b *Builder) buildFromTables(
+	tables tree.TableExprs, locking lockingSpec, inScope *scope,
+) (outScope *scope) {
 	// If there are any lateral data sources, we need to build the join tree
 	// left-deep instead of right-deep.
 	for i := range tables {
 		if b.exprIsLateral(tables[i]) {
-			return b.buildFromWithLateral(tables, inScope)
+			return b.buildFromWithLateral(tables, locking, inScope)
 		}
 	}
-	return b.buildFromTablesRightDeep(tables, inScope)
+	return b.buildFromTablesRightDeep(tables, locking, inScope)
 }
 
 // buildFromTablesRightDeep recursively builds a series of InnerJoin
@@ -807,16 +933,16 @@ func (b *Builder) buildFromTables(tables tree.TableExprs, inScope *scope) (outSc
 // See Builder.buildStmt for a description of the remaining input and
 // return values.
 func (b *Builder) buildFromTablesRightDeep(
-	tables tree.TableExprs, inScope *scope,
+	tables tree.TableExprs, locking lockingSpec, inScope *scope,
 ) (outScope *scope) {
-	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, inScope)
+	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, locking, inScope)
 
 	// Recursively build table join.
 	tables = tables[1:]
 	if len(tables) == 0 {
 		return outScope
 	}
-	tableScope := b.buildFromTablesRightDeep(tables, inScope)
+	tableScope := b.buildFromTablesRightDeep(tables, locking, inScope)
 
 	// Check that the same table name is not used multiple times.
 	b.validateJoinTableNames(outScope, tableScope)
@@ -855,8 +981,10 @@ func (b *Builder) exprIsLateral(t tree.TableExpr) bool {
 //
 //   buildFromTablesRightDeep: a JOIN (b JOIN c)
 //   buildFromWithLateral:     (a JOIN b) JOIN c
-func (b *Builder) buildFromWithLateral(tables tree.TableExprs, inScope *scope) (outScope *scope) {
-	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, inScope)
+func (b *Builder) buildFromWithLateral(
+	tables tree.TableExprs, locking lockingSpec, inScope *scope,
+) (outScope *scope) {
+	outScope = b.buildDataSource(tables[0], nil /* indexFlags */, locking, inScope)
 	for i := 1; i < len(tables); i++ {
 		scope := inScope
 		// Lateral expressions need to be able to refer to the expressions that
@@ -864,7 +992,7 @@ func (b *Builder) buildFromWithLateral(tables tree.TableExprs, inScope *scope) (
 		if b.exprIsLateral(tables[i]) {
 			scope = outScope
 		}
-		tableScope := b.buildDataSource(tables[i], nil /* indexFlags */, scope)
+		tableScope := b.buildDataSource(tables[i], nil /* indexFlags */, locking, scope)
 
 		// Check that the same table name is not used multiple times.
 		b.validateJoinTableNames(outScope, tableScope)
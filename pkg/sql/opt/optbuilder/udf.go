@@ -0,0 +1,121 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// maxInlineDepth limits the nesting of inlined user-defined functions, which
+// can call each other recursively. Deeper calls are evaluated by executing the
+// body of the function.
+const maxInlineDepth = 8
+
+// tryInlineFunction attempts to replace a call to a user-defined function with
+// the body of the function, e.g.:
+//
+//   CREATE FUNCTION add_one(x INT) RETURNS INT AS 'SELECT x + 1' LANGUAGE SQL
+//   SELECT add_one(k) FROM kv
+//   =>
+//   SELECT k + 1 FROM kv
+//
+// Only bodies of the form SELECT <expr>, without a FROM clause or a subquery,
+// can be inlined. Strict functions are not inlined, since the body would be
+// evaluated with NULL arguments. tryInlineFunction returns nil if the call
+// cannot be inlined.
+func (b *Builder) tryInlineFunction(
+	overload *tree.Overload, args memo.ScalarListExpr,
+) opt.ScalarExpr {
+	if overload == nil || overload.SQLBody == nil || overload.SQLBody.Strict {
+		return nil
+	}
+	if b.inlineDepth >= maxInlineDepth {
+		return nil
+	}
+	expr := b.inlinableFunctionBody(overload.SQLBody)
+	if expr == nil {
+		return nil
+	}
+
+	// The parameters are replaced with columns of a scope that only contains
+	// them. These columns are replaced with the arguments once the body is
+	// built.
+	argTypes := overload.Types.(tree.ArgTypes)
+	paramScope := b.allocScope()
+	paramCols := make(opt.ColList, len(argTypes))
+	for i := range argTypes {
+		col := b.synthesizeColumn(paramScope, argTypes[i].Name, argTypes[i].Typ, nil, nil)
+		paramCols[i] = col.id
+	}
+	expr, _ = tree.SimpleVisit(expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+		if t, ok := expr.(*tree.AnnotateTypeExpr); ok {
+			if p, ok := t.Expr.(*tree.Placeholder); ok {
+				return false, &paramScope.cols[p.Idx], nil
+			}
+		}
+		return true, expr, nil
+	})
+
+	b.inlineDepth++
+	defer func() { b.inlineDepth-- }()
+
+	retType := overload.FixedReturnType()
+	texpr := paramScope.resolveType(expr, retType)
+	body := b.buildScalar(texpr, paramScope, nil, nil, nil)
+	if !texpr.ResolvedType().Identical(retType) {
+		body = b.factory.ConstructCast(body, retType)
+	}
+	return b.factory.CustomFuncs().InlineFunctionBody(body, paramCols, args)
+}
+
+// inlinableFunctionBody returns the expression of a body of the form
+// SELECT <expr>, or nil if the body has another form or if the expression
+// refers to columns, contains a subquery, or calls a function that is not a
+// normal function.
+func (b *Builder) inlinableFunctionBody(body *tree.SQLFunctionBody) tree.Expr {
+	sel, ok := body.Stmt.(*tree.Select)
+	if !ok || sel.With != nil || sel.OrderBy != nil || sel.Limit != nil || len(sel.Locking) != 0 {
+		return nil
+	}
+	clause, ok := sel.Select.(*tree.SelectClause)
+	if !ok || clause.TableSelect || clause.Distinct || len(clause.Exprs) != 1 ||
+		clause.Where != nil || clause.GroupBy != nil || clause.Having != nil || clause.Window != nil {
+		return nil
+	}
+	if clause.From != nil && (len(clause.From.Tables) != 0 || clause.From.AsOf.Expr != nil) {
+		return nil
+	}
+
+	expr := clause.Exprs[0].Expr
+	inlinable := true
+	_, _ = tree.SimpleVisit(expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+		switch t := expr.(type) {
+		case tree.UnqualifiedStar, *tree.UnresolvedName, *tree.AllColumnsSelector,
+			*tree.ColumnItem, *tree.TupleStar, *tree.Subquery:
+			inlinable = false
+
+		case *tree.FuncExpr:
+			def, err := t.Func.ResolveInContext(b.semaCtx)
+			if err != nil || def.Class != tree.NormalClass || t.WindowDef != nil || t.Filter != nil {
+				inlinable = false
+			}
+		}
+		return inlinable, expr, nil
+	})
+	if !inlinable {
+		return nil
+	}
+	return expr
+}
@@ -28,9 +28,16 @@ import (
 func (b *Builder) buildUnion(
 	clause *tree.UnionClause, desiredTypes []*types.T, inScope *scope,
 ) (outScope *scope) {
-	leftScope := b.buildSelect(clause.Left, desiredTypes, inScope)
-	rightScope := b.buildSelect(clause.Right, desiredTypes, inScope)
+	leftScope := b.buildSelect(clause.Left, noRowLocking, desiredTypes, inScope)
+	rightScope := b.buildSelect(clause.Right, noRowLocking, desiredTypes, inScope)
+	return b.buildSetOp(clause.Type, clause.All, inScope, leftScope, rightScope)
+}
 
+// buildSetOp builds a set operation of the given type from the already built
+// left and right scopes.
+func (b *Builder) buildSetOp(
+	opType tree.UnionType, all bool, inScope, leftScope, rightScope *scope,
+) (outScope *scope) {
 	// Remove any hidden columns, as they are not included in the Union.
 	leftScope.removeHiddenCols()
 	rightScope.removeHiddenCols()
@@ -40,7 +47,7 @@ func (b *Builder) buildUnion(
 		panic(pgerror.Newf(
 			pgerror.CodeSyntaxError,
 			"each %v query must have the same number of columns: %d vs %d",
-			clause.Type, len(leftScope.cols), len(rightScope.cols),
+			opType, len(leftScope.cols), len(rightScope.cols),
 		))
 	}
 
@@ -52,7 +59,7 @@ func (b *Builder) buildUnion(
 	// synthesize new columns to contain these values. This is not necessary for
 	// INTERSECT or EXCEPT, since these operations are basically filters on the
 	// left relation.
-	newColsNeeded := clause.Type == tree.UnionOp
+	newColsNeeded := opType == tree.UnionOp
 	if newColsNeeded {
 		outScope.cols = make([]scopeColumn, 0, len(leftScope.cols))
 	}
@@ -78,11 +85,11 @@ func (b *Builder) buildUnion(
 			l.typ.Family() == types.UnknownFamily ||
 			r.typ.Family() == types.UnknownFamily) {
 			panic(pgerror.Newf(pgerror.CodeDatatypeMismatchError,
-				"%v types %s and %s cannot be matched", clause.Type, l.typ, r.typ))
+				"%v types %s and %s cannot be matched", opType, l.typ, r.typ))
 		}
 		if l.hidden != r.hidden {
 			// This should never happen.
-			panic(pgerror.AssertionFailedf("%v types cannot be matched", clause.Type))
+			panic(pgerror.AssertionFailedf("%v types cannot be matched", opType))
 		}
 
 		var typ *types.T
@@ -126,8 +133,8 @@ func (b *Builder) buildUnion(
 	right := rightScope.expr.(memo.RelExpr)
 	private := memo.SetPrivate{LeftCols: leftCols, RightCols: rightCols, OutCols: newCols}
 
-	if clause.All {
-		switch clause.Type {
+	if all {
+		switch opType {
 		case tree.UnionOp:
 			outScope.expr = b.factory.ConstructUnionAll(left, right, &private)
 		case tree.IntersectOp:
@@ -136,7 +143,7 @@ func (b *Builder) buildUnion(
 			outScope.expr = b.factory.ConstructExceptAll(left, right, &private)
 		}
 	} else {
-		switch clause.Type {
+		switch opType {
 		case tree.UnionOp:
 			outScope.expr = b.factory.ConstructUnion(left, right, &private)
 		case tree.IntersectOp:
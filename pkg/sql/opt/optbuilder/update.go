@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -55,6 +56,17 @@ import (
 //   LEFT JOIN LATERAL (SELECT y FROM xyz WHERE x=a)
 //   ON True
 //
+// FROM tables are joined with the table, and only one joined row is kept for
+// each row of the table:
+//
+//   UPDATE abc SET b=x FROM xyz WHERE a=y
+//   =>
+//   SELECT DISTINCT ON (a) a AS oa, b AS ob, c AS oc, x AS nb
+//   FROM abc, xyz
+//   WHERE a=y
+//
+// The columns of the FROM tables can't be referenced by the RETURNING clause.
+//
 // Computed columns result in an additional wrapper projection that can depend
 // on input columns.
 //
@@ -75,7 +87,7 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 	}
 
 	if upd.With != nil {
-		inScope = b.buildCTE(upd.With.CTEList, inScope)
+		inScope = b.buildCTE(upd.With, inScope)
 		defer b.checkCTEUsage(inScope)
 	}
 
@@ -85,7 +97,7 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 	tn, alias := getAliasedTableName(upd.Table)
 
 	// Find which table we're working on, check the permissions.
-	tab, resName := b.resolveTable(tn, privilege.UPDATE)
+	tab, resName := b.resolveTableForMutation(tn, privilege.UPDATE)
 	if alias == nil {
 		alias = &resName
 	}
@@ -99,17 +111,20 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 	// Build the input expression that selects the rows that will be updated:
 	//
 	//   WITH <with>
-	//   SELECT <cols> FROM <table> WHERE <where>
+	//   SELECT <cols> FROM <table> [, <from>] WHERE <where>
 	//   ORDER BY <order-by> LIMIT <limit>
 	//
 	// All columns from the update table will be projected.
-	mb.buildInputForUpdateOrDelete(inScope, upd.Where, upd.Limit, upd.OrderBy)
+	mb.buildInputForUpdateOrDelete(inScope, upd.From, upd.Where, upd.Limit, upd.OrderBy)
 
 	// Derive the columns that will be updated from the SET expressions.
-	mb.addTargetColsForUpdate(upd.Exprs)
+	// Assignments of fields of composite columns are rewritten into
+	// assignments of whole columns.
+	exprs := mb.rewriteFieldAssignments(upd.Exprs)
+	mb.addTargetColsForUpdate(exprs)
 
 	// Build each of the SET expressions.
-	mb.addUpdateCols(upd.Exprs)
+	mb.addUpdateCols(exprs)
 
 	// Add additional columns for computed expressions that may depend on the
 	// updated columns.
@@ -151,7 +166,7 @@ func (mb *mutationBuilder) addTargetColsForUpdate(exprs tree.UpdateExprs) {
 				for i := range desiredTypes {
 					desiredTypes[i] = mb.md.ColumnMeta(mb.targetColList[targetIdx+i]).Type
 				}
-				outScope := mb.b.buildSelectStmt(t.Select, desiredTypes, mb.outScope)
+				outScope := mb.b.buildSelectStmt(t.Select, noRowLocking, desiredTypes, mb.outScope)
 				mb.subqueries = append(mb.subqueries, outScope)
 				n = len(outScope.cols)
 
@@ -168,6 +183,33 @@ func (mb *mutationBuilder) addTargetColsForUpdate(exprs tree.UpdateExprs) {
 					len(expr.Names), n))
 			}
 		}
+
+		mb.checkIdentityColsForUpdate(expr)
+	}
+}
+
+// checkIdentityColsForUpdate raises an error if the given SET expression sets an
+// identity column defined as GENERATED ALWAYS to a value other than DEFAULT:
+//
+//   UPDATE t SET id = DEFAULT
+func (mb *mutationBuilder) checkIdentityColsForUpdate(expr *tree.UpdateExpr) {
+	for i, name := range expr.Names {
+		tabCol := mb.tab.Column(cat.FindTableColumnByName(mb.tab, name))
+		if !tabCol.IsGeneratedAlwaysAsIdentity() {
+			continue
+		}
+		val := expr.Expr
+		if expr.Tuple {
+			// DEFAULT cannot be returned by a subquery.
+			tuple, ok := val.(*tree.Tuple)
+			if !ok {
+				panic(builderError{sqlbase.NewGeneratedAlwaysUpdateError(string(tabCol.ColName()))})
+			}
+			val = tuple.Exprs[i]
+		}
+		if _, ok := val.(tree.DefaultVal); !ok {
+			panic(builderError{sqlbase.NewGeneratedAlwaysUpdateError(string(tabCol.ColName()))})
+		}
 	}
 }
 
@@ -287,6 +329,13 @@ func (mb *mutationBuilder) addUpdateCols(exprs tree.UpdateExprs) {
 	// inserted columns.
 	mb.roundDecimalValues(mb.updateOrds, false /* roundComputedCols */)
 
+	// Run the BEFORE row-level triggers, which can replace the updated values
+	// or skip rows. INSERT ... ON CONFLICT DO UPDATE statements cannot fire
+	// triggers.
+	if mb.op == opt.UpdateOp {
+		mb.addBeforeTriggers()
+	}
+
 	// Add additional columns for computed expressions that may depend on any
 	// updated columns.
 	mb.addComputedColsForUpdate()
@@ -321,6 +370,7 @@ func (mb *mutationBuilder) addComputedColsForUpdate() {
 // operator that corresponds to the given RETURNING clause.
 func (mb *mutationBuilder) buildUpdate(returning tree.ReturningExprs) {
 	mb.addCheckConstraintCols()
+	mb.addAfterTriggerCols()
 
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructUpdate(mb.outScope.expr, private)
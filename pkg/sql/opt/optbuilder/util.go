@@ -435,6 +435,19 @@ func (b *Builder) resolveTable(
 	return tab, resName
 }
 
+// resolveTableForMutation is similar to resolveTable, but it also raises an
+// error if the table stores a materialized view, since the contents of a
+// materialized view can only be changed by refreshing it.
+func (b *Builder) resolveTableForMutation(
+	tn *tree.TableName, priv privilege.Kind,
+) (cat.Table, tree.TableName) {
+	tab, resName := b.resolveTable(tn, priv)
+	if tab.IsMaterializedView() {
+		panic(builderError{sqlbase.NewWrongObjectTypeError(tn, "table")})
+	}
+	return tab, resName
+}
+
 // resolveDataSource returns the data source in the catalog with the given name.
 // If the name does not resolve to a table, or if the current user does not have
 // the given privilege, then resolveDataSource raises an error.
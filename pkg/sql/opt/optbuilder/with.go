@@ -0,0 +1,190 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// buildRecursiveCTE builds a CTE that is part of a WITH RECURSIVE clause. A
+// CTE that does not reference itself is built like any other CTE. Otherwise,
+// it must have the form:
+//
+//   <initial query> UNION [ALL] <recursive query>
+//
+// where only the recursive query references the CTE, and does so at most
+// once. Such a CTE is built as a RecursiveCTE operator, and the reference is
+// built as a WorkTableScan operator over the rows produced by the previous
+// iteration.
+func (b *Builder) buildRecursiveCTE(cte *tree.CTE, inScope *scope) (outScope *scope) {
+	name := cte.Name.Alias
+	tableName := tree.MakeUnqualifiedTableName(name)
+
+	// guardScope returns a scope in which any reference to the CTE raises the
+	// given error.
+	guardScope := func(format string) *scope {
+		s := inScope.push()
+		s.ctes = map[string]*cteSource{name.String(): {
+			name: cte.Name,
+			onRef: func(*scope) *scope {
+				panic(pgerror.Newf(pgerror.CodeInvalidRecursionError, format, tree.ErrString(&name)))
+			},
+		}}
+		return s
+	}
+
+	union, ok := recursiveCTEUnion(cte.Stmt)
+	if !ok {
+		return b.buildStmt(cte.Stmt, nil /* desiredTypes */, guardScope(
+			"recursive query %q does not have the form non-recursive-term UNION [ALL] recursive-term",
+		))
+	}
+
+	initialScope := b.buildSelect(union.Left, noRowLocking, nil /* desiredTypes */, guardScope(
+		"recursive reference to query %q must not appear within its non-recursive term",
+	))
+	initialScope.removeHiddenCols()
+
+	if cte.Name.Cols != nil && len(initialScope.cols) != len(cte.Name.Cols) {
+		panic(pgerror.Newf(
+			pgerror.CodeInvalidColumnReferenceError,
+			"source %q has %d columns available but %d columns specified",
+			name, len(initialScope.cols), len(cte.Name.Cols),
+		))
+	}
+
+	// Synthesize the output columns of the CTE, which are also the columns of
+	// the working table. Their types are determined by the initial query.
+	cteScope := inScope.push()
+	desiredTypes := make([]*types.T, len(initialScope.cols))
+	for i := range initialScope.cols {
+		col := &initialScope.cols[i]
+		colName := col.name
+		if cte.Name.Cols != nil {
+			colName = cte.Name.Cols[i]
+		}
+		if col.typ.Family() == types.UnknownFamily {
+			panic(pgerror.Newf(pgerror.CodeDatatypeMismatchError,
+				"could not determine data type of column %q of recursive query %q",
+				tree.ErrString(&colName), tree.ErrString(&name)))
+		}
+		desiredTypes[i] = col.typ
+		outCol := b.synthesizeColumn(cteScope, string(colName), col.typ, nil, nil /* scalar */)
+		outCol.table = tableName
+	}
+	cteCols := colsToColList(cteScope.cols)
+
+	// Build the recursive query. The reference to the CTE is built as a scan of
+	// the working table, with new column IDs.
+	withID := b.factory.Metadata().NextWithID()
+	numRefs := 0
+	recursiveScope := inScope.push()
+	recursiveScope.ctes = map[string]*cteSource{name.String(): {
+		name: cte.Name,
+		onRef: func(refScope *scope) *scope {
+			numRefs++
+			if numRefs > 1 {
+				panic(pgerror.Newf(pgerror.CodeInvalidRecursionError,
+					"recursive reference to query %q must not appear more than once",
+					tree.ErrString(&name)))
+			}
+			scanScope := refScope.push()
+			for i := range cteScope.cols {
+				col := &cteScope.cols[i]
+				scanCol := b.synthesizeColumn(scanScope, string(col.name), col.typ, nil, nil /* scalar */)
+				scanCol.table = tableName
+			}
+			scanScope.expr = b.factory.ConstructWorkTableScan(&memo.WorkTableScanPrivate{
+				Name:    string(name),
+				ID:      withID,
+				InCols:  cteCols,
+				OutCols: colsToColList(scanScope.cols),
+			})
+			return scanScope
+		},
+	}}
+	recursiveScope = b.buildSelect(union.Right, noRowLocking, desiredTypes, recursiveScope)
+
+	if numRefs == 0 {
+		// The CTE doesn't reference itself, so it is just a regular UNION.
+		return b.buildSetOp(union.Type, union.All, inScope, initialScope, recursiveScope)
+	}
+
+	recursiveScope.removeHiddenCols()
+	if len(recursiveScope.cols) != len(cteScope.cols) {
+		panic(pgerror.Newf(
+			pgerror.CodeSyntaxError,
+			"each %v query must have the same number of columns: %d vs %d",
+			union.Type, len(cteScope.cols), len(recursiveScope.cols),
+		))
+	}
+	propagateTypes := false
+	for i := range recursiveScope.cols {
+		l := &cteScope.cols[i]
+		r := &recursiveScope.cols[i]
+		if r.typ.Family() == types.UnknownFamily {
+			propagateTypes = true
+		} else if !l.typ.Equivalent(r.typ) {
+			panic(pgerror.Newf(pgerror.CodeDatatypeMismatchError,
+				"recursive query %q column %d has type %s in non-recursive term but type %s in recursive term",
+				tree.ErrString(&name), i+1, l.typ, r.typ))
+		}
+	}
+	if propagateTypes {
+		recursiveScope = b.propagateTypes(recursiveScope, cteScope)
+	}
+
+	outScope = inScope.push()
+	outScope.cols = cteScope.cols
+	outScope.expr = b.factory.ConstructRecursiveCTE(
+		initialScope.expr,
+		recursiveScope.expr,
+		&memo.RecursiveCTEPrivate{
+			Name:          string(name),
+			WithID:        withID,
+			InitialCols:   colsToColList(initialScope.cols),
+			RecursiveCols: colsToColList(recursiveScope.cols),
+			OutCols:       cteCols,
+			Deduplicate:   !union.All,
+		},
+	)
+
+	telemetry.Inc(sqltelemetry.RecursiveCteUseCounter)
+
+	return outScope
+}
+
+// recursiveCTEUnion returns the top-level UNION of the given CTE statement, if
+// the statement consists of nothing more than such a UNION.
+func recursiveCTEUnion(stmt tree.Statement) (*tree.UnionClause, bool) {
+	sel, ok := stmt.(*tree.Select)
+	for ok {
+		if sel.With != nil || sel.OrderBy != nil || sel.Limit != nil {
+			return nil, false
+		}
+		switch t := sel.Select.(type) {
+		case *tree.UnionClause:
+			return t, t.Type == tree.UnionOp
+		case *tree.ParenSelect:
+			sel = t.Select
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
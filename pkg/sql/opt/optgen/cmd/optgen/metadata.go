@@ -152,6 +152,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"SchemaID":       {fullName: "opt.SchemaID", passByVal: true},
 		"SequenceID":     {fullName: "opt.SequenceID", passByVal: true},
 		"ValuesID":       {fullName: "opt.ValuesID", passByVal: true},
+		"WithID":         {fullName: "opt.WithID", passByVal: true},
 		"Ordering":       {fullName: "opt.Ordering", passByVal: true},
 		"OrderingChoice": {fullName: "physical.OrderingChoice", passByVal: true},
 		"TupleOrdinal":   {fullName: "memo.TupleOrdinal", passByVal: true},
@@ -170,6 +171,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"Subquery":       {fullName: "*tree.Subquery", isPointer: true, usePointerIntern: true},
 		"CreateTable":    {fullName: "*tree.CreateTable", isPointer: true, usePointerIntern: true},
 		"Constraint":     {fullName: "*constraint.Constraint", isPointer: true, usePointerIntern: true},
+		"LockingItem":    {fullName: "*tree.LockingItem", isPointer: true, usePointerIntern: true},
 		"FuncProps":      {fullName: "*tree.FunctionProperties", isPointer: true, usePointerIntern: true},
 		"FuncOverload":   {fullName: "*tree.Overload", isPointer: true, usePointerIntern: true},
 		"WindowFrame":    {fullName: "*tree.WindowFrame", isPointer: true},
@@ -142,6 +142,19 @@ type TableMeta struct {
 	// in certain queries. See comment above GenerateConstrainedScans for more
 	// detail.
 	constraints []ScalarExpr
+
+	// partialIndexPredicates maps the ordinals of the partial indexes of the
+	// table to their predicates, stored in the ScalarExpr form so that the
+	// optimizer can determine whether a query filter implies them. See the
+	// comment above GenerateConstrainedScans for more detail.
+	partialIndexPredicates map[int]ScalarExpr
+
+	// virtualColExprs maps the ordinals of the virtual computed columns of the
+	// table to their expressions, stored in the ScalarExpr form so that the
+	// columns can be computed when they are read from an index that does not
+	// store them, and so that the optimizer can replace occurrences of the
+	// expressions with references to the columns.
+	virtualColExprs map[int]ScalarExpr
 }
 
 // clearAnnotations resets all the table annotations; used when copying a
@@ -196,6 +209,46 @@ func (tm *TableMeta) AddConstraint(constraint ScalarExpr) {
 	tm.constraints = append(tm.constraints, constraint)
 }
 
+// PartialIndexPredicate returns the predicate of the partial index with the
+// given ordinal, and true if it was added to the table's metadata. It returns
+// false if the index is not partial or if its predicate is not available.
+func (tm *TableMeta) PartialIndexPredicate(indexOrd int) (ScalarExpr, bool) {
+	pred, ok := tm.partialIndexPredicates[indexOrd]
+	return pred, ok
+}
+
+// AddPartialIndexPredicate adds the predicate of the partial index with the
+// given ordinal to the table's metadata.
+func (tm *TableMeta) AddPartialIndexPredicate(indexOrd int, pred ScalarExpr) {
+	if tm.partialIndexPredicates == nil {
+		tm.partialIndexPredicates = make(map[int]ScalarExpr)
+	}
+	tm.partialIndexPredicates[indexOrd] = pred
+}
+
+// VirtualColumnExpr returns the expression of the virtual computed column with
+// the given ordinal, and true if it was added to the table's metadata. It
+// returns false if the column is not a virtual computed column.
+func (tm *TableMeta) VirtualColumnExpr(colOrd int) (ScalarExpr, bool) {
+	expr, ok := tm.virtualColExprs[colOrd]
+	return expr, ok
+}
+
+// AddVirtualColumnExpr adds the expression of the virtual computed column with
+// the given ordinal to the table's metadata.
+func (tm *TableMeta) AddVirtualColumnExpr(colOrd int, expr ScalarExpr) {
+	if tm.virtualColExprs == nil {
+		tm.virtualColExprs = make(map[int]ScalarExpr)
+	}
+	tm.virtualColExprs[colOrd] = expr
+}
+
+// HasVirtualColumnExprs returns true if the expression of at least one virtual
+// computed column was added to the table's metadata.
+func (tm *TableMeta) HasVirtualColumnExprs() bool {
+	return len(tm.virtualColExprs) > 0
+}
+
 // TableAnnotation returns the given annotation that is associated with the
 // given table. If the table has no such annotation, TableAnnotation returns
 // nil.
@@ -352,6 +352,16 @@ func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 	if def.Computed.Expr != nil {
 		s := tree.Serialize(def.Computed.Expr)
 		col.ComputedExpr = &s
+		col.Virtual = def.Computed.Virtual
+	}
+
+	// The test catalog has no sequences, so identity columns use the same
+	// default value as the hidden rowid column.
+	if def.IsIdentity() {
+		col.Nullable = false
+		col.DefaultExpr = &uniqueRowIDString
+		col.Identity = true
+		col.GeneratedAlways = def.Identity.Always
 	}
 
 	tt.Columns = append(tt.Columns, col)
@@ -365,6 +375,9 @@ func (tt *Table) addIndex(def *tree.IndexTableDef, typ indexType) *Index {
 		IdxZone:  &config.ZoneConfig{},
 		table:    tt,
 	}
+	if def.Predicate != nil {
+		idx.IdxPredicate = tree.Serialize(def.Predicate)
+	}
 
 	// Look for name suffixes indicating this is a mutation index.
 	if name, ok := extractWriteOnlyIndex(def); ok {
@@ -545,6 +545,11 @@ func (tt *Table) IsVirtualTable() bool {
 	return tt.IsVirtual
 }
 
+// IsMaterializedView is part of the cat.Table interface.
+func (tt *Table) IsMaterializedView() bool {
+	return false
+}
+
 // IsInterleaved is part of the cat.Table interface.
 func (tt *Table) IsInterleaved() bool {
 	return false
@@ -640,6 +645,17 @@ func (tt *Table) InboundForeignKey(i int) cat.ForeignKeyConstraint {
 	return &tt.inboundFKs[i]
 }
 
+// TriggerCount is part of the cat.Table interface. Triggers are not supported
+// by the test catalog.
+func (tt *Table) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (tt *Table) Trigger(i int) cat.Trigger {
+	panic(pgerror.AssertionFailedf("no triggers"))
+}
+
 // FindOrdinal returns the ordinal of the column with the given name.
 func (tt *Table) FindOrdinal(name string) int {
 	for i, col := range tt.Columns {
@@ -676,6 +692,10 @@ type Index struct {
 	// Inverted is true when this index is an inverted index.
 	Inverted bool
 
+	// IdxPredicate is the partial index predicate, or the empty string if
+	// this index is not partial.
+	IdxPredicate string
+
 	Columns []cat.IndexColumn
 
 	// IdxZone is the zone associated with the index. This may be inherited from
@@ -711,6 +731,11 @@ func (ti *Index) IsInverted() bool {
 	return ti.Inverted
 }
 
+// Predicate is part of the cat.Index interface.
+func (ti *Index) Predicate() (string, bool) {
+	return ti.IdxPredicate, ti.IdxPredicate != ""
+}
+
 // ColumnCount is part of the cat.Index interface.
 func (ti *Index) ColumnCount() int {
 	return len(ti.Columns)
@@ -751,6 +776,10 @@ type Column struct {
 	ColType      types.T
 	DefaultExpr  *string
 	ComputedExpr *string
+	Virtual      bool
+
+	Identity        bool
+	GeneratedAlways bool
 }
 
 var _ cat.Column = &Column{}
@@ -777,24 +806,20 @@ func (tc *Column) DatumType() *types.T {
 
 // ColTypePrecision is part of the cat.Column interface.
 func (tc *Column) ColTypePrecision() int {
-	if tc.ColType.Family() == types.ArrayFamily {
-		if tc.ColType.ArrayContents().Family() == types.ArrayFamily {
-			panic(pgerror.AssertionFailedf("column type should never be a nested array"))
-		}
-		return int(tc.ColType.ArrayContents().Precision())
+	typ := &tc.ColType
+	for typ.Family() == types.ArrayFamily {
+		typ = typ.ArrayContents()
 	}
-	return int(tc.ColType.Precision())
+	return int(typ.Precision())
 }
 
 // ColTypeWidth is part of the cat.Column interface.
 func (tc *Column) ColTypeWidth() int {
-	if tc.ColType.Family() == types.ArrayFamily {
-		if tc.ColType.ArrayContents().Family() == types.ArrayFamily {
-			panic(pgerror.AssertionFailedf("column type should never be a nested array"))
-		}
-		return int(tc.ColType.ArrayContents().Width())
+	typ := &tc.ColType
+	for typ.Family() == types.ArrayFamily {
+		typ = typ.ArrayContents()
 	}
-	return int(tc.ColType.Width())
+	return int(typ.Width())
 }
 
 // ColTypeStr is part of the cat.Column interface.
@@ -827,6 +852,21 @@ func (tc *Column) ComputedExprStr() string {
 	return *tc.ComputedExpr
 }
 
+// IsVirtualComputed is part of the cat.Column interface.
+func (tc *Column) IsVirtualComputed() bool {
+	return tc.Virtual
+}
+
+// IsIdentity is part of the cat.Column interface.
+func (tc *Column) IsIdentity() bool {
+	return tc.Identity
+}
+
+// IsGeneratedAlwaysAsIdentity is part of the cat.Column interface.
+func (tc *Column) IsGeneratedAlwaysAsIdentity() bool {
+	return tc.Identity && tc.GeneratedAlways
+}
+
 // TableStat implements the cat.TableStatistic interface for testing purposes.
 type TableStat struct {
 	js stats.JSONStatistic
@@ -250,6 +250,10 @@ func (c *CustomFuncs) checkConstraintFilters(tabID opt.TableID) memo.FiltersExpr
 // GenerateConstrainedScans will further constrain the enumerated index scans
 // by trying to use the check constraints that apply to the table being
 // scanned.
+//
+// Partial indexes are only enumerated when the filters imply their predicate,
+// since they don't contain the rows that don't satisfy it. See
+// partialIndexPredicateImplied.
 func (c *CustomFuncs) GenerateConstrainedScans(
 	grp memo.RelExpr, scanPrivate *memo.ScanPrivate, explicitFilters memo.FiltersExpr,
 ) {
@@ -262,15 +266,28 @@ func (c *CustomFuncs) GenerateConstrainedScans(
 	// Consider the checkFilters as well to constrain each of the indexes.
 	filters := append(explicitFilters, checkFilters...)
 
-	// Iterate over all indexes.
+	// Iterate over all indexes, including the partial indexes.
 	var iter scanIndexIter
 	iter.init(c.e.mem, scanPrivate)
+	iter.includePartial = true
 	for iter.next() {
+		// A partial index can only be used if the filters imply its predicate.
+		_, isPartial := iter.index.Predicate()
+		if isPartial && !c.partialIndexPredicateImplied(filters, scanPrivate.Table, iter.indexOrdinal) {
+			continue
+		}
+
 		// Check whether the filter can constrain the index.
 		constraintFilters, remainingFilters, ok := c.tryConstrainIndex(
 			filters, scanPrivate.Table, iter.indexOrdinal, false /* isInverted */)
 		if !ok {
-			continue
+			if !isPartial {
+				continue
+			}
+			// The partial index contains all the rows that satisfy the filters,
+			// so it can be scanned in full even if they don't constrain it.
+			constraintFilters = nil
+			remainingFilters = append(memo.FiltersExpr(nil), explicitFilters...)
 		}
 
 		// If a check constraint filter wasn't able to constrain the index, it
@@ -327,6 +344,34 @@ func (c *CustomFuncs) GenerateConstrainedScans(
 	}
 }
 
+// virtualComputedCols returns the virtual computed columns scanned by the
+// given ScanPrivate.
+func (c *CustomFuncs) virtualComputedCols(scanPrivate *memo.ScanPrivate) opt.ColSet {
+	var cols opt.ColSet
+	tab := c.e.mem.Metadata().Table(scanPrivate.Table)
+	for i, n := 0, tab.DeletableColumnCount(); i < n; i++ {
+		colID := scanPrivate.Table.ColumnID(i)
+		if tab.Column(i).IsVirtualComputed() && scanPrivate.Cols.Contains(int(colID)) {
+			cols.Add(int(colID))
+		}
+	}
+	return cols
+}
+
+// partialIndexPredicateImplied returns true if the given filters imply the
+// predicate of the partial index with the given ordinal, which means that the
+// index contains all the rows that satisfy the filters. See
+// memo.FiltersImplyPredicate.
+func (c *CustomFuncs) partialIndexPredicateImplied(
+	filters memo.FiltersExpr, tabID opt.TableID, indexOrd int,
+) bool {
+	pred, ok := c.e.mem.Metadata().TableMeta(tabID).PartialIndexPredicate(indexOrd)
+	if !ok {
+		return false
+	}
+	return memo.FiltersImplyPredicate(c.e.evalCtx, c.e.mem, filters, pred)
+}
+
 // HasInvertedIndexes returns true if at least one inverted index is defined on
 // the Scan operator's table.
 func (c *CustomFuncs) HasInvertedIndexes(scanPrivate *memo.ScanPrivate) bool {
@@ -790,6 +835,10 @@ func (c *CustomFuncs) GenerateLookupJoins(
 	if joinPrivate.Flags.DisallowLookupJoin {
 		return
 	}
+	if scanPrivate.Locking != nil {
+		// Lookup joins don't lock the rows that they look up.
+		return
+	}
 	inputProps := input.Relational()
 
 	leftEq, rightEq := memo.ExtractJoinEqualityColumns(inputProps.OutputCols, scanPrivate.Cols, on)
@@ -800,6 +849,10 @@ func (c *CustomFuncs) GenerateLookupJoins(
 
 	var pkCols opt.ColList
 
+	// Virtual computed columns are not stored in the primary index, so they can
+	// only be looked up in secondary indexes that store them.
+	virtualCols := c.virtualComputedCols(scanPrivate)
+
 	var iter scanIndexIter
 	iter.init(c.e.mem, scanPrivate)
 	for iter.next() {
@@ -809,6 +862,11 @@ func (c *CustomFuncs) GenerateLookupJoins(
 			continue
 		}
 
+		if !virtualCols.Empty() &&
+			(iter.indexOrdinal == cat.PrimaryIndex || !virtualCols.SubsetOf(iter.indexCols())) {
+			continue
+		}
+
 		lookupJoin := memo.LookupJoinExpr{Input: input, On: on}
 		lookupJoin.JoinPrivate = *joinPrivate
 		lookupJoin.JoinType = joinType
@@ -1037,6 +1095,11 @@ func (c *CustomFuncs) GenerateZigzagJoins(
 		return
 	}
 
+	// Zigzag joins don't lock the rows that they read.
+	if scanPrivate.Locking != nil {
+		return
+	}
+
 	fixedCols := memo.ExtractConstColumns(filters, c.e.mem, c.e.evalCtx)
 
 	if fixedCols.Len() == 0 {
@@ -1210,6 +1273,11 @@ func (c *CustomFuncs) GenerateZigzagJoins(
 				continue
 			}
 
+			// Virtual computed columns cannot be looked up in the primary index.
+			if !c.virtualComputedCols(scanPrivate).SubsetOf(zigzagCols) {
+				continue
+			}
+
 			// Case 2 (wrap zigzag join in an index join).
 			var indexJoin memo.LookupJoinExpr
 			// Ensure the zigzag join returns pk columns.
@@ -1379,6 +1447,11 @@ func (c *CustomFuncs) GenerateInvertedIndexZigzagJoins(
 			continue
 		}
 
+		// Virtual computed columns cannot be looked up in the primary index.
+		if !c.virtualComputedCols(scanPrivate).SubsetOf(zigzagCols) {
+			continue
+		}
+
 		// Case 2 (wrap zigzag join in an index join).
 
 		var indexJoin memo.LookupJoinExpr
@@ -1569,6 +1642,11 @@ type scanIndexIter struct {
 	indexOrdinal int
 	index        cat.Index
 	cols         opt.ColSet
+
+	// includePartial is true if next should also return the partial indexes
+	// of the table. Callers that set it must check that the partial indexes
+	// contain all the rows they need.
+	includePartial bool
 }
 
 func (it *scanIndexIter) init(mem *memo.Memo, scanPrivate *memo.ScanPrivate) {
@@ -1581,10 +1659,12 @@ func (it *scanIndexIter) init(mem *memo.Memo, scanPrivate *memo.ScanPrivate) {
 
 // next advances iteration to the next index of the Scan operator's table. This
 // is the primary index if it's the first time next is called, or a secondary
-// index thereafter. Inverted index are skipped. If the ForceIndex flag is set,
-// then all indexes except the forced index are skipped. When there are no more
-// indexes to enumerate, next returns false. The current index is accessible via
-// the iterator's "index" field.
+// index thereafter. Inverted index are skipped, and so are partial indexes
+// unless includePartial is set. If the ForceIndex flag is set, then all indexes
+// except the forced index are skipped. If the scan is a locking scan, all
+// secondary indexes are skipped. When there are no more indexes to enumerate,
+// next returns false. The current index is accessible via the iterator's
+// "index" field.
 func (it *scanIndexIter) next() bool {
 	for {
 		it.indexOrdinal++
@@ -1596,6 +1676,14 @@ func (it *scanIndexIter) next() bool {
 		if it.index.IsInverted() {
 			continue
 		}
+		if _, isPartial := it.index.Predicate(); isPartial && !it.includePartial {
+			continue
+		}
+		if it.scanPrivate.Locking != nil && it.indexOrdinal != cat.PrimaryIndex {
+			// Locking scans lock the rows of the primary index, so they can't
+			// be replaced by scans over other indexes.
+			continue
+		}
 		if it.scanPrivate.Flags.ForceIndex && it.scanPrivate.Flags.Index != it.indexOrdinal {
 			// If we are forcing a specific index, ignore the others.
 			continue
@@ -1621,6 +1709,10 @@ func (it *scanIndexIter) nextInverted() bool {
 		if !it.index.IsInverted() {
 			continue
 		}
+		if it.scanPrivate.Locking != nil {
+			// Locking scans can only use the primary index; see next.
+			continue
+		}
 		if it.scanPrivate.Flags.ForceIndex && it.scanPrivate.Flags.Index != it.indexOrdinal {
 			// If we are forcing a specific index, ignore the others.
 			continue
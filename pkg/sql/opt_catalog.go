@@ -245,8 +245,9 @@ func (oc *optCatalog) dataSourceForDesc(
 	desc *sqlbase.ImmutableTableDescriptor,
 	name *cat.DataSourceName,
 ) (cat.DataSource, error) {
-	if desc.IsTable() {
-		// Tables require invalidation logic for cached wrappers.
+	if desc.IsTable() || desc.MaterializedView() {
+		// Tables require invalidation logic for cached wrappers. Materialized
+		// views are read like tables.
 		return oc.dataSourceForTable(ctx, flags, desc, name)
 	}
 
@@ -517,6 +518,9 @@ type optTable struct {
 	outboundFKs []optForeignKeyConstraint
 	inboundFKs  []optForeignKeyConstraint
 
+	// triggers are the table's triggers, with their bodies prepared to run.
+	triggers []*sqlTrigger
+
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap map[sqlbase.ColumnID]int
@@ -621,6 +625,8 @@ func newOptTable(
 		}
 	}
 
+	ot.triggers = makeSQLTriggers(ot.desc.TableDesc())
+
 	// Add stats last, now that other metadata is initialized.
 	if stats != nil {
 		ot.stats = make([]optTableStat, len(stats))
@@ -714,6 +720,11 @@ func (ot *optTable) IsVirtualTable() bool {
 	return ot.desc.IsVirtualTable()
 }
 
+// IsMaterializedView is part of the cat.Table interface.
+func (ot *optTable) IsMaterializedView() bool {
+	return ot.desc.MaterializedView()
+}
+
 // IsInterleaved is part of the cat.Table interface.
 func (ot *optTable) IsInterleaved() bool {
 	return ot.desc.IsInterleaved()
@@ -828,6 +839,16 @@ func (ot *optTable) InboundForeignKey(i int) cat.ForeignKeyConstraint {
 	return &ot.inboundFKs[i]
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	return ot.triggers[i]
+}
+
 // lookupColumnOrdinal returns the ordinal of the column with the given ID. A
 // cache makes the lookup O(1).
 func (ot *optTable) lookupColumnOrdinal(colID sqlbase.ColumnID) (int, error) {
@@ -935,6 +956,11 @@ func (oi *optIndex) IsInverted() bool {
 	return oi.desc.Type == sqlbase.IndexDescriptor_INVERTED
 }
 
+// Predicate is part of the cat.Index interface.
+func (oi *optIndex) Predicate() (string, bool) {
+	return oi.desc.Predicate, oi.desc.IsPartial()
+}
+
 // ColumnCount is part of the cat.Index interface.
 func (oi *optIndex) ColumnCount() int {
 	return oi.numCols
@@ -74,6 +74,7 @@ func (ef *execFactory) ConstructScan(
 	reverse bool,
 	maxResults uint64,
 	reqOrdering exec.OutputOrdering,
+	locking *tree.LockingItem,
 ) (exec.Node, error) {
 	tabDesc := table.(*optTable).desc
 	indexDesc := index.(*optIndex).desc
@@ -101,6 +102,10 @@ func (ef *execFactory) ConstructScan(
 	scan.reverse = reverse
 	scan.maxResults = maxResults
 	scan.parallelScansEnabled = sqlbase.ParallelScans.Get(&ef.planner.extendedEvalCtx.Settings.SV)
+	if locking != nil {
+		scan.lockingStrength = sqlbase.ToScanLockingStrength(locking.Strength)
+		scan.lockingWaitPolicy = sqlbase.ToScanLockingWaitPolicy(locking.WaitPolicy)
+	}
 	var err error
 	scan.spans, err = spansFromConstraint(
 		tabDesc,
@@ -1146,6 +1151,7 @@ func (ef *execFactory) ConstructInsert(
 	table cat.Table,
 	insertCols exec.ColumnOrdinalSet,
 	checks exec.CheckOrdinalSet,
+	triggers exec.TriggerOrdinalSet,
 	rowsNeeded bool,
 ) (exec.Node, error) {
 	// Derive insert table and column descriptors.
@@ -1160,6 +1166,10 @@ func (ef *execFactory) ConstructInsert(
 	if err != nil {
 		return nil, err
 	}
+	deferredChecks, err := ef.planner.registerDeferrableConstraints(tabDesc, fkTables, row.CheckInserts)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create the table insert, which does the bulk of the work.
 	ri, err := row.MakeInserter(ef.planner.txn, tabDesc, fkTables, colDescs,
@@ -1167,6 +1177,7 @@ func (ef *execFactory) ConstructInsert(
 	if err != nil {
 		return nil, err
 	}
+	ri.SetDeferredChecks(deferredChecks)
 
 	// Determine the relational type of the generated insert node.
 	// If rows are not needed, no columns are returned.
@@ -1193,6 +1204,7 @@ func (ef *execFactory) ConstructInsert(
 			insertCols: ri.InsertCols,
 		},
 	}
+	ins.run.triggers.init(table, tree.TriggerInsert, triggers)
 
 	// serialize the data-modifying plan to ensure that no data is
 	// observed that hasn't been validated first. See the comments
@@ -1212,6 +1224,7 @@ func (ef *execFactory) ConstructUpdate(
 	fetchCols exec.ColumnOrdinalSet,
 	updateCols exec.ColumnOrdinalSet,
 	checks exec.CheckOrdinalSet,
+	triggers exec.TriggerOrdinalSet,
 	rowsNeeded bool,
 ) (exec.Node, error) {
 	// Derive table and column descriptors.
@@ -1243,6 +1256,10 @@ func (ef *execFactory) ConstructUpdate(
 	if err != nil {
 		return nil, err
 	}
+	deferredChecks, err := ef.planner.registerDeferrableConstraints(tabDesc, fkTables, row.CheckUpdates)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create the table updater, which does the bulk of the work. In the HP,
 	// the updater derives the columns that need to be fetched. By contrast, the
@@ -1261,6 +1278,7 @@ func (ef *execFactory) ConstructUpdate(
 	if err != nil {
 		return nil, err
 	}
+	ru.SetDeferredChecks(deferredChecks)
 
 	// Truncate any FetchCols added by MakeUpdater. The optimizer has already
 	// computed a correct set that can sometimes be smaller.
@@ -1301,6 +1319,7 @@ func (ef *execFactory) ConstructUpdate(
 			updateColsIdx: updateColsIdx,
 		},
 	}
+	upd.run.triggers.init(table, tree.TriggerUpdate, triggers)
 
 	// Serialize the data-modifying plan to ensure that no data is observed that
 	// hasn't been validated first. See the comments on BatchedNext() in
@@ -1355,6 +1374,10 @@ func (ef *execFactory) ConstructUpsert(
 	if err != nil {
 		return nil, err
 	}
+	deferredChecks, err := ef.planner.registerDeferrableConstraints(tabDesc, fkTables, row.CheckUpdates)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create the table inserter, which does the bulk of the insert-related work.
 	ri, err := row.MakeInserter(ef.planner.txn, tabDesc, fkTables, insertColDescs,
@@ -1362,6 +1385,7 @@ func (ef *execFactory) ConstructUpsert(
 	if err != nil {
 		return nil, err
 	}
+	ri.SetDeferredChecks(deferredChecks)
 
 	// Create the table updater, which does the bulk of the update-related work.
 	// In the HP, the updater derives the columns that need to be fetched. By
@@ -1381,6 +1405,7 @@ func (ef *execFactory) ConstructUpsert(
 	if err != nil {
 		return nil, err
 	}
+	ru.SetDeferredChecks(deferredChecks)
 
 	// Truncate any FetchCols added by MakeUpdater. The optimizer has already
 	// computed a correct set that can sometimes be smaller.
@@ -1443,7 +1468,11 @@ func (ef *execFactory) ConstructUpsert(
 }
 
 func (ef *execFactory) ConstructDelete(
-	input exec.Node, table cat.Table, fetchCols exec.ColumnOrdinalSet, rowsNeeded bool,
+	input exec.Node,
+	table cat.Table,
+	fetchCols exec.ColumnOrdinalSet,
+	triggers exec.TriggerOrdinalSet,
+	rowsNeeded bool,
 ) (exec.Node, error) {
 	// Derive table and column descriptors.
 	tabDesc := table.(*optTable).desc
@@ -1454,11 +1483,19 @@ func (ef *execFactory) ConstructDelete(
 	if err != nil {
 		return nil, err
 	}
+	deferredChecks, err := ef.planner.registerDeferrableConstraints(tabDesc, fkTables, row.CheckDeletes)
+	if err != nil {
+		return nil, err
+	}
 
-	fastPathInterleaved := canDeleteFastInterleaved(tabDesc, fkTables)
-	if fastPathNode, ok := maybeCreateDeleteFastNode(
-		context.TODO(), input.(planNode), tabDesc, fastPathInterleaved, rowsNeeded); ok {
-		return fastPathNode, nil
+	// The fast paths don't fire triggers, nor report the deleted rows to the
+	// deferrable constraints.
+	if len(tabDesc.Triggers) == 0 && deferredChecks == nil {
+		fastPathInterleaved := canDeleteFastInterleaved(tabDesc, fkTables)
+		if fastPathNode, ok := maybeCreateDeleteFastNode(
+			context.TODO(), input.(planNode), tabDesc, fastPathInterleaved, rowsNeeded); ok {
+			return fastPathNode, nil
+		}
 	}
 
 	// Create the table deleter, which does the bulk of the work. In the HP,
@@ -1477,6 +1514,7 @@ func (ef *execFactory) ConstructDelete(
 	if err != nil {
 		return nil, err
 	}
+	rd.SetDeferredChecks(deferredChecks)
 
 	// Truncate any FetchCols added by MakeUpdater. The optimizer has already
 	// computed a correct set that can sometimes be smaller.
@@ -1501,6 +1539,7 @@ func (ef *execFactory) ConstructDelete(
 			rowsNeeded: rowsNeeded,
 		},
 	}
+	del.run.triggers.init(table, tree.TriggerDelete, triggers)
 
 	// Serialize the data-modifying plan to ensure that no data is observed that
 	// hasn't been validated first. See the comments on BatchedNext() in
@@ -1562,6 +1601,26 @@ func (ef *execFactory) ConstructSaveTable(
 	return ef.planner.makeSaveTable(input.(planNode), table, colNames), nil
 }
 
+// ConstructRecursiveCTE is part of the exec.Factory interface.
+func (ef *execFactory) ConstructRecursiveCTE(
+	initial exec.Node, fn exec.RecursiveCTEIterationFn, label string, deduplicate bool,
+) (exec.Node, error) {
+	return &recursiveCTENode{
+		initial:        initial.(planNode),
+		genIterationFn: fn,
+		label:          label,
+		deduplicate:    deduplicate,
+	}, nil
+}
+
+// ConstructScanBuffer is part of the exec.Factory interface.
+func (ef *execFactory) ConstructScanBuffer(ref exec.BufferNode, label string) (exec.Node, error) {
+	return &scanBufferNode{
+		buffer: ref.(*bufferNode),
+		label:  label,
+	}, nil
+}
+
 // renderBuilder encapsulates the code to build a renderNode.
 type renderBuilder struct {
 	r   *renderNode
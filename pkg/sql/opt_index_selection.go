@@ -107,6 +107,12 @@ func (p *planner) selectIndex(
 
 	candidates := make([]*indexInfo, 0, len(s.desc.Indexes)+1)
 	if s.specifiedIndex != nil {
+		// Partial indexes are only considered by the cost-based optimizer, which
+		// can determine whether the filter implies their predicate.
+		if s.specifiedIndex.IsPartial() {
+			return nil, fmt.Errorf("index \"%s\" is partial and cannot be used for this query",
+				s.specifiedIndex.Name)
+		}
 		// An explicit secondary index was requested. Only add it to the candidate
 		// indexes list.
 		candidates = append(candidates, &indexInfo{
@@ -120,6 +126,9 @@ func (p *planner) selectIndex(
 			index: &s.desc.PrimaryIndex,
 		})
 		for i := range s.desc.Indexes {
+			if s.desc.Indexes[i].IsPartial() {
+				continue
+			}
 			candidates = append(candidates, &indexInfo{
 				desc:  s.desc,
 				index: &s.desc.Indexes[i],
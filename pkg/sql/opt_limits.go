@@ -208,6 +208,7 @@ func (p *planner) applyLimit(plan planNode, numRows int64, soft bool) {
 	case *alterIndexNode:
 	case *alterTableNode:
 	case *alterSequenceNode:
+	case *alterTypeNode:
 	case *alterUserSetPasswordNode:
 	case *deleteRangeNode:
 	case *renameColumnNode:
@@ -224,12 +225,19 @@ func (p *planner) applyLimit(plan planNode, numRows int64, soft bool) {
 	case *CreateUserNode:
 	case *createViewNode:
 	case *createSequenceNode:
+	case *createTypeNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
 	case *createStatsNode:
+	case *refreshMaterializedViewNode:
 	case *dropDatabaseNode:
 	case *dropIndexNode:
 	case *dropTableNode:
 	case *dropViewNode:
 	case *dropSequenceNode:
+	case *dropTypeNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
 	case *DropUserNode:
 	case *zeroNode:
 	case *unaryNode:
@@ -243,7 +251,7 @@ func (p *planner) applyLimit(plan planNode, numRows int64, soft bool) {
 	case *scatterNode:
 	case *scanBufferNode:
 
-	case *applyJoinNode, *lookupJoinNode, *zigzagJoinNode, *saveTableNode:
+	case *applyJoinNode, *lookupJoinNode, *zigzagJoinNode, *saveTableNode, *recursiveCTENode:
 		// These nodes are only planned by the optimizer.
 
 	default:
@@ -16,6 +16,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/distsqlrun"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
 
@@ -51,9 +52,39 @@ func (p *planner) optimizePlan(
 	// the needed columns are properly computed for newly expanded nodes.
 	setNeededColumns(newPlan, needed)
 
+	if err := checkVirtualColumnScans(ctx, newPlan); err != nil {
+		return newPlan, err
+	}
+
 	return newPlan, nil
 }
 
+// checkVirtualColumnScans returns an error if a scan of the plan needs the
+// value of a virtual computed column from an index that doesn't store it. Only
+// the cost-based optimizer knows how to compute the values of virtual computed
+// columns from the other columns.
+func checkVirtualColumnScans(ctx context.Context, plan planNode) error {
+	return walkPlan(ctx, plan, planObserver{
+		enterNode: func(_ context.Context, _ string, plan planNode) (bool, error) {
+			scan, ok := plan.(*scanNode)
+			if !ok {
+				return true, nil
+			}
+			for i := range scan.cols {
+				col := &scan.cols[i]
+				if !col.Virtual || !scan.valNeededForCol.Contains(i) {
+					continue
+				}
+				if scan.index.ID == scan.desc.PrimaryIndex.ID || !scan.index.ContainsColumnID(col.ID) {
+					return false, pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+						"virtual computed column %q can only be read by the cost-based optimizer", col.Name)
+				}
+			}
+			return true, nil
+		},
+	})
+}
+
 // optimizeSubquery ensures plan optimization has been perfomed on the given subquery.
 func (p *planner) optimizeSubquery(ctx context.Context, sq *subquery) error {
 	if sq.expanded {
@@ -349,6 +349,16 @@ func newDecimal(prec, scale int32) (*types.T, error) {
 	return types.MakeDecimal(prec, scale), nil
 }
 
+// checkIntervalPrecision checks that the fractional second precision of an
+// INTERVAL type is supported.
+func checkIntervalPrecision(prec int32) error {
+	if prec < 0 || prec > 6 {
+		return pgerror.Newf(pgerror.CodeInvalidParameterValueError,
+			"INTERVAL(%d) precision must be between 0 and 6", prec)
+	}
+	return nil
+}
+
 // ArrayOf creates a type alias for an array of the given element type and fixed
 // bounds.
 func arrayOf(colType *types.T, bounds []int32) (*types.T, error) {
@@ -356,8 +366,12 @@ func arrayOf(colType *types.T, bounds []int32) (*types.T, error) {
 		return nil, err
 	}
 
-	// Currently bounds are ignored.
-	return types.MakeArray(colType), nil
+	// Currently bounds are ignored, except for the number of dimensions.
+	typ := types.MakeArray(colType)
+	for i := 1; i < len(bounds); i++ {
+		typ = types.MakeArray(typ)
+	}
+	return typ, nil
 }
 
 // The SERIAL types are pseudo-types that are only used during parsing. After
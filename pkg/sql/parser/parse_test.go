@@ -95,10 +95,17 @@ func TestParse(t *testing.T) {
 		{`CREATE INVERTED INDEX a ON b.c (d)`},
 		{`CREATE INVERTED INDEX a ON b (c) STORING (d)`},
 		{`CREATE INVERTED INDEX a ON b (c) INTERLEAVE IN PARENT d (e)`},
+		{`CREATE INDEX a ON b (c) WHERE d > 0`},
+		{`CREATE INDEX a ON b (c) STORING (d) WHERE (d IS NOT NULL) AND (e = 'f')`},
+		{`CREATE UNIQUE INDEX a ON b (c) WHERE NOT d`},
+		{`CREATE INDEX IF NOT EXISTS a ON b (c) WHERE d > 0`},
 
 		{`CREATE TABLE a ()`},
 		{`EXPLAIN CREATE TABLE a ()`},
 		{`CREATE TABLE a (b INT8)`},
+		{`CREATE TEMPORARY TABLE a (b INT8)`},
+		{`CREATE TEMPORARY TABLE IF NOT EXISTS a (b INT8)`},
+		{`CREATE TEMPORARY TABLE a AS SELECT * FROM b`},
 		{`CREATE TABLE a (b INT8, c INT8)`},
 		{`CREATE TABLE a (b CHAR)`},
 		{`CREATE TABLE a (b CHAR(3))`},
@@ -110,6 +117,8 @@ func TestParse(t *testing.T) {
 		{`CREATE TABLE a (b FLOAT8)`},
 		{`CREATE TABLE a (b SERIAL8)`},
 		{`CREATE TABLE a (b TIME)`},
+		{`CREATE TABLE a (b TIMETZ)`},
+		{`CREATE TABLE a (b INTERVAL DAY TO SECOND(3))`},
 		{`CREATE TABLE a (b UUID)`},
 		{`CREATE TABLE a (b INET)`},
 		{`CREATE TABLE a (b "char")`},
@@ -142,6 +151,11 @@ func TestParse(t *testing.T) {
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b) REFERENCES other ON UPDATE SET NULL)`},
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b) REFERENCES other ON DELETE SET NULL)`},
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b) REFERENCES other ON DELETE SET NULL ON UPDATE SET NULL)`},
+		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b) REFERENCES other DEFERRABLE INITIALLY IMMEDIATE)`},
+		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b) REFERENCES other DEFERRABLE INITIALLY DEFERRED)`},
+		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b) REFERENCES other ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED)`},
+		{`CREATE TABLE a (b INT8, c STRING, UNIQUE (b) DEFERRABLE INITIALLY IMMEDIATE)`},
+		{`CREATE TABLE a (b INT8, c STRING, CONSTRAINT d UNIQUE (b, c) DEFERRABLE INITIALLY DEFERRED)`},
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b) REFERENCES other ON UPDATE SET DEFAULT)`},
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b) REFERENCES other ON DELETE SET DEFAULT)`},
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b) REFERENCES other ON DELETE SET DEFAULT ON UPDATE SET DEFAULT)`},
@@ -200,13 +214,25 @@ func TestParse(t *testing.T) {
 		{`CREATE TABLE a (b INT8, INDEX (b) STORING (c))`},
 		{`CREATE TABLE a (b INT8, c STRING, INDEX (b ASC, c DESC) STORING (c))`},
 		{`CREATE TABLE a (b INT8, INDEX (b) INTERLEAVE IN PARENT c (d, e))`},
+		{`CREATE TABLE a (b INT8, INDEX (b) WHERE b > 0)`},
+		{`CREATE TABLE a (b INT8, c BOOL, UNIQUE INDEX d (b) STORING (c) WHERE c)`},
 		{`CREATE TABLE a (b INT8, FAMILY (b))`},
 		{`CREATE TABLE a (b INT8, c STRING, FAMILY foo (b), FAMILY (c))`},
+		{`CREATE TABLE a (LIKE b)`},
+		{`CREATE TABLE a (LIKE b INCLUDING ALL)`},
+		{`CREATE TABLE a (LIKE b INCLUDING ALL EXCLUDING INDEXES, c INT8)`},
+		{`CREATE TABLE a (LIKE b INCLUDING DEFAULTS INCLUDING CONSTRAINTS EXCLUDING GENERATED, LIKE c INCLUDING INDEXES)`},
 		{`CREATE TABLE a (b INT8) INTERLEAVE IN PARENT foo (c, d)`},
 		{`CREATE TABLE a (b INT8) INTERLEAVE IN PARENT foo (c) CASCADE`},
 		{`CREATE TABLE a.b (b INT8)`},
 		{`CREATE TABLE IF NOT EXISTS a (b INT8)`},
 		{`CREATE TABLE a (b INT8 AS (a + b) STORED)`},
+		{`CREATE TABLE a (b INT8 AS (a + b) VIRTUAL)`},
+		{`CREATE TABLE a (b INT8 GENERATED ALWAYS AS IDENTITY)`},
+		{`CREATE TABLE a (b INT8 PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY)`},
+		{`CREATE TABLE a (b INT8 GENERATED ALWAYS AS IDENTITY (START WITH 10 INCREMENT BY 5 MAXVALUE 100))`},
+		{`CREATE TABLE a (b INT8 GENERATED BY DEFAULT AS IDENTITY (START 2))`},
+		{`CREATE TABLE a (b STRING AS (lower(c)) VIRTUAL, INDEX (b))`},
 		{`CREATE TABLE view (view INT8)`},
 
 		{`CREATE TABLE a (b INT8 CONSTRAINT c PRIMARY KEY)`},
@@ -260,6 +286,9 @@ func TestParse(t *testing.T) {
 		{`CREATE TABLE a (b STRING(3)[] COLLATE de)`},
 
 		{`CREATE VIEW a AS SELECT * FROM b`},
+		{`CREATE TEMPORARY VIEW a AS SELECT * FROM b`},
+		{`CREATE MATERIALIZED VIEW a AS SELECT * FROM b`},
+		{`CREATE MATERIALIZED VIEW a (x, y) AS SELECT c, d FROM b`},
 		{`EXPLAIN CREATE VIEW a AS SELECT * FROM b`},
 		{`CREATE VIEW a AS SELECT b.* FROM b LIMIT 5`},
 		{`CREATE VIEW a AS (SELECT c, d FROM b WHERE c > 0 ORDER BY c)`},
@@ -286,8 +315,42 @@ func TestParse(t *testing.T) {
 		{`CREATE SEQUENCE a START WITH 1000`},
 		{`CREATE SEQUENCE a INCREMENT 5 NO MAXVALUE MINVALUE 1 START 3`},
 		{`CREATE SEQUENCE a INCREMENT 5 NO CYCLE NO MAXVALUE MINVALUE 1 START 3 CACHE 1`},
+		{`CREATE SEQUENCE a OWNED BY b.c`},
+		{`CREATE SEQUENCE a OWNED BY db.sc.b.c`},
+		{`CREATE SEQUENCE a OWNED BY NONE`},
 		{`CREATE SEQUENCE a VIRTUAL`},
 
+		{`CREATE TYPE a AS ENUM ()`},
+		{`CREATE TYPE a AS ENUM ('b', 'c')`},
+		{`EXPLAIN CREATE TYPE a AS ENUM ('b')`},
+		{`CREATE TYPE a.b AS ENUM ('c')`},
+		{`CREATE TYPE a AS ()`},
+		{`CREATE TYPE a AS (b INT8, c STRING)`},
+		{`CREATE TYPE a.b AS (c INT8[], d DECIMAL(10,2))`},
+		{`EXPLAIN CREATE TYPE a AS (b INT8)`},
+		{`ALTER TYPE a ADD VALUE 'b'`},
+		{`ALTER TYPE a ADD VALUE IF NOT EXISTS 'b'`},
+		{`ALTER TYPE a ADD VALUE 'b' BEFORE 'c'`},
+		{`ALTER TYPE a.b ADD VALUE IF NOT EXISTS 'c' AFTER 'd'`},
+		{`DROP TYPE a`},
+		{`DROP TYPE IF EXISTS a, b.c`},
+		{`DROP TYPE a CASCADE`},
+
+		{`CREATE FUNCTION f() RETURNS INT8 AS 'SELECT 1'`},
+		{`CREATE FUNCTION a.f(x INT8, STRING) RETURNS STRING LANGUAGE sql IMMUTABLE AS 'SELECT $2'`},
+		{`CREATE OR REPLACE FUNCTION f(x INT8) RETURNS SETOF INT8 STABLE STRICT AS 'SELECT generate_series(1, x)'`},
+		{`CREATE FUNCTION f(x DECIMAL) RETURNS DECIMAL AS 'SELECT x' VOLATILE CALLED ON NULL INPUT`},
+		{`CREATE FUNCTION f(x INT8[]) RETURNS BOOL AS 'SELECT true' RETURNS NULL ON NULL INPUT LANGUAGE sql`},
+		{`DROP FUNCTION f`},
+		{`DROP FUNCTION f()`},
+		{`DROP FUNCTION IF EXISTS f(INT8, x STRING), a.g CASCADE`},
+
+		{`CREATE TRIGGER a BEFORE INSERT ON b FOR EACH ROW AS 'SELECT new.x + 1'`},
+		{`CREATE TRIGGER a AFTER INSERT OR UPDATE OR DELETE ON b.c FOR EACH STATEMENT AS 'UPDATE d SET n = n + 1; DELETE FROM e'`},
+		{`CREATE TRIGGER a AFTER UPDATE ON b FOR EACH ROW WHEN (old.x IS DISTINCT FROM new.x) AS 'INSERT INTO log VALUES (old.x, new.x)'`},
+		{`DROP TRIGGER a ON b`},
+		{`DROP TRIGGER IF EXISTS a ON b.c CASCADE`},
+
 		{`CREATE STATISTICS a ON col1 FROM t`},
 		{`EXPLAIN CREATE STATISTICS a ON col1 FROM t`},
 		{`CREATE STATISTICS a ON col1, col2 FROM t`},
@@ -347,6 +410,8 @@ func TestParse(t *testing.T) {
 		{`DROP VIEW IF EXISTS a, b RESTRICT`},
 		{`DROP VIEW a.b CASCADE`},
 		{`DROP VIEW a, b CASCADE`},
+		{`DROP MATERIALIZED VIEW a`},
+		{`DROP MATERIALIZED VIEW IF EXISTS a, b CASCADE`},
 		{`DROP SEQUENCE a`},
 		{`EXPLAIN DROP SEQUENCE a`},
 		{`DROP SEQUENCE a.b`},
@@ -492,6 +557,10 @@ func TestParse(t *testing.T) {
 		{`SHOW SYNTAX 'select 1'`},
 		{`EXPLAIN SHOW SYNTAX 'select 1'`},
 
+		{`REFRESH MATERIALIZED VIEW a`},
+		{`REFRESH MATERIALIZED VIEW CONCURRENTLY a.b`},
+		{`REFRESH MATERIALIZED VIEW concurrently`},
+
 		{`PREPARE a AS SELECT 1`},
 		{`PREPARE a AS EXPLAIN SELECT 1`},
 		{`PREPARE a (INT8) AS SELECT $1`},
@@ -545,6 +614,7 @@ func TestParse(t *testing.T) {
 		{`GRANT DROP ON DATABASE foo TO root`},
 		{`GRANT ALL ON DATABASE foo TO root, test`},
 		{`GRANT SELECT, INSERT ON DATABASE bar TO foo, bar, baz`},
+		{`GRANT EXECUTE ON FUNCTION f, db.g TO foo`},
 		{`GRANT SELECT, INSERT ON DATABASE db1, db2 TO foo, bar, baz`},
 		{`GRANT SELECT, INSERT ON DATABASE db1, db2 TO "test-user"`},
 		{`GRANT rolea, roleb TO usera, userb`},
@@ -555,6 +625,7 @@ func TestParse(t *testing.T) {
 		{`REVOKE SELECT ON TABLE foo FROM root`},
 		{`REVOKE UPDATE, DELETE ON TABLE foo, db.foo FROM root, bar`},
 		{`REVOKE INSERT ON DATABASE foo FROM root`},
+		{`REVOKE ALL ON FUNCTION f FROM foo`},
 		{`REVOKE ALL ON DATABASE foo FROM root, test`},
 		{`REVOKE SELECT, INSERT ON DATABASE bar FROM foo, bar, baz`},
 		{`REVOKE SELECT, INSERT ON DATABASE db1, db2 FROM foo, bar, baz`},
@@ -569,6 +640,10 @@ func TestParse(t *testing.T) {
 		{`INSERT INTO a VALUES (1, 2), (3, 4)`},
 		{`INSERT INTO a VALUES (a + 1, 2 * 3)`},
 		{`INSERT INTO a(a, b) VALUES (1, 2)`},
+		{`INSERT INTO a(a, b.c, b.d) VALUES (1, 2, 3)`},
+		{`UPSERT INTO a(a, b.c) VALUES (1, 2)`},
+		{`INSERT INTO a OVERRIDING SYSTEM VALUE VALUES (1, 2)`},
+		{`INSERT INTO a(a, b) OVERRIDING USER VALUE SELECT c, d FROM e`},
 		{`INSERT INTO a SELECT b, c FROM d`},
 		{`INSERT INTO a DEFAULT VALUES`},
 		{`INSERT INTO a VALUES (1) RETURNING a, b`},
@@ -604,6 +679,10 @@ func TestParse(t *testing.T) {
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET (a, b) = (SELECT 1, 2) RETURNING 1, 2`},
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET (a, b) = (SELECT 1, 2) RETURNING a + b`},
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET (a, b) = (SELECT 1, 2) RETURNING NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT (a) WHERE b > 2 DO NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT (a, b) WHERE c = 'x' AND d DO UPDATE SET a = 1 WHERE b > 2`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT ON CONSTRAINT a_key DO NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT ON CONSTRAINT "primary" DO UPDATE SET a = excluded.a RETURNING a`},
 
 		{`SELECT 1 + 1`},
 		{`SELECT -1`},
@@ -616,6 +695,7 @@ func TestParse(t *testing.T) {
 		{`SELECT true = false`},
 		{`SELECT (true = false)`},
 		{`SELECT (ARRAY['a', 'b'])[2]`},
+		{`SELECT (ARRAY[ARRAY['a', 'b'], ARRAY['c', 'd']])[2][1]`},
 		{`SELECT (ARRAY (VALUES (1), (2)))[1]`},
 		{`SELECT (SELECT 1)`},
 		{`SELECT ((SELECT 1))`},
@@ -706,6 +786,11 @@ func TestParse(t *testing.T) {
 		{`SELECT 'foo'::TIMESTAMP(6)`},
 		{`SELECT 'foo'::TIMESTAMPTZ(6)`},
 		{`SELECT 'foo'::TIME(6)`},
+		{`SELECT 'foo'::TIMETZ(6)`},
+		{`SELECT 'foo'::INTERVAL(3)`},
+		{`SELECT 'foo'::INTERVAL YEAR`},
+		{`SELECT 'foo'::INTERVAL HOUR TO MINUTE`},
+		{`SELECT 'foo'::INTERVAL SECOND(0)`},
 
 		{`SELECT '192.168.0.1'::INET`},
 		{`SELECT '192.168.0.1':::INET`},
@@ -738,6 +823,7 @@ func TestParse(t *testing.T) {
 
 		{`SELECT "FROM" FROM t`},
 		{`SELECT CAST(1 AS STRING)`},
+		{`SELECT CAST(ARRAY[ARRAY[1]] AS INT8[][])`},
 		{`SELECT ANNOTATE_TYPE(1, STRING)`},
 		{`SELECT a FROM t AS bar`},
 		{`SELECT a FROM t AS bar (bar1)`},
@@ -750,6 +836,8 @@ func TestParse(t *testing.T) {
 		{`SELECT a FROM (SELECT 1 FROM t) AS bar (bar1, bar2, bar3)`},
 		{`SELECT a FROM (SELECT 1 FROM t) WITH ORDINALITY`},
 		{`SELECT a FROM (SELECT 1 FROM t) WITH ORDINALITY AS bar`},
+		{`WITH RECURSIVE a AS (SELECT 1 UNION ALL SELECT a + 1 FROM a) SELECT * FROM a`},
+		{`WITH RECURSIVE a (x) AS (SELECT 1 UNION SELECT x + 1 FROM a WHERE x < 10) SELECT x FROM a`},
 		{`SELECT a FROM ROWS FROM (a(x), b(y), c(z))`},
 		{`SELECT a FROM t1, t2`},
 		{`SELECT a FROM t1, LATERAL (SELECT * FROM t2 WHERE a = b)`},
@@ -960,6 +1048,19 @@ func TestParse(t *testing.T) {
 		{`SELECT a FROM t LIMIT a`},
 		{`SELECT a FROM t OFFSET b`},
 		{`SELECT a FROM t LIMIT a OFFSET b`},
+
+		{`SELECT a FROM t FOR UPDATE`},
+		{`SELECT a FROM t FOR NO KEY UPDATE`},
+		{`SELECT a FROM t FOR SHARE`},
+		{`SELECT a FROM t FOR KEY SHARE`},
+		{`SELECT a FROM t FOR UPDATE OF t`},
+		{`SELECT a FROM t, u FOR UPDATE OF t, db.public.u`},
+		{`SELECT a FROM t FOR UPDATE SKIP LOCKED`},
+		{`SELECT a FROM t FOR SHARE NOWAIT`},
+		{`SELECT a FROM t, u FOR UPDATE OF t NOWAIT FOR SHARE OF u SKIP LOCKED`},
+		{`SELECT a FROM t ORDER BY a LIMIT 1 FOR UPDATE`},
+		{`WITH cte AS (SELECT a FROM t) SELECT a FROM cte LIMIT 1 FOR UPDATE`},
+		{`SELECT a FROM (SELECT a FROM t FOR UPDATE)`},
 		{`SELECT DISTINCT * FROM t`},
 		{`SELECT DISTINCT a, b FROM t`},
 		{`SELECT DISTINCT ON (a, b) c FROM t`},
@@ -978,6 +1079,8 @@ func TestParse(t *testing.T) {
 		{`SET TRANSACTION PRIORITY NORMAL`},
 		{`SET TRANSACTION PRIORITY HIGH`},
 		{`SET TRANSACTION ISOLATION LEVEL SERIALIZABLE, PRIORITY HIGH`},
+		{`SET CONSTRAINTS ALL DEFERRED`},
+		{`SET CONSTRAINTS ALL IMMEDIATE`},
 
 		{`SET TRACING = off`},
 		{`EXPLAIN SET TRACING = off`},
@@ -1025,6 +1128,8 @@ func TestParse(t *testing.T) {
 		{`UPDATE a SET b = 3, c = DEFAULT`},
 		{`UPDATE a SET b = 3 + 4`},
 		{`UPDATE a SET (b, c) = (3, DEFAULT)`},
+		{`UPDATE a SET b.c = 3`},
+		{`UPDATE a SET (b, c.d) = (3, 4)`},
 		{`UPDATE a SET (b, c) = (SELECT 3, 4)`},
 		{`UPDATE a SET b = 3 WHERE a = b`},
 		{`UPDATE a SET b = 3 WHERE a = b LIMIT c`},
@@ -1035,6 +1140,10 @@ func TestParse(t *testing.T) {
 		{`UPDATE a SET b = 3 WHERE a = b RETURNING a, a + b`},
 		{`UPDATE a SET b = 3 WHERE a = b RETURNING NOTHING`},
 		{`UPDATE a SET b = 3 WHERE a = b ORDER BY c LIMIT d RETURNING e`},
+		{`UPDATE a SET b = c FROM d`},
+		{`UPDATE a SET b = d.c FROM d, e WHERE a.x = d.x AND d.y = e.y`},
+		{`UPDATE a AS x SET b = y.c FROM (SELECT c FROM d) AS y WHERE x.b < y.c RETURNING x.b`},
+		{`UPDATE a SET b = c FROM d JOIN e USING (f) WHERE a.f = d.f ORDER BY c LIMIT 1`},
 
 		{`UPDATE t AS "0" SET k = ''`},                 // "0" lost its quotes
 		{`SELECT * FROM "0" JOIN "0" USING (id, "0")`}, // last "0" lost its quotes.
@@ -1090,6 +1199,7 @@ func TestParse(t *testing.T) {
 		{`ALTER TABLE a ALTER COLUMN b SET DEFAULT NULL`},
 		{`ALTER TABLE a ALTER COLUMN b DROP DEFAULT`},
 		{`ALTER TABLE a ALTER COLUMN b DROP NOT NULL`},
+		{`ALTER TABLE a ALTER COLUMN b SET NOT NULL`},
 		{`ALTER TABLE a ALTER COLUMN b DROP STORED`},
 
 		{`ALTER TABLE a ALTER COLUMN b SET DATA TYPE INT8`},
@@ -1220,6 +1330,8 @@ func TestParse(t *testing.T) {
 		{`ALTER SEQUENCE IF EXISTS a RENAME TO b`},
 
 		{`ALTER SEQUENCE a INCREMENT BY 5 START WITH 1000`},
+		{`ALTER SEQUENCE a OWNED BY b.c`},
+		{`ALTER SEQUENCE a OWNED BY NONE`},
 		{`EXPLAIN ALTER SEQUENCE a INCREMENT BY 5 START WITH 1000`},
 		{`ALTER SEQUENCE IF EXISTS a INCREMENT BY 5 START WITH 1000`},
 		{`ALTER SEQUENCE IF EXISTS a NO CYCLE CACHE 1`},
@@ -1297,6 +1409,9 @@ func TestParse(t *testing.T) {
 		// {`CREATE CHANGEFEED FOR TABLE foo PARTITION bar, baz INTO 'sink'`},
 		// {`CREATE CHANGEFEED FOR DATABASE foo INTO 'sink'`},
 		{`CREATE CHANGEFEED FOR TABLE foo INTO 'sink' WITH bar = 'baz'`},
+		{`CREATE CHANGEFEED INTO 'sink' AS SELECT * FROM foo`},
+		{`CREATE CHANGEFEED INTO 'sink' WITH bar = 'baz' AS SELECT a, b + 1 AS c FROM db.foo WHERE a > 1`},
+		{`EXPERIMENTAL CHANGEFEED AS SELECT a FROM foo WHERE b = 'x'`},
 
 		// Regression for #15926
 		{`SELECT * FROM ((t1 NATURAL JOIN t2 WITH ORDINALITY AS o1)) WITH ORDINALITY AS o2`},
@@ -1325,6 +1440,18 @@ func TestParse2(t *testing.T) {
 		sql      string
 		expected string
 	}{
+		{`CREATE TEMP TABLE a (b INT8)`, `CREATE TEMPORARY TABLE a (b INT8)`},
+		{`CREATE LOCAL TEMPORARY TABLE a (b INT8)`, `CREATE TEMPORARY TABLE a (b INT8)`},
+		{`CREATE GLOBAL TEMP TABLE a (b INT8)`, `CREATE TEMPORARY TABLE a (b INT8)`},
+		{`CREATE FUNCTION f(x INT) RETURNS TEXT LANGUAGE SQL AS 'SELECT x::TEXT || ''a'''`,
+			`CREATE FUNCTION f(x INT8) RETURNS STRING LANGUAGE sql AS e'SELECT x::TEXT || \'a\''`},
+		{`CREATE FUNCTION f(x INT) RETURNS INT LANGUAGE 'sql' AS 'SELECT x'`,
+			`CREATE FUNCTION f(x INT8) RETURNS INT8 LANGUAGE sql AS 'SELECT x'`},
+		{`CREATE TRIGGER a AFTER DELETE ON b AS 'SELECT 1'`,
+			`CREATE TRIGGER a AFTER DELETE ON b FOR EACH STATEMENT AS 'SELECT 1'`},
+		{`CREATE TRIGGER a BEFORE UPDATE ON b FOR ROW WHEN (NEW.x > 0) AS 'SELECT NEW.x'`,
+			`CREATE TRIGGER a BEFORE UPDATE ON b FOR EACH ROW WHEN (new.x > 0) AS 'SELECT NEW.x'`},
+		{`CREATE TEMP VIEW a AS SELECT * FROM b`, `CREATE TEMPORARY VIEW a AS SELECT * FROM b`},
 		{`CREATE DATABASE a WITH ENCODING = 'foo'`,
 			`CREATE DATABASE a ENCODING = 'foo'`},
 		{`CREATE DATABASE a TEMPLATE = template0`,
@@ -1377,6 +1504,13 @@ func TestParse2(t *testing.T) {
 		{`SELECT CAST(1 AS "timestamp")`, `SELECT CAST(1 AS TIMESTAMP)`},
 		{`SELECT CAST(1 AS _int8)`, `SELECT CAST(1 AS INT8[])`},
 		{`SELECT CAST(1 AS "_int8")`, `SELECT CAST(1 AS INT8[])`},
+		{`CREATE TABLE a (b INT[][], c INT[2][3], d INT ARRAY[2])`,
+			`CREATE TABLE a (b INT8[][], c INT8[][], d INT8[])`},
+		{`SELECT CAST(1.2+2.3 AS notatype)`, `SELECT CAST(1.2 + 2.3 AS notatype)`},
+		{`SELECT ANNOTATE_TYPE(1.2+2.3, notatype)`, `SELECT ANNOTATE_TYPE(1.2 + 2.3, notatype)`},
+		{`SELECT 'f'::"blah"`, `SELECT 'f'::blah`},
+		{`SELECT 'f'::blah[]`, `SELECT 'f'::blah[]`},
+		{`SELECT foo''`, `SELECT foo ''`},
 		{`SELECT SERIAL8 'foo', 'foo'::SERIAL8`, `SELECT INT8 'foo', 'foo'::INT8`},
 
 		{`SELECT 'a' FROM t@{FORCE_INDEX=bar}`, `SELECT 'a' FROM t@bar`},
@@ -1472,6 +1606,20 @@ func TestParse2(t *testing.T) {
 			`SELECT current_timestamp()`},
 		{`SELECT CURRENT_DATE`,
 			`SELECT current_date()`},
+		{`SELECT CURRENT_TIME`,
+			`SELECT current_time()`},
+		{`SELECT CURRENT_TIME()`,
+			`SELECT current_time()`},
+		{`SELECT 'foo'::TIME WITH TIME ZONE`,
+			`SELECT 'foo'::TIMETZ`},
+		{`SELECT 'foo'::TIME(6) WITH TIME ZONE`,
+			`SELECT 'foo'::TIMETZ(6)`},
+		{`SELECT INTERVAL '1 day 02:03:04.5678' DAY TO SECOND(2)`,
+			`SELECT '1 day 02:03:04.57'`},
+		{`SELECT INTERVAL '1 day 02:03:04' HOUR`,
+			`SELECT '1 day 02:00:00'`},
+		{`SELECT INTERVAL(3) '1.23456s'`,
+			`SELECT '00:00:01.235'`},
 		{`SELECT POSITION(a IN b)`,
 			`SELECT strpos(b, a)`},
 		{`SELECT TRIM(BOTH a FROM b)`,
@@ -1511,6 +1659,15 @@ func TestParse2(t *testing.T) {
 			`SELECT a FROM t LIMIT 2 * a OFFSET b`},
 		{`SELECT a FROM t FETCH FIRST (2 * a) ROWS ONLY OFFSET b`,
 			`SELECT a FROM t LIMIT 2 * a OFFSET b`},
+		// The locking clause can be placed before or after LIMIT, but it is
+		// always output last.
+		{`SELECT a FROM t FOR UPDATE LIMIT 1`,
+			`SELECT a FROM t LIMIT 1 FOR UPDATE`},
+		{`SELECT a FROM t ORDER BY a FOR SHARE OFFSET 2`,
+			`SELECT a FROM t ORDER BY a OFFSET 2 FOR SHARE`},
+		// FOR READ ONLY does not lock anything.
+		{`SELECT a FROM t FOR READ ONLY`,
+			`SELECT a FROM t`},
 		// Double negation. See #1800.
 		{`SELECT *,-/* comment */-5`,
 			`SELECT *, 5`},
@@ -1690,6 +1847,14 @@ func TestParse2(t *testing.T) {
 			`COMMIT TRANSACTION`},
 		{`BEGIN TRANSACTION PRIORITY LOW, ISOLATION LEVEL SNAPSHOT`,
 			`BEGIN TRANSACTION ISOLATION LEVEL SERIALIZABLE, PRIORITY LOW`},
+		{`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES c (x) DEFERRABLE)`,
+			`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES c (x) DEFERRABLE INITIALLY IMMEDIATE)`},
+		{`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES c (x) INITIALLY DEFERRED)`,
+			`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES c (x) DEFERRABLE INITIALLY DEFERRED)`},
+		{`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES c (x) INITIALLY IMMEDIATE)`,
+			`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES c (x))`},
+		{`CREATE TABLE a (b INT8, UNIQUE (b) DEFERRABLE)`,
+			`CREATE TABLE a (b INT8, UNIQUE (b) DEFERRABLE INITIALLY IMMEDIATE)`},
 		{`SET TRANSACTION PRIORITY NORMAL, ISOLATION LEVEL SERIALIZABLE`,
 			`SET TRANSACTION ISOLATION LEVEL SERIALIZABLE, PRIORITY NORMAL`},
 		{`SET TRANSACTION ISOLATION LEVEL SERIALIZABLE READ WRITE`,
@@ -2022,6 +2187,7 @@ func TestParse2(t *testing.T) {
 		{`ALTER TABLE a ADD b INT8 FAMILY fam_a`, `ALTER TABLE a ADD COLUMN b INT8 FAMILY fam_a`},
 		{`ALTER TABLE a DROP b`, `ALTER TABLE a DROP COLUMN b`},
 		{`ALTER TABLE a ALTER b DROP NOT NULL`, `ALTER TABLE a ALTER COLUMN b DROP NOT NULL`},
+		{`ALTER TABLE a ALTER b SET NOT NULL`, `ALTER TABLE a ALTER COLUMN b SET NOT NULL`},
 		{`ALTER TABLE a ALTER b TYPE INT8`, `ALTER TABLE a ALTER COLUMN b SET DATA TYPE INT8`},
 		{`EXPLAIN ANALYZE SELECT 1`, `EXPLAIN ANALYZE (DISTSQL) SELECT 1`},
 
@@ -2144,6 +2310,14 @@ SET TIME ZONE INTERVAL 'foobar'
 		{`SELECT INTERVAL 'foo'`, `syntax error: could not parse "foo" as type interval: interval: missing unit at position 0: "foo" at or near "EOF"
 SELECT INTERVAL 'foo'
                      ^
+`},
+		{`SELECT 'a'::INTERVAL(7)`, `syntax error: INTERVAL(7) precision must be between 0 and 6 at or near ")"
+SELECT 'a'::INTERVAL(7)
+                      ^
+`},
+		{`SELECT 'a'::INTERVAL SECOND(9)`, `syntax error: INTERVAL(9) precision must be between 0 and 6 at or near ")"
+SELECT 'a'::INTERVAL SECOND(9)
+                             ^
 `},
 		{`SELECT 1 /* hello`, `lexical error: unterminated comment
 SELECT 1 /* hello
@@ -2180,6 +2354,22 @@ CREATE TABLE test (
   foo INT8 DEFAULT 1 DEFAULT 2
 )
 ^
+`},
+		{`CREATE TABLE test (
+  foo INT8 DEFAULT 1 GENERATED ALWAYS AS IDENTITY
+)`, `syntax error: both default and identity specified for column "foo" at or near ")"
+CREATE TABLE test (
+  foo INT8 DEFAULT 1 GENERATED ALWAYS AS IDENTITY
+)
+^
+`},
+		{`CREATE TABLE test (
+  foo INT8 GENERATED ALWAYS AS IDENTITY GENERATED BY DEFAULT AS IDENTITY
+)`, `syntax error: multiple identity specifications for column "foo" at or near ")"
+CREATE TABLE test (
+  foo INT8 GENERATED ALWAYS AS IDENTITY GENERATED BY DEFAULT AS IDENTITY
+)
+^
 `},
 		{`CREATE TABLE test (
   foo INT8 REFERENCES t1 REFERENCES t2
@@ -2253,11 +2443,6 @@ HINT: try \h SELECT`},
 SELECT 1e-
        ^
 HINT: try \h SELECT`},
-		{"SELECT foo''",
-			`syntax error: type does not exist at or near ""
-SELECT foo''
-          ^
-`},
 		{
 			`SELECT 0x FROM t`,
 			`lexical error: invalid hexadecimal numeric literal
@@ -2355,20 +2540,6 @@ HINT: try \h INSERT`,
 ALTER TABLE t RENAME COLUMN x TO family
                                  ^
 HINT: try \h ALTER TABLE`,
-		},
-		{
-			`SELECT CAST(1.2+2.3 AS notatype)`,
-			`syntax error: type does not exist at or near "notatype"
-SELECT CAST(1.2+2.3 AS notatype)
-                       ^
-`,
-		},
-		{
-			`SELECT ANNOTATE_TYPE(1.2+2.3, notatype)`,
-			`syntax error: type does not exist at or near "notatype"
-SELECT ANNOTATE_TYPE(1.2+2.3, notatype)
-                              ^
-`,
 		},
 		{
 			`CREATE USER foo WITH PASSWORD`,
@@ -2431,13 +2602,6 @@ SELECT EXISTS(SELECT 1)[1]
 			`syntax error: + ANY <array> is invalid because "+" is not a boolean operator at or near "EOF"
 SELECT 1 + ANY ARRAY[1, 2, 3]
                              ^
-`,
-		},
-		{
-			`SELECT 'f'::"blah"`,
-			`syntax error: type does not exist at or near "blah"
-SELECT 'f'::"blah"
-            ^
 `,
 		},
 		// Ensure that the support for ON ROLE <namelist> doesn't leak
@@ -2562,6 +2726,13 @@ SELECT $123456789
 HINT: try \h SELECT`,
 		},
 
+		{
+			`CREATE TABLE a (b INT8, CHECK (b > 0) DEFERRABLE)`,
+			`syntax error: CHECK constraints cannot be marked DEFERRABLE at or near ")"
+CREATE TABLE a (b INT8, CHECK (b > 0) DEFERRABLE)
+                                                ^
+`,
+		},
 		{
 			`CREATE STATISTICS a ON col1 FROM t WITH OPTIONS THROTTLING 2.0`,
 			`syntax error: THROTTLING fraction must be between 0 and 1 at or near "2.0"
@@ -2827,7 +2998,6 @@ func TestUnimplementedSyntax(t *testing.T) {
 		expected string
 	}{
 		{`ALTER TABLE a ALTER CONSTRAINT foo`, 31632, `alter constraint`},
-		{`ALTER TABLE a ALTER b SET NOT NULL`, 28751, ``},
 
 		{`CREATE AGGREGATE a`, 0, `create aggregate`},
 		{`CREATE CAST a`, 0, `create cast`},
@@ -2837,10 +3007,7 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE EXTENSION a`, 0, `create extension a`},
 		{`CREATE FOREIGN DATA WRAPPER a`, 0, `create fdw`},
 		{`CREATE FOREIGN TABLE a`, 0, `create foreign table`},
-		{`CREATE FUNCTION a`, 17511, `create`},
-		{`CREATE OR REPLACE FUNCTION a`, 17511, `create`},
 		{`CREATE LANGUAGE a`, 17511, `create language a`},
-		{`CREATE MATERIALIZED VIEW a`, 24747, ``},
 		{`CREATE OPERATOR a`, 0, `create operator`},
 		{`CREATE PUBLICATION a`, 0, `create publication`},
 		{`CREATE RULE a`, 0, `create rule`},
@@ -2848,7 +3015,9 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE SERVER a`, 0, `create server`},
 		{`CREATE SUBSCRIPTION a`, 0, `create subscription`},
 		{`CREATE TEXT SEARCH a`, 7821, `create text`},
-		{`CREATE TRIGGER a`, 28296, `create`},
+		{`CREATE TRIGGER a BEFORE INSERT ON b EXECUTE FUNCTION f()`, 28296, `execute function`},
+		{`CREATE TRIGGER a BEFORE UPDATE OF c ON b AS 'SELECT 1'`, 28296, `update of`},
+		{`CREATE TRIGGER a BEFORE TRUNCATE ON b AS 'SELECT 1'`, 28296, `truncate`},
 
 		{`DROP AGGREGATE a`, 0, `drop aggregate`},
 		{`DROP CAST a`, 0, `drop cast`},
@@ -2858,7 +3027,6 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`DROP EXTENSION a`, 0, `drop extension a`},
 		{`DROP FOREIGN TABLE a`, 0, `drop foreign table`},
 		{`DROP FOREIGN DATA WRAPPER a`, 0, `drop fdw`},
-		{`DROP FUNCTION a`, 17511, `drop `},
 		{`DROP LANGUAGE a`, 17511, `drop language a`},
 		{`DROP OPERATOR a`, 0, `drop operator`},
 		{`DROP PUBLICATION a`, 0, `drop publication`},
@@ -2867,8 +3035,6 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`DROP SERVER a`, 0, `drop server`},
 		{`DROP SUBSCRIPTION a`, 0, `drop subscription`},
 		{`DROP TEXT SEARCH a`, 7821, `drop text`},
-		{`DROP TRIGGER a`, 28296, `drop`},
-		{`DROP TYPE a`, 27793, `drop type`},
 
 		{`DISCARD PLANS`, 0, `discard plans`},
 		{`DISCARD SEQUENCES`, 0, `discard sequences`},
@@ -2879,48 +3045,30 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`SET LOCAL foo = bar`, 32562, ``},
 		{`SET foo FROM CURRENT`, 0, `set from current`},
 
-		{`CREATE TEMP TABLE a(b INT8)`, 5807, ``},
 		{`CREATE UNLOGGED TABLE a(b INT8)`, 0, `create unlogged`},
-		{`CREATE TEMP VIEW a AS SELECT b`, 5807, ``},
-		{`CREATE TEMP SEQUENCE a`, 5807, ``},
-
-		{`CREATE TABLE a(x INT[][])`, 32552, ``},
-		{`CREATE TABLE a(x INT[1][2])`, 32552, ``},
-		{`CREATE TABLE a(x INT ARRAY[1][2])`, 32552, ``},
-
-		{`CREATE TABLE a(LIKE b)`, 30840, ``},
+		{`CREATE TEMP SEQUENCE a`, 5807, `create temp sequence`},
 
 		{`CREATE TABLE a(b INT8) WITH OIDS`, 0, `create table with oids`},
 		{`CREATE TABLE a(b INT8) WITH foo = bar`, 0, `create table with foo`},
 
 		{`CREATE TABLE a AS SELECT b WITH NO DATA`, 0, `create table as with no data`},
 
-		{`CREATE TABLE a(b INT8 AS (123) VIRTUAL)`, 0, `virtual computed columns`},
 		{`CREATE TABLE a(b INT8 REFERENCES c(x) MATCH PARTIAL`, 20305, `match partial`},
 		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) MATCH PARTIAL)`, 20305, `match partial`},
 
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) DEFERRABLE)`, 31632, `deferrable`},
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) INITIALLY DEFERRED)`, 31632, `initially deferred`},
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) INITIALLY IMMEDIATE)`, 31632, `initially immediate`},
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) DEFERRABLE INITIALLY DEFERRED)`, 31632, `initially deferred`},
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) DEFERRABLE INITIALLY IMMEDIATE)`, 31632, `initially immediate`},
-		{`CREATE TABLE a(b INT8, UNIQUE (b) DEFERRABLE)`, 31632, `deferrable`},
-		{`CREATE TABLE a(b INT8, CHECK (b > 0) DEFERRABLE)`, 31632, `deferrable`},
+		{`SET CONSTRAINTS a DEFERRED`, 31632, `set constraints name`},
+		{`SET CONSTRAINTS a, b IMMEDIATE`, 31632, `set constraints name`},
 
 		{`CREATE SEQUENCE a AS DOUBLE PRECISION`, 25110, `FLOAT8`},
-		{`CREATE SEQUENCE a OWNED BY b`, 26382, ``},
 
 		{`CREATE OR REPLACE VIEW a AS SELECT b`, 24897, ``},
 		{`CREATE RECURSIVE VIEW a AS SELECT b`, 0, `create recursive view`},
 
-		{`CREATE TYPE a AS (b)`, 27792, ``},
-		{`CREATE TYPE a AS ENUM (b)`, 24873, ``},
 		{`CREATE TYPE a AS RANGE b`, 27791, ``},
 		{`CREATE TYPE a (b)`, 27793, `base`},
 		{`CREATE TYPE a`, 27793, `shell`},
 		{`CREATE DOMAIN a`, 27796, `create`},
 
-		{`CREATE INDEX a ON b(c) WHERE d > 0`, 9683, ``},
 		{`CREATE INDEX a ON b USING HASH (c)`, 0, `index using hash`},
 		{`CREATE INDEX a ON b USING GIST (c)`, 0, `index using gist`},
 		{`CREATE INDEX a ON b USING SPGIST (c)`, 0, `index using spgist`},
@@ -2930,21 +3078,12 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE INDEX a ON b(c[d])`, 9682, ``},
 		{`CREATE INDEX a ON b(foo(c))`, 9682, ``},
 
-		{`INSERT INTO foo(a, a.b) VALUES (1,2)`, 27792, ``},
-		{`INSERT INTO foo VALUES (1,2) ON CONFLICT ON CONSTRAINT a DO NOTHING`, 28161, ``},
-
 		{`SELECT max(a ORDER BY b) FROM ab`, 23620, ``},
 
-		{`SELECT * FROM a FOR UPDATE`, 6583, ``},
 		{`SELECT * FROM ROWS FROM (a(b) AS (d))`, 0, `ROWS FROM with col_def_list`},
 
 		{`SELECT 123 AT TIME ZONE 'b'`, 32005, ``},
 
-		{`SELECT 'a'::INTERVAL SECOND`, 0, `interval with unit qualifier`},
-		{`SELECT 'a'::INTERVAL(123)`, 32564, ``},
-		{`SELECT 'a'::INTERVAL SECOND(123)`, 32564, `interval second`},
-		{`SELECT INTERVAL(3) 'a'`, 32564, ``},
-
 		{`SELECT 'a'::TIMESTAMP(123)`, 32098, ``},
 		{`SELECT 'a'::TIMESTAMP(123) WITHOUT TIME ZONE`, 32098, ``},
 		{`SELECT 'a'::TIMESTAMPTZ(123)`, 32098, ``},
@@ -2954,10 +3093,10 @@ func TestUnimplementedSyntax(t *testing.T) {
 
 		{`SELECT 'a'::TIME(123)`, 32565, ``},
 		{`SELECT 'a'::TIME(123) WITHOUT TIME ZONE`, 32565, ``},
-		{`SELECT 'a'::TIMETZ(123)`, 26097, `type with precision`},
+		{`SELECT 'a'::TIMETZ(123)`, 32565, ``},
 		{`SELECT 'a'::TIME(123) WITH TIME ZONE`, 32565, ``},
 		{`SELECT TIME(3) 'a'`, 32565, ``},
-		{`SELECT TIMETZ(3) 'a'`, 26097, `type with precision`},
+		{`SELECT TIMETZ(3) 'a'`, 32565, ``},
 
 		{`SELECT a(b) 'c'`, 0, `a(...) SCONST`},
 		{`SELECT (a,b) OVERLAPS (c,d)`, 0, `overlaps`},
@@ -2966,8 +3105,6 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`SELECT a(VARIADIC b)`, 0, `variadic`},
 		{`SELECT a(b, c, VARIADIC b)`, 0, `variadic`},
 		{`SELECT COLLATION FOR (a)`, 32563, ``},
-		{`SELECT CURRENT_TIME`, 26097, `current_time`},
-		{`SELECT CURRENT_TIME()`, 26097, `current_time`},
 		{`SELECT TREAT (a AS INT8)`, 0, `treat`},
 		{`SELECT a(b) WITHIN GROUP (ORDER BY c)`, 0, `within group`},
 
@@ -2987,18 +3124,6 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE TABLE a(b TSVECTOR)`, 7821, `tsvector`},
 		{`CREATE TABLE a(b TXID_SNAPSHOT)`, 0, `txid_snapshot`},
 		{`CREATE TABLE a(b XML)`, 0, `xml`},
-		{`CREATE TABLE a(b TIMETZ)`, 26097, `type`},
-
-		{`INSERT INTO a VALUES (1) ON CONFLICT (x) WHERE x > 3 DO NOTHING`, 32557, ``},
-
-		{`WITH RECURSIVE a AS (TABLE b) SELECT c`, 21085, ``},
-
-		{`UPDATE foo SET (a, a.b) = (1, 2)`, 27792, ``},
-		{`UPDATE foo SET a.b = 1`, 27792, ``},
-		{`UPDATE foo SET x = y FROM a, b`, 7841, ``},
-		{`UPDATE Foo SET x.y = z`, 27792, ``},
-
-		{`UPSERT INTO foo(a, a.b) VALUES (1,2)`, 27792, ``},
 	}
 	for _, d := range testData {
 		t.Run(d.sql, func(t *testing.T) {
@@ -58,6 +58,31 @@ func unimplementedWithIssueDetail(sqllex sqlLexer, issue int, detail string) int
     sqllex.(*lexer).UnimplementedWithIssueDetail(issue, detail)
     return 1
 }
+
+// columnTargets holds the target columns of an INSERT or of an UPDATE SET
+// clause, and the fields of the columns of composite types that they assign
+// instead of the whole columns. fields is nil if no field is assigned;
+// otherwise it parallels names.
+type columnTargets struct {
+    names  tree.NameList
+    fields tree.NameList
+}
+
+// add appends a target, given as the name of a column optionally followed by
+// the name of one of its fields.
+func (t *columnTargets) add(target tree.NameList) {
+    if len(target) > 1 && t.fields == nil {
+        t.fields = make(tree.NameList, len(t.names))
+    }
+    t.names = append(t.names, target[0])
+    if t.fields != nil {
+        var field tree.Name
+        if len(target) > 1 {
+            field = target[1]
+        }
+        t.fields = append(t.fields, field)
+    }
+}
 %}
 
 %{
@@ -245,6 +270,51 @@ func (u *sqlSymUnion) seqOpt() tree.SequenceOption {
 func (u *sqlSymUnion) seqOpts() []tree.SequenceOption {
     return u.val.([]tree.SequenceOption)
 }
+func (u *sqlSymUnion) functionParam() tree.FunctionParam {
+    return u.val.(tree.FunctionParam)
+}
+func (u *sqlSymUnion) functionParams() tree.FunctionParams {
+    return u.val.(tree.FunctionParams)
+}
+func (u *sqlSymUnion) compositeTypeField() tree.CompositeTypeField {
+    return u.val.(tree.CompositeTypeField)
+}
+func (u *sqlSymUnion) compositeTypeFields() []tree.CompositeTypeField {
+    return u.val.([]tree.CompositeTypeField)
+}
+func (u *sqlSymUnion) functionOption() tree.FunctionOption {
+    return u.val.(tree.FunctionOption)
+}
+func (u *sqlSymUnion) functionOptions() tree.FunctionOptions {
+    return u.val.(tree.FunctionOptions)
+}
+func (u *sqlSymUnion) funcObj() tree.FuncObj {
+    return u.val.(tree.FuncObj)
+}
+func (u *sqlSymUnion) funcObjs() tree.FuncObjs {
+    return u.val.(tree.FuncObjs)
+}
+func (u *sqlSymUnion) likeTableOptionList() []tree.LikeTableOption {
+    return u.val.([]tree.LikeTableOption)
+}
+func (u *sqlSymUnion) likeTableOpt() tree.LikeTableOpt {
+    return u.val.(tree.LikeTableOpt)
+}
+func (u *sqlSymUnion) overriding() tree.Overriding {
+    return u.val.(tree.Overriding)
+}
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvent() tree.TriggerEvent {
+    return u.val.(tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerEvents() tree.TriggerEvents {
+    return u.val.(tree.TriggerEvents)
+}
+func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacement {
+    return u.val.(*tree.AlterTypeAddValuePlacement)
+}
 func (u *sqlSymUnion) expr() tree.Expr {
     if expr, ok := u.val.(tree.Expr); ok {
         return expr
@@ -296,9 +366,24 @@ func (u *sqlSymUnion) updateExpr() *tree.UpdateExpr {
 func (u *sqlSymUnion) updateExprs() tree.UpdateExprs {
     return u.val.(tree.UpdateExprs)
 }
+func (u *sqlSymUnion) columnTargets() *columnTargets {
+    return u.val.(*columnTargets)
+}
 func (u *sqlSymUnion) limit() *tree.Limit {
     return u.val.(*tree.Limit)
 }
+func (u *sqlSymUnion) lockingClause() tree.LockingClause {
+    return u.val.(tree.LockingClause)
+}
+func (u *sqlSymUnion) lockingItem() *tree.LockingItem {
+    return u.val.(*tree.LockingItem)
+}
+func (u *sqlSymUnion) lockingStrength() tree.LockingStrength {
+    return u.val.(tree.LockingStrength)
+}
+func (u *sqlSymUnion) lockingWaitPolicy() tree.LockingWaitPolicy {
+    return u.val.(tree.LockingWaitPolicy)
+}
 func (u *sqlSymUnion) targetList() tree.TargetList {
     return u.val.(tree.TargetList)
 }
@@ -413,8 +498,8 @@ func (u *sqlSymUnion) op() tree.Operator {
 func (u *sqlSymUnion) cmpOp() tree.ComparisonOperator {
     return u.val.(tree.ComparisonOperator)
 }
-func (u *sqlSymUnion) durationField() tree.DurationField {
-    return u.val.(tree.DurationField)
+func (u *sqlSymUnion) intervalTypeMetadata() types.IntervalMetadata {
+    return u.val.(types.IntervalMetadata)
 }
 func (u *sqlSymUnion) kvOption() tree.KVOption {
     return u.val.(tree.KVOption)
@@ -431,6 +516,9 @@ func (u *sqlSymUnion) transactionModes() tree.TransactionModes {
 func (u *sqlSymUnion) compositeKeyMatchMethod() tree.CompositeKeyMatchMethod {
   return u.val.(tree.CompositeKeyMatchMethod)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+    return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
     return u.val.(tree.ReferenceAction)
 }
@@ -474,28 +562,28 @@ func newNameFromStr(s string) *tree.Name {
 // below; search this file for "Keyword category lists".
 
 // Ordinary key words in alphabetical order.
-%token <str> ABORT ACTION ADD ADMIN AGGREGATE
-%token <str> ALL ALTER ANALYSE ANALYZE AND ANY ANNOTATE_TYPE ARRAY AS ASC
+%token <str> ABORT ACTION ADD ADMIN AFTER AGGREGATE
+%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND ANY ANNOTATE_TYPE ARRAY AS ASC
 %token <str> ASYMMETRIC AT AUTOMATIC
 
-%token <str> BACKUP BEGIN BETWEEN BIGINT BIGSERIAL BIT
+%token <str> BACKUP BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BIT
 %token <str> BLOB BOOL BOOLEAN BOTH BY BYTEA BYTES
 
-%token <str> CACHE CANCEL CASCADE CASE CAST CHANGEFEED CHAR
+%token <str> CACHE CALLED CANCEL CASCADE CASE CAST CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK
 %token <str> CLUSTER COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMIT
-%token <str> COMMITTED COMPACT CONCAT CONFIGURATION CONFIGURATIONS CONFIGURE
+%token <str> COMMITTED COMPACT CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONSTRAINT CONSTRAINTS CONTAINS CONVERSION COPY COVERING CREATE
 %token <str> CROSS CUBE CURRENT CURRENT_CATALOG CURRENT_DATE CURRENT_SCHEMA
 %token <str> CURRENT_ROLE CURRENT_TIME CURRENT_TIMESTAMP
 %token <str> CURRENT_USER CYCLE
 
-%token <str> DATA DATABASE DATABASES DATE DAY DEC DECIMAL DEFAULT
+%token <str> DATA DATABASE DATABASES DATE DAY DEC DECIMAL DEFAULT DEFAULTS
 %token <str> DEALLOCATE DEFERRABLE DEFERRED DELETE DESC
 %token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING END ENUM ESCAPE EXCEPT
-%token <str> EXISTS EXECUTE EXPERIMENTAL
+%token <str> EACH ELSE ENCODING END ENUM ESCAPE EXCEPT
+%token <str> EXCLUDING EXISTS EXECUTE EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT
 %token <str> EXPLAIN EXPORT EXTENSION EXTRACT EXTRACT_DURATION
@@ -504,14 +592,14 @@ func newNameFromStr(s string) *tree.Name {
 %token <str> FILES FILTER
 %token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE_INDEX FOREIGN FROM FULL FUNCTION
 
-%token <str> GLOBAL GRANT GRANTS GREATEST GROUP GROUPING GROUPS
+%token <str> GENERATED GLOBAL GRANT GRANTS GREATEST GROUP GROUPING GROUPS
 
 %token <str> HAVING HASH HIGH HISTOGRAM HOUR
 
-%token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMPORT IN INCREMENT INCREMENTAL
+%token <str> IDENTITY IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMUTABLE IMPORT IN INCLUDING INCREMENT INCREMENTAL
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS INET_CONTAINS_OR_CONTAINED_BY
 %token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INJECT INTERLEAVE INITIALLY
-%token <str> INNER INSERT INT INT2VECTOR INT2 INT4 INT8 INT64 INTEGER
+%token <str> INNER INPUT INSERT INT INT2VECTOR INT2 INT4 INT8 INT64 INTEGER
 %token <str> INTERSECT INTERVAL INTO INVERTED IS ISERROR ISNULL ISOLATION
 
 %token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
@@ -519,16 +607,16 @@ func newNameFromStr(s string) *tree.Name {
 %token <str> KEY KEYS KV
 
 %token <str> LANGUAGE LATERAL LC_CTYPE LC_COLLATE
-%token <str> LEADING LEASE LEAST LEFT LESS LEVEL LIKE LIMIT LIST LOCAL
+%token <str> LEADING LEASE LEAST LEFT LESS LEVEL LIKE LIMIT LIST LOCAL LOCKED
 %token <str> LOCALTIME LOCALTIMESTAMP LOOKUP LOW LSHIFT
 
 %token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE MINUTE MONTH
 
-%token <str> NAN NAME NAMES NATURAL NEXT NO NO_INDEX_JOIN NORMAL
+%token <str> NAN NAME NAMES NATURAL NEXT NO NO_INDEX_JOIN NORMAL NOWAIT
 %token <str> NOT NOTHING NOTNULL NULL NULLIF NUMERIC
 
 %token <str> OF OFF OFFSET OID OIDS OIDVECTOR ON ONLY OPT OPTION OPTIONS OR
-%token <str> ORDER ORDINALITY OUT OUTER OVER OVERLAPS OVERLAY OWNED OPERATOR
+%token <str> ORDER ORDINALITY OUT OUTER OVER OVERLAPS OVERLAY OVERRIDING OWNED OPERATOR
 
 %token <str> PARENT PARTIAL PARTITION PASSWORD PAUSE PHYSICAL PLACING
 %token <str> PLAN PLANS POSITION PRECEDING PRECISION PREPARE PRIMARY PRIORITY
@@ -536,18 +624,18 @@ func newNameFromStr(s string) *tree.Name {
 
 %token <str> QUERIES QUERY
 
-%token <str> RANGE RANGES READ REAL RECURSIVE REF REFERENCES
+%token <str> RANGE RANGES READ REAL RECURSIVE REF REFERENCES REFRESH
 %token <str> REGCLASS REGPROC REGPROCEDURE REGNAMESPACE REGTYPE
 %token <str> REMOVE_PATH RENAME REPEATABLE REPLACE
-%token <str> RELEASE RESET RESTORE RESTRICT RESUME RETURNING REVOKE RIGHT
+%token <str> RELEASE RESET RESTORE RESTRICT RESUME RETURNING RETURNS REVOKE RIGHT
 %token <str> ROLE ROLES ROLLBACK ROLLUP ROW ROWS RSHIFT RULE
 
 %token <str> SAVEPOINT SCATTER SCHEMA SCHEMAS SCRUB SEARCH SECOND SELECT SEQUENCE SEQUENCES
 %token <str> SERIAL SERIAL2 SERIAL4 SERIAL8
-%token <str> SERIALIZABLE SERVER SESSION SESSIONS SESSION_USER SET SETTING SETTINGS
-%token <str> SHOW SIMILAR SIMPLE SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
+%token <str> SERIALIZABLE SERVER SESSION SESSIONS SESSION_USER SET SETOF SETTING SETTINGS
+%token <str> SHARE SHOW SIMILAR SIMPLE SKIP SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
 
-%token <str> START STATISTICS STATUS STDIN STRICT STRING STORE STORED STORING SUBSTRING
+%token <str> STABLE START STATEMENT STATISTICS STATUS STDIN STRICT STRING STORE STORED STORING SUBSTRING
 %token <str> SYMMETRIC SYNTAX SYSTEM SUBSCRIPTION
 
 %token <str> TABLE TABLES TEMP TEMPLATE TEMPORARY TESTING_RANGES EXPERIMENTAL_RANGES TESTING_RELOCATE EXPERIMENTAL_RELOCATE TEXT THEN
@@ -558,7 +646,7 @@ func newNameFromStr(s string) *tree.Name {
 %token <str> UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN UNLOGGED UNSPLIT
 %token <str> UPDATE UPSERT USE USER USERS USING UUID
 
-%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VIEW VARYING VIRTUAL
+%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VIEW VARYING VIRTUAL VOLATILE
 
 %token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
 
@@ -592,6 +680,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> alter_index_stmt
 %type <tree.Statement> alter_view_stmt
 %type <tree.Statement> alter_sequence_stmt
+%type <tree.Statement> alter_type_stmt
 %type <tree.Statement> alter_database_stmt
 %type <tree.Statement> alter_user_stmt
 %type <tree.Statement> alter_range_stmt
@@ -671,6 +760,22 @@ func newNameFromStr(s string) *tree.Name {
 %type <*tree.CreateStatsOptions> create_stats_option
 
 %type <tree.Statement> create_type_stmt
+%type <[]tree.CompositeTypeField> opt_composite_type_field_list composite_type_field_list
+%type <tree.CompositeTypeField> composite_type_field
+%type <tree.Statement> create_function_stmt
+%type <bool> opt_or_replace opt_setof
+%type <tree.FunctionParams> opt_func_param_list func_param_list
+%type <tree.FunctionParam> func_param
+%type <tree.FunctionOptions> func_option_list
+%type <tree.FunctionOption> func_option
+%type <tree.Statement> create_trigger_stmt
+%type <tree.TriggerActionTime> trigger_action_time
+%type <tree.TriggerEvents> trigger_event_list
+%type <tree.TriggerEvent> trigger_event
+%type <bool> opt_trigger_for_each
+%type <tree.Expr> opt_trigger_when
+%type <[]string> opt_enum_val_list enum_val_list
+%type <*tree.AlterTypeAddValuePlacement> opt_add_val_placement
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
@@ -683,6 +788,11 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> drop_user_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
+%type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_function_stmt
+%type <tree.FuncObjs> func_obj_list
+%type <tree.FuncObj> func_obj
+%type <tree.Statement> drop_trigger_stmt
 
 %type <tree.Statement> explain_stmt
 %type <tree.Statement> prepare_stmt
@@ -695,6 +805,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> insert_stmt
 %type <tree.Statement> import_stmt
 %type <tree.Statement> pause_stmt
+%type <tree.Statement> refresh_stmt
 %type <tree.Statement> release_stmt
 %type <tree.Statement> reset_stmt reset_session_stmt reset_csetting_stmt
 %type <tree.Statement> resume_stmt
@@ -709,6 +820,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -786,7 +898,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <*tree.UnresolvedName> func_name
 %type <str> opt_collate
 
-%type <str> database_name index_name opt_index_name column_name insert_column_item statistics_name window_name
+%type <str> database_name index_name opt_index_name column_name statistics_name window_name
 %type <str> family_name opt_family_name table_alias_name constraint_name target_name zone_name partition_name collation_name
 %type <str> db_object_name_component
 %type <*tree.UnresolvedObjectName> table_name standalone_index_name sequence_name type_name view_name db_object_name simple_db_object_name complex_db_object_name
@@ -813,14 +925,15 @@ func newNameFromStr(s string) *tree.Name {
 %type <empty> opt_all_clause
 %type <bool> distinct_clause
 %type <tree.DistinctOn> distinct_on_clause
-%type <tree.NameList> opt_column_list insert_column_list opt_stats_columns
+%type <tree.NameList> opt_column_list insert_column_item opt_stats_columns
+%type <*columnTargets> insert_column_list
 %type <tree.OrderBy> sort_clause opt_sort_clause
 %type <[]*tree.Order> sortby_list
 %type <tree.IndexElemList> index_params
 %type <tree.NameList> name_list privilege_list
 %type <[]int32> opt_array_bounds
-%type <*tree.From> from_clause update_from_clause
-%type <tree.TableExprs> from_list rowsfrom_list
+%type <*tree.From> from_clause
+%type <tree.TableExprs> from_list rowsfrom_list update_from_clause
 %type <tree.TablePatterns> table_pattern_list single_table_pattern_list
 %type <tree.TableNames> table_name_list
 %type <tree.Exprs> expr_list opt_expr_list tuple1_ambiguous_values tuple1_unambiguous_values
@@ -831,11 +944,11 @@ func newNameFromStr(s string) *tree.Name {
 %type <*tree.UpdateExpr> set_clause multiple_set_clause
 %type <tree.ArraySubscripts> array_subscripts
 %type <tree.GroupBy> group_clause
-%type <*tree.Limit> select_limit
+%type <*tree.Limit> select_limit opt_select_limit
 %type <tree.TableNames> relation_expr_list
 %type <tree.ReturningClause> returning_clause
 
-%type <[]tree.SequenceOption> sequence_option_list opt_sequence_option_list
+%type <[]tree.SequenceOption> sequence_option_list opt_sequence_option_list opt_identity_sequence_options
 %type <tree.SequenceOption> sequence_option_elem
 
 %type <bool> all_or_distinct
@@ -851,21 +964,27 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.Exprs> substr_list
 %type <tree.Exprs> trim_list
 %type <tree.Exprs> execute_param_clause
-%type <tree.DurationField> opt_interval interval_second interval_qualifier
+%type <types.IntervalMetadata> opt_interval interval_second interval_qualifier
 %type <tree.Expr> overlay_placing
 
-%type <bool> opt_unique opt_cluster
+%type <bool> opt_unique opt_cluster opt_temp
 %type <bool> opt_using_gin_btree
 
 %type <*tree.Limit> limit_clause offset_clause opt_limit_clause
+%type <tree.LockingClause> for_locking_clause opt_for_locking_clause for_locking_items
+%type <*tree.LockingItem> for_locking_item
+%type <tree.LockingStrength> for_locking_strength
+%type <tree.LockingWaitPolicy> opt_nowait_or_skip
+%type <tree.TableNames> opt_locked_rels
 %type <tree.Expr> select_limit_value
 %type <tree.Expr> opt_select_fetch_first_value
 %type <empty> row_or_rows
 %type <empty> first_or_next
 
 %type <tree.Statement> insert_rest
-%type <tree.NameList> opt_conf_expr opt_col_def_list
-%type <*tree.OnConflict> on_conflict
+%type <tree.Overriding> override_kind
+%type <tree.NameList> opt_col_def_list
+%type <*tree.OnConflict> on_conflict opt_conf_expr
 
 %type <tree.Statement> begin_transaction
 %type <tree.TransactionModes> transaction_mode_list transaction_mode
@@ -873,7 +992,9 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.NameList> opt_storing
 %type <*tree.ColumnTableDef> column_def
 %type <tree.TableDef> table_elem
-%type <tree.Expr> where_clause opt_where_clause
+%type <[]tree.LikeTableOption> like_table_option_list
+%type <tree.LikeTableOpt> like_table_option
+%type <tree.Expr> where_clause opt_where_clause opt_idx_where
 %type <*tree.ArraySubscript> array_subscript
 %type <tree.Expr> opt_slice_bound
 %type <*tree.IndexFlags> opt_index_flags
@@ -948,6 +1069,7 @@ func newNameFromStr(s string) *tree.Name {
 %type <tree.NamedColumnQualification> col_qualification
 %type <tree.ColumnQualification> col_qualification_elem
 %type <tree.CompositeKeyMatchMethod> key_match
+%type <tree.ConstraintDeferrability> opt_deferrable
 %type <tree.ReferenceActions> reference_actions
 %type <tree.ReferenceAction> reference_action reference_on_delete reference_on_update
 
@@ -970,7 +1092,7 @@ func newNameFromStr(s string) *tree.Name {
 
 %type <[]tree.ColumnID> opt_tableref_col_list tableref_col_list
 
-%type <tree.TargetList> targets targets_roles changefeed_targets
+%type <tree.TargetList> targets targets_roles grant_targets changefeed_targets
 %type <*tree.TargetList> opt_on_targets_roles
 %type <tree.NameList> for_grantee_clause
 %type <privilege.List> privileges
@@ -1019,8 +1141,14 @@ func newNameFromStr(s string) *tree.Name {
 // cause UNBOUNDED to be treated differently from other unreserved keywords
 // anywhere else in the grammar, but it's definitely risky. We can blame any
 // funny behavior of UNBOUNDED on the SQL standard, though.
+//
+// GENERATED also gets the precedence of IDENT, so that CREATE FAMILY followed
+// by GENERATED shifts and names the family "generated", as it did before
+// identity columns were supported, rather than ending the CREATE FAMILY column
+// qualification. The rule for CREATE FAMILY without a name has the lowest
+// precedence for this purpose.
 %nonassoc  UNBOUNDED         // ideally should have same precedence as IDENT
-%nonassoc  IDENT NULL PARTITION RANGE ROWS GROUPS PRECEDING FOLLOWING CUBE ROLLUP
+%nonassoc  IDENT NULL PARTITION RANGE ROWS GROUPS PRECEDING FOLLOWING CUBE ROLLUP GENERATED
 %left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH  // multi-character ops
 %left      '|'
 %left      '#'
@@ -1090,6 +1218,7 @@ alter_ddl_stmt:
 | alter_sequence_stmt // EXTEND WITH HELP: ALTER SEQUENCE
 | alter_database_stmt // EXTEND WITH HELP: ALTER DATABASE
 | alter_range_stmt    // EXTEND WITH HELP: ALTER RANGE
+| alter_type_stmt     { /* SKIP DOC */ }
 
 // %Help: ALTER TABLE - change the definition of a table
 // %Category: DDL
@@ -1103,6 +1232,7 @@ alter_ddl_stmt:
 //   ALTER TABLE ... DROP CONSTRAINT [IF EXISTS] <constraintname> [RESTRICT | CASCADE]
 //   ALTER TABLE ... ALTER [COLUMN] <colname> {SET DEFAULT <expr> | DROP DEFAULT}
 //   ALTER TABLE ... ALTER [COLUMN] <colname> DROP NOT NULL
+//   ALTER TABLE ... ALTER [COLUMN] <colname> SET NOT NULL
 //   ALTER TABLE ... ALTER [COLUMN] <colname> DROP STORED
 //   ALTER TABLE ... ALTER [COLUMN] <colname> [SET DATA] TYPE <type> [COLLATE <collation>]
 //   ALTER TABLE ... RENAME TO <newname>
@@ -1165,6 +1295,7 @@ alter_view_stmt:
 //   [MAXVALUE <maxvalue> | NO MAXVALUE]
 //   [START <start>]
 //   [[NO] CYCLE]
+//   [OWNED BY <tablename>.<colname> | OWNED BY NONE]
 // ALTER SEQUENCE [IF EXISTS] <name> RENAME TO <newname>
 alter_sequence_stmt:
   alter_rename_sequence_stmt
@@ -1523,7 +1654,10 @@ alter_table_cmd:
     $$.val = &tree.AlterTableDropStored{Column: tree.Name($3)}
   }
   // ALTER TABLE <name> ALTER [COLUMN] <colname> SET NOT NULL
-| ALTER opt_column column_name SET NOT NULL { return unimplementedWithIssue(sqllex, 28751) }
+| ALTER opt_column column_name SET NOT NULL
+  {
+    $$.val = &tree.AlterTableSetNotNull{Column: tree.Name($3)}
+  }
   // ALTER TABLE <name> DROP [COLUMN] IF EXISTS <colname> [RESTRICT|CASCADE]
 | DROP opt_column IF EXISTS column_name opt_drop_behavior
   {
@@ -1806,7 +1940,11 @@ import_stmt:
 | IMPORT INTO table_name '(' insert_column_list ')' import_format DATA '(' string_or_placeholder_list ')' opt_with_options
   {
     name := $3.unresolvedObjectName().ToTableName()
-    $$.val = &tree.Import{Table: &name, Into: true, IntoCols: $5.nameList(), FileFormat: $7, Files: $10.exprs(), Options: $12.kvOptions()}
+    targets := $5.columnTargets()
+    if targets.fields != nil {
+      return unimplementedWithIssueDetail(sqllex, 27792, "import into field")
+    }
+    $$.val = &tree.Import{Table: &name, Into: true, IntoCols: targets.names, FileFormat: $7, Files: $10.exprs(), Options: $12.kvOptions()}
   }
 | IMPORT error // SHOW HELP: IMPORT
 
@@ -2064,7 +2202,7 @@ comment_text:
 // %Text:
 // CREATE DATABASE, CREATE TABLE, CREATE INDEX, CREATE TABLE AS,
 // CREATE USER, CREATE VIEW, CREATE SEQUENCE, CREATE STATISTICS,
-// CREATE ROLE
+// CREATE ROLE, CREATE FUNCTION
 create_stmt:
   create_user_stmt     // EXTEND WITH HELP: CREATE USER
 | create_role_stmt     // EXTEND WITH HELP: CREATE ROLE
@@ -2083,10 +2221,7 @@ create_unsupported:
 | CREATE EXTENSION name error { return unimplemented(sqllex, "create extension " + $3) }
 | CREATE FOREIGN TABLE error { return unimplemented(sqllex, "create foreign table") }
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
-| CREATE FUNCTION error { return unimplementedWithIssueDetail(sqllex, 17511, "create function") }
-| CREATE OR REPLACE FUNCTION error { return unimplementedWithIssueDetail(sqllex, 17511, "create function") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
-| CREATE MATERIALIZED VIEW error { return unimplementedWithIssue(sqllex, 24747) }
 | CREATE OPERATOR error { return unimplemented(sqllex, "create operator") }
 | CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
@@ -2094,11 +2229,10 @@ create_unsupported:
 | CREATE SERVER error { return unimplemented(sqllex, "create server") }
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create") }
 
 opt_or_replace:
-  OR REPLACE {}
-| /* EMPTY */ {}
+  OR REPLACE { $$.val = true }
+| /* EMPTY */ { $$.val = false }
 
 opt_trusted:
   TRUSTED {}
@@ -2118,7 +2252,6 @@ drop_unsupported:
 | DROP EXTENSION name error { return unimplemented(sqllex, "drop extension " + $3) }
 | DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
-| DROP FUNCTION error { return unimplementedWithIssueDetail(sqllex, 17511, "drop function") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
 | DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
@@ -2127,8 +2260,6 @@ drop_unsupported:
 | DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
 | DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TYPE error { return unimplementedWithIssueDetail(sqllex, 27793, "drop type") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
 
 create_ddl_stmt:
   create_changefeed_stmt
@@ -2141,6 +2272,8 @@ create_ddl_stmt:
 | create_type_stmt     { /* SKIP DOC */ }
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
+| create_function_stmt // EXTEND WITH HELP: CREATE FUNCTION
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
@@ -2254,6 +2387,26 @@ create_changefeed_stmt:
       Options: $5.kvOptions(),
     }
   }
+| CREATE CHANGEFEED opt_changefeed_sink opt_with_options AS SELECT target_list FROM table_name opt_where_clause
+  {
+    $$.val = &tree.CreateChangefeed{
+      Targets: tree.TargetList{Tables: tree.TablePatterns{$9.unresolvedObjectName().ToUnresolvedName()}},
+      SinkURI: $3.expr(),
+      Options: $4.kvOptions(),
+      Projection: $7.selExprs(),
+      Filter: tree.NewWhere(tree.AstWhere, $10.expr()),
+    }
+  }
+| EXPERIMENTAL CHANGEFEED opt_with_options AS SELECT target_list FROM table_name opt_where_clause
+  {
+    /* SKIP DOC */
+    $$.val = &tree.CreateChangefeed{
+      Targets: tree.TargetList{Tables: tree.TablePatterns{$8.unresolvedObjectName().ToUnresolvedName()}},
+      Options: $3.kvOptions(),
+      Projection: $6.selExprs(),
+      Filter: tree.NewWhere(tree.AstWhere, $9.expr()),
+    }
+  }
 
 changefeed_targets:
   single_table_pattern_list
@@ -2326,7 +2479,7 @@ discard_stmt:
 // %Category: Group
 // %Text:
 // DROP DATABASE, DROP INDEX, DROP TABLE, DROP VIEW, DROP SEQUENCE,
-// DROP USER, DROP ROLE
+// DROP USER, DROP ROLE, DROP FUNCTION, DROP TRIGGER
 drop_stmt:
   drop_ddl_stmt      // help texts in sub-rule
 | drop_role_stmt     // EXTEND WITH HELP: DROP ROLE
@@ -2340,10 +2493,13 @@ drop_ddl_stmt:
 | drop_table_stmt    // EXTEND WITH HELP: DROP TABLE
 | drop_view_stmt     // EXTEND WITH HELP: DROP VIEW
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
+| drop_type_stmt     { /* SKIP DOC */ }
+| drop_function_stmt // EXTEND WITH HELP: DROP FUNCTION
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
-// %Text: DROP VIEW [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
+// %Text: DROP [MATERIALIZED] VIEW [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
 // %SeeAlso: WEBDOCS/drop-index.html
 drop_view_stmt:
   DROP VIEW table_name_list opt_drop_behavior
@@ -2354,7 +2510,26 @@ drop_view_stmt:
   {
     $$.val = &tree.DropView{Names: $5.tableNames(), IfExists: true, DropBehavior: $6.dropBehavior()}
   }
+| DROP MATERIALIZED VIEW table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropView{
+      Names: $4.tableNames(),
+      IfExists: false,
+      DropBehavior: $5.dropBehavior(),
+      IsMaterialized: true,
+    }
+  }
+| DROP MATERIALIZED VIEW IF EXISTS table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropView{
+      Names: $6.tableNames(),
+      IfExists: true,
+      DropBehavior: $7.dropBehavior(),
+      IsMaterialized: true,
+    }
+  }
 | DROP VIEW error // SHOW HELP: DROP VIEW
+| DROP MATERIALIZED VIEW error // SHOW HELP: DROP VIEW
 
 // %Help: DROP SEQUENCE - remove a sequence
 // %Category: DDL
@@ -2371,6 +2546,83 @@ drop_sequence_stmt:
   }
 | DROP SEQUENCE error // SHOW HELP: DROP VIEW
 
+drop_type_stmt:
+  DROP TYPE table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{Names: $3.tableNames(), IfExists: false, DropBehavior: $4.dropBehavior()}
+  }
+| DROP TYPE IF EXISTS table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{Names: $5.tableNames(), IfExists: true, DropBehavior: $6.dropBehavior()}
+  }
+
+// %Help: DROP FUNCTION - remove a user-defined function
+// %Category: DDL
+// %Text:
+// DROP FUNCTION [IF EXISTS] <name> [ ( [ [<argname>] <argtype> [, ...] ] ) ] [, ...]
+//   [CASCADE | RESTRICT]
+// %SeeAlso: CREATE FUNCTION
+drop_function_stmt:
+  DROP FUNCTION func_obj_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{Functions: $3.funcObjs(), IfExists: false, DropBehavior: $4.dropBehavior()}
+  }
+| DROP FUNCTION IF EXISTS func_obj_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{Functions: $5.funcObjs(), IfExists: true, DropBehavior: $6.dropBehavior()}
+  }
+| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+
+// %Help: DROP TRIGGER - remove a trigger
+// %Category: DDL
+// %Text: DROP TRIGGER [IF EXISTS] <name> ON <tablename> [CASCADE | RESTRICT]
+// %SeeAlso: CREATE TRIGGER
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName().ToTableName(),
+      IfExists: false,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($5),
+      Table: $7.unresolvedObjectName().ToTableName(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
+
+func_obj_list:
+  func_obj
+  {
+    $$.val = tree.FuncObjs{$1.funcObj()}
+  }
+| func_obj_list ',' func_obj
+  {
+    $$.val = append($1.funcObjs(), $3.funcObj())
+  }
+
+func_obj:
+  db_object_name
+  {
+    $$.val = tree.FuncObj{FuncName: $1.unresolvedObjectName().ToTableName()}
+  }
+| db_object_name '(' opt_func_param_list ')'
+  {
+    params := $3.functionParams()
+    if params == nil {
+      // An empty parameter list refers to the overload without parameters.
+      params = tree.FunctionParams{}
+    }
+    $$.val = tree.FuncObj{FuncName: $1.unresolvedObjectName().ToTableName(), Params: params}
+  }
+
 // %Help: DROP TABLE - remove a table
 // %Category: DDL
 // %Text: DROP TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
@@ -2525,6 +2777,7 @@ preparable_stmt:
 | import_stmt       // EXTEND WITH HELP: IMPORT
 | insert_stmt       // EXTEND WITH HELP: INSERT
 | pause_stmt        // EXTEND WITH HELP: PAUSE JOBS
+| refresh_stmt      // EXTEND WITH HELP: REFRESH
 | reset_stmt        // help texts in sub-rule
 | restore_stmt      // EXTEND WITH HELP: RESTORE
 | resume_stmt       // EXTEND WITH HELP: RESUME JOBS
@@ -2662,15 +2915,16 @@ deallocate_stmt:
 //   GRANT <roles...> TO <grantees...> [WITH ADMIN OPTION]
 //
 // Privileges:
-//   CREATE, DROP, GRANT, SELECT, INSERT, DELETE, UPDATE
+//   CREATE, DROP, GRANT, SELECT, INSERT, DELETE, UPDATE, EXECUTE
 //
 // Targets:
 //   DATABASE <databasename> [, ...]
 //   [TABLE] [<databasename> .] { <tablename> | * } [, ...]
+//   FUNCTION [<databasename> .] <functionname> [, ...]
 //
 // %SeeAlso: REVOKE, WEBDOCS/grant.html
 grant_stmt:
-  GRANT privileges ON targets TO name_list
+  GRANT privileges ON grant_targets TO name_list
   {
     $$.val = &tree.Grant{Privileges: $2.privilegeList(), Grantees: $6.nameList(), Targets: $4.targetList()}
   }
@@ -2693,15 +2947,16 @@ grant_stmt:
 //   REVOKE [ADMIN OPTION FOR] <roles...> FROM <grantees...>
 //
 // Privileges:
-//   CREATE, DROP, GRANT, SELECT, INSERT, DELETE, UPDATE
+//   CREATE, DROP, GRANT, SELECT, INSERT, DELETE, UPDATE, EXECUTE
 //
 // Targets:
 //   DATABASE <databasename> [, <databasename>]...
 //   [TABLE] [<databasename> .] { <tablename> | * } [, ...]
+//   FUNCTION [<databasename> .] <functionname> [, ...]
 //
 // %SeeAlso: GRANT, WEBDOCS/revoke.html
 revoke_stmt:
-  REVOKE privileges ON targets FROM name_list
+  REVOKE privileges ON grant_targets FROM name_list
   {
     $$.val = &tree.Revoke{Privileges: $2.privilegeList(), Grantees: $6.nameList(), Targets: $4.targetList()}
   }
@@ -2797,7 +3052,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 | SET LOCAL error { return unimplementedWithIssue(sqllex, 32562) }
 
 // SET SESSION / SET CLUSTER SETTING
@@ -2980,6 +3235,34 @@ set_transaction_stmt:
   }
 | SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
+// %Help: SET CONSTRAINTS - change when deferrable constraints are checked
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS ALL { DEFERRED | IMMEDIATE }
+//
+// DEFERRED postpones the validation of the deferrable constraints until the
+// current transaction commits. IMMEDIATE validates them at the end of each
+// statement, including the pending ones.
+// %SeeAlso: SET TRANSACTION, CREATE TABLE
+set_constraints_stmt:
+  SET CONSTRAINTS ALL DEFERRED
+  {
+    $$.val = &tree.SetConstraints{Deferred: true}
+  }
+| SET CONSTRAINTS ALL IMMEDIATE
+  {
+    $$.val = &tree.SetConstraints{Deferred: false}
+  }
+| SET CONSTRAINTS name_list DEFERRED
+  {
+    return unimplementedWithIssueDetail(sqllex, 31632, "set constraints name")
+  }
+| SET CONSTRAINTS name_list IMMEDIATE
+  {
+    return unimplementedWithIssueDetail(sqllex, 31632, "set constraints name")
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
 generic_set:
   var_name to_or_eq var_list
   {
@@ -3882,6 +4165,15 @@ targets:
     $$.val = tree.TargetList{Databases: $2.nameList()}
   }
 
+// grant_targets is the variant of targets which recognizes ON FUNCTION
+// with a list of function names. Only GRANT and REVOKE accept it.
+grant_targets:
+  FUNCTION table_name_list
+  {
+    $$.val = tree.TargetList{Functions: $2.tableNames()}
+  }
+| targets
+
 // target_roles is the variant of targets which recognizes ON ROLES
 // with a name list. This cannot be included in targets directly
 // because some statements must not recognize this syntax.
@@ -3927,8 +4219,8 @@ pause_stmt:
 // %Help: CREATE TABLE - create a new table
 // %Category: DDL
 // %Text:
-// CREATE TABLE [IF NOT EXISTS] <tablename> ( <elements...> ) [<interleave>]
-// CREATE TABLE [IF NOT EXISTS] <tablename> [( <colnames...> )] AS <source>
+// CREATE [TEMPORARY] TABLE [IF NOT EXISTS] <tablename> ( <elements...> ) [<interleave>]
+// CREATE [TEMPORARY] TABLE [IF NOT EXISTS] <tablename> [( <colnames...> )] AS <source>
 //
 // Table elements:
 //    <name> <type> [<qualifiers...>]
@@ -3936,6 +4228,7 @@ pause_stmt:
 //                            [STORING ( <colnames...> )] [<interleave>]
 //    FAMILY [<name>] ( <colnames...> )
 //    [CONSTRAINT <name>] <constraint>
+//    LIKE <tablename> [{INCLUDING | EXCLUDING} {CONSTRAINTS | DEFAULTS | GENERATED | INDEXES | ALL} ...]
 //
 // Table constraints:
 //    PRIMARY KEY ( <colnames...> )
@@ -3948,7 +4241,8 @@ pause_stmt:
 //   FAMILY <familyname>, CREATE [IF NOT EXISTS] FAMILY [<familyname>]
 //   REFERENCES <tablename> [( <colnames...> )] [ON DELETE {NO ACTION | RESTRICT}] [ON UPDATE {NO ACTION | RESTRICT}]
 //   COLLATE <collationname>
-//   AS ( <expr> ) STORED
+//   AS ( <expr> ) { STORED | VIRTUAL }
+//   GENERATED { ALWAYS | BY DEFAULT } AS IDENTITY [( <sequence options...> )]
 //
 // Interleave clause:
 //    INTERLEAVE IN PARENT <tablename> ( <colnames...> ) [CASCADE | RESTRICT]
@@ -3968,6 +4262,7 @@ create_table_stmt:
       AsSource: nil,
       AsColumnNames: nil,
       PartitionBy: $9.partitionBy(),
+      Temporary: $2.bool(),
     }
   }
 | CREATE opt_temp TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' opt_interleave opt_partition_by opt_table_with
@@ -3981,6 +4276,7 @@ create_table_stmt:
       AsSource: nil,
       AsColumnNames: nil,
       PartitionBy: $12.partitionBy(),
+      Temporary: $2.bool(),
     }
   }
 
@@ -4000,6 +4296,7 @@ create_table_as_stmt:
       Defs: nil,
       AsSource: $8.slct(),
       AsColumnNames: $5.nameList(),
+      Temporary: $2.bool(),
     }
   }
 | CREATE opt_temp TABLE IF NOT EXISTS table_name opt_column_list opt_table_with AS select_stmt opt_create_as_data
@@ -4012,6 +4309,7 @@ create_table_as_stmt:
       Defs: nil,
       AsSource: $11.slct(),
       AsColumnNames: $8.nameList(),
+      Temporary: $2.bool(),
     }
   }
 
@@ -4032,14 +4330,14 @@ opt_create_as_data:
  * so we'll probably continue to treat LOCAL as a noise word.
  */
 opt_temp:
-  TEMPORARY         { return unimplementedWithIssue(sqllex, 5807) }
-| TEMP              { return unimplementedWithIssue(sqllex, 5807) }
-| LOCAL TEMPORARY   { return unimplementedWithIssue(sqllex, 5807) }
-| LOCAL TEMP        { return unimplementedWithIssue(sqllex, 5807) }
-| GLOBAL TEMPORARY  { return unimplementedWithIssue(sqllex, 5807) }
-| GLOBAL TEMP       { return unimplementedWithIssue(sqllex, 5807) }
+  TEMPORARY         { $$.val = true }
+| TEMP              { $$.val = true }
+| LOCAL TEMPORARY   { $$.val = true }
+| LOCAL TEMP        { $$.val = true }
+| GLOBAL TEMPORARY  { $$.val = true }
+| GLOBAL TEMP       { $$.val = true }
 | UNLOGGED          { return unimplemented(sqllex, "create unlogged") }
-| /*EMPTY*/         { /* no error */ }
+| /*EMPTY*/         { $$.val = false }
 
 opt_table_elem_list:
   table_elem_list
@@ -4069,7 +4367,34 @@ table_elem:
   {
     $$.val = $1.constraintDef()
   }
-| LIKE table_name error { return unimplementedWithIssue(sqllex, 30840) }
+| LIKE table_name like_table_option_list
+  {
+    $$.val = &tree.LikeTableDef{
+      Name: $2.unresolvedObjectName().ToTableName(),
+      Options: $3.likeTableOptionList(),
+    }
+  }
+
+like_table_option_list:
+  like_table_option_list INCLUDING like_table_option
+  {
+    $$.val = append($1.likeTableOptionList(), tree.LikeTableOption{Opt: $3.likeTableOpt()})
+  }
+| like_table_option_list EXCLUDING like_table_option
+  {
+    $$.val = append($1.likeTableOptionList(), tree.LikeTableOption{Excluded: true, Opt: $3.likeTableOpt()})
+  }
+| /* EMPTY */
+  {
+    $$.val = []tree.LikeTableOption(nil)
+  }
+
+like_table_option:
+  CONSTRAINTS { $$.val = tree.LikeTableOptConstraints }
+| DEFAULTS    { $$.val = tree.LikeTableOptDefaults }
+| GENERATED   { $$.val = tree.LikeTableOptGenerated }
+| INDEXES     { $$.val = tree.LikeTableOptIndexes }
+| ALL         { $$.val = tree.LikeTableOptAll }
 
 opt_interleave:
   INTERLEAVE IN PARENT table_name '(' name_list ')' opt_interleave_drop_behavior
@@ -4229,7 +4554,7 @@ col_qualification:
   {
     $$.val = tree.NamedColumnQualification{Qualification: &tree.ColumnFamilyConstraint{Family: tree.Name($3), Create: true}}
   }
-| CREATE FAMILY
+| CREATE FAMILY %prec VALUES
   {
     $$.val = tree.NamedColumnQualification{Qualification: &tree.ColumnFamilyConstraint{Create: true}}
   }
@@ -4291,16 +4616,34 @@ col_qualification_elem:
  }
 | AS '(' a_expr ')' VIRTUAL
  {
-    return unimplemented(sqllex, "virtual computed columns")
+    $$.val = &tree.ColumnComputedDef{Expr: $3.expr(), Virtual: true}
  }
 | AS error
  {
-    sqllex.Error("syntax error: use AS ( <expr> ) STORED")
+    sqllex.Error("syntax error: use AS ( <expr> ) STORED or AS ( <expr> ) VIRTUAL")
     return 1
  }
+| GENERATED ALWAYS AS IDENTITY opt_identity_sequence_options
+ {
+    $$.val = &tree.ColumnIdentityDef{Always: true, SeqOptions: $5.seqOpts()}
+ }
+| GENERATED BY DEFAULT AS IDENTITY opt_identity_sequence_options
+ {
+    $$.val = &tree.ColumnIdentityDef{SeqOptions: $6.seqOpts()}
+ }
+
+opt_identity_sequence_options:
+  '(' sequence_option_list ')'
+  {
+    $$.val = $2.seqOpts()
+  }
+| /* EMPTY */
+  {
+    $$.val = []tree.SequenceOption(nil)
+  }
 
 index_def:
-  INDEX opt_index_name '(' index_params ')' opt_storing opt_interleave opt_partition_by
+  INDEX opt_index_name '(' index_params ')' opt_storing opt_interleave opt_partition_by opt_idx_where
   {
     $$.val = &tree.IndexTableDef{
       Name:    tree.Name($2),
@@ -4308,9 +4651,10 @@ index_def:
       Storing: $6.nameList(),
       Interleave: $7.interleave(),
       PartitionBy: $8.partitionBy(),
+      Predicate: $9.expr(),
     }
   }
-| UNIQUE INDEX opt_index_name '(' index_params ')' opt_storing opt_interleave opt_partition_by
+| UNIQUE INDEX opt_index_name '(' index_params ')' opt_storing opt_interleave opt_partition_by opt_idx_where
   {
     $$.val = &tree.UniqueConstraintTableDef{
       IndexTableDef: tree.IndexTableDef {
@@ -4319,6 +4663,7 @@ index_def:
         Storing: $7.nameList(),
         Interleave: $8.interleave(),
         PartitionBy: $9.partitionBy(),
+        Predicate: $10.expr(),
       },
     }
   }
@@ -4357,6 +4702,10 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability() != tree.NotDeferrable {
+      sqllex.Error("CHECK constraints cannot be marked DEFERRABLE")
+      return 1
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
@@ -4370,6 +4719,7 @@ constraint_elem:
         Interleave: $6.interleave(),
         PartitionBy: $7.partitionBy(),
       },
+      Deferrability: $8.constraintDeferrability(),
     }
   }
 | PRIMARY KEY '(' index_params ')'
@@ -4391,16 +4741,37 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
     }
   }
 
+// INITIALLY DEFERRED implies DEFERRABLE, while INITIALLY IMMEDIATE alone is
+// the default.
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.NotDeferrable
+  }
+| DEFERRABLE
+  {
+    $$.val = tree.DeferrableInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.DeferrableInitiallyDeferred
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.DeferrableInitiallyImmediate
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.DeferrableInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.NotDeferrable
+  }
 
 storing:
   COVERING
@@ -4554,17 +4925,24 @@ numeric_only:
 //   [START [WITH] <start>]
 //   [CACHE <cache>]
 //   [NO CYCLE]
+//   [OWNED BY <tablename>.<colname> | OWNED BY NONE]
 //   [VIRTUAL]
 //
 // %SeeAlso: CREATE TABLE
 create_sequence_stmt:
   CREATE opt_temp SEQUENCE sequence_name opt_sequence_option_list
   {
+    if $2.bool() {
+      return unimplementedWithIssueDetail(sqllex, 5807, "create temp sequence")
+    }
     name := $4.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateSequence{Name: name, Options: $5.seqOpts()}
   }
 | CREATE opt_temp SEQUENCE IF NOT EXISTS sequence_name opt_sequence_option_list
   {
+    if $2.bool() {
+      return unimplementedWithIssueDetail(sqllex, 5807, "create temp sequence")
+    }
     name := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateSequence{Name: name, Options: $8.seqOpts(), IfNotExists: true}
   }
@@ -4583,7 +4961,21 @@ sequence_option_elem:
 | CYCLE                        { /* SKIP DOC */
                                  $$.val = tree.SequenceOption{Name: tree.SeqOptCycle} }
 | NO CYCLE                     { $$.val = tree.SequenceOption{Name: tree.SeqOptNoCycle} }
-| OWNED BY column_path         { return unimplementedWithIssue(sqllex, 26382) }
+| OWNED BY column_path         { varName, err := $3.unresolvedName().NormalizeVarName()
+                                 if err != nil {
+                                   return setErr(sqllex, err)
+                                 }
+                                 columnItem, ok := varName.(*tree.ColumnItem)
+                                 if !ok {
+                                   sqllex.Error(fmt.Sprintf("invalid column name: %q", tree.ErrString($3.unresolvedName())))
+                                   return 1
+                                 }
+                                 // As in Postgres, NONE is not a keyword: OWNED BY NONE
+                                 // is an unqualified column name that is handled here.
+                                 if columnItem.TableName == nil && columnItem.ColumnName == "none" {
+                                   columnItem = nil
+                                 }
+                                 $$.val = tree.SequenceOption{Name: tree.SeqOptOwnedBy, ColumnItemVal: columnItem} }
 | CACHE signed_iconst64        { /* SKIP DOC */
                                  x := $2.int64()
                                  $$.val = tree.SequenceOption{Name: tree.SeqOptCache, IntVal: &x} }
@@ -4662,7 +5054,7 @@ role_or_group:
 
 // %Help: CREATE VIEW - create a new view
 // %Category: DDL
-// %Text: CREATE VIEW <viewname> [( <colnames...> )] AS <source>
+// %Text: CREATE [TEMPORARY | MATERIALIZED] VIEW <viewname> [( <colnames...> )] AS <source>
 // %SeeAlso: CREATE TABLE, SHOW CREATE, WEBDOCS/create-view.html
 create_view_stmt:
   CREATE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt
@@ -4672,22 +5064,66 @@ create_view_stmt:
       Name: name,
       ColumnNames: $6.nameList(),
       AsSource: $8.slct(),
+      Temporary: $2.bool(),
+    }
+  }
+| CREATE MATERIALIZED VIEW view_name opt_column_list AS select_stmt
+  {
+    name := $4.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateView{
+      Name: name,
+      ColumnNames: $5.nameList(),
+      AsSource: $7.slct(),
+      Materialized: true,
     }
   }
 | CREATE OR REPLACE opt_temp opt_view_recursive VIEW error { return unimplementedWithIssue(sqllex, 24897) }
 | CREATE opt_temp opt_view_recursive VIEW error // SHOW HELP: CREATE VIEW
+| CREATE MATERIALIZED VIEW error // SHOW HELP: CREATE VIEW
 
 opt_view_recursive:
   /* EMPTY */ { /* no error */ }
 | RECURSIVE { return unimplemented(sqllex, "create recursive view") }
 
-// CREATE TYPE/DOMAIN is not yet supported by CockroachDB but we
-// want to report it with the right issue number.
+// %Help: REFRESH - recompute the contents of a materialized view
+// %Category: Misc
+// %Text: REFRESH MATERIALIZED VIEW [CONCURRENTLY] <viewname>
+// %SeeAlso: CREATE VIEW
+refresh_stmt:
+  REFRESH MATERIALIZED VIEW view_name
+  {
+    $$.val = &tree.RefreshMaterializedView{Name: $4.unresolvedObjectName().ToTableName()}
+  }
+| REFRESH MATERIALIZED VIEW CONCURRENTLY view_name
+  {
+    $$.val = &tree.RefreshMaterializedView{
+      Name: $5.unresolvedObjectName().ToTableName(),
+      Concurrently: true,
+    }
+  }
+| REFRESH error // SHOW HELP: REFRESH
+
+// Only enum and composite types are supported by CREATE TYPE. The other
+// kinds of types and CREATE DOMAIN are reported with the right issue number.
 create_type_stmt:
-  // Record/Composite types.
-  CREATE TYPE type_name AS '(' error      { return unimplementedWithIssue(sqllex, 27792) }
   // Enum types.
-| CREATE TYPE type_name AS ENUM '(' error { return unimplementedWithIssue(sqllex, 24873) }
+  CREATE TYPE type_name AS ENUM '(' opt_enum_val_list ')'
+  {
+    $$.val = &tree.CreateType{
+      TypeName: $3.unresolvedObjectName().ToTableName(),
+      Variety: tree.Enum,
+      EnumLabels: $7.strs(),
+    }
+  }
+  // Record/Composite types.
+| CREATE TYPE type_name AS '(' opt_composite_type_field_list ')'
+  {
+    $$.val = &tree.CreateType{
+      TypeName: $3.unresolvedObjectName().ToTableName(),
+      Variety: tree.Composite,
+      CompositeFields: $6.compositeTypeFields(),
+    }
+  }
   // Range types.
 | CREATE TYPE type_name AS RANGE error    { return unimplementedWithIssue(sqllex, 27791) }
   // Base (primitive) types.
@@ -4697,12 +5133,304 @@ create_type_stmt:
   // Domain types.
 | CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
 
+opt_composite_type_field_list:
+  composite_type_field_list
+  {
+    $$.val = $1.compositeTypeFields()
+  }
+| /* EMPTY */
+  {
+    $$.val = []tree.CompositeTypeField(nil)
+  }
+
+composite_type_field_list:
+  composite_type_field
+  {
+    $$.val = []tree.CompositeTypeField{$1.compositeTypeField()}
+  }
+| composite_type_field_list ',' composite_type_field
+  {
+    $$.val = append($1.compositeTypeFields(), $3.compositeTypeField())
+  }
+
+composite_type_field:
+  name typename
+  {
+    $$.val = tree.CompositeTypeField{Name: tree.Name($1), Type: $2.colType()}
+  }
+
+opt_enum_val_list:
+  enum_val_list
+  {
+    $$.val = $1.strs()
+  }
+| /* EMPTY */
+  {
+    $$.val = []string(nil)
+  }
+
+enum_val_list:
+  SCONST
+  {
+    $$.val = []string{$1}
+  }
+| enum_val_list ',' SCONST
+  {
+    $$.val = append($1.strs(), $3)
+  }
+
+// %Help: CREATE FUNCTION - define a new function
+// %Category: DDL
+// %Text:
+// CREATE [OR REPLACE] FUNCTION <name> ( [ [<argname>] <argtype> [, ...] ] )
+//   RETURNS [SETOF] <rettype>
+//   { LANGUAGE SQL
+//   | IMMUTABLE | STABLE | VOLATILE
+//   | CALLED ON NULL INPUT | RETURNS NULL ON NULL INPUT | STRICT
+//   | AS '<definition>'
+//   } ...
+//
+// The parameters can be referenced in the definition by name or as $1, $2, ...
+// %SeeAlso: DROP FUNCTION
+create_function_stmt:
+  CREATE opt_or_replace FUNCTION db_object_name '(' opt_func_param_list ')' RETURNS opt_setof typename func_option_list
+  {
+    $$.val = &tree.CreateFunction{
+      FuncName: $4.unresolvedObjectName().ToTableName(),
+      Replace: $2.bool(),
+      Params: $6.functionParams(),
+      ReturnType: $10.colType(),
+      ReturnsSet: $9.bool(),
+      Options: $11.functionOptions(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION error // SHOW HELP: CREATE FUNCTION
+
+opt_func_param_list:
+  func_param_list
+| /* EMPTY */
+  {
+    $$.val = tree.FunctionParams(nil)
+  }
+
+func_param_list:
+  func_param
+  {
+    $$.val = tree.FunctionParams{$1.functionParam()}
+  }
+| func_param_list ',' func_param
+  {
+    $$.val = append($1.functionParams(), $3.functionParam())
+  }
+
+// Parameter names are restricted to identifiers: keywords would be ambiguous
+// with the type names that are also keywords.
+func_param:
+  typename
+  {
+    $$.val = tree.FunctionParam{Type: $1.colType()}
+  }
+| IDENT typename
+  {
+    $$.val = tree.FunctionParam{Name: tree.Name($1), Type: $2.colType()}
+  }
+
+opt_setof:
+  SETOF
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
+func_option_list:
+  func_option
+  {
+    $$.val = tree.FunctionOptions{$1.functionOption()}
+  }
+| func_option_list func_option
+  {
+    $$.val = append($1.functionOptions(), $2.functionOption())
+  }
+
+func_option:
+  AS SCONST
+  {
+    $$.val = tree.FunctionOption{Name: tree.FuncOptAs, StrVal: $2}
+  }
+| LANGUAGE non_reserved_word_or_sconst
+  {
+    $$.val = tree.FunctionOption{Name: tree.FuncOptLanguage, StrVal: $2}
+  }
+| IMMUTABLE
+  {
+    $$.val = tree.FunctionOption{Name: tree.FuncOptImmutable}
+  }
+| STABLE
+  {
+    $$.val = tree.FunctionOption{Name: tree.FuncOptStable}
+  }
+| VOLATILE
+  {
+    $$.val = tree.FunctionOption{Name: tree.FuncOptVolatile}
+  }
+| CALLED ON NULL INPUT
+  {
+    $$.val = tree.FunctionOption{Name: tree.FuncOptCalledOnNullInput}
+  }
+| RETURNS NULL ON NULL INPUT
+  {
+    $$.val = tree.FunctionOption{Name: tree.FuncOptReturnsNullOnNullInput}
+  }
+| STRICT
+  {
+    $$.val = tree.FunctionOption{Name: tree.FuncOptStrict}
+  }
+
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE TRIGGER <name> { BEFORE | AFTER } <event> [ OR <event> ... ]
+//   ON <tablename> [ FOR [EACH] { ROW | STATEMENT } ]
+//   [ WHEN ( <condition> ) ]
+//   AS '<definition>'
+//
+// <event> is one of INSERT, UPDATE or DELETE. The definition is a list of
+// SQL statements separated by semicolons. In row triggers, the affected row
+// can be referenced in the condition and the definition as NEW.<colname> and
+// OLD.<colname>.
+// %SeeAlso: DROP TRIGGER
+create_trigger_stmt:
+  CREATE TRIGGER name trigger_action_time trigger_event_list ON table_name opt_trigger_for_each opt_trigger_when AS SCONST
+  {
+    $$.val = &tree.CreateTrigger{
+      Name: tree.Name($3),
+      ActionTime: $4.triggerActionTime(),
+      Events: $5.triggerEvents(),
+      Table: $7.unresolvedObjectName().ToTableName(),
+      ForEachRow: $8.bool(),
+      When: $9.expr(),
+      Body: $11,
+    }
+  }
+| CREATE TRIGGER name trigger_action_time trigger_event_list ON table_name opt_trigger_for_each opt_trigger_when EXECUTE error
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "execute function")
+  }
+| CREATE TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+trigger_action_time:
+  BEFORE
+  {
+    $$.val = tree.TriggerBefore
+  }
+| AFTER
+  {
+    $$.val = tree.TriggerAfter
+  }
+
+trigger_event_list:
+  trigger_event
+  {
+    $$.val = tree.TriggerEvents{$1.triggerEvent()}
+  }
+| trigger_event_list OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$.val = tree.TriggerInsert
+  }
+| UPDATE
+  {
+    $$.val = tree.TriggerUpdate
+  }
+| DELETE
+  {
+    $$.val = tree.TriggerDelete
+  }
+| UPDATE OF error
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "update of")
+  }
+| TRUNCATE
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "truncate")
+  }
+
+opt_trigger_for_each:
+  FOR opt_each ROW
+  {
+    $$.val = true
+  }
+| FOR opt_each STATEMENT
+  {
+    $$.val = false
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
+opt_each:
+  EACH {}
+| /* EMPTY */ {}
+
+opt_trigger_when:
+  WHEN '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+alter_type_stmt:
+  ALTER TYPE type_name ADD VALUE SCONST opt_add_val_placement
+  {
+    $$.val = &tree.AlterTypeAddValue{
+      TypeName: $3.unresolvedObjectName().ToTableName(),
+      NewVal: $6,
+      Placement: $7.alterTypeAddValuePlacement(),
+    }
+  }
+| ALTER TYPE type_name ADD VALUE IF NOT EXISTS SCONST opt_add_val_placement
+  {
+    $$.val = &tree.AlterTypeAddValue{
+      TypeName: $3.unresolvedObjectName().ToTableName(),
+      IfNotExists: true,
+      NewVal: $9,
+      Placement: $10.alterTypeAddValuePlacement(),
+    }
+  }
+
+opt_add_val_placement:
+  BEFORE SCONST
+  {
+    $$.val = &tree.AlterTypeAddValuePlacement{Before: true, ExistingVal: $2}
+  }
+| AFTER SCONST
+  {
+    $$.val = &tree.AlterTypeAddValuePlacement{Before: false, ExistingVal: $2}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.AlterTypeAddValuePlacement)(nil)
+  }
+
 // %Help: CREATE INDEX - create a new index
 // %Category: DDL
 // %Text:
 // CREATE [UNIQUE | INVERTED] INDEX [IF NOT EXISTS] [<idxname>]
 //        ON <tablename> ( <colname> [ASC | DESC] [, ...] )
 //        [STORING ( <colnames...> )] [<interleave>]
+//        [WHERE <predicate>]
 //
 // Interleave clause:
 //    INTERLEAVE IN PARENT <tablename> ( <colnames...> ) [CASCADE | RESTRICT]
@@ -4722,6 +5450,7 @@ create_index_stmt:
       Interleave: $12.interleave(),
       PartitionBy: $13.partitionBy(),
       Inverted: $7.bool(),
+      Predicate: $14.expr(),
     }
   }
 | CREATE opt_unique INDEX IF NOT EXISTS index_name ON table_name opt_using_gin_btree '(' index_params ')' opt_storing opt_interleave opt_partition_by opt_idx_where
@@ -4737,6 +5466,7 @@ create_index_stmt:
       Interleave:  $15.interleave(),
       PartitionBy: $16.partitionBy(),
       Inverted:    $10.bool(),
+      Predicate:   $17.expr(),
     }
   }
 | CREATE opt_unique INVERTED INDEX opt_index_name ON table_name '(' index_params ')' opt_storing opt_interleave opt_partition_by opt_idx_where
@@ -4751,6 +5481,7 @@ create_index_stmt:
       Storing:     $11.nameList(),
       Interleave:  $12.interleave(),
       PartitionBy: $13.partitionBy(),
+      Predicate:   $14.expr(),
     }
   }
 | CREATE opt_unique INVERTED INDEX IF NOT EXISTS index_name ON table_name '(' index_params ')' opt_storing opt_interleave opt_partition_by opt_idx_where
@@ -4766,13 +5497,20 @@ create_index_stmt:
       Storing:     $14.nameList(),
       Interleave:  $15.interleave(),
       PartitionBy: $16.partitionBy(),
+      Predicate:   $17.expr(),
     }
   }
 | CREATE opt_unique INDEX error // SHOW HELP: CREATE INDEX
 
 opt_idx_where:
-  /* EMPTY */ { /* no error */ }
-| WHERE error { return unimplementedWithIssue(sqllex, 9683) }
+  /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+| WHERE a_expr
+  {
+    $$.val = $2.expr()
+  }
 
 opt_using_gin_btree:
   USING name
@@ -5225,8 +5963,10 @@ opt_equal:
 // %Category: DML
 // %Text:
 // INSERT INTO <tablename> [[AS] <name>] [( <colnames...> )]
+//        [OVERRIDING {SYSTEM | USER} VALUE]
 //        <selectclause>
-//        [ON CONFLICT [( <colnames...> )] {DO UPDATE SET ... [WHERE <expr>] | DO NOTHING}]
+//        [ON CONFLICT [( <colnames...> ) [WHERE <expr>] | ON CONSTRAINT <name>]
+//            {DO UPDATE SET ... [WHERE <expr>] | DO NOTHING}]
 //        [RETURNING <exprs...>]
 // %SeeAlso: UPSERT, UPDATE, DELETE, WEBDOCS/insert.html
 insert_stmt:
@@ -5288,21 +6028,45 @@ insert_rest:
   }
 | '(' insert_column_list ')' select_stmt
   {
-    $$.val = &tree.Insert{Columns: $2.nameList(), Rows: $4.slct()}
+    targets := $2.columnTargets()
+    $$.val = &tree.Insert{Columns: targets.names, ColumnFields: targets.fields, Rows: $4.slct()}
+  }
+| OVERRIDING override_kind VALUE select_stmt
+  {
+    $$.val = &tree.Insert{Overriding: $2.overriding(), Rows: $4.slct()}
+  }
+| '(' insert_column_list ')' OVERRIDING override_kind VALUE select_stmt
+  {
+    targets := $2.columnTargets()
+    $$.val = &tree.Insert{Columns: targets.names, ColumnFields: targets.fields, Overriding: $5.overriding(), Rows: $7.slct()}
   }
 | DEFAULT VALUES
   {
     $$.val = &tree.Insert{Rows: &tree.Select{}}
   }
 
+override_kind:
+  SYSTEM
+  {
+    $$.val = tree.OverridingSystemValue
+  }
+| USER
+  {
+    $$.val = tree.OverridingUserValue
+  }
+
 insert_column_list:
   insert_column_item
   {
-    $$.val = tree.NameList{tree.Name($1)}
+    targets := &columnTargets{}
+    targets.add($1.nameList())
+    $$.val = targets
   }
 | insert_column_list ',' insert_column_item
   {
-    $$.val = append($1.nameList(), tree.Name($3))
+    targets := $1.columnTargets()
+    targets.add($3.nameList())
+    $$.val = targets
   }
 
 // insert_column_item represents the target of an INSERT/UPSERT or one
@@ -5314,35 +6078,47 @@ insert_column_list:
 //    UPDATE foo SET x = 1+2, (y, z) = (4, 5)
 //                   ^^ here   ^^^^ here
 //
-// Currently CockroachDB only supports simple column names in this
-// position. The rule below can be extended to support a sequence of
-// field subscript or array indexing operators to designate a part of
-// a field, when partial updates are to be supported. This likely will
-// be needed together with support for composite types (#27792).
+// A target is either a column, or a field of a column of composite
+// type. The other fields of the column keep their values in an UPDATE,
+// and are NULL in an INSERT. Array subscripts are not supported in this
+// position.
 insert_column_item:
   column_name
-| column_name '.' error { return unimplementedWithIssue(sqllex, 27792) }
+  {
+    $$.val = tree.NameList{tree.Name($1)}
+  }
+| column_name '.' unrestricted_name
+  {
+    $$.val = tree.NameList{tree.Name($1), tree.Name($3)}
+  }
 
 on_conflict:
   ON CONFLICT opt_conf_expr DO UPDATE SET set_clause_list opt_where_clause
   {
-    $$.val = &tree.OnConflict{Columns: $3.nameList(), Exprs: $7.updateExprs(), Where: tree.NewWhere(tree.AstWhere, $8.expr())}
+    oc := $3.onConflict()
+    oc.Exprs = $7.updateExprs()
+    oc.Where = tree.NewWhere(tree.AstWhere, $8.expr())
+    $$.val = oc
   }
 | ON CONFLICT opt_conf_expr DO NOTHING
   {
-    $$.val = &tree.OnConflict{Columns: $3.nameList(), DoNothing: true}
+    oc := $3.onConflict()
+    oc.DoNothing = true
+    $$.val = oc
   }
 
 opt_conf_expr:
-  '(' name_list ')'
+  '(' name_list ')' opt_where_clause
   {
-    $$.val = $2.nameList()
+    $$.val = &tree.OnConflict{Columns: $2.nameList(), ArbiterPredicate: $4.expr()}
+  }
+| ON CONSTRAINT constraint_name
+  {
+    $$.val = &tree.OnConflict{Constraint: tree.Name($3)}
   }
-| '(' name_list ')' where_clause { return unimplementedWithIssue(sqllex, 32557) }
-| ON CONSTRAINT constraint_name { return unimplementedWithIssue(sqllex, 28161) }
 | /* EMPTY */
   {
-    $$.val = tree.NameList(nil)
+    $$.val = &tree.OnConflict{}
   }
 
 returning_clause:
@@ -5365,6 +6141,7 @@ returning_clause:
 // %Text:
 // UPDATE <tablename> [[AS] <name>]
 //        SET ...
+//        [FROM <source>]
 //        [WHERE <expr>]
 //        [ORDER BY <exprs...>]
 //        [LIMIT <expr>]
@@ -5378,6 +6155,7 @@ update_stmt:
       With: $1.with(),
       Table: $3.tblExpr(),
       Exprs: $5.updateExprs(),
+      From: $6.tblExprs(),
       Where: tree.NewWhere(tree.AstWhere, $7.expr()),
       OrderBy: $8.orderBy(),
       Limit: $9.limit(),
@@ -5386,10 +6164,16 @@ update_stmt:
   }
 | opt_with_clause UPDATE error // SHOW HELP: UPDATE
 
-// Mark this as unimplemented until the normal from_clause is supported here.
+// The FROM clause of UPDATE doesn't support AS OF SYSTEM TIME.
 update_from_clause:
-  FROM from_list { return unimplementedWithIssue(sqllex, 7841) }
-| /* EMPTY */ {}
+  FROM from_list
+  {
+    $$.val = $2.tblExprs()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.TableExprs{}
+  }
 
 set_clause_list:
   set_clause
@@ -5401,9 +6185,6 @@ set_clause_list:
     $$.val = append($1.updateExprs(), $3.updateExpr())
   }
 
-// TODO(knz): The LHS in these can be extended to support
-// a path to a field member when compound types are supported.
-// Keep it simple for now.
 set_clause:
   single_set_clause
 | multiple_set_clause
@@ -5413,12 +6194,16 @@ single_set_clause:
   {
     $$.val = &tree.UpdateExpr{Names: tree.NameList{tree.Name($1)}, Expr: $3.expr()}
   }
-| column_name '.' error { return unimplementedWithIssue(sqllex, 27792) }
+| column_name '.' unrestricted_name '=' a_expr
+  {
+    $$.val = &tree.UpdateExpr{Names: tree.NameList{tree.Name($1)}, Fields: tree.NameList{tree.Name($3)}, Expr: $5.expr()}
+  }
 
 multiple_set_clause:
   '(' insert_column_list ')' '=' in_expr
   {
-    $$.val = &tree.UpdateExpr{Tuple: true, Names: $2.nameList(), Expr: $5.expr()}
+    targets := $2.columnTargets()
+    $$.val = &tree.UpdateExpr{Tuple: true, Names: targets.names, Fields: targets.fields, Expr: $5.expr()}
   }
 
 // A complete SELECT statement looks like this.
@@ -5484,34 +6269,120 @@ select_with_parens:
 //      clause.
 //      - 2002-08-28 bjm
 select_no_parens:
-  simple_select opt_for
+  simple_select
   {
     $$.val = &tree.Select{Select: $1.selectStmt()}
   }
-| select_clause sort_clause opt_for
+| select_clause sort_clause
   {
     $$.val = &tree.Select{Select: $1.selectStmt(), OrderBy: $2.orderBy()}
   }
-| select_clause opt_sort_clause select_limit opt_for
+| select_clause opt_sort_clause for_locking_clause opt_select_limit
+  {
+    $$.val = &tree.Select{Select: $1.selectStmt(), OrderBy: $2.orderBy(), Limit: $4.limit(), Locking: $3.lockingClause()}
+  }
+| select_clause opt_sort_clause select_limit opt_for_locking_clause
   {
-    $$.val = &tree.Select{Select: $1.selectStmt(), OrderBy: $2.orderBy(), Limit: $3.limit()}
+    $$.val = &tree.Select{Select: $1.selectStmt(), OrderBy: $2.orderBy(), Limit: $3.limit(), Locking: $4.lockingClause()}
   }
-| with_clause select_clause opt_for
+| with_clause select_clause
   {
     $$.val = &tree.Select{With: $1.with(), Select: $2.selectStmt()}
   }
-| with_clause select_clause sort_clause opt_for
+| with_clause select_clause sort_clause
   {
     $$.val = &tree.Select{With: $1.with(), Select: $2.selectStmt(), OrderBy: $3.orderBy()}
   }
-| with_clause select_clause opt_sort_clause select_limit opt_for
+| with_clause select_clause opt_sort_clause for_locking_clause opt_select_limit
   {
-    $$.val = &tree.Select{With: $1.with(), Select: $2.selectStmt(), OrderBy: $3.orderBy(), Limit: $4.limit()}
+    $$.val = &tree.Select{With: $1.with(), Select: $2.selectStmt(), OrderBy: $3.orderBy(), Limit: $5.limit(), Locking: $4.lockingClause()}
+  }
+| with_clause select_clause opt_sort_clause select_limit opt_for_locking_clause
+  {
+    $$.val = &tree.Select{With: $1.with(), Select: $2.selectStmt(), OrderBy: $3.orderBy(), Limit: $4.limit(), Locking: $5.lockingClause()}
   }
 
-opt_for:
-  /* EMPTY */ { /* no error */ }
-| FOR error { return unimplementedWithIssue(sqllex, 6583) }
+for_locking_clause:
+  for_locking_items
+  {
+    $$.val = $1.lockingClause()
+  }
+| FOR READ ONLY
+  {
+    $$.val = (tree.LockingClause)(nil)
+  }
+
+opt_for_locking_clause:
+  for_locking_clause
+  {
+    $$.val = $1.lockingClause()
+  }
+| /* EMPTY */
+  {
+    $$.val = (tree.LockingClause)(nil)
+  }
+
+for_locking_items:
+  for_locking_item
+  {
+    $$.val = tree.LockingClause{$1.lockingItem()}
+  }
+| for_locking_items for_locking_item
+  {
+    $$.val = append($1.lockingClause(), $2.lockingItem())
+  }
+
+for_locking_item:
+  for_locking_strength opt_locked_rels opt_nowait_or_skip
+  {
+    $$.val = &tree.LockingItem{
+      Strength:   $1.lockingStrength(),
+      Targets:    $2.tableNames(),
+      WaitPolicy: $3.lockingWaitPolicy(),
+    }
+  }
+
+for_locking_strength:
+  FOR UPDATE
+  {
+    $$.val = tree.ForUpdate
+  }
+| FOR NO KEY UPDATE
+  {
+    $$.val = tree.ForNoKeyUpdate
+  }
+| FOR SHARE
+  {
+    $$.val = tree.ForShare
+  }
+| FOR KEY SHARE
+  {
+    $$.val = tree.ForKeyShare
+  }
+
+opt_locked_rels:
+  /* EMPTY */
+  {
+    $$.val = tree.TableNames{}
+  }
+| OF table_name_list
+  {
+    $$.val = $2.tableNames()
+  }
+
+opt_nowait_or_skip:
+  /* EMPTY */
+  {
+    $$.val = tree.LockWaitBlock
+  }
+| SKIP LOCKED
+  {
+    $$.val = tree.LockWaitSkip
+  }
+| NOWAIT
+  {
+    $$.val = tree.LockWaitError
+  }
 
 select_clause:
 // We only provide help if an open parenthesis is provided, because
@@ -5570,6 +6441,8 @@ simple_select:
 //        [ ORDER BY <expr> [ ASC | DESC ] [, ...] ]
 //        [ LIMIT { <expr> | ALL } ]
 //        [ OFFSET <expr> [ ROW | ROWS ] ]
+//        [ FOR { UPDATE | NO KEY UPDATE | SHARE | KEY SHARE } [ OF <tablename> [, ...] ]
+//              [ NOWAIT | SKIP LOCKED ] [...] ]
 // %SeeAlso: WEBDOCS/select-clause.html
 simple_select_clause:
   SELECT opt_all_clause target_list
@@ -5678,7 +6551,10 @@ with_clause:
     /* SKIP DOC */
     $$.val = &tree.With{CTEList: $2.ctes()}
   }
-| WITH RECURSIVE cte_list { return unimplementedWithIssue(sqllex, 21085) }
+| WITH RECURSIVE cte_list
+  {
+    $$.val = &tree.With{Recursive: true, CTEList: $3.ctes()}
+  }
 
 cte_list:
   common_table_expr
@@ -5821,6 +6697,10 @@ select_limit:
 | limit_clause
 | offset_clause
 
+opt_select_limit:
+  select_limit { $$.val = $1.limit() }
+| /* EMPTY */  { $$.val = (*tree.Limit)(nil) }
+
 opt_limit_clause:
   limit_clause
 | /* EMPTY */ { $$.val = (*tree.Limit)(nil) }
@@ -6448,7 +7328,6 @@ typename:
       return setErr(sqllex, err)
     }
   }
-| simple_typename ARRAY '[' ICONST ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
 | simple_typename ARRAY {
     var err error
     $$.val, err = arrayOf($1.colType(), nil)
@@ -6468,20 +7347,16 @@ cast_target:
   }
 
 opt_array_bounds:
-  // TODO(justin): reintroduce multiple array bounds
-  // opt_array_bounds '[' ']' { $$.val = append($1.int32s(), -1) }
-  '[' ']' { $$.val = []int32{-1} }
-| '[' ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
-| '[' ICONST ']'
+  opt_array_bounds '[' ']' { $$.val = append($1.int32s(), -1) }
+| opt_array_bounds '[' ICONST ']'
   {
     /* SKIP DOC */
-    bound, err := $2.numVal().AsInt32()
+    bound, err := $3.numVal().AsInt32()
     if err != nil {
       return setErr(sqllex, err)
     }
-    $$.val = []int32{bound}
+    $$.val = append($1.int32s(), bound)
   }
-| '[' ICONST ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
 | /* EMPTY */ { $$.val = []int32(nil) }
 
 const_json:
@@ -6493,8 +7368,18 @@ simple_typename:
 | bit_with_length
 | character_with_length
 | const_interval
-| const_interval interval_qualifier { return unimplemented(sqllex, "interval with unit qualifier") }
-| const_interval '(' ICONST ')' { return unimplementedWithIssue(sqllex, 32564) }
+| const_interval interval_qualifier
+  {
+    $$.val = types.MakeInterval($2.intervalTypeMetadata())
+  }
+| const_interval '(' iconst32 ')'
+  {
+    prec := $3.int32()
+    if err := checkIntervalPrecision(prec); err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = types.MakeInterval(types.IntervalMetadata{Precision: prec, PrecisionIsSet: true})
+  }
 
 // We have a separate const_typename to allow defaulting fixed-length types
 // such as CHAR() and BIT() to an unspecified length. SQL9x requires that these
@@ -6589,8 +7474,8 @@ const_typename:
     // See https://www.postgresql.org/docs/9.1/static/datatype-character.html
     // Postgres supports a special character type named "char" (with the quotes)
     // that is a single-character column type. It's used by system tables.
-    // Eventually this clause will be used to parse user-defined types as well,
-    // since their names can be quoted.
+    // This clause also parses the names of user-defined types, since their
+    // names can be quoted.
     if $1 == "char" {
       $$.val = types.MakeQChar(0)
     } else {
@@ -6600,10 +7485,9 @@ const_typename:
       if !ok {
           switch unimp {
               case 0:
-                // Note: we can only report an unimplemented error for specific
-                // known type names. Anything else may return PII.
-                sqllex.Error("type does not exist")
-                return 1
+                // The name may refer to a user-defined type, which is
+                // resolved during semantic analysis.
+                $$.val = types.MakeUnresolvedType($1)
               case -1:
                 return unimplemented(sqllex, "type name " + $1)
               default:
@@ -6844,8 +7728,11 @@ const_datetime:
   }
 | TIME opt_timezone
   {
-    if $2.bool() { return unimplementedWithIssueDetail(sqllex, 26097, "type") }
-    $$.val = types.Time
+    if $2.bool() {
+      $$.val = types.TimeTZ
+    } else {
+      $$.val = types.Time
+    }
   }
 | TIME '(' iconst32 ')' opt_timezone
   {
@@ -6853,10 +7740,24 @@ const_datetime:
     if prec != 6 {
          return unimplementedWithIssue(sqllex, 32565)
     }
-    $$.val = types.MakeTime(prec)
+    if $5.bool() {
+      $$.val = types.MakeTimeTZ(prec)
+    } else {
+      $$.val = types.MakeTime(prec)
+    }
+  }
+| TIMETZ
+  {
+    $$.val = types.TimeTZ
+  }
+| TIMETZ '(' iconst32 ')'
+  {
+    prec := $3.int32()
+    if prec != 6 {
+         return unimplementedWithIssue(sqllex, 32565)
+    }
+    $$.val = types.MakeTimeTZ(prec)
   }
-| TIMETZ                             { return unimplementedWithIssueDetail(sqllex, 26097, "type") }
-| TIMETZ '(' ICONST ')'              { return unimplementedWithIssueDetail(sqllex, 26097, "type with precision") }
 | TIMESTAMP opt_timezone
   {
     if $2.bool() {
@@ -6904,72 +7805,102 @@ const_interval:
 interval_qualifier:
   YEAR
   {
-    $$.val = tree.Year
+    $$.val = types.IntervalMetadata{DurationType: types.IntervalDurationType_YEAR}
   }
 | MONTH
   {
-    $$.val = tree.Month
+    $$.val = types.IntervalMetadata{DurationType: types.IntervalDurationType_MONTH}
   }
 | DAY
   {
-    $$.val = tree.Day
+    $$.val = types.IntervalMetadata{DurationType: types.IntervalDurationType_DAY}
   }
 | HOUR
   {
-    $$.val = tree.Hour
+    $$.val = types.IntervalMetadata{DurationType: types.IntervalDurationType_HOUR}
   }
 | MINUTE
   {
-    $$.val = tree.Minute
+    $$.val = types.IntervalMetadata{DurationType: types.IntervalDurationType_MINUTE}
   }
 | interval_second
   {
-    $$.val = $1.durationField()
+    $$.val = $1.intervalTypeMetadata()
   }
-// Like Postgres, we ignore the left duration field. See explanation:
+// Like Postgres, we only use the left duration field to format the type. See
+// explanation:
 // https://www.postgresql.org/message-id/20110510040219.GD5617%40tornado.gateway.2wire.net
 | YEAR TO MONTH
   {
-    $$.val = tree.Month
+    $$.val = types.IntervalMetadata{
+      FromDurationType: types.IntervalDurationType_YEAR,
+      DurationType: types.IntervalDurationType_MONTH,
+    }
   }
 | DAY TO HOUR
   {
-    $$.val = tree.Hour
+    $$.val = types.IntervalMetadata{
+      FromDurationType: types.IntervalDurationType_DAY,
+      DurationType: types.IntervalDurationType_HOUR,
+    }
   }
 | DAY TO MINUTE
   {
-    $$.val = tree.Minute
+    $$.val = types.IntervalMetadata{
+      FromDurationType: types.IntervalDurationType_DAY,
+      DurationType: types.IntervalDurationType_MINUTE,
+    }
   }
 | DAY TO interval_second
   {
-    $$.val = $3.durationField()
+    ret := $3.intervalTypeMetadata()
+    ret.FromDurationType = types.IntervalDurationType_DAY
+    $$.val = ret
   }
 | HOUR TO MINUTE
   {
-    $$.val = tree.Minute
+    $$.val = types.IntervalMetadata{
+      FromDurationType: types.IntervalDurationType_HOUR,
+      DurationType: types.IntervalDurationType_MINUTE,
+    }
   }
 | HOUR TO interval_second
   {
-    $$.val = $3.durationField()
+    ret := $3.intervalTypeMetadata()
+    ret.FromDurationType = types.IntervalDurationType_HOUR
+    $$.val = ret
   }
 | MINUTE TO interval_second
   {
-    $$.val = $3.durationField()
+    ret := $3.intervalTypeMetadata()
+    ret.FromDurationType = types.IntervalDurationType_MINUTE
+    $$.val = ret
   }
 
 opt_interval:
   interval_qualifier
 | /* EMPTY */
   {
-    $$.val = nil
+    $$.val = types.IntervalMetadata{}
   }
 
 interval_second:
   SECOND
   {
-    $$.val = tree.Second
+    $$.val = types.IntervalMetadata{DurationType: types.IntervalDurationType_SECOND}
+  }
+| SECOND '(' iconst32 ')'
+  {
+    prec := $3.int32()
+    if err := checkIntervalPrecision(prec); err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = types.IntervalMetadata{
+      DurationType: types.IntervalDurationType_SECOND,
+      Precision: prec,
+      PrecisionIsSet: true,
+    }
   }
-| SECOND '(' ICONST ')' { return unimplementedWithIssueDetail(sqllex, 32564, "interval second") }
 
 // General expressions. This is the heart of the expression syntax.
 //
@@ -7553,7 +8484,17 @@ d_expr:
   {
     $$.val = $1.expr()
   }
-| const_interval '(' ICONST ')' SCONST { return unimplementedWithIssue(sqllex, 32564) }
+| const_interval '(' iconst32 ')' SCONST
+  {
+    prec := $3.int32()
+    if err := checkIntervalPrecision(prec); err != nil {
+      return setErr(sqllex, err)
+    }
+    typ := types.MakeInterval(types.IntervalMetadata{Precision: prec, PrecisionIsSet: true})
+    d, err := tree.ParseDIntervalWithTypeMetadata($5, typ)
+    if err != nil { return setErr(sqllex, err) }
+    $$.val = d
+  }
 | TRUE
   {
     $$.val = tree.MakeDBool(true)
@@ -7697,7 +8638,7 @@ func_expr_common_subexpr:
   }
 | CURRENT_TIME
   {
-    return unimplementedWithIssueDetail(sqllex, 26097, "current_time")
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1)}
   }
 | CURRENT_USER
   {
@@ -7777,7 +8718,7 @@ special_function:
 | CURRENT_TIMESTAMP '(' error { return helpWithFunctionByName(sqllex, $1) }
 | CURRENT_TIME '(' ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 26097, "current_time")
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1)}
   }
 | CURRENT_TIME '(' error { return helpWithFunctionByName(sqllex, $1) }
 | CURRENT_USER '(' ')'
@@ -8601,13 +9542,7 @@ interval:
   {
     // We don't carry opt_interval information into the column type, so we need
     // to parse the interval directly.
-    var err error
-    var d tree.Datum
-    if $3.val == nil {
-      d, err = tree.ParseDInterval($2)
-    } else {
-      d, err = tree.ParseDIntervalWithField($2, $3.durationField())
-    }
+    d, err := tree.ParseDIntervalWithTypeMetadata($2, types.MakeInterval($3.intervalTypeMetadata()))
     if err != nil { return setErr(sqllex, err) }
     $$.val = d
   }
@@ -8865,11 +9800,14 @@ unreserved_keyword:
 | ACTION
 | ADD
 | ADMIN
+| AFTER
 | AGGREGATE
 | ALTER
+| ALWAYS
 | AT
 | AUTOMATIC
 | BACKUP
+| BEFORE
 | BEGIN
 | BIGSERIAL
 | BLOB
@@ -8878,6 +9816,7 @@ unreserved_keyword:
 | BYTEA
 | BYTES
 | CACHE
+| CALLED
 | CANCEL
 | CASCADE
 | CHANGEFEED
@@ -8887,6 +9826,7 @@ unreserved_keyword:
 | COMMIT
 | COMMITTED
 | COMPACT
+| CONCURRENTLY
 | CONFLICT
 | CONFIGURATION
 | CONFIGURATIONS
@@ -8904,15 +9844,18 @@ unreserved_keyword:
 | DATE
 | DAY
 | DEALLOCATE
+| DEFAULTS
 | DELETE
 | DEFERRED
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ENCODING
 | ENUM
 | ESCAPE
+| EXCLUDING
 | EXECUTE
 | EXPERIMENTAL
 | EXPERIMENTAL_AUDIT
@@ -8931,6 +9874,7 @@ unreserved_keyword:
 | FOLLOWING
 | FORCE_INDEX
 | FUNCTION
+| GENERATED
 | GLOBAL
 | GRANTS
 | GROUPS
@@ -8938,13 +9882,17 @@ unreserved_keyword:
 | HIGH
 | HISTOGRAM
 | HOUR
+| IDENTITY
 | IMMEDIATE
+| IMMUTABLE
 | IMPORT
+| INCLUDING
 | INCREMENT
 | INCREMENTAL
 | INDEXES
 | INET
 | INJECT
+| INPUT
 | INSERT
 | INT2
 | INT2VECTOR
@@ -8969,6 +9917,7 @@ unreserved_keyword:
 | LEVEL
 | LIST
 | LOCAL
+| LOCKED
 | LOOKUP
 | LOW
 | MATCH
@@ -8984,6 +9933,7 @@ unreserved_keyword:
 | NEXT
 | NO
 | NORMAL
+| NOWAIT
 | NO_INDEX_JOIN
 | IGNORE_FOREIGN_KEYS
 | OF
@@ -8997,6 +9947,7 @@ unreserved_keyword:
 | OPTIONS
 | ORDINALITY
 | OVER
+| OVERRIDING
 | OWNED
 | PARENT
 | PARTIAL
@@ -9017,6 +9968,7 @@ unreserved_keyword:
 | READ
 | RECURSIVE
 | REF
+| REFRESH
 | REGCLASS
 | REGPROC
 | REGPROCEDURE
@@ -9030,6 +9982,7 @@ unreserved_keyword:
 | RESTORE
 | RESTRICT
 | RESUME
+| RETURNS
 | REVOKE
 | ROLE
 | ROLES
@@ -9058,12 +10011,17 @@ unreserved_keyword:
 | SESSION
 | SESSIONS
 | SET
+| SETOF
+| SHARE
 | SHOW
 | SIMPLE
+| SKIP
 | SMALLSERIAL
 | SNAPSHOT
 | SQL
+| STABLE
 | START
+| STATEMENT
 | STATISTICS
 | STDIN
 | STORE
@@ -9104,6 +10062,7 @@ unreserved_keyword:
 | VALUE
 | VARYING
 | VIEW
+| VOLATILE
 | WITHIN
 | WITHOUT
 | WRITE
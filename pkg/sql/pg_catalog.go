@@ -24,6 +24,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -465,10 +466,11 @@ CREATE TABLE pg_catalog.pg_auth_members (
 }
 
 var (
-	relKindTable    = tree.NewDString("r")
-	relKindIndex    = tree.NewDString("i")
-	relKindView     = tree.NewDString("v")
-	relKindSequence = tree.NewDString("S")
+	relKindTable            = tree.NewDString("r")
+	relKindIndex            = tree.NewDString("i")
+	relKindView             = tree.NewDString("v")
+	relKindMaterializedView = tree.NewDString("m")
+	relKindSequence         = tree.NewDString("S")
 
 	relPersistencePermanent = tree.NewDString("p")
 )
@@ -512,7 +514,9 @@ CREATE TABLE pg_catalog.pg_class (
 			func(db *sqlbase.DatabaseDescriptor, scName string, table *sqlbase.TableDescriptor) error {
 				// The only difference between tables, views and sequences is the relkind column.
 				relKind := relKindTable
-				if table.IsView() {
+				if table.MaterializedView() {
+					relKind = relKindMaterializedView
+				} else if table.IsView() {
 					relKind = relKindView
 				} else if table.IsSequence() {
 					relKind = relKindSequence
@@ -1109,7 +1113,7 @@ CREATE TABLE pg_catalog.pg_shdescription (
 }
 
 var pgCatalogEnumTable = virtualSchemaTable{
-	comment: `enum types and labels (empty - feature does not exist)
+	comment: `enum types and labels
 https://www.postgresql.org/docs/9.5/catalog-pg-enum.html`,
 	schema: `
 CREATE TABLE pg_catalog.pg_enum (
@@ -1118,9 +1122,23 @@ CREATE TABLE pg_catalog.pg_enum (
   enumsortorder FLOAT,
   enumlabel STRING
 )`,
-	populate: func(_ context.Context, p *planner, _ *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Enum types are not currently supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachTypeDesc(ctx, p, dbContext, func(_ *DatabaseDescriptor, typ *sqlbase.TypeDescriptor) error {
+			typOid := tree.NewDOid(tree.DInt(types.TypeIDToOID(uint32(typ.ID))))
+			for i := range typ.EnumMembers {
+				label := typ.EnumMembers[i].LogicalRepresentation
+				if err := addRow(
+					h.EnumEntryOid(typOid, label), // oid
+					typOid,                        // enumtypid
+					tree.NewDFloat(tree.DFloat(float64(i+1))), // enumsortorder
+					tree.NewDString(label),                    // enumlabel
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	},
 }
 
@@ -1398,6 +1416,13 @@ func indexDefFromDescriptor(
 		}
 		indexDef.Interleave = intlDef
 	}
+	if index.IsPartial() {
+		pred, err := parser.ParseExpr(index.Predicate)
+		if err != nil {
+			return "", err
+		}
+		indexDef.Predicate = pred
+	}
 	return indexDef.String(), nil
 }
 
@@ -1587,7 +1612,7 @@ var (
 )
 
 var pgCatalogProcTable = virtualSchemaTable{
-	comment: `built-in functions (incomplete)
+	comment: `built-in and user-defined functions (incomplete)
 https://www.postgresql.org/docs/9.5/catalog-pg-proc.html`,
 	schema: `
 CREATE TABLE pg_catalog.pg_proc (
@@ -1624,7 +1649,7 @@ CREATE TABLE pg_catalog.pg_proc (
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext *DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		h := makeOidHasher()
-		return forEachDatabaseDesc(ctx, p, dbContext, func(db *DatabaseDescriptor) error {
+		if err := forEachDatabaseDesc(ctx, p, dbContext, func(db *DatabaseDescriptor) error {
 			nspOid := h.NamespaceOid(db, pgCatalogName)
 			for _, name := range builtins.AllBuiltinNames {
 				// parser.Builtins contains duplicate uppercase and lowercase keys.
@@ -1740,10 +1765,83 @@ CREATE TABLE pg_catalog.pg_proc (
 				}
 			}
 			return nil
+		}); err != nil {
+			return err
+		}
+		return forEachFunctionDesc(ctx, p, dbContext, func(
+			db *DatabaseDescriptor, fnDesc *sqlbase.FunctionDescriptor,
+		) error {
+			nspOid := h.NamespaceOid(db, tree.PublicSchema)
+			for i := range fnDesc.Overloads {
+				ol := &fnDesc.Overloads[i]
+				dArgTypes := tree.NewDArray(types.Oid)
+				dArgNames := tree.NewDArray(types.String)
+				hasArgNames := false
+				for _, param := range ol.Params {
+					if err := dArgTypes.Append(tree.NewDOid(tree.DInt(param.Type.Oid()))); err != nil {
+						return err
+					}
+					if err := dArgNames.Append(tree.NewDString(param.Name)); err != nil {
+						return err
+					}
+					hasArgNames = hasArgNames || param.Name != ""
+				}
+				var argNames tree.Datum = tree.DNull
+				if hasArgNames {
+					argNames = dArgNames
+				}
+				err := addRow(
+					h.UserDefinedFunctionOid(fnDesc, ol),  // oid
+					tree.NewDName(fnDesc.Name),            // proname
+					nspOid,                                // pronamespace
+					tree.DNull,                            // proowner
+					proLangSQL,                            // prolang
+					tree.DNull,                            // procost
+					tree.DNull,                            // prorows
+					oidZero,                               // provariadic
+					tree.DNull,                            // protransform
+					tree.DBoolFalse,                       // proisagg
+					tree.DBoolFalse,                       // proiswindow
+					tree.DBoolFalse,                       // prosecdef
+					tree.DBoolFalse,                       // proleakproof
+					tree.MakeDBool(tree.DBool(ol.Strict)), // proisstrict
+					tree.MakeDBool(tree.DBool(ol.ReturnsSet)),    // proretset
+					proVolatility[ol.Volatility],                 // provolatile
+					tree.DNull,                                   // proparallel
+					tree.NewDInt(tree.DInt(len(ol.Params))),      // pronargs
+					tree.NewDInt(tree.DInt(0)),                   // pronargdefaults
+					tree.NewDOid(tree.DInt(ol.ReturnType.Oid())), // prorettype
+					tree.NewDOidVectorFromDArray(dArgTypes),      // proargtypes
+					tree.DNull,                                   // proallargtypes
+					tree.DNull,                                   // proargmodes
+					argNames,                                     // proargnames
+					tree.DNull,                                   // proargdefaults
+					tree.DNull,                                   // protrftypes
+					tree.NewDString(ol.Body),                     // prosrc
+					tree.DNull,                                   // probin
+					tree.DNull,                                   // proconfig
+					tree.DNull,                                   // proacl
+				)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
 		})
 	},
 }
 
+var (
+	// proLangSQL is the OID of the "sql" language in Postgres.
+	proLangSQL = tree.NewDOid(14)
+
+	proVolatility = map[sqlbase.FunctionDescriptor_Volatility]tree.Datum{
+		sqlbase.FunctionDescriptor_IMMUTABLE: tree.NewDString("i"),
+		sqlbase.FunctionDescriptor_STABLE:    tree.NewDString("s"),
+		sqlbase.FunctionDescriptor_VOLATILE:  tree.NewDString("v"),
+	}
+)
+
 var pgCatalogRangeTable = virtualSchemaTable{
 	comment: `range types (empty - feature does not exist)
 https://www.postgresql.org/docs/9.5/catalog-pg-range.html`,
@@ -2047,9 +2145,7 @@ var (
 	typTypeRange     = tree.NewDString("r")
 
 	// Avoid unused warning for constants.
-	_ = typTypeComposite
 	_ = typTypeDomain
-	_ = typTypeEnum
 	_ = typTypePseudo
 	_ = typTypeRange
 
@@ -2071,8 +2167,6 @@ var (
 	typCategoryUnknown     = tree.NewDString("X")
 
 	// Avoid unused warning for constants.
-	_ = typCategoryComposite
-	_ = typCategoryEnum
 	_ = typCategoryGeometric
 	_ = typCategoryRange
 	_ = typCategoryBitString
@@ -2193,7 +2287,52 @@ CREATE TABLE pg_catalog.pg_type (
 					return err
 				}
 			}
-			return nil
+
+			// User-defined types live in the public schema of their database.
+			userNspOid := h.NamespaceOid(db, tree.PublicSchema)
+			return forEachTypeDesc(ctx, p, db, func(_ *DatabaseDescriptor, typDesc *sqlbase.TypeDescriptor) error {
+				typ := typDesc.MakeTypesT()
+				typType, procPrefix := typTypeEnum, "enum"
+				if typDesc.Kind == sqlbase.TypeDescriptor_COMPOSITE {
+					typType, procPrefix = typTypeComposite, "record"
+				}
+				return addRow(
+					typOid(typ),                 // oid
+					tree.NewDName(typDesc.Name), // typname
+					userNspOid,                  // typnamespace
+					tree.DNull,                  // typowner
+					typLen(typ),                 // typlen
+					typByVal(typ),               // typbyval
+					typType,                     // typtype
+					typCategory(typ),            // typcategory
+					tree.DBoolFalse,             // typispreferred
+					tree.DBoolTrue,              // typisdefined
+					typDelim,                    // typdelim
+					oidZero,                     // typrelid
+					oidZero,                     // typelem
+					oidZero,                     // typarray
+
+					// regproc references
+					h.RegProc(procPrefix+"_in"),   // typinput
+					h.RegProc(procPrefix+"_out"),  // typoutput
+					h.RegProc(procPrefix+"_recv"), // typreceive
+					h.RegProc(procPrefix+"_send"), // typsend
+					oidZero,                       // typmodin
+					oidZero,                       // typmodout
+					oidZero,                       // typanalyze
+
+					tree.DNull,      // typalign
+					tree.DNull,      // typstorage
+					tree.DBoolFalse, // typnotnull
+					oidZero,         // typbasetype
+					negOneVal,       // typtypmod
+					zeroVal,         // typndims
+					oidZero,         // typcollation
+					tree.DNull,      // typdefaultbin
+					tree.DNull,      // typdefault
+					tree.DNull,      // typacl
+				)
+			})
 		})
 	},
 }
@@ -2363,6 +2502,7 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.BytesFamily:       typCategoryUserDefined,
 	types.DateFamily:        typCategoryDateTime,
 	types.TimeFamily:        typCategoryDateTime,
+	types.TimeTZFamily:      typCategoryDateTime,
 	types.FloatFamily:       typCategoryNumeric,
 	types.IntFamily:         typCategoryNumeric,
 	types.IntervalFamily:    typCategoryTimespan,
@@ -2377,6 +2517,7 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
 	types.UnknownFamily:     typCategoryUnknown,
+	types.EnumFamily:        typCategoryEnum,
 }
 
 func typCategory(typ *types.T) tree.Datum {
@@ -2384,6 +2525,10 @@ func typCategory(typ *types.T) tree.Datum {
 	if typ.Family() == types.ArrayFamily && typ.ArrayContents().Family() == types.AnyFamily {
 		return typCategoryPseudo
 	}
+	// Composite types are named tuple types.
+	if typ.Family() == types.TupleFamily && typ.CompositeData() != nil {
+		return typCategoryComposite
+	}
 	return datumToTypeCategory[typ.Family()]
 }
 
@@ -2402,7 +2547,8 @@ CREATE TABLE pg_catalog.pg_views (
 		// because it does not distinguish views in separate databases.
 		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /*virtual schemas do not have views*/
 			func(db *sqlbase.DatabaseDescriptor, scName string, desc *sqlbase.TableDescriptor) error {
-				if !desc.IsView() {
+				// As in Postgres, materialized views are not listed in pg_views.
+				if !desc.IsView() || desc.MaterializedView() {
 					return nil
 				}
 				// Note that the view query printed will not include any column aliases
@@ -2495,6 +2641,8 @@ const (
 	userTypeTag
 	collationTypeTag
 	operatorTypeTag
+	enumEntryTypeTag
+	userDefinedFunctionTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -2624,6 +2772,15 @@ func (h oidHasher) BuiltinOid(name string, builtin *tree.Overload) *tree.DOid {
 	return h.getOid()
 }
 
+func (h oidHasher) UserDefinedFunctionOid(
+	fnDesc *sqlbase.FunctionDescriptor, overload *sqlbase.FunctionDescriptor_Overload,
+) *tree.DOid {
+	h.writeTypeTag(userDefinedFunctionTypeTag)
+	h.writeUInt32(uint32(fnDesc.ID))
+	h.writeStr(overload.Signature(fnDesc.Name))
+	return h.getOid()
+}
+
 func (h oidHasher) RegProc(name string) tree.Datum {
 	_, overloads := builtins.GetBuiltinProperties(name)
 	if len(overloads) == 0 {
@@ -2653,6 +2810,13 @@ func (h oidHasher) OperatorOid(name string, leftType, rightType, returnType *tre
 	return h.getOid()
 }
 
+func (h oidHasher) EnumEntryOid(typOid *tree.DOid, label string) *tree.DOid {
+	h.writeTypeTag(enumEntryTypeTag)
+	h.writeOID(typOid)
+	h.writeStr(label)
+	return h.getOid()
+}
+
 func defaultOid(id sqlbase.ID) *tree.DOid {
 	return tree.NewDOid(tree.DInt(id))
 }
@@ -197,6 +197,7 @@ const (
 	CodeCollationMismatchError                  = "42P21"
 	CodeIndeterminateCollationError             = "42P22"
 	CodeWrongObjectTypeError                    = "42809"
+	CodeGeneratedAlwaysError                    = "428C9"
 	CodeUndefinedColumnError                    = "42703"
 	CodeUndefinedFunctionError                  = "42883"
 	CodeUndefinedTableError                     = "42P01"
@@ -242,6 +243,7 @@ const (
 	CodeObjectInUseError                  = "55006"
 	CodeCantChangeRuntimeParamError       = "55P02"
 	CodeLockNotAvailableError             = "55P03"
+	CodeUnsafeNewEnumValueUsageError      = "55P04"
 	// Class 57 - Operator Intervention
 	CodeOperatorInterventionError = "57000"
 	CodeQueryCanceledError        = "57014"
@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/jackc/pgx/pgtype"
@@ -188,25 +189,50 @@ func NewProtocolViolationErrorf(format string, args ...interface{}) error {
 	return pgerror.Newf(pgerror.CodeProtocolViolationError, format, args...)
 }
 
-// validateArrayDimensions takes the number of dimensions and elements and
-// returns an error if we don't support that combination.
-func validateArrayDimensions(nDimensions int, nElements int) error {
-	switch nDimensions {
-	case 1:
-		break
-	case 0:
-		// 0-dimensional array means 0-length array: validate that.
-		if nElements == 0 {
-			break
-		}
-		fallthrough
-	default:
-		return pgerror.UnimplementedWithIssuef(32552,
-			"%d-dimension arrays not supported; only 1-dimension", nDimensions)
+// validateArrayDimensions returns an error if the given number of dimensions
+// of an array is not supported.
+func validateArrayDimensions(nDimensions int) error {
+	if nDimensions < 0 {
+		return pgerror.Newf(pgerror.CodeInvalidBinaryRepresentationError,
+			"invalid number of dimensions: %d", nDimensions)
+	}
+	if nDimensions > tree.MaxArrayDimensions {
+		return pgerror.Newf(pgerror.CodeProgramLimitExceededError,
+			"number of array dimensions (%d) exceeds the maximum allowed (%d)",
+			nDimensions, tree.MaxArrayDimensions)
 	}
 	return nil
 }
 
+// makeArrayFromElements constructs an array from its innermost elements, which
+// have the given type, and the dimensions decoded by pgtype. A
+// multi-dimensional array is constructed with nested arrays as elements.
+func makeArrayFromElements(
+	elemTyp *types.T, dimensions []pgtype.ArrayDimension, elems tree.Datums,
+) (*tree.DArray, error) {
+	if err := validateArrayDimensions(len(dimensions)); err != nil {
+		return nil, err
+	}
+	if len(dimensions) <= 1 {
+		out := tree.NewDArray(elemTyp)
+		for _, d := range elems {
+			if err := out.Append(d); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	dims := make([]int, len(dimensions))
+	paramTyp := elemTyp
+	for i := range dimensions {
+		dims[i] = int(dimensions[i].Length)
+		if i > 0 {
+			paramTyp = types.MakeArray(paramTyp)
+		}
+	}
+	return tree.NewDArrayFromFlattened(paramTyp, dims, elems)
+}
+
 // DecodeOidDatum decodes bytes with specified Oid and format code into
 // a datum. If the ParseTimeContext is nil, reasonable defaults
 // will be applied.
@@ -282,6 +308,12 @@ func DecodeOidDatum(
 				return nil, pgerror.Newf(pgerror.CodeSyntaxError, "could not parse string %q as time", b)
 			}
 			return d, nil
+		case oid.T_timetz:
+			d, err := tree.ParseDTimeTZ(ctx, string(b))
+			if err != nil {
+				return nil, pgerror.Newf(pgerror.CodeSyntaxError, "could not parse string %q as timetz", b)
+			}
+			return d, nil
 
 		case oid.T_interval:
 			d, err := tree.ParseDInterval(string(b))
@@ -311,22 +343,15 @@ func DecodeOidDatum(
 			if arr.Status != pgtype.Present {
 				return tree.DNull, nil
 			}
-			if err := validateArrayDimensions(len(arr.Dimensions), len(arr.Elements)); err != nil {
-				return nil, err
-			}
-			out := tree.NewDArray(types.Int)
-			var d tree.Datum
-			for _, v := range arr.Elements {
+			elems := make(tree.Datums, len(arr.Elements))
+			for i, v := range arr.Elements {
 				if v.Status != pgtype.Present {
-					d = tree.DNull
+					elems[i] = tree.DNull
 				} else {
-					d = tree.NewDInt(tree.DInt(v.Int))
-				}
-				if err := out.Append(d); err != nil {
-					return nil, err
+					elems[i] = tree.NewDInt(tree.DInt(v.Int))
 				}
 			}
-			return out, nil
+			return makeArrayFromElements(types.Int, arr.Dimensions, elems)
 		case oid.T__text, oid.T__name:
 			var arr pgtype.TextArray
 			if err := arr.DecodeText(nil, b); err != nil {
@@ -336,28 +361,23 @@ func DecodeOidDatum(
 			if arr.Status != pgtype.Present {
 				return tree.DNull, nil
 			}
-			if err := validateArrayDimensions(len(arr.Dimensions), len(arr.Elements)); err != nil {
-				return nil, err
-			}
-			out := tree.NewDArray(types.String)
+			elemTyp := types.String
 			if id == oid.T__name {
-				out.ParamTyp = types.Name
+				elemTyp = types.Name
 			}
-			var d tree.Datum
-			for _, v := range arr.Elements {
+			elems := make(tree.Datums, len(arr.Elements))
+			for i, v := range arr.Elements {
 				if v.Status != pgtype.Present {
-					d = tree.DNull
+					elems[i] = tree.DNull
 				} else {
-					d = tree.NewDString(v.String)
+					d := tree.NewDString(v.String)
+					elems[i] = d
 					if id == oid.T__name {
-						d = tree.NewDNameFromDString(d.(*tree.DString))
+						elems[i] = tree.NewDNameFromDString(d)
 					}
 				}
-				if err := out.Append(d); err != nil {
-					return nil, err
-				}
 			}
-			return out, nil
+			return makeArrayFromElements(elemTyp, arr.Dimensions, elems)
 		case oid.T_jsonb:
 			if err := validateStringBytes(b); err != nil {
 				return nil, err
@@ -525,6 +545,13 @@ func DecodeOidDatum(
 			}
 			i := int64(binary.BigEndian.Uint64(b))
 			return tree.MakeDTime(timeofday.TimeOfDay(i)), nil
+		case oid.T_timetz:
+			if len(b) < 12 {
+				return nil, pgerror.Newf(pgerror.CodeSyntaxError, "timetz requires 12 bytes for binary format")
+			}
+			timeOfDayMicros := int64(binary.BigEndian.Uint64(b))
+			offsetSecs := int32(binary.BigEndian.Uint32(b[8:]))
+			return tree.NewDTimeTZ(timetz.MakeTimeTZ(timeofday.TimeOfDay(timeOfDayMicros), offsetSecs)), nil
 		case oid.T_interval:
 			if len(b) < 16 {
 				return nil, pgerror.Newf(pgerror.CodeSyntaxError, "interval requires 16 bytes for binary format")
@@ -704,32 +731,53 @@ func decodeBinaryArray(ctx tree.ParseTimeContext, b []byte, code FormatCode) (tr
 		// Nullflag
 		_       int32
 		ElemOid int32
-		// The next two fields should be arrays of size Ndims. However, since
-		// we only support 1-dimensional arrays for now, for convenience we can
-		// leave them in this struct as such for `binary.Read` to parse for us.
-		DimSize int32
-		// Dim lower bound
-		_ int32
 	}{}
 	r := bytes.NewBuffer(b)
 	if err := binary.Read(r, binary.BigEndian, &hdr); err != nil {
 		return nil, err
 	}
-	if err := validateArrayDimensions(int(hdr.Ndims), int(hdr.DimSize)); err != nil {
+	if err := validateArrayDimensions(int(hdr.Ndims)); err != nil {
 		return nil, err
 	}
 
+	// The header is followed by the size and lower bound of each dimension. A
+	// 0-dimensional array is an empty array.
+	dims := []int{0}
+	nElems := 0
+	if hdr.Ndims > 0 {
+		dims = make([]int, hdr.Ndims)
+		nElems = 1
+		for i := range dims {
+			dim := struct {
+				Size int32
+				// Dim lower bound
+				_ int32
+			}{}
+			if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
+				return nil, err
+			}
+			if dim.Size < 0 {
+				return nil, pgerror.Newf(pgerror.CodeInvalidBinaryRepresentationError,
+					"invalid array dimension size: %d", dim.Size)
+			}
+			dims[i] = int(dim.Size)
+			nElems *= dims[i]
+		}
+	}
+
 	elemOid := oid.Oid(hdr.ElemOid)
-	arr := tree.NewDArray(types.OidToType[elemOid])
+	paramTyp := types.OidToType[elemOid]
+	for i := 1; i < len(dims); i++ {
+		paramTyp = types.MakeArray(paramTyp)
+	}
+	var elems tree.Datums
 	var vlen int32
-	for i := int32(0); i < hdr.DimSize; i++ {
+	for i := 0; i < nElems; i++ {
 		if err := binary.Read(r, binary.BigEndian, &vlen); err != nil {
 			return nil, err
 		}
 		if vlen < 0 {
-			if err := arr.Append(tree.DNull); err != nil {
-				return nil, err
-			}
+			elems = append(elems, tree.DNull)
 			continue
 		}
 		buf := r.Next(int(vlen))
@@ -737,11 +785,9 @@ func decodeBinaryArray(ctx tree.ParseTimeContext, b []byte, code FormatCode) (tr
 		if err != nil {
 			return nil, err
 		}
-		if err := arr.Append(elem); err != nil {
-			return nil, err
-		}
+		elems = append(elems, elem)
 	}
-	return arr, nil
+	return tree.NewDArrayFromFlattened(paramTyp, dims, elems)
 }
 
 var invalidUTF8Error = pgerror.Newf(pgerror.CodeCharacterNotInRepertoireError, "invalid UTF-8 sequence")
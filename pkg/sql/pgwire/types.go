@@ -142,6 +142,9 @@ func (b *writeBuffer) writeTextDatum(
 	case *tree.DIPAddr:
 		b.writeLengthPrefixedString(v.IPAddr.String())
 
+	case *tree.DEnum:
+		b.writeLengthPrefixedString(v.LogicalRep)
+
 	case *tree.DString:
 		b.writeLengthPrefixedString(string(*v))
 
@@ -159,6 +162,9 @@ func (b *writeBuffer) writeTextDatum(
 		b.putInt32(int32(len(s)))
 		b.write(s)
 
+	case *tree.DTimeTZ:
+		b.writeLengthPrefixedString(v.TimeTZ.String())
+
 	case *tree.DTimestamp:
 		// Start at offset 4 because `putInt32` clobbers the first 4 bytes.
 		s := formatTs(v.Time, nil, b.putbuf[4:4])
@@ -186,8 +192,6 @@ func (b *writeBuffer) writeTextDatum(
 		case oid.T_int2vector, oid.T_oidvector:
 			// vectors are serialized as a string of space-separated values.
 			sep := ""
-			// TODO(justin): add a test for nested arrays when #32552 is
-			// addressed.
 			for _, d := range v.Array {
 				b.textFormatter.WriteString(sep)
 				b.textFormatter.FormatNode(d)
@@ -379,6 +383,10 @@ func (b *writeBuffer) writeBinaryDatum(
 		b.putInt32(16)
 		b.write(v.GetBytes())
 
+	case *tree.DEnum:
+		// The binary format of enum values is their label, like in Postgres.
+		b.writeLengthPrefixedString(v.LogicalRep)
+
 	case *tree.DIPAddr:
 		// We calculate the Postgres binary format for an IPAddr. For the spec see,
 		// https://github.com/postgres/postgres/blob/81c5e46c490e2426db243eada186995da5bb0ba7/src/backend/utils/adt/network.c#L144
@@ -436,6 +444,13 @@ func (b *writeBuffer) writeBinaryDatum(
 		b.putInt32(8)
 		b.putInt64(int64(*v))
 
+	case *tree.DTimeTZ:
+		// Like in Postgres, the time zone offset is sent in seconds west of
+		// UTC, which is how it is stored.
+		b.putInt32(12)
+		b.putInt64(int64(v.TimeOfDay))
+		b.putInt32(v.OffsetSecs)
+
 	case *tree.DInterval:
 		b.putInt32(16)
 		b.putInt64(v.Nanos() / int64(time.Microsecond/time.Nanosecond))
@@ -447,34 +462,52 @@ func (b *writeBuffer) writeBinaryDatum(
 		subWriter := newWriteBuffer(nil /* bytecount */)
 		// Put the number of datums.
 		subWriter.putInt32(int32(len(v.D)))
-		for _, elem := range v.D {
+		// The fields are described by the types of the tuple when they are
+		// known, like for composite types, so that NULL fields have the OID of
+		// their type too.
+		contents := v.ResolvedType().TupleContents()
+		for i, elem := range v.D {
 			oid := elem.ResolvedType().Oid()
+			if i < len(contents) && contents[i].Family() != types.UnknownFamily {
+				oid = contents[i].Oid()
+			}
 			subWriter.putInt32(int32(oid))
 			subWriter.writeBinaryDatum(ctx, elem, sessionLoc, oid)
 		}
 		b.writeLengthPrefixedBuffer(&subWriter.wrapped)
 
 	case *tree.DArray:
-		if v.ParamTyp.Family() == types.ArrayFamily {
-			b.setError(pgerror.UnimplementedWithIssueDetail(32552,
-				"binenc", "unsupported binary serialization of multidimensional arrays"))
+		// Multi-dimensional arrays are serialized as the flat list of their
+		// innermost elements, preceded by the size of each dimension.
+		dims, elems, err := v.Flatten()
+		if err != nil {
+			b.setError(err)
 			return
 		}
 		// TODO(andrei): We shouldn't be allocating a new buffer for every array.
 		subWriter := newWriteBuffer(nil /* bytecount */)
-		// Put the number of dimensions. We currently support 1d arrays only.
-		subWriter.putInt32(1)
+		// Put the number of dimensions.
+		subWriter.putInt32(int32(len(dims)))
 		hasNulls := 0
-		if v.HasNulls {
-			hasNulls = 1
+		for _, elem := range elems {
+			if elem == tree.DNull {
+				hasNulls = 1
+				break
+			}
 		}
-		oid := v.ParamTyp.Oid()
+		elemTyp := v.ParamTyp
+		for elemTyp.Family() == types.ArrayFamily {
+			elemTyp = elemTyp.ArrayContents()
+		}
+		oid := elemTyp.Oid()
 		subWriter.putInt32(int32(hasNulls))
 		subWriter.putInt32(int32(oid))
-		subWriter.putInt32(int32(v.Len()))
-		// Lower bound, we only support a lower bound of 1.
-		subWriter.putInt32(1)
-		for _, elem := range v.Array {
+		for _, dim := range dims {
+			subWriter.putInt32(int32(dim))
+			// Lower bound, we only support a lower bound of 1.
+			subWriter.putInt32(1)
+		}
+		for _, elem := range elems {
 			subWriter.writeBinaryDatum(ctx, elem, sessionLoc, oid)
 		}
 		b.writeLengthPrefixedBuffer(&subWriter.wrapped)
@@ -143,6 +143,40 @@ func TestIntArrayRoundTrip(t *testing.T) {
 	}
 }
 
+func TestMultiDimensionalIntArrayRoundTrip(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	evalCtx := tree.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	defer evalCtx.Stop(context.Background())
+	d, err := tree.ParseDArrayFromString(evalCtx, "{{1,2,3},{4,NULL,6}}", types.IntArray)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaultConv := makeTestingConvCfg()
+	for _, code := range []pgwirebase.FormatCode{pgwirebase.FormatText, pgwirebase.FormatBinary} {
+		buf := newWriteBuffer(nil /* bytecount */)
+		buf.bytecount = metric.NewCounter(metric.Metadata{})
+		if code == pgwirebase.FormatText {
+			buf.writeTextDatum(context.Background(), d, defaultConv)
+		} else {
+			buf.writeBinaryDatum(context.Background(), d, defaultConv.Location, 0 /* oid */)
+		}
+		if buf.err != nil {
+			t.Fatal(buf.err)
+		}
+		b := buf.wrapped.Bytes()
+
+		got, err := pgwirebase.DecodeOidDatum(nil, oid.T__int8, code, b[4:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Compare(evalCtx, d) != 0 {
+			t.Fatalf("%s: expected %s, got %s", code, d, got)
+		}
+	}
+}
+
 func TestFloatConversion(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
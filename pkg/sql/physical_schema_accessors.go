@@ -104,8 +104,30 @@ func (a UncachedPhysicalAccessor) GetObjectNames(
 		return nil, err
 	}
 
+	// User-defined types and functions share the namespace of tables, but
+	// they are not objects that can be listed.
+	ids := make([]sqlbase.ID, len(sr))
+	for i := range sr {
+		ids[i] = sqlbase.ID(sr[i].ValueInt())
+	}
+	descs, err := getDescs(ctx, txn, ids)
+	if err != nil {
+		return nil, err
+	}
+	nonObjectIDs := make(map[sqlbase.ID]struct{})
+	for _, desc := range descs {
+		if typDesc := desc.GetType(); typDesc != nil {
+			nonObjectIDs[typDesc.ID] = struct{}{}
+		} else if fnDesc := desc.GetFunction(); fnDesc != nil {
+			nonObjectIDs[fnDesc.ID] = struct{}{}
+		}
+	}
+
 	var tableNames tree.TableNames
-	for _, row := range sr {
+	for i, row := range sr {
+		if _, ok := nonObjectIDs[ids[i]]; ok {
+			continue
+		}
 		_, tableName, err := encoding.DecodeUnsafeStringAscending(
 			bytes.TrimPrefix(row.Key, prefix), nil)
 		if err != nil {
@@ -161,6 +183,14 @@ func (a UncachedPhysicalAccessor) GetObjectDesc(
 	desc := &sqlbase.TableDescriptor{}
 	err = getDescriptorByID(ctx, txn, descID, desc)
 	if err != nil {
+		// User-defined types share the namespace of tables, but they are not
+		// relations.
+		if _, typErr := sqlbase.GetTypeDescFromID(ctx, txn, descID); typErr == nil {
+			if flags.required {
+				return nil, sqlbase.NewUndefinedRelationError(name)
+			}
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -93,12 +93,14 @@ func planPhysicalProps(plan planNode) physicalProps {
 	case *applyJoinNode:
 	case *bufferNode:
 	case *scanBufferNode:
+	case *recursiveCTENode:
 
 	// Every other node simply has no guarantees on its output rows.
 	case *CreateUserNode:
 	case *DropUserNode:
 	case *alterIndexNode:
 	case *alterSequenceNode:
+	case *alterTypeNode:
 	case *alterTableNode:
 	case *alterUserSetPasswordNode:
 	case *cancelQueriesNode:
@@ -110,7 +112,11 @@ func planPhysicalProps(plan planNode) physicalProps {
 	case *createDatabaseNode:
 	case *createIndexNode:
 	case *createSequenceNode:
+	case *createTypeNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
 	case *createStatsNode:
+	case *refreshMaterializedViewNode:
 	case *createTableNode:
 	case *createViewNode:
 	case *delayedNode:
@@ -118,6 +124,9 @@ func planPhysicalProps(plan planNode) physicalProps {
 	case *dropDatabaseNode:
 	case *dropIndexNode:
 	case *dropSequenceNode:
+	case *dropTypeNode:
+	case *dropFunctionNode:
+	case *dropTriggerNode:
 	case *dropTableNode:
 	case *dropViewNode:
 	case *errorIfRowsNode:
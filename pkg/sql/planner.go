@@ -67,6 +67,16 @@ type extendedEvalContext struct {
 
 	SchemaChangers *schemaChangerCollection
 
+	// DeferredConstraints queues the deferrable constraints to validate. It is
+	// nil if the planner is not associated with a session, in which case
+	// tables with deferrable constraints cannot be modified.
+	DeferredConstraints *deferredConstraintCollection
+
+	// TemporarySchema tracks the temporary objects of the session. It is nil
+	// if the planner is not associated with a session, in which case
+	// temporary objects cannot be created or accessed.
+	TemporarySchema *temporarySchema
+
 	schemaAccessors *schemaInterface
 }
 
@@ -249,6 +259,8 @@ func newInternalPlanner(
 	p.semaCtx = tree.MakeSemaContext()
 	p.semaCtx.Location = &sd.DataConversion.Location
 	p.semaCtx.SearchPath = sd.SearchPath
+	p.semaCtx.TypeResolver = p
+	p.semaCtx.FunctionResolver = p
 
 	plannerMon := mon.MakeUnlimitedMonitor(ctx,
 		fmt.Sprintf("internal-planner.%s.%s", user, opName),
@@ -38,12 +38,16 @@ const (
 	INSERT
 	DELETE
 	UPDATE
+	EXECUTE
 )
 
 // Predefined sets of privileges.
 var (
 	ReadData      = List{GRANT, SELECT}
 	ReadWriteData = List{GRANT, SELECT, INSERT, DELETE, UPDATE}
+	// FunctionPrivileges are the privileges that can be granted on functions.
+	// EXECUTE can only be granted on functions.
+	FunctionPrivileges = List{ALL, DROP, GRANT, EXECUTE}
 )
 
 // Mask returns the bitmask for a given privilege.
@@ -51,21 +55,36 @@ func (k Kind) Mask() uint32 {
 	return 1 << k
 }
 
+// AppliesTo returns whether the privilege can be granted on functions, if
+// isFunction is set, or on databases and tables otherwise.
+func (k Kind) AppliesTo(isFunction bool) bool {
+	if !isFunction {
+		return k != EXECUTE
+	}
+	for _, fk := range FunctionPrivileges {
+		if k == fk {
+			return true
+		}
+	}
+	return false
+}
+
 // ByValue is just an array of privilege kinds sorted by value.
 var ByValue = [...]Kind{
-	ALL, CREATE, DROP, GRANT, SELECT, INSERT, DELETE, UPDATE,
+	ALL, CREATE, DROP, GRANT, SELECT, INSERT, DELETE, UPDATE, EXECUTE,
 }
 
 // ByName is a map of string -> kind value.
 var ByName = map[string]Kind{
-	"ALL":    ALL,
-	"CREATE": CREATE,
-	"DROP":   DROP,
-	"GRANT":  GRANT,
-	"SELECT": SELECT,
-	"INSERT": INSERT,
-	"DELETE": DELETE,
-	"UPDATE": UPDATE,
+	"ALL":     ALL,
+	"CREATE":  CREATE,
+	"DROP":    DROP,
+	"GRANT":   GRANT,
+	"SELECT":  SELECT,
+	"INSERT":  INSERT,
+	"DELETE":  DELETE,
+	"UPDATE":  UPDATE,
+	"EXECUTE": EXECUTE,
 }
 
 // List is a list of privileges.
@@ -0,0 +1,203 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// recursiveCTEMaxIterations limits the number of iterations of a recursive
+// CTE. It protects against queries that never terminate, for example a
+// UNION ALL query that walks a graph with cycles.
+var recursiveCTEMaxIterations = settings.RegisterNonNegativeIntSetting(
+	"sql.recursive_cte.max_iterations",
+	"maximum number of iterations of a recursive common table expression; 0 disables the limit",
+	100000,
+)
+
+// recursiveCTENode implements the logic for a recursive CTE:
+//  1. Evaluate the initial query; emit the results and also save them in
+//     a "working" table.
+//  2. So long as the working table is not empty:
+//     * evaluate the recursive query, substituting the current contents of
+//       the working table for the recursive self-reference;
+//     * emit all resulting rows, and save them as the next iteration's
+//       working table.
+// The recursive query tree is regenerated each time using a callback
+// (implemented by the execbuilder).
+//
+// The working table is stored in a disk-backed row container, so it spills to
+// temporary storage when it gets large.
+type recursiveCTENode struct {
+	initial planNode
+
+	genIterationFn exec.RecursiveCTEIterationFn
+
+	// label is a string used to describe the node in an EXPLAIN output.
+	label string
+
+	// deduplicate is set when the CTE uses UNION rather than UNION ALL. In that
+	// case, rows that were already emitted are discarded.
+	deduplicate bool
+
+	recursiveCTERun
+}
+
+type recursiveCTERun struct {
+	// workingRows contains the rows produced by the current iteration (aka the
+	// "working" table).
+	workingRows *rowContainerHelper
+	// iterator is used to emit the rows in workingRows, once the iteration that
+	// produced them is complete.
+	iterator *rowContainerIterator
+	// currentRow is the row returned by Values.
+	currentRow tree.Datums
+
+	initialDone bool
+	iterations  int64
+
+	// seen contains all the rows emitted so far; it is only used when
+	// deduplicate is set.
+	seen *rowSetHelper
+}
+
+func (n *recursiveCTENode) startExec(params runParams) error {
+	n.workingRows = newRowContainerHelper(
+		params, getPlanColumns(n.initial, false /* mut */), "recursive-cte",
+	)
+	if n.deduplicate {
+		n.seen = newRowSetHelper(
+			params, getPlanColumns(n.initial, false /* mut */), "recursive-cte-seen",
+		)
+	}
+	return nil
+}
+
+func (n *recursiveCTENode) Next(params runParams) (bool, error) {
+	if err := params.p.cancelChecker.Check(); err != nil {
+		return false, err
+	}
+
+	for !n.initialDone {
+		ok, err := n.initial.Next(params)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			n.initialDone = true
+			break
+		}
+		n.currentRow = n.initial.Values()
+		if added, err := n.addWorkingRow(params.ctx, n.currentRow); err != nil || added {
+			return added, err
+		}
+	}
+
+	for {
+		if n.iterator != nil {
+			row, err := n.iterator.next()
+			if err != nil {
+				return false, err
+			}
+			if row != nil {
+				n.currentRow = row
+				return true, nil
+			}
+			n.iterator.close()
+			n.iterator = nil
+		}
+
+		if n.workingRows.len() == 0 {
+			// The last iteration didn't produce any rows, so we are done.
+			return false, nil
+		}
+		if err := n.runIteration(params); err != nil {
+			return false, err
+		}
+		n.iterator = n.workingRows.newIterator(params.ctx)
+	}
+}
+
+// runIteration plans and runs the recursive query against the current working
+// table. The rows it produces become the new working table.
+func (n *recursiveCTENode) runIteration(params runParams) error {
+	n.iterations++
+	maxIterations := recursiveCTEMaxIterations.Get(&params.EvalContext().Settings.SV)
+	if maxIterations > 0 && n.iterations > maxIterations {
+		return pgerror.Newf(pgerror.CodeProgramLimitExceededError,
+			"recursive query %q exceeded the maximum of %d iterations", n.label, maxIterations,
+		).SetHintf("the limit can be changed with the sql.recursive_cte.max_iterations cluster setting")
+	}
+
+	lastWorkingRows := n.workingRows
+	defer lastWorkingRows.close(params.ctx)
+	n.workingRows = newRowContainerHelper(
+		params, getPlanColumns(n.initial, false /* mut */), "recursive-cte",
+	)
+
+	// Set up a bufferNode that can be used as a reference for a scanBufferNode.
+	buf := &bufferNode{
+		// The plan here is only used for planColumns, so it's ok to always use the
+		// initial plan.
+		plan:         n.initial,
+		label:        n.label,
+		bufferedRows: lastWorkingRows,
+	}
+	newPlan, err := n.genIterationFn(buf)
+	if err != nil {
+		return err
+	}
+
+	return runPlanInsidePlan(params, newPlan.(*planTop), newCallbackResultWriter(
+		func(ctx context.Context, row tree.Datums) error {
+			_, err := n.addWorkingRow(ctx, row)
+			return err
+		},
+	))
+}
+
+// addWorkingRow adds a row to the working table, unless the node deduplicates
+// rows and the row was already seen. It returns whether the row was added.
+func (n *recursiveCTENode) addWorkingRow(ctx context.Context, row tree.Datums) (bool, error) {
+	if n.deduplicate {
+		if added, err := n.seen.add(ctx, row); err != nil || !added {
+			return false, err
+		}
+	}
+	return true, n.workingRows.addRow(ctx, row)
+}
+
+func (n *recursiveCTENode) Values() tree.Datums {
+	return n.currentRow
+}
+
+func (n *recursiveCTENode) Close(ctx context.Context) {
+	n.initial.Close(ctx)
+	if n.iterator != nil {
+		n.iterator.close()
+		n.iterator = nil
+	}
+	if n.workingRows != nil {
+		n.workingRows.close(ctx)
+		n.workingRows = nil
+	}
+	if n.seen != nil {
+		n.seen.close(ctx)
+		n.seen = nil
+	}
+}
@@ -0,0 +1,407 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/pkg/errors"
+)
+
+// refreshMaterializedViewNode represents a REFRESH MATERIALIZED VIEW
+// statement. The refresh itself is performed by a MaterializedViewRefresh job,
+// so that it is resumed if the node running it dies.
+type refreshMaterializedViewNode struct {
+	n    *tree.RefreshMaterializedView
+	desc *sqlbase.ImmutableTableDescriptor
+}
+
+// RefreshMaterializedView recomputes the contents of a materialized view.
+// Privileges: UPDATE on the materialized view.
+//   Notes: postgres requires the owner of the materialized view.
+func (p *planner) RefreshMaterializedView(
+	ctx context.Context, n *tree.RefreshMaterializedView,
+) (planNode, error) {
+	desc, err := ResolveExistingObject(ctx, p, &n.Name, true /* required */, ResolveRequireViewDesc)
+	if err != nil {
+		return nil, err
+	}
+	if !desc.MaterializedView() {
+		return nil, sqlbase.NewWrongObjectTypeError(&n.Name, "materialized view")
+	}
+	if err := p.CheckPrivilege(ctx, desc, privilege.UPDATE); err != nil {
+		return nil, err
+	}
+	return &refreshMaterializedViewNode{n: n, desc: desc}, nil
+}
+
+func (n *refreshMaterializedViewNode) startExec(params runParams) error {
+	if !params.p.ExtendedEvalContext().TxnImplicit {
+		return errors.Errorf("REFRESH MATERIALIZED VIEW cannot be used inside a transaction")
+	}
+
+	record := jobs.Record{
+		Description:   tree.AsStringWithFQNames(n.n, params.Ann()),
+		Username:      params.p.User(),
+		DescriptorIDs: sqlbase.IDs{n.desc.ID},
+		Details: jobspb.MaterializedViewRefreshDetails{
+			TableID:      n.desc.ID,
+			Concurrently: n.n.Concurrently,
+		},
+		Progress: jobspb.MaterializedViewRefreshProgress{},
+	}
+	_, errCh, err := params.ExecCfg().JobRegistry.StartJob(params.ctx, nil /* resultsCh */, record)
+	if err != nil {
+		return err
+	}
+	return <-errCh
+}
+
+func (*refreshMaterializedViewNode) Next(runParams) (bool, error) { return false, nil }
+func (*refreshMaterializedViewNode) Values() tree.Datums          { return tree.Datums{} }
+func (*refreshMaterializedViewNode) Close(context.Context)        {}
+
+// materializedViewRowIDIdx returns the position of the hidden rowid column
+// that is the primary key of the given materialized view.
+func materializedViewRowIDIdx(desc *sqlbase.ImmutableTableDescriptor) int {
+	return desc.ColumnIdxMap()[desc.PrimaryIndex.ColumnIDs[0]]
+}
+
+// runInternalQuery plans the given query with an internal planner, runs it in
+// the given transaction and calls fn with each of its result rows. The rows
+// are streamed from the plan as they are produced, so fn must not retain them.
+func runInternalQuery(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	txn *client.Txn,
+	user string,
+	opName string,
+	query string,
+	fn func(ctx context.Context, row tree.Datums) error,
+) error {
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return err
+	}
+	p, cleanup := newInternalPlanner(opName, txn, user, &MemoryMetrics{}, execCfg)
+	defer cleanup()
+	p.stmt = &Statement{Statement: stmt}
+	p.optPlanningCtx.init(p)
+	plan, _, err := p.makeOptimizerPlan(ctx)
+	if err != nil {
+		return err
+	}
+	defer plan.close(ctx)
+	params := runParams{ctx: ctx, extendedEvalCtx: &p.extendedEvalCtx, p: p}
+	return runPlanInsidePlan(params, plan, newCallbackResultWriter(fn))
+}
+
+// queryMaterializedView runs the query of the given materialized view in the
+// given transaction, and calls fn with each of the resulting rows. The rows
+// are laid out like the columns of the view, and contain NULL for the rowid
+// column. The row passed to fn is only valid until fn returns.
+func queryMaterializedView(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	txn *client.Txn,
+	user string,
+	desc *sqlbase.ImmutableTableDescriptor,
+	fn func(ctx context.Context, row tree.Datums) error,
+) error {
+	rowIDIdx := materializedViewRowIDIdx(desc)
+	withRowID := make(tree.Datums, len(desc.Columns))
+	return runInternalQuery(
+		ctx, execCfg, txn, user, "query-materialized-view", desc.ViewQuery,
+		func(ctx context.Context, r tree.Datums) error {
+			if len(r) != len(desc.Columns)-1 {
+				return pgerror.AssertionFailedf(
+					"materialized view %q has %d columns, but its query returned %d",
+					desc.Name, len(desc.Columns)-1, len(r))
+			}
+			copy(withRowID, r[:rowIDIdx])
+			withRowID[rowIDIdx] = tree.DNull
+			copy(withRowID[rowIDIdx+1:], r[rowIDIdx:])
+			return fn(ctx, withRowID)
+		},
+	)
+}
+
+// materializedViewWriter writes the rows of a materialized view in batches of
+// bounded size, like the INSERT and DELETE statements do.
+type materializedViewWriter struct {
+	desc     *sqlbase.ImmutableTableDescriptor
+	nodeID   roachpb.NodeID
+	rowIDIdx int
+	ti       tableInserter
+	td       tableDeleter
+	alloc    sqlbase.DatumAlloc
+}
+
+func makeMaterializedViewWriter(
+	execCfg *ExecutorConfig, txn *client.Txn, desc *sqlbase.ImmutableTableDescriptor,
+) (*materializedViewWriter, error) {
+	w := &materializedViewWriter{
+		desc:     desc,
+		nodeID:   execCfg.NodeID.Get(),
+		rowIDIdx: materializedViewRowIDIdx(desc),
+	}
+	ri, err := row.MakeInserter(
+		txn, desc, nil /* fkTables */, desc.Columns, row.SkipFKs, &w.alloc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rd, err := row.MakeDeleter(
+		txn, desc, nil /* fkTables */, desc.Columns, row.SkipFKs, nil /* evalCtx */, &w.alloc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	w.ti = tableInserter{ri: ri}
+	w.td = tableDeleter{rd: rd, alloc: &w.alloc}
+	if err := w.ti.init(txn, nil /* evalCtx */); err != nil {
+		return nil, err
+	}
+	if err := w.td.init(txn, nil /* evalCtx */); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// insertRow inserts the given row, which is laid out like the columns of the
+// view, with a new rowid.
+func (w *materializedViewWriter) insertRow(ctx context.Context, r tree.Datums) error {
+	r[w.rowIDIdx] = tree.NewDInt(builtins.GenerateUniqueInt(w.nodeID))
+	if err := w.ti.row(ctx, r, false /* traceKV */); err != nil {
+		return err
+	}
+	if w.ti.curBatchSize() >= maxInsertBatchSize {
+		return w.ti.flushAndStartNewBatch(ctx)
+	}
+	return nil
+}
+
+// deleteRow deletes the given row, which is laid out like the columns of the
+// view and contains its rowid.
+func (w *materializedViewWriter) deleteRow(ctx context.Context, r tree.Datums) error {
+	if err := w.td.row(ctx, r, false /* traceKV */); err != nil {
+		return err
+	}
+	if w.td.curBatchSize() >= maxDeleteBatchSize {
+		return w.td.flushAndStartNewBatch(ctx)
+	}
+	return nil
+}
+
+// finalize runs the last batches of deletions and insertions.
+func (w *materializedViewWriter) finalize(ctx context.Context) error {
+	if _, err := w.td.finalize(ctx, false /* traceKV */); err != nil {
+		return err
+	}
+	_, err := w.ti.finalize(ctx, false /* traceKV */)
+	return err
+}
+
+// populateMaterializedView writes the rows of a materialized view that was
+// created in the given transaction.
+func populateMaterializedView(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	txn *client.Txn,
+	user string,
+	desc *sqlbase.ImmutableTableDescriptor,
+) error {
+	w, err := makeMaterializedViewWriter(execCfg, txn, desc)
+	if err != nil {
+		return err
+	}
+	if err := queryMaterializedView(ctx, execCfg, txn, user, desc, w.insertRow); err != nil {
+		return err
+	}
+	return w.finalize(ctx)
+}
+
+// refreshMaterializedView recomputes the contents of the given materialized
+// view in the given transaction.
+//
+// A regular refresh replaces all the stored rows. A concurrent refresh
+// compares the stored rows with the new result of the query of the view, and
+// only deletes and inserts the rows that differ, which leaves the rows that
+// didn't change available to concurrent readers. The new result is buffered
+// while it is compared with the stored rows, and spills to temporary storage
+// like the other buffering operators do.
+func refreshMaterializedView(
+	params runParams,
+	txn *client.Txn,
+	user string,
+	desc *sqlbase.ImmutableTableDescriptor,
+	concurrently bool,
+) error {
+	ctx, execCfg := params.ctx, params.ExecCfg()
+	if !concurrently {
+		span := desc.TableSpan()
+		if err := txn.DelRange(ctx, span.Key, span.EndKey); err != nil {
+			return err
+		}
+		return populateMaterializedView(ctx, execCfg, txn, user, desc)
+	}
+
+	// Rows are compared on all the columns but rowid, and duplicate rows are
+	// matched one for one: unmatched counts the copies of every row of the new
+	// result that weren't matched with a stored row yet.
+	cols := sqlbase.ResultColumnsFromColDescs(desc.Columns)
+	rowIDIdx := materializedViewRowIDIdx(desc)
+	keyCols := make(sqlbase.ResultColumns, 0, len(cols)-1)
+	keyCols = append(keyCols, cols[:rowIDIdx]...)
+	keyCols = append(keyCols, cols[rowIDIdx+1:]...)
+	key := make(tree.Datums, len(keyCols))
+	rowKey := func(r tree.Datums) tree.Datums {
+		copy(key, r[:rowIDIdx])
+		copy(key[rowIDIdx:], r[rowIDIdx+1:])
+		return key
+	}
+
+	rows := newRowContainerHelper(params, cols, "refresh-materialized-view")
+	defer rows.close(ctx)
+	unmatched := newRowSetHelper(params, keyCols, "refresh-materialized-view-unmatched")
+	defer unmatched.close(ctx)
+	if err := queryMaterializedView(ctx, execCfg, txn, user, desc,
+		func(ctx context.Context, r tree.Datums) error {
+			if err := unmatched.insert(ctx, rowKey(r)); err != nil {
+				return err
+			}
+			return rows.addRow(ctx, r)
+		},
+	); err != nil {
+		return err
+	}
+
+	w, err := makeMaterializedViewWriter(execCfg, txn, desc)
+	if err != nil {
+		return err
+	}
+
+	// Delete the stored rows, including their rowid, that don't match a row of
+	// the new result.
+	names := make(tree.NameList, len(desc.Columns))
+	for i := range desc.Columns {
+		names[i] = tree.Name(desc.Columns[i].Name)
+	}
+	if err := runInternalQuery(
+		ctx, execCfg, txn, user, "read-materialized-view",
+		fmt.Sprintf("SELECT %s FROM [%d AS t]", tree.AsString(&names), desc.ID),
+		func(ctx context.Context, r tree.Datums) error {
+			if matched, err := unmatched.remove(ctx, rowKey(r)); err != nil || matched {
+				return err
+			}
+			return w.deleteRow(ctx, r)
+		},
+	); err != nil {
+		return err
+	}
+
+	// Insert the rows of the new result that remain unmatched.
+	it := rows.newIterator(ctx)
+	defer it.close()
+	for {
+		r, err := it.next()
+		if err != nil {
+			return err
+		}
+		if r == nil {
+			break
+		}
+		ok, err := unmatched.remove(ctx, rowKey(r))
+		if err != nil {
+			return err
+		}
+		if ok {
+			if err := w.insertRow(ctx, r); err != nil {
+				return err
+			}
+		}
+	}
+	return w.finalize(ctx)
+}
+
+// materializedViewRefreshResumer implements the jobs.Resumer interface for
+// MaterializedViewRefresh jobs.
+type materializedViewRefreshResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = &materializedViewRefreshResumer{}
+
+// Resume is part of the jobs.Resumer interface.
+func (r *materializedViewRefreshResumer) Resume(
+	ctx context.Context, phs interface{}, _ chan<- tree.Datums,
+) error {
+	p := phs.(*planner)
+	details := r.job.Details().(jobspb.MaterializedViewRefreshDetails)
+	execCfg := p.ExecCfg()
+	// The whole refresh runs in a single transaction, so that a job that is
+	// resumed after a node failure starts over from the stored contents.
+	return execCfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		desc, err := sqlbase.GetTableDescFromID(ctx, txn, details.TableID)
+		if err != nil {
+			return err
+		}
+		if desc.Dropped() {
+			return pgerror.Newf(pgerror.CodeObjectNotInPrerequisiteStateError,
+				"materialized view %q is being dropped", desc.Name)
+		}
+		params := runParams{ctx: ctx, extendedEvalCtx: &p.extendedEvalCtx, p: p}
+		return refreshMaterializedView(
+			params, txn, r.job.Payload().Username,
+			sqlbase.NewImmutableTableDescriptor(*desc), details.Concurrently,
+		)
+	})
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface.
+func (r *materializedViewRefreshResumer) OnFailOrCancel(context.Context, *client.Txn) error {
+	return nil
+}
+
+// OnSuccess is part of the jobs.Resumer interface.
+func (r *materializedViewRefreshResumer) OnSuccess(context.Context, *client.Txn) error {
+	return nil
+}
+
+// OnTerminal is part of the jobs.Resumer interface.
+func (r *materializedViewRefreshResumer) OnTerminal(
+	context.Context, jobs.Status, chan<- tree.Datums,
+) {
+}
+
+func init() {
+	jobs.RegisterConstructor(
+		jobspb.TypeMaterializedViewRefresh,
+		func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+			return &materializedViewRefreshResumer{job: job}
+		},
+	)
+}
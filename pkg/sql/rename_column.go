@@ -83,6 +83,9 @@ func (p *planner) renameColumn(
 	if err != nil {
 		return false, err
 	}
+	if err := checkNoNotNullMutation(tableDesc, col); err != nil {
+		return false, err
+	}
 
 	for _, tableRef := range tableDesc.DependedOnBy {
 		found := false
@@ -157,6 +160,29 @@ func (p *planner) renameColumn(
 		}
 	}
 
+	// Rename the column in the predicates of partial indexes, including the
+	// ones being added or dropped, since they are still maintained.
+	renameInPredicate := func(idx *sqlbase.IndexDescriptor) error {
+		if !idx.IsPartial() {
+			return nil
+		}
+		var err error
+		idx.Predicate, err = renameIn(idx.Predicate)
+		return err
+	}
+	for i := range tableDesc.Indexes {
+		if err := renameInPredicate(&tableDesc.Indexes[i]); err != nil {
+			return false, err
+		}
+	}
+	for _, m := range tableDesc.Mutations {
+		if idx := m.GetIndex(); idx != nil {
+			if err := renameInPredicate(idx); err != nil {
+				return false, err
+			}
+		}
+	}
+
 	// Rename the column in the indexes.
 	tableDesc.RenameColumnDescriptor(col, string(*newName))
 
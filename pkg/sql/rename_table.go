@@ -81,6 +81,10 @@ func (n *renameTableNode) startExec(params runParams) error {
 	newTn := n.newTn
 	tableDesc := n.tableDesc
 
+	if tableDesc.IsTemporary() {
+		return p.renameTemporaryObject(ctx, newTn, tableDesc)
+	}
+
 	prevDbDesc, err := p.ResolveUncachedDatabase(ctx, oldTn)
 	if err != nil {
 		return err
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
 // SchemaResolver abstracts the interfaces needed from the logical
@@ -215,6 +216,14 @@ func (p *planner) ResolveUncachedTableDescriptor(
 // resolution.
 func ResolveTargetObject(
 	ctx context.Context, sc SchemaResolver, tn *ObjectName,
+) (res *DatabaseDescriptor, err error) {
+	return resolveTargetObject(ctx, sc, tn, false /* allowTemporary */)
+}
+
+// resolveTargetObject implements ResolveTargetObject. If allowTemporary is
+// set, the target may also be in the session's temporary schema.
+func resolveTargetObject(
+	ctx context.Context, sc SchemaResolver, tn *ObjectName, allowTemporary bool,
 ) (res *DatabaseDescriptor, err error) {
 	found, descI, err := tn.ResolveTarget(ctx, sc, sc.CurrentDatabase(), sc.CurrentSearchPath())
 	if err != nil {
@@ -228,7 +237,8 @@ func ResolveTargetObject(
 			"cannot create %q because the target database or schema does not exist",
 			tree.ErrString(tn)).SetHintf("verify that the current database and search_path are valid and/or the target database exists")
 	}
-	if tn.Schema() != tree.PublicSchema {
+	if tn.Schema() != tree.PublicSchema &&
+		!(allowTemporary && tn.Schema() == sessiondata.PgTempSchemaName) {
 		return nil, pgerror.Newf(pgerror.CodeInvalidNameError,
 			"schema cannot be modified: %q", tree.ErrString(&tn.TableNamePrefix))
 	}
@@ -268,6 +278,9 @@ var requiredTypeNames = [...]string{
 func (p *planner) LookupSchema(
 	ctx context.Context, dbName, scName string,
 ) (found bool, scMeta tree.SchemaMeta, err error) {
+	if scName == sessiondata.PgTempSchemaName {
+		return p.lookupTemporarySchema(ctx, dbName)
+	}
 	sc := p.LogicalSchemaAccessor()
 	dbDesc, err := sc.GetDatabaseDesc(ctx, p.txn, dbName, p.CommonLookupFlags(false /*required*/))
 	if err != nil || dbDesc == nil {
@@ -280,12 +293,137 @@ func (p *planner) LookupSchema(
 func (p *planner) LookupObject(
 	ctx context.Context, requireMutable bool, dbName, scName, tbName string,
 ) (found bool, objMeta tree.NameResolutionResult, err error) {
+	if scName == sessiondata.PgTempSchemaName {
+		return p.lookupTemporaryObject(ctx, requireMutable, dbName, tbName)
+	}
 	sc := p.LogicalSchemaAccessor()
 	p.tableName = tree.MakeTableNameWithSchema(tree.Name(dbName), tree.Name(scName), tree.Name(tbName))
 	objDesc, err := sc.GetObjectDesc(ctx, p.txn, &p.tableName, p.ObjectLookupFlags(false /*required*/, requireMutable))
 	return objDesc != nil, objDesc, err
 }
 
+// ResolveType implements the tree.TypeReferenceResolver interface.
+// User-defined types live in the public schema of the current database.
+func (p *planner) ResolveType(name string) (*types.T, error) {
+	ctx := p.EvalContext().Context
+	dbDesc, err := p.LogicalSchemaAccessor().GetDatabaseDesc(
+		ctx, p.txn, p.CurrentDatabase(), p.CommonLookupFlags(false /*required*/))
+	if err != nil {
+		return nil, err
+	}
+	if dbDesc == nil {
+		return nil, pgerror.Newf(pgerror.CodeUndefinedObjectError, "type %q does not exist", name)
+	}
+	typDesc, err := lookupTypeDesc(ctx, p.txn, dbDesc.ID, name)
+	if err != nil {
+		return nil, err
+	}
+	if typDesc == nil {
+		return nil, pgerror.Newf(pgerror.CodeUndefinedObjectError, "type %q does not exist", name)
+	}
+	return typDesc.MakeTypesT(), nil
+}
+
+// lookupTypeDesc looks up the type with the given name in the given database.
+// It returns nil if the name does not exist or does not refer to a type.
+func lookupTypeDesc(
+	ctx context.Context, txn *client.Txn, dbID sqlbase.ID, name string,
+) (*sqlbase.TypeDescriptor, error) {
+	id, err := getDescriptorID(ctx, txn, sqlbase.NewTableKey(dbID, name))
+	if err != nil || id == sqlbase.InvalidID {
+		return nil, err
+	}
+	typDesc, err := sqlbase.GetTypeDescFromID(ctx, txn, id)
+	if err == sqlbase.ErrDescriptorNotFound {
+		return nil, nil
+	}
+	return typDesc, err
+}
+
+// getDescs returns the existing descriptors among the descriptors with the
+// given IDs.
+func getDescs(
+	ctx context.Context, txn *client.Txn, ids []sqlbase.ID,
+) ([]*sqlbase.Descriptor, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	b := txn.NewBatch()
+	for _, id := range ids {
+		b.Get(sqlbase.MakeDescMetadataKey(id))
+	}
+	if err := txn.Run(ctx, b); err != nil {
+		return nil, err
+	}
+	var descs []*sqlbase.Descriptor
+	for _, result := range b.Results {
+		for i := range result.Rows {
+			if result.Rows[i].Value == nil {
+				continue
+			}
+			desc := &sqlbase.Descriptor{}
+			if err := result.Rows[i].ValueProto(desc); err != nil {
+				return nil, err
+			}
+			descs = append(descs, desc)
+		}
+	}
+	return descs, nil
+}
+
+// getDescsInDatabase returns the descriptors of the objects of the database
+// with the given ID.
+func getDescsInDatabase(
+	ctx context.Context, txn *client.Txn, dbID sqlbase.ID,
+) ([]*sqlbase.Descriptor, error) {
+	prefix := sqlbase.MakeNameMetadataKey(dbID, "")
+	sr, err := txn.Scan(ctx, prefix, prefix.PrefixEnd(), 0)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]sqlbase.ID, len(sr))
+	for i := range sr {
+		ids[i] = sqlbase.ID(sr[i].ValueInt())
+	}
+	return getDescs(ctx, txn, ids)
+}
+
+// getTypeDescsInDatabase returns the descriptors of the types of the database
+// with the given ID.
+func getTypeDescsInDatabase(
+	ctx context.Context, txn *client.Txn, dbID sqlbase.ID,
+) ([]*sqlbase.TypeDescriptor, error) {
+	descs, err := getDescsInDatabase(ctx, txn, dbID)
+	if err != nil {
+		return nil, err
+	}
+	var typDescs []*sqlbase.TypeDescriptor
+	for _, desc := range descs {
+		if typDesc := desc.GetType(); typDesc != nil {
+			typDescs = append(typDescs, typDesc)
+		}
+	}
+	return typDescs, nil
+}
+
+// getFunctionDescsInDatabase returns the descriptors of the functions of the
+// database with the given ID.
+func getFunctionDescsInDatabase(
+	ctx context.Context, txn *client.Txn, dbID sqlbase.ID,
+) ([]*sqlbase.FunctionDescriptor, error) {
+	descs, err := getDescsInDatabase(ctx, txn, dbID)
+	if err != nil {
+		return nil, err
+	}
+	var fnDescs []*sqlbase.FunctionDescriptor
+	for _, desc := range descs {
+		if fnDesc := desc.GetFunction(); fnDesc != nil {
+			fnDescs = append(fnDescs, fnDesc)
+		}
+	}
+	return fnDescs, nil
+}
+
 func (p *planner) CommonLookupFlags(required bool) CommonLookupFlags {
 	return CommonLookupFlags{
 		required:    required,
@@ -304,6 +442,27 @@ func (p *planner) ObjectLookupFlags(required, requireMutable bool) ObjectLookupF
 func getDescriptorsFromTargetList(
 	ctx context.Context, p *planner, targets tree.TargetList,
 ) ([]sqlbase.DescriptorProto, error) {
+	if targets.Functions != nil {
+		descs := make([]sqlbase.DescriptorProto, 0, len(targets.Functions))
+		for i := range targets.Functions {
+			fn := &targets.Functions[i]
+			dbDesc, err := p.ResolveUncachedDatabase(ctx, fn)
+			if err != nil {
+				return nil, err
+			}
+			fnDesc, err := lookupFunctionDesc(ctx, p.txn, dbDesc.ID, fn.Table())
+			if err != nil {
+				return nil, err
+			}
+			if fnDesc == nil {
+				return nil, pgerror.Newf(pgerror.CodeUndefinedFunctionError,
+					"function %q does not exist", fn.Table())
+			}
+			descs = append(descs, fnDesc)
+		}
+		return descs, nil
+	}
+
 	if targets.Databases != nil {
 		if len(targets.Databases) == 0 {
 			return nil, errNoDatabase
@@ -571,6 +730,10 @@ type internalLookupCtx struct {
 	dbDescs map[sqlbase.ID]*DatabaseDescriptor
 	tbDescs map[sqlbase.ID]*TableDescriptor
 	tbIDs   []sqlbase.ID
+	tyDescs map[sqlbase.ID]*sqlbase.TypeDescriptor
+	tyIDs   []sqlbase.ID
+	fnDescs map[sqlbase.ID]*sqlbase.FunctionDescriptor
+	fnIDs   []sqlbase.ID
 }
 
 // tableLookupFn can be used to retrieve a table descriptor and its corresponding
@@ -583,7 +746,9 @@ func newInternalLookupCtx(
 	dbNames := make(map[sqlbase.ID]string)
 	dbDescs := make(map[sqlbase.ID]*DatabaseDescriptor)
 	tbDescs := make(map[sqlbase.ID]*TableDescriptor)
-	var tbIDs, dbIDs []sqlbase.ID
+	tyDescs := make(map[sqlbase.ID]*sqlbase.TypeDescriptor)
+	fnDescs := make(map[sqlbase.ID]*sqlbase.FunctionDescriptor)
+	var tbIDs, dbIDs, tyIDs, fnIDs []sqlbase.ID
 	// Record database descriptors for name lookups.
 	for _, desc := range descs {
 		switch d := desc.(type) {
@@ -599,6 +764,16 @@ func newInternalLookupCtx(
 				// Only make the table visible for iteration if the prefix was included.
 				tbIDs = append(tbIDs, d.ID)
 			}
+		case *sqlbase.TypeDescriptor:
+			tyDescs[d.ID] = d
+			if prefix == nil || prefix.ID == d.ParentID {
+				tyIDs = append(tyIDs, d.ID)
+			}
+		case *sqlbase.FunctionDescriptor:
+			fnDescs[d.ID] = d
+			if prefix == nil || prefix.ID == d.ParentID {
+				fnIDs = append(fnIDs, d.ID)
+			}
 		}
 	}
 	return &internalLookupCtx{
@@ -607,6 +782,10 @@ func newInternalLookupCtx(
 		tbDescs: tbDescs,
 		tbIDs:   tbIDs,
 		dbIDs:   dbIDs,
+		tyDescs: tyDescs,
+		tyIDs:   tyIDs,
+		fnDescs: fnDescs,
+		fnIDs:   fnIDs,
 	}
 }
 
@@ -45,6 +45,9 @@ type cascader struct {
 	updaterRowFetchers map[TableID]Fetcher                    // RowFetchers for rowUpdaters by Table ID
 	originalRows       map[TableID]*rowcontainer.RowContainer // Original values for rows that have been updated by Table ID
 	updatedRows        map[TableID]*rowcontainer.RowContainer // New values for rows that have been updated by Table ID
+
+	// deferredChecks, if set, is given to the row deleters and updaters.
+	deferredChecks DeferredChecks
 }
 
 // makeDeleteCascader only creates a cascader if there is a chance that there is
@@ -373,6 +376,8 @@ func (c *cascader) addIndexPKRowFetcher(
 	var rowFetcher Fetcher
 	if err := rowFetcher.Init(
 		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
 		false, /* returnRangeInfo */
 		false, /* isCheck */
 		c.alloc,
@@ -392,6 +397,12 @@ func (c *cascader) addIndexPKRowFetcher(
 	return rowFetcher, nil
 }
 
+func errCascadeVirtualColumns(table *sqlbase.ImmutableTableDescriptor) error {
+	return pgerror.Unimplementedf("cascade virtual columns",
+		"cascading updates and deletes are not supported on table %q with virtual computed columns",
+		table.Name)
+}
+
 // addRowDeleter creates the row deleter and primary index row fetcher.
 func (c *cascader) addRowDeleter(
 	table *sqlbase.ImmutableTableDescriptor,
@@ -407,6 +418,12 @@ func (c *cascader) addRowDeleter(
 		return rowDeleter, rowFetcher, nil
 	}
 
+	// The row fetcher reads the rows from the primary index, which doesn't
+	// store the values of virtual computed columns.
+	if table.HasVirtualColumns() {
+		return Deleter{}, Fetcher{}, errCascadeVirtualColumns(table)
+	}
+
 	// Create the row deleter. The row deleter is needed prior to the row fetcher
 	// as it will dictate what columns are required in the row fetcher.
 	rowDeleter, err := makeRowDeleterWithoutCascader(
@@ -420,6 +437,7 @@ func (c *cascader) addRowDeleter(
 	if err != nil {
 		return Deleter{}, Fetcher{}, err
 	}
+	rowDeleter.SetDeferredChecks(c.deferredChecks)
 
 	// Create the row fetcher that will retrive the rows and columns needed for
 	// deletion.
@@ -436,6 +454,8 @@ func (c *cascader) addRowDeleter(
 	var rowFetcher Fetcher
 	if err := rowFetcher.Init(
 		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
 		false, /* returnRangeInfo */
 		false, /* isCheck */
 		c.alloc,
@@ -466,6 +486,12 @@ func (c *cascader) addRowUpdater(
 		return rowUpdater, rowFetcher, nil
 	}
 
+	// The row fetcher reads the rows from the primary index, which doesn't
+	// store the values of virtual computed columns.
+	if table.HasVirtualColumns() {
+		return Updater{}, Fetcher{}, errCascadeVirtualColumns(table)
+	}
+
 	// Create the row updater. The row updater requires all the columns in the
 	// table.
 	rowUpdater, err := makeUpdaterWithoutCascader(
@@ -480,6 +506,7 @@ func (c *cascader) addRowUpdater(
 	if err != nil {
 		return Updater{}, Fetcher{}, err
 	}
+	rowUpdater.SetDeferredChecks(c.deferredChecks)
 
 	// Create the row fetcher that will retrive the rows and columns needed for
 	// deletion.
@@ -496,6 +523,8 @@ func (c *cascader) addRowUpdater(
 	var rowFetcher Fetcher
 	if err := rowFetcher.Init(
 		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
 		false, /* returnRangeInfo */
 		false, /* isCheck */
 		c.alloc,
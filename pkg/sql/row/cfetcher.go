@@ -429,7 +429,11 @@ func (rf *CFetcher) StartScan(
 		firstBatchLimit++
 	}
 
-	f, err := makeKVBatchFetcher(txn, spans, rf.reverse, limitBatches, firstBatchLimit, rf.returnRangeInfo)
+	f, err := makeKVBatchFetcher(
+		txn, spans, rf.reverse, limitBatches, firstBatchLimit,
+		sqlbase.ScanLockingStrength_FOR_NONE, sqlbase.ScanLockingWaitPolicy_BLOCK,
+		rf.returnRangeInfo,
+	)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,57 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package row
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+// DeferredChecks queues the checks of deferrable constraints. Deferrable
+// foreign keys and UNIQUE constraints are not checked row by row: the writers
+// report the key values of the rows that they modify, and the SQL layer
+// validates the constraints for these values once they become immediate.
+//
+// Writers that are not given a DeferredChecks check deferrable foreign keys
+// immediately, like the other foreign keys.
+type DeferredChecks interface {
+	// AddForeignKeyCheck queues the check of the deferrable foreign key
+	// carried by the index idx of the referencing table. values are the
+	// values of the referencing columns that need to be checked.
+	AddForeignKeyCheck(tableID sqlbase.ID, idx *sqlbase.IndexDescriptor, values tree.Datums)
+
+	// AddUniqueCheck queues the check of the deferrable UNIQUE constraint
+	// carried by the index idx of the table. values are the values of the
+	// index columns of a row that was written to the table.
+	AddUniqueCheck(tableID sqlbase.ID, idx *sqlbase.IndexDescriptor, values tree.Datums)
+}
+
+// queueDeferrableUniqueCheck queues the check of the deferrable UNIQUE
+// constraint of the i-th secondary index, if any, for the given row. Rows with
+// a NULL value in the index columns are skipped since they can't conflict.
+func (rh *rowHelper) queueDeferrableUniqueCheck(
+	dc DeferredChecks, i int, colIDtoRowIndex map[sqlbase.ColumnID]int, values []tree.Datum,
+) {
+	idx := &rh.Indexes[i]
+	if !idx.IsDeferrableUnique() {
+		return
+	}
+	key := make(tree.Datums, len(idx.ColumnIDs))
+	for j, colID := range idx.ColumnIDs {
+		key[j] = values[colIDtoRowIndex[colID]]
+		if key[j] == tree.DNull {
+			return
+		}
+	}
+	dc.AddUniqueCheck(rh.TableDesc.ID, idx, key)
+}
@@ -107,15 +107,25 @@ func makeRowDeleterWithoutCascader(
 				return Deleter{}, err
 			}
 		}
+		// The predicate columns are needed to know whether the row is part of
+		// a partial index.
+		for _, colID := range index.PredicateColumnIDs {
+			if err := maybeAddCol(colID); err != nil {
+				return Deleter{}, err
+			}
+		}
 	}
 
+	helper, err := newRowHelper(tableDesc, indexes)
+	if err != nil {
+		return Deleter{}, err
+	}
 	rd := Deleter{
-		Helper:               newRowHelper(tableDesc, indexes),
+		Helper:               helper,
 		FetchCols:            fetchCols,
 		FetchColIDtoRowIndex: fetchColIDtoRowIndex,
 	}
 	if checkFKs == CheckFKs {
-		var err error
 		if rd.Fks, err = makeFkExistenceCheckHelperForDelete(txn, tableDesc, fkTables,
 			fetchColIDtoRowIndex, alloc); err != nil {
 			return Deleter{}, err
@@ -125,6 +135,18 @@ func makeRowDeleterWithoutCascader(
 	return rd, nil
 }
 
+// SetDeferredChecks makes the Deleter queue the checks of the deferrable
+// constraints of the rows it deletes, including the rows written by cascading
+// actions, with dc.
+func (rd *Deleter) SetDeferredChecks(dc DeferredChecks) {
+	if rd.Fks.checker != nil {
+		rd.Fks.checker.deferredChecks = dc
+	}
+	if rd.cascader != nil {
+		rd.cascader.deferredChecks = dc
+	}
+}
+
 // DeleteRow adds to the batch the kv operations necessary to delete a table row
 // with the given values. It also will cascade as required and check for
 // orphaned rows. The bytesMonitor is only used if cascading/fk checking and can
@@ -144,6 +166,10 @@ func (rd *Deleter) DeleteRow(
 	// Delete the row from any secondary indices.
 	for i := range secondaryIndexEntries {
 		secondaryIndexEntry := &secondaryIndexEntries[i]
+		if secondaryIndexEntry.Key == nil {
+			// The row is not part of this partial index.
+			continue
+		}
 		if traceKV {
 			log.VEventf(ctx, 2, "Del %s", keys.PrettyPrint(rd.Helper.secIndexValDirs[i], secondaryIndexEntry.Key))
 		}
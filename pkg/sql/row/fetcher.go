@@ -182,6 +182,14 @@ type Fetcher struct {
 	// or not when StartScan is invoked.
 	reverse bool
 
+	// lockStr represents the row-level locking mode to use when fetching
+	// rows.
+	lockStr sqlbase.ScanLockingStrength
+
+	// lockWaitPolicy represents the policy to use when the fetcher encounters
+	// rows that are locked by other transactions.
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy
+
 	// maxKeysPerRow memoizes the maximum number of keys per row
 	// out of all the tables. This is used to calculate the kvBatchFetcher's
 	// firstBatchLimit.
@@ -243,9 +251,14 @@ func (rf *Fetcher) Reset() {
 
 // Init sets up a Fetcher for a given table and index. If we are using a
 // non-primary index, tables.ValNeededForCol can only refer to columns in the
-// index.
+// index. If lockStr is not FOR_NONE, the fetcher locks each row that it scans
+// in its transaction; lockWaitPolicy controls what happens when a row is
+// already locked by another transaction.
 func (rf *Fetcher) Init(
-	reverse, returnRangeInfo bool,
+	reverse bool,
+	lockStr sqlbase.ScanLockingStrength,
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy,
+	returnRangeInfo bool,
 	isCheck bool,
 	alloc *sqlbase.DatumAlloc,
 	tables ...FetcherTableArgs,
@@ -253,8 +266,21 @@ func (rf *Fetcher) Init(
 	if len(tables) == 0 {
 		return pgerror.AssertionFailedf("no tables to fetch from")
 	}
+	if lockWaitPolicy == sqlbase.ScanLockingWaitPolicy_SKIP {
+		// Rows are skipped one key at a time, so a row that is split across
+		// several keys could be returned partially.
+		for _, tableArgs := range tables {
+			if len(tableArgs.Desc.Families) > 1 {
+				return pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+					"SKIP LOCKED is not supported on table %q with multiple column families",
+					tableArgs.Desc.Name)
+			}
+		}
+	}
 
 	rf.reverse = reverse
+	rf.lockStr = lockStr
+	rf.lockWaitPolicy = lockWaitPolicy
 	rf.returnRangeInfo = returnRangeInfo
 	rf.alloc = alloc
 	rf.isCheck = isCheck
@@ -451,7 +477,8 @@ func (rf *Fetcher) StartScan(
 
 	rf.traceKV = traceKV
 	f, err := makeKVBatchFetcher(
-		txn, spans, rf.reverse, limitBatches, rf.firstBatchLimit(limitHint), rf.returnRangeInfo,
+		txn, spans, rf.reverse, limitBatches, rf.firstBatchLimit(limitHint),
+		rf.lockStr, rf.lockWaitPolicy, rf.returnRangeInfo,
 	)
 	if err != nil {
 		return err
@@ -481,6 +508,11 @@ func (rf *Fetcher) StartInconsistentScan(
 	if len(spans) == 0 {
 		return pgerror.AssertionFailedf("no spans")
 	}
+	if rf.lockStr != sqlbase.ScanLockingStrength_FOR_NONE {
+		// Locks are acquired in the scan's transaction, which is committed as
+		// soon as the timestamp is bumped.
+		return pgerror.AssertionFailedf("locking inconsistent scans are not supported")
+	}
 
 	txnTimestamp := initialTimestamp
 	txnStartTime := timeutil.Now()
@@ -530,6 +562,8 @@ func (rf *Fetcher) StartInconsistentScan(
 		rf.reverse,
 		limitBatches,
 		rf.firstBatchLimit(limitHint),
+		rf.lockStr,
+		rf.lockWaitPolicy,
 		rf.returnRangeInfo,
 	)
 	if err != nil {
@@ -72,8 +72,15 @@ func initFetcher(
 
 	fetcherArgs := makeFetcherArgs(entries)
 
-	if err := fetcher.Init(reverseScan, false /*reverse*/, false, /* isCheck */
-		alloc, fetcherArgs...); err != nil {
+	if err := fetcher.Init(
+		reverseScan,
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		alloc,
+		fetcherArgs...,
+	); err != nil {
 		return nil, err
 	}
 
@@ -1040,8 +1047,15 @@ func TestRowFetcherReset(t *testing.T) {
 	// didn't reset.
 
 	fetcherArgs := makeFetcherArgs(args)
-	if err := resetFetcher.Init(false, false /*reverse*/, false, /* isCheck */
-		&da, fetcherArgs...); err != nil {
+	if err := resetFetcher.Init(
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		&da,
+		fetcherArgs...,
+	); err != nil {
 		t.Fatal(err)
 	}
 
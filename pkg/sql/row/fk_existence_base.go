@@ -80,6 +80,15 @@ type fkExistenceCheckBaseHelper struct {
 	// mutatedIdx is the descriptor for the target index being mutated.
 	// Stored only for error messages.
 	mutatedIdx *sqlbase.IndexDescriptor
+
+	// deferrable is set if the FK constraint is deferrable. Its existence
+	// checks are then queued with the SQL layer instead, if the checker
+	// has been given a DeferredChecks.
+	deferrable bool
+	// referencingTableID and referencingIdx identify the FK constraint for
+	// the SQL layer: the referencing index carries the constraint.
+	referencingTableID sqlbase.ID
+	referencingIdx     *sqlbase.IndexDescriptor
 }
 
 // makeFkExistenceCheckBaseHelper instantiates a FK helper.
@@ -97,6 +106,8 @@ type fkExistenceCheckBaseHelper struct {
 //   This is used to derive the searched table/index,
 //   and determine the MATCH style.
 //
+// - mutatedTable is the table being mutated.
+//
 // - writeIdx is the target index being mutated. This is used
 //   to determine prefixLen in combination with searchIdx.
 //
@@ -112,6 +123,7 @@ type fkExistenceCheckBaseHelper struct {
 func makeFkExistenceCheckBaseHelper(
 	txn *client.Txn,
 	otherTables FkTableMetadata,
+	mutatedTable *sqlbase.ImmutableTableDescriptor,
 	mutatedIdx *sqlbase.IndexDescriptor,
 	ref sqlbase.ForeignKeyReference,
 	colMap map[sqlbase.ColumnID]int,
@@ -129,6 +141,13 @@ func makeFkExistenceCheckBaseHelper(
 		return ret, err
 	}
 
+	// Backward references don't record the deferrability, which is found
+	// on the referencing index instead.
+	referencingTableID, referencingIdx := mutatedTable.ID, mutatedIdx
+	if dir == CheckDeletes {
+		referencingTableID, referencingIdx = searchTable.ID, searchIdx
+	}
+
 	// Determine the number of columns being looked up.
 	prefixLen := len(searchIdx.ColumnIDs)
 	if len(mutatedIdx.ColumnIDs) < prefixLen {
@@ -154,7 +173,14 @@ func makeFkExistenceCheckBaseHelper(
 	}
 	rf := &Fetcher{}
 	if err := rf.Init(
-		false /* reverse */, false /* returnRangeInfo */, false /* isCheck */, alloc, tableArgs); err != nil {
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		alloc,
+		tableArgs,
+	); err != nil {
 		return ret, err
 	}
 
@@ -169,6 +195,10 @@ func makeFkExistenceCheckBaseHelper(
 		prefixLen:    prefixLen,
 		searchPrefix: searchPrefix,
 		mutatedIdx:   mutatedIdx,
+
+		deferrable:         referencingIdx.ForeignKey.IsDeferrable(),
+		referencingTableID: referencingTableID,
+		referencingIdx:     referencingIdx,
 	}, nil
 }
 
@@ -39,6 +39,10 @@ type fkExistenceBatchChecker struct {
 	// batchIdxToFk maps the index of the check request/response in the kv batch
 	// to the fkExistenceCheckBaseHelper that created it.
 	batchIdxToFk []*fkExistenceCheckBaseHelper
+
+	// deferredChecks, if set, receives the checks of deferrable FK
+	// constraints instead of the batch.
+	deferredChecks DeferredChecks
 }
 
 // reset starts a new batch.
@@ -67,6 +71,23 @@ func (f *fkExistenceBatchChecker) addCheck(
 	return nil
 }
 
+// deferCheck queues the check of a deferrable FK constraint for the given row
+// with the SQL layer. It returns false if the check can't be deferred, in
+// which case it must be added to the batch.
+func (f *fkExistenceBatchChecker) deferCheck(
+	row tree.Datums, source *fkExistenceCheckBaseHelper,
+) bool {
+	if !source.deferrable || f.deferredChecks == nil {
+		return false
+	}
+	values := make(tree.Datums, source.prefixLen)
+	for valueIdx, colID := range source.searchIdx.ColumnIDs[:source.prefixLen] {
+		values[valueIdx] = row[source.ids[colID]]
+	}
+	f.deferredChecks.AddForeignKeyCheck(source.referencingTableID, source.referencingIdx, values)
+	return true
+}
+
 // runCheck sends the accumulated batch of foreign key checks to kv, given the
 // old and new values of the row being modified. Either oldRow or newRow can
 // be set to nil in the case of an insert or a delete, respectively.
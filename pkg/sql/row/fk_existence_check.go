@@ -50,6 +50,9 @@ outer:
 					continue outer
 				}
 			}
+			if checkRunner.deferCheck(mutatedRow, &fkInfo[mutatedIdx][i]) {
+				continue
+			}
 			if err := checkRunner.addCheck(ctx, mutatedRow, &fkInfo[mutatedIdx][i], traceKV); err != nil {
 				return err
 			}
@@ -68,6 +71,9 @@ outer:
 				}
 			}
 			if nulls && notNulls {
+				if checkRunner.deferCheck(mutatedRow, &fkInfo[mutatedIdx][i]) {
+					continue
+				}
 				// TODO(bram): expand this error to show more details.
 				return pgerror.Newf(pgerror.CodeForeignKeyViolationError,
 					"foreign key violation: MATCH FULL does not allow mixing of null and nonnull values %s for %s",
@@ -78,6 +84,9 @@ outer:
 			if nulls {
 				continue
 			}
+			if checkRunner.deferCheck(mutatedRow, &fkInfo[mutatedIdx][i]) {
+				continue
+			}
 			if err := checkRunner.addCheck(ctx, mutatedRow, &fkInfo[mutatedIdx][i], traceKV); err != nil {
 				return err
 			}
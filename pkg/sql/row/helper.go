@@ -28,6 +28,10 @@ type rowHelper struct {
 	Indexes      []sqlbase.IndexDescriptor
 	indexEntries []sqlbase.IndexEntry
 
+	// partialIndexes is used to leave out the entries of the partial indexes
+	// that do not contain a row. It is nil if none of the indexes is partial.
+	partialIndexes *sqlbase.PartialIndexHelper
+
 	// Computed during initialization for pretty-printing.
 	primIndexValDirs []encoding.Direction
 	secIndexValDirs  [][]encoding.Direction
@@ -40,9 +44,17 @@ type rowHelper struct {
 
 func newRowHelper(
 	desc *sqlbase.ImmutableTableDescriptor, indexes []sqlbase.IndexDescriptor,
-) rowHelper {
+) (rowHelper, error) {
 	rh := rowHelper{TableDesc: desc, Indexes: indexes}
 
+	var err error
+	rh.partialIndexes, err = sqlbase.NewPartialIndexHelper(
+		desc.TableDesc(), desc.DeletableColumns(), indexes,
+	)
+	if err != nil {
+		return rowHelper{}, err
+	}
+
 	// Pre-compute the encoding directions of the index key values for
 	// pretty-printing in traces.
 	rh.primIndexValDirs = sqlbase.IndexKeyValDirs(&rh.TableDesc.PrimaryIndex)
@@ -52,7 +64,7 @@ func newRowHelper(
 		rh.secIndexValDirs[i] = sqlbase.IndexKeyValDirs(&rh.Indexes[i])
 	}
 
-	return rh
+	return rh, nil
 }
 
 // encodeIndexes encodes the primary and secondary index keys. The
@@ -80,6 +92,9 @@ func (rh *rowHelper) encodeIndexes(
 // encodeSecondaryIndexes encodes the secondary index keys. The
 // secondaryIndexEntries are only valid until the next call to encodeIndexes or
 // encodeSecondaryIndexes.
+//
+// The entry of a partial index that does not contain the row is left empty
+// (its Key is nil), so that the entries still parallel rh.Indexes.
 func (rh *rowHelper) encodeSecondaryIndexes(
 	colIDtoRowIndex map[sqlbase.ColumnID]int, values []tree.Datum,
 ) (secondaryIndexEntries []sqlbase.IndexEntry, err error) {
@@ -91,6 +106,18 @@ func (rh *rowHelper) encodeSecondaryIndexes(
 	if err != nil {
 		return nil, err
 	}
+	if rh.partialIndexes != nil {
+		rh.partialIndexes.LoadRow(colIDtoRowIndex, values)
+		for i := range rh.Indexes {
+			contains, err := rh.partialIndexes.IndexContainsRow(i)
+			if err != nil {
+				return nil, err
+			}
+			if !contains {
+				rh.indexEntries[i] = sqlbase.IndexEntry{}
+			}
+		}
+	}
 	return rh.indexEntries, nil
 }
 
@@ -31,6 +31,9 @@ type Inserter struct {
 	InsertColIDtoRowIndex map[sqlbase.ColumnID]int
 	Fks                   fkExistenceCheckForInsert
 
+	// deferredChecks, if set, receives the checks of deferrable constraints.
+	deferredChecks DeferredChecks
+
 	// For allocation avoidance.
 	marshaled []roachpb.Value
 	key       roachpb.Key
@@ -49,8 +52,12 @@ func MakeInserter(
 	checkFKs checkFKConstraints,
 	alloc *sqlbase.DatumAlloc,
 ) (Inserter, error) {
+	helper, err := newRowHelper(tableDesc, tableDesc.WritableIndexes())
+	if err != nil {
+		return Inserter{}, err
+	}
 	ri := Inserter{
-		Helper:                newRowHelper(tableDesc, tableDesc.WritableIndexes()),
+		Helper:                helper,
 		InsertCols:            insertCols,
 		InsertColIDtoRowIndex: ColIDtoRowIndexFromCols(insertCols),
 		marshaled:             make([]roachpb.Value, len(insertCols)),
@@ -63,7 +70,6 @@ func MakeInserter(
 	}
 
 	if checkFKs == CheckFKs {
-		var err error
 		if ri.Fks, err = makeFkExistenceCheckHelperForInsert(txn, tableDesc, fkTables,
 			ri.InsertColIDtoRowIndex, alloc); err != nil {
 			return ri, err
@@ -120,6 +126,21 @@ type putter interface {
 	Del(key ...interface{})
 }
 
+// SetDeferredChecks makes the Inserter queue the checks of the deferrable
+// constraints of the rows it writes with dc.
+func (ri *Inserter) SetDeferredChecks(dc DeferredChecks) {
+	ri.deferredChecks = dc
+	if ri.Fks.checker != nil {
+		ri.Fks.checker.deferredChecks = dc
+	}
+}
+
+// DeferredChecks returns the DeferredChecks set with SetDeferredChecks, if
+// any.
+func (ri *Inserter) DeferredChecks() DeferredChecks {
+	return ri.deferredChecks
+}
+
 // InsertRow adds to the batch the kv operations necessary to insert a table row
 // with the given values.
 func (ri *Inserter) InsertRow(
@@ -174,9 +195,21 @@ func (ri *Inserter) InsertRow(
 		return err
 	}
 
+	if ri.deferredChecks != nil {
+		for i := range ri.Helper.Indexes {
+			if secondaryIndexEntries[i].Key != nil {
+				ri.Helper.queueDeferrableUniqueCheck(ri.deferredChecks, i, ri.InsertColIDtoRowIndex, values)
+			}
+		}
+	}
+
 	putFn = insertInvertedPutFn
 	for i := range secondaryIndexEntries {
 		e := &secondaryIndexEntries[i]
+		if e.Key == nil {
+			// The row is not part of this partial index.
+			continue
+		}
 		putFn(ctx, b, &e.Key, &e.Value, traceKV)
 	}
 
@@ -55,6 +55,11 @@ type txnKVFetcher struct {
 	firstBatchLimit int64
 	useBatchLimit   bool
 	reverse         bool
+	// lockStr represents the locking mode to use when fetching KVs.
+	lockStr sqlbase.ScanLockingStrength
+	// lockWaitPolicy represents the policy to use for KVs that are locked by
+	// other transactions.
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy
 	// returnRangeInfo, if set, causes the kvBatchFetcher to populate rangeInfos.
 	// See also rowFetcher.returnRangeInfo.
 	returnRangeInfo bool
@@ -147,6 +152,8 @@ func makeKVBatchFetcher(
 	reverse bool,
 	useBatchLimit bool,
 	firstBatchLimit int64,
+	lockStr sqlbase.ScanLockingStrength,
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy,
 	returnRangeInfo bool,
 ) (txnKVFetcher, error) {
 	sendFn := func(ctx context.Context, ba roachpb.BatchRequest) (*roachpb.BatchResponse, error) {
@@ -157,7 +164,8 @@ func makeKVBatchFetcher(
 		return res, nil
 	}
 	return makeKVBatchFetcherWithSendFunc(
-		sendFn, spans, reverse, useBatchLimit, firstBatchLimit, returnRangeInfo,
+		sendFn, spans, reverse, useBatchLimit, firstBatchLimit, lockStr, lockWaitPolicy,
+		returnRangeInfo,
 	)
 }
 
@@ -169,6 +177,8 @@ func makeKVBatchFetcherWithSendFunc(
 	reverse bool,
 	useBatchLimit bool,
 	firstBatchLimit int64,
+	lockStr sqlbase.ScanLockingStrength,
+	lockWaitPolicy sqlbase.ScanLockingWaitPolicy,
 	returnRangeInfo bool,
 ) (txnKVFetcher, error) {
 	if firstBatchLimit < 0 || (!useBatchLimit && firstBatchLimit != 0) {
@@ -220,20 +230,42 @@ func makeKVBatchFetcherWithSendFunc(
 		reverse:         reverse,
 		useBatchLimit:   useBatchLimit,
 		firstBatchLimit: firstBatchLimit,
+		lockStr:         lockStr,
+		lockWaitPolicy:  lockWaitPolicy,
 		returnRangeInfo: returnRangeInfo,
 	}, nil
 }
 
+// getWaitPolicy returns the wait policy that the fetcher's requests use when
+// they run into rows locked by other transactions.
+func (f *txnKVFetcher) getWaitPolicy() roachpb.WaitPolicy {
+	switch f.lockWaitPolicy {
+	case sqlbase.ScanLockingWaitPolicy_BLOCK:
+		return roachpb.WaitPolicy_BLOCK
+	case sqlbase.ScanLockingWaitPolicy_SKIP:
+		return roachpb.WaitPolicy_SKIP
+	case sqlbase.ScanLockingWaitPolicy_ERROR:
+		return roachpb.WaitPolicy_ERROR
+	default:
+		panic(pgerror.AssertionFailedf("unknown wait policy %s", f.lockWaitPolicy))
+	}
+}
+
 // fetch retrieves spans from the kv
 func (f *txnKVFetcher) fetch(ctx context.Context) error {
 	var ba roachpb.BatchRequest
 	ba.Header.MaxSpanRequestKeys = f.getBatchSize()
 	ba.Header.ReturnRangeInfo = f.returnRangeInfo
+	ba.Header.WaitPolicy = f.getWaitPolicy()
+	// The KV layer only supports exclusive locks, so FOR SHARE and FOR KEY
+	// SHARE are upgraded to the same exclusive lock as FOR UPDATE.
+	keyLocking := f.lockStr != sqlbase.ScanLockingStrength_FOR_NONE
 	ba.Requests = make([]roachpb.RequestUnion, len(f.spans))
 	if f.reverse {
 		scans := make([]roachpb.ReverseScanRequest, len(f.spans))
 		for i := range f.spans {
 			scans[i].ScanFormat = roachpb.BATCH_RESPONSE
+			scans[i].KeyLocking = keyLocking
 			scans[i].SetSpan(f.spans[i])
 			ba.Requests[i].MustSetInner(&scans[i])
 		}
@@ -241,6 +273,7 @@ func (f *txnKVFetcher) fetch(ctx context.Context) error {
 		scans := make([]roachpb.ScanRequest, len(f.spans))
 		for i := range f.spans {
 			scans[i].ScanFormat = roachpb.BATCH_RESPONSE
+			scans[i].KeyLocking = keyLocking
 			scans[i].SetSpan(f.spans[i])
 			ba.Requests[i].MustSetInner(&scans[i])
 		}
@@ -268,6 +301,10 @@ func (f *txnKVFetcher) fetch(ctx context.Context) error {
 
 	br, err := f.sendFn(ctx, ba)
 	if err != nil {
+		if _, ok := err.(*roachpb.WriteIntentError); ok &&
+			f.lockWaitPolicy == sqlbase.ScanLockingWaitPolicy_ERROR {
+			return pgerror.Newf(pgerror.CodeLockNotAvailableError, "could not obtain lock on row")
+		}
 		return err
 	}
 	if br != nil {
@@ -45,6 +45,9 @@ type Updater struct {
 	Fks      fkExistenceCheckForUpdate
 	cascader *cascader
 
+	// deferredChecks, if set, receives the checks of deferrable constraints.
+	deferredChecks DeferredChecks
+
 	// For allocation avoidance.
 	marshaled       []roachpb.Value
 	newValues       []tree.Datum
@@ -140,12 +143,22 @@ func makeUpdaterWithoutCascader(
 		if primaryKeyColChange {
 			return true
 		}
-		return index.RunOverAllColumns(func(id sqlbase.ColumnID) error {
+		if index.RunOverAllColumns(func(id sqlbase.ColumnID) error {
 			if _, ok := updateColIDtoRowIndex[id]; ok {
 				return returnTruePseudoError
 			}
 			return nil
-		}) != nil
+		}) != nil {
+			return true
+		}
+		// A row can enter or leave a partial index when the columns of its
+		// predicate change.
+		for _, id := range index.PredicateColumnIDs {
+			if _, ok := updateColIDtoRowIndex[id]; ok {
+				return true
+			}
+		}
+		return false
 	}
 
 	writableIndexes := tableDesc.WritableIndexes()
@@ -172,12 +185,19 @@ func makeUpdaterWithoutCascader(
 
 	var deleteOnlyHelper *rowHelper
 	if len(deleteOnlyIndexes) > 0 {
-		rh := newRowHelper(tableDesc, deleteOnlyIndexes)
+		rh, err := newRowHelper(tableDesc, deleteOnlyIndexes)
+		if err != nil {
+			return Updater{}, err
+		}
 		deleteOnlyHelper = &rh
 	}
 
+	helper, err := newRowHelper(tableDesc, includeIndexes)
+	if err != nil {
+		return Updater{}, err
+	}
 	ru := Updater{
-		Helper:                newRowHelper(tableDesc, includeIndexes),
+		Helper:                helper,
 		DeleteHelper:          deleteOnlyHelper,
 		UpdateCols:            updateCols,
 		UpdateColIDtoRowIndex: updateColIDtoRowIndex,
@@ -190,7 +210,6 @@ func makeUpdaterWithoutCascader(
 		// These fields are only used when the primary key is changing.
 		// When changing the primary key, we delete the old values and reinsert
 		// them, so request them all.
-		var err error
 		if ru.rd, err = makeRowDeleterWithoutCascader(
 			txn, tableDesc, fkTables, tableCols, SkipFKs, alloc,
 		); err != nil {
@@ -250,20 +269,31 @@ func makeUpdaterWithoutCascader(
 		}
 
 		// Fetch all columns from indices that are being update so that they can
-		// be used to create the new kv pairs for those indices.
+		// be used to create the new kv pairs for those indices. The predicate
+		// columns of partial indexes are needed to know whether the old and the
+		// new row are part of the index.
 		for _, index := range includeIndexes {
 			if err := index.RunOverAllColumns(maybeAddCol); err != nil {
 				return Updater{}, err
 			}
+			for _, colID := range index.PredicateColumnIDs {
+				if err := maybeAddCol(colID); err != nil {
+					return Updater{}, err
+				}
+			}
 		}
 		for _, index := range deleteOnlyIndexes {
 			if err := index.RunOverAllColumns(maybeAddCol); err != nil {
 				return Updater{}, err
 			}
+			for _, colID := range index.PredicateColumnIDs {
+				if err := maybeAddCol(colID); err != nil {
+					return Updater{}, err
+				}
+			}
 		}
 	}
 
-	var err error
 	if ru.Fks, err = makeFkExistenceCheckHelperForUpdate(txn, tableDesc, fkTables,
 		ru.FetchColIDtoRowIndex, alloc); err != nil {
 		return Updater{}, err
@@ -271,6 +301,23 @@ func makeUpdaterWithoutCascader(
 	return ru, nil
 }
 
+// SetDeferredChecks makes the Updater queue the checks of the deferrable
+// constraints of the rows it writes, including the rows written by cascading
+// actions, with dc.
+func (ru *Updater) SetDeferredChecks(dc DeferredChecks) {
+	ru.deferredChecks = dc
+	if ru.Fks.checker != nil {
+		ru.Fks.checker.deferredChecks = dc
+	}
+	if ru.primaryKeyColChange {
+		ru.rd.SetDeferredChecks(dc)
+		ru.ri.SetDeferredChecks(dc)
+	}
+	if ru.cascader != nil {
+		ru.cascader.deferredChecks = dc
+	}
+}
+
 // UpdateRow adds to the batch the kv operations necessary to update a table row
 // with the given values.
 //
@@ -427,10 +474,20 @@ func (ru *Updater) UpdateRow(
 		var expValue interface{}
 		if !bytes.Equal(newSecondaryIndexEntry.Key, oldSecondaryIndexEntry.Key) {
 			ru.Fks.addCheckForIndex(ru.Helper.Indexes[i].ID, ru.Helper.Indexes[i].Type)
-			if traceKV {
-				log.VEventf(ctx, 2, "Del %s", keys.PrettyPrint(ru.Helper.secIndexValDirs[i], oldSecondaryIndexEntry.Key))
+			// The old or the new key is nil if the old or the new row is not part
+			// of this partial index.
+			if oldSecondaryIndexEntry.Key != nil {
+				if traceKV {
+					log.VEventf(ctx, 2, "Del %s", keys.PrettyPrint(ru.Helper.secIndexValDirs[i], oldSecondaryIndexEntry.Key))
+				}
+				batch.Del(oldSecondaryIndexEntry.Key)
+			}
+			if newSecondaryIndexEntry.Key == nil {
+				continue
+			}
+			if ru.deferredChecks != nil {
+				ru.Helper.queueDeferrableUniqueCheck(ru.deferredChecks, i, ru.FetchColIDtoRowIndex, ru.newValues)
 			}
-			batch.Del(oldSecondaryIndexEntry.Key)
 		} else if !newSecondaryIndexEntry.Value.EqualData(oldSecondaryIndexEntry.Value) {
 			expValue = &oldSecondaryIndexEntry.Value
 		} else {
@@ -453,6 +510,9 @@ func (ru *Updater) UpdateRow(
 	// indexed will be handled separately.
 	if ru.DeleteHelper != nil {
 		for _, deletedSecondaryIndexEntry := range deleteOldSecondaryIndexEntries {
+			if deletedSecondaryIndexEntry.Key == nil {
+				continue
+			}
 			if traceKV {
 				log.VEventf(ctx, 2, "Del %s", deletedSecondaryIndexEntry.Key)
 			}
@@ -121,6 +121,11 @@ type scanNode struct {
 
 	// Indicates if this scan is the source for a delete node.
 	isDeleteSource bool
+
+	// lockingStrength and lockingWaitPolicy represent the row-level locking
+	// mode of the Scan.
+	lockingStrength   sqlbase.ScanLockingStrength
+	lockingWaitPolicy sqlbase.ScanLockingWaitPolicy
 }
 
 // scanVisibility represents which table columns should be included in a scan.
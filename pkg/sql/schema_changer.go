@@ -134,6 +134,10 @@ type SchemaChanger struct {
 	// original schema change job for the sql command, or the
 	// rollback job for the rollback of the schema change.
 	job *jobs.Job
+	// The ID of the mutations queued by the completion of this schema
+	// change, such as the ones dropping a column replaced by ALTER COLUMN
+	// TYPE. They are run by a separate job.
+	cleanupMutationID sqlbase.MutationID
 	// Caches updated by DistSQL.
 	rangeDescriptorCache *kv.RangeDescriptorCache
 	leaseHolderCache     *kv.LeaseHolderCache
@@ -596,6 +600,41 @@ func (sc *SchemaChanger) maybeMakeAddTablePublic(
 	return nil
 }
 
+// maybePromoteEnumMembers makes the enum values that ALTER TYPE ... ADD VALUE
+// added to the column types of the table writable. Publish waits until every
+// node uses the table version that introduced the values, so that the values
+// can be decoded everywhere before they are written. The values become
+// writable in the type descriptors too once no table that uses the types has
+// read-only values left.
+func (sc *SchemaChanger) maybePromoteEnumMembers(
+	ctx context.Context, table *sqlbase.TableDescriptor,
+) error {
+	if table.Dropped() || !tableHasReadOnlyEnumMembers(table) {
+		return nil
+	}
+	var typeIDs []sqlbase.ID
+	_, err := sc.leaseMgr.Publish(
+		ctx,
+		table.ID,
+		func(tbl *sqlbase.MutableTableDescriptor) error {
+			typeIDs = promoteEnumColumnTypes(tbl.TableDesc())
+			if len(typeIDs) == 0 {
+				return errDidntUpdateDescriptor
+			}
+			return nil
+		},
+		func(txn *client.Txn) error {
+			for _, id := range typeIDs {
+				if err := maybePromoteEnumType(ctx, txn, id); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+	return err
+}
+
 func (sc *SchemaChanger) maybeGCMutations(
 	ctx context.Context, inSession bool, table *sqlbase.TableDescriptor,
 ) error {
@@ -825,6 +864,10 @@ func (sc *SchemaChanger) exec(
 		return err
 	}
 
+	if err := sc.maybePromoteEnumMembers(ctx, tableDesc); err != nil {
+		return err
+	}
+
 	// Wait for the schema change to propagate to all nodes after this function
 	// returns, so that the new schema is live everywhere. This is not needed for
 	// correctness but is done to make the UI experience/tests predictable.
@@ -1069,12 +1112,17 @@ func (sc *SchemaChanger) waitToUpdateLeases(ctx context.Context, tableID sqlbase
 func (sc *SchemaChanger) done(ctx context.Context) (*sqlbase.ImmutableTableDescriptor, error) {
 	isRollback := false
 	jobSucceeded := true
+	cleanupMutationID := sqlbase.InvalidMutationID
 	now := timeutil.Now().UnixNano()
-	return sc.leaseMgr.Publish(ctx, sc.tableID, func(desc *sqlbase.MutableTableDescriptor) error {
+	tableDesc, err := sc.leaseMgr.Publish(ctx, sc.tableID, func(desc *sqlbase.MutableTableDescriptor) error {
 		// Reset vars here because update function can be called multiple times in a retry.
 		isRollback = false
 		jobSucceeded = true
+		cleanupMutationID = sqlbase.InvalidMutationID
 
+		// Completing a column type swap queues the mutations dropping the
+		// replaced column and indexes at the end of the list.
+		numMutations := len(desc.Mutations)
 		i := 0
 		for _, mutation := range desc.Mutations {
 			if mutation.MutationID != sc.mutationID {
@@ -1106,6 +1154,9 @@ func (sc *SchemaChanger) done(ctx context.Context) (*sqlbase.ImmutableTableDescr
 			// the version.
 			return errDidntUpdateDescriptor
 		}
+		if len(desc.Mutations) > numMutations {
+			cleanupMutationID = desc.Mutations[numMutations].MutationID
+		}
 		// Trim the executed mutations from the descriptor.
 		desc.Mutations = desc.Mutations[i:]
 
@@ -1132,6 +1183,12 @@ func (sc *SchemaChanger) done(ctx context.Context) (*sqlbase.ImmutableTableDescr
 			}
 		}
 
+		if cleanupMutationID != sqlbase.InvalidMutationID {
+			if err := sc.createCleanupJob(ctx, txn, cleanupMutationID); err != nil {
+				return err
+			}
+		}
+
 		schemaChangeEventType := EventLogFinishSchemaChange
 		if isRollback {
 			schemaChangeEventType = EventLogFinishSchemaRollback
@@ -1152,6 +1209,11 @@ func (sc *SchemaChanger) done(ctx context.Context) (*sqlbase.ImmutableTableDescr
 			}{uint32(sc.mutationID)},
 		)
 	})
+	if err != nil {
+		return nil, err
+	}
+	sc.cleanupMutationID = cleanupMutationID
+	return tableDesc, nil
 }
 
 // notFirstInLine returns true whenever the schema change has been queued
@@ -1436,11 +1498,58 @@ func (sc *SchemaChanger) createRollbackJob(
 	return nil, pgerror.AssertionFailedf("no job found for table %d mutation %d", log.Safe(sc.tableID), log.Safe(sc.mutationID))
 }
 
+// createCleanupJob creates the job running the mutations queued by the
+// completion of this schema change, with the given mutation ID.
+func (sc *SchemaChanger) createCleanupJob(
+	ctx context.Context, txn *client.Txn, mutationID sqlbase.MutationID,
+) error {
+	// Read the table descriptor from the store. The Version of the
+	// descriptor has already been incremented in the transaction and
+	// this descriptor can be modified without incrementing the version.
+	tableDesc, err := sqlbase.GetTableDescFromID(ctx, txn, sc.tableID)
+	if err != nil {
+		return err
+	}
+
+	// Initialize refresh spans to scan the entire table.
+	span := tableDesc.PrimaryIndexSpan()
+	var spanList []jobspb.ResumeSpanList
+	for _, m := range tableDesc.Mutations {
+		if m.MutationID == mutationID {
+			spanList = append(spanList,
+				jobspb.ResumeSpanList{
+					ResumeSpans: []roachpb.Span{span},
+				},
+			)
+		}
+	}
+	payload := sc.job.Payload()
+	cleanupJob := sc.jobRegistry.NewJob(jobs.Record{
+		Description:   fmt.Sprintf("CLEANUP JOB for %s", payload.Description),
+		Username:      payload.Username,
+		DescriptorIDs: payload.DescriptorIDs,
+		Details:       jobspb.SchemaChangeDetails{ResumeSpanList: spanList},
+		Progress:      jobspb.SchemaChangeProgress{},
+	})
+	if err := cleanupJob.WithTxn(txn).Created(ctx); err != nil {
+		return err
+	}
+	tableDesc.MutationJobs = append(tableDesc.MutationJobs, sqlbase.TableDescriptor_MutationJob{
+		MutationID: mutationID, JobID: *cleanupJob.ID()})
+
+	// write descriptor, the version has already been incremented.
+	descKey := sqlbase.MakeDescMetadataKey(tableDesc.GetID())
+	descVal := sqlbase.WrapDescriptor(tableDesc)
+	b := txn.NewBatch()
+	b.Put(descKey, descVal)
+	return txn.Run(ctx, b)
+}
+
 func (sc *SchemaChanger) maybeDropValidatingConstraint(
 	ctx context.Context, desc *MutableTableDescriptor, constraint *sqlbase.ConstraintToUpdate,
 ) error {
 	switch constraint.ConstraintType {
-	case sqlbase.ConstraintToUpdate_CHECK:
+	case sqlbase.ConstraintToUpdate_CHECK, sqlbase.ConstraintToUpdate_NOT_NULL:
 		for j, c := range desc.Checks {
 			if c.Name == constraint.Name {
 				desc.Checks = append(desc.Checks[:j], desc.Checks[j+1:]...)
@@ -1911,7 +2020,8 @@ func (s *SchemaChangeManager) Start(stopper *stop.Stopper) {
 
 						// Keep track of outstanding schema changes.
 						pendingChanges := table.Adding() ||
-							table.HasDrainingNames() || len(table.Mutations) > 0
+							table.HasDrainingNames() || len(table.Mutations) > 0 ||
+							tableHasReadOnlyEnumMembers(table)
 						if pendingChanges {
 							if log.V(2) {
 								log.Infof(ctx, "%s: queue up pending schema change; table: %d, version: %d",
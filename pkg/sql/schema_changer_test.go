@@ -4781,3 +4781,52 @@ INSERT INTO t.test (k, v) VALUES (1, 99), (2, 100);
 		t.Fatal(err)
 	}
 }
+
+// TestSetNotNullValidation tests that the check constraint enforcing NOT NULL
+// on a column while the column is validated is hidden from users.
+func TestSetNotNullValidation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	params, _ := tests.CreateTestServerParams()
+	var runBeforeChecksValidation func() error
+	params.Knobs = base.TestingKnobs{
+		SQLSchemaChanger: &sql.SchemaChangerTestingKnobs{
+			RunBeforeChecksValidation: func() error {
+				if runBeforeChecksValidation == nil {
+					return nil
+				}
+				return runBeforeChecksValidation()
+			},
+		},
+	}
+	s, sqlDB, _ := serverutils.StartServer(t, params)
+	defer s.Stopper().Stop(context.TODO())
+	if _, err := sqlDB.Exec(`
+CREATE DATABASE t;
+CREATE TABLE t.test (k INT PRIMARY KEY, v INT);
+INSERT INTO t.test (k, v) VALUES (1, 1);
+`); err != nil {
+		t.Fatal(err)
+	}
+
+	runBeforeChecksValidation = func() error {
+		// Writes are rejected as for a NOT NULL column.
+		_, err := sqlDB.Exec(`INSERT INTO t.test (k, v) VALUES (2, NULL)`)
+		if !testutils.IsError(err, `null value in column "v" violates not-null constraint`) {
+			return errors.Errorf("unexpected error: %v", err)
+		}
+		var count int
+		if err := sqlDB.QueryRow(
+			`SELECT count(*) FROM [SHOW CONSTRAINTS FROM t.test] WHERE constraint_type = 'CHECK'`,
+		).Scan(&count); err != nil {
+			return err
+		}
+		if count != 0 {
+			return errors.Errorf("expected no CHECK constraints, found %d", count)
+		}
+		return nil
+	}
+
+	if _, err := sqlDB.Exec(`ALTER TABLE t.test ALTER COLUMN v SET NOT NULL`); err != nil {
+		t.Fatal(err)
+	}
+}
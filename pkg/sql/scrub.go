@@ -426,8 +426,12 @@ func createIndexCheckOperations(
 ) (results []checkOperation, err error) {
 	if indexNames == nil {
 		// Populate results with all secondary indexes of the
-		// table.
+		// table. Partial indexes are skipped, since they don't
+		// contain all the rows of the table.
 		for i := range tableDesc.Indexes {
+			if tableDesc.Indexes[i].IsPartial() {
+				continue
+			}
 			results = append(results, newIndexCheckOperation(
 				tableName,
 				tableDesc,
@@ -445,6 +449,10 @@ func createIndexCheckOperations(
 	}
 	for i := range tableDesc.Indexes {
 		if _, ok := names[tableDesc.Indexes[i].Name]; ok {
+			if tableDesc.Indexes[i].IsPartial() {
+				return nil, pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+					"cannot check partial index %q", tableDesc.Indexes[i].Name)
+			}
 			results = append(results, newIndexCheckOperation(
 				tableName,
 				tableDesc,
@@ -1690,6 +1690,20 @@ CockroachDB supports the following flags:
 		},
 	),
 
+	"current_time": makeBuiltin(
+		tree.FunctionProperties{Category: categoryDateAndTime, Impure: true},
+		tree.Overload{
+			Types:      tree.ArgTypes{},
+			ReturnType: tree.FixedReturnType(types.TimeTZ),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				t := ctx.GetTxnTimestamp(time.Microsecond).Time
+				return tree.NewDTimeTZFromTime(t.In(ctx.GetLocation())), nil
+			},
+			Info: "Returns the time of day of the current transaction, with the " +
+				"time zone of the session." + txnTSContextDoc,
+		},
+	),
+
 	"now":                   txnTSImpl,
 	"current_timestamp":     txnTSImpl,
 	"transaction_timestamp": txnTSImpl,
@@ -2391,9 +2405,7 @@ may increase either contention or retry errors, or both.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLength(arr, dimen), nil
 			},
-			Info: "Calculates the length of `input` on the provided `array_dimension`. However, " +
-				"because CockroachDB doesn't yet support multi-dimensional arrays, the only supported" +
-				" `array_dimension` is **1**.",
+			Info: "Calculates the length of `input` on the provided `array_dimension`.",
 		},
 	),
 
@@ -2406,9 +2418,7 @@ may increase either contention or retry errors, or both.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLower(arr, dimen), nil
 			},
-			Info: "Calculates the minimum value of `input` on the provided `array_dimension`. " +
-				"However, because CockroachDB doesn't yet support multi-dimensional arrays, the only " +
-				"supported `array_dimension` is **1**.",
+			Info: "Calculates the minimum value of `input` on the provided `array_dimension`.",
 		},
 	),
 
@@ -2421,9 +2431,60 @@ may increase either contention or retry errors, or both.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLength(arr, dimen), nil
 			},
-			Info: "Calculates the maximum value of `input` on the provided `array_dimension`. " +
-				"However, because CockroachDB doesn't yet support multi-dimensional arrays, the only " +
-				"supported `array_dimension` is **1**.",
+			Info: "Calculates the maximum value of `input` on the provided `array_dimension`.",
+		},
+	),
+
+	"array_ndims": makeBuiltin(arrayProps(),
+		tree.Overload{
+			Types:      tree.ArgTypes{{"input", types.AnyArray}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				dims := arrayDimensions(tree.MustBeDArray(args[0]))
+				if dims == nil {
+					return tree.DNull, nil
+				}
+				return tree.NewDInt(tree.DInt(len(dims))), nil
+			},
+			Info: "Returns the number of dimensions of `input`.",
+		},
+	),
+
+	"array_dims": makeBuiltin(arrayProps(),
+		tree.Overload{
+			Types:      tree.ArgTypes{{"input", types.AnyArray}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				dims := arrayDimensions(tree.MustBeDArray(args[0]))
+				if dims == nil {
+					return tree.DNull, nil
+				}
+				var buf bytes.Buffer
+				for _, dim := range dims {
+					fmt.Fprintf(&buf, "[1:%d]", dim)
+				}
+				return tree.NewDString(buf.String()), nil
+			},
+			Info: "Returns a text representation of the dimensions of `input`.",
+		},
+	),
+
+	"cardinality": makeBuiltin(arrayProps(),
+		tree.Overload{
+			Types:      tree.ArgTypes{{"input", types.AnyArray}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				dims := arrayDimensions(tree.MustBeDArray(args[0]))
+				n := 0
+				if dims != nil {
+					n = 1
+					for _, dim := range dims {
+						n *= dim
+					}
+				}
+				return tree.NewDInt(tree.DInt(n)), nil
+			},
+			Info: "Returns the total number of elements in `input`, or 0 if it is empty.",
 		},
 	),
 
@@ -4192,6 +4253,23 @@ func arrayLength(arr *tree.DArray, dim int64) tree.Datum {
 	return arrayLength(a, dim-1)
 }
 
+// arrayDimensions returns the length of each dimension of the given array, or
+// nil if the array is empty.
+func arrayDimensions(arr *tree.DArray) []int {
+	var dims []int
+	for {
+		if arr.Len() == 0 {
+			return nil
+		}
+		dims = append(dims, arr.Len())
+		a, ok := tree.AsDArray(arr.Array[0])
+		if !ok {
+			return dims
+		}
+		arr = a
+	}
+}
+
 var intOne = tree.NewDInt(tree.DInt(1))
 
 func arrayLower(arr *tree.DArray, dim int64) tree.Datum {
@@ -4510,7 +4588,7 @@ func asJSONBuildObjectKey(d tree.Datum) (string, error) {
 		return t.Contents, nil
 	case *tree.DBool, *tree.DInt, *tree.DFloat, *tree.DDecimal, *tree.DTimestamp, *tree.DTimestampTZ,
 		*tree.DDate, *tree.DUuid, *tree.DInterval, *tree.DBytes, *tree.DIPAddr, *tree.DOid,
-		*tree.DTime, *tree.DBitArray:
+		*tree.DTime, *tree.DTimeTZ, *tree.DBitArray:
 		return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
 	default:
 		return "", pgerror.AssertionFailedf("unexpected type %T for key value", d)
@@ -37,7 +37,9 @@ func (v *IsAggregateVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tre
 		}
 		fd, err := t.Func.Resolve(v.searchPath)
 		if err != nil {
-			return false, expr
+			// The name may refer to a user-defined function, which is never an
+			// aggregate function but can have aggregate arguments.
+			return true, expr
 		}
 		if fd.Class == tree.AggregateClass {
 			v.Aggregated = true
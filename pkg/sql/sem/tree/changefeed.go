@@ -17,6 +17,12 @@ type CreateChangefeed struct {
 	Targets TargetList
 	SinkURI Expr
 	Options KVOptions
+
+	// Projection and Filter are set by the `CHANGEFEED ... AS SELECT <proj>
+	// FROM <table> [WHERE <filter>]` form, in which case Targets holds the
+	// single table. Filter is nil if there is no WHERE clause.
+	Projection SelectExprs
+	Filter     *Where
 }
 
 var _ Statement = &CreateChangefeed{}
@@ -30,8 +36,12 @@ func (node *CreateChangefeed) Format(ctx *FmtCtx) {
 		// prefix. They're also still EXPERIMENTAL, so they get marked as such.
 		ctx.WriteString("EXPERIMENTAL ")
 	}
-	ctx.WriteString("CHANGEFEED FOR ")
-	ctx.FormatNode(&node.Targets)
+	if node.Projection != nil {
+		ctx.WriteString("CHANGEFEED")
+	} else {
+		ctx.WriteString("CHANGEFEED FOR ")
+		ctx.FormatNode(&node.Targets)
+	}
 	if node.SinkURI != nil {
 		ctx.WriteString(" INTO ")
 		ctx.FormatNode(node.SinkURI)
@@ -40,4 +50,26 @@ func (node *CreateChangefeed) Format(ctx *FmtCtx) {
 		ctx.WriteString(" WITH ")
 		ctx.FormatNode(&node.Options)
 	}
+	if node.Projection != nil {
+		ctx.WriteString(" AS ")
+		ctx.FormatNode(node.Select())
+	}
+}
+
+// Select returns the `SELECT <proj> FROM <table> [WHERE <filter>]` clause of a
+// changefeed with a Projection.
+func (node *CreateChangefeed) Select() *SelectClause {
+	var from From
+	for _, t := range node.Targets.Tables {
+		if p, err := t.NormalizeTablePattern(); err == nil {
+			if tn, ok := p.(*TableName); ok {
+				from.Tables = append(from.Tables, tn)
+			}
+		}
+	}
+	return &SelectClause{
+		Exprs: node.Projection,
+		From:  &from,
+		Where: node.Filter,
+	}
 }
@@ -59,6 +59,9 @@ func isConstant(expr Expr) bool {
 
 func typeCheckConstant(c Constant, ctx *SemaContext, desired *types.T) (ret TypedExpr, err error) {
 	avail := c.AvailableTypes()
+	if desired.Family() == types.EnumFamily && !desired.IsAmbiguous() && canConstantBecome(c, desired) {
+		return c.ResolveAsType(ctx, desired)
+	}
 	if desired.Family() != types.AnyFamily {
 		for _, typ := range avail {
 			if desired.Equivalent(typ) {
@@ -94,6 +97,11 @@ func naturalConstantType(c Constant) *types.T {
 // canConstantBecome returns whether the provided Constant can become resolved
 // as the provided type.
 func canConstantBecome(c Constant, typ *types.T) bool {
+	// String literals can become values of any enum type. They are not listed
+	// in StrValAvailAllParsable because enum types are not known statically.
+	if s, ok := c.(*StrVal); ok && !s.scannedAsBytes && typ.Family() == types.EnumFamily {
+		return true
+	}
 	avail := c.AvailableTypes()
 	for _, availTyp := range avail {
 		if availTyp.Equivalent(typ) {
@@ -406,6 +414,7 @@ var (
 		types.Decimal,
 		types.Date,
 		types.Time,
+		types.TimeTZ,
 		types.Timestamp,
 		types.TimestampTZ,
 		types.Interval,
@@ -108,6 +108,8 @@ type CreateIndex struct {
 	Storing     NameList
 	Interleave  *InterleaveDef
 	PartitionBy *PartitionBy
+	// Predicate, if set, restricts the index to the rows that satisfy it.
+	Predicate Expr
 }
 
 // Format implements the NodeFormatter interface.
@@ -143,6 +145,10 @@ func (node *CreateIndex) Format(ctx *FmtCtx) {
 	if node.PartitionBy != nil {
 		ctx.FormatNode(node.PartitionBy)
 	}
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
 }
 
 // TableDef represents a column, index or constraint definition within a CREATE
@@ -160,6 +166,7 @@ type TableDef interface {
 func (*ColumnTableDef) tableDef() {}
 func (*IndexTableDef) tableDef()  {}
 func (*FamilyTableDef) tableDef() {}
+func (*LikeTableDef) tableDef()   {}
 
 // TableDefs represents a list of table definitions.
 type TableDefs []TableDef
@@ -213,6 +220,12 @@ type ColumnTableDef struct {
 	Computed struct {
 		Computed bool
 		Expr     Expr
+		Virtual  bool
+	}
+	Identity struct {
+		Identity   bool
+		Always     bool
+		SeqOptions SequenceOptions
 	}
 	Family struct {
 		Name        Name
@@ -274,6 +287,10 @@ func NewColumnTableDef(
 				return nil, pgerror.Newf(pgerror.CodeSyntaxError,
 					"multiple default values specified for column %q", name)
 			}
+			if d.IsIdentity() {
+				return nil, pgerror.Newf(pgerror.CodeSyntaxError,
+					"both default and identity specified for column %q", name)
+			}
 			d.DefaultExpr.Expr = t.Expr
 			d.DefaultExpr.ConstraintName = c.Name
 		case NotNullConstraint:
@@ -312,8 +329,28 @@ func NewColumnTableDef(
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
 		case *ColumnComputedDef:
+			if d.IsIdentity() {
+				return nil, pgerror.Newf(pgerror.CodeSyntaxError,
+					"both identity and generation expression specified for column %q", name)
+			}
 			d.Computed.Computed = true
 			d.Computed.Expr = t.Expr
+			d.Computed.Virtual = t.Virtual
+		case *ColumnIdentityDef:
+			switch {
+			case d.IsIdentity():
+				return nil, pgerror.Newf(pgerror.CodeSyntaxError,
+					"multiple identity specifications for column %q", name)
+			case d.HasDefaultExpr() || isSerial:
+				return nil, pgerror.Newf(pgerror.CodeSyntaxError,
+					"both default and identity specified for column %q", name)
+			case d.IsComputed():
+				return nil, pgerror.Newf(pgerror.CodeSyntaxError,
+					"both identity and generation expression specified for column %q", name)
+			}
+			d.Identity.Identity = true
+			d.Identity.Always = t.Always
+			d.Identity.SeqOptions = t.SeqOptions
 		case *ColumnFamilyConstraint:
 			if d.HasColumnFamily() {
 				return nil, pgerror.Newf(pgerror.CodeInvalidTableDefinitionError,
@@ -349,6 +386,16 @@ func (node *ColumnTableDef) IsComputed() bool {
 	return node.Computed.Computed
 }
 
+// IsVirtual returns if the ColumnTableDef is a virtual computed column.
+func (node *ColumnTableDef) IsVirtual() bool {
+	return node.Computed.Virtual
+}
+
+// IsIdentity returns if the ColumnTableDef is an identity column.
+func (node *ColumnTableDef) IsIdentity() bool {
+	return node.Identity.Identity
+}
+
 // HasColumnFamily returns if the ColumnTableDef has a column family.
 func (node *ColumnTableDef) HasColumnFamily() bool {
 	return node.Family.Name != "" || node.Family.Create
@@ -388,6 +435,9 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 		ctx.WriteString(" DEFAULT ")
 		ctx.FormatNode(node.DefaultExpr.Expr)
 	}
+	if node.IsIdentity() {
+		formatIdentity(ctx, node.Identity.Always, node.Identity.SeqOptions)
+	}
 	for _, checkExpr := range node.CheckExprs {
 		if checkExpr.ConstraintName != "" {
 			ctx.WriteString(" CONSTRAINT ")
@@ -418,7 +468,11 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
 		ctx.FormatNode(node.Computed.Expr)
-		ctx.WriteString(") STORED")
+		if node.IsVirtual() {
+			ctx.WriteString(") VIRTUAL")
+		} else {
+			ctx.WriteString(") STORED")
+		}
 	}
 	if node.HasColumnFamily() {
 		if node.Family.Create {
@@ -449,6 +503,24 @@ func (node *ColumnTableDef) columnTypeString() string {
 	return node.Type.SQLString()
 }
 
+func formatIdentity(ctx *FmtCtx, always bool, seqOptions SequenceOptions) {
+	if always {
+		ctx.WriteString(" GENERATED ALWAYS AS IDENTITY")
+	} else {
+		ctx.WriteString(" GENERATED BY DEFAULT AS IDENTITY")
+	}
+	if len(seqOptions) > 0 {
+		ctx.WriteString(" (")
+		for i := range seqOptions {
+			if i > 0 {
+				ctx.WriteByte(' ')
+			}
+			ctx.FormatNode(&seqOptions[i])
+		}
+		ctx.WriteByte(')')
+	}
+}
+
 // String implements the fmt.Stringer interface.
 func (node *ColumnTableDef) String() string { return AsString(node) }
 
@@ -473,6 +545,7 @@ func (*ColumnCheckConstraint) columnQualification()  {}
 func (*ColumnComputedDef) columnQualification()      {}
 func (*ColumnFKConstraint) columnQualification()     {}
 func (*ColumnFamilyConstraint) columnQualification() {}
+func (*ColumnIdentityDef) columnQualification()      {}
 
 // ColumnCollation represents a COLLATE clause for a column.
 type ColumnCollation string
@@ -509,7 +582,15 @@ type ColumnFKConstraint struct {
 
 // ColumnComputedDef represents the description of a computed column.
 type ColumnComputedDef struct {
-	Expr Expr
+	Expr    Expr
+	Virtual bool
+}
+
+// ColumnIdentityDef represents GENERATED {ALWAYS | BY DEFAULT} AS IDENTITY on a
+// column.
+type ColumnIdentityDef struct {
+	Always     bool
+	SeqOptions SequenceOptions
 }
 
 // ColumnFamilyConstraint represents FAMILY on a column.
@@ -528,6 +609,8 @@ type IndexTableDef struct {
 	Interleave  *InterleaveDef
 	Inverted    bool
 	PartitionBy *PartitionBy
+	// Predicate, if set, restricts the index to the rows that satisfy it.
+	Predicate Expr
 }
 
 // SetName implements the TableDef interface.
@@ -559,6 +642,10 @@ func (node *IndexTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionBy != nil {
 		ctx.FormatNode(node.PartitionBy)
 	}
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
 }
 
 // ConstraintTableDef represents a constraint definition within a CREATE TABLE
@@ -576,11 +663,18 @@ func (*UniqueConstraintTableDef) constraintTableDef() {}
 // TABLE statement.
 type UniqueConstraintTableDef struct {
 	IndexTableDef
-	PrimaryKey bool
+	PrimaryKey    bool
+	Deferrability ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
 func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Predicate != nil {
+		// Partial unique indexes can only be defined with the index syntax.
+		ctx.WriteString("UNIQUE ")
+		ctx.FormatNode(&node.IndexTableDef)
+		return
+	}
 	if node.Name != "" {
 		ctx.WriteString("CONSTRAINT ")
 		ctx.FormatNode(&node.Name)
@@ -605,6 +699,36 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionBy != nil {
 		ctx.FormatNode(node.PartitionBy)
 	}
+	ctx.FormatNode(node.Deferrability)
+}
+
+// ConstraintDeferrability describes whether the validation of a constraint can
+// be deferred to the end of the transaction, and whether it is by default.
+type ConstraintDeferrability int
+
+// The values for ConstraintDeferrability.
+const (
+	NotDeferrable ConstraintDeferrability = iota
+	DeferrableInitiallyImmediate
+	DeferrableInitiallyDeferred
+)
+
+var constraintDeferrabilityName = [...]string{
+	NotDeferrable:                "NOT DEFERRABLE",
+	DeferrableInitiallyImmediate: "DEFERRABLE INITIALLY IMMEDIATE",
+	DeferrableInitiallyDeferred:  "DEFERRABLE INITIALLY DEFERRED",
+}
+
+func (d ConstraintDeferrability) String() string {
+	return constraintDeferrabilityName[d]
+}
+
+// Format implements the NodeFormatter interface.
+func (d ConstraintDeferrability) Format(ctx *FmtCtx) {
+	if d != NotDeferrable {
+		ctx.WriteByte(' ')
+		ctx.WriteString(d.String())
+	}
 }
 
 // ReferenceAction is the method used to maintain referential integrity through
@@ -682,6 +806,8 @@ type ForeignKeyConstraintTableDef struct {
 	ToCols   NameList
 	Actions  ReferenceActions
 	Match    CompositeKeyMatchMethod
+
+	Deferrability ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
@@ -709,6 +835,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(node.Deferrability)
 }
 
 // SetName implements the TableDef interface.
@@ -770,6 +897,85 @@ func (node *FamilyTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// LikeTableDef represents a LIKE clause within a CREATE TABLE statement, which
+// copies the definitions of the columns of another table and, depending on its
+// options, its default values, constraints, indexes and computed columns.
+type LikeTableDef struct {
+	Name    TableName
+	Options []LikeTableOption
+}
+
+// LikeTableOption represents an INCLUDING or EXCLUDING option of a LIKE clause.
+type LikeTableOption struct {
+	Excluded bool
+	Opt      LikeTableOpt
+}
+
+// LikeTableOpt is a set of the parts of a table that can be copied by a LIKE
+// clause.
+type LikeTableOpt int
+
+// The values for LikeTableOpt.
+const (
+	LikeTableOptConstraints LikeTableOpt = 1 << iota
+	LikeTableOptDefaults
+	LikeTableOptGenerated
+	LikeTableOptIndexes
+
+	likeTableOptInvalid
+)
+
+// LikeTableOptAll is the set of all the parts of a table that can be copied.
+const LikeTableOptAll = likeTableOptInvalid - 1
+
+var likeTableOptName = map[LikeTableOpt]string{
+	LikeTableOptConstraints: "CONSTRAINTS",
+	LikeTableOptDefaults:    "DEFAULTS",
+	LikeTableOptGenerated:   "GENERATED",
+	LikeTableOptIndexes:     "INDEXES",
+	LikeTableOptAll:         "ALL",
+}
+
+func (o LikeTableOpt) String() string {
+	return likeTableOptName[o]
+}
+
+// Has returns true if the set contains all the parts of the given set.
+func (o LikeTableOpt) Has(other LikeTableOpt) bool {
+	return o&other == other
+}
+
+// Opts returns the set of the parts of the table copied by the LIKE clause:
+// nothing by default, then each option in order includes or excludes parts.
+func (node *LikeTableDef) Opts() LikeTableOpt {
+	var opts LikeTableOpt
+	for _, o := range node.Options {
+		if o.Excluded {
+			opts &^= o.Opt
+		} else {
+			opts |= o.Opt
+		}
+	}
+	return opts
+}
+
+// SetName implements the TableDef interface.
+func (node *LikeTableDef) SetName(name Name) {}
+
+// Format implements the NodeFormatter interface.
+func (node *LikeTableDef) Format(ctx *FmtCtx) {
+	ctx.WriteString("LIKE ")
+	ctx.FormatNode(&node.Name)
+	for _, o := range node.Options {
+		if o.Excluded {
+			ctx.WriteString(" EXCLUDING ")
+		} else {
+			ctx.WriteString(" INCLUDING ")
+		}
+		ctx.WriteString(o.Opt.String())
+	}
+}
+
 // InterleaveDef represents an interleave definition within a CREATE TABLE
 // or CREATE INDEX statement.
 type InterleaveDef struct {
@@ -890,6 +1096,7 @@ type CreateTable struct {
 	Table         TableName
 	Interleave    *InterleaveDef
 	PartitionBy   *PartitionBy
+	Temporary     bool
 	Defs          TableDefs
 	AsSource      *Select
 	AsColumnNames NameList // Only to be used in conjunction with AsSource
@@ -903,7 +1110,11 @@ func (node *CreateTable) As() bool {
 
 // Format implements the NodeFormatter interface.
 func (node *CreateTable) Format(ctx *FmtCtx) {
-	ctx.WriteString("CREATE TABLE ")
+	ctx.WriteString("CREATE ")
+	if node.Temporary {
+		ctx.WriteString("TEMPORARY ")
+	}
+	ctx.WriteString("TABLE ")
 	if node.IfNotExists {
 		ctx.WriteString("IF NOT EXISTS ")
 	}
@@ -1009,49 +1220,304 @@ func (node *CreateSequence) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.Options)
 }
 
-// SequenceOptions represents a list of sequence options.
-type SequenceOptions []SequenceOption
+// CreateTypeVariety is the variety of a type created by CREATE TYPE.
+type CreateTypeVariety int
+
+const (
+	// Enum is an enumerated type, created by CREATE TYPE ... AS ENUM.
+	Enum CreateTypeVariety = iota
+	// Composite is a composite (record) type, created by
+	// CREATE TYPE ... AS (...).
+	Composite
+)
+
+// CompositeTypeField is an attribute of a composite type.
+type CompositeTypeField struct {
+	Name Name
+	Type *types.T
+}
+
+// CreateType represents a CREATE TYPE statement.
+type CreateType struct {
+	TypeName TableName
+	Variety  CreateTypeVariety
+	// EnumLabels are the values of the new ENUM type, in declaration order.
+	EnumLabels []string
+	// CompositeFields are the attributes of the new composite type, in
+	// declaration order.
+	CompositeFields []CompositeTypeField
+}
 
 // Format implements the NodeFormatter interface.
-func (node *SequenceOptions) Format(ctx *FmtCtx) {
+func (node *CreateType) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TYPE ")
+	ctx.FormatNode(&node.TypeName)
+	switch node.Variety {
+	case Enum:
+		ctx.WriteString(" AS ENUM (")
+		for i, label := range node.EnumLabels {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			lex.EncodeSQLStringWithFlags(&ctx.Buffer, label, ctx.flags.EncodeFlags())
+		}
+	case Composite:
+		ctx.WriteString(" AS (")
+		for i := range node.CompositeFields {
+			field := &node.CompositeFields[i]
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(&field.Name)
+			ctx.WriteByte(' ')
+			ctx.WriteString(field.Type.SQLString())
+		}
+	}
+	ctx.WriteByte(')')
+}
+
+// CreateFunction represents a CREATE FUNCTION statement.
+type CreateFunction struct {
+	FuncName   TableName
+	Replace    bool
+	Params     FunctionParams
+	ReturnType *types.T
+	ReturnsSet bool
+	Options    FunctionOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateFunction) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("FUNCTION ")
+	ctx.FormatNode(&node.FuncName)
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Params)
+	ctx.WriteString(") RETURNS ")
+	if node.ReturnsSet {
+		ctx.WriteString("SETOF ")
+	}
+	ctx.WriteString(node.ReturnType.SQLString())
+	ctx.FormatNode(&node.Options)
+}
+
+// FunctionParam is a parameter of a function.
+type FunctionParam struct {
+	// Name is empty for unnamed parameters.
+	Name Name
+	Type *types.T
+}
+
+// FunctionParams represents a list of function parameters.
+type FunctionParams []FunctionParam
+
+// Format implements the NodeFormatter interface.
+func (node *FunctionParams) Format(ctx *FmtCtx) {
+	for i := range *node {
+		param := &(*node)[i]
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		if param.Name != "" {
+			ctx.FormatNode(&param.Name)
+			ctx.WriteByte(' ')
+		}
+		ctx.WriteString(param.Type.SQLString())
+	}
+}
+
+// FunctionOptions represents a list of function options.
+type FunctionOptions []FunctionOption
+
+// Format implements the NodeFormatter interface.
+func (node *FunctionOptions) Format(ctx *FmtCtx) {
 	for i := range *node {
 		option := &(*node)[i]
 		ctx.WriteByte(' ')
 		switch option.Name {
-		case SeqOptCycle, SeqOptNoCycle:
-			ctx.WriteString(option.Name)
-		case SeqOptCache:
+		case FuncOptAs:
 			ctx.WriteString(option.Name)
 			ctx.WriteByte(' ')
-			ctx.Printf("%d", *option.IntVal)
-		case SeqOptMaxValue, SeqOptMinValue:
-			if option.IntVal == nil {
-				ctx.WriteString("NO ")
-				ctx.WriteString(option.Name)
-			} else {
-				ctx.WriteString(option.Name)
-				ctx.WriteByte(' ')
-				ctx.Printf("%d", *option.IntVal)
-			}
-		case SeqOptStart:
+			lex.EncodeSQLStringWithFlags(&ctx.Buffer, option.StrVal, ctx.flags.EncodeFlags())
+		case FuncOptLanguage:
 			ctx.WriteString(option.Name)
 			ctx.WriteByte(' ')
-			if option.OptionalWord {
-				ctx.WriteString("WITH ")
-			}
-			ctx.Printf("%d", *option.IntVal)
-		case SeqOptIncrement:
+			ctx.FormatNameP(&option.StrVal)
+		default:
 			ctx.WriteString(option.Name)
+		}
+	}
+}
+
+// FunctionOption represents an option on a CREATE FUNCTION statement.
+type FunctionOption struct {
+	Name string
+
+	// StrVal is the body of the function for FuncOptAs, and the name of the
+	// language for FuncOptLanguage.
+	StrVal string
+}
+
+// Names of options on CREATE FUNCTION.
+const (
+	FuncOptAs                     = "AS"
+	FuncOptLanguage               = "LANGUAGE"
+	FuncOptImmutable              = "IMMUTABLE"
+	FuncOptStable                 = "STABLE"
+	FuncOptVolatile               = "VOLATILE"
+	FuncOptCalledOnNullInput      = "CALLED ON NULL INPUT"
+	FuncOptReturnsNullOnNullInput = "RETURNS NULL ON NULL INPUT"
+	FuncOptStrict                 = "STRICT"
+)
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Name       Name
+	ActionTime TriggerActionTime
+	Events     TriggerEvents
+	Table      TableName
+	ForEachRow bool
+	// When is nil if the trigger has no WHEN clause.
+	When Expr
+	// Body is the definition of the trigger, a list of SQL statements
+	// separated by semicolons.
+	Body string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.ActionTime.String())
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Events)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(&node.Table)
+	if node.ForEachRow {
+		ctx.WriteString(" FOR EACH ROW")
+	} else {
+		ctx.WriteString(" FOR EACH STATEMENT")
+	}
+	if node.When != nil {
+		ctx.WriteString(" WHEN (")
+		ctx.FormatNode(node.When)
+		ctx.WriteByte(')')
+	}
+	ctx.WriteString(" AS ")
+	lex.EncodeSQLStringWithFlags(&ctx.Buffer, node.Body, ctx.flags.EncodeFlags())
+}
+
+// TriggerActionTime specifies whether a trigger fires before or after the
+// triggering event.
+type TriggerActionTime int
+
+// The values of TriggerActionTime.
+const (
+	TriggerBefore TriggerActionTime = iota
+	TriggerAfter
+)
+
+var triggerActionTimeName = [...]string{
+	TriggerBefore: "BEFORE",
+	TriggerAfter:  "AFTER",
+}
+
+func (t TriggerActionTime) String() string {
+	return triggerActionTimeName[t]
+}
+
+// TriggerEvent is an event that fires a trigger.
+type TriggerEvent int
+
+// The values of TriggerEvent.
+const (
+	TriggerInsert TriggerEvent = iota
+	TriggerUpdate
+	TriggerDelete
+)
+
+var triggerEventName = [...]string{
+	TriggerInsert: "INSERT",
+	TriggerUpdate: "UPDATE",
+	TriggerDelete: "DELETE",
+}
+
+func (e TriggerEvent) String() string {
+	return triggerEventName[e]
+}
+
+// TriggerEvents represents the list of events of a CREATE TRIGGER statement.
+type TriggerEvents []TriggerEvent
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerEvents) Format(ctx *FmtCtx) {
+	for i, e := range *node {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.WriteString(e.String())
+	}
+}
+
+// SequenceOptions represents a list of sequence options.
+type SequenceOptions []SequenceOption
+
+// Format implements the NodeFormatter interface.
+func (node *SequenceOptions) Format(ctx *FmtCtx) {
+	for i := range *node {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&(*node)[i])
+	}
+}
+
+// Format implements the NodeFormatter interface.
+func (node *SequenceOption) Format(ctx *FmtCtx) {
+	switch node.Name {
+	case SeqOptCycle, SeqOptNoCycle:
+		ctx.WriteString(node.Name)
+	case SeqOptCache:
+		ctx.WriteString(node.Name)
+		ctx.WriteByte(' ')
+		ctx.Printf("%d", *node.IntVal)
+	case SeqOptMaxValue, SeqOptMinValue:
+		if node.IntVal == nil {
+			ctx.WriteString("NO ")
+			ctx.WriteString(node.Name)
+		} else {
+			ctx.WriteString(node.Name)
 			ctx.WriteByte(' ')
-			if option.OptionalWord {
-				ctx.WriteString("BY ")
-			}
-			ctx.Printf("%d", *option.IntVal)
-		case SeqOptVirtual:
-			ctx.WriteString(option.Name)
-		default:
-			panic(pgerror.AssertionFailedf("unexpected SequenceOption: %v", option))
+			ctx.Printf("%d", *node.IntVal)
+		}
+	case SeqOptStart:
+		ctx.WriteString(node.Name)
+		ctx.WriteByte(' ')
+		if node.OptionalWord {
+			ctx.WriteString("WITH ")
+		}
+		ctx.Printf("%d", *node.IntVal)
+	case SeqOptIncrement:
+		ctx.WriteString(node.Name)
+		ctx.WriteByte(' ')
+		if node.OptionalWord {
+			ctx.WriteString("BY ")
 		}
+		ctx.Printf("%d", *node.IntVal)
+	case SeqOptOwnedBy:
+		ctx.WriteString(node.Name)
+		ctx.WriteByte(' ')
+		if node.ColumnItemVal == nil {
+			ctx.WriteString("NONE")
+		} else {
+			ctx.FormatNode(node.ColumnItemVal)
+		}
+	case SeqOptVirtual:
+		ctx.WriteString(node.Name)
+	default:
+		panic(pgerror.AssertionFailedf("unexpected SequenceOption: %v", node))
 	}
 }
 
@@ -1062,6 +1528,10 @@ type SequenceOption struct {
 	IntVal *int64
 
 	OptionalWord bool
+
+	// ColumnItemVal is the owner of the sequence for OWNED BY. It is nil for
+	// OWNED BY NONE.
+	ColumnItemVal *ColumnItem
 }
 
 // Names of options on CREATE SEQUENCE.
@@ -1079,7 +1549,6 @@ const (
 
 	// Avoid unused warning for constants.
 	_ = SeqOptAs
-	_ = SeqOptOwnedBy
 )
 
 // CreateUser represents a CREATE USER statement.
@@ -1150,14 +1619,23 @@ func (node *CreateRole) Format(ctx *FmtCtx) {
 
 // CreateView represents a CREATE VIEW statement.
 type CreateView struct {
-	Name        TableName
-	ColumnNames NameList
-	AsSource    *Select
+	Name         TableName
+	ColumnNames  NameList
+	AsSource     *Select
+	Temporary    bool
+	Materialized bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *CreateView) Format(ctx *FmtCtx) {
-	ctx.WriteString("CREATE VIEW ")
+	ctx.WriteString("CREATE ")
+	if node.Temporary {
+		ctx.WriteString("TEMPORARY ")
+	}
+	if node.Materialized {
+		ctx.WriteString("MATERIALIZED ")
+	}
+	ctx.WriteString("VIEW ")
 	ctx.FormatNode(&node.Name)
 
 	if len(node.ColumnNames) > 0 {
@@ -1171,6 +1649,21 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.AsSource)
 }
 
+// RefreshMaterializedView represents a REFRESH MATERIALIZED VIEW statement.
+type RefreshMaterializedView struct {
+	Name         TableName
+	Concurrently bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *RefreshMaterializedView) Format(ctx *FmtCtx) {
+	ctx.WriteString("REFRESH MATERIALIZED VIEW ")
+	if node.Concurrently {
+		ctx.WriteString("CONCURRENTLY ")
+	}
+	ctx.FormatNode(&node.Name)
+}
+
 // CreateStats represents a CREATE STATISTICS statement.
 type CreateStats struct {
 	Name        Name
@@ -38,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/stringencoding"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
@@ -1461,6 +1462,161 @@ func (d *DUuid) Size() uintptr {
 	return unsafe.Sizeof(*d)
 }
 
+// DEnum is the Datum for a value of an enum type. The struct members are
+// intended to be immutable.
+type DEnum struct {
+	// EnumTyp is the enum type that the value belongs to.
+	EnumTyp *types.T
+	// PhysicalRep is the encoding of the value in keys and values. Values of
+	// the same enum type sort by their physical representations.
+	PhysicalRep []byte
+	// LogicalRep is the label of the value.
+	LogicalRep string
+}
+
+// MakeDEnumFromPhysicalRepresentation returns the value of the given enum
+// type that has the given physical representation.
+func MakeDEnumFromPhysicalRepresentation(typ *types.T, rep []byte) (*DEnum, error) {
+	for i, physical := range typ.EnumPhysicalRepresentations() {
+		if bytes.Equal(physical, rep) {
+			return &DEnum{
+				EnumTyp:     typ,
+				PhysicalRep: physical,
+				LogicalRep:  typ.EnumLogicalRepresentations()[i],
+			}, nil
+		}
+	}
+	return nil, pgerror.AssertionFailedf(
+		"could not find %v in enum type %s", rep, log.Safe(typ.Name()))
+}
+
+// MakeDEnumFromLogicalRepresentation returns the value of the given enum type
+// that has the given label. It returns an error if the type has no such value,
+// or if the value cannot be written yet.
+func MakeDEnumFromLogicalRepresentation(typ *types.T, rep string) (*DEnum, error) {
+	for i, logical := range typ.EnumLogicalRepresentations() {
+		if logical == rep {
+			if typ.EnumData().IsMemberReadOnly[i] {
+				return nil, pgerror.Newf(pgerror.CodeUnsafeNewEnumValueUsageError,
+					"unsafe use of new value %q of enum type %s", rep, typ.SQLString())
+			}
+			return &DEnum{
+				EnumTyp:     typ,
+				PhysicalRep: typ.EnumPhysicalRepresentations()[i],
+				LogicalRep:  logical,
+			}, nil
+		}
+	}
+	return nil, pgerror.Newf(pgerror.CodeInvalidTextRepresentationError,
+		"invalid input value for enum %s: %q", typ.SQLString(), rep)
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DEnum) ResolvedType() *types.T {
+	return d.EnumTyp
+}
+
+// Compare implements the Datum interface.
+func (d *DEnum) Compare(ctx *EvalContext, other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := UnwrapDatum(ctx, other).(*DEnum)
+	if !ok || v.EnumTyp.TypeID() != d.EnumTyp.TypeID() {
+		panic(makeUnsupportedComparisonMessage(d, other))
+	}
+	return bytes.Compare(d.PhysicalRep, v.PhysicalRep)
+}
+
+// index returns the position of the value in the declaration order of its
+// type.
+func (d *DEnum) index() int {
+	for i, physical := range d.EnumTyp.EnumPhysicalRepresentations() {
+		if bytes.Equal(physical, d.PhysicalRep) {
+			return i
+		}
+	}
+	panic(pgerror.AssertionFailedf(
+		"could not find %v in enum type %s", d.PhysicalRep, log.Safe(d.EnumTyp.Name())))
+}
+
+// enumValueAt returns the value at the given position of the declaration order
+// of the given enum type.
+func enumValueAt(typ *types.T, i int) *DEnum {
+	return &DEnum{
+		EnumTyp:     typ,
+		PhysicalRep: typ.EnumPhysicalRepresentations()[i],
+		LogicalRep:  typ.EnumLogicalRepresentations()[i],
+	}
+}
+
+// Prev implements the Datum interface.
+func (d *DEnum) Prev(_ *EvalContext) (Datum, bool) {
+	i := d.index()
+	if i == 0 {
+		return nil, false
+	}
+	return enumValueAt(d.EnumTyp, i-1), true
+}
+
+// Next implements the Datum interface.
+func (d *DEnum) Next(_ *EvalContext) (Datum, bool) {
+	i := d.index()
+	if i == len(d.EnumTyp.EnumPhysicalRepresentations())-1 {
+		return nil, false
+	}
+	return enumValueAt(d.EnumTyp, i+1), true
+}
+
+// IsMax implements the Datum interface.
+func (d *DEnum) IsMax(_ *EvalContext) bool {
+	return d.index() == len(d.EnumTyp.EnumPhysicalRepresentations())-1
+}
+
+// IsMin implements the Datum interface.
+func (d *DEnum) IsMin(_ *EvalContext) bool {
+	return d.index() == 0
+}
+
+// Max implements the Datum interface.
+func (d *DEnum) Max(_ *EvalContext) (Datum, bool) {
+	n := len(d.EnumTyp.EnumPhysicalRepresentations())
+	if n == 0 {
+		return nil, false
+	}
+	return enumValueAt(d.EnumTyp, n-1), true
+}
+
+// Min implements the Datum interface.
+func (d *DEnum) Min(_ *EvalContext) (Datum, bool) {
+	if len(d.EnumTyp.EnumPhysicalRepresentations()) == 0 {
+		return nil, false
+	}
+	return enumValueAt(d.EnumTyp, 0), true
+}
+
+// AmbiguousFormat implements the Datum interface. Enum values are formatted
+// without a type annotation, because the name of an enum type is only
+// meaningful inside the database that it belongs to. Their type is inferred
+// from the context when they are parsed again.
+func (*DEnum) AmbiguousFormat() bool { return false }
+
+// Format implements the NodeFormatter interface.
+func (d *DEnum) Format(ctx *FmtCtx) {
+	buf, f := &ctx.Buffer, ctx.flags
+	if f.HasFlags(fmtRawStrings) {
+		buf.WriteString(d.LogicalRep)
+	} else {
+		lex.EncodeSQLStringWithFlags(buf, d.LogicalRep, f.EncodeFlags())
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DEnum) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.PhysicalRep)) + uintptr(len(d.LogicalRep))
+}
+
 // DIPAddr is the IPAddr Datum.
 type DIPAddr struct {
 	ipaddr.IPAddr
@@ -1904,6 +2060,114 @@ func (d *DTime) Size() uintptr {
 	return unsafe.Sizeof(*d)
 }
 
+// DTimeTZ is the time with time zone Datum.
+type DTimeTZ struct {
+	timetz.TimeTZ
+}
+
+// NewDTimeTZ creates a DTimeTZ from a TimeTZ.
+func NewDTimeTZ(t timetz.TimeTZ) *DTimeTZ {
+	return &DTimeTZ{TimeTZ: t}
+}
+
+// NewDTimeTZFromTime creates a DTimeTZ from the clock time and the time zone
+// of a time.Time.
+func NewDTimeTZFromTime(t time.Time) *DTimeTZ {
+	return NewDTimeTZ(timetz.MakeTimeTZFromTime(t))
+}
+
+// ParseDTimeTZ parses and returns the *DTimeTZ Datum value represented by the
+// provided string, or an error if parsing is unsuccessful. If the string does
+// not specify a time zone, the time zone of the context is used.
+func ParseDTimeTZ(ctx ParseTimeContext, s string) (*DTimeTZ, error) {
+	now := relativeParseTime(ctx)
+	t, err := timetz.ParseTimeTZ(now, s)
+	if err != nil {
+		if pgErr, ok := pgerror.GetPGCause(err); ok &&
+			pgErr.Code == pgerror.CodeInvalidTimeZoneDisplacementValueError {
+			return nil, err
+		}
+		// Build our own error message to avoid exposing the dummy date.
+		return nil, makeParseError(s, types.TimeTZ, nil)
+	}
+	return NewDTimeTZ(t), nil
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DTimeTZ) ResolvedType() *types.T {
+	return types.TimeTZ
+}
+
+// Compare implements the Datum interface.
+func (d *DTimeTZ) Compare(ctx *EvalContext, other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := UnwrapDatum(ctx, other).(*DTimeTZ)
+	if !ok {
+		panic(makeUnsupportedComparisonMessage(d, other))
+	}
+	return d.TimeTZ.Compare(v.TimeTZ)
+}
+
+// Prev implements the Datum interface.
+func (d *DTimeTZ) Prev(_ *EvalContext) (Datum, bool) {
+	// Values for the same instant in different time zones are ordered by
+	// their offset, so the previous value is not simply one microsecond
+	// earlier.
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DTimeTZ) Next(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+var dTimeTZMin = NewDTimeTZ(timetz.Min)
+var dTimeTZMax = NewDTimeTZ(timetz.Max)
+
+// IsMax implements the Datum interface.
+func (d *DTimeTZ) IsMax(_ *EvalContext) bool {
+	return d.TimeTZ == dTimeTZMax.TimeTZ
+}
+
+// IsMin implements the Datum interface.
+func (d *DTimeTZ) IsMin(_ *EvalContext) bool {
+	return d.TimeTZ == dTimeTZMin.TimeTZ
+}
+
+// Max implements the Datum interface.
+func (d *DTimeTZ) Max(_ *EvalContext) (Datum, bool) {
+	return dTimeTZMax, true
+}
+
+// Min implements the Datum interface.
+func (d *DTimeTZ) Min(_ *EvalContext) (Datum, bool) {
+	return dTimeTZMin, true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DTimeTZ) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DTimeTZ) Format(ctx *FmtCtx) {
+	f := ctx.flags
+	bareStrings := f.HasFlags(FmtFlags(lex.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.WriteString(d.TimeTZ.String())
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DTimeTZ) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
 // DTimestamp is the timestamp Datum.
 type DTimestamp struct {
 	time.Time
@@ -2244,6 +2508,40 @@ func ParseDIntervalWithField(s string, field DurationField) (*DInterval, error)
 	return d, nil
 }
 
+// ParseDIntervalWithTypeMetadata is like ParseDInterval, but it interprets
+// unitless, numeric intervals using the fields of the given INTERVAL type, and
+// truncates the result to the fields and the precision of the type.
+func ParseDIntervalWithTypeMetadata(s string, typ *types.T) (*DInterval, error) {
+	field := Second
+	if itm := typ.IntervalData(); itm != nil && itm.DurationType != types.IntervalDurationType_UNSET {
+		field = DurationField(itm.DurationType)
+	}
+	d, err := parseDInterval(s, field)
+	if err != nil {
+		return nil, err
+	}
+	return AdjustDIntervalForTypeMetadata(d, typ), nil
+}
+
+// AdjustDIntervalForTypeMetadata returns the value of d truncated to the
+// fields of the given INTERVAL type, with the seconds rounded to the
+// fractional second precision of the type. The input datum is not modified.
+func AdjustDIntervalForTypeMetadata(d *DInterval, typ *types.T) *DInterval {
+	itm := typ.IntervalData()
+	if itm == nil {
+		return d
+	}
+	ret := *d
+	if itm.DurationType != types.IntervalDurationType_UNSET {
+		truncateDInterval(&ret, DurationField(itm.DurationType))
+	}
+	if itm.PrecisionIsSet {
+		unit := time.Duration(math.Pow10(9 - int(itm.Precision)))
+		ret.Duration.SetNanos(time.Duration(ret.Duration.Nanos()).Round(unit).Nanoseconds())
+	}
+	return &ret
+}
+
 func parseDInterval(s string, field DurationField) (*DInterval, error) {
 	// At this time the only supported interval formats are:
 	// - SQL standard.
@@ -2488,7 +2786,7 @@ func AsJSON(d Datum) (json.JSON, error) {
 	case *DTimestamp:
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(t.UTC().Format("2006-01-02T15:04:05.999999999")), nil
-	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DBitArray:
+	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DEnum:
 		return json.FromString(AsStringWithFlags(t, FmtBareStrings)), nil
 	default:
 		if d == DNull {
@@ -3122,6 +3420,10 @@ func (d *DArray) Format(ctx *FmtCtx) {
 
 const maxArrayLength = math.MaxInt32
 
+// MaxArrayDimensions is the maximum number of dimensions of an array, which is
+// the same as in Postgres.
+const MaxArrayDimensions = 6
+
 var arrayTooLongError = pgerror.Newf(
 	pgerror.CodeDataExceptionError, "ARRAYs can be at most 2^31-1 elements long")
 
@@ -3131,6 +3433,16 @@ func (d *DArray) Validate() error {
 	if d.Len() > maxArrayLength {
 		return arrayTooLongError
 	}
+	if d.ParamTyp.Family() == types.ArrayFamily {
+		ndims := 1
+		for t := d.ParamTyp; t.Family() == types.ArrayFamily; t = t.ArrayContents() {
+			ndims++
+		}
+		if ndims > MaxArrayDimensions {
+			return pgerror.Newf(pgerror.CodeProgramLimitExceededError,
+				"number of array dimensions (%d) exceeds the maximum allowed (%d)", ndims, MaxArrayDimensions)
+		}
+	}
 	return nil
 }
 
@@ -3151,6 +3463,27 @@ func (d *DArray) Size() uintptr {
 
 var errNonHomogeneousArray = pgerror.New(pgerror.CodeArraySubscriptError, "multidimensional arrays must have array expressions with matching dimensions")
 
+// sameArrayDimensions returns whether the given arrays have the same length in
+// every dimension. Since the elements of a multi-dimensional array are checked
+// to have matching dimensions when they are appended, it is sufficient to
+// compare the first element of every dimension.
+func sameArrayDimensions(a, b *DArray) bool {
+	for {
+		if a.Len() != b.Len() {
+			return false
+		}
+		if a.Len() == 0 {
+			return true
+		}
+		nextA, okA := AsDArray(a.Array[0])
+		nextB, okB := AsDArray(b.Array[0])
+		if !okA || !okB {
+			return okA == okB
+		}
+		a, b = nextA, nextB
+	}
+}
+
 // Append appends a Datum to the array, whose parameterized type must be
 // consistent with the type of the Datum.
 func (d *DArray) Append(v Datum) error {
@@ -3170,8 +3503,7 @@ func (d *DArray) Append(v Datum) error {
 			if prevItem == DNull {
 				return errNonHomogeneousArray
 			}
-			expectedLen := MustBeDArray(prevItem).Len()
-			if MustBeDArray(v).Len() != expectedLen {
+			if !sameArrayDimensions(MustBeDArray(prevItem), MustBeDArray(v)) {
 				return errNonHomogeneousArray
 			}
 		}
@@ -3185,6 +3517,102 @@ func (d *DArray) Append(v Datum) error {
 	return d.Validate()
 }
 
+// Flatten returns the length of each dimension of the array, and its innermost
+// elements in row-major order. The dimensions are determined by the first
+// element of every dimension, so an empty array has a single dimension. It
+// returns an error if the elements of a multi-dimensional array do not have
+// matching dimensions.
+func (d *DArray) Flatten() (dims []int, elems Datums, err error) {
+	dims = []int{d.Len()}
+	if d.ParamTyp.Family() != types.ArrayFamily || d.Len() == 0 {
+		return dims, d.Array, nil
+	}
+	for first := d; ; {
+		inner, ok := AsDArray(first.Array[0])
+		if !ok {
+			return nil, nil, errNonHomogeneousArray
+		}
+		dims = append(dims, inner.Len())
+		if inner.ParamTyp.Family() != types.ArrayFamily || inner.Len() == 0 {
+			break
+		}
+		first = inner
+	}
+
+	n := 1
+	for _, dim := range dims {
+		n *= dim
+	}
+	elems = make(Datums, 0, n)
+	var flatten func(arr *DArray, level int) error
+	flatten = func(arr *DArray, level int) error {
+		if arr.Len() != dims[level] {
+			return errNonHomogeneousArray
+		}
+		if level == len(dims)-1 {
+			elems = append(elems, arr.Array...)
+			return nil
+		}
+		for _, e := range arr.Array {
+			inner, ok := AsDArray(e)
+			if !ok {
+				return errNonHomogeneousArray
+			}
+			if err := flatten(inner, level+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := flatten(d, 0); err != nil {
+		return nil, nil, err
+	}
+	return dims, elems, nil
+}
+
+// NewDArrayFromFlattened constructs an array with elements of the given type
+// from the length of each of its dimensions and its innermost elements in
+// row-major order. It is the counterpart of DArray.Flatten. The innermost
+// elements must have the element type of the innermost dimension.
+func NewDArrayFromFlattened(paramTyp *types.T, dims []int, elems Datums) (*DArray, error) {
+	n := 1
+	for _, dim := range dims {
+		n *= dim
+	}
+	if len(dims) == 0 || n != len(elems) {
+		return nil, pgerror.AssertionFailedf(
+			"array with dimensions %v cannot have %d elements", dims, len(elems))
+	}
+	var build func(typ *types.T, level int) (*DArray, error)
+	build = func(typ *types.T, level int) (*DArray, error) {
+		arr := NewDArray(typ)
+		if level == len(dims)-1 {
+			for _, e := range elems[:dims[level]] {
+				if err := arr.Append(e); err != nil {
+					return nil, err
+				}
+			}
+			elems = elems[dims[level]:]
+			return arr, nil
+		}
+		if typ.Family() != types.ArrayFamily {
+			return nil, pgerror.Newf(pgerror.CodeDatatypeMismatchError,
+				"array with %d dimensions cannot have type %s", len(dims), types.MakeArray(paramTyp))
+		}
+		for i := 0; i < dims[level]; i++ {
+			inner, err := build(typ.ArrayContents(), level+1)
+			if err != nil {
+				return nil, err
+			}
+			if err := arr.Append(inner); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	}
+	return build(paramTyp, 0)
+}
+
 // DOid is the Postgres OID datum. It can represent either an OID type or any
 // of the reg* types, such as regproc or regclass.
 type DOid struct {
@@ -3577,6 +4005,7 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.BytesFamily:          {unsafe.Sizeof(DBytes("")), variableSize},
 	types.DateFamily:           {unsafe.Sizeof(DDate{}), fixedSize},
 	types.TimeFamily:           {unsafe.Sizeof(DTime(0)), fixedSize},
+	types.TimeTZFamily:         {unsafe.Sizeof(DTimeTZ{}), fixedSize},
 	types.TimestampFamily:      {unsafe.Sizeof(DTimestamp{}), fixedSize},
 	types.TimestampTZFamily:    {unsafe.Sizeof(DTimestampTZ{}), fixedSize},
 	types.IntervalFamily:       {unsafe.Sizeof(DInterval{}), fixedSize},
@@ -3584,6 +4013,7 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.UuidFamily:           {unsafe.Sizeof(DUuid{}), fixedSize},
 	types.INetFamily:           {unsafe.Sizeof(DIPAddr{}), fixedSize},
 	types.OidFamily:            {unsafe.Sizeof(DInt(0)), fixedSize},
+	types.EnumFamily:           {unsafe.Sizeof(DEnum{}), variableSize},
 
 	// TODO(jordan,justin): This seems suspicious.
 	types.ArrayFamily: {unsafe.Sizeof(DString("")), variableSize},
@@ -371,6 +371,47 @@ func TestParseDIntervalWithField(t *testing.T) {
 	}
 }
 
+// TestParseDIntervalWithTypeMetadata tests that intervals are truncated to the
+// fields and the precision of qualified INTERVAL types.
+func TestParseDIntervalWithTypeMetadata(t *testing.T) {
+	testData := []struct {
+		str      string
+		itm      types.IntervalMetadata
+		expected string
+	}{
+		{"5", types.IntervalMetadata{}, "5s"},
+		{"5", types.IntervalMetadata{DurationType: types.IntervalDurationType_DAY}, "5 day"},
+		{"1-2 3 4:56:07.891", types.IntervalMetadata{DurationType: types.IntervalDurationType_HOUR}, "1-2 3 4:00:00"},
+		{"1-2 3 4:56:07.891", types.IntervalMetadata{
+			FromDurationType: types.IntervalDurationType_DAY, DurationType: types.IntervalDurationType_MINUTE,
+		}, "1-2 3 4:56:00"},
+		{"1-2 3 4:56:07.891", types.IntervalMetadata{
+			DurationType: types.IntervalDurationType_SECOND, Precision: 1, PrecisionIsSet: true,
+		}, "1-2 3 4:56:07.9"},
+		{"4:56:07.891", types.IntervalMetadata{Precision: 0, PrecisionIsSet: true}, "4:56:08"},
+		{"-4:56:07.25", types.IntervalMetadata{Precision: 1, PrecisionIsSet: true}, "-4:56:07.3"},
+		{"4:56:07.123456", types.IntervalMetadata{Precision: 6, PrecisionIsSet: true}, "4:56:07.123456"},
+	}
+	for _, td := range testData {
+		typ := types.MakeInterval(td.itm)
+		actual, err := tree.ParseDIntervalWithTypeMetadata(td.str, typ)
+		if err != nil {
+			t.Errorf("unexpected error while parsing %s %s: %s", typ.SQLString(), td.str, err)
+			continue
+		}
+		expected, err := tree.ParseDInterval(td.expected)
+		if err != nil {
+			t.Errorf("unexpected error while parsing expected value INTERVAL %s: %s", td.expected, err)
+			continue
+		}
+		evalCtx := tree.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+		defer evalCtx.Stop(context.Background())
+		if expected.Compare(evalCtx, actual) != 0 {
+			t.Errorf("%s %s: got %s, expected %s", typ.SQLString(), td.str, actual, expected)
+		}
+	}
+}
+
 func TestParseDDate(t *testing.T) {
 	testData := []struct {
 		str      string
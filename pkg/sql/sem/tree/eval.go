@@ -42,6 +42,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -594,6 +595,26 @@ var BinOps = map[BinaryOperator]binOpOverload{
 				return MakeDTime(t.Add(left.(*DInterval).Duration)), nil
 			},
 		},
+		&BinOp{
+			LeftType:   types.TimeTZ,
+			RightType:  types.Interval,
+			ReturnType: types.TimeTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				t := left.(*DTimeTZ).TimeTZ
+				t.TimeOfDay = t.TimeOfDay.Add(right.(*DInterval).Duration)
+				return NewDTimeTZ(t), nil
+			},
+		},
+		&BinOp{
+			LeftType:   types.Interval,
+			RightType:  types.TimeTZ,
+			ReturnType: types.TimeTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				t := right.(*DTimeTZ).TimeTZ
+				t.TimeOfDay = t.TimeOfDay.Add(left.(*DInterval).Duration)
+				return NewDTimeTZ(t), nil
+			},
+		},
 		&BinOp{
 			LeftType:   types.Timestamp,
 			RightType:  types.Interval,
@@ -848,6 +869,16 @@ var BinOps = map[BinaryOperator]binOpOverload{
 				return MakeDTime(t.Add(right.(*DInterval).Duration.Mul(-1))), nil
 			},
 		},
+		&BinOp{
+			LeftType:   types.TimeTZ,
+			RightType:  types.Interval,
+			ReturnType: types.TimeTZ,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				t := left.(*DTimeTZ).TimeTZ
+				t.TimeOfDay = t.TimeOfDay.Add(right.(*DInterval).Duration.Mul(-1))
+				return NewDTimeTZ(t), nil
+			},
+		},
 		&BinOp{
 			LeftType:   types.Timestamp,
 			RightType:  types.Interval,
@@ -1746,6 +1777,7 @@ var CmpOps = cmpOpFixups(map[ComparisonOperator]cmpOpOverload{
 		makeEqFn(types.Date, types.Date),
 		makeEqFn(types.Decimal, types.Decimal),
 		makeEqFn(types.AnyCollatedString, types.AnyCollatedString),
+		makeEqFn(types.AnyEnum, types.AnyEnum),
 		makeEqFn(types.Float, types.Float),
 		makeEqFn(types.INet, types.INet),
 		makeEqFn(types.Int, types.Int),
@@ -1754,6 +1786,7 @@ var CmpOps = cmpOpFixups(map[ComparisonOperator]cmpOpOverload{
 		makeEqFn(types.Oid, types.Oid),
 		makeEqFn(types.String, types.String),
 		makeEqFn(types.Time, types.Time),
+		makeEqFn(types.TimeTZ, types.TimeTZ),
 		makeEqFn(types.Timestamp, types.Timestamp),
 		makeEqFn(types.TimestampTZ, types.TimestampTZ),
 		makeEqFn(types.Uuid, types.Uuid),
@@ -1790,6 +1823,7 @@ var CmpOps = cmpOpFixups(map[ComparisonOperator]cmpOpOverload{
 		makeLtFn(types.Date, types.Date),
 		makeLtFn(types.Decimal, types.Decimal),
 		makeLtFn(types.AnyCollatedString, types.AnyCollatedString),
+		makeLtFn(types.AnyEnum, types.AnyEnum),
 		makeLtFn(types.Float, types.Float),
 		makeLtFn(types.INet, types.INet),
 		makeLtFn(types.Int, types.Int),
@@ -1797,6 +1831,7 @@ var CmpOps = cmpOpFixups(map[ComparisonOperator]cmpOpOverload{
 		makeLtFn(types.Oid, types.Oid),
 		makeLtFn(types.String, types.String),
 		makeLtFn(types.Time, types.Time),
+		makeLtFn(types.TimeTZ, types.TimeTZ),
 		makeLtFn(types.Timestamp, types.Timestamp),
 		makeLtFn(types.TimestampTZ, types.TimestampTZ),
 		makeLtFn(types.Uuid, types.Uuid),
@@ -1833,6 +1868,7 @@ var CmpOps = cmpOpFixups(map[ComparisonOperator]cmpOpOverload{
 		makeLeFn(types.Date, types.Date),
 		makeLeFn(types.Decimal, types.Decimal),
 		makeLeFn(types.AnyCollatedString, types.AnyCollatedString),
+		makeLeFn(types.AnyEnum, types.AnyEnum),
 		makeLeFn(types.Float, types.Float),
 		makeLeFn(types.INet, types.INet),
 		makeLeFn(types.Int, types.Int),
@@ -1840,6 +1876,7 @@ var CmpOps = cmpOpFixups(map[ComparisonOperator]cmpOpOverload{
 		makeLeFn(types.Oid, types.Oid),
 		makeLeFn(types.String, types.String),
 		makeLeFn(types.Time, types.Time),
+		makeLeFn(types.TimeTZ, types.TimeTZ),
 		makeLeFn(types.Timestamp, types.Timestamp),
 		makeLeFn(types.TimestampTZ, types.TimestampTZ),
 		makeLeFn(types.Uuid, types.Uuid),
@@ -1884,6 +1921,7 @@ var CmpOps = cmpOpFixups(map[ComparisonOperator]cmpOpOverload{
 		makeIsFn(types.Date, types.Date),
 		makeIsFn(types.Decimal, types.Decimal),
 		makeIsFn(types.AnyCollatedString, types.AnyCollatedString),
+		makeIsFn(types.AnyEnum, types.AnyEnum),
 		makeIsFn(types.Float, types.Float),
 		makeIsFn(types.INet, types.INet),
 		makeIsFn(types.Int, types.Int),
@@ -1892,6 +1930,7 @@ var CmpOps = cmpOpFixups(map[ComparisonOperator]cmpOpOverload{
 		makeIsFn(types.Oid, types.Oid),
 		makeIsFn(types.String, types.String),
 		makeIsFn(types.Time, types.Time),
+		makeIsFn(types.TimeTZ, types.TimeTZ),
 		makeIsFn(types.Timestamp, types.Timestamp),
 		makeIsFn(types.TimestampTZ, types.TimestampTZ),
 		makeIsFn(types.Uuid, types.Uuid),
@@ -1931,6 +1970,7 @@ var CmpOps = cmpOpFixups(map[ComparisonOperator]cmpOpOverload{
 		makeEvalTupleIn(types.Date),
 		makeEvalTupleIn(types.Decimal),
 		makeEvalTupleIn(types.AnyCollatedString),
+		makeEvalTupleIn(types.AnyEnum),
 		makeEvalTupleIn(types.AnyTuple),
 		makeEvalTupleIn(types.Float),
 		makeEvalTupleIn(types.INet),
@@ -1940,6 +1980,7 @@ var CmpOps = cmpOpFixups(map[ComparisonOperator]cmpOpOverload{
 		makeEvalTupleIn(types.Oid),
 		makeEvalTupleIn(types.String),
 		makeEvalTupleIn(types.Time),
+		makeEvalTupleIn(types.TimeTZ),
 		makeEvalTupleIn(types.Timestamp),
 		makeEvalTupleIn(types.TimestampTZ),
 		makeEvalTupleIn(types.Uuid),
@@ -3243,7 +3284,7 @@ func PerformCast(ctx *EvalContext, d Datum, t *types.T) (Datum, error) {
 				ctx.SessionData.DataConversion.GetFloatPrec(), 64)
 		case *DBool, *DInt, *DDecimal:
 			s = d.String()
-		case *DTimestamp, *DTimestampTZ, *DDate, *DTime:
+		case *DTimestamp, *DTimestampTZ, *DDate, *DTime, *DTimeTZ:
 			s = AsStringWithFlags(d, FmtBareStrings)
 		case *DTuple:
 			s = AsStringWithFlags(d, FmtPgwireText)
@@ -3256,6 +3297,8 @@ func PerformCast(ctx *EvalContext, d Datum, t *types.T) (Datum, error) {
 			s = t.ValueAsString()
 		case *DUuid:
 			s = t.UUID.String()
+		case *DEnum:
+			s = t.LogicalRep
 		case *DIPAddr:
 			s = AsStringWithFlags(d, FmtBareStrings)
 		case *DString:
@@ -3315,6 +3358,20 @@ func PerformCast(ctx *EvalContext, d Datum, t *types.T) (Datum, error) {
 			return d, nil
 		}
 
+	case types.EnumFamily:
+		switch v := d.(type) {
+		case *DString:
+			return MakeDEnumFromLogicalRepresentation(t, string(*v))
+		case *DCollatedString:
+			return MakeDEnumFromLogicalRepresentation(t, v.Contents)
+		case *DEnum:
+			if v.EnumTyp.TypeID() != t.TypeID() {
+				return nil, pgerror.Newf(pgerror.CodeCannotCoerceError,
+					"invalid cast: %s -> %s", v.EnumTyp.SQLString(), t.SQLString())
+			}
+			return d, nil
+		}
+
 	case types.INetFamily:
 		switch t := d.(type) {
 		case *DString:
@@ -3355,10 +3412,29 @@ func PerformCast(ctx *EvalContext, d Datum, t *types.T) (Datum, error) {
 			return MakeDTime(timeofday.FromTime(d.Time)), nil
 		case *DTimestampTZ:
 			return MakeDTime(timeofday.FromTime(d.Time)), nil
+		case *DTimeTZ:
+			return MakeDTime(d.TimeOfDay), nil
 		case *DInterval:
 			return MakeDTime(timeofday.Min.Add(d.Duration)), nil
 		}
 
+	case types.TimeTZFamily:
+		switch d := d.(type) {
+		case *DString:
+			return ParseDTimeTZ(ctx, string(*d))
+		case *DCollatedString:
+			return ParseDTimeTZ(ctx, d.Contents)
+		case *DTime:
+			// Like in Postgres, the time zone of the session at the current
+			// time is used.
+			_, offset := ctx.GetRelativeParseTime().Zone()
+			return NewDTimeTZ(timetz.MakeTimeTZ(timeofday.TimeOfDay(*d), -int32(offset))), nil
+		case *DTimeTZ:
+			return d, nil
+		case *DTimestampTZ:
+			return NewDTimeTZFromTime(d.Time.In(ctx.GetLocation())), nil
+		}
+
 	case types.TimestampFamily:
 		// TODO(knz): Timestamp from float, decimal.
 		prec := time.Microsecond
@@ -3411,15 +3487,18 @@ func PerformCast(ctx *EvalContext, d Datum, t *types.T) (Datum, error) {
 	case types.IntervalFamily:
 		switch v := d.(type) {
 		case *DString:
-			return ParseDInterval(string(*v))
+			return ParseDIntervalWithTypeMetadata(string(*v), t)
 		case *DCollatedString:
-			return ParseDInterval(v.Contents)
+			return ParseDIntervalWithTypeMetadata(v.Contents, t)
 		case *DInt:
-			return &DInterval{Duration: duration.FromInt64(int64(*v))}, nil
+			return AdjustDIntervalForTypeMetadata(
+				&DInterval{Duration: duration.FromInt64(int64(*v))}, t), nil
 		case *DFloat:
-			return &DInterval{Duration: duration.FromFloat64(float64(*v))}, nil
+			return AdjustDIntervalForTypeMetadata(
+				&DInterval{Duration: duration.FromFloat64(float64(*v))}, t), nil
 		case *DTime:
-			return &DInterval{Duration: duration.MakeDuration(int64(*v)*1000, 0, 0)}, nil
+			return AdjustDIntervalForTypeMetadata(
+				&DInterval{Duration: duration.MakeDuration(int64(*v)*1000, 0, 0)}, t), nil
 		case *DDecimal:
 			d := ctx.getTmpDec()
 			dnanos := v.Decimal
@@ -3437,9 +3516,9 @@ func PerformCast(ctx *EvalContext, d Datum, t *types.T) (Datum, error) {
 			if !ok {
 				return nil, errDecOutOfRange
 			}
-			return &DInterval{Duration: dv}, nil
+			return AdjustDIntervalForTypeMetadata(&DInterval{Duration: dv}, t), nil
 		case *DInterval:
-			return d, nil
+			return AdjustDIntervalForTypeMetadata(v, t), nil
 		}
 	case types.JsonFamily:
 		switch v := d.(type) {
@@ -3470,6 +3549,27 @@ func PerformCast(ctx *EvalContext, d Datum, t *types.T) (Datum, error) {
 			}
 			return dcast, nil
 		}
+	case types.TupleFamily:
+		// Tuples can be cast to composite types with the same number of fields.
+		if v, ok := d.(*DTuple); ok {
+			if len(v.D) != len(t.TupleContents()) {
+				return nil, pgerror.Newf(pgerror.CodeCannotCoerceError,
+					"cannot cast type %s to %s: expected %d fields, got %d",
+					v.ResolvedType(), t.SQLString(), len(t.TupleContents()), len(v.D))
+			}
+			dcast := NewDTupleWithLen(t, len(v.D))
+			for i, e := range v.D {
+				dcast.D[i] = DNull
+				if e != DNull {
+					var err error
+					dcast.D[i], err = PerformCast(ctx, e, &t.TupleContents()[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+			return dcast, nil
+		}
 	case types.OidFamily:
 		switch v := d.(type) {
 		case *DOid:
@@ -3582,44 +3682,45 @@ func PerformCast(ctx *EvalContext, d Datum, t *types.T) (Datum, error) {
 
 // Eval implements the TypedExpr interface.
 func (expr *IndirectionExpr) Eval(ctx *EvalContext) (Datum, error) {
-	var subscriptIdx int
-	for i, t := range expr.Indirection {
-		if t.Slice || i > 0 {
+	d, err := expr.Expr.(TypedExpr).Eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each subscript indexes into the array resulting from the previous one.
+	for _, t := range expr.Indirection {
+		if t.Slice {
 			return nil, pgerror.AssertionFailedf("unsupported feature should have been rejected during planning")
 		}
+		if d == DNull {
+			return d, nil
+		}
 
-		d, err := t.Begin.(TypedExpr).Eval(ctx)
+		begin, err := t.Begin.(TypedExpr).Eval(ctx)
 		if err != nil {
 			return nil, err
 		}
-		if d == DNull {
-			return d, nil
+		if begin == DNull {
+			return begin, nil
 		}
-		subscriptIdx = int(MustBeDInt(d))
-	}
-
-	d, err := expr.Expr.(TypedExpr).Eval(ctx)
-	if err != nil {
-		return nil, err
-	}
-	if d == DNull {
-		return d, nil
-	}
+		subscriptIdx := int(MustBeDInt(begin))
 
-	// Index into the DArray, using 1-indexing.
-	arr := MustBeDArray(d)
+		// Index into the DArray, using 1-indexing.
+		arr := MustBeDArray(d)
 
-	// VECTOR types use 0-indexing.
-	if w, ok := d.(*DOidWrapper); ok {
-		switch w.Oid {
-		case oid.T_oidvector, oid.T_int2vector:
-			subscriptIdx++
+		// VECTOR types use 0-indexing.
+		if w, ok := d.(*DOidWrapper); ok {
+			switch w.Oid {
+			case oid.T_oidvector, oid.T_int2vector:
+				subscriptIdx++
+			}
 		}
+		if subscriptIdx < 1 || subscriptIdx > arr.Len() {
+			return DNull, nil
+		}
+		d = arr.Array[subscriptIdx-1]
 	}
-	if subscriptIdx < 1 || subscriptIdx > arr.Len() {
-		return DNull, nil
-	}
-	return arr.Array[subscriptIdx-1], nil
+	return d, nil
 }
 
 // Eval implements the TypedExpr interface.
@@ -3648,6 +3749,9 @@ func (expr *ColumnAccessExpr) Eval(ctx *EvalContext) (Datum, error) {
 	if err != nil {
 		return nil, err
 	}
+	if d == DNull {
+		return d, nil
+	}
 	return d.(*DTuple).D[expr.ColIndex], nil
 }
 
@@ -4059,6 +4163,11 @@ func (t *DUuid) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
 }
 
+// Eval implements the TypedExpr interface.
+func (t *DEnum) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
 // Eval implements the TypedExpr interface.
 func (t *DIPAddr) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
@@ -4074,6 +4183,11 @@ func (t *DTime) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
 }
 
+// Eval implements the TypedExpr interface.
+func (t *DTimeTZ) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
 // Eval implements the TypedExpr interface.
 func (t *DFloat) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
@@ -135,6 +135,24 @@ func NewFunctionDefinition(
 	}
 }
 
+// NewUserDefinedFunctionDefinition allocates a function definition
+// corresponding to the given user-defined function. Unlike
+// NewFunctionDefinition, it doesn't set up telemetry for the overloads, since
+// their names are user data.
+func NewUserDefinedFunctionDefinition(
+	name string, props *FunctionProperties, def []Overload,
+) *FunctionDefinition {
+	overloads := make([]overloadImpl, len(def))
+	for i := range def {
+		overloads[i] = &def[i]
+	}
+	return &FunctionDefinition{
+		Name:               name,
+		Definition:         overloads,
+		FunctionProperties: *props,
+	}
+}
+
 // FunDefs holds pre-allocated FunctionDefinition instances
 // for every builtin function. Initialized by builtins.init().
 var FunDefs map[string]*FunctionDefinition
@@ -64,6 +64,41 @@ func (fn *ResolvableFunctionReference) Resolve(
 	}
 }
 
+// ResolveInContext is like Resolve, but it also resolves references to
+// user-defined functions with the FunctionResolver of the given context.
+// Built-in functions take precedence over user-defined functions.
+//
+// Unlike built-in functions, the definitions of user-defined functions are not
+// cached in the reference: they can change from one use of the statement to
+// the next.
+func (fn *ResolvableFunctionReference) ResolveInContext(
+	ctx *SemaContext,
+) (*FunctionDefinition, error) {
+	var searchPath sessiondata.SearchPath
+	if ctx != nil {
+		searchPath = ctx.SearchPath
+	}
+	def, err := fn.Resolve(searchPath)
+	if err == nil || ctx == nil || ctx.FunctionResolver == nil {
+		return def, err
+	}
+	name, ok := fn.FunctionReference.(*UnresolvedName)
+	if !ok {
+		return nil, err
+	}
+	if pgErr, ok := pgerror.GetPGCause(err); !ok || pgErr.Code != pgerror.CodeUndefinedFunctionError {
+		return nil, err
+	}
+	udf, udfErr := ctx.FunctionResolver.ResolveFunction(name)
+	if udfErr != nil {
+		return nil, udfErr
+	}
+	if udf == nil {
+		return nil, err
+	}
+	return udf, nil
+}
+
 // WrapFunction creates a new ResolvableFunctionReference
 // holding a pre-resolved function. Helper for grammar rules.
 func WrapFunction(n string) ResolvableFunctionReference {
@@ -74,6 +109,19 @@ func WrapFunction(n string) ResolvableFunctionReference {
 	return ResolvableFunctionReference{fd}
 }
 
+// WrapFunctionOverload is like WrapFunction, but it also supports the
+// overloads of user-defined functions, which are not in FunDefs.
+func WrapFunctionOverload(
+	n string, props *FunctionProperties, overload *Overload,
+) ResolvableFunctionReference {
+	if overload == nil || overload.SQLBody == nil {
+		return WrapFunction(n)
+	}
+	return ResolvableFunctionReference{
+		NewUserDefinedFunctionDefinition(n, props, []Overload{*overload}),
+	}
+}
+
 // FunctionReference is the common interface to UnresolvedName and QualifiedFunctionName.
 type FunctionReference interface {
 	fmt.Stringer
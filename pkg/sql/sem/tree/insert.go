@@ -23,12 +23,44 @@ package tree
 
 // Insert represents an INSERT statement.
 type Insert struct {
-	With       *With
-	Table      TableExpr
-	Columns    NameList
-	Rows       *Select
-	OnConflict *OnConflict
-	Returning  ReturningClause
+	With    *With
+	Table   TableExpr
+	Columns NameList
+	// ColumnFields is nil unless some of the target columns are fields of
+	// columns of composite types. It then parallels Columns; see
+	// UpdateExpr.Fields.
+	ColumnFields NameList
+	Overriding   Overriding
+	Rows         *Select
+	OnConflict   *OnConflict
+	Returning    ReturningClause
+}
+
+// Overriding represents the OVERRIDING clause of an INSERT statement, which
+// controls how the values specified for identity columns are used.
+type Overriding int
+
+// The values of Overriding.
+const (
+	// OverridingNone means that no OVERRIDING clause was specified. Values
+	// cannot be specified for identity columns defined as GENERATED ALWAYS.
+	OverridingNone Overriding = iota
+	// OverridingSystemValue means that the values specified for identity
+	// columns are inserted, even for columns defined as GENERATED ALWAYS.
+	OverridingSystemValue
+	// OverridingUserValue means that the values specified for identity columns
+	// are ignored, and the values generated by their sequences are inserted.
+	OverridingUserValue
+)
+
+var overridingName = [...]string{
+	OverridingNone:        "",
+	OverridingSystemValue: "OVERRIDING SYSTEM VALUE",
+	OverridingUserValue:   "OVERRIDING USER VALUE",
+}
+
+func (o Overriding) String() string {
+	return overridingName[o]
 }
 
 // Format implements the NodeFormatter interface.
@@ -43,9 +75,13 @@ func (node *Insert) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.Table)
 	if node.Columns != nil {
 		ctx.WriteByte('(')
-		ctx.FormatNode(&node.Columns)
+		ctx.FormatNode(&columnTargets{names: node.Columns, fields: node.ColumnFields})
 		ctx.WriteByte(')')
 	}
+	if node.Overriding != OverridingNone {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.Overriding.String())
+	}
 	if node.DefaultValues() {
 		ctx.WriteString(" DEFAULT VALUES")
 	} else {
@@ -54,11 +90,19 @@ func (node *Insert) Format(ctx *FmtCtx) {
 	}
 	if node.OnConflict != nil && !node.OnConflict.IsUpsertAlias() {
 		ctx.WriteString(" ON CONFLICT")
+		if node.OnConflict.Constraint != "" {
+			ctx.WriteString(" ON CONSTRAINT ")
+			ctx.FormatNode(&node.OnConflict.Constraint)
+		}
 		if len(node.OnConflict.Columns) > 0 {
 			ctx.WriteString(" (")
 			ctx.FormatNode(&node.OnConflict.Columns)
 			ctx.WriteString(")")
 		}
+		if node.OnConflict.ArbiterPredicate != nil {
+			ctx.WriteString(" WHERE ")
+			ctx.FormatNode(node.OnConflict.ArbiterPredicate)
+		}
 		if node.OnConflict.DoNothing {
 			ctx.WriteString(" DO NOTHING")
 		} else {
@@ -81,20 +125,27 @@ func (node *Insert) DefaultValues() bool {
 	return node.Rows.Select == nil
 }
 
-// OnConflict represents an `ON CONFLICT (columns) DO UPDATE SET exprs WHERE
-// where` clause.
+// OnConflict represents an `ON CONFLICT (columns) WHERE arbiter DO UPDATE SET
+// exprs WHERE where` clause.
+//
+// The conflict target is either a list of columns, optionally with a predicate
+// that selects the partial unique indexes that can be used as arbiters, or the
+// name of a unique constraint (`ON CONFLICT ON CONSTRAINT name`).
 //
 // The zero value for OnConflict is used to signal the UPSERT short form, which
 // uses the primary key for as the conflict index and the values being inserted
 // for Exprs.
 type OnConflict struct {
-	Columns   NameList
-	Exprs     UpdateExprs
-	Where     *Where
-	DoNothing bool
+	Columns          NameList
+	ArbiterPredicate Expr
+	Constraint       Name
+	Exprs            UpdateExprs
+	Where            *Where
+	DoNothing        bool
 }
 
 // IsUpsertAlias returns true if the UPSERT syntactic sugar was used.
 func (oc *OnConflict) IsUpsertAlias() bool {
-	return oc != nil && oc.Columns == nil && oc.Exprs == nil && oc.Where == nil && !oc.DoNothing
+	return oc != nil && oc.Columns == nil && oc.ArbiterPredicate == nil && oc.Constraint == "" &&
+		oc.Exprs == nil && oc.Where == nil && !oc.DoNothing
 }
@@ -307,8 +307,9 @@ func (t *TableName) ResolveExisting(
 		return false, nil, nil
 	}
 
-	// This is a naked table name. Use the search path.
-	iter := searchPath.Iter()
+	// This is a naked table name. Use the search path, which includes the
+	// temporary schema of the session.
+	iter := searchPath.IterWithTemporarySchema()
 	for next, ok := iter.Next(); ok; next, ok = iter.Next() {
 		if found, objMeta, err := r.LookupObject(ctx, requireMutable, curDb, next, t.Table()); found || err != nil {
 			if err == nil {
@@ -516,6 +516,7 @@ func newFakeMetadata() *fakeMetadata {
 			{"db2", []knownSchema{
 				{"public", []tree.Name{"foo"}},
 				{"extended", []tree.Name{"bar", "pg_tables"}},
+				{"pg_temp", []tree.Name{"foo", "tmp"}},
 			}},
 		},
 	}
@@ -563,6 +564,10 @@ func TestResolveTablePatternOrName(t *testing.T) {
 		{`pg_tables`, `db2`, mpath("pg_catalog", "extended"), true, `pg_tables`, `db2.pg_catalog.pg_tables`, `db2.pg_catalog[0]`, ``},
 		// When pg_catalog is not explicitly mentioned in the search path, it is searched first.
 		{`pg_tables`, `db2`, mpath("foo"), true, `pg_tables`, `db2.pg_catalog.pg_tables`, `db2.pg_catalog[0]`, ``},
+		// When pg_temp is not explicitly mentioned in the search path, it is searched first.
+		{`foo`, `db2`, mpath("public", "pg_catalog"), true, `foo`, `db2.pg_temp.foo`, `db2.pg_temp[0]`, ``},
+		{`tmp`, `db2`, mpath("public", "pg_catalog"), true, `tmp`, `db2.pg_temp.tmp`, `db2.pg_temp[1]`, ``},
+		{`foo`, `db2`, mpath("public", "pg_temp"), true, `foo`, `db2.public.foo`, `db2.public[0]`, ``},
 
 		// Names of length 2.
 
@@ -51,11 +51,28 @@ type Overload struct {
 	Fn            func(*EvalContext, Datums) (Datum, error)
 	Generator     GeneratorFactory
 
+	// SQLBody is set for the overloads of user-defined functions. Fn, or
+	// Generator for set-returning functions, evaluate it.
+	SQLBody *SQLFunctionBody
+
 	// counter, if non-nil, should be incremented upon successful
 	// type check of expressions using this overload.
 	counter telemetry.Counter
 }
 
+// SQLFunctionBody is the definition of an overload of a user-defined function
+// written in SQL.
+type SQLFunctionBody struct {
+	// Stmt is the statement that computes the result of the function. The
+	// references to the parameters are placeholders annotated with the type of
+	// the parameter: $1 is the first parameter, etc.
+	Stmt Statement
+
+	// Strict is set if the function returns NULL, without being evaluated,
+	// when one of its arguments is NULL.
+	Strict bool
+}
+
 // params implements the overloadImpl interface.
 func (b Overload) params() TypeList { return b.Types }
 
@@ -777,6 +794,13 @@ func checkReturn(
 		p := o.params()
 		for _, i := range s.constIdxs {
 			des := p.GetAt(i)
+			if des.Family() == types.EnumFamily && des.IsAmbiguous() {
+				// Constants cannot become values of the AnyEnum wildcard type, so
+				// they desire the enum type of the other arguments instead.
+				if typ := resolvedEnumType(s); typ != nil {
+					des = typ
+				}
+			}
 			typ, err := s.exprs[i].TypeCheck(ctx, des)
 			if err != nil {
 				return false, s.typedExprs, nil, pgerror.Wrapf(
@@ -811,6 +835,17 @@ func checkReturn(
 	}
 }
 
+// resolvedEnumType returns the type of the first resolvable argument that has
+// an enum type, or nil if there is no such argument.
+func resolvedEnumType(s *typeCheckOverloadState) *types.T {
+	for _, i := range s.resolvableIdxs {
+		if typ := s.typedExprs[i].ResolvedType(); typ.Family() == types.EnumFamily && !typ.IsAmbiguous() {
+			return typ
+		}
+	}
+	return nil
+}
+
 func formatCandidates(prefix string, candidates []overloadImpl) string {
 	var buf bytes.Buffer
 	for _, candidate := range candidates {
@@ -24,7 +24,6 @@ import (
 
 var enclosingError = pgerror.Newf(pgerror.CodeInvalidTextRepresentationError, "array must be enclosed in { and }")
 var extraTextError = pgerror.Newf(pgerror.CodeInvalidTextRepresentationError, "extra text after closing right brace")
-var nestedArraysNotSupportedError = pgerror.UnimplementedWithIssueDetail(32552, "strcast", "nested arrays not supported")
 var malformedError = pgerror.Newf(pgerror.CodeInvalidTextRepresentationError, "malformed array")
 
 var isQuoteChar = func(ch byte) bool {
@@ -73,8 +72,6 @@ func (p *parseState) gobbleString(isTerminatingChar func(ch byte) bool) (out str
 type parseState struct {
 	s       string
 	evalCtx *EvalContext
-	result  *DArray
-	t       *types.T
 }
 
 func (p *parseState) advance() {
@@ -109,13 +106,56 @@ func (p *parseState) parseUnquotedString() (string, error) {
 	return strings.TrimSpace(out), nil
 }
 
-func (p *parseState) parseElement() error {
+// parseArray parses an array with elements of the given type, including its
+// enclosing braces.
+func (p *parseState) parseArray(t *types.T) (*DArray, error) {
+	if p.peek() != '{' {
+		return nil, enclosingError
+	}
+	p.advance()
+	p.eatWhitespace()
+	result := NewDArray(t)
+	if p.peek() != '}' {
+		if err := p.parseElement(result); err != nil {
+			return nil, err
+		}
+		p.eatWhitespace()
+		for p.peek() == ',' {
+			p.advance()
+			p.eatWhitespace()
+			if err := p.parseElement(result); err != nil {
+				return nil, err
+			}
+			p.eatWhitespace()
+		}
+	}
+	p.eatWhitespace()
+	if p.eof() {
+		return nil, enclosingError
+	}
+	if p.peek() != '}' {
+		return nil, malformedError
+	}
+	p.advance()
+	return result, nil
+}
+
+func (p *parseState) parseElement(result *DArray) error {
 	var next string
 	var err error
 	r := p.peek()
 	switch r {
 	case '{':
-		return nestedArraysNotSupportedError
+		// The elements of a multi-dimensional array are themselves arrays.
+		if result.ParamTyp.Family() != types.ArrayFamily {
+			return pgerror.Newf(pgerror.CodeInvalidTextRepresentationError,
+				"malformed array: unexpected nested array for element type %s", result.ParamTyp)
+		}
+		inner, err := p.parseArray(result.ParamTyp.ArrayContents())
+		if err != nil {
+			return err
+		}
+		return result.Append(inner)
 	case '"':
 		p.advance()
 		next, err = p.parseQuotedString()
@@ -132,58 +172,34 @@ func (p *parseState) parseElement() error {
 			return err
 		}
 		if strings.EqualFold(next, "null") {
-			return p.result.Append(DNull)
+			return result.Append(DNull)
 		}
 	}
 
-	d, err := PerformCast(p.evalCtx, NewDString(next), p.t)
+	d, err := PerformCast(p.evalCtx, NewDString(next), result.ParamTyp)
 	if err != nil {
 		return err
 	}
-	return p.result.Append(d)
+	return result.Append(d)
 }
 
 // ParseDArrayFromString parses the string-form of constructing arrays, handling
-// cases such as `'{1,2,3}'::INT[]`.
+// cases such as `'{1,2,3}'::INT[]` and `'{{1,2},{3,4}}'::INT[][]`.
 func ParseDArrayFromString(evalCtx *EvalContext, s string, t *types.T) (*DArray, error) {
 	parser := parseState{
 		s:       s,
 		evalCtx: evalCtx,
-		result:  NewDArray(t),
-		t:       t,
 	}
 
 	parser.eatWhitespace()
-	if parser.peek() != '{' {
-		return nil, enclosingError
-	}
-	parser.advance()
-	parser.eatWhitespace()
-	if parser.peek() != '}' {
-		if err := parser.parseElement(); err != nil {
-			return nil, err
-		}
-		parser.eatWhitespace()
-		for parser.peek() == ',' {
-			parser.advance()
-			parser.eatWhitespace()
-			if err := parser.parseElement(); err != nil {
-				return nil, err
-			}
-		}
-	}
-	parser.eatWhitespace()
-	if parser.eof() {
-		return nil, enclosingError
-	}
-	if parser.peek() != '}' {
-		return nil, malformedError
+	result, err := parser.parseArray(t)
+	if err != nil {
+		return nil, err
 	}
-	parser.advance()
 	parser.eatWhitespace()
 	if !parser.eof() {
 		return nil, extraTextError
 	}
 
-	return parser.result, nil
+	return result, nil
 }
@@ -78,6 +78,24 @@ lo}`, types.String, Datums{NewDString(`hel`), NewDString(`lo`)}},
 		// occur.
 		{string([]byte{'{', 'a', 200, '}'}), types.String, Datums{NewDString("a\xc8")}},
 		{string([]byte{'{', 'a', 200, 'a', '}'}), types.String, Datums{NewDString("a\xc8a")}},
+
+		// Multi-dimensional arrays.
+		{`{{1,2},{3,4}}`, types.IntArray, Datums{
+			&DArray{ParamTyp: types.Int, Array: Datums{NewDInt(1), NewDInt(2)}},
+			&DArray{ParamTyp: types.Int, Array: Datums{NewDInt(3), NewDInt(4)}},
+		}},
+		{` { { 1 , NULL } , {"3", 4} } `, types.IntArray, Datums{
+			&DArray{ParamTyp: types.Int, Array: Datums{NewDInt(1), DNull}},
+			&DArray{ParamTyp: types.Int, Array: Datums{NewDInt(3), NewDInt(4)}},
+		}},
+		{`{{{a}},{{b}}}`, types.MakeArray(types.StringArray), Datums{
+			&DArray{ParamTyp: types.StringArray, Array: Datums{
+				&DArray{ParamTyp: types.String, Array: Datums{NewDString(`a`)}},
+			}},
+			&DArray{ParamTyp: types.StringArray, Array: Datums{
+				&DArray{ParamTyp: types.String, Array: Datums{NewDString(`b`)}},
+			}},
+		}},
 	}
 	for _, td := range testData {
 		t.Run(td.str, func(t *testing.T) {
@@ -166,8 +184,12 @@ func TestParseArrayError(t *testing.T) {
 		{`{,}`, types.Int, "malformed array"},
 		{`{}{}`, types.Int, "extra text after closing right brace"},
 		{`{} {}`, types.Int, "extra text after closing right brace"},
-		{`{{}}`, types.Int, "unimplemented: nested arrays not supported"},
-		{`{1, {1}}`, types.Int, "unimplemented: nested arrays not supported"},
+		{`{{}}`, types.Int, "malformed array: unexpected nested array for element type int"},
+		{`{1, {1}}`, types.Int, "malformed array: unexpected nested array for element type int"},
+		{`{1, {1}}`, types.IntArray, "array must be enclosed in { and }"},
+		{`{{1}, NULL}`, types.IntArray, "multidimensional arrays must have array expressions with matching dimensions"},
+		{`{{1}, {1, 2}}`, types.IntArray, "multidimensional arrays must have array expressions with matching dimensions"},
+		{`{{{1}}, {{1, 2}}}`, types.MakeArray(types.IntArray), "multidimensional arrays must have array expressions with matching dimensions"},
 		{`{hello}`, types.Int, `could not parse "hello" as type int: strconv.ParseInt: parsing "hello": invalid syntax`},
 		{`{"hello}`, types.String, `malformed array`},
 		// It might be unnecessary to disallow this, but Postgres does.
@@ -66,6 +66,8 @@ func parseStringAs(t *types.T, s string, ctx ParseTimeContext) (Datum, error) {
 		return ParseDDate(ctx, s)
 	case types.DecimalFamily:
 		return ParseDDecimal(s)
+	case types.EnumFamily:
+		return MakeDEnumFromLogicalRepresentation(t, s)
 	case types.FloatFamily:
 		return ParseDFloat(s)
 	case types.INetFamily:
@@ -73,13 +75,15 @@ func parseStringAs(t *types.T, s string, ctx ParseTimeContext) (Datum, error) {
 	case types.IntFamily:
 		return ParseDInt(s)
 	case types.IntervalFamily:
-		return ParseDInterval(s)
+		return ParseDIntervalWithTypeMetadata(s, t)
 	case types.JsonFamily:
 		return ParseDJSON(s)
 	case types.StringFamily:
 		return NewDString(s), nil
 	case types.TimeFamily:
 		return ParseDTime(ctx, s)
+	case types.TimeTZFamily:
+		return ParseDTimeTZ(ctx, s)
 	case types.TimestampFamily:
 		if t.Precision() == 0 {
 			return ParseDTimestamp(ctx, s, time.Second)
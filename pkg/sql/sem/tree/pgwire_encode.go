@@ -103,6 +103,10 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 			// double escaped.
 		case *DBytes:
 			ctx.FormatNode(dv)
+		case *DArray:
+			// The elements of a multi-dimensional array are printed as nested
+			// arrays, without quoting.
+			dv.pgwireFormat(ctx)
 		default:
 			s := AsStringWithFlags(v, ctx.flags)
 			pgwireFormatStringInArray(&ctx.Buffer, s)
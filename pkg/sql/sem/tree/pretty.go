@@ -527,6 +527,29 @@ func (node *OrderBy) docRow(p *PrettyCfg) pretty.TableRow {
 	return p.row("ORDER BY", p.commaSeparated(d...))
 }
 
+func (node *LockingClause) docTable(p *PrettyCfg) []pretty.TableRow {
+	items := make([]pretty.TableRow, len(*node))
+	for i, n := range *node {
+		items[i] = p.row("", p.Doc(n))
+	}
+	return items
+}
+
+func (node *LockingItem) doc(p *PrettyCfg) pretty.Doc {
+	d := pretty.Keyword(node.Strength.String())
+	if len(node.Targets) > 0 {
+		targets := make([]pretty.Doc, len(node.Targets))
+		for i := range node.Targets {
+			targets[i] = p.Doc(&node.Targets[i])
+		}
+		d = pretty.ConcatSpace(d, pretty.ConcatSpace(pretty.Keyword("OF"), p.commaSeparated(targets...)))
+	}
+	if node.WaitPolicy != LockWaitBlock {
+		d = pretty.ConcatSpace(d, pretty.Keyword(node.WaitPolicy.String()))
+	}
+	return d
+}
+
 func (node *Select) doc(p *PrettyCfg) pretty.Doc {
 	return p.rlTable(node.docTable(p)...)
 }
@@ -541,6 +564,7 @@ func (node *Select) docTable(p *PrettyCfg) []pretty.TableRow {
 	}
 	items = append(items, node.OrderBy.docRow(p))
 	items = append(items, node.Limit.docTable(p)...)
+	items = append(items, node.Locking.docTable(p)...)
 	return items
 }
 
@@ -622,6 +646,9 @@ func (node *With) docRow(p *PrettyCfg) pretty.TableRow {
 			p.bracketKeyword("AS", " (", p.Doc(cte.Stmt), ")", ""),
 		)
 	}
+	if node.Recursive {
+		return p.row("WITH RECURSIVE", p.commaSeparated(d...))
+	}
 	return p.row("WITH", p.commaSeparated(d...))
 }
 
@@ -854,10 +881,15 @@ func (node *Insert) doc(p *PrettyCfg) pretty.Doc {
 
 	into := p.Doc(node.Table)
 	if node.Columns != nil {
-		into = p.nestUnder(into, p.bracket("(", p.Doc(&node.Columns), ")"))
+		targets := &columnTargets{names: node.Columns, fields: node.ColumnFields}
+		into = p.nestUnder(into, p.bracket("(", p.Doc(targets), ")"))
 	}
 	items = append(items, p.row("INTO", into))
 
+	if node.Overriding != OverridingNone {
+		items = append(items, p.row("", pretty.Keyword(node.Overriding.String())))
+	}
+
 	if node.DefaultValues() {
 		items = append(items, p.row("", pretty.Keyword("DEFAULT VALUES")))
 	} else {
@@ -866,10 +898,16 @@ func (node *Insert) doc(p *PrettyCfg) pretty.Doc {
 
 	if node.OnConflict != nil && !node.OnConflict.IsUpsertAlias() {
 		cond := pretty.Nil
+		if node.OnConflict.Constraint != "" {
+			cond = pretty.ConcatSpace(pretty.Keyword("ON CONSTRAINT"), p.Doc(&node.OnConflict.Constraint))
+		}
 		if len(node.OnConflict.Columns) > 0 {
 			cond = p.bracket("(", p.Doc(&node.OnConflict.Columns), ")")
 		}
 		items = append(items, p.row("ON CONFLICT", cond))
+		if node.OnConflict.ArbiterPredicate != nil {
+			items = append(items, p.row("WHERE", p.Doc(node.OnConflict.ArbiterPredicate)))
+		}
 
 		if node.OnConflict.DoNothing {
 			items = append(items, p.row("DO", pretty.Keyword("NOTHING")))
@@ -1022,7 +1060,11 @@ func (node *Update) doc(p *PrettyCfg) pretty.Doc {
 	items = append(items,
 		node.With.docRow(p),
 		p.row("UPDATE", p.Doc(node.Table)),
-		p.row("SET", p.Doc(&node.Exprs)),
+		p.row("SET", p.Doc(&node.Exprs)))
+	if len(node.From) > 0 {
+		items = append(items, p.row("FROM", node.From.doc(p)))
+	}
+	items = append(items,
 		node.Where.docRow(p),
 		node.OrderBy.docRow(p))
 	items = append(items, node.Limit.docTable(p)...)
@@ -1083,7 +1125,7 @@ func (node *Order) doc(p *PrettyCfg) pretty.Doc {
 }
 
 func (node *UpdateExpr) doc(p *PrettyCfg) pretty.Doc {
-	d := p.Doc(&node.Names)
+	d := p.Doc(&columnTargets{names: node.Names, fields: node.Fields})
 	if node.Tuple {
 		d = p.bracket("(", d, ")")
 	}
@@ -1097,12 +1139,16 @@ func (node *UpdateExpr) doc(p *PrettyCfg) pretty.Doc {
 func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	// Final layout:
 	//
-	// CREATE TABLE [IF NOT EXISTS] name ( .... ) [AS]
+	// CREATE [TEMPORARY] TABLE [IF NOT EXISTS] name ( .... ) [AS]
 	//     [SELECT ...] - for CREATE TABLE AS
 	//     [INTERLEAVE ...]
 	//     [PARTITION BY ...]
 	//
-	title := pretty.Keyword("CREATE TABLE")
+	title := pretty.Keyword("CREATE")
+	if node.Temporary {
+		title = pretty.ConcatSpace(title, pretty.Keyword("TEMPORARY"))
+	}
+	title = pretty.ConcatSpace(title, pretty.Keyword("TABLE"))
 	if node.IfNotExists {
 		title = pretty.ConcatSpace(title, pretty.Keyword("IF NOT EXISTS"))
 	}
@@ -1139,11 +1185,18 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 func (node *CreateView) doc(p *PrettyCfg) pretty.Doc {
 	// Final layout:
 	//
-	// CREATE VIEW name ( ... ) AS
+	// CREATE [TEMPORARY | MATERIALIZED] VIEW name ( ... ) AS
 	//     SELECT ...
 	//
+	title := pretty.Keyword("CREATE")
+	if node.Temporary {
+		title = pretty.ConcatSpace(title, pretty.Keyword("TEMPORARY"))
+	}
+	if node.Materialized {
+		title = pretty.ConcatSpace(title, pretty.Keyword("MATERIALIZED"))
+	}
 	d := pretty.ConcatSpace(
-		pretty.Keyword("CREATE VIEW"),
+		pretty.ConcatSpace(title, pretty.Keyword("VIEW")),
 		p.Doc(&node.Name),
 	)
 	if len(node.ColumnNames) > 0 {
@@ -1380,6 +1433,7 @@ func (node *CreateIndex) doc(p *PrettyCfg) pretty.Doc {
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
+	//    [WHERE ...]
 	//
 	title := make([]pretty.Doc, 0, 6)
 	title = append(title, pretty.Keyword("CREATE"))
@@ -1416,6 +1470,9 @@ func (node *CreateIndex) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionBy != nil {
 		clauses = append(clauses, p.Doc(node.PartitionBy))
 	}
+	if node.Predicate != nil {
+		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
+	}
 	return p.nestUnder(
 		pretty.Fold(pretty.ConcatSpace, title...),
 		pretty.Group(pretty.Stack(clauses...)))
@@ -1457,6 +1514,7 @@ func (node *IndexTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
+	//    [WHERE ...]
 	//
 	title := pretty.Keyword("INDEX")
 	if node.Name != "" {
@@ -1480,6 +1538,9 @@ func (node *IndexTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionBy != nil {
 		clauses = append(clauses, p.Doc(node.PartitionBy))
 	}
+	if node.Predicate != nil {
+		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
+	}
 
 	if len(clauses) == 0 {
 		return title
@@ -1494,6 +1555,7 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
+	//    [DEFERRABLE ...]
 	//
 	// or (no constraint name):
 	//
@@ -1501,7 +1563,13 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    [STORING ( ... )]
 	//    [INTERLEAVE ...]
 	//    [PARTITION BY ...]
+	//    [DEFERRABLE ...]
 	//
+	// Partial unique indexes use the layout of the other indexes, prefixed
+	// with UNIQUE.
+	if node.Predicate != nil {
+		return pretty.ConcatSpace(pretty.Keyword("UNIQUE"), p.Doc(&node.IndexTableDef))
+	}
 	clauses := make([]pretty.Doc, 0, 4)
 	var title pretty.Doc
 	if node.PrimaryKey {
@@ -1526,6 +1594,9 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionBy != nil {
 		clauses = append(clauses, p.Doc(node.PartitionBy))
 	}
+	if node.Deferrability != NotDeferrable {
+		clauses = append(clauses, pretty.Keyword(node.Deferrability.String()))
+	}
 
 	if len(clauses) == 0 {
 		return title
@@ -1540,6 +1611,7 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl (...)
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
 	// or (no constraint name):
 	//
@@ -1547,6 +1619,7 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl [(...)]
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
 	clauses := make([]pretty.Doc, 0, 4)
 	title := pretty.ConcatSpace(
@@ -1573,6 +1646,10 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 		clauses = append(clauses, actions)
 	}
 
+	if node.Deferrability != NotDeferrable {
+		clauses = append(clauses, pretty.Keyword(node.Deferrability.String()))
+	}
+
 	return p.nestUnder(title, pretty.Group(pretty.Stack(clauses...)))
 }
 
@@ -1594,9 +1671,10 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 	// Final layout:
 	// colname
 	//   type
-	//   [AS ( ... ) STORED]
+	//   [AS ( ... ) {STORED|VIRTUAL}]
 	//   [[CREATE [IF NOT EXISTS]] FAMILY [name]]
 	//   [[CONSTRAINT name] DEFAULT expr]
+	//   [GENERATED {ALWAYS|BY DEFAULT} AS IDENTITY [( ... )]]
 	//   [[CONSTRAINT name] {NULL|NOT NULL}]
 	//   [[CONSTRAINT name] {PRIMARY KEY|UNIQUE}]
 	//   [[CONSTRAINT name] CHECK ...]
@@ -1612,8 +1690,12 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 
 	// Compute expression (for computed columns).
 	if node.IsComputed() {
+		after := ") STORED"
+		if node.IsVirtual() {
+			after = ") VIRTUAL"
+		}
 		clauses = append(clauses, pretty.ConcatSpace(pretty.Keyword("AS"),
-			p.bracket("(", p.Doc(node.Computed.Expr), ") STORED"),
+			p.bracket("(", p.Doc(node.Computed.Expr), after),
 		))
 	}
 
@@ -1639,6 +1721,22 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 			pretty.ConcatSpace(pretty.Keyword("DEFAULT"), p.Doc(node.DefaultExpr.Expr))))
 	}
 
+	// Identity.
+	if node.IsIdentity() {
+		d := pretty.Keyword("GENERATED BY DEFAULT AS IDENTITY")
+		if node.Identity.Always {
+			d = pretty.Keyword("GENERATED ALWAYS AS IDENTITY")
+		}
+		if opts := node.Identity.SeqOptions; len(opts) > 0 {
+			optDocs := make([]pretty.Doc, len(opts))
+			for i := range opts {
+				optDocs[i] = p.Doc(&opts[i])
+			}
+			d = pretty.ConcatSpace(d, p.bracket("(", pretty.Fold(pretty.ConcatSpace, optDocs...), ")"))
+		}
+		clauses = append(clauses, d)
+	}
+
 	// NULL/NOT NULL constraint.
 	nConstraint := pretty.Nil
 	switch node.Nullable.Nullability {
@@ -1780,6 +1878,9 @@ func (node *TargetList) docRow(p *PrettyCfg) pretty.TableRow {
 	if node.Databases != nil {
 		return p.row("DATABASE", p.Doc(&node.Databases))
 	}
+	if node.Functions != nil {
+		return p.row("FUNCTION", p.Doc(&node.Functions))
+	}
 	return p.row("TABLE", p.Doc(&node.Tables))
 }
 
@@ -18,6 +18,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 )
@@ -61,6 +62,8 @@ func SampleDatum(t *types.T) Datum {
 		return NewDDate(pgdate.MakeCompatibleDateFromDisk(123123))
 	case types.TimeFamily:
 		return MakeDTime(timeofday.FromInt(789))
+	case types.TimeTZFamily:
+		return NewDTimeTZ(timetz.MakeTimeTZ(timeofday.FromInt(789), 0))
 	case types.TimestampFamily:
 		return MakeDTimestamp(timeutil.Unix(123, 123), time.Second)
 	case types.TimestampTZFamily:
@@ -59,9 +59,57 @@ type SemaContext struct {
 	// globally for the entire txn and this field would not be needed.
 	AsOfTimestamp *hlc.Timestamp
 
+	// TypeResolver is used to resolve references to user-defined types. If it
+	// is nil, such references cannot be resolved.
+	TypeResolver TypeReferenceResolver
+
+	// FunctionResolver is used to resolve references to user-defined
+	// functions. If it is nil, only built-in functions can be used.
+	FunctionResolver FunctionReferenceResolver
+
 	Properties SemaProperties
 }
 
+// TypeReferenceResolver resolves references to user-defined types.
+type TypeReferenceResolver interface {
+	// ResolveType returns the user-defined type with the given name.
+	ResolveType(name string) (*types.T, error)
+}
+
+// FunctionReferenceResolver resolves references to user-defined functions.
+type FunctionReferenceResolver interface {
+	// ResolveFunction returns the definition of the user-defined function
+	// with the given name, or nil if there is no such function.
+	ResolveFunction(name *UnresolvedName) (*FunctionDefinition, error)
+}
+
+// ResolveType returns the type that typ refers to. References to user-defined
+// types (see types.MakeUnresolvedType) are resolved with the TypeResolver of
+// the given context; other types are returned unchanged.
+func ResolveType(typ *types.T, ctx *SemaContext) (*types.T, error) {
+	if typ.Family() == types.ArrayFamily {
+		contents, err := ResolveType(typ.ArrayContents(), ctx)
+		if err != nil {
+			return nil, err
+		}
+		if contents == typ.ArrayContents() {
+			return typ, nil
+		}
+		if err := types.CheckArrayElementType(contents); err != nil {
+			return nil, err
+		}
+		return types.MakeArray(contents), nil
+	}
+	name := typ.UnresolvedName()
+	if name == "" {
+		return typ, nil
+	}
+	if ctx == nil || ctx.TypeResolver == nil {
+		return nil, pgerror.Newf(pgerror.CodeUndefinedObjectError, "type %q does not exist", name)
+	}
+	return ctx.TypeResolver.ResolveType(name)
+}
+
 // SemaProperties is a holder for required and derived properties
 // during semantic analysis. It provides scoping semantics via its
 // Restore() method, see below.
@@ -422,6 +470,18 @@ func isCastDeepValid(castFrom, castTo *types.T) (bool, telemetry.Counter) {
 		}
 		return ok, c
 	}
+	if castTo.Family() == types.TupleFamily && castFrom.Family() == types.TupleFamily {
+		// Tuples can only be cast to composite types, and each of their fields
+		// must be castable to the type of the corresponding field.
+		if castTo.CompositeData() == nil || len(castFrom.TupleContents()) != len(castTo.TupleContents()) {
+			return false, nil
+		}
+		for i := range castTo.TupleContents() {
+			if ok, _ := isCastDeepValid(&castFrom.TupleContents()[i], &castTo.TupleContents()[i]); !ok {
+				return false, nil
+			}
+		}
+	}
 	for _, t := range validCastTypes(castTo) {
 		if castFrom.Family() == t.fromT.Family() {
 			return true, t.counter
@@ -437,6 +497,18 @@ func isEmptyArray(expr Expr) bool {
 
 // TypeCheck implements the Expr interface.
 func (expr *CastExpr) TypeCheck(ctx *SemaContext, _ *types.T) (TypedExpr, error) {
+	typ, err := ResolveType(expr.Type, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if typ != expr.Type {
+		// Resolve the type in a copy, so that the statement is resolved again
+		// if it is type checked again.
+		exprCopy := *expr
+		exprCopy.Type = typ
+		expr = &exprCopy
+	}
+
 	// The desired type provided to a CastExpr is ignored. Instead,
 	// types.Any is passed to the child of the cast. There are two
 	// exceptions, described below.
@@ -452,8 +524,8 @@ func (expr *CastExpr) TypeCheck(ctx *SemaContext, _ *types.T) (TypedExpr, error)
 			// If the type doesn't have any possible parameters (like length,
 			// precision), the CastExpr becomes a no-op and can be elided.
 			switch expr.Type.Family() {
-			case types.BoolFamily, types.DateFamily, types.TimeFamily, types.TimestampFamily, types.TimestampTZFamily,
-				types.IntervalFamily, types.BytesFamily:
+			case types.BoolFamily, types.DateFamily, types.TimeFamily, types.TimeTZFamily, types.TimestampFamily,
+				types.TimestampTZFamily, types.IntervalFamily, types.BytesFamily:
 				return expr.Expr.TypeCheck(ctx, expr.Type)
 			}
 		}
@@ -492,31 +564,35 @@ func (expr *CastExpr) TypeCheck(ctx *SemaContext, _ *types.T) (TypedExpr, error)
 
 // TypeCheck implements the Expr interface.
 func (expr *IndirectionExpr) TypeCheck(ctx *SemaContext, desired *types.T) (TypedExpr, error) {
-	for i, t := range expr.Indirection {
+	desiredArray := desired
+	for _, t := range expr.Indirection {
 		if t.Slice {
 			return nil, pgerror.UnimplementedWithIssuef(32551, "ARRAY slicing in %s", expr)
 		}
-		if i > 0 {
-			return nil, pgerror.UnimplementedWithIssueDetailf(32552, "ind", "multidimensional indexing: %s", expr)
-		}
 
 		beginExpr, err := typeCheckAndRequire(ctx, t.Begin, types.Int, "ARRAY subscript")
 		if err != nil {
 			return nil, err
 		}
 		t.Begin = beginExpr
+		desiredArray = types.MakeArray(desiredArray)
 	}
 
-	subExpr, err := expr.Expr.TypeCheck(ctx, types.MakeArray(desired))
+	subExpr, err := expr.Expr.TypeCheck(ctx, desiredArray)
 	if err != nil {
 		return nil, err
 	}
+
+	// Each subscript indexes into one dimension of the array.
 	typ := subExpr.ResolvedType()
-	if typ.Family() != types.ArrayFamily {
-		return nil, pgerror.Newf(pgerror.CodeDatatypeMismatchError, "cannot subscript type %s because it is not an array", typ)
+	for range expr.Indirection {
+		if typ.Family() != types.ArrayFamily {
+			return nil, pgerror.Newf(pgerror.CodeDatatypeMismatchError, "cannot subscript type %s because it is not an array", typ)
+		}
+		typ = typ.ArrayContents()
 	}
 	expr.Expr = subExpr
-	expr.typ = typ.ArrayContents()
+	expr.typ = typ
 
 	telemetry.Inc(sqltelemetry.ArraySubscriptCounter)
 	return expr, nil
@@ -524,6 +600,17 @@ func (expr *IndirectionExpr) TypeCheck(ctx *SemaContext, desired *types.T) (Type
 
 // TypeCheck implements the Expr interface.
 func (expr *AnnotateTypeExpr) TypeCheck(ctx *SemaContext, desired *types.T) (TypedExpr, error) {
+	typ, err := ResolveType(expr.Type, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if typ != expr.Type {
+		// See CastExpr.TypeCheck.
+		exprCopy := *expr
+		exprCopy.Type = typ
+		expr = &exprCopy
+	}
+
 	subExpr, err := typeCheckAndRequire(ctx, expr.Expr, expr.Type,
 		fmt.Sprintf("type annotation for %v as %s, found", expr.Expr, expr.Type))
 	if err != nil {
@@ -806,11 +893,7 @@ func CheckIsWindowOrAgg(def *FunctionDefinition) error {
 
 // TypeCheck implements the Expr interface.
 func (expr *FuncExpr) TypeCheck(ctx *SemaContext, desired *types.T) (TypedExpr, error) {
-	var searchPath sessiondata.SearchPath
-	if ctx != nil {
-		searchPath = ctx.SearchPath
-	}
-	def, err := expr.Func.Resolve(searchPath)
+	def, err := expr.Func.ResolveInContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1066,6 +1149,25 @@ func (expr *IfExpr) TypeCheck(ctx *SemaContext, desired *types.T) (TypedExpr, er
 
 // TypeCheck implements the Expr interface.
 func (expr *IsOfTypeExpr) TypeCheck(ctx *SemaContext, desired *types.T) (TypedExpr, error) {
+	var resolvedTypes []*types.T
+	for i, typ := range expr.Types {
+		resolved, err := ResolveType(typ, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if resolved != typ && resolvedTypes == nil {
+			resolvedTypes = append([]*types.T(nil), expr.Types...)
+		}
+		if resolvedTypes != nil {
+			resolvedTypes[i] = resolved
+		}
+	}
+	if resolvedTypes != nil {
+		// See CastExpr.TypeCheck.
+		exprCopy := *expr
+		exprCopy.Types = resolvedTypes
+		expr = &exprCopy
+	}
 	exprTyped, err := expr.Expr.TypeCheck(ctx, types.Any)
 	if err != nil {
 		return nil, err
@@ -1426,6 +1528,10 @@ func (d *DBytes) TypeCheck(_ *SemaContext, _ *types.T) (TypedExpr, error) { retu
 // identity function for Datum.
 func (d *DUuid) TypeCheck(_ *SemaContext, _ *types.T) (TypedExpr, error) { return d, nil }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DEnum) TypeCheck(_ *SemaContext, _ *types.T) (TypedExpr, error) { return d, nil }
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DIPAddr) TypeCheck(_ *SemaContext, _ *types.T) (TypedExpr, error) { return d, nil }
@@ -1438,6 +1544,10 @@ func (d *DDate) TypeCheck(_ *SemaContext, _ *types.T) (TypedExpr, error) { retur
 // identity function for Datum.
 func (d *DTime) TypeCheck(_ *SemaContext, _ *types.T) (TypedExpr, error) { return d, nil }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DTimeTZ) TypeCheck(_ *SemaContext, _ *types.T) (TypedExpr, error) { return d, nil }
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTimestamp) TypeCheck(_ *SemaContext, _ *types.T) (TypedExpr, error) { return d, nil }
@@ -26,6 +26,7 @@ type Update struct {
 	With      *With
 	Table     TableExpr
 	Exprs     UpdateExprs
+	From      TableExprs
 	Where     *Where
 	OrderBy   OrderBy
 	Limit     *Limit
@@ -39,6 +40,10 @@ func (node *Update) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.Table)
 	ctx.WriteString(" SET ")
 	ctx.FormatNode(&node.Exprs)
+	if len(node.From) > 0 {
+		ctx.WriteString(" FROM ")
+		ctx.FormatNode(&node.From)
+	}
 	if node.Where != nil {
 		ctx.WriteByte(' ')
 		ctx.FormatNode(node.Where)
@@ -74,7 +79,11 @@ func (node *UpdateExprs) Format(ctx *FmtCtx) {
 type UpdateExpr struct {
 	Tuple bool
 	Names NameList
-	Expr  Expr
+	// Fields is nil unless some of the targets are fields of columns of
+	// composite types, as in `SET p.x = 1`. It then parallels Names and
+	// contains the empty name for the columns that are assigned entirely.
+	Fields NameList
+	Expr   Expr
 }
 
 // Format implements the NodeFormatter interface.
@@ -84,8 +93,29 @@ func (node *UpdateExpr) Format(ctx *FmtCtx) {
 		open, close = "(", ")"
 	}
 	ctx.WriteString(open)
-	ctx.FormatNode(&node.Names)
+	ctx.FormatNode(&columnTargets{names: node.Names, fields: node.Fields})
 	ctx.WriteString(close)
 	ctx.WriteString(" = ")
 	ctx.FormatNode(node.Expr)
 }
+
+// columnTargets formats the target columns of an INSERT or of an UPDATE SET
+// clause, along with the fields of composite columns that they assign.
+type columnTargets struct {
+	names  NameList
+	fields NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *columnTargets) Format(ctx *FmtCtx) {
+	for i := range node.names {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&node.names[i])
+		if node.fields != nil && node.fields[i] != "" {
+			ctx.WriteByte('.')
+			ctx.FormatNode(&node.fields[i])
+		}
+	}
+}
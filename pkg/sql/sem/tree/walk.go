@@ -620,6 +620,9 @@ func (expr *DDate) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DTime) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DTimeTZ) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DFloat) Walk(_ Visitor) Expr { return expr }
 
@@ -638,6 +641,9 @@ func (expr *DJSON) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DUuid) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DEnum) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DIPAddr) Walk(_ Visitor) Expr { return expr }
 
@@ -1410,6 +1416,17 @@ func SimpleVisit(expr Expr, preFn SimpleVisitFn) (Expr, error) {
 	return newExpr, nil
 }
 
+// SimpleStmtVisit is like SimpleVisit, but it visits the expressions of a
+// statement. See walkStmt for the parts of the statement that are visited.
+func SimpleStmtVisit(stmt Statement, preFn SimpleVisitFn) (Statement, error) {
+	v := simpleVisitor{fn: preFn}
+	newStmt, _ := walkStmt(&v, stmt)
+	if v.err != nil {
+		return nil, v.err
+	}
+	return newStmt, nil
+}
+
 type debugVisitor struct {
 	buf   bytes.Buffer
 	level int
@@ -260,6 +260,8 @@ func assignSequenceOptions(
 			opts.Start = *option.IntVal
 		case tree.SeqOptVirtual:
 			opts.Virtual = true
+		case tree.SeqOptOwnedBy:
+			// Do nothing; this is handled by assignSequenceOwner.
 		}
 	}
 
@@ -369,6 +371,138 @@ func removeSequenceDependencies(
 	return nil
 }
 
+// setSequenceOwner makes the given column of the table with the given ID the
+// owner of the sequence, so that the sequence is dropped along with the column
+// or its table. The column descriptor is mutated but not saved to persistent
+// storage; the caller must save it, and the sequence descriptor.
+func setSequenceOwner(
+	seqDesc *sqlbase.MutableTableDescriptor, tableID sqlbase.ID, col *sqlbase.ColumnDescriptor,
+) {
+	seqDesc.SequenceOpts.SequenceOwner = sqlbase.TableDescriptor_SequenceOpts_SequenceOwner{
+		OwnerTableID:  tableID,
+		OwnerColumnID: col.ID,
+	}
+	col.OwnsSequenceIds = append(col.OwnsSequenceIds, seqDesc.ID)
+}
+
+// removeSequenceOwnership removes the reference from the column that owns the
+// sequence, if any, to the sequence, and writes the table descriptor of the
+// column. The sequence descriptor is mutated but not saved to persistent
+// storage; the caller must save it.
+func (p *planner) removeSequenceOwnership(
+	ctx context.Context, seqDesc *sqlbase.MutableTableDescriptor,
+) error {
+	owner := seqDesc.SequenceOpts.SequenceOwner
+	if owner.OwnerTableID == sqlbase.InvalidID {
+		return nil
+	}
+	seqDesc.SequenceOpts.SequenceOwner = sqlbase.TableDescriptor_SequenceOpts_SequenceOwner{}
+
+	tableDesc, err := p.Tables().getMutableTableVersionByID(ctx, owner.OwnerTableID, p.txn)
+	if err != nil {
+		return err
+	}
+	// The table is being dropped along with the sequences it owns.
+	if tableDesc.Dropped() {
+		return nil
+	}
+	col, err := tableDesc.FindColumnByID(owner.OwnerColumnID)
+	if err != nil {
+		return err
+	}
+	for i, id := range col.OwnsSequenceIds {
+		if id == seqDesc.ID {
+			col.OwnsSequenceIds = append(col.OwnsSequenceIds[:i], col.OwnsSequenceIds[i+1:]...)
+			return p.writeSchemaChange(ctx, tableDesc, sqlbase.InvalidMutationID)
+		}
+	}
+	return pgerror.AssertionFailedf("couldn't find reference from column to this sequence")
+}
+
+// assignSequenceOwner makes the column named by the OWNED BY option, if any,
+// the owner of the sequence, in place of its current owner. OWNED BY NONE
+// removes the current owner. The sequence descriptor is mutated but not saved
+// to persistent storage; the caller must save it.
+func (p *planner) assignSequenceOwner(
+	ctx context.Context, seqDesc *sqlbase.MutableTableDescriptor, optsNode tree.SequenceOptions,
+) error {
+	for _, option := range optsNode {
+		if option.Name != tree.SeqOptOwnedBy {
+			continue
+		}
+		colItem := option.ColumnItemVal
+		if colItem != nil && colItem.TableName == nil {
+			return pgerror.New(pgerror.CodeInvalidParameterValueError,
+				"invalid OWNED BY option").SetHintf(
+				"Specify OWNED BY table.column or OWNED BY NONE.")
+		}
+		if err := p.removeSequenceOwnership(ctx, seqDesc); err != nil {
+			return err
+		}
+		if colItem == nil {
+			// OWNED BY NONE.
+			continue
+		}
+
+		tn := colItem.TableName.ToTableName()
+		tableDesc, err := p.ResolveMutableTableDescriptor(ctx, &tn, true /* required */, ResolveRequireTableDesc)
+		if err != nil {
+			return err
+		}
+		if tableDesc.ParentID != seqDesc.ParentID {
+			return pgerror.New(pgerror.CodeObjectNotInPrerequisiteStateError,
+				"sequence must be in same database as table it is linked to")
+		}
+		if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+			return err
+		}
+		col, dropped, err := tableDesc.FindColumnByName(colItem.ColumnName)
+		if err != nil {
+			return err
+		}
+		if dropped {
+			return pgerror.Newf(pgerror.CodeObjectNotInPrerequisiteStateError,
+				"column %q is being dropped", col.Name)
+		}
+		setSequenceOwner(seqDesc, tableDesc.ID, col)
+		if err := p.writeSchemaChange(ctx, tableDesc, sqlbase.InvalidMutationID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropSequencesOwnedByCol drops the sequences owned by the given column, which
+// is being dropped. It must be called after the dependencies of the column on
+// sequences have been removed, since a sequence used by another column cannot
+// be dropped. The column descriptor is mutated but not saved to persistent
+// storage; the caller must save it.
+func (p *planner) dropSequencesOwnedByCol(
+	ctx context.Context, col *sqlbase.ColumnDescriptor,
+) error {
+	for _, seqID := range col.OwnsSequenceIds {
+		seqDesc, err := p.Tables().getMutableTableVersionByID(ctx, seqID, p.txn)
+		if err != nil {
+			return err
+		}
+		// The sequence may already have been dropped by the same statement,
+		// e.g. by DROP DATABASE.
+		if seqDesc.Dropped() {
+			continue
+		}
+		if err := p.sequenceDependencyError(ctx, seqDesc); err != nil {
+			return err
+		}
+		// The column is being dropped; there is no need to update it.
+		seqDesc.SequenceOpts.SequenceOwner = sqlbase.TableDescriptor_SequenceOpts_SequenceOwner{}
+		if err := p.dropSequenceImpl(ctx, seqDesc, tree.DropDefault); err != nil {
+			return err
+		}
+	}
+	col.OwnsSequenceIds = nil
+	return nil
+}
+
 // getUsedSequenceNames returns the name of the sequence passed to
 // a call to nextval in the given expression, or nil if there is
 // no call to nextval.
@@ -15,6 +15,7 @@ package sql
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -40,7 +41,8 @@ var virtualSequenceOpts = tree.SequenceOptions{
 }
 
 // processSerialInColumnDef analyzes a column definition and determines
-// whether to use a sequence if the requested type is SERIAL-like.
+// whether to use a sequence if the requested type is SERIAL-like, or if
+// the column is an identity column.
 // If a sequence must be created, it returns an ObjectName to use
 // to create the new sequence and the DatabaseDescriptor of the
 // parent database where it should be created.
@@ -48,6 +50,10 @@ var virtualSequenceOpts = tree.SequenceOptions{
 func (p *planner) processSerialInColumnDef(
 	ctx context.Context, d *tree.ColumnTableDef, tableName *ObjectName,
 ) (*tree.ColumnTableDef, *DatabaseDescriptor, *ObjectName, tree.SequenceOptions, error) {
+	if d.IsIdentity() {
+		return p.processIdentityInColumnDef(ctx, d, tableName)
+	}
+
 	if !d.IsSerial {
 		// Column is not SERIAL: nothing to do.
 		return d, nil, nil, nil, nil
@@ -100,8 +106,92 @@ func (p *planner) processSerialInColumnDef(
 
 	log.VEventf(ctx, 2, "creating sequence for new column %q of %q", d, tableName)
 
-	// We want a sequence; for this we need to generate a new sequence name.
-	// The constraint on the name is that an object of this name must not exist already.
+	dbDesc, seqName, err := p.makeColumnSequenceName(ctx, d, tableName)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	defaultExpr := makeNextvalExpr(seqName)
+
+	seqType := ""
+	seqOpts := realSequenceOpts
+	if serialNormalizationMode == sessiondata.SerialUsesVirtualSequences {
+		seqType = "virtual "
+		seqOpts = virtualSequenceOpts
+	}
+	log.VEventf(ctx, 2, "new column %q of %q will have %ssequence name %q and default %q",
+		d, tableName, seqType, seqName, defaultExpr)
+
+	newSpec.DefaultExpr.Expr = defaultExpr
+
+	return &newSpec, dbDesc, seqName, seqOpts, nil
+}
+
+// processIdentityInColumnDef is the equivalent of processSerialInColumnDef
+// for identity columns. The values of an identity column are always taken
+// from a new sequence, regardless of the SerialNormalizationMode, which is
+// created with the sequence options of the column definition. The caller is
+// responsible for making the column the owner of the sequence.
+func (p *planner) processIdentityInColumnDef(
+	ctx context.Context, d *tree.ColumnTableDef, tableName *ObjectName,
+) (*tree.ColumnTableDef, *DatabaseDescriptor, *ObjectName, tree.SequenceOptions, error) {
+	if err := assertValidIdentityColumnDef(d, tableName); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	newSpec := *d
+	newSpec.Nullable.Nullability = tree.NotNull
+
+	log.VEventf(ctx, 2, "creating sequence for new identity column %q of %q", d, tableName)
+
+	dbDesc, seqName, err := p.makeColumnSequenceName(ctx, d, tableName)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	newSpec.DefaultExpr.Expr = makeNextvalExpr(seqName)
+
+	return &newSpec, dbDesc, seqName, identitySequenceOptions(d), nil
+}
+
+// identitySequenceOptions returns the options of the sequence of an identity
+// column. Unless they are specified, the bounds of the sequence are the bounds
+// of the column type, like in Postgres.
+func identitySequenceOptions(d *tree.ColumnTableDef) tree.SequenceOptions {
+	opts := d.Identity.SeqOptions
+	if d.Type.Width() == 64 {
+		return opts
+	}
+	bound := int64(math.MaxInt32)
+	if d.Type.Width() == 16 {
+		bound = math.MaxInt16
+	}
+	descending, hasMin, hasMax := false, false, false
+	for _, opt := range opts {
+		switch opt.Name {
+		case tree.SeqOptIncrement:
+			descending = *opt.IntVal < 0
+		case tree.SeqOptMinValue:
+			hasMin = true
+		case tree.SeqOptMaxValue:
+			hasMax = true
+		}
+	}
+	opts = append(tree.SequenceOptions(nil), opts...)
+	if descending && !hasMin {
+		minValue := -bound - 1
+		opts = append(opts, tree.SequenceOption{Name: tree.SeqOptMinValue, IntVal: &minValue})
+	} else if !descending && !hasMax {
+		opts = append(opts, tree.SequenceOption{Name: tree.SeqOptMaxValue, IntVal: &bound})
+	}
+	return opts
+}
+
+// makeColumnSequenceName generates the name of a new sequence for a column.
+// The constraint on the name is that an object of this name must not exist
+// already. It also returns the descriptor of the database where the sequence
+// should be created.
+func (p *planner) makeColumnSequenceName(
+	ctx context.Context, d *tree.ColumnTableDef, tableName *ObjectName,
+) (*DatabaseDescriptor, *ObjectName, error) {
 	seqName := tree.NewUnqualifiedTableName(
 		tree.Name(tableName.Table() + "_" + string(d.Name) + "_seq"))
 
@@ -113,7 +203,7 @@ func (p *planner) processSerialInColumnDef(
 	// descriptor was written already in an early txn attempt.
 	dbDesc, err := p.ResolveUncachedDatabase(ctx, seqName)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, err
 	}
 	// Now skip over all names that are already taken.
 	nameBase := seqName.TableName
@@ -123,30 +213,20 @@ func (p *planner) processSerialInColumnDef(
 		}
 		res, err := p.ResolveUncachedTableDescriptor(ctx, seqName, false /*required*/, ResolveAnyDescType)
 		if err != nil {
-			return nil, nil, nil, nil, err
+			return nil, nil, err
 		}
 		if res == nil {
 			break
 		}
 	}
+	return dbDesc, seqName, nil
+}
 
-	defaultExpr := &tree.FuncExpr{
+func makeNextvalExpr(seqName *ObjectName) tree.Expr {
+	return &tree.FuncExpr{
 		Func:  tree.WrapFunction("nextval"),
 		Exprs: tree.Exprs{tree.NewStrVal(seqName.Table())},
 	}
-
-	seqType := ""
-	seqOpts := realSequenceOpts
-	if serialNormalizationMode == sessiondata.SerialUsesVirtualSequences {
-		seqType = "virtual "
-		seqOpts = virtualSequenceOpts
-	}
-	log.VEventf(ctx, 2, "new column %q of %q will have %ssequence name %q and default %q",
-		d, tableName, seqType, seqName, defaultExpr)
-
-	newSpec.DefaultExpr.Expr = defaultExpr
-
-	return &newSpec, dbDesc, seqName, seqOpts, nil
 }
 
 // SimplifySerialInColumnDefWithRowID analyzes a column definition and
@@ -154,10 +234,22 @@ func (p *planner) processSerialInColumnDef(
 // to SerialUsesRowID. No sequence needs to be created.
 //
 // This is currently used by bulk I/O import statements which do not
-// (yet?) support customization of the SERIAL behavior.
+// (yet?) support customization of the SERIAL behavior. Identity columns
+// are simplified in the same way, but keep their GENERATED ALWAYS or BY
+// DEFAULT behavior.
 func SimplifySerialInColumnDefWithRowID(
 	ctx context.Context, d *tree.ColumnTableDef, tableName *ObjectName,
 ) error {
+	if d.IsIdentity() {
+		if err := assertValidIdentityColumnDef(d, tableName); err != nil {
+			return err
+		}
+		d.Nullable.Nullability = tree.NotNull
+		d.Type = types.Int
+		d.DefaultExpr.Expr = uniqueRowIDExpr
+		return nil
+	}
+
 	if !d.IsSerial {
 		// Column is not SERIAL: nothing to do.
 		return nil
@@ -208,3 +300,27 @@ func assertValidSerialColumnDef(d *tree.ColumnTableDef, tableName *ObjectName) e
 
 	return nil
 }
+
+func assertValidIdentityColumnDef(d *tree.ColumnTableDef, tableName *ObjectName) error {
+	if d.Type.Family() != types.IntFamily {
+		return pgerror.Newf(pgerror.CodeInvalidParameterValueError,
+			"identity column type must be smallint, integer, or bigint")
+	}
+
+	if d.Nullable.Nullability == tree.Null {
+		// This is the error produced by pg in such case.
+		return pgerror.Newf(pgerror.CodeSyntaxError,
+			"conflicting NULL/NOT NULL declarations for column %q of table %q",
+			tree.ErrString(&d.Name), tree.ErrString(tableName))
+	}
+
+	for _, opt := range d.Identity.SeqOptions {
+		if opt.Name == tree.SeqOptOwnedBy || opt.Name == tree.SeqOptVirtual {
+			return pgerror.Newf(pgerror.CodeSyntaxError,
+				"%s cannot be specified for the sequence of identity column %q of table %q",
+				opt.Name, tree.ErrString(&d.Name), tree.ErrString(tableName))
+		}
+	}
+
+	return nil
+}
@@ -24,26 +24,35 @@ const DefaultDatabaseName = "defaultdb"
 // PgCatalogName is the name of the pg_catalog system schema.
 const PgCatalogName = "pg_catalog"
 
+// PgTempSchemaName is the alias for the temporary schema of the current
+// session, which contains its temporary tables and views.
+const PgTempSchemaName = "pg_temp"
+
 // SearchPath represents a list of namespaces to search builtins in.
 // The names must be normalized (as per Name.Normalize) already.
 type SearchPath struct {
-	paths             []string
-	containsPgCatalog bool
+	paths                []string
+	containsPgCatalog    bool
+	containsPgTempSchema bool
 }
 
 // MakeSearchPath returns a new immutable SearchPath struct. The paths slice
 // must not be modified after hand-off to MakeSearchPath.
 func MakeSearchPath(paths []string) SearchPath {
 	containsPgCatalog := false
+	containsPgTempSchema := false
 	for _, e := range paths {
-		if e == PgCatalogName {
+		switch e {
+		case PgCatalogName:
 			containsPgCatalog = true
-			break
+		case PgTempSchemaName:
+			containsPgTempSchema = true
 		}
 	}
 	return SearchPath{
-		paths:             paths,
-		containsPgCatalog: containsPgCatalog,
+		paths:                paths,
+		containsPgCatalog:    containsPgCatalog,
+		containsPgTempSchema: containsPgTempSchema,
 	}
 }
 
@@ -56,16 +65,32 @@ func MakeSearchPath(paths []string) SearchPath {
 // will be searched before searching any of the path items."
 // - https://www.postgresql.org/docs/9.1/static/runtime-config-client.html
 func (s SearchPath) Iter() SearchPathIter {
-	if s.containsPgCatalog {
-		return SearchPathIter{paths: s.paths, i: 0}
-	}
-	return SearchPathIter{paths: s.paths, i: -1}
+	return SearchPathIter{paths: s.paths, implicitPgCatalog: !s.containsPgCatalog}
 }
 
 // IterWithoutImplicitPGCatalog is the same as Iter, but does not include the
 // implicit pg_catalog.
 func (s SearchPath) IterWithoutImplicitPGCatalog() SearchPathIter {
-	return SearchPathIter{paths: s.paths, i: 0}
+	return SearchPathIter{paths: s.paths}
+}
+
+// IterWithTemporarySchema is the same as Iter, but also includes the
+// temporary schema of the session at the beginning of the search path, unless
+// it has been explicitly set later by the user. It is meant to be used to
+// resolve relation names.
+// "Likewise, the current session's temporary-table schema, pg_temp_nnn, is
+// searched if it exists. It can be explicitly listed in the path by using the
+// alias pg_temp. If it is not listed in the path then it is searched first
+// (even before pg_catalog). However, the temporary schema is only searched for
+// relation and data type names. It is never searched for function or operator
+// names."
+// - https://www.postgresql.org/docs/9.1/static/runtime-config-client.html
+func (s SearchPath) IterWithTemporarySchema() SearchPathIter {
+	return SearchPathIter{
+		paths:                s.paths,
+		implicitPgCatalog:    !s.containsPgCatalog,
+		implicitPgTempSchema: !s.containsPgTempSchema,
+	}
 }
 
 // GetPathArray returns the underlying path array of this SearchPath. The
@@ -76,7 +101,8 @@ func (s SearchPath) GetPathArray() []string {
 
 // Equals returns true if two SearchPaths are the same.
 func (s SearchPath) Equals(other *SearchPath) bool {
-	if s.containsPgCatalog != other.containsPgCatalog {
+	if s.containsPgCatalog != other.containsPgCatalog ||
+		s.containsPgTempSchema != other.containsPgTempSchema {
 		return false
 	}
 	if len(s.paths) != len(other.paths) {
@@ -102,14 +128,20 @@ func (s SearchPath) String() string {
 // iterator, and then repeatedly call the Next method in order to iterate over
 // each search path.
 type SearchPathIter struct {
-	paths []string
-	i     int
+	paths                []string
+	implicitPgCatalog    bool
+	implicitPgTempSchema bool
+	i                    int
 }
 
 // Next returns the next search path, or false if there are no remaining paths.
 func (iter *SearchPathIter) Next() (path string, ok bool) {
-	if iter.i == -1 {
-		iter.i++
+	if iter.implicitPgTempSchema {
+		iter.implicitPgTempSchema = false
+		return PgTempSchemaName, true
+	}
+	if iter.implicitPgCatalog {
+		iter.implicitPgCatalog = false
 		return PgCatalogName, true
 	}
 	if iter.i < len(iter.paths) {
@@ -59,6 +59,33 @@ func TestImpliedSearchPath(t *testing.T) {
 	}
 }
 
+func TestSearchPathWithTemporarySchema(t *testing.T) {
+	testCases := []struct {
+		explicitSearchPath []string
+		expectedSearchPath []string
+	}{
+		{[]string{}, []string{`pg_temp`, `pg_catalog`}},
+		{[]string{`foobar`}, []string{`pg_temp`, `pg_catalog`, `foobar`}},
+		{[]string{`foobar`, `pg_catalog`}, []string{`pg_temp`, `foobar`, `pg_catalog`}},
+		{[]string{`foobar`, `pg_temp`}, []string{`pg_catalog`, `foobar`, `pg_temp`}},
+		{[]string{`pg_catalog`, `pg_temp`, `foobar`}, []string{`pg_catalog`, `pg_temp`, `foobar`}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(strings.Join(tc.explicitSearchPath, ","), func(t *testing.T) {
+			searchPath := MakeSearchPath(tc.explicitSearchPath)
+			actualSearchPath := make([]string, 0)
+			iter := searchPath.IterWithTemporarySchema()
+			for p, ok := iter.Next(); ok; p, ok = iter.Next() {
+				actualSearchPath = append(actualSearchPath, p)
+			}
+			if !reflect.DeepEqual(tc.expectedSearchPath, actualSearchPath) {
+				t.Errorf(`Expected search path to be %#v, but was %#v.`, tc.expectedSearchPath, actualSearchPath)
+			}
+		})
+	}
+}
+
 func TestSearchPathEquals(t *testing.T) {
 	a1 := MakeSearchPath([]string{"x", "y", "z"})
 	a2 := MakeSearchPath([]string{"x", "y", "z"})
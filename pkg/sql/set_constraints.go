@@ -0,0 +1,30 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// SetConstraints sets the mode of all the deferrable constraints for the rest
+// of the transaction. Constraints that become immediate are validated at the
+// end of the statement.
+func (p *planner) SetConstraints(n *tree.SetConstraints) (planNode, error) {
+	if p.extendedEvalCtx.DeferredConstraints == nil {
+		return nil, pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+			"SET CONSTRAINTS cannot be used outside of a session")
+	}
+	p.extendedEvalCtx.DeferredConstraints.setMode(n.Deferred)
+	return newZeroNode(nil /* columns */), nil
+}
@@ -30,14 +30,21 @@ func ShowCreateView(
 	ctx context.Context, tn *tree.Name, desc *sqlbase.TableDescriptor,
 ) (string, error) {
 	f := tree.NewFmtCtx(tree.FmtSimple)
-	f.WriteString("CREATE VIEW ")
+	if desc.MaterializedView() {
+		f.WriteString("CREATE MATERIALIZED VIEW ")
+	} else {
+		f.WriteString("CREATE VIEW ")
+	}
 	f.FormatNode(tn)
 	f.WriteString(" (")
-	for i := range desc.Columns {
+	// The hidden rowid column of a materialized view is not part of its
+	// definition.
+	cols := desc.VisibleColumns()
+	for i := range cols {
 		if i > 0 {
 			f.WriteString(", ")
 		}
-		f.FormatNameP(&desc.Columns[i].Name)
+		f.FormatNameP(&cols[i].Name)
 	}
 	f.WriteString(") AS ")
 	f.WriteString(desc.ViewQuery)
@@ -101,6 +108,10 @@ func printForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(fk.OnUpdate.String())
 	}
+	if fk.IsDeferrable() {
+		buf.WriteByte(' ')
+		buf.WriteString(sqlbase.TreeConstraintDeferrabilityValue[fk.Deferrability].String())
+	}
 	return nil
 }
 
@@ -181,7 +192,22 @@ func ShowCreateTable(
 		if idx.ID != desc.PrimaryIndex.ID {
 			// Showing the primary index is handled above.
 			f.WriteString(",\n\t")
-			f.WriteString(idx.SQLString(&sqlbase.AnonymousTable))
+			if idx.IsDeferrableUnique() {
+				// Deferrable unique indexes can only be defined with the
+				// constraint syntax.
+				f.WriteString("CONSTRAINT ")
+				f.FormatNameP(&idx.Name)
+				f.WriteString(" UNIQUE (")
+				idx.ColNamesFormat(f)
+				f.WriteByte(')')
+				if len(idx.StoreColumnNames) > 0 {
+					f.WriteString(" STORING (")
+					formatQuoteNames(&f.Buffer, idx.StoreColumnNames...)
+					f.WriteByte(')')
+				}
+			} else {
+				f.WriteString(idx.SQLString(&sqlbase.AnonymousTable))
+			}
 			// Showing the INTERLEAVE and PARTITION BY for the primary index are
 			// handled last.
 			if err := showCreateInterleave(ctx, idx, &f.Buffer, dbPrefix, lCtx); err != nil {
@@ -192,6 +218,14 @@ func ShowCreateTable(
 			); err != nil {
 				return "", err
 			}
+			if idx.IsPartial() {
+				f.WriteString(" WHERE ")
+				f.WriteString(idx.Predicate)
+			}
+			if idx.IsDeferrableUnique() {
+				f.WriteByte(' ')
+				f.WriteString(sqlbase.TreeConstraintDeferrabilityValue[idx.UniqueDeferrability].String())
+			}
 		}
 	}
 
@@ -210,6 +244,10 @@ func ShowCreateTable(
 	}
 
 	for _, e := range desc.AllActiveAndInactiveChecks() {
+		if e.IsNonNullConstraint {
+			// The column is shown as NOT NULL once the constraint is validated.
+			continue
+		}
 		f.WriteString(",\n\t")
 		if len(e.Name) > 0 {
 			f.WriteString("CONSTRAINT ")
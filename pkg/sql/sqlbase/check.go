@@ -76,7 +76,7 @@ func NewEvalCheckHelper(
 		return nil, nil
 	}
 
-	c := &CheckHelper{}
+	c := &CheckHelper{tableDesc: tableDesc}
 	c.cols = tableDesc.Columns
 	c.sourceInfo = NewSourceInfoForSingleTable(
 		tree.MakeUnqualifiedTableName(tree.Name(tableDesc.Name)),
@@ -188,15 +188,14 @@ func (c *CheckHelper) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
 func (c *CheckHelper) CheckEval(ctx *tree.EvalContext) error {
 	ctx.PushIVarContainer(c)
 	defer func() { ctx.PopIVarContainer() }()
-	for _, expr := range c.Exprs {
+	for i, expr := range c.Exprs {
 		if d, err := expr.Eval(ctx); err != nil {
 			return err
 		} else if res, err := tree.GetBool(d); err != nil {
 			return err
 		} else if !res && d != tree.DNull {
 			// Failed to satisfy CHECK constraint.
-			return pgerror.Newf(pgerror.CodeCheckViolationError,
-				"failed to satisfy CHECK constraint (%s)", expr)
+			return c.violationError(&c.tableDesc.ActiveChecks()[i], expr.String())
 		}
 	}
 	return nil
@@ -220,9 +219,23 @@ func (c *CheckHelper) CheckInput(checkVals tree.Datums) error {
 			return err
 		} else if !res && checkVals[i] != tree.DNull {
 			// Failed to satisfy CHECK constraint.
-			return pgerror.Newf(pgerror.CodeCheckViolationError,
-				"failed to satisfy CHECK constraint (%s)", check.Expr)
+			return c.violationError(&check, check.Expr)
 		}
 	}
 	return nil
 }
+
+// violationError returns the error reported for a row that doesn't satisfy the
+// given check constraint. A row that violates one of the temporary constraints
+// enforcing NOT NULL on a column gets the same error as for a NOT NULL column.
+func (c *CheckHelper) violationError(check *TableDescriptor_CheckConstraint, expr string) error {
+	if check.IsNonNullConstraint {
+		col, err := c.tableDesc.FindColumnByID(check.ColumnIDs[0])
+		if err != nil {
+			return err
+		}
+		return NewNonNullViolationError(col.Name)
+	}
+	return pgerror.Newf(pgerror.CodeCheckViolationError,
+		"failed to satisfy CHECK constraint (%s)", expr)
+}
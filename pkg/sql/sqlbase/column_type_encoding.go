@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/lib/pq/oid"
@@ -104,6 +105,11 @@ func EncodeTableKey(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, e
 			return encoding.EncodeVarintAscending(b, int64(*t)), nil
 		}
 		return encoding.EncodeVarintDescending(b, int64(*t)), nil
+	case *tree.DTimeTZ:
+		if dir == encoding.Ascending {
+			return encoding.EncodeTimeTZAscending(b, t.TimeTZ), nil
+		}
+		return encoding.EncodeTimeTZDescending(b, t.TimeTZ), nil
 	case *tree.DTimestamp:
 		if dir == encoding.Ascending {
 			return encoding.EncodeTimeAscending(b, t.Time), nil
@@ -124,6 +130,11 @@ func EncodeTableKey(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, e
 			return encoding.EncodeBytesAscending(b, t.GetBytes()), nil
 		}
 		return encoding.EncodeBytesDescending(b, t.GetBytes()), nil
+	case *tree.DEnum:
+		if dir == encoding.Ascending {
+			return encoding.EncodeBytesAscending(b, t.PhysicalRep), nil
+		}
+		return encoding.EncodeBytesDescending(b, t.PhysicalRep), nil
 	case *tree.DIPAddr:
 		data := t.ToBuffer(nil)
 		if dir == encoding.Ascending {
@@ -269,6 +280,14 @@ func DecodeTableKey(
 			rkey, t, err = encoding.DecodeVarintDescending(key)
 		}
 		return a.NewDTime(tree.DTime(t)), rkey, err
+	case types.TimeTZFamily:
+		var t timetz.TimeTZ
+		if dir == encoding.Ascending {
+			rkey, t, err = encoding.DecodeTimeTZAscending(key)
+		} else {
+			rkey, t, err = encoding.DecodeTimeTZDescending(key)
+		}
+		return a.NewDTimeTZ(tree.DTimeTZ{TimeTZ: t}), rkey, err
 	case types.TimestampFamily:
 		var t time.Time
 		if dir == encoding.Ascending {
@@ -305,6 +324,18 @@ func DecodeTableKey(
 		}
 		u, err := uuid.FromBytes(r)
 		return a.NewDUuid(tree.DUuid{UUID: u}), rkey, err
+	case types.EnumFamily:
+		var r []byte
+		if dir == encoding.Ascending {
+			rkey, r, err = encoding.DecodeBytesAscending(key, nil)
+		} else {
+			rkey, r, err = encoding.DecodeBytesDescending(key, nil)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		d, err := tree.MakeDEnumFromPhysicalRepresentation(valType, r)
+		return d, rkey, err
 	case types.INetFamily:
 		var r []byte
 		if dir == encoding.Ascending {
@@ -367,6 +398,8 @@ func EncodeTableValue(
 		return encoding.EncodeIntValue(appendTo, uint32(colID), t.UnixEpochDaysWithOrig()), nil
 	case *tree.DTime:
 		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(*t)), nil
+	case *tree.DTimeTZ:
+		return encoding.EncodeTimeTZValue(appendTo, uint32(colID), t.TimeTZ), nil
 	case *tree.DTimestamp:
 		return encoding.EncodeTimeValue(appendTo, uint32(colID), t.Time), nil
 	case *tree.DTimestampTZ:
@@ -375,6 +408,8 @@ func EncodeTableValue(
 		return encoding.EncodeDurationValue(appendTo, uint32(colID), t.Duration), nil
 	case *tree.DUuid:
 		return encoding.EncodeUUIDValue(appendTo, uint32(colID), t.UUID), nil
+	case *tree.DEnum:
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), t.PhysicalRep), nil
 	case *tree.DIPAddr:
 		return encoding.EncodeIPAddrValue(appendTo, uint32(colID), t.IPAddr), nil
 	case *tree.DJSON:
@@ -483,6 +518,12 @@ func decodeUntaggedDatum(a *DatumAlloc, t *types.T, buf []byte) (tree.Datum, []b
 			return nil, b, err
 		}
 		return a.NewDTime(tree.DTime(data)), b, nil
+	case types.TimeTZFamily:
+		b, data, err := encoding.DecodeUntaggedTimeTZValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		return a.NewDTimeTZ(tree.DTimeTZ{TimeTZ: data}), b, nil
 	case types.TimestampFamily:
 		b, data, err := encoding.DecodeUntaggedTimeValue(buf)
 		if err != nil {
@@ -501,6 +542,13 @@ func decodeUntaggedDatum(a *DatumAlloc, t *types.T, buf []byte) (tree.Datum, []b
 	case types.UuidFamily:
 		b, data, err := encoding.DecodeUntaggedUUIDValue(buf)
 		return a.NewDUuid(tree.DUuid{UUID: data}), b, err
+	case types.EnumFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		d, err := tree.MakeDEnumFromPhysicalRepresentation(t, data)
+		return d, b, err
 	case types.INetFamily:
 		b, data, err := encoding.DecodeUntaggedIPAddrValue(buf)
 		return a.NewDIPAddr(tree.DIPAddr{IPAddr: data}), b, err
@@ -613,6 +661,11 @@ func MarshalColumnValue(col *ColumnDescriptor, val tree.Datum) (roachpb.Value, e
 			r.SetInt(int64(*v))
 			return r, nil
 		}
+	case types.TimeTZFamily:
+		if v, ok := val.(*tree.DTimeTZ); ok {
+			r.SetTimeTZ(v.TimeTZ)
+			return r, nil
+		}
 	case types.TimestampFamily:
 		if v, ok := val.(*tree.DTimestamp); ok {
 			r.SetTime(v.Time)
@@ -633,6 +686,11 @@ func MarshalColumnValue(col *ColumnDescriptor, val tree.Datum) (roachpb.Value, e
 			r.SetBytes(v.GetBytes())
 			return r, nil
 		}
+	case types.EnumFamily:
+		if v, ok := val.(*tree.DEnum); ok {
+			r.SetBytes(v.PhysicalRep)
+			return r, nil
+		}
 	case types.INetFamily:
 		if v, ok := val.(*tree.DIPAddr); ok {
 			data := v.ToBuffer(nil)
@@ -660,6 +718,15 @@ func MarshalColumnValue(col *ColumnDescriptor, val tree.Datum) (roachpb.Value, e
 			r.SetBytes(b)
 			return r, nil
 		}
+	case types.TupleFamily:
+		if v, ok := val.(*tree.DTuple); ok {
+			b, err := encodeUntaggedTuple(v, nil /* appendTo */, nil /* scratch */)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(b)
+			return r, nil
+		}
 	case types.CollatedStringFamily:
 		if v, ok := val.(*tree.DCollatedString); ok {
 			if v.Locale == col.Type.Locale() {
@@ -754,6 +821,12 @@ func UnmarshalColumnValue(a *DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return a.NewDTime(tree.DTime(v)), nil
+	case types.TimeTZFamily:
+		v, err := value.GetTimeTZ()
+		if err != nil {
+			return nil, err
+		}
+		return a.NewDTimeTZ(tree.DTimeTZ{TimeTZ: v}), nil
 	case types.TimestampFamily:
 		v, err := value.GetTime()
 		if err != nil {
@@ -788,6 +861,12 @@ func UnmarshalColumnValue(a *DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return a.NewDUuid(tree.DUuid{UUID: u}), nil
+	case types.EnumFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return tree.MakeDEnumFromPhysicalRepresentation(typ, v)
 	case types.INetFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -812,6 +891,13 @@ func UnmarshalColumnValue(a *DatumAlloc, typ *types.T, value roachpb.Value) (tre
 		}
 		datum, _, err := decodeArrayNoMarshalColumnValue(a, typ.ArrayContents(), v)
 		return datum, err
+	case types.TupleFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		datum, _, err := decodeTuple(a, typ, v)
+		return datum, err
 	case types.JsonFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -830,6 +916,13 @@ func UnmarshalColumnValue(a *DatumAlloc, typ *types.T, value roachpb.Value) (tre
 // encodeTuple produces the value encoding for a tuple.
 func encodeTuple(t *tree.DTuple, appendTo []byte, colID uint32, scratch []byte) ([]byte, error) {
 	appendTo = encoding.EncodeValueTag(appendTo, colID, encoding.Tuple)
+	return encodeUntaggedTuple(t, appendTo, scratch)
+}
+
+// encodeUntaggedTuple produces the value encoding for a tuple without the
+// value tag. This is the encoding of the values of composite type columns
+// stored in their own column family.
+func encodeUntaggedTuple(t *tree.DTuple, appendTo []byte, scratch []byte) ([]byte, error) {
 	appendTo = encoding.EncodeNonsortingUvarint(appendTo, uint64(len(t.D)))
 
 	var err error
@@ -850,9 +943,9 @@ func decodeTuple(a *DatumAlloc, tupTyp *types.T, b []byte) (tree.Datum, []byte,
 		return nil, nil, err
 	}
 
-	result := tree.DTuple{
-		D: a.NewDatums(len(tupTyp.TupleContents())),
-	}
+	// The decoded tuple keeps the type it was decoded with, so that the labels
+	// and the name of a composite type are preserved.
+	result := tree.NewDTuple(tupTyp, a.NewDatums(len(tupTyp.TupleContents()))...)
 
 	var datum tree.Datum
 	for i := range tupTyp.TupleContents() {
@@ -862,26 +955,46 @@ func decodeTuple(a *DatumAlloc, tupTyp *types.T, b []byte) (tree.Datum, []byte,
 		}
 		result.D[i] = datum
 	}
-	return a.NewDTuple(result), b, nil
+	return result, b, nil
 }
 
-// encodeArray produces the value encoding for an array.
+// encodeArray produces the value encoding for an array. A multi-dimensional
+// array is encoded as the length of each of its dimensions, followed by its
+// innermost elements in row-major order.
 func encodeArray(d *tree.DArray, scratch []byte) ([]byte, error) {
 	if err := d.Validate(); err != nil {
 		return scratch, err
 	}
 	scratch = scratch[0:0]
-	elementType, err := datumTypeToArrayElementEncodingType(d.ParamTyp)
+	dims, elems, err := d.Flatten()
+	if err != nil {
+		return nil, err
+	}
+	innermostType := d.ParamTyp
+	for innermostType.Family() == types.ArrayFamily {
+		innermostType = innermostType.ArrayContents()
+	}
+	elementType, err := datumTypeToArrayElementEncodingType(innermostType)
 
 	if err != nil {
 		return nil, err
 	}
+	hasNulls := d.HasNulls
+	if len(dims) > 1 {
+		hasNulls = false
+		for _, e := range elems {
+			if e == tree.DNull {
+				hasNulls = true
+				break
+			}
+		}
+	}
 	header := arrayHeader{
-		hasNulls: d.HasNulls,
-		// TODO(justin): support multiple dimensions.
-		numDimensions: 1,
+		hasNulls:      hasNulls,
+		numDimensions: len(dims),
 		elementType:   elementType,
-		length:        uint64(d.Len()),
+		length:        uint64(len(elems)),
+		dimensions:    dims,
 		// We don't encode the NULL bitmap in this function because we do it in lockstep with the
 		// main data.
 	}
@@ -890,14 +1003,14 @@ func encodeArray(d *tree.DArray, scratch []byte) ([]byte, error) {
 		return nil, err
 	}
 	nullBitmapStart := len(scratch)
-	if d.HasNulls {
-		for i := 0; i < numBytesInBitArray(d.Len()); i++ {
+	if hasNulls {
+		for i := 0; i < numBytesInBitArray(len(elems)); i++ {
 			scratch = append(scratch, 0)
 		}
 	}
-	for i, e := range d.Array {
+	for i, e := range elems {
 		var err error
-		if d.HasNulls && e == tree.DNull {
+		if hasNulls && e == tree.DNull {
 			setBit(scratch[nullBitmapStart:], i)
 		} else {
 			scratch, err = encodeArrayElement(scratch, e)
@@ -927,6 +1040,9 @@ func decodeArrayNoMarshalColumnValue(
 	if err != nil {
 		return nil, b, err
 	}
+	if header.numDimensions > 1 {
+		return decodeMultiDimensionalArray(a, elementType, header, b)
+	}
 	result := tree.DArray{
 		Array:    make(tree.Datums, header.length),
 		ParamTyp: elementType,
@@ -948,6 +1064,38 @@ func decodeArrayNoMarshalColumnValue(
 	return &result, b, nil
 }
 
+// decodeMultiDimensionalArray decodes the innermost elements of an array with
+// more than one dimension, and reconstructs the array from them.
+func decodeMultiDimensionalArray(
+	a *DatumAlloc, elementType *types.T, header arrayHeader, b []byte,
+) (tree.Datum, []byte, error) {
+	innermostType := elementType
+	for i := 1; i < header.numDimensions; i++ {
+		if innermostType.Family() != types.ArrayFamily {
+			return nil, b, errors.Errorf("array with %d dimensions cannot have type %s",
+				header.numDimensions, types.MakeArray(elementType))
+		}
+		innermostType = innermostType.ArrayContents()
+	}
+	elems := make(tree.Datums, header.length)
+	var err error
+	for i := range elems {
+		if header.isNull(uint64(i)) {
+			elems[i] = tree.DNull
+		} else {
+			elems[i], b, err = decodeUntaggedDatum(a, innermostType, b)
+			if err != nil {
+				return nil, b, err
+			}
+		}
+	}
+	result, err := tree.NewDArrayFromFlattened(elementType, header.dimensions, elems)
+	if err != nil {
+		return nil, b, err
+	}
+	return result, b, nil
+}
+
 // arrayHeader is a parameter passing struct between
 // encodeArray/decodeArray and encodeArrayHeader/decodeArrayHeader.
 //
@@ -962,6 +1110,10 @@ type arrayHeader struct {
 	elementType encoding.Type
 	// length is the total number of elements encoded.
 	length uint64
+	// dimensions is the length of each dimension of the array. It is only
+	// encoded if the array has more than one dimension, in which case length
+	// is the product of the dimensions.
+	dimensions []int
 	// nullBitmap is a compact representation of which array indexes
 	// have NULL values.
 	nullBitmap []byte
@@ -1009,6 +1161,12 @@ func encodeArrayHeader(h arrayHeader, buf []byte) ([]byte, error) {
 	}
 	buf = append(buf, byte(headerByte))
 	buf = encoding.EncodeValueTag(buf, encoding.NoColumnID, h.elementType)
+	if h.numDimensions > 1 {
+		for _, dim := range h.dimensions {
+			buf = encoding.EncodeNonsortingUvarint(buf, uint64(dim))
+		}
+		return buf, nil
+	}
 	buf = encoding.EncodeNonsortingUvarint(buf, h.length)
 	return buf, nil
 }
@@ -1020,26 +1178,45 @@ func decodeArrayHeader(b []byte) (arrayHeader, []byte, error) {
 		return arrayHeader{}, b, errors.Errorf("buffer too small")
 	}
 	hasNulls := b[0]&hasNullFlag != 0
+	// The low 4 bits encode the number of dimensions in the array.
+	numDimensions := int(b[0] & 0x0f)
 	b = b[1:]
 	_, dataOffset, _, encType, err := encoding.DecodeValueTag(b)
 	if err != nil {
 		return arrayHeader{}, b, err
 	}
 	b = b[dataOffset:]
-	b, _, length, err := encoding.DecodeNonsortingUvarint(b)
-	if err != nil {
-		return arrayHeader{}, b, err
+	var length uint64
+	var dimensions []int
+	if numDimensions > 1 {
+		length = 1
+		dimensions = make([]int, numDimensions)
+		for i := range dimensions {
+			var dim uint64
+			b, _, dim, err = encoding.DecodeNonsortingUvarint(b)
+			if err != nil {
+				return arrayHeader{}, b, err
+			}
+			dimensions[i] = int(dim)
+			length *= dim
+		}
+	} else {
+		numDimensions = 1
+		b, _, length, err = encoding.DecodeNonsortingUvarint(b)
+		if err != nil {
+			return arrayHeader{}, b, err
+		}
 	}
 	nullBitmap := []byte(nil)
 	if hasNulls {
 		b, nullBitmap = makeBitVec(b, int(length))
 	}
 	return arrayHeader{
-		hasNulls: hasNulls,
-		// TODO(justin): support multiple dimensions.
-		numDimensions: 1,
+		hasNulls:      hasNulls,
+		numDimensions: numDimensions,
 		elementType:   encType,
 		length:        length,
+		dimensions:    dimensions,
 		nullBitmap:    nullBitmap,
 	}, b, nil
 }
@@ -1066,6 +1243,8 @@ func datumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 	// persisted with incorrect elementType values.
 	case types.DateFamily, types.TimeFamily:
 		return encoding.Int, nil
+	case types.TimeTZFamily:
+		return encoding.TimeTZ, nil
 	case types.IntervalFamily:
 		return encoding.Duration, nil
 	case types.BoolFamily:
@@ -1086,6 +1265,9 @@ func checkElementType(paramType *types.T, elemType *types.T) error {
 		return errors.Errorf("type of array contents %s doesn't match column type %s",
 			paramType, elemType.Family())
 	}
+	if paramType.Family() == types.ArrayFamily {
+		return checkElementType(paramType.ArrayContents(), elemType.ArrayContents())
+	}
 	if paramType.Family() == types.CollatedStringFamily {
 		if paramType.Locale() != elemType.Locale() {
 			return errors.Errorf("locale of collated string array being inserted (%s) doesn't match locale of column type (%s)",
@@ -1121,6 +1303,8 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 		return encoding.EncodeUntaggedIntValue(b, t.UnixEpochDaysWithOrig()), nil
 	case *tree.DTime:
 		return encoding.EncodeUntaggedIntValue(b, int64(*t)), nil
+	case *tree.DTimeTZ:
+		return encoding.EncodeUntaggedTimeTZValue(b, t.TimeTZ), nil
 	case *tree.DTimestamp:
 		return encoding.EncodeUntaggedTimeValue(b, t.Time), nil
 	case *tree.DTimestampTZ:
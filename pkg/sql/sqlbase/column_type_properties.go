@@ -25,7 +25,8 @@ import (
 // LimitValueWidth checks that the width (for strings, byte arrays, and bit
 // strings) and scale (for decimals) of the value fits the specified column
 // type. In case of decimals, it can truncate fractional digits in the input
-// value in order to fit the target column. If the input value fits the target
+// value in order to fit the target column. Intervals are truncated to the
+// fields and fractional second precision of the column type. If the input value fits the target
 // column, it is returned unchanged. If the input value can be truncated to fit,
 // then a truncated copy is returned. Otherwise, an error is returned. This
 // method is used by INSERT and UPDATE.
@@ -98,6 +99,10 @@ func LimitValueWidth(typ *types.T, inVal tree.Datum, name *string) (outVal tree.
 			}
 			return &outDec, nil
 		}
+	case types.IntervalFamily:
+		if v, ok := inVal.(*tree.DInterval); ok {
+			return tree.AdjustDIntervalForTypeMetadata(v, typ), nil
+		}
 	case types.ArrayFamily:
 		if inArr, ok := inVal.(*tree.DArray); ok {
 			var outArr *tree.DArray
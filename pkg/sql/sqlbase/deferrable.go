@@ -0,0 +1,45 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sqlbase
+
+import "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+
+// ConstraintDeferrabilityValue allows the conversion from a
+// tree.ConstraintDeferrability to a ConstraintDeferrability.
+var ConstraintDeferrabilityValue = [...]ConstraintDeferrability{
+	tree.NotDeferrable:                ConstraintDeferrability_NotDeferrable,
+	tree.DeferrableInitiallyImmediate: ConstraintDeferrability_DeferrableInitiallyImmediate,
+	tree.DeferrableInitiallyDeferred:  ConstraintDeferrability_DeferrableInitiallyDeferred,
+}
+
+// TreeConstraintDeferrabilityValue allows the conversion from a
+// ConstraintDeferrability to a tree.ConstraintDeferrability. This should match
+// ConstraintDeferrabilityValue.
+var TreeConstraintDeferrabilityValue = [...]tree.ConstraintDeferrability{
+	ConstraintDeferrability_NotDeferrable:                tree.NotDeferrable,
+	ConstraintDeferrability_DeferrableInitiallyImmediate: tree.DeferrableInitiallyImmediate,
+	ConstraintDeferrability_DeferrableInitiallyDeferred:  tree.DeferrableInitiallyDeferred,
+}
+
+// IsDeferrable returns whether the validation of the foreign key can be
+// deferred. Only the outbound reference of the referencing table records it.
+func (f ForeignKeyReference) IsDeferrable() bool {
+	return f.Deferrability != ConstraintDeferrability_NotDeferrable
+}
+
+// IsDeferrableUnique returns whether the index enforces a deferrable UNIQUE
+// constraint. Such an index is not Unique: it is encoded like a non-unique
+// index, since it can temporarily contain duplicate keys.
+func (desc *IndexDescriptor) IsDeferrableUnique() bool {
+	return desc.UniqueDeferrability != ConstraintDeferrability_NotDeferrable
+}
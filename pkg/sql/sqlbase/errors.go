@@ -56,6 +56,24 @@ func NewNonNullViolationError(columnName string) error {
 	return pgerror.Newf(pgerror.CodeNotNullViolationError, "null value in column %q violates not-null constraint", columnName)
 }
 
+// NewGeneratedAlwaysInsertError creates an error for an INSERT statement that
+// specifies a value for an identity column defined as GENERATED ALWAYS.
+func NewGeneratedAlwaysInsertError(columnName string) error {
+	return pgerror.Newf(pgerror.CodeGeneratedAlwaysError,
+		"cannot insert into column %q", columnName).SetDetailf(
+		"Column %q is an identity column defined as GENERATED ALWAYS.", columnName).SetHintf(
+		"Use OVERRIDING SYSTEM VALUE to override.")
+}
+
+// NewGeneratedAlwaysUpdateError creates an error for an UPDATE statement that
+// sets an identity column defined as GENERATED ALWAYS to a value other than
+// DEFAULT.
+func NewGeneratedAlwaysUpdateError(columnName string) error {
+	return pgerror.Newf(pgerror.CodeGeneratedAlwaysError,
+		"column %q can only be updated to DEFAULT", columnName).SetDetailf(
+		"Column %q is an identity column defined as GENERATED ALWAYS.", columnName)
+}
+
 // NewInvalidSchemaDefinitionError creates an error for an invalid schema
 // definition such as a schema definition that doesn't parse.
 func NewInvalidSchemaDefinitionError(err error) error {
@@ -0,0 +1,147 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sqlbase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// FunctionLanguageSQL is the only language supported for the body of
+// user-defined functions.
+const FunctionLanguageSQL = "sql"
+
+// SetID implements the DescriptorProto interface.
+func (desc *FunctionDescriptor) SetID(id ID) {
+	desc.ID = id
+}
+
+// TypeName returns the plain type of this descriptor.
+func (desc *FunctionDescriptor) TypeName() string {
+	return "function"
+}
+
+// SetName implements the DescriptorProto interface.
+func (desc *FunctionDescriptor) SetName(name string) {
+	desc.Name = name
+}
+
+// GetAuditMode is part of the DescriptorProto interface.
+// Functions cannot be audited.
+func (desc *FunctionDescriptor) GetAuditMode() TableDescriptor_AuditMode {
+	return TableDescriptor_DISABLED
+}
+
+// Validate validates that the function descriptor is well formed. Checks
+// include validating the function name, and verifying that no two overloads
+// have the same parameter types.
+func (desc *FunctionDescriptor) Validate() error {
+	if err := validateName(desc.Name, "function"); err != nil {
+		return err
+	}
+	if desc.ID == 0 {
+		return fmt.Errorf("invalid function ID %d", desc.ID)
+	}
+	if desc.ParentID == 0 {
+		return fmt.Errorf("invalid parent ID %d", desc.ParentID)
+	}
+	if len(desc.Overloads) == 0 {
+		return fmt.Errorf("function %q has no overloads", desc.Name)
+	}
+	for i := range desc.Overloads {
+		ol := &desc.Overloads[i]
+		if ol.Language != FunctionLanguageSQL {
+			return fmt.Errorf("function %q has unsupported language %q", desc.Name, ol.Language)
+		}
+		for j := 0; j < i; j++ {
+			if desc.Overloads[j].SameParamTypes(ol.ParamTypes()) {
+				return fmt.Errorf("function %q has duplicate overloads %s",
+					desc.Name, ol.Signature(desc.Name))
+			}
+		}
+	}
+	return desc.Privileges.Validate(desc.GetID())
+}
+
+// FindOverload returns the index of the overload with the given parameter
+// types, or -1 if there is none.
+func (desc *FunctionDescriptor) FindOverload(paramTypes []types.T) int {
+	for i := range desc.Overloads {
+		if desc.Overloads[i].SameParamTypes(paramTypes) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParamTypes returns the types of the parameters of the overload.
+func (ol *FunctionDescriptor_Overload) ParamTypes() []types.T {
+	typs := make([]types.T, len(ol.Params))
+	for i := range ol.Params {
+		typs[i] = ol.Params[i].Type
+	}
+	return typs
+}
+
+// SameParamTypes returns whether the overload has exactly the given
+// parameter types.
+func (ol *FunctionDescriptor_Overload) SameParamTypes(paramTypes []types.T) bool {
+	if len(ol.Params) != len(paramTypes) {
+		return false
+	}
+	for i := range ol.Params {
+		if !ol.Params[i].Type.Identical(&paramTypes[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Signature returns the name of the function followed by the types of the
+// parameters of the overload, e.g. f(INT8, STRING).
+func (ol *FunctionDescriptor_Overload) Signature(name string) string {
+	var buf strings.Builder
+	buf.WriteString(name)
+	buf.WriteByte('(')
+	for i := range ol.Params {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(ol.Params[i].Type.SQLString())
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+// GetFunctionDescFromID retrieves the function descriptor for the function
+// ID passed in using an existing txn. Returns an error if the descriptor
+// doesn't exist or if it exists and is not a function.
+func GetFunctionDescFromID(
+	ctx context.Context, txn *client.Txn, id ID,
+) (*FunctionDescriptor, error) {
+	desc := &Descriptor{}
+	descKey := MakeDescMetadataKey(id)
+
+	if err := txn.GetProto(ctx, descKey, desc); err != nil {
+		return nil, err
+	}
+	fn := desc.GetFunction()
+	if fn == nil {
+		return nil, ErrDescriptorNotFound
+	}
+	return fn, nil
+}
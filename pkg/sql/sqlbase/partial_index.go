@@ -0,0 +1,158 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sqlbase
+
+import (
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// IsPartial returns whether the index is a partial index, that is, whether it
+// only contains the rows of the table that satisfy its predicate.
+func (desc *IndexDescriptor) IsPartial() bool {
+	return desc.Predicate != ""
+}
+
+// predicateColumnIDs returns the sorted IDs of the columns of the table that
+// are referenced by the given partial index predicate.
+func predicateColumnIDs(desc *TableDescriptor, predicate string) ([]ColumnID, error) {
+	parsed, err := parser.ParseExpr(predicate)
+	if err != nil {
+		return nil, pgerror.Wrapf(err, pgerror.CodeSyntaxError,
+			"could not parse index predicate %s", predicate)
+	}
+
+	colIDsUsed := make(map[ColumnID]struct{})
+	visitFn := func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		if vBase, ok := expr.(tree.VarName); ok {
+			v, err := vBase.NormalizeVarName()
+			if err != nil {
+				return false, nil, err
+			}
+			if c, ok := v.(*tree.ColumnItem); ok {
+				col, dropped, err := desc.FindColumnByName(c.ColumnName)
+				if err != nil || dropped {
+					return false, nil, pgerror.Newf(pgerror.CodeUndefinedColumnError,
+						"column %q not found for index predicate %q",
+						c.ColumnName, parsed.String())
+				}
+				colIDsUsed[col.ID] = struct{}{}
+			}
+			return false, v, nil
+		}
+		return true, expr, nil
+	}
+	if _, err := tree.SimpleVisit(parsed, visitFn); err != nil {
+		return nil, err
+	}
+
+	colIDs := make([]ColumnID, 0, len(colIDsUsed))
+	for colID := range colIDsUsed {
+		colIDs = append(colIDs, colID)
+	}
+	sort.Sort(ColumnIDs(colIDs))
+	return colIDs, nil
+}
+
+// PartialIndexHelper determines which of the partial indexes of a table
+// contain a row, on INSERT, UPDATE, DELETE and when backfilling an index.
+//
+// Callers should call NewPartialIndexHelper to initialize a new instance of
+// PartialIndexHelper. For each row, they call LoadRow to set the values of the
+// row, and then IndexContainsRow for every index.
+type PartialIndexHelper struct {
+	// exprs parallels the indexes the helper was created for. It contains nil
+	// for the indexes that are not partial.
+	exprs []tree.TypedExpr
+	cols  []ColumnDescriptor
+	eval  *RowExprEvaluator
+}
+
+// NewPartialIndexHelper constructs a new instance of PartialIndexHelper for
+// the given indexes of the table. cols must contain all the columns of the
+// table, including the mutation columns, since a column being dropped can
+// still be referenced by the predicate of an index being dropped. It returns
+// nil if none of the indexes is a partial index.
+func NewPartialIndexHelper(
+	tableDesc *TableDescriptor, cols []ColumnDescriptor, indexes []IndexDescriptor,
+) (*PartialIndexHelper, error) {
+	var exprStrings []string
+	for i := range indexes {
+		if indexes[i].IsPartial() {
+			exprStrings = append(exprStrings, indexes[i].Predicate)
+		}
+	}
+	if len(exprStrings) == 0 {
+		return nil, nil
+	}
+	exprs, err := parser.ParseExprs(exprStrings)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &PartialIndexHelper{
+		cols: cols,
+		eval: NewRowExprEvaluator(tree.MakeUnqualifiedTableName(tree.Name(tableDesc.Name)), cols),
+	}
+	h.exprs = make([]tree.TypedExpr, len(indexes))
+	exprIdx := 0
+	for i := range indexes {
+		if !indexes[i].IsPartial() {
+			continue
+		}
+		typedExpr, err := h.eval.TypeCheck(exprs[exprIdx], "index predicate", types.Bool)
+		if err != nil {
+			return nil, err
+		}
+		h.exprs[i] = typedExpr
+		exprIdx++
+	}
+	return h, nil
+}
+
+// LoadRow sets the values of the columns used by the predicates. colIdx maps
+// the IDs of the columns to their position in row. The columns that are not
+// in row are set to NULL; callers must make sure that row contains all the
+// columns referenced by the predicates.
+func (h *PartialIndexHelper) LoadRow(colIdx map[ColumnID]int, row tree.Datums) {
+	curSourceRow := h.eval.Row()
+	for i := range h.cols {
+		// colIdx can map columns past the end of row, see for example how the
+		// optimizer truncates the columns fetched by a row.Updater.
+		if ri, ok := colIdx[h.cols[i].ID]; ok && ri < len(row) {
+			curSourceRow[i] = row[ri]
+		} else {
+			curSourceRow[i] = tree.DNull
+		}
+	}
+}
+
+// IndexContainsRow returns whether the index at position i in the indexes the
+// helper was created for contains the row that was previously set via a call
+// to LoadRow. A partial index only contains the rows for which its predicate
+// evaluates to true.
+func (h *PartialIndexHelper) IndexContainsRow(i int) (bool, error) {
+	expr := h.exprs[i]
+	if expr == nil {
+		return true, nil
+	}
+	d, err := h.eval.Eval(expr)
+	if err != nil {
+		return false, err
+	}
+	return d == tree.DBoolTrue, nil
+}
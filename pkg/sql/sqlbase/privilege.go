@@ -139,6 +139,16 @@ func (p *PrivilegeDescriptor) Grant(user string, privList privilege.List) {
 
 // Revoke removes privileges from this descriptor for a given list of users.
 func (p *PrivilegeDescriptor) Revoke(user string, privList privilege.List) {
+	p.revoke(user, privList, false /* isFunction */)
+}
+
+// RevokeFunctionPrivileges is like Revoke, for the privilege descriptor of a
+// function.
+func (p *PrivilegeDescriptor) RevokeFunctionPrivileges(user string, privList privilege.List) {
+	p.revoke(user, privList, true /* isFunction */)
+}
+
+func (p *PrivilegeDescriptor) revoke(user string, privList privilege.List, isFunction bool) {
 	userPriv, ok := p.findUser(user)
 	if !ok || userPriv.Privileges == 0 {
 		// Removing privileges from a user without privileges is a no-op.
@@ -156,10 +166,10 @@ func (p *PrivilegeDescriptor) Revoke(user string, privList privilege.List) {
 
 	if isPrivilegeSet(userPriv.Privileges, privilege.ALL) {
 		// User has 'ALL' privilege. Remove it and set
-		// all other privileges one.
+		// all other privileges that apply to the object one.
 		userPriv.Privileges = 0
 		for _, v := range privilege.ByValue {
-			if v != privilege.ALL {
+			if v != privilege.ALL && v.AppliesTo(isFunction) {
 				userPriv.Privileges |= v.Mask()
 			}
 		}
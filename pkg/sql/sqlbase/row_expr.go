@@ -0,0 +1,123 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sqlbase
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// RowExprEvaluator evaluates expressions over the columns of a single row of a
+// table, like the predicates of partial indexes or the projections and filters
+// of changefeeds.
+//
+// The expressions are evaluated without a session, so that every node and
+// every transaction agrees on their results. In particular, all the
+// timezone-dependent operations are evaluated in UTC.
+type RowExprEvaluator struct {
+	sourceInfo   *DataSourceInfo
+	ivarHelper   tree.IndexedVarHelper
+	semaCtx      tree.SemaContext
+	curSourceRow tree.Datums
+	evalCtx      tree.EvalContext
+}
+
+var _ tree.IndexedVarContainer = &RowExprEvaluator{}
+
+// NewRowExprSessionData returns the session data under which a
+// RowExprEvaluator evaluates expressions. It can be used to evaluate the same
+// expressions in SQL, for example to validate a new partial index.
+func NewRowExprSessionData() *sessiondata.SessionData {
+	return &sessiondata.SessionData{
+		SearchPath:    DefaultSearchPath,
+		SequenceState: sessiondata.NewSequenceState(),
+		DataConversion: sessiondata.DataConversionConfig{
+			Location: time.UTC,
+		},
+		User: security.NodeUser,
+	}
+}
+
+// NewRowExprEvaluator creates a RowExprEvaluator for the rows with the given
+// columns. The expressions can refer to the columns qualified by tableName.
+func NewRowExprEvaluator(tableName tree.TableName, cols []ColumnDescriptor) *RowExprEvaluator {
+	e := &RowExprEvaluator{
+		sourceInfo:   NewSourceInfoForSingleTable(tableName, ResultColumnsFromColDescs(cols)),
+		semaCtx:      tree.MakeSemaContext(),
+		curSourceRow: make(tree.Datums, len(cols)),
+	}
+	e.ivarHelper = tree.MakeIndexedVarHelper(e, len(cols))
+	e.semaCtx.IVarContainer = e
+	e.evalCtx = tree.EvalContext{
+		Context:     context.Background(),
+		SessionData: NewRowExprSessionData(),
+	}
+	e.evalCtx.IVarContainer = e
+	return e
+}
+
+// TypeCheck resolves the column references of the given expression and type
+// checks it. The expression is evaluated for every row, possibly long after it
+// was written and on any node, so it must not depend on anything but the row.
+// context names the expression in the errors.
+func (e *RowExprEvaluator) TypeCheck(
+	expr tree.Expr, context string, desired *types.T,
+) (tree.TypedExpr, error) {
+	expr, _, _, err := ResolveNames(
+		expr, MakeMultiSourceInfo(e.sourceInfo), e.ivarHelper, DefaultSearchPath,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer e.semaCtx.Properties.Restore(e.semaCtx.Properties)
+	e.semaCtx.Properties.Require(context,
+		tree.RejectSpecial|tree.RejectImpureFunctions|tree.RejectSubqueries)
+	return tree.TypeCheck(expr, &e.semaCtx, desired)
+}
+
+// IndexedVar returns an expression that refers to the column at position i.
+func (e *RowExprEvaluator) IndexedVar(i int) tree.TypedExpr {
+	return e.ivarHelper.IndexedVar(i)
+}
+
+// Row returns the values of the columns of the current row. Callers set them
+// before calling Eval.
+func (e *RowExprEvaluator) Row() tree.Datums {
+	return e.curSourceRow
+}
+
+// Eval evaluates the given expression, which was returned by TypeCheck or
+// IndexedVar, on the current row.
+func (e *RowExprEvaluator) Eval(expr tree.TypedExpr) (tree.Datum, error) {
+	return expr.Eval(&e.evalCtx)
+}
+
+// IndexedVarEval implements the tree.IndexedVarContainer interface.
+func (e *RowExprEvaluator) IndexedVarEval(idx int, ctx *tree.EvalContext) (tree.Datum, error) {
+	return e.curSourceRow[idx].Eval(ctx)
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (e *RowExprEvaluator) IndexedVarResolvedType(idx int) *types.T {
+	return e.sourceInfo.SourceColumns[idx].Typ
+}
+
+// IndexedVarNodeFormatter implements the tree.IndexedVarContainer interface.
+func (e *RowExprEvaluator) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
+	return e.sourceInfo.NodeFormatter(idx)
+}
@@ -507,12 +507,25 @@ func (desc *TableDescriptor) IsView() bool {
 	return desc.ViewQuery != ""
 }
 
+// MaterializedView returns true if the TableDescriptor describes a
+// materialized view, whose contents are stored like the rows of a table.
+func (desc *TableDescriptor) MaterializedView() bool {
+	return desc.IsMaterializedView
+}
+
 // IsSequence returns true if the TableDescriptor actually describes a
 // Sequence resource rather than a Table.
 func (desc *TableDescriptor) IsSequence() bool {
 	return desc.SequenceOpts != nil
 }
 
+// IsTemporary returns true if the TableDescriptor describes a table or view
+// that was created with CREATE TEMPORARY, and is thus only visible to the
+// session that created it.
+func (desc *TableDescriptor) IsTemporary() bool {
+	return desc.TemporarySessionID != nil
+}
+
 // IsVirtualTable returns true if the TableDescriptor describes a
 // virtual Table (like the information_schema tables) and thus doesn't
 // need to be physically stored.
@@ -531,10 +544,11 @@ func IsVirtualTable(id ID) bool {
 // physical Table that needs to be stored in the kv layer, as opposed to a
 // different resource like a view or a virtual table. Physical tables have
 // primary keys, column families, and indexes (unlike virtual tables).
-// Sequences count as physical tables because their values are stored in
-// the KV layer.
+// Sequences and materialized views count as physical tables because their
+// values are stored in the KV layer.
 func (desc *TableDescriptor) IsPhysicalTable() bool {
-	return desc.IsSequence() || (desc.IsTable() && !desc.IsVirtualTable())
+	return desc.IsSequence() || desc.MaterializedView() ||
+		(desc.IsTable() && !desc.IsVirtualTable())
 }
 
 // KeysPerRow returns the maximum number of keys used to encode a row for the
@@ -974,6 +988,14 @@ func (desc *MutableTableDescriptor) allocateIndexIDs(columnNames map[string]Colu
 				index.CompositeColumnIDs = append(index.CompositeColumnIDs, colID)
 			}
 		}
+
+		if index.IsPartial() {
+			colIDs, err := predicateColumnIDs(desc.TableDesc(), index.Predicate)
+			if err != nil {
+				return err
+			}
+			index.PredicateColumnIDs = colIDs
+		}
 	}
 	return nil
 }
@@ -1018,6 +1040,10 @@ func (desc *MutableTableDescriptor) allocateColumnFamilyIDs(columnNames map[stri
 		if _, ok := columnsInFamilies[col.ID]; ok {
 			return
 		}
+		if col.Virtual {
+			// Virtual computed columns are not stored in the primary index.
+			return
+		}
 		if _, ok := primaryIndexColIDs[col.ID]; ok {
 			// Primary index columns are required to be assigned to family 0.
 			desc.Families[0].ColumnNames = append(desc.Families[0].ColumnNames, col.Name)
@@ -1394,6 +1420,13 @@ func (desc *TableDescriptor) ValidateTable(st *cluster.Settings) error {
 					"mutation in state %s, direction %s, constraint %v",
 					log.Safe(m.State), log.Safe(m.Direction), desc.Constraint.Name)
 			}
+		case *DescriptorMutation_ColumnTypeSwap:
+			if unSetEnums {
+				return pgerror.AssertionFailedf(
+					"mutation in state %s, direction %s, column type swap %d -> %d",
+					log.Safe(m.State), log.Safe(m.Direction),
+					log.Safe(desc.ColumnTypeSwap.OldColumnID), log.Safe(desc.ColumnTypeSwap.NewColumnID))
+			}
 		default:
 			return pgerror.AssertionFailedf(
 				"mutation in state %s, direction %s, and no column/index descriptor",
@@ -1486,7 +1519,16 @@ func (desc *TableDescriptor) validateColumnFamilies(
 		}
 	}
 	for colID := range columnIDs {
-		if _, ok := colIDToFamilyID[colID]; !ok {
+		col, err := desc.FindColumnByID(colID)
+		if err != nil {
+			return nil, err
+		}
+		_, ok := colIDToFamilyID[colID]
+		if col.Virtual && ok {
+			return nil, pgerror.Newf(pgerror.CodeInvalidTableDefinitionError,
+				"virtual computed column %q cannot be part of a family", col.Name)
+		}
+		if !col.Virtual && !ok {
 			return nil, fmt.Errorf("column %d is not in any column family", colID)
 		}
 	}
@@ -1564,9 +1606,17 @@ func (desc *TableDescriptor) validateTableIndexes(
 			}
 			validateIndexDup[colID] = struct{}{}
 		}
+
+		if index.IsPartial() && index.ID == desc.PrimaryIndex.ID {
+			return fmt.Errorf("primary index %q cannot be partial", index.Name)
+		}
 	}
 
 	for _, colID := range desc.PrimaryIndex.ColumnIDs {
+		if col, err := desc.FindColumnByID(colID); err == nil && col.Virtual {
+			return pgerror.Newf(pgerror.CodeInvalidTableDefinitionError,
+				"primary index column %q cannot be virtual", col.Name)
+		}
 		famID, ok := colIDToFamilyID[colID]
 		if !ok || famID != FamilyID(0) {
 			return fmt.Errorf("primary key column %d is not in column family 0", colID)
@@ -2397,9 +2447,26 @@ func (desc *MutableTableDescriptor) MakeMutationComplete(m DescriptorMutation) e
 					return err
 				}
 				idx.ForeignKey.Validity = ConstraintValidity_Validated
+			case ConstraintToUpdate_NOT_NULL:
+				// The column is now validated; replace the check constraint that was
+				// enforcing NOT NULL in the meantime.
+				for i, c := range desc.Checks {
+					if c.Name == t.Constraint.Check.Name {
+						desc.Checks = append(desc.Checks[:i], desc.Checks[i+1:]...)
+						break
+					}
+				}
+				col, err := desc.FindColumnByID(t.Constraint.NotNullColumn)
+				if err != nil {
+					return err
+				}
+				col.Nullable = false
 			default:
 				return errors.Errorf("unsupported constraint type: %d", t.Constraint.ConstraintType)
 			}
+
+		case *DescriptorMutation_ColumnTypeSwap:
+			return desc.performColumnTypeSwap(t.ColumnTypeSwap)
 		}
 
 	case DescriptorMutation_DROP:
@@ -2413,6 +2480,95 @@ func (desc *MutableTableDescriptor) MakeMutationComplete(m DescriptorMutation) e
 	return nil
 }
 
+// performColumnTypeSwap replaces a column, and the secondary indexes that
+// contain it, with the column and indexes holding its converted values. The
+// new column takes the name and the position of the old one and stops being
+// computed. The old column and indexes are queued to be dropped.
+func (desc *MutableTableDescriptor) performColumnTypeSwap(swap *ColumnTypeSwap) error {
+	oldPos, newPos := -1, -1
+	for i := range desc.Columns {
+		switch desc.Columns[i].ID {
+		case swap.OldColumnID:
+			oldPos = i
+		case swap.NewColumnID:
+			newPos = i
+		}
+	}
+	if oldPos < 0 || newPos < 0 {
+		return pgerror.AssertionFailedf("column %d or %d not found for type swap",
+			log.Safe(swap.OldColumnID), log.Safe(swap.NewColumnID))
+	}
+	oldName, newName := desc.Columns[oldPos].Name, desc.Columns[newPos].Name
+	desc.RenameColumnDescriptor(&desc.Columns[oldPos], newName)
+	desc.RenameColumnDescriptor(&desc.Columns[newPos], oldName)
+
+	oldCol, newCol := desc.Columns[oldPos], desc.Columns[newPos]
+	newCol.ComputeExpr = nil
+	newCol.Hidden = oldCol.Hidden
+	newCol.DefaultExpr = oldCol.DefaultExpr
+	desc.Columns[oldPos] = newCol
+	desc.Columns = append(desc.Columns[:newPos], desc.Columns[newPos+1:]...)
+	for i := range desc.Families {
+		family := &desc.Families[i]
+		oldFamPos, newFamPos := -1, -1
+		for j, id := range family.ColumnIDs {
+			switch id {
+			case swap.OldColumnID:
+				oldFamPos = j
+			case swap.NewColumnID:
+				newFamPos = j
+			}
+		}
+		if oldFamPos >= 0 && newFamPos >= 0 {
+			family.ColumnIDs[oldFamPos], family.ColumnIDs[newFamPos] =
+				family.ColumnIDs[newFamPos], family.ColumnIDs[oldFamPos]
+			family.ColumnNames[oldFamPos], family.ColumnNames[newFamPos] =
+				family.ColumnNames[newFamPos], family.ColumnNames[oldFamPos]
+		}
+	}
+
+	findIndex := func(id IndexID) (int, error) {
+		for i := range desc.Indexes {
+			if desc.Indexes[i].ID == id {
+				return i, nil
+			}
+		}
+		return -1, pgerror.AssertionFailedf("index %d not found for type swap", log.Safe(id))
+	}
+	for i := range swap.OldIndexIDs {
+		oldIdxPos, err := findIndex(swap.OldIndexIDs[i])
+		if err != nil {
+			return err
+		}
+		newIdxPos, err := findIndex(swap.NewIndexIDs[i])
+		if err != nil {
+			return err
+		}
+		oldIdx, newIdx := desc.Indexes[oldIdxPos], desc.Indexes[newIdxPos]
+		oldIdx.Name, newIdx.Name = newIdx.Name, oldIdx.Name
+		desc.Indexes[oldIdxPos] = newIdx
+		desc.Indexes = append(desc.Indexes[:newIdxPos], desc.Indexes[newIdxPos+1:]...)
+		desc.addMutation(DescriptorMutation{
+			Descriptor_: &DescriptorMutation_Index{Index: &oldIdx}, Direction: DescriptorMutation_DROP,
+		})
+	}
+	desc.addMutation(DescriptorMutation{
+		Descriptor_: &DescriptorMutation_Column{Column: &oldCol}, Direction: DescriptorMutation_DROP,
+	})
+	return nil
+}
+
+// AddColumnTypeSwapMutation adds a mutation to desc.Mutations that replaces a
+// column and the indexes containing it once the mutations preceding it have
+// completed.
+func (desc *MutableTableDescriptor) AddColumnTypeSwapMutation(swap *ColumnTypeSwap) {
+	m := DescriptorMutation{
+		Descriptor_: &DescriptorMutation_ColumnTypeSwap{ColumnTypeSwap: swap},
+		Direction:   DescriptorMutation_ADD,
+	}
+	desc.addMutation(m)
+}
+
 // AddCheckValidationMutation adds a check constraint validation mutation to desc.Mutations.
 func (desc *MutableTableDescriptor) AddCheckValidationMutation(
 	ck *TableDescriptor_CheckConstraint,
@@ -2428,6 +2584,65 @@ func (desc *MutableTableDescriptor) AddCheckValidationMutation(
 	desc.addMutation(m)
 }
 
+// AddNotNullMutation adds a NOT NULL constraint mutation for the column
+// checked by the given constraint, created with MakeNotNullCheckConstraint,
+// to desc.Mutations.
+func (desc *MutableTableDescriptor) AddNotNullMutation(
+	ck *TableDescriptor_CheckConstraint, direction DescriptorMutation_Direction,
+) {
+	m := DescriptorMutation{
+		Descriptor_: &DescriptorMutation_Constraint{
+			Constraint: &ConstraintToUpdate{
+				ConstraintType: ConstraintToUpdate_NOT_NULL,
+				Name:           ck.Name,
+				NotNullColumn:  ck.ColumnIDs[0],
+				Check:          *ck,
+			},
+		},
+		Direction: direction,
+	}
+	desc.addMutation(m)
+}
+
+// MakeNotNullCheckConstraint creates the check constraint "col IS NOT NULL"
+// used to enforce NOT NULL on a column, for writes and for existing rows,
+// before the column is marked as not nullable. The name of the constraint is
+// chosen so that it doesn't collide with inuseNames.
+func MakeNotNullCheckConstraint(
+	colName string, colID ColumnID, inuseNames map[string]struct{}, validity ConstraintValidity,
+) *TableDescriptor_CheckConstraint {
+	name := fmt.Sprintf("%s_auto_not_null", colName)
+	// If generated name isn't unique, attempt to add a number to the end to
+	// get a unique name.
+	if _, ok := inuseNames[name]; ok {
+		i := 1
+		for {
+			appended := fmt.Sprintf("%s%d", name, i)
+			if _, ok := inuseNames[appended]; !ok {
+				name = appended
+				break
+			}
+			i++
+		}
+	}
+	if inuseNames != nil {
+		inuseNames[name] = struct{}{}
+	}
+
+	expr := &tree.ComparisonExpr{
+		Operator: tree.IsDistinctFrom,
+		Left:     &tree.ColumnItem{ColumnName: tree.Name(colName)},
+		Right:    tree.DNull,
+	}
+	return &TableDescriptor_CheckConstraint{
+		Name:                name,
+		Expr:                tree.Serialize(expr),
+		Validity:            validity,
+		ColumnIDs:           []ColumnID{colID},
+		IsNonNullConstraint: true,
+	}
+}
+
 // AddForeignKeyValidationMutation adds a foreign key constraint validation mutation to desc.Mutations.
 func (desc *MutableTableDescriptor) AddForeignKeyValidationMutation(
 	fk *ForeignKeyReference, idx IndexID,
@@ -2509,8 +2724,12 @@ func (desc *ImmutableTableDescriptor) MakeFirstMutationPublic() (*MutableTableDe
 			// of mutations if they have the mutation ID we're looking for.
 			break
 		}
-		if err := table.MakeMutationComplete(mutation); err != nil {
-			return nil, err
+		// The columns and indexes being added are made public, but they do not
+		// replace the ones whose type is being changed yet.
+		if mutation.GetColumnTypeSwap() == nil {
+			if err := table.MakeMutationComplete(mutation); err != nil {
+				return nil, err
+			}
 		}
 		i++
 	}
@@ -2520,8 +2739,12 @@ func (desc *ImmutableTableDescriptor) MakeFirstMutationPublic() (*MutableTableDe
 }
 
 // ColumnNeedsBackfill returns true if adding the given column requires a
-// backfill (dropping a column always requires a backfill).
+// backfill (dropping a column always requires a backfill). Virtual computed
+// columns are not stored, so adding one never requires a backfill.
 func ColumnNeedsBackfill(desc *ColumnDescriptor) bool {
+	if desc.Virtual {
+		return false
+	}
 	return desc.DefaultExpr != nil || !desc.Nullable || desc.IsComputed()
 }
 
@@ -2657,6 +2880,10 @@ func (desc *Descriptor) GetID() ID {
 		return t.Table.ID
 	case *Descriptor_Database:
 		return t.Database.ID
+	case *Descriptor_Type:
+		return t.Type.ID
+	case *Descriptor_Function:
+		return t.Function.ID
 	default:
 		return 0
 	}
@@ -2669,6 +2896,10 @@ func (desc *Descriptor) GetName() string {
 		return t.Table.Name
 	case *Descriptor_Database:
 		return t.Database.Name
+	case *Descriptor_Type:
+		return t.Type.Name
+	case *Descriptor_Function:
+		return t.Function.Name
 	default:
 		return ""
 	}
@@ -2746,14 +2977,25 @@ func (desc *ColumnDescriptor) SQLString() string {
 	} else {
 		f.WriteString(" NOT NULL")
 	}
-	if desc.DefaultExpr != nil {
-		f.WriteString(" DEFAULT ")
-		f.WriteString(*desc.DefaultExpr)
+	switch desc.GeneratedAsIdentityType {
+	case GeneratedAsIdentityType_GeneratedAlways:
+		f.WriteString(" GENERATED ALWAYS AS IDENTITY")
+	case GeneratedAsIdentityType_GeneratedByDefault:
+		f.WriteString(" GENERATED BY DEFAULT AS IDENTITY")
+	default:
+		if desc.DefaultExpr != nil {
+			f.WriteString(" DEFAULT ")
+			f.WriteString(*desc.DefaultExpr)
+		}
 	}
 	if desc.IsComputed() {
 		f.WriteString(" AS (")
 		f.WriteString(*desc.ComputeExpr)
-		f.WriteString(") STORED")
+		if desc.Virtual {
+			f.WriteString(") VIRTUAL")
+		} else {
+			f.WriteString(") STORED")
+		}
 	}
 	return f.CloseAndGetString()
 }
@@ -2904,24 +3146,20 @@ func (desc *ColumnDescriptor) DatumType() *types.T {
 
 // ColTypePrecision is part of the cat.Column interface.
 func (desc *ColumnDescriptor) ColTypePrecision() int {
-	if desc.Type.Family() == types.ArrayFamily {
-		if desc.Type.ArrayContents().Family() == types.ArrayFamily {
-			panic(pgerror.AssertionFailedf("column type should never be a nested array"))
-		}
-		return int(desc.Type.ArrayContents().Precision())
+	typ := &desc.Type
+	for typ.Family() == types.ArrayFamily {
+		typ = typ.ArrayContents()
 	}
-	return int(desc.Type.Precision())
+	return int(typ.Precision())
 }
 
 // ColTypeWidth is part of the cat.Column interface.
 func (desc *ColumnDescriptor) ColTypeWidth() int {
-	if desc.Type.Family() == types.ArrayFamily {
-		if desc.Type.ArrayContents().Family() == types.ArrayFamily {
-			panic(pgerror.AssertionFailedf("column type should never be a nested array"))
-		}
-		return int(desc.Type.ArrayContents().Width())
+	typ := &desc.Type
+	for typ.Family() == types.ArrayFamily {
+		typ = typ.ArrayContents()
 	}
-	return int(desc.Type.Width())
+	return int(typ.Width())
 }
 
 // ColTypeStr is part of the cat.Column interface.
@@ -2954,6 +3192,21 @@ func (desc *ColumnDescriptor) ComputedExprStr() string {
 	return *desc.ComputeExpr
 }
 
+// IsVirtualComputed is part of the cat.Column interface.
+func (desc *ColumnDescriptor) IsVirtualComputed() bool {
+	return desc.Virtual
+}
+
+// IsIdentity is part of the cat.Column interface.
+func (desc *ColumnDescriptor) IsIdentity() bool {
+	return desc.GeneratedAsIdentityType != GeneratedAsIdentityType_NotIdentityColumn
+}
+
+// IsGeneratedAlwaysAsIdentity is part of the cat.Column interface.
+func (desc *ColumnDescriptor) IsGeneratedAlwaysAsIdentity() bool {
+	return desc.GeneratedAsIdentityType == GeneratedAsIdentityType_GeneratedAlways
+}
+
 // CheckCanBeFKRef returns whether the given column is computed.
 func (desc *ColumnDescriptor) CheckCanBeFKRef() error {
 	if desc.IsComputed() {
@@ -52,6 +52,15 @@ func SanitizeVarFreeExpr(
 	}
 	semaCtx.Properties.Require(context, flags)
 
+	if err := checkNoUserDefinedTypeReferences(expr, context); err != nil {
+		return nil, err
+	}
+
+	// Stored expressions cannot refer to user-defined functions, for the same
+	// reason as user-defined types.
+	defer func(r tree.FunctionReferenceResolver) { semaCtx.FunctionResolver = r }(semaCtx.FunctionResolver)
+	semaCtx.FunctionResolver = nil
+
 	typedExpr, err := tree.TypeCheck(expr, semaCtx, expectedType)
 	if err != nil {
 		return nil, err
@@ -67,6 +76,72 @@ func SanitizeVarFreeExpr(
 	return typedExpr, nil
 }
 
+// SanitizeIndexPredicate verifies that the predicate of a partial index is a
+// boolean expression that can be evaluated on every write to the table. The
+// column references in the expression must already have been replaced by
+// dummies of the right type.
+func SanitizeIndexPredicate(expr tree.Expr, semaCtx *tree.SemaContext) (tree.TypedExpr, error) {
+	const context = "index predicate"
+
+	// We need to save and restore the previous value of the field in
+	// semaCtx in case we are recursively called from another context
+	// which uses the properties field.
+	defer semaCtx.Properties.Restore(semaCtx.Properties)
+
+	// The predicate is evaluated for every row written to the table, so it
+	// must not depend on anything but the row.
+	semaCtx.Properties.Require(context,
+		tree.RejectSpecial|tree.RejectImpureFunctions|tree.RejectSubqueries)
+
+	if err := checkNoUserDefinedTypeReferences(expr, context); err != nil {
+		return nil, err
+	}
+
+	defer func(r tree.FunctionReferenceResolver) { semaCtx.FunctionResolver = r }(semaCtx.FunctionResolver)
+	semaCtx.FunctionResolver = nil
+
+	typedExpr, err := tree.TypeCheck(expr, semaCtx, types.Bool)
+	if err != nil {
+		return nil, err
+	}
+	if actualType := typedExpr.ResolvedType(); actualType.Family() != types.BoolFamily {
+		return nil, pgerror.Newf(pgerror.CodeDatatypeMismatchError,
+			"expected %s expression to have type %s, but '%s' has type %s",
+			context, types.Bool, expr, actualType)
+	}
+	return typedExpr, nil
+}
+
+// checkNoUserDefinedTypeReferences returns an error if the expression refers
+// to a user-defined type by name. Stored expressions are parsed again without
+// knowing which database they belong to, so such references could not be
+// resolved. Values of user-defined types can still be written as constants,
+// since their type is inferred from the context.
+func checkNoUserDefinedTypeReferences(expr tree.Expr, context string) error {
+	_, err := tree.SimpleVisit(expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+		var typs []*types.T
+		switch t := expr.(type) {
+		case *tree.CastExpr:
+			typs = []*types.T{t.Type}
+		case *tree.AnnotateTypeExpr:
+			typs = []*types.T{t.Type}
+		case *tree.IsOfTypeExpr:
+			typs = t.Types
+		}
+		for _, typ := range typs {
+			for typ.Family() == types.ArrayFamily {
+				typ = typ.ArrayContents()
+			}
+			if typ.UnresolvedName() != "" || typ.TypeID() != 0 {
+				return false, nil, pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+					"references to user-defined type %s are not allowed in %s", typ.SQLString(), context)
+			}
+		}
+		return true, expr, nil
+	})
+	return err
+}
+
 // ValidateColumnDefType returns an error if the type of a column definition is
 // not valid. It is checked when a column is created or altered.
 func ValidateColumnDefType(t *types.T) error {
@@ -89,18 +164,25 @@ func ValidateColumnDefType(t *types.T) error {
 		}
 
 	case types.ArrayFamily:
-		if t.ArrayContents().Family() == types.ArrayFamily {
-			// Nested arrays are not supported as a column type.
-			return errors.Errorf("nested array unsupported as column type: %s", t.String())
-		}
 		if err := types.CheckArrayElementType(t.ArrayContents()); err != nil {
 			return err
 		}
+		if t.ArrayContents().Family() == types.TupleFamily {
+			return pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+				"arrays of type %s cannot be used for table columns", t.ArrayContents().String())
+		}
 		return ValidateColumnDefType(t.ArrayContents())
 
+	case types.TupleFamily:
+		// Only the tuple types of composite types can be used for columns.
+		if t.CompositeData() == nil {
+			return pgerror.Newf(pgerror.CodeInvalidTableDefinitionError,
+				"value type %s cannot be used for table columns", t.String())
+		}
+
 	case types.BitFamily, types.IntFamily, types.FloatFamily, types.BoolFamily, types.BytesFamily, types.DateFamily,
 		types.INetFamily, types.IntervalFamily, types.JsonFamily, types.OidFamily, types.TimeFamily,
-		types.TimestampFamily, types.TimestampTZFamily, types.UuidFamily:
+		types.TimeTZFamily, types.TimestampFamily, types.TimestampTZFamily, types.UuidFamily, types.EnumFamily:
 		// These types are OK.
 
 	default:
@@ -138,6 +220,13 @@ func MakeColumnDefDescs(
 			"SERIAL cannot be used in this context")
 	}
 
+	if d.IsIdentity() && !d.HasDefaultExpr() {
+		// As for SERIAL, the sequence of an identity column must be created by
+		// processSerialInColumnDef() prior to calling MakeColumnDefDescs.
+		return nil, nil, nil, pgerror.New(pgerror.CodeFeatureNotSupportedError,
+			"identity columns cannot be used in this context")
+	}
+
 	if len(d.CheckExprs) > 0 {
 		// Should never happen since `HoistConstraints` moves these to table level
 		return nil, nil, nil, errors.New("unexpected column CHECK constraint")
@@ -151,13 +240,22 @@ func MakeColumnDefDescs(
 		Name:     string(d.Name),
 		Nullable: d.Nullable.Nullability != tree.NotNull && !d.PrimaryKey,
 	}
+	if d.IsIdentity() {
+		col.GeneratedAsIdentityType = GeneratedAsIdentityType_GeneratedByDefault
+		if d.Identity.Always {
+			col.GeneratedAsIdentityType = GeneratedAsIdentityType_GeneratedAlways
+		}
+	}
 
-	// Validate and assign column type.
-	err := ValidateColumnDefType(d.Type)
+	// Resolve, validate and assign column type.
+	typ, err := tree.ResolveType(d.Type, semaCtx)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	col.Type = *d.Type
+	if err := ValidateColumnDefType(typ); err != nil {
+		return nil, nil, nil, err
+	}
+	col.Type = *typ
 
 	var typedExpr tree.TypedExpr
 	if d.HasDefaultExpr() {
@@ -165,7 +263,7 @@ func MakeColumnDefDescs(
 		// and does not contain invalid functions.
 		var err error
 		if typedExpr, err = SanitizeVarFreeExpr(
-			d.DefaultExpr.Expr, d.Type, "DEFAULT", semaCtx, true, /* allowImpure */
+			d.DefaultExpr.Expr, typ, "DEFAULT", semaCtx, true, /* allowImpure */
 		); err != nil {
 			return nil, nil, nil, err
 		}
@@ -180,6 +278,11 @@ func MakeColumnDefDescs(
 	if d.IsComputed() {
 		s := tree.Serialize(d.Computed.Expr)
 		col.ComputeExpr = &s
+		col.Virtual = d.IsVirtual()
+	}
+	if col.Virtual && d.HasColumnFamily() {
+		return nil, nil, nil, pgerror.Newf(pgerror.CodeInvalidTableDefinitionError,
+			"virtual computed column %q cannot be part of a family", col.Name)
 	}
 
 	var idx *IndexDescriptor
@@ -339,7 +442,7 @@ func (desc *TableDescriptor) collectConstraintInfo(
 			detail.Columns = index.ColumnNames
 			detail.Index = index
 			info[index.Name] = detail
-		} else if index.Unique {
+		} else if index.Unique || index.IsDeferrableUnique() {
 			if _, ok := info[index.Name]; ok {
 				return nil, pgerror.Newf(pgerror.CodeDuplicateObjectError,
 					"duplicate constraint name: %q", index.Name)
@@ -395,10 +498,20 @@ func (desc *TableDescriptor) collectConstraintInfo(
 		info[fk.Name] = detail
 	}
 
+	// The temporary check constraints enforcing NOT NULL on a column are not
+	// listed, but their names are still reserved.
+	nonNullNames := make(map[string]struct{})
 	for _, c := range desc.AllActiveAndInactiveChecks() {
 		if _, ok := info[c.Name]; ok {
 			return nil, errors.Errorf("duplicate constraint name: %q", c.Name)
 		}
+		if _, ok := nonNullNames[c.Name]; ok {
+			return nil, errors.Errorf("duplicate constraint name: %q", c.Name)
+		}
+		if c.IsNonNullConstraint {
+			nonNullNames[c.Name] = struct{}{}
+			continue
+		}
 		detail := ConstraintDetail{Kind: ConstraintTypeCheck}
 		// Constraints in the Validating state are considered Unvalidated for this purpose
 		detail.Unvalidated = c.Validity != ConstraintValidity_Validated
@@ -346,6 +346,36 @@ func TestArrayEncoding(t *testing.T) {
 				HasNulls: true,
 			},
 			[]byte{17, 3, 9, 6, 1, 2, 4, 6, 8, 10, 12},
+		}, {
+			"two-dimensional int array",
+			tree.DArray{
+				ParamTyp: types.IntArray,
+				Array: tree.Datums{
+					&tree.DArray{ParamTyp: types.Int, Array: tree.Datums{tree.NewDInt(1), tree.NewDInt(2)}},
+					&tree.DArray{ParamTyp: types.Int, Array: tree.Datums{tree.NewDInt(3), tree.NewDInt(4)}},
+				},
+			},
+			[]byte{2, 3, 2, 2, 2, 4, 6, 8},
+		}, {
+			"two-dimensional array containing nulls",
+			tree.DArray{
+				ParamTyp: types.IntArray,
+				Array: tree.Datums{
+					&tree.DArray{ParamTyp: types.Int, Array: tree.Datums{tree.NewDInt(1), tree.DNull}, HasNulls: true},
+					&tree.DArray{ParamTyp: types.Int, Array: tree.Datums{tree.DNull, tree.NewDInt(4)}, HasNulls: true},
+				},
+			},
+			[]byte{18, 3, 2, 2, 6, 2, 8},
+		}, {
+			"two-dimensional array of empty arrays",
+			tree.DArray{
+				ParamTyp: types.IntArray,
+				Array: tree.Datums{
+					&tree.DArray{ParamTyp: types.Int, Array: tree.Datums{}},
+					&tree.DArray{ParamTyp: types.Int, Array: tree.Datums{}},
+				},
+			},
+			[]byte{2, 3, 2, 0},
 		},
 	}
 
@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -131,6 +132,8 @@ func RandDatumWithNullChance(rng *rand.Rand, typ *types.T, nullChance int) tree.
 		return tree.NewDDate(d)
 	case types.TimeFamily:
 		return tree.MakeDTime(timeofday.Random(rng))
+	case types.TimeTZFamily:
+		return tree.NewDTimeTZ(timetz.Random(rng))
 	case types.TimestampFamily:
 		return &tree.DTimestamp{Time: timeutil.Unix(rng.Int63n(1000000), rng.Int63n(1000000))}
 	case types.IntervalFamily:
@@ -275,6 +278,10 @@ var (
 			tree.MakeDTime(timeofday.Min),
 			tree.MakeDTime(timeofday.Max),
 		},
+		types.TimeTZFamily: {
+			tree.NewDTimeTZ(timetz.Min),
+			tree.NewDTimeTZ(timetz.Max),
+		},
 		types.TimestampFamily: func() []tree.Datum {
 			res := make([]tree.Datum, len(randTimestampSpecials))
 			for i, t := range randTimestampSpecials {
@@ -0,0 +1,174 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sqlbase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// SetID implements the DescriptorProto interface.
+func (desc *TypeDescriptor) SetID(id ID) {
+	desc.ID = id
+}
+
+// TypeName returns the plain type of this descriptor.
+func (desc *TypeDescriptor) TypeName() string {
+	return "type"
+}
+
+// SetName implements the DescriptorProto interface.
+func (desc *TypeDescriptor) SetName(name string) {
+	desc.Name = name
+}
+
+// GetAuditMode is part of the DescriptorProto interface.
+// Types cannot be audited.
+func (desc *TypeDescriptor) GetAuditMode() TableDescriptor_AuditMode {
+	return TableDescriptor_DISABLED
+}
+
+// Validate validates that the type descriptor is well formed. Checks include
+// validating the type name, verifying that the enum members are unique and
+// sorted by their physical representations, and that the names of the fields
+// of a composite type are unique.
+func (desc *TypeDescriptor) Validate() error {
+	if err := validateName(desc.Name, "type"); err != nil {
+		return err
+	}
+	if desc.ID == 0 {
+		return fmt.Errorf("invalid type ID %d", desc.ID)
+	}
+	if desc.ParentID == 0 {
+		return fmt.Errorf("invalid parent ID %d", desc.ParentID)
+	}
+	labels := make(map[string]struct{}, len(desc.EnumMembers))
+	for i := range desc.EnumMembers {
+		member := &desc.EnumMembers[i]
+		if _, ok := labels[member.LogicalRepresentation]; ok {
+			return fmt.Errorf("duplicate enum member %q", member.LogicalRepresentation)
+		}
+		labels[member.LogicalRepresentation] = struct{}{}
+		if i > 0 && bytes.Compare(desc.EnumMembers[i-1].PhysicalRepresentation,
+			member.PhysicalRepresentation) >= 0 {
+			return fmt.Errorf("enum members %q and %q are not sorted",
+				desc.EnumMembers[i-1].LogicalRepresentation, member.LogicalRepresentation)
+		}
+	}
+	if desc.Kind != TypeDescriptor_ENUM && len(desc.EnumMembers) > 0 {
+		return fmt.Errorf("type %q of kind %s has enum members", desc.Name, desc.Kind)
+	}
+	if desc.Kind != TypeDescriptor_COMPOSITE && len(desc.CompositeFields) > 0 {
+		return fmt.Errorf("type %q of kind %s has composite fields", desc.Name, desc.Kind)
+	}
+	fields := make(map[string]struct{}, len(desc.CompositeFields))
+	for i := range desc.CompositeFields {
+		field := &desc.CompositeFields[i]
+		if _, ok := fields[field.Name]; ok {
+			return fmt.Errorf("duplicate composite field %q", field.Name)
+		}
+		fields[field.Name] = struct{}{}
+	}
+	return desc.Privileges.Validate(desc.GetID())
+}
+
+// MakeTypesT returns the types.T that columns and expressions of this type
+// use.
+func (desc *TypeDescriptor) MakeTypesT() *types.T {
+	if desc.Kind == TypeDescriptor_COMPOSITE {
+		contents := make([]types.T, len(desc.CompositeFields))
+		labels := make([]string, len(desc.CompositeFields))
+		for i := range desc.CompositeFields {
+			contents[i] = desc.CompositeFields[i].Type
+			labels[i] = desc.CompositeFields[i].Name
+		}
+		return types.MakeComposite(uint32(desc.ID), desc.Name, contents, labels)
+	}
+	logical := make([]string, len(desc.EnumMembers))
+	physical := make([][]byte, len(desc.EnumMembers))
+	readOnly := make([]bool, len(desc.EnumMembers))
+	for i := range desc.EnumMembers {
+		member := &desc.EnumMembers[i]
+		logical[i] = member.LogicalRepresentation
+		physical[i] = member.PhysicalRepresentation
+		readOnly[i] = member.Capability == TypeDescriptor_EnumMember_READ_ONLY
+	}
+	return types.MakeEnum(uint32(desc.ID), desc.Name, logical, physical, readOnly)
+}
+
+// HasReadOnlyMembers returns whether some member of the type is not writable
+// yet.
+func (desc *TypeDescriptor) HasReadOnlyMembers() bool {
+	for i := range desc.EnumMembers {
+		if desc.EnumMembers[i].Capability == TypeDescriptor_EnumMember_READ_ONLY {
+			return true
+		}
+	}
+	return false
+}
+
+// AddReferencingDescriptorID records that the descriptor with the given ID
+// uses the type. It is a no-op if the reference already exists.
+func (desc *TypeDescriptor) AddReferencingDescriptorID(id ID) {
+	for _, ref := range desc.ReferencingDescriptorIDs {
+		if ref == id {
+			return
+		}
+	}
+	desc.ReferencingDescriptorIDs = append(desc.ReferencingDescriptorIDs, id)
+}
+
+// RemoveReferencingDescriptorID removes the reference from the descriptor with
+// the given ID, if any.
+func (desc *TypeDescriptor) RemoveReferencingDescriptorID(id ID) {
+	for i, ref := range desc.ReferencingDescriptorIDs {
+		if ref == id {
+			desc.ReferencingDescriptorIDs = append(
+				desc.ReferencingDescriptorIDs[:i], desc.ReferencingDescriptorIDs[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetTypeDescFromID retrieves the type descriptor for the type ID passed in
+// using an existing txn. Returns an error if the descriptor doesn't exist or
+// if it exists and is not a type.
+func GetTypeDescFromID(ctx context.Context, txn *client.Txn, id ID) (*TypeDescriptor, error) {
+	desc := &Descriptor{}
+	descKey := MakeDescMetadataKey(id)
+
+	if err := txn.GetProto(ctx, descKey, desc); err != nil {
+		return nil, err
+	}
+	typ := desc.GetType()
+	if typ == nil {
+		return nil, ErrDescriptorNotFound
+	}
+	return typ, nil
+}
+
+// ColumnTypeReferencesType returns whether values of the given column type
+// are, or contain, values of the user-defined type with the given ID.
+func ColumnTypeReferencesType(t *types.T, id ID) bool {
+	switch t.Family() {
+	case types.EnumFamily, types.TupleFamily:
+		return t.TypeID() == uint32(id)
+	case types.ArrayFamily:
+		return ColumnTypeReferencesType(t.ArrayContents(), id)
+	}
+	return false
+}
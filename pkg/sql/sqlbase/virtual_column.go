@@ -0,0 +1,171 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sqlbase
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+)
+
+// HasVirtualColumns returns whether the table has a virtual computed column,
+// including a column that is being added or dropped. The values of virtual
+// computed columns are not stored in the primary index.
+func (desc *TableDescriptor) HasVirtualColumns() bool {
+	for i := range desc.Columns {
+		if desc.Columns[i].Virtual {
+			return true
+		}
+	}
+	for i := range desc.Mutations {
+		if col := desc.Mutations[i].GetColumn(); col != nil && col.Virtual {
+			return true
+		}
+	}
+	return false
+}
+
+// VirtualColumnHelper computes the values of the virtual computed columns of a
+// table from the values of the other columns of a row, when backfilling a
+// secondary index that stores virtual computed columns.
+//
+// Callers should call NewVirtualColumnHelper to initialize a new instance of
+// VirtualColumnHelper, make sure that the rows they read contain the columns
+// returned by Dependencies, and call ComputeRow for every row.
+//
+// Like the predicates of partial indexes, the expressions are evaluated without
+// a session. Computed column expressions cannot use impure functions, so they
+// evaluate to the same values as when the rows are read by a query.
+type VirtualColumnHelper struct {
+	// exprs parallels cols. It contains nil for the columns that are not
+	// virtual computed columns.
+	exprs        []tree.TypedExpr
+	deps         []util.FastIntSet
+	cols         []ColumnDescriptor
+	sourceInfo   *DataSourceInfo
+	curSourceRow tree.Datums
+	evalCtx      tree.EvalContext
+}
+
+var _ tree.IndexedVarContainer = &VirtualColumnHelper{}
+
+// NewVirtualColumnHelper constructs a new instance of VirtualColumnHelper for
+// the given columns of the table. It returns nil if none of the columns is a
+// virtual computed column.
+func NewVirtualColumnHelper(
+	tableDesc *TableDescriptor, cols []ColumnDescriptor,
+) (*VirtualColumnHelper, error) {
+	var exprStrings []string
+	for i := range cols {
+		if cols[i].Virtual {
+			exprStrings = append(exprStrings, *cols[i].ComputeExpr)
+		}
+	}
+	if len(exprStrings) == 0 {
+		return nil, nil
+	}
+	exprs, err := parser.ParseExprs(exprStrings)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &VirtualColumnHelper{cols: cols}
+	h.sourceInfo = NewSourceInfoForSingleTable(
+		tree.MakeUnqualifiedTableName(tree.Name(tableDesc.Name)),
+		ResultColumnsFromColDescs(cols),
+	)
+	semaCtx := tree.MakeSemaContext()
+	semaCtx.IVarContainer = h
+
+	h.exprs = make([]tree.TypedExpr, len(cols))
+	h.deps = make([]util.FastIntSet, len(cols))
+	exprIdx := 0
+	for i := range cols {
+		if !cols[i].Virtual {
+			continue
+		}
+		ivarHelper := tree.MakeIndexedVarHelper(h, len(h.cols))
+		expr, _, _, err := ResolveNames(
+			exprs[exprIdx], MakeMultiSourceInfo(h.sourceInfo), ivarHelper, DefaultSearchPath,
+		)
+		if err != nil {
+			return nil, err
+		}
+		typedExpr, err := tree.TypeCheck(expr, &semaCtx, &cols[i].Type)
+		if err != nil {
+			return nil, err
+		}
+		h.exprs[i] = typedExpr
+		for j := range cols {
+			if ivarHelper.IndexedVarUsed(j) {
+				h.deps[i].Add(j)
+			}
+		}
+		exprIdx++
+	}
+
+	h.curSourceRow = make(tree.Datums, len(h.cols))
+	h.evalCtx = tree.EvalContext{
+		Context:     context.Background(),
+		SessionData: &sessiondata.SessionData{},
+	}
+	h.evalCtx.IVarContainer = h
+	return h, nil
+}
+
+// Dependencies returns the positions of the columns referenced by the
+// expression of the column at position i in the columns the helper was created
+// for. It returns the empty set if the column is not a virtual computed column.
+func (h *VirtualColumnHelper) Dependencies(i int) util.FastIntSet {
+	return h.deps[i]
+}
+
+// ComputeRow sets the values of the virtual computed columns in row, which
+// parallels the columns the helper was created for, from the values of the
+// other columns.
+func (h *VirtualColumnHelper) ComputeRow(row tree.Datums) error {
+	copy(h.curSourceRow, row)
+	for i, expr := range h.exprs {
+		if expr == nil {
+			continue
+		}
+		d, err := expr.Eval(&h.evalCtx)
+		if err != nil {
+			return err
+		}
+		row[i], err = LimitValueWidth(&h.cols[i].Type, d, &h.cols[i].Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IndexedVarEval implements the tree.IndexedVarContainer interface.
+func (h *VirtualColumnHelper) IndexedVarEval(idx int, ctx *tree.EvalContext) (tree.Datum, error) {
+	return h.curSourceRow[idx].Eval(ctx)
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (h *VirtualColumnHelper) IndexedVarResolvedType(idx int) *types.T {
+	return h.sourceInfo.SourceColumns[idx].Typ
+}
+
+// IndexedVarNodeFormatter implements the tree.IndexedVarContainer interface.
+func (h *VirtualColumnHelper) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
+	return h.sourceInfo.NodeFormatter(idx)
+}
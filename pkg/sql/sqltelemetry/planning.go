@@ -22,6 +22,10 @@ import (
 // is planned without error in a query.
 var CteUseCounter = telemetry.GetCounterOnce("sql.plan.cte")
 
+// RecursiveCteUseCounter is to be incremented every time a recursive CTE
+// (WITH RECURSIVE ...) is planned without error in a query.
+var RecursiveCteUseCounter = telemetry.GetCounterOnce("sql.plan.cte.recursive")
+
 // SubqueryUseCounter is to be incremented every time a subquery is
 // planned.
 var SubqueryUseCounter = telemetry.GetCounterOnce("sql.plan.subquery")
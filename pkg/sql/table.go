@@ -528,6 +528,11 @@ func (tc *TableCollection) getUncommittedTable(
 	for i := len(tc.uncommittedTables) - 1; i >= 0; i-- {
 		table := tc.uncommittedTables[i]
 		mutTbl := table.MutableTableDescriptor
+		// Temporary tables do not live in the public schema; they are
+		// resolved through the session's temporary schema instead.
+		if mutTbl.IsTemporary() {
+			continue
+		}
 		// If a table has gotten renamed we'd like to disallow using the old names.
 		// The renames could have happened in another transaction but it's still okay
 		// to disallow the use of the old name in this transaction because the other
@@ -18,6 +18,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/internal/client"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -282,6 +283,14 @@ func (tu *tableUpserter) init(txn *client.Txn, evalCtx *tree.EvalContext) error
 
 	tableDesc := tu.tableDesc()
 
+	// The existing rows are fetched from the primary index, which doesn't store
+	// the values of virtual computed columns.
+	if tableDesc.HasVirtualColumns() {
+		return pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+			"upserting into table %q with virtual computed columns requires the cost-based optimizer",
+			tableDesc.Name)
+	}
+
 	requestedCols := tableDesc.Columns
 
 	if len(tu.updateCols) == 0 {
@@ -301,6 +310,7 @@ func (tu *tableUpserter) init(txn *client.Txn, evalCtx *tree.EvalContext) error
 		if err != nil {
 			return err
 		}
+		tu.ru.SetDeferredChecks(tu.ri.DeferredChecks())
 
 		// t.ru.fetchCols can also contain columns undergoing mutation.
 		tu.fetchCols = tu.ru.FetchCols
@@ -329,7 +339,13 @@ func (tu *tableUpserter) init(txn *client.Txn, evalCtx *tree.EvalContext) error
 	}
 
 	if err := tu.fetcher.Init(
-		false /* reverse */, false /*returnRangeInfo*/, false /* isCheck */, tu.alloc, tableArgs,
+		false, /* reverse */
+		sqlbase.ScanLockingStrength_FOR_NONE,
+		sqlbase.ScanLockingWaitPolicy_BLOCK,
+		false, /* returnRangeInfo */
+		false, /* isCheck */
+		tu.alloc,
+		tableArgs,
 	); err != nil {
 		return err
 	}
@@ -89,7 +89,11 @@ func (tu *optTableUpserter) init(txn *client.Txn, evalCtx *tree.EvalContext) err
 		evalCtx,
 		tu.alloc,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	tu.ru.SetDeferredChecks(tu.ri.DeferredChecks())
+	return nil
 }
 
 // desc is part of the tableWriter interface.
@@ -99,12 +99,13 @@ func (tu *strictTableUpserter) atBatchEnd(ctx context.Context, traceKV bool) err
 	return nil
 }
 
-// Get all unique indexes and store them in tu.ConflictIndexes.
+// Get all unique indexes and store them in tu.ConflictIndexes. Partial
+// indexes are not considered: the rows they don't contain never conflict.
 func (tu *strictTableUpserter) getUniqueIndexes() (err error) {
 	tableDesc := tu.tableDesc()
 	indexes := tableDesc.Indexes
 	for _, index := range indexes {
-		if index.Unique {
+		if index.Unique && !index.IsPartial() {
 			tu.conflictIndexes = append(tu.conflictIndexes, index)
 		}
 	}
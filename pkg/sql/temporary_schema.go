@@ -0,0 +1,434 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logtags"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// temporaryObjectCleanupInterval is the interval at which each node looks for
+// temporary objects left behind by sessions that are no longer running, for
+// example because the node they were connected to died.
+var temporaryObjectCleanupInterval = settings.RegisterNonNegativeDurationSetting(
+	"sql.temp_object_cleaner.cleanup_interval",
+	"how often to clean up orphaned temporary objects",
+	30*time.Minute,
+)
+
+// temporarySchema keeps track of the temporary tables and views created by a
+// session. These objects live in the session's pg_temp schema: they are only
+// visible to the session that created them, and they are dropped when the
+// session ends.
+//
+// Temporary objects do not have an entry in system.namespace; the session
+// resolves their names through this registry instead. The registry is only
+// a hint: the descriptors it points to are always read from KV within the
+// current transaction and are checked to still match the requested name.
+// This ensures that renames, drops and transaction aborts are handled
+// correctly without having to keep the registry in sync with the outcome of
+// each transaction.
+type temporarySchema struct {
+	// sessionID is the ID of the session owning the temporary objects. It is
+	// stored in the descriptor of every temporary object.
+	sessionID ClusterWideID
+
+	// objects maps a database ID and an object name to the IDs of the
+	// temporary objects that have had that name. The most recent ID is last.
+	objects map[sqlbase.ID]map[string][]sqlbase.ID
+}
+
+// add records a temporary object with the given name in the given database.
+func (ts *temporarySchema) add(dbID sqlbase.ID, name string, id sqlbase.ID) {
+	if ts.objects == nil {
+		ts.objects = make(map[sqlbase.ID]map[string][]sqlbase.ID)
+	}
+	names := ts.objects[dbID]
+	if names == nil {
+		names = make(map[string][]sqlbase.ID)
+		ts.objects[dbID] = names
+	}
+	names[name] = append(names[name], id)
+}
+
+// empty returns true if the session never created a temporary object.
+func (ts *temporarySchema) empty() bool {
+	return len(ts.objects) == 0
+}
+
+// allIDs returns the IDs of all the temporary objects that were created by
+// the session. Some of them may have already been dropped.
+func (ts *temporarySchema) allIDs() []sqlbase.ID {
+	var ids []sqlbase.ID
+	seen := make(map[sqlbase.ID]struct{})
+	for _, names := range ts.objects {
+		for _, nameIDs := range names {
+			for _, id := range nameIDs {
+				if _, ok := seen[id]; !ok {
+					seen[id] = struct{}{}
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// ownsDescriptor returns true if desc is a live temporary object created by
+// this session.
+func (ts *temporarySchema) ownsDescriptor(desc *sqlbase.TableDescriptor) bool {
+	return desc.IsTemporary() && !desc.Dropped() &&
+		BytesToClusterWideID(desc.TemporarySessionID) == ts.sessionID
+}
+
+// errTemporaryFKMismatch is returned when a foreign key would connect a
+// temporary table with a permanent one.
+func errTemporaryFKMismatch(temporary bool) error {
+	if temporary {
+		return pgerror.New(pgerror.CodeInvalidTableDefinitionError,
+			"constraints on temporary tables may reference only temporary tables")
+	}
+	return pgerror.New(pgerror.CodeInvalidTableDefinitionError,
+		"constraints on permanent tables may reference only permanent tables")
+}
+
+// resolveTargetObjectMaybeTemporary is like ResolveUncachedDatabase, but it
+// also accepts the pg_temp schema. It returns whether the new object must be
+// temporary, which is the case if temporary is set or if the object was
+// explicitly qualified with pg_temp. The name is modified in-place so that
+// temporary objects always have the pg_temp schema.
+func (p *planner) resolveTargetObjectMaybeTemporary(
+	ctx context.Context, tn *ObjectName, temporary bool,
+) (res *DatabaseDescriptor, isTemporary bool, err error) {
+	p.runWithOptions(resolveFlags{skipCache: true}, func() {
+		res, err = resolveTargetObject(ctx, p, tn, true /* allowTemporary */)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if tn.Schema() == sessiondata.PgTempSchemaName {
+		return res, true, nil
+	}
+	if temporary {
+		if tn.ExplicitSchema {
+			return nil, false, pgerror.New(pgerror.CodeInvalidTableDefinitionError,
+				"cannot create temporary relation in non-temporary schema")
+		}
+		if p.extendedEvalCtx.TemporarySchema == nil {
+			return nil, false, pgerror.New(pgerror.CodeFeatureNotSupportedError,
+				"temporary objects are not supported in this context")
+		}
+		tn.SchemaName = sessiondata.PgTempSchemaName
+	}
+	return res, temporary, nil
+}
+
+// lookupTemporarySchema implements LookupSchema for the pg_temp schema.
+// The temporary schema is valid in every database, but only in sessions
+// that can hold temporary objects.
+func (p *planner) lookupTemporarySchema(
+	ctx context.Context, dbName string,
+) (found bool, scMeta tree.SchemaMeta, err error) {
+	if p.extendedEvalCtx.TemporarySchema == nil {
+		return false, nil, nil
+	}
+	dbDesc, err := p.LogicalSchemaAccessor().GetDatabaseDesc(ctx, p.txn, dbName,
+		p.CommonLookupFlags(false /*required*/))
+	if err != nil || dbDesc == nil {
+		return false, nil, err
+	}
+	return true, dbDesc, nil
+}
+
+// lookupTemporaryObject implements LookupObject for the pg_temp schema.
+func (p *planner) lookupTemporaryObject(
+	ctx context.Context, requireMutable bool, dbName, tbName string,
+) (found bool, objMeta tree.NameResolutionResult, err error) {
+	ts := p.extendedEvalCtx.TemporarySchema
+	if ts == nil || ts.empty() {
+		return false, nil, nil
+	}
+	dbDesc, err := p.LogicalSchemaAccessor().GetDatabaseDesc(ctx, p.txn, dbName,
+		p.CommonLookupFlags(false /*required*/))
+	if err != nil || dbDesc == nil {
+		return false, nil, err
+	}
+	ids := ts.objects[dbDesc.ID][tbName]
+	// Try the most recent objects first.
+	for i := len(ids) - 1; i >= 0; i-- {
+		if t := p.Tables().getUncommittedTableByID(ids[i]); t.MutableTableDescriptor != nil {
+			mutDesc := t.MutableTableDescriptor
+			if mutDesc.Name != tbName || !ts.ownsDescriptor(mutDesc.TableDesc()) {
+				continue
+			}
+			if requireMutable {
+				return true, mutDesc, nil
+			}
+			return true, t.ImmutableTableDescriptor, nil
+		}
+		desc, err := sqlbase.GetTableDescFromID(ctx, p.txn, ids[i])
+		if err != nil {
+			if err == sqlbase.ErrDescriptorNotFound {
+				continue
+			}
+			return false, nil, err
+		}
+		if desc.Name != tbName || !ts.ownsDescriptor(desc) {
+			continue
+		}
+		if requireMutable {
+			return true, sqlbase.NewMutableExistingTableDescriptor(*desc), nil
+		}
+		return true, sqlbase.NewImmutableTableDescriptor(*desc), nil
+	}
+	return false, nil, nil
+}
+
+// registerTemporaryObject sets up desc as a temporary object of the current
+// session. It must be called before the descriptor is written.
+func (p *planner) registerTemporaryObject(dbID sqlbase.ID, desc *sqlbase.MutableTableDescriptor) {
+	ts := p.extendedEvalCtx.TemporarySchema
+	desc.TemporarySessionID = ts.sessionID.GetBytes()
+	ts.add(dbID, desc.Name, desc.ID)
+}
+
+// temporaryObjectExists returns true if the current session has a
+// temporary object with the given name in the given database.
+func (p *planner) temporaryObjectExists(
+	ctx context.Context, dbDesc *DatabaseDescriptor, name string,
+) (bool, error) {
+	found, _, err := p.lookupTemporaryObject(ctx, false /* requireMutable */, dbDesc.Name, name)
+	return found, err
+}
+
+// renameTemporaryObject renames a temporary table or view. Temporary objects
+// do not have a namespace entry, so only the descriptor and the session's
+// registry need to be updated. They cannot be moved to another database.
+func (p *planner) renameTemporaryObject(
+	ctx context.Context, newTn *ObjectName, tableDesc *sqlbase.MutableTableDescriptor,
+) error {
+	targetDbDesc, _, err := p.resolveTargetObjectMaybeTemporary(ctx, newTn, true /* temporary */)
+	if err != nil {
+		return err
+	}
+	if targetDbDesc.ID != tableDesc.ParentID {
+		return pgerror.New(pgerror.CodeFeatureNotSupportedError,
+			"cannot move a temporary object to another database")
+	}
+	if newTn.Table() == tableDesc.Name {
+		// Noop.
+		return nil
+	}
+	if exists, err := p.temporaryObjectExists(ctx, targetDbDesc, newTn.Table()); err != nil {
+		return err
+	} else if exists {
+		return sqlbase.NewRelationAlreadyExistsError(newTn.Table())
+	}
+
+	tableDesc.SetName(newTn.Table())
+	if err := tableDesc.Validate(ctx, p.txn, p.EvalContext().Settings); err != nil {
+		return err
+	}
+	if err := p.writeSchemaChange(ctx, tableDesc, sqlbase.InvalidMutationID); err != nil {
+		return err
+	}
+	p.extendedEvalCtx.TemporarySchema.add(tableDesc.ParentID, tableDesc.Name, tableDesc.ID)
+	return nil
+}
+
+// cleanupTemporaryObjects drops the temporary objects with the given IDs
+// that were created by the given session. Objects that no longer exist, that
+// are already dropped, or that belong to a different session are skipped.
+// Both the descriptors and the data are removed asynchronously by the
+// schema changer, as for a regular DROP.
+func cleanupTemporaryObjects(
+	ctx context.Context, execCfg *ExecutorConfig, sessionID ClusterWideID, ids []sqlbase.ID,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return execCfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		p, cleanup := newInternalPlanner(
+			"drop-temp-objects", txn, security.RootUser, &MemoryMetrics{}, execCfg,
+		)
+		defer cleanup()
+		params := runParams{ctx: ctx, extendedEvalCtx: &p.extendedEvalCtx, p: p}
+		ts := temporarySchema{sessionID: sessionID}
+		for _, id := range ids {
+			desc, err := p.Tables().getMutableTableVersionByID(ctx, id, txn)
+			if err != nil {
+				if err == sqlbase.ErrDescriptorNotFound {
+					continue
+				}
+				return err
+			}
+			// A view may have already been dropped along with a table it
+			// depends on.
+			if !ts.ownsDescriptor(desc.TableDesc()) {
+				continue
+			}
+			if desc.IsView() {
+				if _, err := p.dropViewImpl(ctx, desc, tree.DropCascade); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := p.createDropTablesJob(
+				ctx,
+				[]*sqlbase.MutableTableDescriptor{desc},
+				[]jobspb.DroppedTableDetails{{Name: desc.Name, ID: desc.ID}},
+				"dropping temporary objects",
+				false, /* drainNames */
+				sqlbase.InvalidID /* droppedDatabaseID */); err != nil {
+				return err
+			}
+			if _, err := p.dropTableImpl(params, desc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// cleanupTemporarySchema drops all the temporary objects of the session.
+func (ex *connExecutor) cleanupTemporarySchema(ctx context.Context) {
+	ts := &ex.temporarySchema
+	if ts.empty() || ex.server.cfg.TestingKnobs.DisableTempObjectsCleanupOnSessionExit {
+		return
+	}
+	// The session's context may already be canceled, for example if the client
+	// disconnected, so only its log tags are kept.
+	ctx = logtags.WithTags(context.Background(), logtags.FromContext(ctx))
+	if err := cleanupTemporaryObjects(ctx, ex.server.cfg, ts.sessionID, ts.allIDs()); err != nil {
+		// The objects will eventually be dropped by the TemporaryObjectCleaner.
+		log.Warningf(ctx, "error while dropping temporary objects: %s", err)
+	}
+	ts.objects = nil
+}
+
+// TemporaryObjectCleaner periodically drops the temporary objects of
+// sessions that are no longer running. Sessions normally drop their own
+// temporary objects when they end, so this only deals with sessions which
+// could not do so, for example because their node crashed.
+type TemporaryObjectCleaner struct {
+	ambientCtx log.AmbientContext
+	execCfg    *ExecutorConfig
+	// liveness tells apart the nodes that could not be reached but may still
+	// be running sessions from the nodes that died.
+	liveness livenessProvider
+}
+
+// NewTemporaryObjectCleaner creates a TemporaryObjectCleaner.
+func NewTemporaryObjectCleaner(
+	ambientCtx log.AmbientContext, execCfg *ExecutorConfig, liveness livenessProvider,
+) *TemporaryObjectCleaner {
+	return &TemporaryObjectCleaner{ambientCtx: ambientCtx, execCfg: execCfg, liveness: liveness}
+}
+
+// Start starts the cleaner loop.
+func (c *TemporaryObjectCleaner) Start(stopper *stop.Stopper) {
+	ctx := c.ambientCtx.AnnotateCtx(context.Background())
+	stopper.RunWorker(ctx, func(ctx context.Context) {
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			timer.Reset(temporaryObjectCleanupInterval.Get(&c.execCfg.Settings.SV))
+			select {
+			case <-stopper.ShouldQuiesce():
+				return
+			case <-timer.C:
+				timer.Read = true
+				if err := c.cleanup(ctx); err != nil {
+					log.Warningf(ctx, "error while cleaning up temporary objects: %s", err)
+				}
+			}
+		}
+	})
+}
+
+// cleanup drops the temporary objects of all the sessions that are not
+// running anymore.
+func (c *TemporaryObjectCleaner) cleanup(ctx context.Context) error {
+	if c.execCfg.StatusServer == nil {
+		return nil
+	}
+
+	// Collect the temporary objects of all sessions.
+	objects := make(map[ClusterWideID][]sqlbase.ID)
+	if err := c.execCfg.DB.Txn(ctx, func(ctx context.Context, txn *client.Txn) error {
+		descs, err := GetAllDescriptors(ctx, txn)
+		if err != nil {
+			return err
+		}
+		for _, desc := range descs {
+			if table, ok := desc.(*sqlbase.TableDescriptor); ok && table.IsTemporary() && !table.Dropped() {
+				sessionID := BytesToClusterWideID(table.TemporarySessionID)
+				objects[sessionID] = append(objects[sessionID], table.ID)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+
+	// The empty username lists the sessions of all users.
+	resp, err := c.execCfg.StatusServer.ListSessions(ctx, &serverpb.ListSessionsRequest{})
+	if err != nil {
+		return err
+	}
+	activeSessions := make(map[ClusterWideID]struct{}, len(resp.Sessions))
+	for i := range resp.Sessions {
+		activeSessions[BytesToClusterWideID(resp.Sessions[i].ID)] = struct{}{}
+	}
+	// The sessions on live nodes that could not be reached may still be
+	// running. The sessions of dead nodes are orphaned.
+	unreachableNodes := make(map[roachpb.NodeID]struct{}, len(resp.Errors))
+	for _, e := range resp.Errors {
+		live, err := c.liveness.IsLive(e.NodeID)
+		if err != nil || live {
+			unreachableNodes[e.NodeID] = struct{}{}
+		}
+	}
+
+	for sessionID, ids := range objects {
+		if _, ok := activeSessions[sessionID]; ok {
+			continue
+		}
+		if _, ok := unreachableNodes[roachpb.NodeID(sessionID.GetNodeID())]; ok {
+			continue
+		}
+		log.Infof(ctx, "dropping %d temporary objects of session %s", len(ids), sessionID)
+		if err := cleanupTemporaryObjects(ctx, c.execCfg, sessionID, ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
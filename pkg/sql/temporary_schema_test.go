@@ -0,0 +1,118 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+	gosql "database/sql"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// testNodeLiveness returns the node liveness of a test server.
+func testNodeLiveness(s serverutils.TestServerInterface) livenessProvider {
+	return s.(interface{ GetNodeLiveness() *storage.NodeLiveness }).GetNodeLiveness()
+}
+
+// TestTemporaryObjectCleanerKeepsActiveSessions verifies that the cleaner
+// does not drop the temporary objects of running sessions, regardless of the
+// user owning them.
+func TestTemporaryObjectCleanerKeepsActiveSessions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, rawSQLDBroot, _ := serverutils.StartServer(t, base.TestServerArgs{Insecure: true})
+	defer s.Stopper().Stop(ctx)
+	sqlDBroot := sqlutils.MakeSQLRunner(rawSQLDBroot)
+
+	sqlDBroot.Exec(t, `CREATE DATABASE test`)
+	sqlDBroot.Exec(t, `CREATE USER nonroot`)
+	sqlDBroot.Exec(t, `GRANT ALL ON DATABASE test TO nonroot`)
+
+	pgURL := url.URL{
+		Scheme:   "postgres",
+		User:     url.User("nonroot"),
+		Host:     s.ServingAddr(),
+		Path:     "test",
+		RawQuery: "sslmode=disable",
+	}
+	rawSQLDBnonroot, err := gosql.Open("postgres", pgURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rawSQLDBnonroot.Close()
+	// Temporary tables are only visible to the session which created them, so
+	// all the statements below must run on the same connection.
+	rawSQLDBnonroot.SetMaxOpenConns(1)
+	sqlDBnonroot := sqlutils.MakeSQLRunner(rawSQLDBnonroot)
+
+	sqlDBnonroot.Exec(t, `CREATE TEMP TABLE tmp (a INT PRIMARY KEY)`)
+	sqlDBnonroot.Exec(t, `INSERT INTO tmp VALUES (1)`)
+
+	execCfg := s.ExecutorConfig().(ExecutorConfig)
+	cleaner := NewTemporaryObjectCleaner(log.AmbientContext{}, &execCfg, testNodeLiveness(s))
+	if err := cleaner.cleanup(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDBnonroot.CheckQueryResults(t, `SELECT a FROM tmp`, [][]string{{"1"}})
+}
+
+// TestTemporaryObjectCleanerDeadNode verifies that the cleaner drops the
+// temporary objects of the sessions of a node that died.
+func TestTemporaryObjectCleanerDeadNode(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	tc := serverutils.StartTestCluster(t, 3, base.TestClusterArgs{
+		ServerArgs: base.TestServerArgs{
+			Knobs: base.TestingKnobs{
+				// The sessions of the stopped node would otherwise drop their
+				// temporary objects themselves.
+				SQLExecutor: &ExecutorTestingKnobs{DisableTempObjectsCleanupOnSessionExit: true},
+			},
+		},
+	})
+	defer tc.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+
+	sqlDB.Exec(t, `CREATE DATABASE test`)
+	sqlutils.MakeSQLRunner(tc.ServerConn(2)).Exec(t, `CREATE TEMP TABLE test.tmp (a INT PRIMARY KEY)`)
+	tc.StopServer(2)
+
+	execCfg := tc.Server(0).ExecutorConfig().(ExecutorConfig)
+	cleaner := NewTemporaryObjectCleaner(log.AmbientContext{}, &execCfg, testNodeLiveness(tc.Server(0)))
+	// The table is only dropped once the stopped node is not live anymore.
+	testutils.SucceedsSoon(t, func() error {
+		if err := cleaner.cleanup(ctx); err != nil {
+			return err
+		}
+		var count int
+		sqlDB.QueryRow(t,
+			`SELECT count(*) FROM crdb_internal.tables WHERE name = 'tmp' AND state = 'PUBLIC'`,
+		).Scan(&count)
+		if count != 0 {
+			return errors.New("temporary table was not dropped")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,506 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// sqlTrigger is a trigger of a table, with its body prepared to run.
+//
+// The body of a trigger is a list of statements that run in the transaction of
+// the triggering statement. The statements of the body of a row-level trigger
+// can reference the columns of the affected row as NEW.<column> and
+// OLD.<column>; these references are replaced by placeholders, and the values
+// of the row are passed as arguments. The new values of a deleted row and the
+// old values of an inserted row are NULL.
+//
+// Row-level triggers are planned by the optimizer as part of the mutation: a
+// BEFORE trigger is a function evaluated on the rows of the input of the
+// mutation (see cat.Trigger.Function), and the input of the mutation provides
+// the arguments of the AFTER triggers fired by each row. Statement-level
+// triggers and AFTER row-level triggers are run by the mutation node (see
+// triggerRun).
+type sqlTrigger struct {
+	desc *sqlbase.TableDescriptor_Trigger
+
+	// stmts are the statements of the body.
+	stmts []triggerStmt
+
+	// args lists the values of the affected row referenced by the body, in the
+	// order in which they are first referenced.
+	args []cat.TriggerArg
+
+	// argTypes are the types of the values listed by args.
+	argTypes []types.T
+
+	// returnsRow is true if the last statement of the body is a SELECT. A
+	// BEFORE row-level trigger skips the affected row if this statement
+	// returns no row.
+	returnsRow bool
+
+	// resultCols are the ordinals of the columns whose new values are replaced
+	// by the row returned by a BEFORE INSERT or UPDATE row-level trigger. The
+	// columns of the returned row are matched with the table columns by name.
+	resultCols []int
+
+	// resultType is the type of the tuple returned by the function of a BEFORE
+	// row-level trigger.
+	resultType *types.T
+
+	// err is the error encountered while preparing the body, if any. It is
+	// reported when the trigger fires.
+	err error
+}
+
+// triggerStmt is a statement of the body of a trigger, in which the references
+// to the affected row are replaced by placeholders.
+type triggerStmt struct {
+	sql string
+
+	// args maps each placeholder of the statement to the index of its value in
+	// sqlTrigger.args.
+	args []int
+}
+
+// makeSQLTriggers prepares the bodies of the triggers of a table, in the order
+// of the table descriptor.
+func makeSQLTriggers(desc *sqlbase.TableDescriptor) []*sqlTrigger {
+	if len(desc.Triggers) == 0 {
+		return nil
+	}
+	triggers := make([]*sqlTrigger, len(desc.Triggers))
+	for i := range desc.Triggers {
+		t := &sqlTrigger{desc: &desc.Triggers[i], resultType: types.EmptyTuple}
+		t.err = t.prepare(desc)
+		triggers[i] = t
+	}
+	return triggers
+}
+
+var _ cat.Trigger = &sqlTrigger{}
+
+// Name is part of the cat.Trigger interface.
+func (t *sqlTrigger) Name() tree.Name {
+	return tree.Name(t.desc.Name)
+}
+
+// IsBefore is part of the cat.Trigger interface.
+func (t *sqlTrigger) IsBefore() bool {
+	return t.desc.ActionTime == sqlbase.TableDescriptor_Trigger_BEFORE
+}
+
+// FiresOn is part of the cat.Trigger interface.
+func (t *sqlTrigger) FiresOn(event tree.TriggerEvent) bool {
+	for _, e := range t.desc.Events {
+		if e == sqlbase.TableDescriptor_Trigger_Event(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForEachRow is part of the cat.Trigger interface.
+func (t *sqlTrigger) ForEachRow() bool {
+	return t.desc.ForEachRow
+}
+
+// When is part of the cat.Trigger interface.
+func (t *sqlTrigger) When() string {
+	return t.desc.When
+}
+
+// ArgCount is part of the cat.Trigger interface.
+func (t *sqlTrigger) ArgCount() int {
+	return len(t.args)
+}
+
+// Arg is part of the cat.Trigger interface.
+func (t *sqlTrigger) Arg(i int) cat.TriggerArg {
+	return t.args[i]
+}
+
+// ResultColumnCount is part of the cat.Trigger interface.
+func (t *sqlTrigger) ResultColumnCount() int {
+	return len(t.resultCols)
+}
+
+// ResultColumnOrdinal is part of the cat.Trigger interface.
+func (t *sqlTrigger) ResultColumnOrdinal(i int) int {
+	return t.resultCols[i]
+}
+
+// prepare parses the body of the trigger and replaces the references to the
+// affected row with placeholders.
+func (t *sqlTrigger) prepare(desc *sqlbase.TableDescriptor) error {
+	stmts, err := parser.Parse(t.desc.Body)
+	if err != nil {
+		return err
+	}
+	if len(stmts) == 0 {
+		return pgerror.Newf(pgerror.CodeInvalidFunctionDefinitionError,
+			"the body of trigger %q is empty", t.desc.Name)
+	}
+
+	t.stmts = make([]triggerStmt, len(stmts))
+	for i := range stmts {
+		ts := &t.stmts[i]
+		stmt, err := tree.SimpleStmtVisit(stmts[i].AST, func(expr tree.Expr) (bool, tree.Expr, error) {
+			name, ok := expr.(*tree.UnresolvedName)
+			if !ok || !isTriggerRowReference(name) {
+				return true, expr, nil
+			}
+			if !t.desc.ForEachRow {
+				return false, nil, pgerror.Newf(pgerror.CodeInvalidObjectDefinitionError,
+					"statement trigger's body cannot reference column values")
+			}
+			ord, err := findTriggerColumn(desc, name)
+			if err != nil {
+				return false, nil, err
+			}
+			argIdx := t.addArg(cat.TriggerArg{Ordinal: ord, New: name.Parts[1] == "new"}, desc.Columns[ord].Type)
+			idx := -1
+			for j := range ts.args {
+				if ts.args[j] == argIdx {
+					idx = j
+				}
+			}
+			if idx == -1 {
+				idx = len(ts.args)
+				ts.args = append(ts.args, argIdx)
+			}
+			return false, &tree.AnnotateTypeExpr{
+				Expr:       &tree.Placeholder{Idx: tree.PlaceholderIdx(idx)},
+				Type:       &t.argTypes[argIdx],
+				SyntaxMode: tree.AnnotateShort,
+			}, nil
+		})
+		if err != nil {
+			return err
+		}
+		ts.sql = tree.AsStringWithFlags(stmt, tree.FmtParsable)
+	}
+
+	sel, ok := stmts[len(stmts)-1].AST.(*tree.Select)
+	if !ok {
+		return nil
+	}
+	t.returnsRow = true
+	if t.IsBefore() && t.desc.ForEachRow &&
+		(t.FiresOn(tree.TriggerInsert) || t.FiresOn(tree.TriggerUpdate)) {
+		return t.prepareResultCols(desc, sel)
+	}
+	return nil
+}
+
+// prepareResultCols determines the columns replaced by the row returned by a
+// BEFORE INSERT or UPDATE row-level trigger. Each column of the row returned by
+// the last statement of the body must be named after a column of the table,
+// either with an alias or by referencing the column:
+//
+//   SELECT now() AS updated_at, lower(NEW.email) AS email
+//   SELECT NEW.total WHERE NEW.total > 0
+//
+func (t *sqlTrigger) prepareResultCols(desc *sqlbase.TableDescriptor, sel *tree.Select) error {
+	stmt := sel.Select
+	for {
+		paren, ok := stmt.(*tree.ParenSelect)
+		if !ok {
+			break
+		}
+		stmt = paren.Select.Select
+	}
+	clause, ok := stmt.(*tree.SelectClause)
+	if !ok {
+		return pgerror.Newf(pgerror.CodeInvalidFunctionDefinitionError,
+			"the row returned by trigger %q must be produced by a simple SELECT", t.desc.Name)
+	}
+
+	contents := make([]types.T, 0, len(clause.Exprs))
+	for _, expr := range clause.Exprs {
+		name := string(expr.As)
+		if name == "" {
+			if n, ok := expr.Expr.(*tree.UnresolvedName); ok && !n.Star {
+				name = n.Parts[0]
+			}
+		}
+		if name == "" {
+			return pgerror.Newf(pgerror.CodeInvalidFunctionDefinitionError,
+				"the columns of the row returned by trigger %q must be named after the columns of the table",
+				t.desc.Name)
+		}
+		ord := -1
+		for i := range desc.Columns {
+			if desc.Columns[i].Name == name {
+				ord = i
+			}
+		}
+		if ord == -1 {
+			return pgerror.Newf(pgerror.CodeUndefinedColumnError,
+				"column %q returned by trigger %q does not exist", name, t.desc.Name)
+		}
+		col := &desc.Columns[ord]
+		if col.IsComputed() {
+			return sqlbase.CannotWriteToComputedColError(name)
+		}
+		for _, prev := range t.resultCols {
+			if prev == ord {
+				return pgerror.Newf(pgerror.CodeSyntaxError,
+					"multiple assignments to the same column %q", name)
+			}
+		}
+		t.resultCols = append(t.resultCols, ord)
+		contents = append(contents, col.Type)
+	}
+	t.resultType = types.MakeTuple(contents)
+	return nil
+}
+
+// addArg returns the index of the given value of the affected row in the
+// arguments of the trigger, adding it if needed.
+func (t *sqlTrigger) addArg(arg cat.TriggerArg, typ types.T) int {
+	for i := range t.args {
+		if t.args[i] == arg {
+			return i
+		}
+	}
+	t.args = append(t.args, arg)
+	t.argTypes = append(t.argTypes, typ)
+	return len(t.args) - 1
+}
+
+// isTriggerRowReference returns true if the name references a column of the
+// affected row of a trigger, as NEW.<column> or OLD.<column>.
+func isTriggerRowReference(name *tree.UnresolvedName) bool {
+	return name.NumParts == 2 && !name.Star && (name.Parts[1] == "new" || name.Parts[1] == "old")
+}
+
+// findTriggerColumn returns the ordinal of the public column referenced as
+// NEW.<column> or OLD.<column>.
+func findTriggerColumn(desc *sqlbase.TableDescriptor, name *tree.UnresolvedName) (int, error) {
+	for i := range desc.Columns {
+		if desc.Columns[i].Name == name.Parts[0] {
+			return i, nil
+		}
+	}
+	return -1, pgerror.Newf(pgerror.CodeUndefinedColumnError,
+		"record %q has no field %q", name.Parts[1], name.Parts[0])
+}
+
+// Function is part of the cat.Trigger interface.
+func (t *sqlTrigger) Function() (*tree.FunctionProperties, *tree.Overload) {
+	props := &tree.FunctionProperties{
+		// The values of the affected row can be NULL.
+		NullableArgs: true,
+		Impure:       true,
+		// The body is run with the internal executor of the session.
+		DistsqlBlacklist: true,
+	}
+	argTypes := make(tree.ArgTypes, len(t.args))
+	for i := range t.args {
+		argTypes[i].Name = fmt.Sprintf("$%d", i+1)
+		argTypes[i].Typ = &t.argTypes[i]
+	}
+	overload := &tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(t.resultType),
+		Fn:         t.evalBefore,
+	}
+	return props, overload
+}
+
+// evalBefore runs the body of a BEFORE row-level trigger and returns the
+// values that replace the new values of the resultCols, or NULL if the
+// affected row must be skipped.
+func (t *sqlTrigger) evalBefore(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+	rows, err := t.run(ctx.Ctx(), ctx.InternalExecutor, ctx.Txn, args)
+	if err != nil {
+		return nil, err
+	}
+	if t.returnsRow && len(rows) == 0 {
+		return tree.DNull, nil
+	}
+	if len(t.resultCols) == 0 {
+		return tree.NewDTupleWithLen(t.resultType, 0), nil
+	}
+	row := rows[0]
+	if len(row) != len(t.resultCols) {
+		return nil, pgerror.AssertionFailedf(
+			"trigger %q returned %d columns, expected %d", t.desc.Name, len(row), len(t.resultCols))
+	}
+	result := tree.NewDTupleWithLen(t.resultType, len(row))
+	for i := range row {
+		typ := &t.resultType.TupleContents()[i]
+		if row[i] != tree.DNull && !row[i].ResolvedType().Identical(typ) {
+			if row[i], err = tree.PerformCast(ctx, row[i], typ); err != nil {
+				return nil, err
+			}
+		}
+		result.D[i] = row[i]
+	}
+	return result, nil
+}
+
+// run runs the statements of the body in the given transaction, with the
+// given values of the affected row, and returns the rows returned by the last
+// statement. Triggers can fire each other recursively: they share the nesting
+// limit of user-defined functions.
+func (t *sqlTrigger) run(
+	ctx context.Context, ie tree.SessionBoundInternalExecutor, txn *client.Txn, args tree.Datums,
+) ([]tree.Datums, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	depth, _ := ctx.Value(udfDepthKey{}).(int)
+	if depth >= maxUDFDepth {
+		return nil, pgerror.Newf(pgerror.CodeStatementTooComplexError,
+			"stack depth limit exceeded")
+	}
+	ctx = context.WithValue(ctx, udfDepthKey{}, depth+1)
+
+	var rows []tree.Datums
+	for i := range t.stmts {
+		s := &t.stmts[i]
+		qargs := make([]interface{}, len(s.args))
+		for j, idx := range s.args {
+			qargs[j] = args[idx]
+		}
+		var err error
+		if rows, err = ie.Query(ctx, "trigger", txn, s.sql, qargs...); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+// runStatementTriggers runs the given statement-level triggers whose WHEN
+// condition is satisfied.
+func runStatementTriggers(params runParams, triggers []*sqlTrigger) error {
+	ie := params.EvalContext().InternalExecutor
+	for _, t := range triggers {
+		if t.desc.When != "" {
+			row, err := ie.QueryRow(params.ctx, "trigger-when", params.p.txn,
+				fmt.Sprintf("SELECT (%s)::BOOL", t.desc.When))
+			if err != nil {
+				return err
+			}
+			if row == nil || row[0] != tree.DBoolTrue {
+				continue
+			}
+		}
+		if _, err := t.run(params.ctx, ie, params.p.txn, nil /* args */); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// triggerRun contains the run-time state of the triggers fired by a mutation
+// node. The BEFORE row-level triggers are evaluated by the input of the node.
+type triggerRun struct {
+	// beforeStmt and afterStmt are the statement-level triggers that fire
+	// before and after the statement.
+	beforeStmt, afterStmt []*sqlTrigger
+
+	// afterRow are the AFTER row-level triggers that can be fired by the rows
+	// of the statement. The last columns of the source rows are the tuples of
+	// arguments passed to each of them, in the same order, or NULL if the
+	// trigger doesn't fire for the row.
+	afterRow []*sqlTrigger
+
+	// pending are the AFTER row-level triggers fired by the rows of the
+	// current batch. They run once the batch is written.
+	pending []pendingTrigger
+}
+
+// pendingTrigger is an AFTER row-level trigger fired by a row, with the values
+// of the row passed to its body.
+type pendingTrigger struct {
+	t    *sqlTrigger
+	args tree.Datums
+}
+
+// init sets up the triggers fired by the given event on a table. afterRow
+// contains the ordinals of the AFTER row-level triggers whose arguments are
+// provided by the source rows.
+func (r *triggerRun) init(table cat.Table, event tree.TriggerEvent, afterRow exec.TriggerOrdinalSet) {
+	for i, t := range table.(*optTable).triggers {
+		switch {
+		case !t.FiresOn(event):
+		case t.desc.ForEachRow:
+			if afterRow.Contains(i) {
+				r.afterRow = append(r.afterRow, t)
+			}
+		case t.IsBefore():
+			r.beforeStmt = append(r.beforeStmt, t)
+		default:
+			r.afterStmt = append(r.afterStmt, t)
+		}
+	}
+}
+
+// hasAfter returns true if triggers fire after the rows are written, in which
+// case the transaction cannot be committed with the last batch.
+func (r *triggerRun) hasAfter() bool {
+	return len(r.afterRow) > 0 || len(r.afterStmt) > 0
+}
+
+// splitRow splits a source row into the values used by the mutation and the
+// arguments of the AFTER row-level triggers.
+func (r *triggerRun) splitRow(row tree.Datums) (tree.Datums, tree.Datums) {
+	n := len(row) - len(r.afterRow)
+	return row[:n], row[n:]
+}
+
+// queueRow queues the AFTER row-level triggers fired by a row, given the
+// arguments split off the source row by splitRow.
+func (r *triggerRun) queueRow(triggerVals tree.Datums) {
+	for i, d := range triggerVals {
+		if d == tree.DNull {
+			continue
+		}
+		r.pending = append(r.pending, pendingTrigger{t: r.afterRow[i], args: d.(*tree.DTuple).D})
+	}
+}
+
+// fireBefore runs the BEFORE statement-level triggers.
+func (r *triggerRun) fireBefore(params runParams) error {
+	return runStatementTriggers(params, r.beforeStmt)
+}
+
+// fireAfter runs the AFTER row-level triggers fired by the rows of the batch
+// that was just written and, after the last batch, the AFTER statement-level
+// triggers.
+func (r *triggerRun) fireAfter(params runParams, lastBatch bool) error {
+	ie := params.EvalContext().InternalExecutor
+	for _, p := range r.pending {
+		if _, err := p.t.run(params.ctx, ie, params.p.txn, p.args); err != nil {
+			return err
+		}
+	}
+	r.pending = r.pending[:0]
+	if lastBatch {
+		return runStatementTriggers(params, r.afterStmt)
+	}
+	return nil
+}
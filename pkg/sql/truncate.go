@@ -210,15 +210,18 @@ func (p *planner) truncateTable(
 	//
 	// TODO(vivek): Fix properly along with #12123.
 	zoneKey := config.MakeZoneKey(uint32(tableDesc.ID))
-	nameKey := sqlbase.MakeNameMetadataKey(tableDesc.ParentID, tableDesc.GetName())
 	b := &client.Batch{}
-	// Use CPut because we want to remove a specific name -> id map.
-	if traceKV {
-		log.VEventf(ctx, 2, "CPut %s -> nil", nameKey)
-	}
-	b.CPut(nameKey, nil, tableDesc.ID)
-	if err := p.txn.Run(ctx, b); err != nil {
-		return err
+	// Temporary tables do not have a name -> id map.
+	if !tableDesc.IsTemporary() {
+		nameKey := sqlbase.MakeNameMetadataKey(tableDesc.ParentID, tableDesc.GetName())
+		// Use CPut because we want to remove a specific name -> id map.
+		if traceKV {
+			log.VEventf(ctx, 2, "CPut %s -> nil", nameKey)
+		}
+		b.CPut(nameKey, nil, tableDesc.ID)
+		if err := p.txn.Run(ctx, b); err != nil {
+			return err
+		}
 	}
 
 	// Drop table.
@@ -268,10 +271,24 @@ func (p *planner) truncateTable(
 	newTableDesc.GCMutations = nil
 	newTableDesc.ModificationTime = p.txn.CommitTimestamp()
 	key := sqlbase.NewTableKey(newTableDesc.ParentID, newTableDesc.Name).Key()
+	if newTableDesc.IsTemporary() {
+		// The new table replaces the old one in the session's temporary
+		// schema.
+		key = nil
+		p.extendedEvalCtx.TemporarySchema.add(newTableDesc.ParentID, newTableDesc.Name, newID)
+	}
+	if err := p.addTypeBackReferences(
+		ctx, newID, columnTypes(newTableDesc.TableDesc()),
+	); err != nil {
+		return err
+	}
 	if err := p.createDescriptorWithID(
 		ctx, key, newID, newTableDesc, p.ExtendedEvalContext().Settings); err != nil {
 		return err
 	}
+	if err := p.removeTypeBackReferences(ctx, tableDesc.TableDesc()); err != nil {
+		return err
+	}
 
 	// Reassign comment.
 	if err := reassignComment(ctx, p, tableDesc, newID); err != nil {
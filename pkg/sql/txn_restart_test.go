@@ -1454,7 +1454,7 @@ func TestRollbackToSavepointFromUnusualStates(t *testing.T) {
 
 	// ROLLBACK TO SAVEPOINT with a wrong name
 	_, err := sqlDB.Exec("ROLLBACK TO SAVEPOINT foo")
-	if !testutils.IsError(err, "SAVEPOINT not supported except for cockroach_restart") {
+	if !testutils.IsError(err, "there is no transaction in progress") {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
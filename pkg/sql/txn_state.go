@@ -110,6 +110,23 @@ type txnState struct {
 	// activeSavepointName stores the name of the active savepoint,
 	// or is empty if no savepoint is active.
 	activeSavepointName tree.Name
+
+	// savepoints is the stack of the regular savepoints (i.e. all but the
+	// restart savepoint) established in the current transaction, innermost
+	// last.
+	savepoints savepointStack
+
+	// ddlCount counts the statements of the current transaction that may have
+	// modified the schema. Rolling back to a savepoint established before such
+	// a statement is not supported.
+	ddlCount int
+
+	// pendingCleanupErr is set when the transaction encountered a non-retriable
+	// error while savepoints were established. The KV txn is then not cleaned
+	// up right away, so that the transaction can be rolled back to one of the
+	// savepoints and resumed. If the SQL txn is finished instead, the KV txn is
+	// cleaned up using this error.
+	pendingCleanupErr error
 }
 
 // txnType represents the type of a SQL transaction.
@@ -223,12 +240,18 @@ func (ts *txnState) resetForNewSQLTxn(
 
 	// Discard the old schemaChangers, if any.
 	ts.schemaChangers = schemaChangerCollection{}
+
+	// Discard the savepoints of the previous transaction, if any.
+	ts.savepoints = nil
+	ts.ddlCount = 0
+	ts.pendingCleanupErr = nil
 }
 
 // finishSQLTxn finalizes a transaction's results and closes the root span for
 // the current SQL txn. This needs to be called before resetForNewSQLTxn() is
 // called for starting another SQL txn.
 func (ts *txnState) finishSQLTxn() {
+	ts.cleanupPendingErr()
 	ts.mon.Stop(ts.Ctx)
 	if ts.cancel != nil {
 		ts.cancel()
@@ -86,6 +86,7 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_time:         Time,
 	oid.T_timestamp:    Timestamp,
 	oid.T_timestamptz:  TimestampTZ,
+	oid.T_timetz:       TimeTZ,
 	oid.T_unknown:      Unknown,
 	oid.T_uuid:         Uuid,
 	oid.T_varbit:       VarBit,
@@ -124,6 +125,7 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_time:         oid.T__time,
 	oid.T_timestamp:    oid.T__timestamp,
 	oid.T_timestamptz:  oid.T__timestamptz,
+	oid.T_timetz:       oid.T__timetz,
 	oid.T_uuid:         oid.T__uuid,
 	oid.T_varbit:       oid.T__varbit,
 	oid.T_varchar:      oid.T__varchar,
@@ -150,9 +152,11 @@ var familyToOid = map[Family]oid.Oid{
 	ArrayFamily:          oid.T_anyarray,
 	INetFamily:           oid.T_inet,
 	TimeFamily:           oid.T_time,
+	TimeTZFamily:         oid.T_timetz,
 	JsonFamily:           oid.T_jsonb,
 	TupleFamily:          oid.T_record,
 	BitFamily:            oid.T_bit,
+	EnumFamily:           oid.T_anyenum,
 	AnyFamily:            oid.T_anyelement,
 }
 
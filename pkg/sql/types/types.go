@@ -81,6 +81,7 @@ import (
 // | UUID              | UUID           | T_uuid        | 0         | 0     |
 // | INET              | INET           | T_inet        | 0         | 0     |
 // | TIME              | TIME           | T_time        | 0         | 0     |
+// | TIMETZ            | TIMETZ         | T_timetz      | 0         | 0     |
 // | JSON              | JSONB          | T_jsonb       | 0         | 0     |
 // | JSONB             | JSONB          | T_jsonb       | 0         | 0     |
 // |                   |                |               |           |       |
@@ -159,6 +160,11 @@ type T struct {
 	// string representation of an unexported field. This is a problem when this
 	// struct is embedded in a larger struct (like a ColumnDescriptor).
 	InternalType InternalType
+
+	// unresolvedName is the name of the user-defined type that this type
+	// refers to, if the reference has not been resolved yet. It is never
+	// serialized. See MakeUnresolvedType.
+	unresolvedName string
 }
 
 // Convenience list of pre-constructed types. Caller code can use any of these
@@ -249,6 +255,15 @@ var (
 	Time = &T{InternalType: InternalType{
 		Family: TimeFamily, Oid: oid.T_time, Locale: &emptyLocale}}
 
+	// TimeTZ is the type of a value specifying hour, minute, second (with no
+	// date component), as well as an associated timezone. By default, it has
+	// microsecond precision. For example:
+	//
+	//   HH:MM:SS.ssssss+-ZZ:ZZ
+	//
+	TimeTZ = &T{InternalType: InternalType{
+		Family: TimeTZFamily, Oid: oid.T_timetz, Locale: &emptyLocale}}
+
 	// Timestamp is the type of a value specifying year, month, day, hour, minute,
 	// and second, but with no associated timezone. By default, it has microsecond
 	// precision. For example:
@@ -311,6 +326,7 @@ var (
 		Uuid,
 		INet,
 		Time,
+		TimeTZ,
 		Jsonb,
 		VarBit,
 	}
@@ -341,6 +357,12 @@ var (
 	AnyCollatedString = &T{InternalType: InternalType{
 		Family: CollatedStringFamily, Oid: oid.T_text, Locale: &emptyLocale}}
 
+	// AnyEnum is a special type used only during static analysis as a wildcard
+	// type that matches any enum type. Execution-time values should never have
+	// this type.
+	AnyEnum = &T{InternalType: InternalType{
+		Family: EnumFamily, Oid: oid.T_anyenum, Locale: &emptyLocale}}
+
 	// EmptyTuple is the tuple type with no fields. Note that this is different
 	// than AnyTuple, which is a wildcard type.
 	EmptyTuple = &T{InternalType: InternalType{
@@ -466,7 +488,7 @@ func MakeScalar(family Family, o oid.Oid, precision, width int32, locale string)
 		panic(pgerror.AssertionFailedf("negative precision is not allowed"))
 	}
 	switch family {
-	case DecimalFamily, TimeFamily, TimestampFamily, TimestampTZFamily:
+	case DecimalFamily, TimeFamily, TimeTZFamily, TimestampFamily, TimestampTZFamily:
 	default:
 		if precision != 0 {
 			panic(pgerror.AssertionFailedf("type %s cannot have precision", family))
@@ -642,6 +664,39 @@ func MakeTime(precision int32) *T {
 		Family: TimeFamily, Oid: oid.T_time, Precision: precision, Locale: &emptyLocale}}
 }
 
+// MakeTimeTZ constructs a new instance of a TIMETZ type (oid = T_timetz) that
+// has at most the given number of fractional second digits.
+func MakeTimeTZ(precision int32) *T {
+	if precision == 0 {
+		return TimeTZ
+	}
+	if precision != 6 {
+		panic(pgerror.AssertionFailedf("precision %d is not currently supported", precision))
+	}
+	return &T{InternalType: InternalType{
+		Family: TimeTZFamily, Oid: oid.T_timetz, Precision: precision, Locale: &emptyLocale}}
+}
+
+// MakeInterval constructs a new instance of an INTERVAL type with the given
+// fields and fractional second precision, like INTERVAL DAY TO SECOND(3).
+func MakeInterval(itm IntervalMetadata) *T {
+	switch itm.DurationType {
+	case IntervalDurationType_UNSET, IntervalDurationType_SECOND:
+	default:
+		if itm.PrecisionIsSet {
+			panic(pgerror.AssertionFailedf("cannot set precision for duration type %s", itm.DurationType))
+		}
+	}
+	if itm.PrecisionIsSet && (itm.Precision < 0 || itm.Precision > 6) {
+		panic(pgerror.AssertionFailedf("precision must be between 0 and 6 inclusive"))
+	}
+	if itm == (IntervalMetadata{}) {
+		return Interval
+	}
+	return &T{InternalType: InternalType{
+		Family: IntervalFamily, Oid: oid.T_interval, Locale: &emptyLocale, IntervalData: &itm}}
+}
+
 // MakeTimestamp constructs a new instance of a TIMESTAMP type that has at most
 // the given number of fractional second digits.
 func MakeTimestamp(precision int32) *T {
@@ -696,6 +751,93 @@ func MakeLabeledTuple(contents []T, labels []string) *T {
 	}}
 }
 
+// MakeEnum constructs a new instance of an EnumFamily type. The type describes
+// the enum whose type descriptor has the given ID and name. The logical and
+// physical representations of its values must be listed in declaration order,
+// and readOnly indicates for each value whether it is not yet writable.
+func MakeEnum(
+	typeID uint32, name string, logical []string, physical [][]byte, readOnly []bool,
+) *T {
+	if len(logical) != len(physical) || len(logical) != len(readOnly) {
+		panic(pgerror.AssertionFailedf(
+			"enum representations must be of same length: %v, %v, %v", logical, physical, readOnly))
+	}
+	return &T{InternalType: InternalType{
+		Family: EnumFamily,
+		Oid:    TypeIDToOID(typeID),
+		Locale: &emptyLocale,
+		EnumData: &EnumMetadata{
+			TypeID:                  typeID,
+			Name:                    name,
+			LogicalRepresentations:  logical,
+			PhysicalRepresentations: physical,
+			IsMemberReadOnly:        readOnly,
+		},
+	}}
+}
+
+// MakeComposite constructs a new instance of a TupleFamily type that describes
+// the composite type whose type descriptor has the given ID and name. The
+// fields of the type are given by their types and labels, in declaration
+// order.
+func MakeComposite(typeID uint32, name string, contents []T, labels []string) *T {
+	if len(contents) != len(labels) {
+		panic(pgerror.AssertionFailedf(
+			"composite contents and labels must be of same length: %v, %v", contents, labels))
+	}
+	return &T{InternalType: InternalType{
+		Family:        TupleFamily,
+		Oid:           TypeIDToOID(typeID),
+		TupleContents: contents,
+		TupleLabels:   labels,
+		Locale:        &emptyLocale,
+		CompositeData: &CompositeMetadata{
+			TypeID: typeID,
+			Name:   name,
+		},
+	}}
+}
+
+// MakeUnresolvedType constructs a reference to the user-defined type with the
+// given name. The parser produces such references for type names that it does
+// not know about; they must be replaced by the referenced type before they
+// are used (see tree.TypeReferenceResolver). Unresolved types are in the
+// UnknownFamily.
+func MakeUnresolvedType(name string) *T {
+	return &T{
+		InternalType:   InternalType{Family: UnknownFamily, Oid: oid.T_unknown, Locale: &emptyLocale},
+		unresolvedName: name,
+	}
+}
+
+// UnresolvedName returns the name of the user-defined type that an unresolved
+// type refers to, or the empty string if the type is not an unresolved
+// reference.
+func (t *T) UnresolvedName() string {
+	return t.unresolvedName
+}
+
+// userDefinedTypeOIDOffset is added to the descriptor ID of a user-defined
+// type to form its OID. It keeps the OIDs of user-defined types clear of the
+// OIDs that Postgres assigns to predefined types.
+const userDefinedTypeOIDOffset = 100000
+
+// TypeIDToOID returns the OID of the user-defined type whose type descriptor
+// has the given ID.
+func TypeIDToOID(id uint32) oid.Oid {
+	return oid.Oid(id + userDefinedTypeOIDOffset)
+}
+
+// UserDefinedTypeOIDToID returns the descriptor ID of the user-defined type
+// with the given OID. It returns false if the OID does not belong to a
+// user-defined type.
+func UserDefinedTypeOIDToID(o oid.Oid) (uint32, bool) {
+	if o <= userDefinedTypeOIDOffset {
+		return 0, false
+	}
+	return uint32(o) - userDefinedTypeOIDOffset, true
+}
+
 // Family specifies a group of types that are compatible with one another. Types
 // in the same family can be compared, assigned, etc., but may differ from one
 // another in width, precision, locale, and other attributes. For example, it is
@@ -763,6 +905,7 @@ func (t *T) Width() int32 {
 //
 //   DECIMAL    : max # digits (must be >= Width/Scale)
 //   TIME       : max # fractional second digits
+//   TIMETZ     : max # fractional second digits
 //   TIMESTAMP  : max # fractional second digits
 //   TIMESTAMPTZ: max # fractional second digits
 //
@@ -797,6 +940,55 @@ func (t *T) TupleLabels() []string {
 	return t.InternalType.TupleLabels
 }
 
+// EnumData returns the metadata of an enum type. This is nil for types that
+// are not in the EnumFamily, and for the AnyEnum wildcard type.
+func (t *T) EnumData() *EnumMetadata {
+	return t.InternalType.EnumData
+}
+
+// IntervalData returns the fields and the fractional second precision of an
+// interval type. This is nil for types that are not in the IntervalFamily, and
+// for INTERVAL types without qualifiers.
+func (t *T) IntervalData() *IntervalMetadata {
+	return t.InternalType.IntervalData
+}
+
+// CompositeData returns the ID and name of a composite type. This is nil for
+// types that are not in the TupleFamily, and for anonymous tuple types.
+func (t *T) CompositeData() *CompositeMetadata {
+	return t.InternalType.CompositeData
+}
+
+// TypeID returns the descriptor ID of an enum or composite type, or zero if
+// the type is not user-defined.
+func (t *T) TypeID() uint32 {
+	if t.InternalType.EnumData != nil {
+		return t.InternalType.EnumData.TypeID
+	}
+	if t.InternalType.CompositeData != nil {
+		return t.InternalType.CompositeData.TypeID
+	}
+	return 0
+}
+
+// EnumLogicalRepresentations returns the labels of the values of an enum type
+// in declaration order. This is nil for non-EnumFamily types.
+func (t *T) EnumLogicalRepresentations() []string {
+	if t.InternalType.EnumData == nil {
+		return nil
+	}
+	return t.InternalType.EnumData.LogicalRepresentations
+}
+
+// EnumPhysicalRepresentations returns the encodings of the values of an enum
+// type in declaration order. This is nil for non-EnumFamily types.
+func (t *T) EnumPhysicalRepresentations() [][]byte {
+	if t.InternalType.EnumData == nil {
+		return nil
+	}
+	return t.InternalType.EnumData.PhysicalRepresentations
+}
+
 // Name returns a single word description of the type that describes it
 // succinctly, but without all the details, such as width, locale, etc. The name
 // is sometimes the same as the name returned by SQLStandardName, but is more
@@ -828,6 +1020,11 @@ func (t *T) Name() string {
 		return "date"
 	case DecimalFamily:
 		return "decimal"
+	case EnumFamily:
+		if t.EnumData() == nil {
+			return "anyenum"
+		}
+		return t.EnumData().Name
 	case FloatFamily:
 		switch t.Width() {
 		case 64:
@@ -873,14 +1070,23 @@ func (t *T) Name() string {
 		panic(pgerror.AssertionFailedf("unexpected OID: %d", t.Oid()))
 	case TimeFamily:
 		return "time"
+	case TimeTZFamily:
+		return "timetz"
 	case TimestampFamily:
 		return "timestamp"
 	case TimestampTZFamily:
 		return "timestamptz"
 	case TupleFamily:
-		// Tuple types are currently anonymous, with no name.
+		// Tuple types are anonymous, with no name, unless they describe a
+		// composite type.
+		if t.CompositeData() != nil {
+			return t.CompositeData().Name
+		}
 		return ""
 	case UnknownFamily:
+		if t.unresolvedName != "" {
+			return t.unresolvedName
+		}
 		return "unknown"
 	case UuidFamily:
 		return "uuid"
@@ -901,6 +1107,12 @@ func (t *T) Name() string {
 //   int4[]       _int4
 //
 func (t *T) PGName() string {
+	if t.Family() == EnumFamily && t.EnumData() != nil {
+		return t.EnumData().Name
+	}
+	if t.Family() == TupleFamily && t.CompositeData() != nil {
+		return t.CompositeData().Name
+	}
 	name, ok := oid.TypeName[t.Oid()]
 	if ok {
 		return strings.ToLower(name)
@@ -945,6 +1157,8 @@ func (t *T) SQLStandardName() string {
 		return "date"
 	case DecimalFamily:
 		return "numeric"
+	case EnumFamily:
+		return t.Name()
 	case FloatFamily:
 		switch t.Width() {
 		case 32:
@@ -1007,11 +1221,16 @@ func (t *T) SQLStandardName() string {
 		panic(pgerror.AssertionFailedf("unexpected OID: %d", t.Oid()))
 	case TimeFamily:
 		return "time without time zone"
+	case TimeTZFamily:
+		return "time with time zone"
 	case TimestampFamily:
 		return "timestamp without time zone"
 	case TimestampTZFamily:
 		return "timestamp with time zone"
 	case TupleFamily:
+		if t.CompositeData() != nil {
+			return t.Name()
+		}
 		return "record"
 	case UnknownFamily:
 		return "unknown"
@@ -1040,6 +1259,12 @@ func (t *T) InformationSchemaName() string {
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
 	switch t.Family() {
+	case EnumFamily, UnknownFamily, TupleFamily:
+		if t.EnumData() != nil || t.CompositeData() != nil || t.unresolvedName != "" {
+			var buf bytes.Buffer
+			lex.EncodeRestrictedSQLIdent(&buf, t.Name(), lex.EncNoFlags)
+			return buf.String()
+		}
 	case BitFamily:
 		o := t.Oid()
 		typName := "BIT"
@@ -1090,10 +1315,14 @@ func (t *T) SQLString() string {
 		}
 		// This is the timestamp with the default precision value
 		return strings.ToUpper(t.Name())
-	case TimeFamily:
+	case TimeFamily, TimeTZFamily:
 		if t.Precision() > 0 {
 			return fmt.Sprintf("%s(%d)", strings.ToUpper(t.Name()), t.Precision())
 		}
+	case IntervalFamily:
+		if itm := t.IntervalData(); itm != nil {
+			return itm.sqlString()
+		}
 	case OidFamily:
 		if name, ok := oid.TypeName[t.Oid()]; ok {
 			return name
@@ -1145,6 +1374,13 @@ func (t *T) Equivalent(other *T) bool {
 		if IsWildcardTupleType(t) || IsWildcardTupleType(other) {
 			return true
 		}
+		// Two composite types are only equivalent if they are the same type,
+		// but anonymous tuples are equivalent to composite types with the same
+		// field types.
+		if t.CompositeData() != nil && other.CompositeData() != nil &&
+			t.TypeID() != other.TypeID() {
+			return false
+		}
 		if len(t.TupleContents()) != len(other.TupleContents()) {
 			return false
 		}
@@ -1158,6 +1394,15 @@ func (t *T) Equivalent(other *T) bool {
 		if !t.ArrayContents().Equivalent(other.ArrayContents()) {
 			return false
 		}
+
+	case EnumFamily:
+		// The AnyEnum wildcard type is equivalent to any other enum type.
+		if t.EnumData() == nil || other.EnumData() == nil {
+			return true
+		}
+		if t.TypeID() != other.TypeID() {
+			return false
+		}
 	}
 
 	return true
@@ -1245,9 +1490,73 @@ func (t *InternalType) Identical(other *InternalType) bool {
 			return false
 		}
 	}
+	if t.EnumData != nil && other.EnumData != nil {
+		if !t.EnumData.identical(other.EnumData) {
+			return false
+		}
+	} else if t.EnumData != nil {
+		return false
+	} else if other.EnumData != nil {
+		return false
+	}
+	if t.IntervalData != nil && other.IntervalData != nil {
+		if *t.IntervalData != *other.IntervalData {
+			return false
+		}
+	} else if t.IntervalData != nil {
+		return false
+	} else if other.IntervalData != nil {
+		return false
+	}
+	if t.CompositeData != nil && other.CompositeData != nil {
+		if t.CompositeData.TypeID != other.CompositeData.TypeID ||
+			t.CompositeData.Name != other.CompositeData.Name {
+			return false
+		}
+	} else if t.CompositeData != nil {
+		return false
+	} else if other.CompositeData != nil {
+		return false
+	}
 	return t.Oid == other.Oid
 }
 
+// identical returns true if both enum metadata describe the same type with the
+// same values.
+func (m *EnumMetadata) identical(other *EnumMetadata) bool {
+	if m.TypeID != other.TypeID || m.Name != other.Name {
+		return false
+	}
+	if len(m.LogicalRepresentations) != len(other.LogicalRepresentations) {
+		return false
+	}
+	for i := range m.LogicalRepresentations {
+		if m.LogicalRepresentations[i] != other.LogicalRepresentations[i] ||
+			!bytes.Equal(m.PhysicalRepresentations[i], other.PhysicalRepresentations[i]) ||
+			m.IsMemberReadOnly[i] != other.IsMemberReadOnly[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sqlString formats an INTERVAL type with the given qualifiers, like
+// INTERVAL DAY TO SECOND(3).
+func (m *IntervalMetadata) sqlString() string {
+	var buf bytes.Buffer
+	buf.WriteString("INTERVAL")
+	if m.FromDurationType != IntervalDurationType_UNSET {
+		fmt.Fprintf(&buf, " %s TO", m.FromDurationType)
+	}
+	if m.DurationType != IntervalDurationType_UNSET {
+		fmt.Fprintf(&buf, " %s", m.DurationType)
+	}
+	if m.PrecisionIsSet {
+		fmt.Fprintf(&buf, "(%d)", m.Precision)
+	}
+	return buf.String()
+}
+
 // Unmarshal deserializes a type from the given byte representation using gogo
 // protobuf serialization rules. It is backwards-compatible with formats used
 // by older versions of CRDB.
@@ -1384,11 +1693,6 @@ func (t *T) upgradeType() error {
 			t.InternalType.Oid = calcArrayOid(t.ArrayContents())
 		}
 
-		// Marshaling/unmarshaling nested arrays is not yet supported.
-		if t.ArrayContents().Family() == ArrayFamily {
-			return pgerror.AssertionFailedf("nested array should never be unmarshaled")
-		}
-
 		// Zero out fields that may have been used to store information about
 		// the array element type, or which are no longer in use.
 		t.InternalType.Width = 0
@@ -1472,6 +1776,12 @@ func (t *T) MarshalTo(data []byte) (int, error) {
 // CRDB. This is necessary to preserve backwards-compatibility in mixed-version
 // scenarios, such as during upgrade.
 func (t *T) downgradeType() error {
+	// References to user-defined types must be resolved before they are
+	// marshaled, since the name of the referenced type is not serialized.
+	if t.unresolvedName != "" {
+		return pgerror.AssertionFailedf("unresolved type %s should never be marshaled", t.unresolvedName)
+	}
+
 	// Set Family and VisibleType for 19.1 backwards-compatibility.
 	switch t.Family() {
 	case BitFamily:
@@ -1502,14 +1812,18 @@ func (t *T) downgradeType() error {
 		}
 
 	case ArrayFamily:
-		// Marshaling/unmarshaling nested arrays is not yet supported.
-		if t.ArrayContents().Family() == ArrayFamily {
-			return pgerror.AssertionFailedf("nested array should never be marshaled")
-		}
-
 		// Downgrade to array representation used before 19.2, in which the array
-		// type fields specified the width, locale, etc. of the element type.
-		temp := *t.InternalType.ArrayContents
+		// type fields specified the width, locale, etc. of the element type. The
+		// element type of a multi-dimensional array is its innermost element
+		// type, and ArrayDimensions contains an unbounded dimension for each
+		// level of nesting.
+		elemTyp := t.ArrayContents()
+		ndims := 1
+		for elemTyp.Family() == ArrayFamily {
+			elemTyp = elemTyp.ArrayContents()
+			ndims++
+		}
+		temp := *elemTyp
 		if err := temp.downgradeType(); err != nil {
 			return err
 		}
@@ -1517,7 +1831,13 @@ func (t *T) downgradeType() error {
 		t.InternalType.Precision = temp.InternalType.Precision
 		t.InternalType.Locale = temp.InternalType.Locale
 		t.InternalType.VisibleType = temp.InternalType.VisibleType
-		t.InternalType.ArrayElemType = &t.InternalType.ArrayContents.InternalType.Family
+		t.InternalType.ArrayElemType = &elemTyp.InternalType.Family
+		if ndims > 1 {
+			t.InternalType.ArrayDimensions = make([]int32, ndims)
+			for i := range t.InternalType.ArrayDimensions {
+				t.InternalType.ArrayDimensions[i] = -1
+			}
+		}
 
 		switch t.Oid() {
 		case oid.T_int2vector:
@@ -1558,6 +1878,9 @@ func (t *T) String() string {
 		return t.ArrayContents().String() + "[]"
 
 	case TupleFamily:
+		if t.CompositeData() != nil {
+			return t.Name()
+		}
 		var buf bytes.Buffer
 		buf.WriteString("tuple")
 		if len(t.TupleContents()) != 0 && !IsWildcardTupleType(t) {
@@ -1585,7 +1908,8 @@ func (t *T) DebugString() string {
 	return t.InternalType.String()
 }
 
-// IsAmbiguous returns true if this type is in UnknownFamily or AnyFamily.
+// IsAmbiguous returns true if this type is in UnknownFamily or AnyFamily, or is
+// the AnyEnum wildcard type.
 // Instances of ambiguous types can be NULL or be in one of several different
 // type families. This is important for parameterized types to determine whether
 // they are fully concrete or not.
@@ -1596,6 +1920,9 @@ func (t *T) IsAmbiguous() bool {
 	case CollatedStringFamily:
 		return t.Locale() == ""
 	case TupleFamily:
+		if t.CompositeData() != nil {
+			return false
+		}
 		if len(t.TupleContents()) == 0 {
 			return true
 		}
@@ -1607,6 +1934,8 @@ func (t *T) IsAmbiguous() bool {
 		return false
 	case ArrayFamily:
 		return t.ArrayContents().IsAmbiguous()
+	case EnumFamily:
+		return t.EnumData() == nil
 	}
 	return false
 }
@@ -1629,6 +1958,8 @@ func IsValidArrayElementType(t *T) (valid bool, issueNum int) {
 	switch t.Family() {
 	case JsonFamily:
 		return false, 23468
+	case EnumFamily:
+		return false, 24873
 	default:
 		return true, 0
 	}
@@ -1651,6 +1982,8 @@ func IsDateTimeType(t *T) bool {
 		return true
 	case TimeFamily:
 		return true
+	case TimeTZFamily:
+		return true
 	case TimestampFamily:
 		return true
 	case TimestampTZFamily:
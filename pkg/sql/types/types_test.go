@@ -132,6 +132,17 @@ func TestTypes(t *testing.T) {
 			Family: DecimalFamily, Oid: oid.T_numeric, Precision: 10, Width: 3, Locale: &emptyLocale}}},
 		{MakeDecimal(10, 3), MakeScalar(DecimalFamily, oid.T_numeric, 10, 3, emptyLocale)},
 
+		// ENUM
+		{MakeEnum(52, "mood", []string{"sad", "happy"}, [][]byte{{0x40}, {0x80}}, []bool{false, true}),
+			&T{InternalType: InternalType{
+				Family: EnumFamily, Oid: 100052, Locale: &emptyLocale, EnumData: &EnumMetadata{
+					TypeID:                  52,
+					Name:                    "mood",
+					LogicalRepresentations:  []string{"sad", "happy"},
+					PhysicalRepresentations: [][]byte{{0x40}, {0x80}},
+					IsMemberReadOnly:        []bool{false, true},
+				}}}},
+
 		// FLOAT
 		{Float, &T{InternalType: InternalType{
 			Family: FloatFamily, Width: 64, Oid: oid.T_float8, Locale: &emptyLocale}}},
@@ -157,6 +168,16 @@ func TestTypes(t *testing.T) {
 		{Interval, &T{InternalType: InternalType{
 			Family: IntervalFamily, Oid: oid.T_interval, Locale: &emptyLocale}}},
 		{Interval, MakeScalar(IntervalFamily, oid.T_interval, 0, 0, emptyLocale)},
+		{MakeInterval(IntervalMetadata{}), Interval},
+		{MakeInterval(IntervalMetadata{
+			DurationType: IntervalDurationType_SECOND, FromDurationType: IntervalDurationType_DAY,
+			Precision: 3, PrecisionIsSet: true,
+		}), &T{InternalType: InternalType{
+			Family: IntervalFamily, Oid: oid.T_interval, Locale: &emptyLocale,
+			IntervalData: &IntervalMetadata{
+				DurationType: IntervalDurationType_SECOND, FromDurationType: IntervalDurationType_DAY,
+				Precision: 3, PrecisionIsSet: true,
+			}}}},
 
 		// JSON
 		{Jsonb, &T{InternalType: InternalType{
@@ -220,6 +241,14 @@ func TestTypes(t *testing.T) {
 			Family: TimeFamily, Oid: oid.T_time, Precision: 6, Locale: &emptyLocale}}},
 		{MakeTime(6), MakeScalar(TimeFamily, oid.T_time, 6, 0, emptyLocale)},
 
+		// TIMETZ
+		{MakeTimeTZ(0), TimeTZ},
+		{MakeTimeTZ(0), &T{InternalType: InternalType{
+			Family: TimeTZFamily, Oid: oid.T_timetz, Locale: &emptyLocale}}},
+		{MakeTimeTZ(6), &T{InternalType: InternalType{
+			Family: TimeTZFamily, Oid: oid.T_timetz, Precision: 6, Locale: &emptyLocale}}},
+		{MakeTimeTZ(6), MakeScalar(TimeTZFamily, oid.T_timetz, 6, 0, emptyLocale)},
+
 		// TIMESTAMP
 		{MakeTimestamp(0), &T{InternalType: InternalType{
 			Family: TimestampFamily, Precision: 0, Oid: oid.T_timestamp, Locale: &emptyLocale}}},
@@ -245,6 +274,10 @@ func TestTypes(t *testing.T) {
 		{MakeLabeledTuple([]T{*Int, *String}, []string{"foo", "bar"}), &T{InternalType: InternalType{
 			Family: TupleFamily, Oid: oid.T_record, TupleContents: []T{*Int, *String},
 			TupleLabels: []string{"foo", "bar"}, Locale: &emptyLocale}}},
+		{MakeComposite(52, "pair", []T{*Int, *String}, []string{"foo", "bar"}), &T{InternalType: InternalType{
+			Family: TupleFamily, Oid: 100052, TupleContents: []T{*Int, *String},
+			TupleLabels: []string{"foo", "bar"}, Locale: &emptyLocale,
+			CompositeData: &CompositeMetadata{TypeID: 52, Name: "pair"}}}},
 
 		// UNKNOWN
 		{Unknown, &T{InternalType: InternalType{
@@ -266,13 +299,7 @@ func TestTypes(t *testing.T) {
 			t.Errorf("expected <%v>, got <%v>", tc.expected.DebugString(), tc.actual.DebugString())
 		}
 
-		// Roundtrip type by marshaling, then unmarshaling. Only do this for non-
-		// nested array types (since we don't yet support marshaling/ummarshaling
-		// nested arrays).
-		if tc.actual.Family() == ArrayFamily && tc.actual.ArrayContents().Family() == ArrayFamily {
-			continue
-		}
-
+		// Roundtrip type by marshaling, then unmarshaling.
 		data, err := protoutil.Marshal(tc.actual)
 		if err != nil {
 			t.Errorf("error during marshal of type <%v>: %v", tc.actual.DebugString(), err)
@@ -338,6 +365,14 @@ func TestEquivalent(t *testing.T) {
 		{Any, MakeDecimal(10, 0), true},
 		{Decimal, Float, false},
 
+		// ENUM
+		{MakeEnum(52, "mood", []string{"sad"}, [][]byte{{0x80}}, []bool{false}),
+			MakeEnum(52, "mood", []string{"sad", "ok"}, [][]byte{{0x80}, {0xc0}}, []bool{false, true}), true},
+		{MakeEnum(52, "mood", nil, nil, nil), AnyEnum, true},
+		{AnyEnum, MakeEnum(53, "color", nil, nil, nil), true},
+		{MakeEnum(52, "mood", nil, nil, nil), MakeEnum(53, "color", nil, nil, nil), false},
+		{MakeEnum(52, "mood", nil, nil, nil), String, false},
+
 		// INT
 		{Int2, Int4, true},
 		{Int4, Int, true},
@@ -354,6 +389,10 @@ func TestEquivalent(t *testing.T) {
 		{MakeLabeledTuple([]T{*Int, *String}, []string{"label1", "label2"}),
 			MakeLabeledTuple([]T{*Int4, *VarChar}, []string{"label2", "label1"}), true},
 		{MakeTuple([]T{*String, *Int}), MakeTuple([]T{*Int, *String}), false},
+		{MakeComposite(52, "pair", []T{*Int, *String}, []string{"a", "b"}),
+			MakeTuple([]T{*Int4, *VarChar}), true},
+		{MakeComposite(52, "pair", []T{*Int, *String}, []string{"a", "b"}),
+			MakeComposite(53, "other", []T{*Int, *String}, []string{"a", "b"}), false},
 
 		// UNKNOWN
 		{Unknown, &T{InternalType: InternalType{
@@ -401,6 +440,8 @@ func TestMarshalCompat(t *testing.T) {
 			ArrayElemType: &oidElemType, ArrayContents: Oid}},
 		{IntArray, InternalType{Family: ArrayFamily, Oid: oid.T__int8, Width: 64,
 			ArrayElemType: &intElemType, ArrayContents: Int}},
+		{MakeArray(IntArray), InternalType{Family: ArrayFamily, Oid: oid.T__int8, Width: 64,
+			ArrayDimensions: []int32{-1, -1}, ArrayElemType: &intElemType, ArrayContents: IntArray}},
 		{MakeArray(MakeVarChar(10)), InternalType{Family: ArrayFamily, Oid: oid.T__varchar, Width: 10, VisibleType: visibleVARCHAR,
 			ArrayElemType: &strElemType, ArrayContents: MakeVarChar(10)}},
 		{MakeArray(MakeCollatedString(String, enLocale)), InternalType{Family: ArrayFamily, Oid: oid.T__text, Locale: &enLocale,
@@ -0,0 +1,316 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/internal/client"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// ResolveFunction implements the tree.FunctionReferenceResolver interface.
+// User-defined functions live in the public schema of a database. Their names
+// can be qualified with the schema, the database, or both.
+func (p *planner) ResolveFunction(name *tree.UnresolvedName) (*tree.FunctionDefinition, error) {
+	if name.Star {
+		return nil, nil
+	}
+	dbName := p.CurrentDatabase()
+	switch name.NumParts {
+	case 1:
+	case 2:
+		if name.Parts[1] != tree.PublicSchema {
+			dbName = name.Parts[1]
+		}
+	case 3:
+		if name.Parts[1] != tree.PublicSchema {
+			return nil, nil
+		}
+		dbName = name.Parts[2]
+	default:
+		return nil, nil
+	}
+
+	ctx := p.EvalContext().Context
+	dbDesc, err := p.LogicalSchemaAccessor().GetDatabaseDesc(
+		ctx, p.txn, dbName, p.CommonLookupFlags(false /*required*/))
+	if err != nil || dbDesc == nil {
+		return nil, err
+	}
+	fnDesc, err := lookupFunctionDesc(ctx, p.txn, dbDesc.ID, name.Parts[0])
+	if err != nil || fnDesc == nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	// Register the dependency to the planner, if requested.
+	if p.curPlan.fnDeps != nil {
+		p.curPlan.fnDeps[fnDesc.ID] = fnDesc
+	}
+	return makeFunctionDefinition(fnDesc)
+}
+
+// lookupFunctionDesc looks up the function with the given name in the given
+// database. It returns nil if the name does not exist or does not refer to a
+// function.
+func lookupFunctionDesc(
+	ctx context.Context, txn *client.Txn, dbID sqlbase.ID, name string,
+) (*sqlbase.FunctionDescriptor, error) {
+	id, err := getDescriptorID(ctx, txn, sqlbase.NewTableKey(dbID, name))
+	if err != nil || id == sqlbase.InvalidID {
+		return nil, err
+	}
+	fnDesc, err := sqlbase.GetFunctionDescFromID(ctx, txn, id)
+	if err == sqlbase.ErrDescriptorNotFound {
+		return nil, nil
+	}
+	return fnDesc, err
+}
+
+// makeFunctionDefinition returns the definition of the given user-defined
+// function. The properties of a definition are shared by all its overloads:
+// the function is impure if one of its overloads is volatile.
+func makeFunctionDefinition(desc *sqlbase.FunctionDescriptor) (*tree.FunctionDefinition, error) {
+	props := &tree.FunctionProperties{
+		// Strict overloads handle NULL arguments themselves, see
+		// evalSQLFunctionBody.
+		NullableArgs: true,
+		// The body is evaluated with the internal executor of the session.
+		DistsqlBlacklist: true,
+	}
+	overloads := make([]tree.Overload, len(desc.Overloads))
+	for i := range desc.Overloads {
+		ol := &desc.Overloads[i]
+		if ol.Volatility == sqlbase.FunctionDescriptor_VOLATILE {
+			props.Impure = true
+			props.NeedsRepeatedEvaluation = true
+		}
+		if ol.ReturnsSet {
+			props.Class = tree.GeneratorClass
+			props.ReturnLabels = []string{desc.Name}
+		}
+		var err error
+		if overloads[i], err = makeFunctionOverload(desc.Name, ol); err != nil {
+			return nil, err
+		}
+	}
+	return tree.NewUserDefinedFunctionDefinition(desc.Name, props, overloads), nil
+}
+
+// makeFunctionOverload returns the overload that evaluates the body of the
+// given overload of a user-defined function.
+func makeFunctionOverload(
+	name string, ol *sqlbase.FunctionDescriptor_Overload,
+) (tree.Overload, error) {
+	stmt, err := parseFunctionBody(ol.Body, ol.Params)
+	if err != nil {
+		return tree.Overload{}, err
+	}
+	argTypes := make(tree.ArgTypes, len(ol.Params))
+	for i := range ol.Params {
+		argTypes[i].Name = ol.Params[i].Name
+		if argTypes[i].Name == "" {
+			argTypes[i].Name = fmt.Sprintf("$%d", i+1)
+		}
+		typ := ol.Params[i].Type
+		argTypes[i].Typ = &typ
+	}
+	retType := ol.ReturnType
+	body := &tree.SQLFunctionBody{Stmt: stmt, Strict: ol.Strict}
+	e := &sqlFunctionEvaluator{
+		name:    name,
+		body:    body,
+		stmt:    tree.AsStringWithFlags(stmt, tree.FmtParsable),
+		retType: &retType,
+	}
+
+	overload := tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(&retType),
+		SQLBody:    body,
+	}
+	if ol.ReturnsSet {
+		overload.Generator = func(ctx *tree.EvalContext, args tree.Datums) (tree.ValueGenerator, error) {
+			return &sqlFunctionGenerator{e: e, evalCtx: ctx, args: args}, nil
+		}
+		overload.Fn = func(*tree.EvalContext, tree.Datums) (tree.Datum, error) {
+			return nil, pgerror.AssertionFailedf("generator functions cannot be evaluated as scalars")
+		}
+	} else {
+		overload.Fn = func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+			rows, err := e.eval(ctx, args)
+			if err != nil || len(rows) == 0 {
+				return tree.DNull, err
+			}
+			return rows[0][0], nil
+		}
+	}
+	return overload, nil
+}
+
+// parseFunctionBody parses the body of an overload of a user-defined function
+// and replaces the references to its parameters, by name or by position, with
+// placeholders annotated with the type of the parameter. A parameter takes
+// precedence over a column with the same name. References in the FROM clause
+// are not replaced.
+func parseFunctionBody(
+	body string, params []sqlbase.FunctionDescriptor_Param,
+) (tree.Statement, error) {
+	stmts, err := parser.Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(stmts) != 1 {
+		return nil, pgerror.Unimplemented("udf multiple statements",
+			"the body of a function must consist of a single statement")
+	}
+	sel, ok := stmts[0].AST.(*tree.Select)
+	if !ok {
+		return nil, pgerror.Newf(pgerror.CodeInvalidFunctionDefinitionError,
+			"the body of a function must be a SELECT statement, not %s", stmts[0].AST.StatementTag())
+	}
+
+	param := func(i int) tree.Expr {
+		typ := params[i].Type
+		return &tree.AnnotateTypeExpr{
+			Expr:       &tree.Placeholder{Idx: tree.PlaceholderIdx(i)},
+			Type:       &typ,
+			SyntaxMode: tree.AnnotateShort,
+		}
+	}
+	return tree.SimpleStmtVisit(sel, func(expr tree.Expr) (bool, tree.Expr, error) {
+		switch t := expr.(type) {
+		case *tree.UnresolvedName:
+			if t.NumParts == 1 && !t.Star {
+				for i := range params {
+					if params[i].Name != "" && params[i].Name == t.Parts[0] {
+						return false, param(i), nil
+					}
+				}
+			}
+		case *tree.Placeholder:
+			if int(t.Idx) >= len(params) {
+				return false, nil, pgerror.Newf(pgerror.CodeUndefinedParameterError,
+					"there is no parameter $%d", t.Idx+1)
+			}
+			return false, param(int(t.Idx)), nil
+		}
+		return true, expr, nil
+	})
+}
+
+// udfDepthKey is the key of the context value that records how deeply the
+// evaluations of user-defined functions and the bodies of triggers are nested.
+type udfDepthKey struct{}
+
+// maxUDFDepth limits the nesting of the evaluations of user-defined functions
+// and the bodies of triggers, which can call or fire each other recursively.
+const maxUDFDepth = 32
+
+// sqlFunctionEvaluator evaluates the body of an overload of a user-defined
+// function.
+type sqlFunctionEvaluator struct {
+	name    string
+	body    *tree.SQLFunctionBody
+	stmt    string
+	retType *types.T
+}
+
+// eval runs the body with the given arguments in the transaction of the
+// caller, and returns the resulting rows. The single column of the rows is
+// converted to the return type of the function.
+func (e *sqlFunctionEvaluator) eval(ctx *tree.EvalContext, args tree.Datums) ([]tree.Datums, error) {
+	if e.body.Strict {
+		for _, arg := range args {
+			if arg == tree.DNull {
+				return nil, nil
+			}
+		}
+	}
+	depth, _ := ctx.Ctx().Value(udfDepthKey{}).(int)
+	if depth >= maxUDFDepth {
+		return nil, pgerror.Newf(pgerror.CodeStatementTooComplexError,
+			"stack depth limit exceeded")
+	}
+	qargs := make([]interface{}, len(args))
+	for i := range args {
+		qargs[i] = args[i]
+	}
+	rows, err := ctx.InternalExecutor.Query(
+		context.WithValue(ctx.Ctx(), udfDepthKey{}, depth+1), "udf", ctx.Txn, e.stmt, qargs...)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if len(row) != 1 {
+			return nil, pgerror.Newf(pgerror.CodeInvalidFunctionDefinitionError,
+				"return type mismatch in function %s: the body must return a single column, not %d",
+				e.name, len(row))
+		}
+		if row[0] == tree.DNull || row[0].ResolvedType().Identical(e.retType) {
+			continue
+		}
+		if row[0], err = tree.PerformCast(ctx, row[0], e.retType); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+// sqlFunctionGenerator is the value generator of the set-returning
+// user-defined functions. It returns the rows of the body.
+type sqlFunctionGenerator struct {
+	e       *sqlFunctionEvaluator
+	evalCtx *tree.EvalContext
+	args    tree.Datums
+
+	rows []tree.Datums
+	// idx is the index of the current row, or -1 before the first one.
+	idx int
+}
+
+var _ tree.ValueGenerator = &sqlFunctionGenerator{}
+
+// ResolvedType is part of the tree.ValueGenerator interface.
+func (g *sqlFunctionGenerator) ResolvedType() *types.T { return g.e.retType }
+
+// Start is part of the tree.ValueGenerator interface.
+func (g *sqlFunctionGenerator) Start() error {
+	rows, err := g.e.eval(g.evalCtx, g.args)
+	if err != nil {
+		return err
+	}
+	g.rows = rows
+	g.idx = -1
+	return nil
+}
+
+// Next is part of the tree.ValueGenerator interface.
+func (g *sqlFunctionGenerator) Next() (bool, error) {
+	g.idx++
+	return g.idx < len(g.rows), nil
+}
+
+// Values is part of the tree.ValueGenerator interface.
+func (g *sqlFunctionGenerator) Values() tree.Datums { return g.rows[g.idx] }
+
+// Close is part of the tree.ValueGenerator interface.
+func (g *sqlFunctionGenerator) Close() {}
@@ -60,6 +60,16 @@ func (p *planner) Update(
 		return nil, pgerror.DangerousStatementf("UPDATE without WHERE clause")
 	}
 
+	// UPDATE ... FROM is only supported by the optimizer.
+	if len(n.From) > 0 {
+		return nil, pgerror.UnimplementedWithIssue(7841,
+			"UPDATE with a FROM clause requires the cost-based optimizer")
+	}
+	if assignsFields(n.Exprs) {
+		return nil, pgerror.UnimplementedWithIssue(27792,
+			"UPDATE of a field of a column requires the cost-based optimizer")
+	}
+
 	// CTE analysis.
 	resetter, err := p.initWith(ctx, n.With)
 	if err != nil {
@@ -93,6 +103,11 @@ func (p *planner) Update(
 	if err != nil {
 		return nil, err
 	}
+	// Triggers are only fired by the mutations planned by the optimizer.
+	if len(desc.Triggers) > 0 {
+		return nil, pgerror.UnimplementedWithIssue(28296,
+			"UPDATE on a table with triggers requires the cost-based optimizer")
+	}
 	if err := p.CheckPrivilege(ctx, desc, privilege.UPDATE); err != nil {
 		return nil, err
 	}
@@ -116,6 +131,10 @@ func (p *planner) Update(
 	if err != nil {
 		return nil, err
 	}
+	deferredChecks, err := p.registerDeferrableConstraints(desc, fkTables, row.CheckUpdates)
+	if err != nil {
+		return nil, err
+	}
 
 	// Extract all the LHS column names, and verify that the arity of
 	// the LHS and RHS match when assigning tuples.
@@ -143,6 +162,9 @@ func (p *planner) Update(
 	if err := checkHasNoComputedCols(updateCols); err != nil {
 		return nil, err
 	}
+	if err := checkIdentityColsForUpdate(updateCols, n.Exprs); err != nil {
+		return nil, err
+	}
 
 	// Extract the pre-analyzed, pre-typed default expressions for all
 	// the updated columns. There are as many defaultExprs as there are
@@ -224,6 +246,7 @@ func (p *planner) Update(
 	if err != nil {
 		return nil, err
 	}
+	ru.SetDeferredChecks(deferredChecks)
 
 	tracing.AnnotateTrace()
 
@@ -430,6 +453,10 @@ type updateRun struct {
 	// traceKV caches the current KV tracing flag.
 	traceKV bool
 
+	// triggers contains the run-time state of the triggers fired by the
+	// update.
+	triggers triggerRun
+
 	// computedCols are the columns that need to be (re-)computed as
 	// the result of updating some of the columns in updateCols.
 	computedCols []sqlbase.ColumnDescriptor
@@ -500,7 +527,11 @@ func (u *updateNode) startExec(params runParams) error {
 			params.EvalContext().Mon.MakeBoundAccount(),
 			sqlbase.ColTypeInfoFromResCols(u.columns), 0)
 	}
-	return u.run.tu.init(params.p.txn, params.EvalContext())
+	if err := u.run.tu.init(params.p.txn, params.EvalContext()); err != nil {
+		return err
+	}
+
+	return u.run.triggers.fireBefore(params)
 }
 
 // Next is required because batchedPlanNode inherits from planNode, but
@@ -578,6 +609,11 @@ func (u *updateNode) BatchedNext(params runParams) (bool, error) {
 		u.run.done = true
 	}
 
+	// Run the AFTER triggers now that the rows of the batch are written.
+	if err := u.run.triggers.fireAfter(params, lastBatch); err != nil {
+		return false, err
+	}
+
 	// Possibly initiate a run of CREATE STATISTICS.
 	params.ExecCfg().StatsRefresher.NotifyMutation(
 		u.run.tu.tableDesc().ID,
@@ -594,7 +630,9 @@ func (u *updateNode) processSourceRow(params runParams, sourceVals tree.Datums)
 	// table descriptor. (One per column in u.tw.ru.FetchCols)
 	//
 	// And then after that, all the extra expressions potentially added via
-	// a renderNode for the RHS of the assignments.
+	// a renderNode for the RHS of the assignments, followed by the arguments
+	// of the AFTER row-level triggers, which are split off first.
+	sourceVals, triggerVals := u.run.triggers.splitRow(sourceVals)
 
 	// oldValues is the prefix of sourceVals that corresponds to real
 	// stored columns in the table, that is, excluding the RHS assignment
@@ -691,6 +729,7 @@ func (u *updateNode) processSourceRow(params runParams, sourceVals tree.Datums)
 	if err != nil {
 		return err
 	}
+	u.run.triggers.queueRow(triggerVals)
 
 	// If result rows need to be accumulated, do it.
 	if u.run.rows != nil {
@@ -729,6 +768,11 @@ func (u *updateNode) Close(ctx context.Context) {
 
 // enableAutoCommit implements the autoCommitNode interface.
 func (u *updateNode) enableAutoCommit() {
+	// The AFTER triggers run once the rows are written, so the transaction
+	// cannot be committed with the last batch.
+	if u.run.triggers.hasAfter() {
+		return
+	}
 	u.run.tu.enableAutoCommit()
 }
 
@@ -908,6 +952,35 @@ func checkHasNoComputedCols(cols []sqlbase.ColumnDescriptor) error {
 	return nil
 }
 
+// checkIdentityColsForUpdate returns an error if the SET expressions set an
+// identity column defined as GENERATED ALWAYS to a value other than DEFAULT.
+// The columns must line up with the names in the SET expressions.
+func checkIdentityColsForUpdate(cols []sqlbase.ColumnDescriptor, exprs tree.UpdateExprs) error {
+	i := 0
+	for _, expr := range exprs {
+		tuple, isTuple := expr.Expr.(*tree.Tuple)
+		for j := range expr.Names {
+			col := &cols[i]
+			i++
+			if !col.IsGeneratedAlwaysAsIdentity() {
+				continue
+			}
+			val := expr.Expr
+			if expr.Tuple {
+				// DEFAULT cannot be returned by a subquery.
+				if !isTuple {
+					return sqlbase.NewGeneratedAlwaysUpdateError(col.Name)
+				}
+				val = tuple.Exprs[j]
+			}
+			if _, ok := val.(tree.DefaultVal); !ok {
+				return sqlbase.NewGeneratedAlwaysUpdateError(col.Name)
+			}
+		}
+	}
+	return nil
+}
+
 // enforceLocalColumnConstraints asserts the column constraints that
 // do not require data validation from other sources than the row data
 // itself. This includes:
@@ -934,3 +1007,14 @@ func enforceLocalColumnConstraints(row tree.Datums, cols []sqlbase.ColumnDescrip
 	}
 	return nil
 }
+
+// assignsFields returns whether some of the given SET expressions assign
+// fields of composite columns, which only the optimizer supports.
+func assignsFields(exprs tree.UpdateExprs) bool {
+	for _, expr := range exprs {
+		if expr.Fields != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -799,6 +799,30 @@ func upsertExprsAndIndex(
 		return true, updateExprs, conflictIndex, nil
 	}
 
+	if assignsFields(onConflict.Exprs) {
+		return false, nil, nil, pgerror.UnimplementedWithIssue(27792,
+			"ON CONFLICT DO UPDATE of a field of a column requires the cost-based optimizer")
+	}
+
+	if onConflict.ArbiterPredicate != nil {
+		return false, nil, nil, pgerror.UnimplementedWithIssue(32557,
+			"ON CONFLICT with a WHERE predicate requires the cost-based optimizer")
+	}
+
+	if onConflict.Constraint != "" {
+		// ON CONFLICT ON CONSTRAINT names the conflict index directly.
+		index, dropped, err := tableDesc.FindIndexByName(string(onConflict.Constraint))
+		if err != nil || dropped || !index.Unique {
+			return false, nil, nil, pgerror.Newf(pgerror.CodeUndefinedObjectError,
+				"constraint %q for table %q does not exist", onConflict.Constraint, tableDesc.Name)
+		}
+		if index.IsPartial() {
+			return false, nil, nil, pgerror.UnimplementedWithIssue(32557,
+				"ON CONFLICT with a partial index requires the cost-based optimizer")
+		}
+		return false, onConflict.Exprs, index, nil
+	}
+
 	if onConflict.DoNothing && len(onConflict.Columns) == 0 {
 		return false, onConflict.Exprs, nil, nil
 	}
@@ -806,7 +830,7 @@ func upsertExprsAndIndex(
 	// General case: INSERT with an ON CONFLICT clause.
 
 	indexMatch := func(index sqlbase.IndexDescriptor) bool {
-		if !index.Unique {
+		if !index.Unique || index.IsPartial() {
 			return false
 		}
 		if len(index.ColumnNames) != len(onConflict.Columns) {
@@ -45,6 +45,10 @@ type planDependencyInfo struct {
 // detailed dependencies on that table.
 type planDependencies map[sqlbase.ID]planDependencyInfo
 
+// planFunctionDependencies maps the ID of a user-defined function depended
+// upon to its descriptor.
+type planFunctionDependencies map[sqlbase.ID]*sqlbase.FunctionDescriptor
+
 // String implements the fmt.Stringer interface.
 func (d planDependencies) String() string {
 	var buf bytes.Buffer
@@ -65,14 +69,16 @@ func (d planDependencies) String() string {
 // analyzeViewQuery extracts the set of dependencies (tables and views
 // that this view's query depends on), together with the more detailed
 // information about which indexes and columns are needed from each
-// dependency. The set of columns from the view query's results is
-// also returned.
+// dependency. The user-defined functions used by the query and the set
+// of columns from the view query's results are also returned.
 func (p *planner) analyzeViewQuery(
 	ctx context.Context, viewSelect *tree.Select,
-) (planDependencies, sqlbase.ResultColumns, error) {
+) (planDependencies, planFunctionDependencies, sqlbase.ResultColumns, error) {
 	// Request dependency tracking.
 	defer func(prev planDependencies) { p.curPlan.deps = prev }(p.curPlan.deps)
 	p.curPlan.deps = make(planDependencies)
+	defer func(prev planFunctionDependencies) { p.curPlan.fnDeps = prev }(p.curPlan.fnDeps)
+	p.curPlan.fnDeps = make(planFunctionDependencies)
 
 	// Request star detection
 	defer func(prev bool) { p.curPlan.hasStar = prev }(p.curPlan.hasStar)
@@ -81,15 +87,15 @@ func (p *planner) analyzeViewQuery(
 	// Now generate the source plan.
 	sourcePlan, err := p.Select(ctx, viewSelect, []*types.T{})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	// The plan will not be needed further.
 	defer sourcePlan.Close(ctx)
 
 	// TODO(a-robinson): Support star expressions as soon as we can (#10028).
 	if p.curPlan.hasStar {
-		return nil, nil, pgerror.UnimplementedWithIssue(10028, "views do not currently support * expressions")
+		return nil, nil, nil, pgerror.UnimplementedWithIssue(10028, "views do not currently support * expressions")
 	}
 
-	return p.curPlan.deps, planColumns(sourcePlan), nil
+	return p.curPlan.deps, p.curPlan.fnDeps, planColumns(sourcePlan), nil
 }
@@ -105,6 +105,10 @@ func containsMutations(plan planNode) (bool, error) {
 // is finished resolving names, which pops the environment frame.
 func (p *planner) initWith(ctx context.Context, with *tree.With) (func(p *planner) error, error) {
 	if with != nil {
+		if with.Recursive {
+			return nil, pgerror.Newf(pgerror.CodeFeatureNotSupportedError,
+				"WITH RECURSIVE is only supported with the cost-based optimizer")
+		}
 		frame := make(cteNameEnvironmentFrame)
 		p.curPlan.cteNameEnvironment = p.curPlan.cteNameEnvironment.push(frame)
 		for _, cte := range with.CTEList {
@@ -767,6 +767,9 @@ func upgradeDescsWithFn(
 						}
 					}
 
+				case *sqlbase.Descriptor_Type, *sqlbase.Descriptor_Function:
+					// Ignore.
+
 				default:
 					return errors.Errorf("Descriptor.Union has unexpected type %T", t)
 				}
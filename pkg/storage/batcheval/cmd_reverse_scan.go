@@ -28,7 +28,8 @@ func init() {
 // ReverseScan scans the key range specified by start key through
 // end key in descending order up to some maximum number of results.
 // maxKeys stores the number of scan results remaining for this batch
-// (MaxInt64 for no limit).
+// (MaxInt64 for no limit). If the request sets KeyLocking, the scan
+// also locks each key that it returns; see lockScannedRows.
 func ReverseScan(
 	ctx context.Context, batch engine.ReadWriter, cArgs CommandArgs, resp roachpb.Response,
 ) (result.Result, error) {
@@ -36,45 +37,72 @@ func ReverseScan(
 	h := cArgs.Header
 	reply := resp.(*roachpb.ReverseScanResponse)
 
-	var err error
-	var intents []roachpb.Intent
-	var resumeSpan *roachpb.Span
+	if err := checkLockingScan(h, args.KeyLocking); err != nil {
+		return result.Result{}, err
+	}
 
-	switch args.ScanFormat {
-	case roachpb.BATCH_RESPONSE:
-		var kvData []byte
-		var numKvs int64
-		kvData, numKvs, resumeSpan, intents, err = engine.MVCCScanToBytes(
-			ctx, batch, args.Key, args.EndKey, cArgs.MaxKeys, h.Timestamp,
-			engine.MVCCScanOptions{
-				Inconsistent:   h.ReadConsistency != roachpb.CONSISTENT,
-				IgnoreSequence: shouldIgnoreSequenceNums(cArgs.EvalCtx),
-				Txn:            h.Txn,
-				Reverse:        true,
-			})
-		if err != nil {
-			return result.Result{}, err
-		}
-		reply.NumKeys = numKvs
-		reply.BatchResponses = [][]byte{kvData}
-	case roachpb.KEY_VALUES:
-		var rows []roachpb.KeyValue
-		rows, resumeSpan, intents, err = engine.MVCCScan(
-			ctx, batch, args.Key, args.EndKey, cArgs.MaxKeys, h.Timestamp, engine.MVCCScanOptions{
-				Inconsistent:   h.ReadConsistency != roachpb.CONSISTENT,
-				IgnoreSequence: shouldIgnoreSequenceNums(cArgs.EvalCtx),
-				Txn:            h.Txn,
-				Reverse:        true,
-			})
-		if err != nil {
-			return result.Result{}, err
+	scanSpan := func(
+		key, endKey roachpb.Key, max int64,
+	) (int64, *roachpb.Span, []roachpb.Intent, error) {
+		switch args.ScanFormat {
+		case roachpb.BATCH_RESPONSE:
+			kvData, numKvs, resumeSpan, intents, err := engine.MVCCScanToBytes(
+				ctx, batch, key, endKey, max, h.Timestamp,
+				engine.MVCCScanOptions{
+					Inconsistent:   h.ReadConsistency != roachpb.CONSISTENT,
+					IgnoreSequence: shouldIgnoreSequenceNums(cArgs.EvalCtx),
+					Txn:            h.Txn,
+					Reverse:        true,
+				})
+			if err != nil {
+				return 0, nil, nil, err
+			}
+			if args.KeyLocking {
+				if err := lockScannedBatchResponse(ctx, batch, cArgs, kvData); err != nil {
+					return 0, nil, nil, err
+				}
+			}
+			reply.BatchResponses = append(reply.BatchResponses, kvData)
+			return numKvs, resumeSpan, intents, nil
+		case roachpb.KEY_VALUES:
+			rows, resumeSpan, intents, err := engine.MVCCScan(
+				ctx, batch, key, endKey, max, h.Timestamp, engine.MVCCScanOptions{
+					Inconsistent:   h.ReadConsistency != roachpb.CONSISTENT,
+					IgnoreSequence: shouldIgnoreSequenceNums(cArgs.EvalCtx),
+					Txn:            h.Txn,
+					Reverse:        true,
+				})
+			if err != nil {
+				return 0, nil, nil, err
+			}
+			if args.KeyLocking {
+				if err := lockScannedRows(ctx, batch, cArgs, rows); err != nil {
+					return 0, nil, nil, err
+				}
+			}
+			reply.Rows = append(reply.Rows, rows...)
+			return int64(len(rows)), resumeSpan, intents, nil
+		default:
+			panic(fmt.Sprintf("Unknown scanFormat %d", args.ScanFormat))
 		}
-		reply.NumKeys = int64(len(rows))
-		reply.Rows = rows
-	default:
-		panic(fmt.Sprintf("Unknown scanFormat %d", args.ScanFormat))
 	}
 
+	var numKeys int64
+	var resumeSpan *roachpb.Span
+	var intents []roachpb.Intent
+	var err error
+	if h.WaitPolicy == roachpb.WaitPolicy_SKIP {
+		numKeys, resumeSpan, intents, err = scanSkipLocked(
+			args.Key, args.EndKey, cArgs.MaxKeys, true /* reverse */, scanSpan,
+		)
+	} else {
+		numKeys, resumeSpan, intents, err = scanSpan(args.Key, args.EndKey, cArgs.MaxKeys)
+	}
+	if err != nil {
+		return result.Result{}, err
+	}
+	reply.NumKeys = numKeys
+
 	if resumeSpan != nil {
 		reply.ResumeSpan = resumeSpan
 		reply.ResumeReason = roachpb.RESUME_KEY_LIMIT
@@ -0,0 +1,159 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package batcheval
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage/engine"
+	"github.com/pkg/errors"
+)
+
+// scanSpanFunc scans the span [key, endKey) and returns at most max keys. The
+// results are accumulated by the function itself; it returns the number of
+// keys that were added, the resume span (if the limit was reached) and the
+// intents that were encountered.
+type scanSpanFunc func(
+	key, endKey roachpb.Key, max int64,
+) (numKeys int64, resumeSpan *roachpb.Span, intents []roachpb.Intent, err error)
+
+// checkLockingScan verifies that a scan which requests locks on the keys that
+// it returns can do so.
+func checkLockingScan(h roachpb.Header, keyLocking bool) error {
+	if !keyLocking {
+		if h.WaitPolicy == roachpb.WaitPolicy_SKIP {
+			return errors.Errorf("wait policy %s requires a locking scan", h.WaitPolicy)
+		}
+		return nil
+	}
+	if h.Txn == nil {
+		return errors.Errorf("locking scans must be transactional")
+	}
+	if h.ReadConsistency != roachpb.CONSISTENT {
+		return errors.Errorf("locking scans require %s reads", roachpb.CONSISTENT)
+	}
+	return nil
+}
+
+// lockScannedRows acquires an exclusive lock on the key of each row returned by
+// a locking scan. The lock is an intent written by the request's transaction
+// that carries the value which was just read, so the row is left unchanged
+// when the transaction commits.
+func lockScannedRows(
+	ctx context.Context, batch engine.ReadWriter, cArgs CommandArgs, rows []roachpb.KeyValue,
+) error {
+	h := cArgs.Header
+	for i := range rows {
+		if err := engine.MVCCPut(
+			ctx, batch, cArgs.Stats, rows[i].Key, h.Timestamp, rows[i].Value, h.Txn,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockScannedBatchResponse is like lockScannedRows, but operates on the rows of
+// a scan that used the BATCH_RESPONSE format.
+func lockScannedBatchResponse(
+	ctx context.Context, batch engine.ReadWriter, cArgs CommandArgs, kvData []byte,
+) error {
+	h := cArgs.Header
+	for len(kvData) > 0 {
+		var key engine.MVCCKey
+		var rawBytes []byte
+		var err error
+		key, rawBytes, kvData, err = engine.MVCCScanDecodeKeyValue(kvData)
+		if err != nil {
+			return err
+		}
+		value := roachpb.Value{RawBytes: rawBytes}
+		if err := engine.MVCCPut(
+			ctx, batch, cArgs.Stats, key.Key, h.Timestamp, value, h.Txn,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanSkipLocked scans the span [key, endKey) with the provided function, but
+// omits the keys that are covered by intents of other transactions instead of
+// returning a WriteIntentError for them. Whenever a scan runs into conflicting
+// intents, the span is narrowed so that it ends right before the first
+// conflicting key in the scan direction; once the narrowed span has been
+// scanned, the scan continues right after that key.
+//
+// Only keys that hold an intent are skipped, so callers must make sure that a
+// single key corresponds to a whole row.
+func scanSkipLocked(
+	key, endKey roachpb.Key, max int64, reverse bool, scan scanSpanFunc,
+) (int64, *roachpb.Span, []roachpb.Intent, error) {
+	var numKeys int64
+	var allIntents []roachpb.Intent
+	origKey, origEndKey := key, endKey
+	// skipped is the conflicting key that bounds the current span, or nil if
+	// the span extends to the original boundary.
+	var skipped roachpb.Key
+	for {
+		n, resumeSpan, intents, err := scan(key, endKey, max-numKeys)
+		if wiErr, ok := err.(*roachpb.WriteIntentError); ok && len(wiErr.Intents) > 0 {
+			skipped = firstConflictingKey(wiErr.Intents, reverse)
+			if reverse {
+				key = skipped.Next()
+			} else {
+				endKey = skipped
+			}
+			continue
+		}
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		numKeys += n
+		allIntents = append(allIntents, intents...)
+		if resumeSpan != nil {
+			// The limit was reached. The resume span has to cover the rest of the
+			// original span, including the parts past the skipped key.
+			if reverse {
+				resumeSpan.Key = origKey
+			} else {
+				resumeSpan.EndKey = origEndKey
+			}
+			return numKeys, resumeSpan, allIntents, nil
+		}
+		if skipped == nil {
+			return numKeys, nil, allIntents, nil
+		}
+		// Continue on the other side of the skipped key.
+		if reverse {
+			key, endKey = origKey, skipped
+		} else {
+			key, endKey = skipped.Next(), origEndKey
+		}
+		skipped = nil
+	}
+}
+
+// firstConflictingKey returns the key of the first intent that a scan in the
+// given direction runs into.
+func firstConflictingKey(intents []roachpb.Intent, reverse bool) roachpb.Key {
+	first := intents[0].Key
+	for _, intent := range intents[1:] {
+		if c := bytes.Compare(intent.Key, first); (c < 0 && !reverse) || (c > 0 && reverse) {
+			first = intent.Key
+		}
+	}
+	return first
+}
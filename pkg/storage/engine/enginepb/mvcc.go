@@ -190,3 +190,28 @@ func (meta *MVCCMetadata) GetIntentValue(seq TxnSeq) ([]byte, bool) {
 	}
 	return nil, false
 }
+
+// GetLatestNonIgnoredIntent goes through the intent history and finds the
+// latest entry whose sequence number was not rolled back, returning its index
+// in the history.
+func (meta *MVCCMetadata) GetLatestNonIgnoredIntent(
+	ignored []IgnoredSeqNumRange,
+) (MVCCMetadata_SequencedIntent, int, bool) {
+	for i := len(meta.IntentHistory) - 1; i >= 0; i-- {
+		if !TxnSeqIsIgnored(meta.IntentHistory[i].Sequence, ignored) {
+			return meta.IntentHistory[i], i, true
+		}
+	}
+	return MVCCMetadata_SequencedIntent{}, -1, false
+}
+
+// TxnSeqIsIgnored returns true iff the sequence number falls within one of
+// the ignored sequence number ranges. The ranges must be sorted and must not
+// overlap.
+func TxnSeqIsIgnored(seq TxnSeq, ignored []IgnoredSeqNumRange) bool {
+	// Find the first range whose end is at or above the sequence number.
+	i := sort.Search(len(ignored), func(i int) bool {
+		return ignored[i].End >= seq
+	})
+	return i < len(ignored) && ignored[i].Start <= seq
+}
@@ -873,6 +873,23 @@ func mvccGetInternal(
 					txn.Epoch, meta.Txn.Epoch)
 			}
 			seekKey = seekKey.Next()
+		} else if ownIntent && enginepb.TxnSeqIsIgnored(meta.Txn.Sequence, txn.IgnoredSeqNums) {
+			// We're reading our own txn's intent, but the write that laid it
+			// down was rolled back by rolling back to a savepoint. Read the
+			// latest value in the intent history that wasn't rolled back or, if
+			// there is none, skip the intent altogether.
+			if intent, _, found := meta.GetLatestNonIgnoredIntent(txn.IgnoredSeqNums); found {
+				value := &buf.value
+				*value = roachpb.Value{
+					RawBytes:  append([]byte(nil), intent.Value...),
+					Timestamp: metaTimestamp,
+				}
+				if err := value.Verify(metaKey.Key); err != nil {
+					return nil, nil, safeValue, err
+				}
+				return value, nil, safeValue, nil
+			}
+			seekKey = seekKey.Next()
 		}
 	} else if txn != nil && timestamp.Less(txn.MaxTimestamp) {
 		// In this branch, the latest timestamp is ahead, and so the read of an
@@ -1407,6 +1424,10 @@ func mvccPutInternal(
 				prevIntentValBytes = existingVal.RawBytes
 			}
 			prevIntentSequence := meta.Txn.Sequence
+			// If the write that laid down the existing intent was rolled back, the
+			// value read above comes from an earlier entry of the intent history
+			// (or from below the intent), and the intent itself is not recorded.
+			prevIntentIgnored := enginepb.TxnSeqIsIgnored(prevIntentSequence, txn.IgnoredSeqNums)
 
 			// Make sure we process valueFn before clearing any earlier
 			// version.  For example, a conditional put within same
@@ -1459,7 +1480,7 @@ func mvccPutInternal(
 			//
 			// If the epoch of the transaction doesn't match the epoch of the
 			// intent, blow away the intent history.
-			if txn.Epoch == meta.Txn.Epoch {
+			if txn.Epoch == meta.Txn.Epoch && !prevIntentIgnored {
 				// This case shouldn't pop up, but it is worth asserting
 				// that it doesn't. We shouldn't write invalid intents
 				// to the history
@@ -1469,7 +1490,7 @@ func mvccPutInternal(
 						metaKey, txn)
 				}
 				buf.newMeta.AddToIntentHistory(prevIntentSequence, prevIntentValBytes)
-			} else {
+			} else if txn.Epoch != meta.Txn.Epoch {
 				buf.newMeta.IntentHistory = nil
 			}
 		} else if !metaTimestamp.Less(readTimestamp) {
@@ -2327,6 +2348,22 @@ func mvccResolveWriteIntent(
 	inProgress := !intent.Status.IsFinalized() && meta.Txn.Epoch >= intent.Txn.Epoch
 	pushed := inProgress && hlc.Timestamp(meta.Timestamp).Less(intent.Txn.Timestamp)
 
+	// If we're committing an intent whose latest write was rolled back by
+	// rolling back to a savepoint, restore the latest write that wasn't rolled
+	// back before committing. If no such write exists, the intent is removed
+	// as if the transaction had aborted.
+	if commit && enginepb.TxnSeqIsIgnored(meta.Txn.Sequence, intent.IgnoredSeqNums) {
+		var removeIntent bool
+		removeIntent, origMetaKeySize, origMetaValSize, err = mvccMaybeRewriteIntentHistory(
+			engine, ms, intent.IgnoredSeqNums, metaKey, meta, origMetaKeySize, origMetaValSize, buf)
+		if err != nil {
+			return false, err
+		}
+		if removeIntent {
+			commit = false
+		}
+	}
+
 	// There's nothing to do if meta's epoch is greater than or equal txn's
 	// epoch and the state is still in progress but the intent was not pushed
 	// to a larger timestamp.
@@ -2506,6 +2543,52 @@ func (b IterAndBuf) Cleanup() {
 	b.iter.Close()
 }
 
+// mvccMaybeRewriteIntentHistory rewrites the intent at metaKey, whose latest
+// write was rolled back, to hold the latest value of its intent history whose
+// sequence number was not rolled back. The entries that follow it in the
+// history are discarded. If no value in the history survives, the intent is
+// left untouched and removeIntent is returned as true; the caller is expected
+// to remove the intent altogether. Otherwise meta is updated in place and the
+// sizes of the rewritten metadata are returned.
+func mvccMaybeRewriteIntentHistory(
+	engine Writer,
+	ms *enginepb.MVCCStats,
+	ignoredSeqNums []enginepb.IgnoredSeqNumRange,
+	metaKey MVCCKey,
+	meta *enginepb.MVCCMetadata,
+	origMetaKeySize, origMetaValSize int64,
+	buf *putBuffer,
+) (removeIntent bool, metaKeySize, metaValSize int64, err error) {
+	restored, idx, found := meta.GetLatestNonIgnoredIntent(ignoredSeqNums)
+	if !found {
+		return true, origMetaKeySize, origMetaValSize, nil
+	}
+
+	// Overwrite the versioned value, which lives at the intent's timestamp.
+	latestKey := MVCCKey{Key: metaKey.Key, Timestamp: hlc.Timestamp(meta.Timestamp)}
+	if err := engine.Put(latestKey, restored.Value); err != nil {
+		return false, 0, 0, err
+	}
+
+	newMeta := *meta
+	txnMeta := *meta.Txn
+	txnMeta.Sequence = restored.Sequence
+	newMeta.Txn = &txnMeta
+	newMeta.IntentHistory = meta.IntentHistory[:idx]
+	newMeta.Deleted = len(restored.Value) == 0
+	newMeta.ValBytes = int64(len(restored.Value))
+	metaKeySize, metaValSize, err = buf.putMeta(engine, metaKey, &newMeta)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	if ms != nil {
+		ms.Add(updateStatsOnPut(metaKey.Key, 0 /* prevValSize */, origMetaKeySize, origMetaValSize,
+			metaKeySize, metaValSize, meta, &newMeta))
+	}
+	*meta = newMeta
+	return false, metaKeySize, metaValSize, nil
+}
+
 // MVCCResolveWriteIntentRange commits or aborts (rolls back) the
 // range of write intents specified by start and end keys for a given
 // txn. ResolveWriteIntentRange will skip write intents of other
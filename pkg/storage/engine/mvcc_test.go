@@ -3582,6 +3582,84 @@ func TestMVCCWriteWithSequence(t *testing.T) {
 	}
 }
 
+// TestMVCCIgnoredSeqNums verifies that writes performed at sequence numbers
+// that were rolled back are ignored by the transaction's reads and discarded
+// when the intent is committed.
+func TestMVCCIgnoredSeqNums(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+
+	type r = enginepb.IgnoredSeqNumRange
+	testCases := []struct {
+		name    string
+		ignored []r
+		// expVal is the value expected to be read by the transaction and to be
+		// committed. nil means that the key has no value.
+		expVal *roachpb.Value
+	}{
+		{"none ignored", nil, &value3},
+		{"latest ignored", []r{{Start: 3, End: 3}}, &value2},
+		{"latest two ignored", []r{{Start: 2, End: 3}}, &value1},
+		{"middle ignored", []r{{Start: 2, End: 2}}, &value3},
+		{"all but middle ignored", []r{{Start: 1, End: 1}, {Start: 3, End: 3}}, &value2},
+		{"all ignored", []r{{Start: 1, End: 3}}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			engine := createTestEngine()
+			defer engine.Close()
+
+			ms := &enginepb.MVCCStats{}
+			txn := *txn1
+			for i, v := range []roachpb.Value{value1, value2, value3} {
+				txn.Sequence = enginepb.TxnSeq(i + 1)
+				if err := MVCCPut(ctx, engine, ms, testKey1, txn.Timestamp, v, &txn); err != nil {
+					t.Fatal(err)
+				}
+			}
+			txn.IgnoredSeqNums = tc.ignored
+
+			for _, impl := range mvccGetImpls {
+				val, _, err := impl.fn(ctx, engine, testKey1, txn.Timestamp, MVCCGetOptions{Txn: &txn})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if tc.expVal == nil {
+					if val.IsPresent() {
+						t.Fatalf("%s: expected no value, got %v", impl.name, val)
+					}
+				} else if val == nil || !bytes.Equal(tc.expVal.RawBytes, val.RawBytes) {
+					t.Fatalf("%s: expected %v, got %v", impl.name, tc.expVal, val)
+				}
+			}
+
+			if err := MVCCResolveWriteIntent(ctx, engine, ms, roachpb.Intent{
+				Span:           roachpb.Span{Key: testKey1},
+				Txn:            txn.TxnMeta,
+				Status:         roachpb.COMMITTED,
+				IgnoredSeqNums: txn.IgnoredSeqNums,
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			val, _, err := MVCCGet(ctx, engine, testKey1, txn.Timestamp, MVCCGetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.expVal == nil {
+				if val != nil {
+					t.Fatalf("expected no committed value, got %v", val)
+				}
+			} else if val == nil || !bytes.Equal(tc.expVal.RawBytes, val.RawBytes) {
+				t.Fatalf("expected committed %v, got %v", tc.expVal, val)
+			}
+			assertEq(t, engine, "after resolve", ms, ms)
+		})
+	}
+}
+
 // TestMVCCWriteWithSequence verifies that delete range operations at sequence
 // numbers equal to or below the sequence of a previous delete range operation
 // verify that they agree with the sequence history of each intent left by the
@@ -2617,10 +2617,25 @@ func goToCTxn(txn *roachpb.Transaction) C.DBTxn {
 		r.epoch = C.uint32_t(txn.Epoch)
 		r.sequence = C.int32_t(txn.Sequence)
 		r.max_timestamp = goToCTimestamp(txn.MaxTimestamp)
+		r.ignored_seqnums = goToCIgnoredSeqNums(txn.IgnoredSeqNums)
 	}
 	return r
 }
 
+func goToCIgnoredSeqNums(b []enginepb.IgnoredSeqNumRange) C.DBIgnoredSeqNums {
+	if len(b) == 0 {
+		return C.DBIgnoredSeqNums{ranges: nil, len: 0}
+	}
+	r := make([]C.DBIgnoredSeqNumRange, len(b))
+	for i := range b {
+		r[i] = C.DBIgnoredSeqNumRange{
+			start_seqnum: C.int32_t(b[i].Start),
+			end_seqnum:   C.int32_t(b[i].End),
+		}
+	}
+	return C.DBIgnoredSeqNums{ranges: &r[0], len: C.int(len(r))}
+}
+
 func goToCIterOptions(opts IterOptions) C.DBIterOptions {
 	return C.DBIterOptions{
 		prefix:             C.bool(opts.Prefix),
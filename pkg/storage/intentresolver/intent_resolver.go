@@ -369,6 +369,7 @@ func updateIntentTxnStatus(
 		}
 		intent.Txn = pushee.TxnMeta
 		intent.Status = pushee.Status
+		intent.IgnoredSeqNums = pushee.IgnoredSeqNums
 		results = append(results, intent)
 	}
 	return results
@@ -709,6 +710,7 @@ func (ir *IntentResolver) CleanupTxnIntentsOnGCAsync(
 				for i := range intents {
 					intents[i].Txn = txn.TxnMeta
 					intents[i].Status = txn.Status
+					intents[i].IgnoredSeqNums = txn.IgnoredSeqNums
 				}
 			}
 			var onCleanupComplete func(error)
@@ -890,19 +892,21 @@ func (ir *IntentResolver) ResolveIntents(
 				resolveReq{
 					rangeID: ir.lookupRangeID(ctx, intent.Key),
 					req: &roachpb.ResolveIntentRequest{
-						RequestHeader: roachpb.RequestHeaderFromSpan(intent.Span),
-						IntentTxn:     intent.Txn,
-						Status:        intent.Status,
-						Poison:        opts.Poison,
+						RequestHeader:  roachpb.RequestHeaderFromSpan(intent.Span),
+						IntentTxn:      intent.Txn,
+						Status:         intent.Status,
+						Poison:         opts.Poison,
+						IgnoredSeqNums: intent.IgnoredSeqNums,
 					},
 				})
 		} else {
 			resolveRangeReqs = append(resolveRangeReqs, &roachpb.ResolveIntentRangeRequest{
-				RequestHeader: roachpb.RequestHeaderFromSpan(intent.Span),
-				IntentTxn:     intent.Txn,
-				Status:        intent.Status,
-				Poison:        opts.Poison,
-				MinTimestamp:  opts.MinTimestamp,
+				RequestHeader:  roachpb.RequestHeaderFromSpan(intent.Span),
+				IntentTxn:      intent.Txn,
+				Status:         intent.Status,
+				Poison:         opts.Poison,
+				MinTimestamp:   opts.MinTimestamp,
+				IgnoredSeqNums: intent.IgnoredSeqNums,
 			})
 		}
 	}
@@ -15,6 +15,7 @@ package rangefeed
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -112,6 +113,11 @@ type Processor struct {
 	reg registry
 	rts resolvedTimestamp
 
+	// diffRegs is the number of registrations that requested the previous
+	// values of updated keys. It is accessed atomically so that NeedPrevVal
+	// does not need to synchronize with the processor goroutine.
+	diffRegs int32
+
 	regC     chan registration
 	unregC   chan *registration
 	lenReqC  chan struct{}
@@ -226,13 +232,13 @@ func (p *Processor) Start(stopper *stop.Stopper, rtsIter engine.SimpleIterator)
 						r.catchupIter.Close() // clean up
 					}
 					r.disconnect(roachpb.NewError(err))
-					p.reg.Unregister(&r)
+					p.unregister(&r)
 				}
 
 			// Respond to unregistration requests; these come from registrations that
 			// encounter an error during their output loop.
 			case r := <-p.unregC:
-				p.reg.Unregister(r)
+				p.unregister(r)
 
 			// Respond to answers about the processor goroutine state.
 			case <-p.lenReqC:
@@ -342,6 +348,9 @@ func (p *Processor) sendStop(pErr *roachpb.Error) {
 // The optionally provided "catch-up" iterator is used to read changes from the
 // engine which occurred after the provided start timestamp.
 //
+// If withDiff is true, the RangeFeedValue events sent to the stream include
+// the previous values of the updated keys.
+//
 // If the method returns false, the processor will have been stopped, so calling
 // Stop is not necessary.
 //
@@ -350,6 +359,7 @@ func (p *Processor) Register(
 	span roachpb.RSpan,
 	startTS hlc.Timestamp,
 	catchupIter engine.SimpleIterator,
+	withDiff bool,
 	stream Stream,
 	errC chan<- *roachpb.Error,
 ) bool {
@@ -359,17 +369,33 @@ func (p *Processor) Register(
 	p.syncEventC()
 
 	r := newRegistration(
-		span.AsRawSpanWithNoLocals(), startTS, catchupIter, p.Config.EventChanCap,
+		span.AsRawSpanWithNoLocals(), startTS, catchupIter, withDiff, p.Config.EventChanCap,
 		p.Metrics, stream, errC,
 	)
+	// Count the registration before handing it to the processor goroutine so
+	// that the previous values of all events it observes are populated.
+	if withDiff {
+		atomic.AddInt32(&p.diffRegs, 1)
+	}
 	select {
 	case p.regC <- r:
 		return true
 	case <-p.stoppedC:
+		if withDiff {
+			atomic.AddInt32(&p.diffRegs, -1)
+		}
 		return false
 	}
 }
 
+// unregister removes a disconnected registration from the registry.
+func (p *Processor) unregister(r *registration) {
+	p.reg.Unregister(r)
+	if r.withDiff {
+		atomic.AddInt32(&p.diffRegs, -1)
+	}
+}
+
 // Len returns the number of registrations attached to the processor.
 func (p *Processor) Len() int {
 	if p == nil {
@@ -386,6 +412,17 @@ func (p *Processor) Len() int {
 	}
 }
 
+// NeedPrevVal returns whether any of the processor's registrations requested
+// the previous values of updated keys. If so, the PrevValue fields of the
+// logical ops passed to ConsumeLogicalOps must be populated. Safe to call on
+// nil Processor.
+func (p *Processor) NeedPrevVal() bool {
+	if p == nil {
+		return false
+	}
+	return atomic.LoadInt32(&p.diffRegs) > 0
+}
+
 // ConsumeLogicalOps informs the rangefeed processor of the set of logical
 // operations. It returns false if consuming the operations hit a timeout, as
 // specified by the EventChanTimeout configuration. If the method returns false,
@@ -502,7 +539,7 @@ func (p *Processor) consumeLogicalOps(ctx context.Context, ops []enginepb.MVCCLo
 		switch t := op.GetValue().(type) {
 		case *enginepb.MVCCWriteValueOp:
 			// Publish the new value directly.
-			p.publishValue(ctx, t.Key, t.Timestamp, t.Value)
+			p.publishValue(ctx, t.Key, t.Timestamp, t.Value, t.PrevValue)
 
 		case *enginepb.MVCCWriteIntentOp:
 			// No updates to publish.
@@ -512,7 +549,7 @@ func (p *Processor) consumeLogicalOps(ctx context.Context, ops []enginepb.MVCCLo
 
 		case *enginepb.MVCCCommitIntentOp:
 			// Publish the newly committed value.
-			p.publishValue(ctx, t.Key, t.Timestamp, t.Value)
+			p.publishValue(ctx, t.Key, t.Timestamp, t.Value, t.PrevValue)
 
 		case *enginepb.MVCCAbortIntentOp:
 			// No updates to publish.
@@ -545,7 +582,7 @@ func (p *Processor) initResolvedTS(ctx context.Context) {
 }
 
 func (p *Processor) publishValue(
-	ctx context.Context, key roachpb.Key, timestamp hlc.Timestamp, value []byte,
+	ctx context.Context, key roachpb.Key, timestamp hlc.Timestamp, value, prevValue []byte,
 ) {
 	if !p.Span.ContainsKey(roachpb.RKey(key)) {
 		log.Fatalf(ctx, "key %v not in Processor's key range %v", key, p.Span)
@@ -559,6 +596,9 @@ func (p *Processor) publishValue(
 			RawBytes:  value,
 			Timestamp: timestamp,
 		},
+		PrevValue: roachpb.Value{
+			RawBytes: prevValue,
+		},
 	})
 	p.reg.PublishToOverlapping(span, &event)
 }
@@ -112,6 +112,14 @@ func rangeFeedValue(key roachpb.Key, val roachpb.Value) *roachpb.RangeFeedEvent
 	})
 }
 
+func rangeFeedValueWithPrev(key roachpb.Key, val, prev roachpb.Value) *roachpb.RangeFeedEvent {
+	return makeRangeFeedEvent(&roachpb.RangeFeedValue{
+		Key:       key,
+		Value:     val,
+		PrevValue: prev,
+	})
+}
+
 func rangeFeedCheckpoint(span roachpb.Span, ts hlc.Timestamp) *roachpb.RangeFeedEvent {
 	return makeRangeFeedEvent(&roachpb.RangeFeedCheckpoint{
 		Span:       span,
@@ -181,7 +189,8 @@ func TestProcessorBasic(t *testing.T) {
 	r1OK := p.Register(
 		roachpb.RSpan{Key: roachpb.RKey("a"), EndKey: roachpb.RKey("m")},
 		hlc.Timestamp{WallTime: 1},
-		nil, /* catchUpIter */
+		nil,   /* catchUpIter */
+		false, /* withDiff */
 		r1Stream,
 		r1ErrC,
 	)
@@ -296,7 +305,8 @@ func TestProcessorBasic(t *testing.T) {
 	r2OK := p.Register(
 		roachpb.RSpan{Key: roachpb.RKey("c"), EndKey: roachpb.RKey("z")},
 		hlc.Timestamp{WallTime: 1},
-		nil, /* catchUpIter */
+		nil,   /* catchUpIter */
+		false, /* withDiff */
 		r2Stream,
 		r2ErrC,
 	)
@@ -366,7 +376,8 @@ func TestProcessorBasic(t *testing.T) {
 	r3OK := p.Register(
 		roachpb.RSpan{Key: roachpb.RKey("c"), EndKey: roachpb.RKey("z")},
 		hlc.Timestamp{WallTime: 1},
-		nil, /* catchUpIter */
+		nil,   /* catchUpIter */
+		false, /* withDiff */
 		r3Stream,
 		r3ErrC,
 	)
@@ -389,7 +400,74 @@ func TestNilProcessor(t *testing.T) {
 	// The following should panic because they are not safe
 	// to call on a nil Processor.
 	require.Panics(t, func() { p.Start(stop.NewStopper(), nil) })
-	require.Panics(t, func() { p.Register(roachpb.RSpan{}, hlc.Timestamp{}, nil, nil, nil) })
+	require.Panics(t, func() { p.Register(roachpb.RSpan{}, hlc.Timestamp{}, nil, false, nil, nil) })
+}
+
+func TestProcessorWithDiff(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	p, stopper := newTestProcessor(nil /* rtsIter */)
+	defer stopper.Stop(context.Background())
+	require.False(t, p.NeedPrevVal())
+
+	// Add a registration without diffs and one with diffs.
+	r1Stream := newTestStream()
+	p.Register(
+		roachpb.RSpan{Key: roachpb.RKey("a"), EndKey: roachpb.RKey("m")},
+		hlc.Timestamp{WallTime: 1},
+		nil,   /* catchUpIter */
+		false, /* withDiff */
+		r1Stream,
+		make(chan *roachpb.Error, 1),
+	)
+	require.False(t, p.NeedPrevVal())
+	r2Stream := newTestStream()
+	p.Register(
+		roachpb.RSpan{Key: roachpb.RKey("a"), EndKey: roachpb.RKey("m")},
+		hlc.Timestamp{WallTime: 1},
+		nil,  /* catchUpIter */
+		true, /* withDiff */
+		r2Stream,
+		make(chan *roachpb.Error, 1),
+	)
+	require.True(t, p.NeedPrevVal())
+	p.syncEventAndRegistrations()
+	r1Stream.Events()
+	r2Stream.Events()
+
+	txn := uuid.MakeV4()
+	p.ConsumeLogicalOps(
+		makeLogicalOp(&enginepb.MVCCWriteValueOp{
+			Key:       roachpb.Key("b"),
+			Timestamp: hlc.Timestamp{WallTime: 5},
+			Value:     []byte("val2"),
+			PrevValue: []byte("val1"),
+		}),
+		writeIntentOpWithKey(txn, roachpb.Key("c"), hlc.Timestamp{WallTime: 6}),
+		makeLogicalOp(&enginepb.MVCCCommitIntentOp{
+			TxnID:     txn,
+			Key:       roachpb.Key("c"),
+			Timestamp: hlc.Timestamp{WallTime: 6},
+			Value:     []byte("val3"),
+		}),
+	)
+	p.syncEventAndRegistrations()
+
+	valB := roachpb.Value{RawBytes: []byte("val2"), Timestamp: hlc.Timestamp{WallTime: 5}}
+	valC := roachpb.Value{RawBytes: []byte("val3"), Timestamp: hlc.Timestamp{WallTime: 6}}
+	require.Equal(t,
+		[]*roachpb.RangeFeedEvent{
+			rangeFeedValue(roachpb.Key("b"), valB),
+			rangeFeedValue(roachpb.Key("c"), valC),
+		},
+		r1Stream.Events(),
+	)
+	require.Equal(t,
+		[]*roachpb.RangeFeedEvent{
+			rangeFeedValueWithPrev(roachpb.Key("b"), valB, roachpb.Value{RawBytes: []byte("val1")}),
+			rangeFeedValue(roachpb.Key("c"), valC),
+		},
+		r2Stream.Events(),
+	)
 }
 
 func TestProcessorSlowConsumer(t *testing.T) {
@@ -406,7 +484,8 @@ func TestProcessorSlowConsumer(t *testing.T) {
 	p.Register(
 		roachpb.RSpan{Key: roachpb.RKey("a"), EndKey: roachpb.RKey("m")},
 		hlc.Timestamp{WallTime: 1},
-		nil, /* catchUpIter */
+		nil,   /* catchUpIter */
+		false, /* withDiff */
 		r1Stream,
 		r1ErrC,
 	)
@@ -415,7 +494,8 @@ func TestProcessorSlowConsumer(t *testing.T) {
 	p.Register(
 		roachpb.RSpan{Key: roachpb.RKey("a"), EndKey: roachpb.RKey("z")},
 		hlc.Timestamp{WallTime: 1},
-		nil, /* catchUpIter */
+		nil,   /* catchUpIter */
+		false, /* withDiff */
 		r2Stream,
 		r2ErrC,
 	)
@@ -534,7 +614,8 @@ func TestProcessorInitializeResolvedTimestamp(t *testing.T) {
 	p.Register(
 		roachpb.RSpan{Key: roachpb.RKey("a"), EndKey: roachpb.RKey("m")},
 		hlc.Timestamp{WallTime: 1},
-		nil, /* catchUpIter */
+		nil,   /* catchUpIter */
+		false, /* withDiff */
 		r1Stream,
 		make(chan *roachpb.Error, 1),
 	)
@@ -768,7 +849,7 @@ func TestProcessorConcurrentStop(t *testing.T) {
 			runtime.Gosched()
 			s := newTestStream()
 			errC := make(chan<- *roachpb.Error, 1)
-			p.Register(p.Span, hlc.Timestamp{}, nil, s, errC)
+			p.Register(p.Span, hlc.Timestamp{}, nil, false /* withDiff */, s, errC)
 		}()
 		go func() {
 			defer wg.Done()
@@ -837,7 +918,7 @@ func TestProcessorRegistrationObservesOnlyNewEvents(t *testing.T) {
 			s := newTestStream()
 			regs[s] = firstIdx
 			errC := make(chan *roachpb.Error, 1)
-			p.Register(p.Span, hlc.Timestamp{}, nil, s, errC)
+			p.Register(p.Span, hlc.Timestamp{}, nil, false /* withDiff */, s, errC)
 			regDone <- struct{}{}
 		}
 	}()
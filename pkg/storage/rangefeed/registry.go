@@ -58,6 +58,7 @@ type registration struct {
 	span             roachpb.Span
 	catchupIter      engine.SimpleIterator
 	catchupTimestamp hlc.Timestamp
+	withDiff         bool
 	metrics          *Metrics
 
 	// Output.
@@ -88,6 +89,7 @@ func newRegistration(
 	span roachpb.Span,
 	startTS hlc.Timestamp,
 	catchupIter engine.SimpleIterator,
+	withDiff bool,
 	bufferSz int,
 	metrics *Metrics,
 	stream Stream,
@@ -96,6 +98,7 @@ func newRegistration(
 	r := registration{
 		span:             span,
 		catchupIter:      catchupIter,
+		withDiff:         withDiff,
 		metrics:          metrics,
 		stream:           stream,
 		errC:             errC,
@@ -113,6 +116,8 @@ func newRegistration(
 // If overflowed is already set, events are ignored and not written to the
 // buffer.
 func (r *registration) publish(event *roachpb.RangeFeedEvent) {
+	event = r.maybeStripEvent(event)
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if r.mu.overflowed {
@@ -128,6 +133,21 @@ func (r *registration) publish(event *roachpb.RangeFeedEvent) {
 	}
 }
 
+// maybeStripEvent removes the previous value from a RangeFeedValue event if
+// the registration did not request it. Events are shared between all of the
+// registrations they are published to, so the event is copied before it is
+// modified.
+func (r *registration) maybeStripEvent(event *roachpb.RangeFeedEvent) *roachpb.RangeFeedEvent {
+	if r.withDiff || event.Val == nil || !event.Val.PrevValue.IsPresent() {
+		return event
+	}
+	val := *event.Val
+	val.PrevValue = roachpb.Value{}
+	var stripped roachpb.RangeFeedEvent
+	stripped.MustSetValue(&val)
+	return &stripped
+}
+
 // disconnect cancels the output loop context for the registration and passes an
 // error to the output error stream for the registration. This also sets the
 // disconnected flag on the registration, preventing it from being disconnected
@@ -225,6 +245,11 @@ func (r *registration) runCatchupScan() error {
 	// the encountered values in reverse.
 	reorderBuf := make([]roachpb.RangeFeedEvent, 0, 5)
 	var lastKey []byte
+	// If the registration requested diffs, the previous value of each event is
+	// the next (older) version of its key that the iterator encounters.
+	// needPrevVal is set while the oldest event in reorderBuf is waiting for
+	// its previous value.
+	needPrevVal := false
 	outputEvents := func() error {
 		for i := len(reorderBuf) - 1; i >= 0; i-- {
 			e := reorderBuf[i]
@@ -272,9 +297,13 @@ func (r *registration) runCatchupScan() error {
 			// filter on the registration's starting timestamp. Instead, we
 			// return all inline writes.
 			unsafeVal = meta.RawBytes
-		} else if !r.catchupTimestamp.Less(unsafeKey.Timestamp) {
-			// At or before the registration's exclusive starting timestamp.
-			// Ignore.
+		}
+
+		// Values at or before the registration's exclusive starting timestamp
+		// are ignored, unless one is the previous value of the oldest event
+		// for its key.
+		ignore := unsafeKey.IsValue() && !r.catchupTimestamp.Less(unsafeKey.Timestamp)
+		if ignore && !(needPrevVal && bytes.Equal(unsafeKey.Key, lastKey)) {
 			continue
 		}
 
@@ -289,6 +318,15 @@ func (r *registration) runCatchupScan() error {
 				return err
 			}
 			lastKey = key
+			needPrevVal = false
+		}
+
+		if needPrevVal {
+			reorderBuf[len(reorderBuf)-1].Val.PrevValue = roachpb.Value{RawBytes: val}
+			needPrevVal = false
+		}
+		if ignore {
+			continue
 		}
 
 		var event roachpb.RangeFeedEvent
@@ -300,6 +338,7 @@ func (r *registration) runCatchupScan() error {
 			},
 		})
 		reorderBuf = append(reorderBuf, event)
+		needPrevVal = r.withDiff
 	}
 
 	// Output events for the last key encountered.
@@ -20,6 +20,7 @@ import (
 	_ "github.com/cockroachdb/cockroach/pkg/keys" // hook up pretty printer
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/engine"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
@@ -98,7 +99,7 @@ type testRegistration struct {
 }
 
 func newTestRegistration(
-	span roachpb.Span, ts hlc.Timestamp, catchup engine.SimpleIterator,
+	span roachpb.Span, ts hlc.Timestamp, catchup engine.SimpleIterator, withDiff bool,
 ) *testRegistration {
 	s := newTestStream()
 	errC := make(chan *roachpb.Error, 1)
@@ -107,6 +108,7 @@ func newTestRegistration(
 			span,
 			ts,
 			catchup,
+			withDiff,
 			5,
 			NewMetrics(),
 			s,
@@ -139,7 +141,7 @@ func TestRegistrationBasic(t *testing.T) {
 	ev2.MustSetValue(&roachpb.RangeFeedValue{Value: val})
 
 	// Registration with no catchup scan specified.
-	noCatchupReg := newTestRegistration(spAB, hlc.Timestamp{}, nil)
+	noCatchupReg := newTestRegistration(spAB, hlc.Timestamp{}, nil, false /* withDiff */)
 	noCatchupReg.publish(ev1)
 	noCatchupReg.publish(ev2)
 	require.Equal(t, len(noCatchupReg.buf), 2)
@@ -155,7 +157,7 @@ func TestRegistrationBasic(t *testing.T) {
 		makeInline("ba", "val2"),
 		makeKV("bc", "val3", 11),
 		makeKV("bd", "val4", 9),
-	}))
+	}), false /* withDiff */)
 	catchupReg.publish(ev1)
 	catchupReg.publish(ev2)
 	require.Equal(t, len(catchupReg.buf), 2)
@@ -169,7 +171,7 @@ func TestRegistrationBasic(t *testing.T) {
 
 	// EXIT CONDITIONS
 	// External Disconnect.
-	disconnectReg := newTestRegistration(spAB, hlc.Timestamp{}, nil)
+	disconnectReg := newTestRegistration(spAB, hlc.Timestamp{}, nil, false /* withDiff */)
 	disconnectReg.publish(ev1)
 	disconnectReg.publish(ev2)
 	go disconnectReg.runOutputLoop(context.Background())
@@ -180,7 +182,7 @@ func TestRegistrationBasic(t *testing.T) {
 	require.Equal(t, discErr, err)
 
 	// Overflow.
-	overflowReg := newTestRegistration(spAB, hlc.Timestamp{}, nil)
+	overflowReg := newTestRegistration(spAB, hlc.Timestamp{}, nil, false /* withDiff */)
 	for i := 0; i < cap(overflowReg.buf)+3; i++ {
 		overflowReg.publish(ev1)
 	}
@@ -190,7 +192,7 @@ func TestRegistrationBasic(t *testing.T) {
 	require.Equal(t, cap(overflowReg.buf), len(overflowReg.Events()))
 
 	// Stream Error.
-	streamErrReg := newTestRegistration(spAB, hlc.Timestamp{}, nil)
+	streamErrReg := newTestRegistration(spAB, hlc.Timestamp{}, nil, false /* withDiff */)
 	streamErr := fmt.Errorf("stream error")
 	streamErrReg.stream.SetSendErr(streamErr)
 	go streamErrReg.runOutputLoop(context.Background())
@@ -199,7 +201,7 @@ func TestRegistrationBasic(t *testing.T) {
 	require.Equal(t, streamErr.Error(), err.GoError().Error())
 
 	// Stream Context Canceled.
-	streamCancelReg := newTestRegistration(spAB, hlc.Timestamp{}, nil)
+	streamCancelReg := newTestRegistration(spAB, hlc.Timestamp{}, nil, false /* withDiff */)
 	streamCancelReg.stream.Cancel()
 	go streamCancelReg.runOutputLoop(context.Background())
 	require.NoError(t, streamCancelReg.waitForCaughtUp())
@@ -210,6 +212,12 @@ func TestRegistrationBasic(t *testing.T) {
 func TestRegistrationCatchUpScan(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
+	testutils.RunTrueAndFalse(t, "withDiff", func(t *testing.T, withDiff bool) {
+		testRegistrationCatchUpScan(t, withDiff)
+	})
+}
+
+func testRegistrationCatchUpScan(t *testing.T, withDiff bool) {
 	// Run a catch-up scan for a registration over a test
 	// iterator with the following keys.
 	txn1, txn2 := uuid.MakeV4(), uuid.MakeV4()
@@ -224,6 +232,7 @@ func TestRegistrationCatchUpScan(t *testing.T) {
 		makeProvisionalKV("d", "txnKey2", 21),
 		makeKV("d", "val5", 20),
 		makeKV("d", "val6", 19),
+		makeKV("d", "val6.5", 3),
 		makeInline("g", "val7"),
 		makeKV("m", "val8", 1),
 		makeIntent("n", txn1, "txnKey1", 12),
@@ -241,7 +250,7 @@ func TestRegistrationCatchUpScan(t *testing.T) {
 	r := newTestRegistration(roachpb.Span{
 		Key:    roachpb.Key("d"),
 		EndKey: roachpb.Key("w"),
-	}, hlc.Timestamp{WallTime: 4}, iter)
+	}, hlc.Timestamp{WallTime: 4}, iter, withDiff)
 
 	require.Zero(t, r.metrics.RangeFeedCatchupScanNanos.Count())
 	require.NoError(t, r.runCatchupScan())
@@ -250,19 +259,26 @@ func TestRegistrationCatchUpScan(t *testing.T) {
 
 	// Compare the events sent on the registration's Stream to the expected events.
 	expEvents := []*roachpb.RangeFeedEvent{
-		rangeFeedValue(
+		rangeFeedValueWithPrev(
 			roachpb.Key("d"),
 			roachpb.Value{RawBytes: []byte("val6"), Timestamp: hlc.Timestamp{WallTime: 19}},
+			roachpb.Value{RawBytes: []byte("val6.5")},
 		),
-		rangeFeedValue(
+		rangeFeedValueWithPrev(
 			roachpb.Key("d"),
 			roachpb.Value{RawBytes: []byte("val5"), Timestamp: hlc.Timestamp{WallTime: 20}},
+			roachpb.Value{RawBytes: []byte("val6")},
 		),
 		rangeFeedValue(
 			roachpb.Key("g"),
 			roachpb.Value{RawBytes: []byte("val7"), Timestamp: hlc.Timestamp{WallTime: 0}},
 		),
 	}
+	if !withDiff {
+		for _, e := range expEvents {
+			e.Val.PrevValue = roachpb.Value{}
+		}
+	}
 	require.Equal(t, expEvents, r.Events())
 }
 
@@ -284,10 +300,10 @@ func TestRegistryBasic(t *testing.T) {
 	require.NotPanics(t, func() { reg.Disconnect(spAB) })
 	require.NotPanics(t, func() { reg.DisconnectWithErr(spAB, err1) })
 
-	rAB := newTestRegistration(spAB, hlc.Timestamp{}, nil)
-	rBC := newTestRegistration(spBC, hlc.Timestamp{}, nil)
-	rCD := newTestRegistration(spCD, hlc.Timestamp{}, nil)
-	rAC := newTestRegistration(spAC, hlc.Timestamp{}, nil)
+	rAB := newTestRegistration(spAB, hlc.Timestamp{}, nil, false /* withDiff */)
+	rBC := newTestRegistration(spBC, hlc.Timestamp{}, nil, false /* withDiff */)
+	rCD := newTestRegistration(spCD, hlc.Timestamp{}, nil, false /* withDiff */)
+	rAC := newTestRegistration(spAC, hlc.Timestamp{}, nil, false /* withDiff */)
 	go rAB.runOutputLoop(context.Background())
 	go rBC.runOutputLoop(context.Background())
 	go rCD.runOutputLoop(context.Background())
@@ -350,7 +366,7 @@ func TestRegistryPublishBeneathStartTimestamp(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	reg := makeRegistry()
 
-	r := newTestRegistration(spAB, hlc.Timestamp{WallTime: 10}, nil)
+	r := newTestRegistration(spAB, hlc.Timestamp{WallTime: 10}, nil, false /* withDiff */)
 	go r.runOutputLoop(context.Background())
 	reg.Register(&r.registration)
 
@@ -219,7 +219,7 @@ func (r *Replica) RangeFeed(
 		iterSemRelease = nil
 	}
 	p := r.registerWithRangefeedRaftMuLocked(
-		ctx, rspan, args.Timestamp, catchUpIter, lockedStream, errC,
+		ctx, rspan, args.Timestamp, catchUpIter, args.WithDiff, lockedStream, errC,
 	)
 	r.raftMu.Unlock()
 
@@ -277,6 +277,7 @@ func (r *Replica) registerWithRangefeedRaftMuLocked(
 	span roachpb.RSpan,
 	startTS hlc.Timestamp,
 	catchupIter engine.SimpleIterator,
+	withDiff bool,
 	stream rangefeed.Stream,
 	errC chan<- *roachpb.Error,
 ) *rangefeed.Processor {
@@ -286,7 +287,7 @@ func (r *Replica) registerWithRangefeedRaftMuLocked(
 	r.rangefeedMu.RLock()
 	p := r.rangefeedMu.proc
 	if p != nil {
-		reg := p.Register(span, startTS, catchupIter, stream, errC)
+		reg := p.Register(span, startTS, catchupIter, withDiff, stream, errC)
 		r.rangefeedMu.RUnlock()
 		if reg {
 			// Registered successfully with an existing processor.
@@ -333,7 +334,7 @@ func (r *Replica) registerWithRangefeedRaftMuLocked(
 	// any other goroutines are able to stop the processor. In other words,
 	// this ensures that the only time the registration fails is during
 	// server shutdown.
-	reg := p.Register(span, startTS, catchupIter, stream, errC)
+	reg := p.Register(span, startTS, catchupIter, withDiff, stream, errC)
 	if !reg {
 		catchupIter.Close() // clean up
 		select {
@@ -432,16 +433,18 @@ func (r *Replica) handleLogicalOpLogRaftMuLocked(ctx context.Context, ops *stora
 
 	// When reading straight from the Raft log, some logical ops will not be
 	// fully populated. Read from the engine (under raftMu) to populate all
-	// fields.
+	// fields. The previous values of keys are only read if a registration
+	// requested them.
+	needPrevVal := p.NeedPrevVal()
 	for _, op := range ops.Ops {
 		var key []byte
 		var ts hlc.Timestamp
-		var valPtr *[]byte
+		var valPtr, prevValPtr *[]byte
 		switch t := op.GetValue().(type) {
 		case *enginepb.MVCCWriteValueOp:
-			key, ts, valPtr = t.Key, t.Timestamp, &t.Value
+			key, ts, valPtr, prevValPtr = t.Key, t.Timestamp, &t.Value, &t.PrevValue
 		case *enginepb.MVCCCommitIntentOp:
-			key, ts, valPtr = t.Key, t.Timestamp, &t.Value
+			key, ts, valPtr, prevValPtr = t.Key, t.Timestamp, &t.Value, &t.PrevValue
 		case *enginepb.MVCCWriteIntentOp,
 			*enginepb.MVCCUpdateIntentOp,
 			*enginepb.MVCCAbortIntentOp,
@@ -466,6 +469,25 @@ func (r *Replica) handleLogicalOpLogRaftMuLocked(ctx context.Context, ops *stora
 			return
 		}
 		*valPtr = val.RawBytes
+
+		if !needPrevVal {
+			continue
+		}
+		// The previous value is the latest version of the key below the
+		// timestamp of the write. It is left nil if the key did not exist or
+		// was deleted.
+		prevVal, _, err := engine.MVCCGet(
+			ctx, r.Engine(), key, ts.Prev(), engine.MVCCGetOptions{Inconsistent: true},
+		)
+		if err != nil {
+			r.disconnectRangefeedWithErr(p, roachpb.NewErrorf(
+				"error consuming %T for key %v @ ts %v: %v", op, key, ts, err,
+			))
+			return
+		}
+		if prevVal != nil {
+			*prevValPtr = prevVal.RawBytes
+		}
 	}
 
 	// Pass the ops to the rangefeed processor.
@@ -3025,6 +3025,12 @@ func (s *Store) Send(
 			pErr = nil
 
 		case *roachpb.WriteIntentError:
+			// Requests that don't want to wait on conflicting transactions (for
+			// example, SELECT ... FOR UPDATE NOWAIT) return the error to the
+			// client right away instead of pushing.
+			if ba.WaitPolicy == roachpb.WaitPolicy_ERROR {
+				return nil, pErr
+			}
 			// Process and resolve write intent error. We do this here because
 			// this is the code path with the requesting client waiting.
 			if pErr.Index != nil {
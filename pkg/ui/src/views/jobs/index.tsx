@@ -63,6 +63,7 @@ const typeOptions = [
   { value: JobType.CHANGEFEED.toString(), label: "Changefeed"},
   { value: JobType.CREATE_STATS.toString(), label: "Statistics Creation"},
   { value: JobType.AUTO_CREATE_STATS.toString(), label: "Auto-Statistics Creation"},
+  { value: JobType.MATERIALIZED_VIEW_REFRESH.toString(), label: "Materialized View Refreshes"},
 ];
 
 const typeSetting = new LocalSetting<AdminUIState, number>(
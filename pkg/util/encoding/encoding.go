@@ -30,6 +30,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/pkg/errors"
@@ -79,6 +81,8 @@ const (
 	bitArrayDataTerminator     = 0x00
 	bitArrayDataDescTerminator = 0xff
 
+	timeTZMarker = bitArrayDescMarker + 1
+
 	// IntMin is chosen such that the range of int tags does not overlap the
 	// ascii character set that is frequently used in testing.
 	IntMin      = 0x80 // 128
@@ -937,6 +941,73 @@ func DecodeTimeDescending(b []byte) ([]byte, time.Time, error) {
 	return b, timeutil.Unix(^sec, ^nsec), nil
 }
 
+// EncodeTimeTZAscending encodes a timetz.TimeTZ value, appends it to the
+// supplied buffer, and returns the final buffer. Values are ordered by their
+// time in UTC first and their time zone offset second, which matches
+// TimeTZ.Compare.
+func EncodeTimeTZAscending(b []byte, t timetz.TimeTZ) []byte {
+	utcMicros, offsetSecs := timeTZUTCMicros(t), int64(t.OffsetSecs)
+	return encodeTimeTZ(b, utcMicros, offsetSecs)
+}
+
+// EncodeTimeTZDescending is the descending version of EncodeTimeTZAscending.
+func EncodeTimeTZDescending(b []byte, t timetz.TimeTZ) []byte {
+	utcMicros, offsetSecs := timeTZUTCMicros(t), int64(t.OffsetSecs)
+	return encodeTimeTZ(b, ^utcMicros, ^offsetSecs)
+}
+
+func timeTZUTCMicros(t timetz.TimeTZ) int64 {
+	return int64(t.TimeOfDay) + int64(t.OffsetSecs)*int64(time.Second/time.Microsecond)
+}
+
+func encodeTimeTZ(b []byte, utcMicros, offsetSecs int64) []byte {
+	b = append(b, timeTZMarker)
+	b = EncodeVarintAscending(b, utcMicros)
+	b = EncodeVarintAscending(b, offsetSecs)
+	return b
+}
+
+// DecodeTimeTZAscending decodes a timetz.TimeTZ value which was encoded using
+// EncodeTimeTZAscending. The remainder of the input buffer and the decoded
+// timetz.TimeTZ are returned.
+func DecodeTimeTZAscending(b []byte) ([]byte, timetz.TimeTZ, error) {
+	b, utcMicros, offsetSecs, err := decodeTimeTZ(b)
+	if err != nil {
+		return b, timetz.TimeTZ{}, err
+	}
+	return b, makeTimeTZFromUTCMicros(utcMicros, offsetSecs), nil
+}
+
+// DecodeTimeTZDescending is the descending version of DecodeTimeTZAscending.
+func DecodeTimeTZDescending(b []byte) ([]byte, timetz.TimeTZ, error) {
+	b, utcMicros, offsetSecs, err := decodeTimeTZ(b)
+	if err != nil {
+		return b, timetz.TimeTZ{}, err
+	}
+	return b, makeTimeTZFromUTCMicros(^utcMicros, ^offsetSecs), nil
+}
+
+func makeTimeTZFromUTCMicros(utcMicros, offsetSecs int64) timetz.TimeTZ {
+	tod := utcMicros - offsetSecs*int64(time.Second/time.Microsecond)
+	return timetz.MakeTimeTZ(timeofday.TimeOfDay(tod), int32(offsetSecs))
+}
+
+func decodeTimeTZ(b []byte) (r []byte, utcMicros int64, offsetSecs int64, err error) {
+	if PeekType(b) != TimeTZ {
+		return nil, 0, 0, errors.Errorf("did not find marker")
+	}
+	b = b[1:]
+	b, utcMicros, err = DecodeVarintAscending(b)
+	if err != nil {
+		return b, 0, 0, err
+	}
+	b, offsetSecs, err = DecodeVarintAscending(b)
+	if err != nil {
+		return b, 0, 0, err
+	}
+	return b, utcMicros, offsetSecs, nil
+}
+
 func decodeTime(b []byte) (r []byte, sec int64, nsec int64, err error) {
 	if PeekType(b) != Time {
 		return nil, 0, 0, errors.Errorf("did not find marker")
@@ -1207,6 +1278,7 @@ const (
 	Tuple        Type = 16
 	BitArray     Type = 17
 	BitArrayDesc Type = 18 // BitArray encoded descendingly
+	TimeTZ       Type = 19
 )
 
 // typMap maps an encoded type byte to a decoded Type. It's got 256 slots, one
@@ -1249,6 +1321,8 @@ func slowPeekType(b []byte) Type {
 			return BitArrayDesc
 		case m == timeMarker:
 			return Time
+		case m == timeTZMarker:
+			return TimeTZ
 		case m == byte(Array):
 			return Array
 		case m == byte(True):
@@ -1330,7 +1404,7 @@ func PeekLength(b []byte) (int, error) {
 		return getJSONInvertedIndexKeyLength(b)
 	case bytesDescMarker:
 		return getBytesLength(b, descendingEscapes)
-	case timeMarker:
+	case timeMarker, timeTZMarker:
 		return GetMultiVarintLen(b, 2)
 	case durationBigNegMarker, durationMarker, durationBigPosMarker:
 		return GetMultiVarintLen(b, 3)
@@ -1536,6 +1610,17 @@ func prettyPrintFirstValue(dir Direction, b []byte) ([]byte, string, error) {
 			return b, "", err
 		}
 		return b, t.UTC().Format(time.RFC3339Nano), nil
+	case TimeTZ:
+		var t timetz.TimeTZ
+		if dir == Descending {
+			b, t, err = DecodeTimeTZDescending(b)
+		} else {
+			b, t, err = DecodeTimeTZAscending(b)
+		}
+		if err != nil {
+			return b, "", err
+		}
+		return b, t.String(), nil
 	case Duration:
 		var d duration.Duration
 		if dir == Descending {
@@ -1826,6 +1911,20 @@ func EncodeUntaggedTimeValue(appendTo []byte, t time.Time) []byte {
 	return EncodeNonsortingStdlibVarint(appendTo, int64(t.Nanosecond()))
 }
 
+// EncodeTimeTZValue encodes a timetz.TimeTZ value with its value tag, appends
+// it to the supplied buffer, and returns the final buffer.
+func EncodeTimeTZValue(appendTo []byte, colID uint32, t timetz.TimeTZ) []byte {
+	appendTo = EncodeValueTag(appendTo, colID, TimeTZ)
+	return EncodeUntaggedTimeTZValue(appendTo, t)
+}
+
+// EncodeUntaggedTimeTZValue encodes a timetz.TimeTZ value, appends it to the
+// supplied buffer, and returns the final buffer.
+func EncodeUntaggedTimeTZValue(appendTo []byte, t timetz.TimeTZ) []byte {
+	appendTo = EncodeNonsortingStdlibVarint(appendTo, int64(t.TimeOfDay))
+	return EncodeNonsortingStdlibVarint(appendTo, int64(t.OffsetSecs))
+}
+
 // EncodeDecimalValue encodes an apd.Decimal value with its value tag, appends
 // it to the supplied buffer, and returns the final buffer.
 func EncodeDecimalValue(appendTo []byte, colID uint32, d *apd.Decimal) []byte {
@@ -2059,6 +2158,30 @@ func DecodeUntaggedTimeValue(b []byte) (remaining []byte, t time.Time, err error
 	return b, timeutil.Unix(sec, nsec), nil
 }
 
+// DecodeTimeTZValue decodes a value encoded by EncodeTimeTZValue.
+func DecodeTimeTZValue(b []byte) (remaining []byte, t timetz.TimeTZ, err error) {
+	b, err = decodeValueTypeAssert(b, TimeTZ)
+	if err != nil {
+		return b, timetz.TimeTZ{}, err
+	}
+	return DecodeUntaggedTimeTZValue(b)
+}
+
+// DecodeUntaggedTimeTZValue decodes a value encoded by
+// EncodeUntaggedTimeTZValue.
+func DecodeUntaggedTimeTZValue(b []byte) (remaining []byte, t timetz.TimeTZ, err error) {
+	var tod, offsetSecs int64
+	b, _, tod, err = DecodeNonsortingStdlibVarint(b)
+	if err != nil {
+		return b, timetz.TimeTZ{}, err
+	}
+	b, _, offsetSecs, err = DecodeNonsortingStdlibVarint(b)
+	if err != nil {
+		return b, timetz.TimeTZ{}, err
+	}
+	return b, timetz.MakeTimeTZ(timeofday.TimeOfDay(tod), int32(offsetSecs)), nil
+}
+
 // DecodeDecimalValue decodes a value encoded by EncodeDecimalValue.
 func DecodeDecimalValue(b []byte) (remaining []byte, d apd.Decimal, err error) {
 	b, err = decodeValueTypeAssert(b, Decimal)
@@ -2264,7 +2387,7 @@ func PeekValueLengthWithOffsetsAndType(b []byte, dataOffset int, typ Type) (leng
 	case Decimal:
 		_, n, i, err := DecodeNonsortingStdlibUvarint(b)
 		return dataOffset + n + int(i), err
-	case Time:
+	case Time, TimeTZ:
 		n, err := getMultiNonsortingVarintLen(b, 2)
 		return dataOffset + n, err
 	case Duration:
@@ -2355,6 +2478,13 @@ func PrettyPrintValueEncoded(b []byte) ([]byte, string, error) {
 			return b, "", err
 		}
 		return b, t.UTC().Format(time.RFC3339Nano), nil
+	case TimeTZ:
+		var t timetz.TimeTZ
+		b, t, err = DecodeTimeTZValue(b)
+		if err != nil {
+			return b, "", err
+		}
+		return b, t.String(), nil
 	case Duration:
 		var d duration.Duration
 		b, d, err = DecodeDurationValue(b)
@@ -26,6 +26,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/pkg/errors"
@@ -1059,6 +1061,50 @@ func TestEncodeDecodeTime(t *testing.T) {
 	}
 }
 
+func TestEncodeDecodeTimeTZ(t *testing.T) {
+	// Test cases are in increasing order. Values at the same time in UTC are
+	// ordered by their time zone offset.
+	testCases := []timetz.TimeTZ{
+		timetz.Min,
+		timetz.MakeTimeTZ(timeofday.New(3, 0, 0, 0), -60*60),
+		timetz.MakeTimeTZ(timeofday.New(2, 0, 0, 0), 0),
+		timetz.MakeTimeTZ(timeofday.New(9, 0, 0, 0), -5*60*60),
+		timetz.MakeTimeTZ(timeofday.New(4, 0, 0, 0), 0),
+		timetz.MakeTimeTZ(timeofday.New(4, 0, 0, 1), 0),
+		timetz.MakeTimeTZ(timeofday.New(23, 59, 59, 999999), 8*60*60),
+		timetz.Max,
+	}
+	for _, dir := range []Direction{Ascending, Descending} {
+		var lastEncoded []byte
+		for i, tc := range testCases {
+			var b []byte
+			var decoded timetz.TimeTZ
+			var err error
+			if dir == Ascending {
+				b = EncodeTimeTZAscending(b, tc)
+				_, decoded, err = DecodeTimeTZAscending(b)
+			} else {
+				b = EncodeTimeTZDescending(b, tc)
+				_, decoded, err = DecodeTimeTZDescending(b)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded != tc {
+				t.Fatalf("lossy transport: before (%v) vs after (%v)", tc, decoded)
+			}
+			testPeekLength(t, b)
+			if i > 0 {
+				if (bytes.Compare(lastEncoded, b) >= 0 && dir == Ascending) ||
+					(bytes.Compare(lastEncoded, b) <= 0 && dir == Descending) {
+					t.Fatalf("encodings %s, %s not increasing", testCases[i-1], tc)
+				}
+			}
+			lastEncoded = b
+		}
+	}
+}
+
 type testCaseDuration struct {
 	value  duration.Duration
 	expEnc []byte
@@ -1678,6 +1724,24 @@ func TestValueEncodeDecodeTime(t *testing.T) {
 	}
 }
 
+func TestValueEncodeDecodeTimeTZ(t *testing.T) {
+	rng, seed := randutil.NewPseudoRand()
+	tests := make([]timetz.TimeTZ, 1000)
+	for i := range tests {
+		tests[i] = timetz.Random(rng)
+	}
+	for _, test := range tests {
+		buf := EncodeTimeTZValue(nil, NoColumnID, test)
+		_, x, err := DecodeTimeTZValue(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x != test {
+			t.Errorf("seed %d: expected %v got %v", seed, test, x)
+		}
+	}
+}
+
 func TestValueEncodeDecodeBitArray(t *testing.T) {
 	rng, seed := randutil.NewPseudoRand()
 	rd := randData{rng}
@@ -0,0 +1,172 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package timetz
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+)
+
+const (
+	// MaxOffsetSecs is the maximum allowed offset of a time zone, which is the
+	// same as in Postgres.
+	MaxOffsetSecs = 15*60*60 + 59*60
+	// MinOffsetSecs is the minimum allowed offset of a time zone.
+	MinOffsetSecs = -MaxOffsetSecs
+
+	microsecondsPerSecond = 1e6
+)
+
+var (
+	// Min is the minimum TimeTZ value.
+	Min = MakeTimeTZ(timeofday.Min, MinOffsetSecs)
+	// Max is the maximum TimeTZ value.
+	Max = MakeTimeTZ(timeofday.Time2400, MaxOffsetSecs)
+
+	// time2400Regex matches the 24:00 time value, optionally followed by a
+	// time zone.
+	time2400Regex = regexp.MustCompile(`^24:00(:00(\.0+)?)?([-+].*)?$`)
+)
+
+// TimeTZ represents a time of day (no date) with a time zone, like Postgres'
+// TIME WITH TIME ZONE.
+type TimeTZ struct {
+	// TimeOfDay is the time since midnight in the time zone given by
+	// OffsetSecs.
+	timeofday.TimeOfDay
+	// OffsetSecs is the offset of the time zone in seconds, with the sign
+	// reversed: -08:00 is stored as 8*60*60. This is the same as in Postgres.
+	OffsetSecs int32
+}
+
+// MakeTimeTZ creates a TimeTZ from a TimeOfDay and the offset of its time
+// zone, with the sign reversed.
+func MakeTimeTZ(t timeofday.TimeOfDay, offsetSecs int32) TimeTZ {
+	return TimeTZ{TimeOfDay: t, OffsetSecs: offsetSecs}
+}
+
+// MakeTimeTZFromTime creates a TimeTZ from the clock time and the time zone
+// offset of a time.Time, ignoring the date.
+func MakeTimeTZFromTime(t time.Time) TimeTZ {
+	_, offset := t.Zone()
+	return MakeTimeTZ(timeofday.FromTime(t), -int32(offset))
+}
+
+// Now returns the current time in the time zone of the given location.
+func Now(now time.Time, loc *time.Location) TimeTZ {
+	return MakeTimeTZFromTime(now.In(loc))
+}
+
+// ParseTimeTZ parses a TimeTZ from a string. If the string does not specify
+// a time zone, the time zone of now is used.
+func ParseTimeTZ(now time.Time, s string) (TimeTZ, error) {
+	// Like for TIME, 24:00 is a valid value of its own, which the parser does
+	// not handle.
+	is2400 := time2400Regex.MatchString(s)
+	if is2400 {
+		s = "00" + s[2:]
+	}
+	t, err := pgdate.ParseTime(now, 0 /* mode */, s)
+	if err != nil {
+		return TimeTZ{}, err
+	}
+	ret := MakeTimeTZFromTime(t)
+	if is2400 {
+		ret.TimeOfDay = timeofday.Time2400
+	}
+	if ret.OffsetSecs < MinOffsetSecs || ret.OffsetSecs > MaxOffsetSecs {
+		return TimeTZ{}, pgerror.Newf(pgerror.CodeInvalidTimeZoneDisplacementValueError,
+			"time zone displacement out of range: %q", s)
+	}
+	return ret, nil
+}
+
+// Random generates a random TimeTZ.
+func Random(rng *rand.Rand) TimeTZ {
+	offsetSecs := rng.Int31n(MaxOffsetSecs*2+1) + MinOffsetSecs
+	return MakeTimeTZ(timeofday.Random(rng), offsetSecs)
+}
+
+// ToTime converts a TimeTZ to a time.Time in its time zone, using the Unix
+// epoch as the date.
+func (t TimeTZ) ToTime() time.Time {
+	loc := time.FixedZone("", -int(t.OffsetSecs))
+	return time.Date(1970, 1, 1, 0, 0, 0, 0, loc).Add(
+		time.Duration(t.TimeOfDay) * time.Microsecond)
+}
+
+// utcMicros returns the time of day of t in UTC, in microseconds. It can be
+// negative or exceed a day, since the time zone offset is not wrapped.
+func (t TimeTZ) utcMicros() int64 {
+	return int64(t.TimeOfDay) + int64(t.OffsetSecs)*microsecondsPerSecond
+}
+
+// Compare returns -1, 0 or 1 depending on whether t is before, equal to or
+// after other. Like in Postgres, values are ordered by their time in UTC
+// first, and by their time zone offset second, so values for the same
+// instant in different time zones are not equal.
+func (t TimeTZ) Compare(other TimeTZ) int {
+	if tu, ou := t.utcMicros(), other.utcMicros(); tu != ou {
+		if tu < ou {
+			return -1
+		}
+		return 1
+	}
+	if t.OffsetSecs < other.OffsetSecs {
+		return -1
+	} else if t.OffsetSecs > other.OffsetSecs {
+		return 1
+	}
+	return 0
+}
+
+// Before returns whether t is ordered before other.
+func (t TimeTZ) Before(other TimeTZ) bool {
+	return t.Compare(other) < 0
+}
+
+// After returns whether t is ordered after other.
+func (t TimeTZ) After(other TimeTZ) bool {
+	return t.Compare(other) > 0
+}
+
+// Equal returns whether t and other represent the same time in the same time
+// zone.
+func (t TimeTZ) Equal(other TimeTZ) bool {
+	return t == other
+}
+
+// String formats t like Postgres, e.g. 12:34:56.789-08 or 12:34:56+05:30.
+func (t TimeTZ) String() string {
+	sign := byte('-')
+	offset := t.OffsetSecs
+	if offset <= 0 {
+		sign = '+'
+		offset = -offset
+	}
+	hours, minutes, seconds := offset/3600, (offset/60)%60, offset%60
+	zoneStr := fmt.Sprintf("%c%02d", sign, hours)
+	if minutes != 0 || seconds != 0 {
+		zoneStr += fmt.Sprintf(":%02d", minutes)
+	}
+	if seconds != 0 {
+		zoneStr += fmt.Sprintf(":%02d", seconds)
+	}
+	return t.TimeOfDay.String() + zoneStr
+}
@@ -0,0 +1,102 @@
+// Copyright 2019 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License included
+// in the file licenses/BSL.txt and at www.mariadb.com/bsl11.
+//
+// Change Date: 2022-10-01
+//
+// On the date above, in accordance with the Business Source License, use
+// of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt and at
+// https://www.apache.org/licenses/LICENSE-2.0
+
+package timetz
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+)
+
+func TestParseTimeTZ(t *testing.T) {
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.FixedZone("", 2*60*60))
+	testData := []struct {
+		s      string
+		exp    TimeTZ
+		expStr string
+		expErr string
+	}{
+		{s: "12:34:56-08", exp: MakeTimeTZ(timeofday.New(12, 34, 56, 0), 8*60*60), expStr: "12:34:56-08"},
+		{s: "12:34:56.789+05:30", exp: MakeTimeTZ(timeofday.New(12, 34, 56, 789000), -(5*60*60 + 30*60)), expStr: "12:34:56.789+05:30"},
+		{s: "01:02:03", exp: MakeTimeTZ(timeofday.New(1, 2, 3, 0), -2*60*60), expStr: "01:02:03+02"},
+		{s: "00:00:00Z", exp: MakeTimeTZ(timeofday.Min, 0), expStr: "00:00:00+00"},
+		{s: "24:00:00-01", exp: MakeTimeTZ(timeofday.Time2400, 60*60), expStr: "24:00:00-01"},
+		{s: "24:00", exp: MakeTimeTZ(timeofday.Time2400, -2*60*60), expStr: "24:00:00+02"},
+		{s: "12:00:00+16", expErr: "time zone displacement out of range"},
+		{s: "not a time", expErr: "as type time"},
+	}
+	for _, td := range testData {
+		t.Run(td.s, func(t *testing.T) {
+			actual, err := ParseTimeTZ(now, td.s)
+			if td.expErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q, got %s", td.expErr, actual)
+				}
+				if !testutils.IsError(err, td.expErr) {
+					t.Fatalf("expected error %q, got %v", td.expErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual != td.exp {
+				t.Errorf("expected %#v, got %#v", td.exp, actual)
+			}
+			if s := actual.String(); s != td.expStr {
+				t.Errorf("expected %s, got %s", td.expStr, s)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	ninePM := MakeTimeTZ(timeofday.New(21, 0, 0, 0), 0)
+	testData := []struct {
+		a, b TimeTZ
+		exp  int
+	}{
+		{ninePM, ninePM, 0},
+		// The same instant in different time zones is ordered by the offset.
+		{MakeTimeTZ(timeofday.New(22, 0, 0, 0), -60*60), ninePM, -1},
+		{ninePM, MakeTimeTZ(timeofday.New(20, 0, 0, 0), 60*60), -1},
+		// The time of day is compared in UTC.
+		{MakeTimeTZ(timeofday.New(23, 0, 0, 0), -3*60*60), ninePM, -1},
+		{MakeTimeTZ(timeofday.New(1, 0, 0, 0), 5*60*60), ninePM, 1},
+		{Min, ninePM, -1},
+		{Max, ninePM, 1},
+	}
+	for _, td := range testData {
+		t.Run(td.a.String()+"_"+td.b.String(), func(t *testing.T) {
+			if actual := td.a.Compare(td.b); actual != td.exp {
+				t.Errorf("expected %d, got %d", td.exp, actual)
+			}
+			if actual := td.b.Compare(td.a); actual != -td.exp {
+				t.Errorf("expected %d in reverse, got %d", -td.exp, actual)
+			}
+		})
+	}
+}
+
+func TestToTime(t *testing.T) {
+	tz := MakeTimeTZ(timeofday.New(12, 30, 0, 0), 8*60*60)
+	exp := time.Date(1970, 1, 1, 20, 30, 0, 0, time.UTC)
+	if actual := tz.ToTime(); !actual.Equal(exp) {
+		t.Errorf("expected %s, got %s", exp, actual)
+	}
+	if actual := MakeTimeTZFromTime(tz.ToTime()); actual != tz {
+		t.Errorf("expected %s, got %s", tz, actual)
+	}
+}
@@ -311,7 +311,7 @@ func DatumToGoSQL(d tree.Datum) (interface{}, error) {
 		return string(*d), nil
 	case *tree.DBytes:
 		return string(*d), nil
-	case *tree.DDate, *tree.DTime:
+	case *tree.DDate, *tree.DTime, *tree.DTimeTZ:
 		return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
 	case *tree.DTimestamp:
 		return d.Time, nil